@@ -2,14 +2,20 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/pixb/go-server/internal/profile"
 	"github.com/pixb/go-server/internal/version"
+	storepb "github.com/pixb/go-server/proto/gen/store"
 	"github.com/pixb/go-server/server"
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/server/ldap"
 	"github.com/pixb/go-server/store"
 	"github.com/pixb/go-server/store/db/mysql"
 	"github.com/pixb/go-server/store/db/postgresql"
@@ -30,21 +36,240 @@ var rootCmd = &cobra.Command{
 	Long:  "go-server demo",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		fmt.Println("===rootCmd Run...===")
-		prof := &profile.Profile{
-			Demo:   viper.GetBool("demo"),
-			Addr:   viper.GetString("addr"),
-			Port:   viper.GetInt("port"),
-			Data:   viper.GetString("data"),
-			Driver: viper.GetString("driver"),
-			DSN:    viper.GetString("dsn"),
-			Secret: viper.GetString("secret"),
-		}
-		prof.Version = version.GetCurrentVersion()
-
-		return run(cmd.Context(), prof)
+		return run(cmd.Context(), newProfile())
 	},
 }
 
+var keysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Manage the access token signing keys.",
+}
+
+var keysRotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Generate a new signing key, the previous key stays valid for a grace period.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		algorithm, err := cmd.Flags().GetString("algorithm")
+		if err != nil {
+			return err
+		}
+		prof := newProfile()
+		if err := prof.Validate(); err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		storeInstance, err := newStore(ctx, prof)
+		if err != nil {
+			return err
+		}
+		defer storeInstance.Close()
+
+		// The private keys are encrypted with the secret the servers run with.
+		secret := prof.Secret
+		if secret == "" {
+			instanceBasicSetting, err := storeInstance.GetInstanceBasicSetting(ctx)
+			if err != nil {
+				return err
+			}
+			secret = instanceBasicSetting.SecretKey
+		}
+		key, err := auth.NewKeyManager(storeInstance, secret).Rotate(ctx, algorithm)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Rotated signing key, new kid: %s (%s)\n", key.Kid, key.Algorithm)
+		return nil
+	},
+}
+
+var secretCmd = &cobra.Command{
+	Use:   "secret",
+	Short: "Manage the instance secret key.",
+}
+
+var secretRotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Replace the instance secret key with a new random one.",
+	Long: `Replace the instance secret key stored in the database with a new random one.
+Access tokens are signed with the signing keys, so rotating the secret does not log users out.
+Restart the servers to start using the new secret. Meanwhile, servers decrypt two-factor
+secrets and signing keys with both the new and the previous key. Once every server has been restarted, run
+"secret finish-rotation" to re-encrypt them and forget the previous key.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		prof := newProfile()
+		if err := prof.Validate(); err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		storeInstance, err := newStore(ctx, prof)
+		if err != nil {
+			return err
+		}
+		defer storeInstance.Close()
+
+		if _, err := storeInstance.RotateInstanceSecretKey(ctx); err != nil {
+			return err
+		}
+		fmt.Println("Rotated instance secret key, restart the servers to apply it and then run \"secret finish-rotation\".")
+		if prof.Secret != "" {
+			fmt.Println("Warning: --secret is set and overrides the stored secret key.")
+		}
+		return nil
+	},
+}
+
+var secretFinishRotationCmd = &cobra.Command{
+	Use:   "finish-rotation",
+	Short: "Re-encrypt two-factor secrets and signing keys with the current instance secret key and forget the previous one.",
+	Long: `Re-encrypt the stored two-factor secrets and signing keys that are encrypted with the previous
+instance secret key, and forget the previous key. Run it once every server has been restarted after
+"secret rotate", servers still running with the previous key encrypt new ones with it.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		prof := newProfile()
+		if err := prof.Validate(); err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		storeInstance, err := newStore(ctx, prof)
+		if err != nil {
+			return err
+		}
+		defer storeInstance.Close()
+
+		if err := auth.ReencryptSigningKeys(ctx, storeInstance); err != nil {
+			return err
+		}
+		if err := auth.ReencryptTOTPCredentials(ctx, storeInstance); err != nil {
+			return err
+		}
+		fmt.Println("Finished the rotation of the instance secret key.")
+		return nil
+	},
+}
+
+var maintenanceCmd = &cobra.Command{
+	Use:   "maintenance",
+	Short: "Take the instance out of service for writes or fully.",
+	Long: `Take the instance out of service for writes or fully. In read-only mode methods that
+change data are rejected with UNAVAILABLE, in full mode only health checks and the instance
+profile are served. Running servers apply changes within the settings poll interval.`,
+}
+
+var maintenanceOnCmd = &cobra.Command{
+	Use:   "on",
+	Short: "Put the instance in maintenance.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mode, err := cmd.Flags().GetString("mode")
+		if err != nil {
+			return err
+		}
+		message, err := cmd.Flags().GetString("message")
+		if err != nil {
+			return err
+		}
+		retryAfter, err := cmd.Flags().GetDuration("retry-after")
+		if err != nil {
+			return err
+		}
+		setting := &storepb.InstanceMaintenanceSetting{
+			Message:           message,
+			RetryAfterSeconds: int32(retryAfter.Seconds()),
+		}
+		switch mode {
+		case "read-only":
+			setting.Mode = storepb.InstanceMaintenanceSetting_READ_ONLY
+		case "full":
+			setting.Mode = storepb.InstanceMaintenanceSetting_FULL
+		default:
+			return fmt.Errorf("unsupported maintenance mode %q, expected read-only or full", mode)
+		}
+		if retryAfter < time.Second || retryAfter > 24*time.Hour {
+			return errors.New("retry-after must be between 1 second and 24 hours")
+		}
+		if err := setMaintenance(cmd.Context(), setting); err != nil {
+			return err
+		}
+		fmt.Printf("Instance is in %s maintenance.\n", mode)
+		return nil
+	},
+}
+
+var maintenanceOffCmd = &cobra.Command{
+	Use:   "off",
+	Short: "Put the instance back in service.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := setMaintenance(cmd.Context(), &storepb.InstanceMaintenanceSetting{}); err != nil {
+			return err
+		}
+		fmt.Println("Instance is in service.")
+		return nil
+	},
+}
+
+func setMaintenance(ctx context.Context, setting *storepb.InstanceMaintenanceSetting) error {
+	prof := newProfile()
+	if err := prof.Validate(); err != nil {
+		return err
+	}
+	storeInstance, err := newStore(ctx, prof)
+	if err != nil {
+		return err
+	}
+	defer storeInstance.Close()
+
+	_, err = storeInstance.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+		Key:   storepb.InstanceSettingKey_MAINTENANCE,
+		Value: &storepb.InstanceSetting_MaintenanceSetting{MaintenanceSetting: setting},
+	})
+	return err
+}
+
+func newProfile() *profile.Profile {
+	prof := &profile.Profile{
+		Demo:   viper.GetBool("demo"),
+		Addr:   viper.GetString("addr"),
+		Port:   viper.GetInt("port"),
+		Data:   viper.GetString("data"),
+		Driver: viper.GetString("driver"),
+		DSN:    viper.GetString("dsn"),
+		Secret: viper.GetString("secret"),
+
+		InstanceURL: viper.GetString("instance_url"),
+
+		SMTPHost:     viper.GetString("smtp_host"),
+		SMTPPort:     viper.GetInt("smtp_port"),
+		SMTPUsername: viper.GetString("smtp_username"),
+		SMTPPassword: viper.GetString("smtp_password"),
+		SMTPFrom:     viper.GetString("smtp_from"),
+		LogEmails:    viper.GetBool("log_emails"),
+
+		LDAPURL:          viper.GetString("ldap_url"),
+		LDAPStartTLS:     viper.GetBool("ldap_start_tls"),
+		LDAPBindDN:       viper.GetString("ldap_bind_dn"),
+		LDAPBindPassword: viper.GetString("ldap_bind_password"),
+		LDAPBaseDN:       viper.GetString("ldap_base_dn"),
+		LDAPUserFilter:   viper.GetString("ldap_user_filter"),
+		LDAPUsernameAttr: viper.GetString("ldap_username_attribute"),
+		LDAPNicknameAttr: viper.GetString("ldap_nickname_attribute"),
+		LDAPEmailAttr:    viper.GetString("ldap_email_attribute"),
+		LDAPPhoneAttr:    viper.GetString("ldap_phone_attribute"),
+		LDAPGroupBaseDN:  viper.GetString("ldap_group_base_dn"),
+		LDAPGroupFilter:  viper.GetString("ldap_group_filter"),
+		LDAPGroupRoles:   viper.GetStringSlice("ldap_group_role"),
+
+		TLSCert:             viper.GetString("tls_cert"),
+		TLSKey:              viper.GetString("tls_key"),
+		TLSClientCA:         viper.GetString("tls_client_ca"),
+		TLSClientPrincipals: viper.GetStringSlice("tls_client_principal"),
+
+		TrustedProxies: viper.GetStringSlice("trusted_proxy"),
+
+		SettingsPollInterval: viper.GetDuration("settings_poll_interval"),
+	}
+	prof.Version = version.GetCurrentVersion()
+	return prof
+}
+
 // init() 方法
 func init() {
 	viper.SetDefault("demo", false)
@@ -57,7 +282,33 @@ func init() {
 	rootCmd.PersistentFlags().String("data", "./data", "data directory")
 	rootCmd.PersistentFlags().String("driver", "sqlite", "data driver")
 	rootCmd.PersistentFlags().String("dsn", "", "database connection string")
-	rootCmd.PersistentFlags().String("secret", "your-secret-key", "Secret key for authentication")
+	rootCmd.PersistentFlags().String("secret", "", "Secret key for authentication, defaults to the one generated in the database")
+	rootCmd.PersistentFlags().String("instance-url", "", "URL users and clients reach the server at, e.g. https://auth.example.com, the OpenID Connect issuer; http://localhost:{port} by default")
+	rootCmd.PersistentFlags().String("smtp-host", "", "SMTP server for sending emails, no emails are sent when empty")
+	rootCmd.PersistentFlags().Int("smtp-port", 587, "SMTP server port")
+	rootCmd.PersistentFlags().String("smtp-username", "", "SMTP username")
+	rootCmd.PersistentFlags().String("smtp-password", "", "SMTP password")
+	rootCmd.PersistentFlags().String("smtp-from", "", "sender address of emails, e.g. \"go-server <noreply@example.com>\"")
+	rootCmd.PersistentFlags().Bool("log-emails", false, "write emails to the log when no SMTP server is set, for development only as they contain password reset tokens")
+	rootCmd.PersistentFlags().String("ldap-url", "", "LDAP directory whose users can sign in, e.g. ldaps://ldap.example.com, only local passwords are checked when empty")
+	rootCmd.PersistentFlags().Bool("ldap-start-tls", false, "upgrade ldap:// connections with StartTLS")
+	rootCmd.PersistentFlags().String("ldap-bind-dn", "", "DN of the service account searching the directory, searches are anonymous when empty")
+	rootCmd.PersistentFlags().String("ldap-bind-password", "", "password of the LDAP service account")
+	rootCmd.PersistentFlags().String("ldap-base-dn", "", "DN under which user entries are searched")
+	rootCmd.PersistentFlags().String("ldap-user-filter", ldap.DefaultUserFilter, "filter of user entries, {username} is replaced with the username, e.g. (sAMAccountName={username}) for Active Directory")
+	rootCmd.PersistentFlags().String("ldap-username-attribute", "uid", "attribute of user entries holding the username")
+	rootCmd.PersistentFlags().String("ldap-nickname-attribute", "displayName", "attribute of user entries holding the nickname")
+	rootCmd.PersistentFlags().String("ldap-email-attribute", "mail", "attribute of user entries holding the email address")
+	rootCmd.PersistentFlags().String("ldap-phone-attribute", "telephoneNumber", "attribute of user entries holding the phone number")
+	rootCmd.PersistentFlags().String("ldap-group-base-dn", "", "DN under which the groups of users are searched, the memberOf attribute is used when empty")
+	rootCmd.PersistentFlags().String("ldap-group-filter", ldap.DefaultGroupFilter, "filter of the groups of a user, {dn} is replaced with the DN of the user entry")
+	rootCmd.PersistentFlags().StringArray("ldap-group-role", nil, "give the members of an LDAP group a role, as role=group DN, may be repeated; the directory then decides the roles of its users")
+	rootCmd.PersistentFlags().String("tls-cert", "", "certificate file to serve TLS with, connections are plaintext when empty")
+	rootCmd.PersistentFlags().String("tls-key", "", "private key file of the TLS certificate")
+	rootCmd.PersistentFlags().String("tls-client-ca", "", "CA file to verify client certificates against, client certificates are optional")
+	rootCmd.PersistentFlags().StringArray("tls-client-principal", nil, "give the service named by a client certificate SAN or CN roles, as name=role[,role...], may be repeated")
+	rootCmd.PersistentFlags().StringArray("trusted-proxy", nil, "IP address or CIDR range of a reverse proxy whose X-Forwarded-For header gives the client address, may be repeated")
+	rootCmd.PersistentFlags().Duration("settings-poll-interval", 30*time.Second, "how often instance settings changed by other replicas are reloaded")
 
 	if err := viper.BindPFlag("demo", rootCmd.PersistentFlags().Lookup("demo")); err != nil {
 		panic(err)
@@ -80,6 +331,33 @@ func init() {
 	if err := viper.BindPFlag("secret", rootCmd.PersistentFlags().Lookup("secret")); err != nil {
 		panic(err)
 	}
+	for _, name := range []string{
+		"instance-url",
+		"smtp-host", "smtp-port", "smtp-username", "smtp-password", "smtp-from", "log-emails",
+		"ldap-url", "ldap-start-tls", "ldap-bind-dn", "ldap-bind-password", "ldap-base-dn", "ldap-user-filter",
+		"ldap-username-attribute", "ldap-nickname-attribute", "ldap-email-attribute", "ldap-phone-attribute",
+		"ldap-group-base-dn", "ldap-group-filter", "ldap-group-role",
+		"tls-cert", "tls-key", "tls-client-ca", "tls-client-principal",
+		"trusted-proxy",
+		"settings-poll-interval",
+	} {
+		// Underscored keys, so that they can be set as GO_SERVER_SMTP_HOST and so on.
+		if err := viper.BindPFlag(strings.ReplaceAll(name, "-", "_"), rootCmd.PersistentFlags().Lookup(name)); err != nil {
+			panic(err)
+		}
+	}
+
+	keysRotateCmd.Flags().String("algorithm", auth.DefaultSigningAlgorithm, "signing algorithm of the new key, RS256 or EdDSA")
+	keysCmd.AddCommand(keysRotateCmd)
+	rootCmd.AddCommand(keysCmd)
+	secretCmd.AddCommand(secretRotateCmd)
+	secretCmd.AddCommand(secretFinishRotationCmd)
+	rootCmd.AddCommand(secretCmd)
+	maintenanceOnCmd.Flags().String("mode", "read-only", "read-only rejects methods that change data, full serves only health checks and the instance profile")
+	maintenanceOnCmd.Flags().String("message", "", "told to rejected callers instead of the default message")
+	maintenanceOnCmd.Flags().Duration("retry-after", auth.DefaultMaintenanceRetryAfter, "how long rejected callers are told to wait before retrying")
+	maintenanceCmd.AddCommand(maintenanceOnCmd, maintenanceOffCmd)
+	rootCmd.AddCommand(maintenanceCmd)
 
 	viper.BindPFlags(rootCmd.Flags())
 	viper.SetEnvPrefix("GO_SERVER")
@@ -101,9 +379,35 @@ func run(ctx context.Context, prof *profile.Profile) error {
 
 	fmt.Printf("== prof.Data: %s\n", prof.Data)
 
+	// 2~4.创建数据目录、数据驱动和存储实例
+	storeInstance, err := newStore(ctx, prof)
+	if err != nil {
+		return err
+	}
+
+	// 5.创建服务实例，启动服务
+	s, err := server.NewServer(ctx, prof, storeInstance)
+	if err != nil {
+		return err
+	}
+
+	if err := s.Start(ctx); err != nil {
+		return err
+	}
+
+	// 6.处理优雅停机
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	<-c
+
+	return s.Shutdown(ctx)
+}
+
+// 创建数据目录、数据驱动，创建存储实例并且迁移数据
+func newStore(ctx context.Context, prof *profile.Profile) (*store.Store, error) {
 	// 2.创建数据目录
 	if err := os.MkdirAll(prof.Data, 0755); err != nil {
-		return err
+		return nil, err
 	}
 
 	// 3.创建数据驱动
@@ -118,35 +422,19 @@ func run(ctx context.Context, prof *profile.Profile) error {
 	case "mysql":
 		dbDriver, err = mysql.NewDriver(prof)
 	default:
-		return fmt.Errorf("unsupported database driver: %s", prof.Driver)
+		return nil, fmt.Errorf("unsupported database driver: %s", prof.Driver)
 	}
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// 4.创建存储实例并且迁移数据
 	storeInstance := store.New(dbDriver, prof)
 	if err := storeInstance.Migrate(ctx); err != nil {
-		return err
-	}
-
-	// 5.创建服务实例，启动服务
-	s, err := server.NewServer(ctx, prof, storeInstance)
-	if err != nil {
-		return err
-	}
-
-	if err := s.Start(ctx); err != nil {
-		return err
+		return nil, err
 	}
-
-	// 6.处理优雅停机
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-	<-c
-
-	return s.Shutdown(ctx)
+	return storeInstance, nil
 }
 
 // main方法执行 rootCmd
@@ -1,11 +1,16 @@
 package profile
 
 import (
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type Profile struct {
@@ -18,6 +23,54 @@ type Profile struct {
 	Driver  string
 	Secret  string
 	Version string
+	// InstanceURL is the URL users and clients reach the server at, such as
+	// https://auth.example.com. It is the issuer of the OpenID Connect provider and the base of
+	// the redirect URLs sent to identity providers, http://localhost:{port} by default.
+	InstanceURL string
+
+	// SMTP is the mail server used to send emails such as password reset tokens.
+	// No emails are sent when SMTPHost is empty, unless LogEmails writes them to the
+	// log, which exposes their tokens and is only meant for development.
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+	LogEmails    bool
+
+	// LDAP is a directory whose users can sign in with their directory password.
+	// Only local passwords are checked when LDAPURL is empty.
+	LDAPURL          string
+	LDAPStartTLS     bool
+	LDAPBindDN       string
+	LDAPBindPassword string
+	LDAPBaseDN       string
+	LDAPUserFilter   string
+	LDAPUsernameAttr string
+	LDAPNicknameAttr string
+	LDAPEmailAttr    string
+	LDAPPhoneAttr    string
+	LDAPGroupBaseDN  string
+	LDAPGroupFilter  string
+	// LDAPGroupRoles are "role=group DN" entries that give the members of a group a role.
+	LDAPGroupRoles []string
+
+	// TLS serves all protocols over TLS with the certificate and key files. Client
+	// certificates are verified against TLSClientCA when it is set, and the services
+	// they name are given roles by "name=role[,role...]" entries of TLSClientPrincipals.
+	TLSCert             string
+	TLSKey              string
+	TLSClientCA         string
+	TLSClientPrincipals []string
+
+	// TrustedProxies are the addresses or CIDR ranges of the reverse proxies in front of the
+	// server. The client address is taken from their X-Forwarded-For entries, and is the peer
+	// address when there are none.
+	TrustedProxies []string
+
+	// SettingsPollInterval is how often instance settings are reloaded to pick up changes made
+	// by other replicas. PostgreSQL also reports changes right away.
+	SettingsPollInterval time.Duration
 }
 
 func (p *Profile) Validate() error {
@@ -51,6 +104,58 @@ func (p *Profile) Validate() error {
 		p.DSN = "host=localhost port=5432 user=postgres password=password dbname=goserver sslmode=disable"
 	}
 
+	if err := p.validateInstanceURL(); err != nil {
+		return err
+	}
+
+	if p.SMTPHost != "" && p.SMTPFrom == "" {
+		return errors.New("smtp sender address is required when an smtp host is set")
+	}
+
+	if p.LDAPURL != "" && p.LDAPBaseDN == "" {
+		return errors.New("ldap base dn is required when an ldap url is set")
+	}
+	for _, groupRole := range p.LDAPGroupRoles {
+		role, groupDN, ok := strings.Cut(groupRole, "=")
+		if !ok || role == "" || groupDN == "" {
+			return fmt.Errorf("ldap group role %q must have the form role=group dn", groupRole)
+		}
+	}
+
+	if (p.TLSCert == "") != (p.TLSKey == "") {
+		return errors.New("tls certificate and key must be set together")
+	}
+	if p.TLSClientCA != "" && p.TLSCert == "" {
+		return errors.New("tls certificate is required when a tls client ca is set")
+	}
+	if len(p.TLSClientPrincipals) > 0 && p.TLSClientCA == "" {
+		return errors.New("tls client ca is required when tls client principals are set")
+	}
+
+	if p.SettingsPollInterval <= 0 {
+		p.SettingsPollInterval = 30 * time.Second
+	}
+
+	return nil
+}
+
+func (p *Profile) validateInstanceURL() error {
+	if p.InstanceURL == "" {
+		scheme, host := "http", p.Addr
+		if p.TLSCert != "" {
+			scheme = "https"
+		}
+		if host == "" || net.ParseIP(host).IsUnspecified() {
+			host = "localhost"
+		}
+		p.InstanceURL = scheme + "://" + net.JoinHostPort(host, strconv.Itoa(p.Port))
+		return nil
+	}
+	u, err := url.Parse(p.InstanceURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("instance url %q must be an absolute http or https URL without query or fragment", p.InstanceURL)
+	}
+	p.InstanceURL = strings.TrimRight(p.InstanceURL, "/")
 	return nil
 }
 
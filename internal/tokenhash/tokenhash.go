@@ -0,0 +1,13 @@
+// Package tokenhash derives the storage keys of opaque tokens.
+package tokenhash
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Hash returns the hex encoded SHA-256 digest of an opaque token, used as its storage key.
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -36,12 +36,28 @@ const (
 	// InstanceServiceGetInstanceProfileProcedure is the fully-qualified name of the InstanceService's
 	// GetInstanceProfile RPC.
 	InstanceServiceGetInstanceProfileProcedure = "/goserver.api.v1.InstanceService/GetInstanceProfile"
+	// InstanceServiceSetupInstanceProcedure is the fully-qualified name of the InstanceService's
+	// SetupInstance RPC.
+	InstanceServiceSetupInstanceProcedure = "/goserver.api.v1.InstanceService/SetupInstance"
+	// InstanceServiceGetInstanceSettingProcedure is the fully-qualified name of the InstanceService's
+	// GetInstanceSetting RPC.
+	InstanceServiceGetInstanceSettingProcedure = "/goserver.api.v1.InstanceService/GetInstanceSetting"
+	// InstanceServiceUpdateInstanceSettingProcedure is the fully-qualified name of the
+	// InstanceService's UpdateInstanceSetting RPC.
+	InstanceServiceUpdateInstanceSettingProcedure = "/goserver.api.v1.InstanceService/UpdateInstanceSetting"
 )
 
 // InstanceServiceClient is a client for the goserver.api.v1.InstanceService service.
 type InstanceServiceClient interface {
 	// Gets the instance profile.
 	GetInstanceProfile(context.Context, *connect.Request[v1.GetInstanceProfileRequest]) (*connect.Response[v1.InstanceProfile], error)
+	// Creates the first administrator and names a new instance. It is only allowed while
+	// the instance has no administrator and succeeds at most once.
+	SetupInstance(context.Context, *connect.Request[v1.SetupInstanceRequest]) (*connect.Response[v1.SetupInstanceResponse], error)
+	// Gets an instance setting. Settings that were never changed are returned with their defaults.
+	GetInstanceSetting(context.Context, *connect.Request[v1.GetInstanceSettingRequest]) (*connect.Response[v1.GetInstanceSettingResponse], error)
+	// Updates the fields of an instance setting listed in update_mask.
+	UpdateInstanceSetting(context.Context, *connect.Request[v1.UpdateInstanceSettingRequest]) (*connect.Response[v1.UpdateInstanceSettingResponse], error)
 }
 
 // NewInstanceServiceClient constructs a client for the goserver.api.v1.InstanceService service. By
@@ -61,12 +77,33 @@ func NewInstanceServiceClient(httpClient connect.HTTPClient, baseURL string, opt
 			connect.WithSchema(instanceServiceMethods.ByName("GetInstanceProfile")),
 			connect.WithClientOptions(opts...),
 		),
+		setupInstance: connect.NewClient[v1.SetupInstanceRequest, v1.SetupInstanceResponse](
+			httpClient,
+			baseURL+InstanceServiceSetupInstanceProcedure,
+			connect.WithSchema(instanceServiceMethods.ByName("SetupInstance")),
+			connect.WithClientOptions(opts...),
+		),
+		getInstanceSetting: connect.NewClient[v1.GetInstanceSettingRequest, v1.GetInstanceSettingResponse](
+			httpClient,
+			baseURL+InstanceServiceGetInstanceSettingProcedure,
+			connect.WithSchema(instanceServiceMethods.ByName("GetInstanceSetting")),
+			connect.WithClientOptions(opts...),
+		),
+		updateInstanceSetting: connect.NewClient[v1.UpdateInstanceSettingRequest, v1.UpdateInstanceSettingResponse](
+			httpClient,
+			baseURL+InstanceServiceUpdateInstanceSettingProcedure,
+			connect.WithSchema(instanceServiceMethods.ByName("UpdateInstanceSetting")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // instanceServiceClient implements InstanceServiceClient.
 type instanceServiceClient struct {
-	getInstanceProfile *connect.Client[v1.GetInstanceProfileRequest, v1.InstanceProfile]
+	getInstanceProfile    *connect.Client[v1.GetInstanceProfileRequest, v1.InstanceProfile]
+	setupInstance         *connect.Client[v1.SetupInstanceRequest, v1.SetupInstanceResponse]
+	getInstanceSetting    *connect.Client[v1.GetInstanceSettingRequest, v1.GetInstanceSettingResponse]
+	updateInstanceSetting *connect.Client[v1.UpdateInstanceSettingRequest, v1.UpdateInstanceSettingResponse]
 }
 
 // GetInstanceProfile calls goserver.api.v1.InstanceService.GetInstanceProfile.
@@ -74,10 +111,32 @@ func (c *instanceServiceClient) GetInstanceProfile(ctx context.Context, req *con
 	return c.getInstanceProfile.CallUnary(ctx, req)
 }
 
+// SetupInstance calls goserver.api.v1.InstanceService.SetupInstance.
+func (c *instanceServiceClient) SetupInstance(ctx context.Context, req *connect.Request[v1.SetupInstanceRequest]) (*connect.Response[v1.SetupInstanceResponse], error) {
+	return c.setupInstance.CallUnary(ctx, req)
+}
+
+// GetInstanceSetting calls goserver.api.v1.InstanceService.GetInstanceSetting.
+func (c *instanceServiceClient) GetInstanceSetting(ctx context.Context, req *connect.Request[v1.GetInstanceSettingRequest]) (*connect.Response[v1.GetInstanceSettingResponse], error) {
+	return c.getInstanceSetting.CallUnary(ctx, req)
+}
+
+// UpdateInstanceSetting calls goserver.api.v1.InstanceService.UpdateInstanceSetting.
+func (c *instanceServiceClient) UpdateInstanceSetting(ctx context.Context, req *connect.Request[v1.UpdateInstanceSettingRequest]) (*connect.Response[v1.UpdateInstanceSettingResponse], error) {
+	return c.updateInstanceSetting.CallUnary(ctx, req)
+}
+
 // InstanceServiceHandler is an implementation of the goserver.api.v1.InstanceService service.
 type InstanceServiceHandler interface {
 	// Gets the instance profile.
 	GetInstanceProfile(context.Context, *connect.Request[v1.GetInstanceProfileRequest]) (*connect.Response[v1.InstanceProfile], error)
+	// Creates the first administrator and names a new instance. It is only allowed while
+	// the instance has no administrator and succeeds at most once.
+	SetupInstance(context.Context, *connect.Request[v1.SetupInstanceRequest]) (*connect.Response[v1.SetupInstanceResponse], error)
+	// Gets an instance setting. Settings that were never changed are returned with their defaults.
+	GetInstanceSetting(context.Context, *connect.Request[v1.GetInstanceSettingRequest]) (*connect.Response[v1.GetInstanceSettingResponse], error)
+	// Updates the fields of an instance setting listed in update_mask.
+	UpdateInstanceSetting(context.Context, *connect.Request[v1.UpdateInstanceSettingRequest]) (*connect.Response[v1.UpdateInstanceSettingResponse], error)
 }
 
 // NewInstanceServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -93,10 +152,34 @@ func NewInstanceServiceHandler(svc InstanceServiceHandler, opts ...connect.Handl
 		connect.WithSchema(instanceServiceMethods.ByName("GetInstanceProfile")),
 		connect.WithHandlerOptions(opts...),
 	)
+	instanceServiceSetupInstanceHandler := connect.NewUnaryHandler(
+		InstanceServiceSetupInstanceProcedure,
+		svc.SetupInstance,
+		connect.WithSchema(instanceServiceMethods.ByName("SetupInstance")),
+		connect.WithHandlerOptions(opts...),
+	)
+	instanceServiceGetInstanceSettingHandler := connect.NewUnaryHandler(
+		InstanceServiceGetInstanceSettingProcedure,
+		svc.GetInstanceSetting,
+		connect.WithSchema(instanceServiceMethods.ByName("GetInstanceSetting")),
+		connect.WithHandlerOptions(opts...),
+	)
+	instanceServiceUpdateInstanceSettingHandler := connect.NewUnaryHandler(
+		InstanceServiceUpdateInstanceSettingProcedure,
+		svc.UpdateInstanceSetting,
+		connect.WithSchema(instanceServiceMethods.ByName("UpdateInstanceSetting")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/goserver.api.v1.InstanceService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case InstanceServiceGetInstanceProfileProcedure:
 			instanceServiceGetInstanceProfileHandler.ServeHTTP(w, r)
+		case InstanceServiceSetupInstanceProcedure:
+			instanceServiceSetupInstanceHandler.ServeHTTP(w, r)
+		case InstanceServiceGetInstanceSettingProcedure:
+			instanceServiceGetInstanceSettingHandler.ServeHTTP(w, r)
+		case InstanceServiceUpdateInstanceSettingProcedure:
+			instanceServiceUpdateInstanceSettingHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -109,3 +192,15 @@ type UnimplementedInstanceServiceHandler struct{}
 func (UnimplementedInstanceServiceHandler) GetInstanceProfile(context.Context, *connect.Request[v1.GetInstanceProfileRequest]) (*connect.Response[v1.InstanceProfile], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.InstanceService.GetInstanceProfile is not implemented"))
 }
+
+func (UnimplementedInstanceServiceHandler) SetupInstance(context.Context, *connect.Request[v1.SetupInstanceRequest]) (*connect.Response[v1.SetupInstanceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.InstanceService.SetupInstance is not implemented"))
+}
+
+func (UnimplementedInstanceServiceHandler) GetInstanceSetting(context.Context, *connect.Request[v1.GetInstanceSettingRequest]) (*connect.Response[v1.GetInstanceSettingResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.InstanceService.GetInstanceSetting is not implemented"))
+}
+
+func (UnimplementedInstanceServiceHandler) UpdateInstanceSetting(context.Context, *connect.Request[v1.UpdateInstanceSettingRequest]) (*connect.Response[v1.UpdateInstanceSettingResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.InstanceService.UpdateInstanceSetting is not implemented"))
+}
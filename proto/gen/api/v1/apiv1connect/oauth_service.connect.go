@@ -0,0 +1,390 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: api/v1/oauth_service.proto
+
+package apiv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/pixb/go-server/proto/gen/api/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// OAuthServiceName is the fully-qualified name of the OAuthService service.
+	OAuthServiceName = "goserver.api.v1.OAuthService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// OAuthServiceListOAuthClientsProcedure is the fully-qualified name of the OAuthService's
+	// ListOAuthClients RPC.
+	OAuthServiceListOAuthClientsProcedure = "/goserver.api.v1.OAuthService/ListOAuthClients"
+	// OAuthServiceGetOAuthClientProcedure is the fully-qualified name of the OAuthService's
+	// GetOAuthClient RPC.
+	OAuthServiceGetOAuthClientProcedure = "/goserver.api.v1.OAuthService/GetOAuthClient"
+	// OAuthServiceCreateOAuthClientProcedure is the fully-qualified name of the OAuthService's
+	// CreateOAuthClient RPC.
+	OAuthServiceCreateOAuthClientProcedure = "/goserver.api.v1.OAuthService/CreateOAuthClient"
+	// OAuthServiceUpdateOAuthClientProcedure is the fully-qualified name of the OAuthService's
+	// UpdateOAuthClient RPC.
+	OAuthServiceUpdateOAuthClientProcedure = "/goserver.api.v1.OAuthService/UpdateOAuthClient"
+	// OAuthServiceRotateOAuthClientSecretProcedure is the fully-qualified name of the OAuthService's
+	// RotateOAuthClientSecret RPC.
+	OAuthServiceRotateOAuthClientSecretProcedure = "/goserver.api.v1.OAuthService/RotateOAuthClientSecret"
+	// OAuthServiceDeleteOAuthClientProcedure is the fully-qualified name of the OAuthService's
+	// DeleteOAuthClient RPC.
+	OAuthServiceDeleteOAuthClientProcedure = "/goserver.api.v1.OAuthService/DeleteOAuthClient"
+	// OAuthServiceGetOAuthAuthorizationProcedure is the fully-qualified name of the OAuthService's
+	// GetOAuthAuthorization RPC.
+	OAuthServiceGetOAuthAuthorizationProcedure = "/goserver.api.v1.OAuthService/GetOAuthAuthorization"
+	// OAuthServiceApproveOAuthAuthorizationProcedure is the fully-qualified name of the OAuthService's
+	// ApproveOAuthAuthorization RPC.
+	OAuthServiceApproveOAuthAuthorizationProcedure = "/goserver.api.v1.OAuthService/ApproveOAuthAuthorization"
+	// OAuthServiceListOAuthConsentsProcedure is the fully-qualified name of the OAuthService's
+	// ListOAuthConsents RPC.
+	OAuthServiceListOAuthConsentsProcedure = "/goserver.api.v1.OAuthService/ListOAuthConsents"
+	// OAuthServiceRevokeOAuthConsentProcedure is the fully-qualified name of the OAuthService's
+	// RevokeOAuthConsent RPC.
+	OAuthServiceRevokeOAuthConsentProcedure = "/goserver.api.v1.OAuthService/RevokeOAuthConsent"
+)
+
+// OAuthServiceClient is a client for the goserver.api.v1.OAuthService service.
+type OAuthServiceClient interface {
+	ListOAuthClients(context.Context, *connect.Request[v1.ListOAuthClientsRequest]) (*connect.Response[v1.ListOAuthClientsResponse], error)
+	GetOAuthClient(context.Context, *connect.Request[v1.GetOAuthClientRequest]) (*connect.Response[v1.GetOAuthClientResponse], error)
+	// Registers a client. The secret of a confidential client is only returned here.
+	CreateOAuthClient(context.Context, *connect.Request[v1.CreateOAuthClientRequest]) (*connect.Response[v1.CreateOAuthClientResponse], error)
+	// Updates the fields of a client listed in update_mask.
+	UpdateOAuthClient(context.Context, *connect.Request[v1.UpdateOAuthClientRequest]) (*connect.Response[v1.UpdateOAuthClientResponse], error)
+	// Replaces the secret of a confidential client, the previous secret stops working at once.
+	RotateOAuthClientSecret(context.Context, *connect.Request[v1.RotateOAuthClientSecretRequest]) (*connect.Response[v1.RotateOAuthClientSecretResponse], error)
+	// Deletes a client with its consents. The tokens it holds are no longer accepted by /userinfo.
+	DeleteOAuthClient(context.Context, *connect.Request[v1.DeleteOAuthClientRequest]) (*connect.Response[v1.DeleteOAuthClientResponse], error)
+	// Validates an authorization request on behalf of the current user and tells whether
+	// the user has to be asked for consent.
+	GetOAuthAuthorization(context.Context, *connect.Request[v1.GetOAuthAuthorizationRequest]) (*connect.Response[v1.GetOAuthAuthorizationResponse], error)
+	// Approves or denies an authorization request of the current user. An approval records
+	// the consent of the user and issues an authorization code.
+	ApproveOAuthAuthorization(context.Context, *connect.Request[v1.ApproveOAuthAuthorizationRequest]) (*connect.Response[v1.ApproveOAuthAuthorizationResponse], error)
+	// Lists the clients the current user has granted access to.
+	ListOAuthConsents(context.Context, *connect.Request[v1.ListOAuthConsentsRequest]) (*connect.Response[v1.ListOAuthConsentsResponse], error)
+	// Revokes the consent of the current user, the client has to ask for it again.
+	RevokeOAuthConsent(context.Context, *connect.Request[v1.RevokeOAuthConsentRequest]) (*connect.Response[v1.RevokeOAuthConsentResponse], error)
+}
+
+// NewOAuthServiceClient constructs a client for the goserver.api.v1.OAuthService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewOAuthServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) OAuthServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	oAuthServiceMethods := v1.File_api_v1_oauth_service_proto.Services().ByName("OAuthService").Methods()
+	return &oAuthServiceClient{
+		listOAuthClients: connect.NewClient[v1.ListOAuthClientsRequest, v1.ListOAuthClientsResponse](
+			httpClient,
+			baseURL+OAuthServiceListOAuthClientsProcedure,
+			connect.WithSchema(oAuthServiceMethods.ByName("ListOAuthClients")),
+			connect.WithClientOptions(opts...),
+		),
+		getOAuthClient: connect.NewClient[v1.GetOAuthClientRequest, v1.GetOAuthClientResponse](
+			httpClient,
+			baseURL+OAuthServiceGetOAuthClientProcedure,
+			connect.WithSchema(oAuthServiceMethods.ByName("GetOAuthClient")),
+			connect.WithClientOptions(opts...),
+		),
+		createOAuthClient: connect.NewClient[v1.CreateOAuthClientRequest, v1.CreateOAuthClientResponse](
+			httpClient,
+			baseURL+OAuthServiceCreateOAuthClientProcedure,
+			connect.WithSchema(oAuthServiceMethods.ByName("CreateOAuthClient")),
+			connect.WithClientOptions(opts...),
+		),
+		updateOAuthClient: connect.NewClient[v1.UpdateOAuthClientRequest, v1.UpdateOAuthClientResponse](
+			httpClient,
+			baseURL+OAuthServiceUpdateOAuthClientProcedure,
+			connect.WithSchema(oAuthServiceMethods.ByName("UpdateOAuthClient")),
+			connect.WithClientOptions(opts...),
+		),
+		rotateOAuthClientSecret: connect.NewClient[v1.RotateOAuthClientSecretRequest, v1.RotateOAuthClientSecretResponse](
+			httpClient,
+			baseURL+OAuthServiceRotateOAuthClientSecretProcedure,
+			connect.WithSchema(oAuthServiceMethods.ByName("RotateOAuthClientSecret")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteOAuthClient: connect.NewClient[v1.DeleteOAuthClientRequest, v1.DeleteOAuthClientResponse](
+			httpClient,
+			baseURL+OAuthServiceDeleteOAuthClientProcedure,
+			connect.WithSchema(oAuthServiceMethods.ByName("DeleteOAuthClient")),
+			connect.WithClientOptions(opts...),
+		),
+		getOAuthAuthorization: connect.NewClient[v1.GetOAuthAuthorizationRequest, v1.GetOAuthAuthorizationResponse](
+			httpClient,
+			baseURL+OAuthServiceGetOAuthAuthorizationProcedure,
+			connect.WithSchema(oAuthServiceMethods.ByName("GetOAuthAuthorization")),
+			connect.WithClientOptions(opts...),
+		),
+		approveOAuthAuthorization: connect.NewClient[v1.ApproveOAuthAuthorizationRequest, v1.ApproveOAuthAuthorizationResponse](
+			httpClient,
+			baseURL+OAuthServiceApproveOAuthAuthorizationProcedure,
+			connect.WithSchema(oAuthServiceMethods.ByName("ApproveOAuthAuthorization")),
+			connect.WithClientOptions(opts...),
+		),
+		listOAuthConsents: connect.NewClient[v1.ListOAuthConsentsRequest, v1.ListOAuthConsentsResponse](
+			httpClient,
+			baseURL+OAuthServiceListOAuthConsentsProcedure,
+			connect.WithSchema(oAuthServiceMethods.ByName("ListOAuthConsents")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeOAuthConsent: connect.NewClient[v1.RevokeOAuthConsentRequest, v1.RevokeOAuthConsentResponse](
+			httpClient,
+			baseURL+OAuthServiceRevokeOAuthConsentProcedure,
+			connect.WithSchema(oAuthServiceMethods.ByName("RevokeOAuthConsent")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// oAuthServiceClient implements OAuthServiceClient.
+type oAuthServiceClient struct {
+	listOAuthClients          *connect.Client[v1.ListOAuthClientsRequest, v1.ListOAuthClientsResponse]
+	getOAuthClient            *connect.Client[v1.GetOAuthClientRequest, v1.GetOAuthClientResponse]
+	createOAuthClient         *connect.Client[v1.CreateOAuthClientRequest, v1.CreateOAuthClientResponse]
+	updateOAuthClient         *connect.Client[v1.UpdateOAuthClientRequest, v1.UpdateOAuthClientResponse]
+	rotateOAuthClientSecret   *connect.Client[v1.RotateOAuthClientSecretRequest, v1.RotateOAuthClientSecretResponse]
+	deleteOAuthClient         *connect.Client[v1.DeleteOAuthClientRequest, v1.DeleteOAuthClientResponse]
+	getOAuthAuthorization     *connect.Client[v1.GetOAuthAuthorizationRequest, v1.GetOAuthAuthorizationResponse]
+	approveOAuthAuthorization *connect.Client[v1.ApproveOAuthAuthorizationRequest, v1.ApproveOAuthAuthorizationResponse]
+	listOAuthConsents         *connect.Client[v1.ListOAuthConsentsRequest, v1.ListOAuthConsentsResponse]
+	revokeOAuthConsent        *connect.Client[v1.RevokeOAuthConsentRequest, v1.RevokeOAuthConsentResponse]
+}
+
+// ListOAuthClients calls goserver.api.v1.OAuthService.ListOAuthClients.
+func (c *oAuthServiceClient) ListOAuthClients(ctx context.Context, req *connect.Request[v1.ListOAuthClientsRequest]) (*connect.Response[v1.ListOAuthClientsResponse], error) {
+	return c.listOAuthClients.CallUnary(ctx, req)
+}
+
+// GetOAuthClient calls goserver.api.v1.OAuthService.GetOAuthClient.
+func (c *oAuthServiceClient) GetOAuthClient(ctx context.Context, req *connect.Request[v1.GetOAuthClientRequest]) (*connect.Response[v1.GetOAuthClientResponse], error) {
+	return c.getOAuthClient.CallUnary(ctx, req)
+}
+
+// CreateOAuthClient calls goserver.api.v1.OAuthService.CreateOAuthClient.
+func (c *oAuthServiceClient) CreateOAuthClient(ctx context.Context, req *connect.Request[v1.CreateOAuthClientRequest]) (*connect.Response[v1.CreateOAuthClientResponse], error) {
+	return c.createOAuthClient.CallUnary(ctx, req)
+}
+
+// UpdateOAuthClient calls goserver.api.v1.OAuthService.UpdateOAuthClient.
+func (c *oAuthServiceClient) UpdateOAuthClient(ctx context.Context, req *connect.Request[v1.UpdateOAuthClientRequest]) (*connect.Response[v1.UpdateOAuthClientResponse], error) {
+	return c.updateOAuthClient.CallUnary(ctx, req)
+}
+
+// RotateOAuthClientSecret calls goserver.api.v1.OAuthService.RotateOAuthClientSecret.
+func (c *oAuthServiceClient) RotateOAuthClientSecret(ctx context.Context, req *connect.Request[v1.RotateOAuthClientSecretRequest]) (*connect.Response[v1.RotateOAuthClientSecretResponse], error) {
+	return c.rotateOAuthClientSecret.CallUnary(ctx, req)
+}
+
+// DeleteOAuthClient calls goserver.api.v1.OAuthService.DeleteOAuthClient.
+func (c *oAuthServiceClient) DeleteOAuthClient(ctx context.Context, req *connect.Request[v1.DeleteOAuthClientRequest]) (*connect.Response[v1.DeleteOAuthClientResponse], error) {
+	return c.deleteOAuthClient.CallUnary(ctx, req)
+}
+
+// GetOAuthAuthorization calls goserver.api.v1.OAuthService.GetOAuthAuthorization.
+func (c *oAuthServiceClient) GetOAuthAuthorization(ctx context.Context, req *connect.Request[v1.GetOAuthAuthorizationRequest]) (*connect.Response[v1.GetOAuthAuthorizationResponse], error) {
+	return c.getOAuthAuthorization.CallUnary(ctx, req)
+}
+
+// ApproveOAuthAuthorization calls goserver.api.v1.OAuthService.ApproveOAuthAuthorization.
+func (c *oAuthServiceClient) ApproveOAuthAuthorization(ctx context.Context, req *connect.Request[v1.ApproveOAuthAuthorizationRequest]) (*connect.Response[v1.ApproveOAuthAuthorizationResponse], error) {
+	return c.approveOAuthAuthorization.CallUnary(ctx, req)
+}
+
+// ListOAuthConsents calls goserver.api.v1.OAuthService.ListOAuthConsents.
+func (c *oAuthServiceClient) ListOAuthConsents(ctx context.Context, req *connect.Request[v1.ListOAuthConsentsRequest]) (*connect.Response[v1.ListOAuthConsentsResponse], error) {
+	return c.listOAuthConsents.CallUnary(ctx, req)
+}
+
+// RevokeOAuthConsent calls goserver.api.v1.OAuthService.RevokeOAuthConsent.
+func (c *oAuthServiceClient) RevokeOAuthConsent(ctx context.Context, req *connect.Request[v1.RevokeOAuthConsentRequest]) (*connect.Response[v1.RevokeOAuthConsentResponse], error) {
+	return c.revokeOAuthConsent.CallUnary(ctx, req)
+}
+
+// OAuthServiceHandler is an implementation of the goserver.api.v1.OAuthService service.
+type OAuthServiceHandler interface {
+	ListOAuthClients(context.Context, *connect.Request[v1.ListOAuthClientsRequest]) (*connect.Response[v1.ListOAuthClientsResponse], error)
+	GetOAuthClient(context.Context, *connect.Request[v1.GetOAuthClientRequest]) (*connect.Response[v1.GetOAuthClientResponse], error)
+	// Registers a client. The secret of a confidential client is only returned here.
+	CreateOAuthClient(context.Context, *connect.Request[v1.CreateOAuthClientRequest]) (*connect.Response[v1.CreateOAuthClientResponse], error)
+	// Updates the fields of a client listed in update_mask.
+	UpdateOAuthClient(context.Context, *connect.Request[v1.UpdateOAuthClientRequest]) (*connect.Response[v1.UpdateOAuthClientResponse], error)
+	// Replaces the secret of a confidential client, the previous secret stops working at once.
+	RotateOAuthClientSecret(context.Context, *connect.Request[v1.RotateOAuthClientSecretRequest]) (*connect.Response[v1.RotateOAuthClientSecretResponse], error)
+	// Deletes a client with its consents. The tokens it holds are no longer accepted by /userinfo.
+	DeleteOAuthClient(context.Context, *connect.Request[v1.DeleteOAuthClientRequest]) (*connect.Response[v1.DeleteOAuthClientResponse], error)
+	// Validates an authorization request on behalf of the current user and tells whether
+	// the user has to be asked for consent.
+	GetOAuthAuthorization(context.Context, *connect.Request[v1.GetOAuthAuthorizationRequest]) (*connect.Response[v1.GetOAuthAuthorizationResponse], error)
+	// Approves or denies an authorization request of the current user. An approval records
+	// the consent of the user and issues an authorization code.
+	ApproveOAuthAuthorization(context.Context, *connect.Request[v1.ApproveOAuthAuthorizationRequest]) (*connect.Response[v1.ApproveOAuthAuthorizationResponse], error)
+	// Lists the clients the current user has granted access to.
+	ListOAuthConsents(context.Context, *connect.Request[v1.ListOAuthConsentsRequest]) (*connect.Response[v1.ListOAuthConsentsResponse], error)
+	// Revokes the consent of the current user, the client has to ask for it again.
+	RevokeOAuthConsent(context.Context, *connect.Request[v1.RevokeOAuthConsentRequest]) (*connect.Response[v1.RevokeOAuthConsentResponse], error)
+}
+
+// NewOAuthServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewOAuthServiceHandler(svc OAuthServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	oAuthServiceMethods := v1.File_api_v1_oauth_service_proto.Services().ByName("OAuthService").Methods()
+	oAuthServiceListOAuthClientsHandler := connect.NewUnaryHandler(
+		OAuthServiceListOAuthClientsProcedure,
+		svc.ListOAuthClients,
+		connect.WithSchema(oAuthServiceMethods.ByName("ListOAuthClients")),
+		connect.WithHandlerOptions(opts...),
+	)
+	oAuthServiceGetOAuthClientHandler := connect.NewUnaryHandler(
+		OAuthServiceGetOAuthClientProcedure,
+		svc.GetOAuthClient,
+		connect.WithSchema(oAuthServiceMethods.ByName("GetOAuthClient")),
+		connect.WithHandlerOptions(opts...),
+	)
+	oAuthServiceCreateOAuthClientHandler := connect.NewUnaryHandler(
+		OAuthServiceCreateOAuthClientProcedure,
+		svc.CreateOAuthClient,
+		connect.WithSchema(oAuthServiceMethods.ByName("CreateOAuthClient")),
+		connect.WithHandlerOptions(opts...),
+	)
+	oAuthServiceUpdateOAuthClientHandler := connect.NewUnaryHandler(
+		OAuthServiceUpdateOAuthClientProcedure,
+		svc.UpdateOAuthClient,
+		connect.WithSchema(oAuthServiceMethods.ByName("UpdateOAuthClient")),
+		connect.WithHandlerOptions(opts...),
+	)
+	oAuthServiceRotateOAuthClientSecretHandler := connect.NewUnaryHandler(
+		OAuthServiceRotateOAuthClientSecretProcedure,
+		svc.RotateOAuthClientSecret,
+		connect.WithSchema(oAuthServiceMethods.ByName("RotateOAuthClientSecret")),
+		connect.WithHandlerOptions(opts...),
+	)
+	oAuthServiceDeleteOAuthClientHandler := connect.NewUnaryHandler(
+		OAuthServiceDeleteOAuthClientProcedure,
+		svc.DeleteOAuthClient,
+		connect.WithSchema(oAuthServiceMethods.ByName("DeleteOAuthClient")),
+		connect.WithHandlerOptions(opts...),
+	)
+	oAuthServiceGetOAuthAuthorizationHandler := connect.NewUnaryHandler(
+		OAuthServiceGetOAuthAuthorizationProcedure,
+		svc.GetOAuthAuthorization,
+		connect.WithSchema(oAuthServiceMethods.ByName("GetOAuthAuthorization")),
+		connect.WithHandlerOptions(opts...),
+	)
+	oAuthServiceApproveOAuthAuthorizationHandler := connect.NewUnaryHandler(
+		OAuthServiceApproveOAuthAuthorizationProcedure,
+		svc.ApproveOAuthAuthorization,
+		connect.WithSchema(oAuthServiceMethods.ByName("ApproveOAuthAuthorization")),
+		connect.WithHandlerOptions(opts...),
+	)
+	oAuthServiceListOAuthConsentsHandler := connect.NewUnaryHandler(
+		OAuthServiceListOAuthConsentsProcedure,
+		svc.ListOAuthConsents,
+		connect.WithSchema(oAuthServiceMethods.ByName("ListOAuthConsents")),
+		connect.WithHandlerOptions(opts...),
+	)
+	oAuthServiceRevokeOAuthConsentHandler := connect.NewUnaryHandler(
+		OAuthServiceRevokeOAuthConsentProcedure,
+		svc.RevokeOAuthConsent,
+		connect.WithSchema(oAuthServiceMethods.ByName("RevokeOAuthConsent")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/goserver.api.v1.OAuthService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case OAuthServiceListOAuthClientsProcedure:
+			oAuthServiceListOAuthClientsHandler.ServeHTTP(w, r)
+		case OAuthServiceGetOAuthClientProcedure:
+			oAuthServiceGetOAuthClientHandler.ServeHTTP(w, r)
+		case OAuthServiceCreateOAuthClientProcedure:
+			oAuthServiceCreateOAuthClientHandler.ServeHTTP(w, r)
+		case OAuthServiceUpdateOAuthClientProcedure:
+			oAuthServiceUpdateOAuthClientHandler.ServeHTTP(w, r)
+		case OAuthServiceRotateOAuthClientSecretProcedure:
+			oAuthServiceRotateOAuthClientSecretHandler.ServeHTTP(w, r)
+		case OAuthServiceDeleteOAuthClientProcedure:
+			oAuthServiceDeleteOAuthClientHandler.ServeHTTP(w, r)
+		case OAuthServiceGetOAuthAuthorizationProcedure:
+			oAuthServiceGetOAuthAuthorizationHandler.ServeHTTP(w, r)
+		case OAuthServiceApproveOAuthAuthorizationProcedure:
+			oAuthServiceApproveOAuthAuthorizationHandler.ServeHTTP(w, r)
+		case OAuthServiceListOAuthConsentsProcedure:
+			oAuthServiceListOAuthConsentsHandler.ServeHTTP(w, r)
+		case OAuthServiceRevokeOAuthConsentProcedure:
+			oAuthServiceRevokeOAuthConsentHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedOAuthServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedOAuthServiceHandler struct{}
+
+func (UnimplementedOAuthServiceHandler) ListOAuthClients(context.Context, *connect.Request[v1.ListOAuthClientsRequest]) (*connect.Response[v1.ListOAuthClientsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.OAuthService.ListOAuthClients is not implemented"))
+}
+
+func (UnimplementedOAuthServiceHandler) GetOAuthClient(context.Context, *connect.Request[v1.GetOAuthClientRequest]) (*connect.Response[v1.GetOAuthClientResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.OAuthService.GetOAuthClient is not implemented"))
+}
+
+func (UnimplementedOAuthServiceHandler) CreateOAuthClient(context.Context, *connect.Request[v1.CreateOAuthClientRequest]) (*connect.Response[v1.CreateOAuthClientResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.OAuthService.CreateOAuthClient is not implemented"))
+}
+
+func (UnimplementedOAuthServiceHandler) UpdateOAuthClient(context.Context, *connect.Request[v1.UpdateOAuthClientRequest]) (*connect.Response[v1.UpdateOAuthClientResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.OAuthService.UpdateOAuthClient is not implemented"))
+}
+
+func (UnimplementedOAuthServiceHandler) RotateOAuthClientSecret(context.Context, *connect.Request[v1.RotateOAuthClientSecretRequest]) (*connect.Response[v1.RotateOAuthClientSecretResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.OAuthService.RotateOAuthClientSecret is not implemented"))
+}
+
+func (UnimplementedOAuthServiceHandler) DeleteOAuthClient(context.Context, *connect.Request[v1.DeleteOAuthClientRequest]) (*connect.Response[v1.DeleteOAuthClientResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.OAuthService.DeleteOAuthClient is not implemented"))
+}
+
+func (UnimplementedOAuthServiceHandler) GetOAuthAuthorization(context.Context, *connect.Request[v1.GetOAuthAuthorizationRequest]) (*connect.Response[v1.GetOAuthAuthorizationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.OAuthService.GetOAuthAuthorization is not implemented"))
+}
+
+func (UnimplementedOAuthServiceHandler) ApproveOAuthAuthorization(context.Context, *connect.Request[v1.ApproveOAuthAuthorizationRequest]) (*connect.Response[v1.ApproveOAuthAuthorizationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.OAuthService.ApproveOAuthAuthorization is not implemented"))
+}
+
+func (UnimplementedOAuthServiceHandler) ListOAuthConsents(context.Context, *connect.Request[v1.ListOAuthConsentsRequest]) (*connect.Response[v1.ListOAuthConsentsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.OAuthService.ListOAuthConsents is not implemented"))
+}
+
+func (UnimplementedOAuthServiceHandler) RevokeOAuthConsent(context.Context, *connect.Request[v1.RevokeOAuthConsentRequest]) (*connect.Response[v1.RevokeOAuthConsentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.OAuthService.RevokeOAuthConsent is not implemented"))
+}
@@ -0,0 +1,319 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: api/v1/role_service.proto
+
+package apiv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/pixb/go-server/proto/gen/api/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// RoleServiceName is the fully-qualified name of the RoleService service.
+	RoleServiceName = "goserver.api.v1.RoleService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// RoleServiceListPermissionsProcedure is the fully-qualified name of the RoleService's
+	// ListPermissions RPC.
+	RoleServiceListPermissionsProcedure = "/goserver.api.v1.RoleService/ListPermissions"
+	// RoleServiceListRolesProcedure is the fully-qualified name of the RoleService's ListRoles RPC.
+	RoleServiceListRolesProcedure = "/goserver.api.v1.RoleService/ListRoles"
+	// RoleServiceGetRoleProcedure is the fully-qualified name of the RoleService's GetRole RPC.
+	RoleServiceGetRoleProcedure = "/goserver.api.v1.RoleService/GetRole"
+	// RoleServiceCreateRoleProcedure is the fully-qualified name of the RoleService's CreateRole RPC.
+	RoleServiceCreateRoleProcedure = "/goserver.api.v1.RoleService/CreateRole"
+	// RoleServiceUpdateRoleProcedure is the fully-qualified name of the RoleService's UpdateRole RPC.
+	RoleServiceUpdateRoleProcedure = "/goserver.api.v1.RoleService/UpdateRole"
+	// RoleServiceDeleteRoleProcedure is the fully-qualified name of the RoleService's DeleteRole RPC.
+	RoleServiceDeleteRoleProcedure = "/goserver.api.v1.RoleService/DeleteRole"
+	// RoleServiceListUserRolesProcedure is the fully-qualified name of the RoleService's ListUserRoles
+	// RPC.
+	RoleServiceListUserRolesProcedure = "/goserver.api.v1.RoleService/ListUserRoles"
+	// RoleServiceSetUserRolesProcedure is the fully-qualified name of the RoleService's SetUserRoles
+	// RPC.
+	RoleServiceSetUserRolesProcedure = "/goserver.api.v1.RoleService/SetUserRoles"
+)
+
+// RoleServiceClient is a client for the goserver.api.v1.RoleService service.
+type RoleServiceClient interface {
+	// Lists every permission a role can grant.
+	ListPermissions(context.Context, *connect.Request[v1.ListPermissionsRequest]) (*connect.Response[v1.ListPermissionsResponse], error)
+	ListRoles(context.Context, *connect.Request[v1.ListRolesRequest]) (*connect.Response[v1.ListRolesResponse], error)
+	GetRole(context.Context, *connect.Request[v1.GetRoleRequest]) (*connect.Response[v1.GetRoleResponse], error)
+	// Creates a custom role.
+	CreateRole(context.Context, *connect.Request[v1.CreateRoleRequest]) (*connect.Response[v1.CreateRoleResponse], error)
+	// Updates the fields of a custom role listed in update_mask. Built-in roles cannot be changed.
+	UpdateRole(context.Context, *connect.Request[v1.UpdateRoleRequest]) (*connect.Response[v1.UpdateRoleResponse], error)
+	// Deletes a custom role and unassigns it from its users. Built-in roles cannot be deleted.
+	DeleteRole(context.Context, *connect.Request[v1.DeleteRoleRequest]) (*connect.Response[v1.DeleteRoleResponse], error)
+	// Lists the custom roles assigned to a user and the permissions they end up with.
+	ListUserRoles(context.Context, *connect.Request[v1.ListUserRolesRequest]) (*connect.Response[v1.ListUserRolesResponse], error)
+	// Replaces the custom roles assigned to a user.
+	SetUserRoles(context.Context, *connect.Request[v1.SetUserRolesRequest]) (*connect.Response[v1.SetUserRolesResponse], error)
+}
+
+// NewRoleServiceClient constructs a client for the goserver.api.v1.RoleService service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewRoleServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) RoleServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	roleServiceMethods := v1.File_api_v1_role_service_proto.Services().ByName("RoleService").Methods()
+	return &roleServiceClient{
+		listPermissions: connect.NewClient[v1.ListPermissionsRequest, v1.ListPermissionsResponse](
+			httpClient,
+			baseURL+RoleServiceListPermissionsProcedure,
+			connect.WithSchema(roleServiceMethods.ByName("ListPermissions")),
+			connect.WithClientOptions(opts...),
+		),
+		listRoles: connect.NewClient[v1.ListRolesRequest, v1.ListRolesResponse](
+			httpClient,
+			baseURL+RoleServiceListRolesProcedure,
+			connect.WithSchema(roleServiceMethods.ByName("ListRoles")),
+			connect.WithClientOptions(opts...),
+		),
+		getRole: connect.NewClient[v1.GetRoleRequest, v1.GetRoleResponse](
+			httpClient,
+			baseURL+RoleServiceGetRoleProcedure,
+			connect.WithSchema(roleServiceMethods.ByName("GetRole")),
+			connect.WithClientOptions(opts...),
+		),
+		createRole: connect.NewClient[v1.CreateRoleRequest, v1.CreateRoleResponse](
+			httpClient,
+			baseURL+RoleServiceCreateRoleProcedure,
+			connect.WithSchema(roleServiceMethods.ByName("CreateRole")),
+			connect.WithClientOptions(opts...),
+		),
+		updateRole: connect.NewClient[v1.UpdateRoleRequest, v1.UpdateRoleResponse](
+			httpClient,
+			baseURL+RoleServiceUpdateRoleProcedure,
+			connect.WithSchema(roleServiceMethods.ByName("UpdateRole")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteRole: connect.NewClient[v1.DeleteRoleRequest, v1.DeleteRoleResponse](
+			httpClient,
+			baseURL+RoleServiceDeleteRoleProcedure,
+			connect.WithSchema(roleServiceMethods.ByName("DeleteRole")),
+			connect.WithClientOptions(opts...),
+		),
+		listUserRoles: connect.NewClient[v1.ListUserRolesRequest, v1.ListUserRolesResponse](
+			httpClient,
+			baseURL+RoleServiceListUserRolesProcedure,
+			connect.WithSchema(roleServiceMethods.ByName("ListUserRoles")),
+			connect.WithClientOptions(opts...),
+		),
+		setUserRoles: connect.NewClient[v1.SetUserRolesRequest, v1.SetUserRolesResponse](
+			httpClient,
+			baseURL+RoleServiceSetUserRolesProcedure,
+			connect.WithSchema(roleServiceMethods.ByName("SetUserRoles")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// roleServiceClient implements RoleServiceClient.
+type roleServiceClient struct {
+	listPermissions *connect.Client[v1.ListPermissionsRequest, v1.ListPermissionsResponse]
+	listRoles       *connect.Client[v1.ListRolesRequest, v1.ListRolesResponse]
+	getRole         *connect.Client[v1.GetRoleRequest, v1.GetRoleResponse]
+	createRole      *connect.Client[v1.CreateRoleRequest, v1.CreateRoleResponse]
+	updateRole      *connect.Client[v1.UpdateRoleRequest, v1.UpdateRoleResponse]
+	deleteRole      *connect.Client[v1.DeleteRoleRequest, v1.DeleteRoleResponse]
+	listUserRoles   *connect.Client[v1.ListUserRolesRequest, v1.ListUserRolesResponse]
+	setUserRoles    *connect.Client[v1.SetUserRolesRequest, v1.SetUserRolesResponse]
+}
+
+// ListPermissions calls goserver.api.v1.RoleService.ListPermissions.
+func (c *roleServiceClient) ListPermissions(ctx context.Context, req *connect.Request[v1.ListPermissionsRequest]) (*connect.Response[v1.ListPermissionsResponse], error) {
+	return c.listPermissions.CallUnary(ctx, req)
+}
+
+// ListRoles calls goserver.api.v1.RoleService.ListRoles.
+func (c *roleServiceClient) ListRoles(ctx context.Context, req *connect.Request[v1.ListRolesRequest]) (*connect.Response[v1.ListRolesResponse], error) {
+	return c.listRoles.CallUnary(ctx, req)
+}
+
+// GetRole calls goserver.api.v1.RoleService.GetRole.
+func (c *roleServiceClient) GetRole(ctx context.Context, req *connect.Request[v1.GetRoleRequest]) (*connect.Response[v1.GetRoleResponse], error) {
+	return c.getRole.CallUnary(ctx, req)
+}
+
+// CreateRole calls goserver.api.v1.RoleService.CreateRole.
+func (c *roleServiceClient) CreateRole(ctx context.Context, req *connect.Request[v1.CreateRoleRequest]) (*connect.Response[v1.CreateRoleResponse], error) {
+	return c.createRole.CallUnary(ctx, req)
+}
+
+// UpdateRole calls goserver.api.v1.RoleService.UpdateRole.
+func (c *roleServiceClient) UpdateRole(ctx context.Context, req *connect.Request[v1.UpdateRoleRequest]) (*connect.Response[v1.UpdateRoleResponse], error) {
+	return c.updateRole.CallUnary(ctx, req)
+}
+
+// DeleteRole calls goserver.api.v1.RoleService.DeleteRole.
+func (c *roleServiceClient) DeleteRole(ctx context.Context, req *connect.Request[v1.DeleteRoleRequest]) (*connect.Response[v1.DeleteRoleResponse], error) {
+	return c.deleteRole.CallUnary(ctx, req)
+}
+
+// ListUserRoles calls goserver.api.v1.RoleService.ListUserRoles.
+func (c *roleServiceClient) ListUserRoles(ctx context.Context, req *connect.Request[v1.ListUserRolesRequest]) (*connect.Response[v1.ListUserRolesResponse], error) {
+	return c.listUserRoles.CallUnary(ctx, req)
+}
+
+// SetUserRoles calls goserver.api.v1.RoleService.SetUserRoles.
+func (c *roleServiceClient) SetUserRoles(ctx context.Context, req *connect.Request[v1.SetUserRolesRequest]) (*connect.Response[v1.SetUserRolesResponse], error) {
+	return c.setUserRoles.CallUnary(ctx, req)
+}
+
+// RoleServiceHandler is an implementation of the goserver.api.v1.RoleService service.
+type RoleServiceHandler interface {
+	// Lists every permission a role can grant.
+	ListPermissions(context.Context, *connect.Request[v1.ListPermissionsRequest]) (*connect.Response[v1.ListPermissionsResponse], error)
+	ListRoles(context.Context, *connect.Request[v1.ListRolesRequest]) (*connect.Response[v1.ListRolesResponse], error)
+	GetRole(context.Context, *connect.Request[v1.GetRoleRequest]) (*connect.Response[v1.GetRoleResponse], error)
+	// Creates a custom role.
+	CreateRole(context.Context, *connect.Request[v1.CreateRoleRequest]) (*connect.Response[v1.CreateRoleResponse], error)
+	// Updates the fields of a custom role listed in update_mask. Built-in roles cannot be changed.
+	UpdateRole(context.Context, *connect.Request[v1.UpdateRoleRequest]) (*connect.Response[v1.UpdateRoleResponse], error)
+	// Deletes a custom role and unassigns it from its users. Built-in roles cannot be deleted.
+	DeleteRole(context.Context, *connect.Request[v1.DeleteRoleRequest]) (*connect.Response[v1.DeleteRoleResponse], error)
+	// Lists the custom roles assigned to a user and the permissions they end up with.
+	ListUserRoles(context.Context, *connect.Request[v1.ListUserRolesRequest]) (*connect.Response[v1.ListUserRolesResponse], error)
+	// Replaces the custom roles assigned to a user.
+	SetUserRoles(context.Context, *connect.Request[v1.SetUserRolesRequest]) (*connect.Response[v1.SetUserRolesResponse], error)
+}
+
+// NewRoleServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewRoleServiceHandler(svc RoleServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	roleServiceMethods := v1.File_api_v1_role_service_proto.Services().ByName("RoleService").Methods()
+	roleServiceListPermissionsHandler := connect.NewUnaryHandler(
+		RoleServiceListPermissionsProcedure,
+		svc.ListPermissions,
+		connect.WithSchema(roleServiceMethods.ByName("ListPermissions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	roleServiceListRolesHandler := connect.NewUnaryHandler(
+		RoleServiceListRolesProcedure,
+		svc.ListRoles,
+		connect.WithSchema(roleServiceMethods.ByName("ListRoles")),
+		connect.WithHandlerOptions(opts...),
+	)
+	roleServiceGetRoleHandler := connect.NewUnaryHandler(
+		RoleServiceGetRoleProcedure,
+		svc.GetRole,
+		connect.WithSchema(roleServiceMethods.ByName("GetRole")),
+		connect.WithHandlerOptions(opts...),
+	)
+	roleServiceCreateRoleHandler := connect.NewUnaryHandler(
+		RoleServiceCreateRoleProcedure,
+		svc.CreateRole,
+		connect.WithSchema(roleServiceMethods.ByName("CreateRole")),
+		connect.WithHandlerOptions(opts...),
+	)
+	roleServiceUpdateRoleHandler := connect.NewUnaryHandler(
+		RoleServiceUpdateRoleProcedure,
+		svc.UpdateRole,
+		connect.WithSchema(roleServiceMethods.ByName("UpdateRole")),
+		connect.WithHandlerOptions(opts...),
+	)
+	roleServiceDeleteRoleHandler := connect.NewUnaryHandler(
+		RoleServiceDeleteRoleProcedure,
+		svc.DeleteRole,
+		connect.WithSchema(roleServiceMethods.ByName("DeleteRole")),
+		connect.WithHandlerOptions(opts...),
+	)
+	roleServiceListUserRolesHandler := connect.NewUnaryHandler(
+		RoleServiceListUserRolesProcedure,
+		svc.ListUserRoles,
+		connect.WithSchema(roleServiceMethods.ByName("ListUserRoles")),
+		connect.WithHandlerOptions(opts...),
+	)
+	roleServiceSetUserRolesHandler := connect.NewUnaryHandler(
+		RoleServiceSetUserRolesProcedure,
+		svc.SetUserRoles,
+		connect.WithSchema(roleServiceMethods.ByName("SetUserRoles")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/goserver.api.v1.RoleService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case RoleServiceListPermissionsProcedure:
+			roleServiceListPermissionsHandler.ServeHTTP(w, r)
+		case RoleServiceListRolesProcedure:
+			roleServiceListRolesHandler.ServeHTTP(w, r)
+		case RoleServiceGetRoleProcedure:
+			roleServiceGetRoleHandler.ServeHTTP(w, r)
+		case RoleServiceCreateRoleProcedure:
+			roleServiceCreateRoleHandler.ServeHTTP(w, r)
+		case RoleServiceUpdateRoleProcedure:
+			roleServiceUpdateRoleHandler.ServeHTTP(w, r)
+		case RoleServiceDeleteRoleProcedure:
+			roleServiceDeleteRoleHandler.ServeHTTP(w, r)
+		case RoleServiceListUserRolesProcedure:
+			roleServiceListUserRolesHandler.ServeHTTP(w, r)
+		case RoleServiceSetUserRolesProcedure:
+			roleServiceSetUserRolesHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedRoleServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedRoleServiceHandler struct{}
+
+func (UnimplementedRoleServiceHandler) ListPermissions(context.Context, *connect.Request[v1.ListPermissionsRequest]) (*connect.Response[v1.ListPermissionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.RoleService.ListPermissions is not implemented"))
+}
+
+func (UnimplementedRoleServiceHandler) ListRoles(context.Context, *connect.Request[v1.ListRolesRequest]) (*connect.Response[v1.ListRolesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.RoleService.ListRoles is not implemented"))
+}
+
+func (UnimplementedRoleServiceHandler) GetRole(context.Context, *connect.Request[v1.GetRoleRequest]) (*connect.Response[v1.GetRoleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.RoleService.GetRole is not implemented"))
+}
+
+func (UnimplementedRoleServiceHandler) CreateRole(context.Context, *connect.Request[v1.CreateRoleRequest]) (*connect.Response[v1.CreateRoleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.RoleService.CreateRole is not implemented"))
+}
+
+func (UnimplementedRoleServiceHandler) UpdateRole(context.Context, *connect.Request[v1.UpdateRoleRequest]) (*connect.Response[v1.UpdateRoleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.RoleService.UpdateRole is not implemented"))
+}
+
+func (UnimplementedRoleServiceHandler) DeleteRole(context.Context, *connect.Request[v1.DeleteRoleRequest]) (*connect.Response[v1.DeleteRoleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.RoleService.DeleteRole is not implemented"))
+}
+
+func (UnimplementedRoleServiceHandler) ListUserRoles(context.Context, *connect.Request[v1.ListUserRolesRequest]) (*connect.Response[v1.ListUserRolesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.RoleService.ListUserRoles is not implemented"))
+}
+
+func (UnimplementedRoleServiceHandler) SetUserRoles(context.Context, *connect.Request[v1.SetUserRolesRequest]) (*connect.Response[v1.SetUserRolesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.RoleService.SetUserRoles is not implemented"))
+}
@@ -45,6 +45,37 @@ const (
 	// UserServiceChangePasswordProcedure is the fully-qualified name of the UserService's
 	// ChangePassword RPC.
 	UserServiceChangePasswordProcedure = "/goserver.api.v1.UserService/ChangePassword"
+	// UserServiceCreatePersonalAccessTokenProcedure is the fully-qualified name of the UserService's
+	// CreatePersonalAccessToken RPC.
+	UserServiceCreatePersonalAccessTokenProcedure = "/goserver.api.v1.UserService/CreatePersonalAccessToken"
+	// UserServiceListPersonalAccessTokensProcedure is the fully-qualified name of the UserService's
+	// ListPersonalAccessTokens RPC.
+	UserServiceListPersonalAccessTokensProcedure = "/goserver.api.v1.UserService/ListPersonalAccessTokens"
+	// UserServiceDeletePersonalAccessTokenProcedure is the fully-qualified name of the UserService's
+	// DeletePersonalAccessToken RPC.
+	UserServiceDeletePersonalAccessTokenProcedure = "/goserver.api.v1.UserService/DeletePersonalAccessToken"
+	// UserServiceListSessionsProcedure is the fully-qualified name of the UserService's ListSessions
+	// RPC.
+	UserServiceListSessionsProcedure = "/goserver.api.v1.UserService/ListSessions"
+	// UserServiceRevokeSessionProcedure is the fully-qualified name of the UserService's RevokeSession
+	// RPC.
+	UserServiceRevokeSessionProcedure = "/goserver.api.v1.UserService/RevokeSession"
+	// UserServiceRevokeAllOtherSessionsProcedure is the fully-qualified name of the UserService's
+	// RevokeAllOtherSessions RPC.
+	UserServiceRevokeAllOtherSessionsProcedure = "/goserver.api.v1.UserService/RevokeAllOtherSessions"
+	// UserServiceEnrollTOTPProcedure is the fully-qualified name of the UserService's EnrollTOTP RPC.
+	UserServiceEnrollTOTPProcedure = "/goserver.api.v1.UserService/EnrollTOTP"
+	// UserServiceConfirmTOTPProcedure is the fully-qualified name of the UserService's ConfirmTOTP RPC.
+	UserServiceConfirmTOTPProcedure = "/goserver.api.v1.UserService/ConfirmTOTP"
+	// UserServiceDisableTOTPProcedure is the fully-qualified name of the UserService's DisableTOTP RPC.
+	UserServiceDisableTOTPProcedure = "/goserver.api.v1.UserService/DisableTOTP"
+	// UserServiceUnlockUserProcedure is the fully-qualified name of the UserService's UnlockUser RPC.
+	UserServiceUnlockUserProcedure = "/goserver.api.v1.UserService/UnlockUser"
+	// UserServiceVerifyEmailProcedure is the fully-qualified name of the UserService's VerifyEmail RPC.
+	UserServiceVerifyEmailProcedure = "/goserver.api.v1.UserService/VerifyEmail"
+	// UserServiceResendVerificationProcedure is the fully-qualified name of the UserService's
+	// ResendVerification RPC.
+	UserServiceResendVerificationProcedure = "/goserver.api.v1.UserService/ResendVerification"
 )
 
 // UserServiceClient is a client for the goserver.api.v1.UserService service.
@@ -57,6 +88,30 @@ type UserServiceClient interface {
 	UpdateUserProfile(context.Context, *connect.Request[v1.UpdateUserProfileRequest]) (*connect.Response[v1.UpdateUserProfileResponse], error)
 	// 修改密码
 	ChangePassword(context.Context, *connect.Request[v1.ChangePasswordRequest]) (*connect.Response[v1.ChangePasswordResponse], error)
+	// 创建个人访问令牌
+	CreatePersonalAccessToken(context.Context, *connect.Request[v1.CreatePersonalAccessTokenRequest]) (*connect.Response[v1.CreatePersonalAccessTokenResponse], error)
+	// 获取个人访问令牌列表
+	ListPersonalAccessTokens(context.Context, *connect.Request[v1.ListPersonalAccessTokensRequest]) (*connect.Response[v1.ListPersonalAccessTokensResponse], error)
+	// 删除个人访问令牌
+	DeletePersonalAccessToken(context.Context, *connect.Request[v1.DeletePersonalAccessTokenRequest]) (*connect.Response[v1.DeletePersonalAccessTokenResponse], error)
+	// 获取当前用户的登录会话列表
+	ListSessions(context.Context, *connect.Request[v1.ListSessionsRequest]) (*connect.Response[v1.ListSessionsResponse], error)
+	// 注销指定会话
+	RevokeSession(context.Context, *connect.Request[v1.RevokeSessionRequest]) (*connect.Response[v1.RevokeSessionResponse], error)
+	// 注销除当前会话外的所有会话
+	RevokeAllOtherSessions(context.Context, *connect.Request[v1.RevokeAllOtherSessionsRequest]) (*connect.Response[v1.RevokeAllOtherSessionsResponse], error)
+	// 开始绑定 TOTP 两步验证，返回密钥和恢复码，需调用 ConfirmTOTP 确认后生效
+	EnrollTOTP(context.Context, *connect.Request[v1.EnrollTOTPRequest]) (*connect.Response[v1.EnrollTOTPResponse], error)
+	// 使用验证器生成的验证码确认绑定 TOTP 两步验证
+	ConfirmTOTP(context.Context, *connect.Request[v1.ConfirmTOTPRequest]) (*connect.Response[v1.ConfirmTOTPResponse], error)
+	// 关闭 TOTP 两步验证
+	DisableTOTP(context.Context, *connect.Request[v1.DisableTOTPRequest]) (*connect.Response[v1.DisableTOTPResponse], error)
+	// 解除因多次登录失败而被锁定的账户，需要 users.write 权限
+	UnlockUser(context.Context, *connect.Request[v1.UnlockUserRequest]) (*connect.Response[v1.UnlockUserResponse], error)
+	// 使用邮件中的令牌验证邮箱，邮箱变更在验证后才生效
+	VerifyEmail(context.Context, *connect.Request[v1.VerifyEmailRequest]) (*connect.Response[v1.VerifyEmailResponse], error)
+	// 重新发送验证邮件，无论邮箱是否存在都返回相同结果
+	ResendVerification(context.Context, *connect.Request[v1.ResendVerificationRequest]) (*connect.Response[v1.ResendVerificationResponse], error)
 }
 
 // NewUserServiceClient constructs a client for the goserver.api.v1.UserService service. By default,
@@ -94,15 +149,99 @@ func NewUserServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(userServiceMethods.ByName("ChangePassword")),
 			connect.WithClientOptions(opts...),
 		),
+		createPersonalAccessToken: connect.NewClient[v1.CreatePersonalAccessTokenRequest, v1.CreatePersonalAccessTokenResponse](
+			httpClient,
+			baseURL+UserServiceCreatePersonalAccessTokenProcedure,
+			connect.WithSchema(userServiceMethods.ByName("CreatePersonalAccessToken")),
+			connect.WithClientOptions(opts...),
+		),
+		listPersonalAccessTokens: connect.NewClient[v1.ListPersonalAccessTokensRequest, v1.ListPersonalAccessTokensResponse](
+			httpClient,
+			baseURL+UserServiceListPersonalAccessTokensProcedure,
+			connect.WithSchema(userServiceMethods.ByName("ListPersonalAccessTokens")),
+			connect.WithClientOptions(opts...),
+		),
+		deletePersonalAccessToken: connect.NewClient[v1.DeletePersonalAccessTokenRequest, v1.DeletePersonalAccessTokenResponse](
+			httpClient,
+			baseURL+UserServiceDeletePersonalAccessTokenProcedure,
+			connect.WithSchema(userServiceMethods.ByName("DeletePersonalAccessToken")),
+			connect.WithClientOptions(opts...),
+		),
+		listSessions: connect.NewClient[v1.ListSessionsRequest, v1.ListSessionsResponse](
+			httpClient,
+			baseURL+UserServiceListSessionsProcedure,
+			connect.WithSchema(userServiceMethods.ByName("ListSessions")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeSession: connect.NewClient[v1.RevokeSessionRequest, v1.RevokeSessionResponse](
+			httpClient,
+			baseURL+UserServiceRevokeSessionProcedure,
+			connect.WithSchema(userServiceMethods.ByName("RevokeSession")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeAllOtherSessions: connect.NewClient[v1.RevokeAllOtherSessionsRequest, v1.RevokeAllOtherSessionsResponse](
+			httpClient,
+			baseURL+UserServiceRevokeAllOtherSessionsProcedure,
+			connect.WithSchema(userServiceMethods.ByName("RevokeAllOtherSessions")),
+			connect.WithClientOptions(opts...),
+		),
+		enrollTOTP: connect.NewClient[v1.EnrollTOTPRequest, v1.EnrollTOTPResponse](
+			httpClient,
+			baseURL+UserServiceEnrollTOTPProcedure,
+			connect.WithSchema(userServiceMethods.ByName("EnrollTOTP")),
+			connect.WithClientOptions(opts...),
+		),
+		confirmTOTP: connect.NewClient[v1.ConfirmTOTPRequest, v1.ConfirmTOTPResponse](
+			httpClient,
+			baseURL+UserServiceConfirmTOTPProcedure,
+			connect.WithSchema(userServiceMethods.ByName("ConfirmTOTP")),
+			connect.WithClientOptions(opts...),
+		),
+		disableTOTP: connect.NewClient[v1.DisableTOTPRequest, v1.DisableTOTPResponse](
+			httpClient,
+			baseURL+UserServiceDisableTOTPProcedure,
+			connect.WithSchema(userServiceMethods.ByName("DisableTOTP")),
+			connect.WithClientOptions(opts...),
+		),
+		unlockUser: connect.NewClient[v1.UnlockUserRequest, v1.UnlockUserResponse](
+			httpClient,
+			baseURL+UserServiceUnlockUserProcedure,
+			connect.WithSchema(userServiceMethods.ByName("UnlockUser")),
+			connect.WithClientOptions(opts...),
+		),
+		verifyEmail: connect.NewClient[v1.VerifyEmailRequest, v1.VerifyEmailResponse](
+			httpClient,
+			baseURL+UserServiceVerifyEmailProcedure,
+			connect.WithSchema(userServiceMethods.ByName("VerifyEmail")),
+			connect.WithClientOptions(opts...),
+		),
+		resendVerification: connect.NewClient[v1.ResendVerificationRequest, v1.ResendVerificationResponse](
+			httpClient,
+			baseURL+UserServiceResendVerificationProcedure,
+			connect.WithSchema(userServiceMethods.ByName("ResendVerification")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // userServiceClient implements UserServiceClient.
 type userServiceClient struct {
-	registerUser      *connect.Client[v1.RegisterUserRequest, v1.RegisterUserResponse]
-	getUserProfile    *connect.Client[v1.GetUserProfileRequest, v1.GetUserProfileResponse]
-	updateUserProfile *connect.Client[v1.UpdateUserProfileRequest, v1.UpdateUserProfileResponse]
-	changePassword    *connect.Client[v1.ChangePasswordRequest, v1.ChangePasswordResponse]
+	registerUser              *connect.Client[v1.RegisterUserRequest, v1.RegisterUserResponse]
+	getUserProfile            *connect.Client[v1.GetUserProfileRequest, v1.GetUserProfileResponse]
+	updateUserProfile         *connect.Client[v1.UpdateUserProfileRequest, v1.UpdateUserProfileResponse]
+	changePassword            *connect.Client[v1.ChangePasswordRequest, v1.ChangePasswordResponse]
+	createPersonalAccessToken *connect.Client[v1.CreatePersonalAccessTokenRequest, v1.CreatePersonalAccessTokenResponse]
+	listPersonalAccessTokens  *connect.Client[v1.ListPersonalAccessTokensRequest, v1.ListPersonalAccessTokensResponse]
+	deletePersonalAccessToken *connect.Client[v1.DeletePersonalAccessTokenRequest, v1.DeletePersonalAccessTokenResponse]
+	listSessions              *connect.Client[v1.ListSessionsRequest, v1.ListSessionsResponse]
+	revokeSession             *connect.Client[v1.RevokeSessionRequest, v1.RevokeSessionResponse]
+	revokeAllOtherSessions    *connect.Client[v1.RevokeAllOtherSessionsRequest, v1.RevokeAllOtherSessionsResponse]
+	enrollTOTP                *connect.Client[v1.EnrollTOTPRequest, v1.EnrollTOTPResponse]
+	confirmTOTP               *connect.Client[v1.ConfirmTOTPRequest, v1.ConfirmTOTPResponse]
+	disableTOTP               *connect.Client[v1.DisableTOTPRequest, v1.DisableTOTPResponse]
+	unlockUser                *connect.Client[v1.UnlockUserRequest, v1.UnlockUserResponse]
+	verifyEmail               *connect.Client[v1.VerifyEmailRequest, v1.VerifyEmailResponse]
+	resendVerification        *connect.Client[v1.ResendVerificationRequest, v1.ResendVerificationResponse]
 }
 
 // RegisterUser calls goserver.api.v1.UserService.RegisterUser.
@@ -125,6 +264,66 @@ func (c *userServiceClient) ChangePassword(ctx context.Context, req *connect.Req
 	return c.changePassword.CallUnary(ctx, req)
 }
 
+// CreatePersonalAccessToken calls goserver.api.v1.UserService.CreatePersonalAccessToken.
+func (c *userServiceClient) CreatePersonalAccessToken(ctx context.Context, req *connect.Request[v1.CreatePersonalAccessTokenRequest]) (*connect.Response[v1.CreatePersonalAccessTokenResponse], error) {
+	return c.createPersonalAccessToken.CallUnary(ctx, req)
+}
+
+// ListPersonalAccessTokens calls goserver.api.v1.UserService.ListPersonalAccessTokens.
+func (c *userServiceClient) ListPersonalAccessTokens(ctx context.Context, req *connect.Request[v1.ListPersonalAccessTokensRequest]) (*connect.Response[v1.ListPersonalAccessTokensResponse], error) {
+	return c.listPersonalAccessTokens.CallUnary(ctx, req)
+}
+
+// DeletePersonalAccessToken calls goserver.api.v1.UserService.DeletePersonalAccessToken.
+func (c *userServiceClient) DeletePersonalAccessToken(ctx context.Context, req *connect.Request[v1.DeletePersonalAccessTokenRequest]) (*connect.Response[v1.DeletePersonalAccessTokenResponse], error) {
+	return c.deletePersonalAccessToken.CallUnary(ctx, req)
+}
+
+// ListSessions calls goserver.api.v1.UserService.ListSessions.
+func (c *userServiceClient) ListSessions(ctx context.Context, req *connect.Request[v1.ListSessionsRequest]) (*connect.Response[v1.ListSessionsResponse], error) {
+	return c.listSessions.CallUnary(ctx, req)
+}
+
+// RevokeSession calls goserver.api.v1.UserService.RevokeSession.
+func (c *userServiceClient) RevokeSession(ctx context.Context, req *connect.Request[v1.RevokeSessionRequest]) (*connect.Response[v1.RevokeSessionResponse], error) {
+	return c.revokeSession.CallUnary(ctx, req)
+}
+
+// RevokeAllOtherSessions calls goserver.api.v1.UserService.RevokeAllOtherSessions.
+func (c *userServiceClient) RevokeAllOtherSessions(ctx context.Context, req *connect.Request[v1.RevokeAllOtherSessionsRequest]) (*connect.Response[v1.RevokeAllOtherSessionsResponse], error) {
+	return c.revokeAllOtherSessions.CallUnary(ctx, req)
+}
+
+// EnrollTOTP calls goserver.api.v1.UserService.EnrollTOTP.
+func (c *userServiceClient) EnrollTOTP(ctx context.Context, req *connect.Request[v1.EnrollTOTPRequest]) (*connect.Response[v1.EnrollTOTPResponse], error) {
+	return c.enrollTOTP.CallUnary(ctx, req)
+}
+
+// ConfirmTOTP calls goserver.api.v1.UserService.ConfirmTOTP.
+func (c *userServiceClient) ConfirmTOTP(ctx context.Context, req *connect.Request[v1.ConfirmTOTPRequest]) (*connect.Response[v1.ConfirmTOTPResponse], error) {
+	return c.confirmTOTP.CallUnary(ctx, req)
+}
+
+// DisableTOTP calls goserver.api.v1.UserService.DisableTOTP.
+func (c *userServiceClient) DisableTOTP(ctx context.Context, req *connect.Request[v1.DisableTOTPRequest]) (*connect.Response[v1.DisableTOTPResponse], error) {
+	return c.disableTOTP.CallUnary(ctx, req)
+}
+
+// UnlockUser calls goserver.api.v1.UserService.UnlockUser.
+func (c *userServiceClient) UnlockUser(ctx context.Context, req *connect.Request[v1.UnlockUserRequest]) (*connect.Response[v1.UnlockUserResponse], error) {
+	return c.unlockUser.CallUnary(ctx, req)
+}
+
+// VerifyEmail calls goserver.api.v1.UserService.VerifyEmail.
+func (c *userServiceClient) VerifyEmail(ctx context.Context, req *connect.Request[v1.VerifyEmailRequest]) (*connect.Response[v1.VerifyEmailResponse], error) {
+	return c.verifyEmail.CallUnary(ctx, req)
+}
+
+// ResendVerification calls goserver.api.v1.UserService.ResendVerification.
+func (c *userServiceClient) ResendVerification(ctx context.Context, req *connect.Request[v1.ResendVerificationRequest]) (*connect.Response[v1.ResendVerificationResponse], error) {
+	return c.resendVerification.CallUnary(ctx, req)
+}
+
 // UserServiceHandler is an implementation of the goserver.api.v1.UserService service.
 type UserServiceHandler interface {
 	// 注册用户
@@ -135,6 +334,30 @@ type UserServiceHandler interface {
 	UpdateUserProfile(context.Context, *connect.Request[v1.UpdateUserProfileRequest]) (*connect.Response[v1.UpdateUserProfileResponse], error)
 	// 修改密码
 	ChangePassword(context.Context, *connect.Request[v1.ChangePasswordRequest]) (*connect.Response[v1.ChangePasswordResponse], error)
+	// 创建个人访问令牌
+	CreatePersonalAccessToken(context.Context, *connect.Request[v1.CreatePersonalAccessTokenRequest]) (*connect.Response[v1.CreatePersonalAccessTokenResponse], error)
+	// 获取个人访问令牌列表
+	ListPersonalAccessTokens(context.Context, *connect.Request[v1.ListPersonalAccessTokensRequest]) (*connect.Response[v1.ListPersonalAccessTokensResponse], error)
+	// 删除个人访问令牌
+	DeletePersonalAccessToken(context.Context, *connect.Request[v1.DeletePersonalAccessTokenRequest]) (*connect.Response[v1.DeletePersonalAccessTokenResponse], error)
+	// 获取当前用户的登录会话列表
+	ListSessions(context.Context, *connect.Request[v1.ListSessionsRequest]) (*connect.Response[v1.ListSessionsResponse], error)
+	// 注销指定会话
+	RevokeSession(context.Context, *connect.Request[v1.RevokeSessionRequest]) (*connect.Response[v1.RevokeSessionResponse], error)
+	// 注销除当前会话外的所有会话
+	RevokeAllOtherSessions(context.Context, *connect.Request[v1.RevokeAllOtherSessionsRequest]) (*connect.Response[v1.RevokeAllOtherSessionsResponse], error)
+	// 开始绑定 TOTP 两步验证，返回密钥和恢复码，需调用 ConfirmTOTP 确认后生效
+	EnrollTOTP(context.Context, *connect.Request[v1.EnrollTOTPRequest]) (*connect.Response[v1.EnrollTOTPResponse], error)
+	// 使用验证器生成的验证码确认绑定 TOTP 两步验证
+	ConfirmTOTP(context.Context, *connect.Request[v1.ConfirmTOTPRequest]) (*connect.Response[v1.ConfirmTOTPResponse], error)
+	// 关闭 TOTP 两步验证
+	DisableTOTP(context.Context, *connect.Request[v1.DisableTOTPRequest]) (*connect.Response[v1.DisableTOTPResponse], error)
+	// 解除因多次登录失败而被锁定的账户，需要 users.write 权限
+	UnlockUser(context.Context, *connect.Request[v1.UnlockUserRequest]) (*connect.Response[v1.UnlockUserResponse], error)
+	// 使用邮件中的令牌验证邮箱，邮箱变更在验证后才生效
+	VerifyEmail(context.Context, *connect.Request[v1.VerifyEmailRequest]) (*connect.Response[v1.VerifyEmailResponse], error)
+	// 重新发送验证邮件，无论邮箱是否存在都返回相同结果
+	ResendVerification(context.Context, *connect.Request[v1.ResendVerificationRequest]) (*connect.Response[v1.ResendVerificationResponse], error)
 }
 
 // NewUserServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -168,6 +391,78 @@ func NewUserServiceHandler(svc UserServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(userServiceMethods.ByName("ChangePassword")),
 		connect.WithHandlerOptions(opts...),
 	)
+	userServiceCreatePersonalAccessTokenHandler := connect.NewUnaryHandler(
+		UserServiceCreatePersonalAccessTokenProcedure,
+		svc.CreatePersonalAccessToken,
+		connect.WithSchema(userServiceMethods.ByName("CreatePersonalAccessToken")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceListPersonalAccessTokensHandler := connect.NewUnaryHandler(
+		UserServiceListPersonalAccessTokensProcedure,
+		svc.ListPersonalAccessTokens,
+		connect.WithSchema(userServiceMethods.ByName("ListPersonalAccessTokens")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceDeletePersonalAccessTokenHandler := connect.NewUnaryHandler(
+		UserServiceDeletePersonalAccessTokenProcedure,
+		svc.DeletePersonalAccessToken,
+		connect.WithSchema(userServiceMethods.ByName("DeletePersonalAccessToken")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceListSessionsHandler := connect.NewUnaryHandler(
+		UserServiceListSessionsProcedure,
+		svc.ListSessions,
+		connect.WithSchema(userServiceMethods.ByName("ListSessions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceRevokeSessionHandler := connect.NewUnaryHandler(
+		UserServiceRevokeSessionProcedure,
+		svc.RevokeSession,
+		connect.WithSchema(userServiceMethods.ByName("RevokeSession")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceRevokeAllOtherSessionsHandler := connect.NewUnaryHandler(
+		UserServiceRevokeAllOtherSessionsProcedure,
+		svc.RevokeAllOtherSessions,
+		connect.WithSchema(userServiceMethods.ByName("RevokeAllOtherSessions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceEnrollTOTPHandler := connect.NewUnaryHandler(
+		UserServiceEnrollTOTPProcedure,
+		svc.EnrollTOTP,
+		connect.WithSchema(userServiceMethods.ByName("EnrollTOTP")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceConfirmTOTPHandler := connect.NewUnaryHandler(
+		UserServiceConfirmTOTPProcedure,
+		svc.ConfirmTOTP,
+		connect.WithSchema(userServiceMethods.ByName("ConfirmTOTP")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceDisableTOTPHandler := connect.NewUnaryHandler(
+		UserServiceDisableTOTPProcedure,
+		svc.DisableTOTP,
+		connect.WithSchema(userServiceMethods.ByName("DisableTOTP")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceUnlockUserHandler := connect.NewUnaryHandler(
+		UserServiceUnlockUserProcedure,
+		svc.UnlockUser,
+		connect.WithSchema(userServiceMethods.ByName("UnlockUser")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceVerifyEmailHandler := connect.NewUnaryHandler(
+		UserServiceVerifyEmailProcedure,
+		svc.VerifyEmail,
+		connect.WithSchema(userServiceMethods.ByName("VerifyEmail")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceResendVerificationHandler := connect.NewUnaryHandler(
+		UserServiceResendVerificationProcedure,
+		svc.ResendVerification,
+		connect.WithSchema(userServiceMethods.ByName("ResendVerification")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/goserver.api.v1.UserService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case UserServiceRegisterUserProcedure:
@@ -178,6 +473,30 @@ func NewUserServiceHandler(svc UserServiceHandler, opts ...connect.HandlerOption
 			userServiceUpdateUserProfileHandler.ServeHTTP(w, r)
 		case UserServiceChangePasswordProcedure:
 			userServiceChangePasswordHandler.ServeHTTP(w, r)
+		case UserServiceCreatePersonalAccessTokenProcedure:
+			userServiceCreatePersonalAccessTokenHandler.ServeHTTP(w, r)
+		case UserServiceListPersonalAccessTokensProcedure:
+			userServiceListPersonalAccessTokensHandler.ServeHTTP(w, r)
+		case UserServiceDeletePersonalAccessTokenProcedure:
+			userServiceDeletePersonalAccessTokenHandler.ServeHTTP(w, r)
+		case UserServiceListSessionsProcedure:
+			userServiceListSessionsHandler.ServeHTTP(w, r)
+		case UserServiceRevokeSessionProcedure:
+			userServiceRevokeSessionHandler.ServeHTTP(w, r)
+		case UserServiceRevokeAllOtherSessionsProcedure:
+			userServiceRevokeAllOtherSessionsHandler.ServeHTTP(w, r)
+		case UserServiceEnrollTOTPProcedure:
+			userServiceEnrollTOTPHandler.ServeHTTP(w, r)
+		case UserServiceConfirmTOTPProcedure:
+			userServiceConfirmTOTPHandler.ServeHTTP(w, r)
+		case UserServiceDisableTOTPProcedure:
+			userServiceDisableTOTPHandler.ServeHTTP(w, r)
+		case UserServiceUnlockUserProcedure:
+			userServiceUnlockUserHandler.ServeHTTP(w, r)
+		case UserServiceVerifyEmailProcedure:
+			userServiceVerifyEmailHandler.ServeHTTP(w, r)
+		case UserServiceResendVerificationProcedure:
+			userServiceResendVerificationHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -202,3 +521,51 @@ func (UnimplementedUserServiceHandler) UpdateUserProfile(context.Context, *conne
 func (UnimplementedUserServiceHandler) ChangePassword(context.Context, *connect.Request[v1.ChangePasswordRequest]) (*connect.Response[v1.ChangePasswordResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.UserService.ChangePassword is not implemented"))
 }
+
+func (UnimplementedUserServiceHandler) CreatePersonalAccessToken(context.Context, *connect.Request[v1.CreatePersonalAccessTokenRequest]) (*connect.Response[v1.CreatePersonalAccessTokenResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.UserService.CreatePersonalAccessToken is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) ListPersonalAccessTokens(context.Context, *connect.Request[v1.ListPersonalAccessTokensRequest]) (*connect.Response[v1.ListPersonalAccessTokensResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.UserService.ListPersonalAccessTokens is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) DeletePersonalAccessToken(context.Context, *connect.Request[v1.DeletePersonalAccessTokenRequest]) (*connect.Response[v1.DeletePersonalAccessTokenResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.UserService.DeletePersonalAccessToken is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) ListSessions(context.Context, *connect.Request[v1.ListSessionsRequest]) (*connect.Response[v1.ListSessionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.UserService.ListSessions is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) RevokeSession(context.Context, *connect.Request[v1.RevokeSessionRequest]) (*connect.Response[v1.RevokeSessionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.UserService.RevokeSession is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) RevokeAllOtherSessions(context.Context, *connect.Request[v1.RevokeAllOtherSessionsRequest]) (*connect.Response[v1.RevokeAllOtherSessionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.UserService.RevokeAllOtherSessions is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) EnrollTOTP(context.Context, *connect.Request[v1.EnrollTOTPRequest]) (*connect.Response[v1.EnrollTOTPResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.UserService.EnrollTOTP is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) ConfirmTOTP(context.Context, *connect.Request[v1.ConfirmTOTPRequest]) (*connect.Response[v1.ConfirmTOTPResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.UserService.ConfirmTOTP is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) DisableTOTP(context.Context, *connect.Request[v1.DisableTOTPRequest]) (*connect.Response[v1.DisableTOTPResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.UserService.DisableTOTP is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) UnlockUser(context.Context, *connect.Request[v1.UnlockUserRequest]) (*connect.Response[v1.UnlockUserResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.UserService.UnlockUser is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) VerifyEmail(context.Context, *connect.Request[v1.VerifyEmailRequest]) (*connect.Response[v1.VerifyEmailResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.UserService.VerifyEmail is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) ResendVerification(context.Context, *connect.Request[v1.ResendVerificationRequest]) (*connect.Response[v1.ResendVerificationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("goserver.api.v1.UserService.ResendVerification is not implemented"))
+}
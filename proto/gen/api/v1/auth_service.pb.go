@@ -81,8 +81,13 @@ type LoginResponse struct {
 	RefreshToken         string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
 	AccessTokenExpiresAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=access_token_expires_at,json=accessTokenExpiresAt,proto3" json:"access_token_expires_at,omitempty"`
 	User                 *User                  `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
-	unknownFields        protoimpl.UnknownFields
-	sizeCache            protoimpl.SizeCache
+	// Set when the user has two-factor authentication enabled. No tokens are issued,
+	// instead the mfa_token is passed to VerifyMFA together with a code.
+	MfaRequired       bool                   `protobuf:"varint,5,opt,name=mfa_required,json=mfaRequired,proto3" json:"mfa_required,omitempty"`
+	MfaToken          string                 `protobuf:"bytes,6,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`
+	MfaTokenExpiresAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=mfa_token_expires_at,json=mfaTokenExpiresAt,proto3" json:"mfa_token_expires_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *LoginResponse) Reset() {
@@ -143,6 +148,149 @@ func (x *LoginResponse) GetUser() *User {
 	return nil
 }
 
+func (x *LoginResponse) GetMfaRequired() bool {
+	if x != nil {
+		return x.MfaRequired
+	}
+	return false
+}
+
+func (x *LoginResponse) GetMfaToken() string {
+	if x != nil {
+		return x.MfaToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetMfaTokenExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MfaTokenExpiresAt
+	}
+	return nil
+}
+
+type VerifyMFARequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The challenge token returned by Login. It can be used only once.
+	MfaToken string `protobuf:"bytes,1,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`
+	// A TOTP code from the authenticator app or an unused recovery code.
+	Code          string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyMFARequest) Reset() {
+	*x = VerifyMFARequest{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyMFARequest) ProtoMessage() {}
+
+func (x *VerifyMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyMFARequest.ProtoReflect.Descriptor instead.
+func (*VerifyMFARequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *VerifyMFARequest) GetMfaToken() string {
+	if x != nil {
+		return x.MfaToken
+	}
+	return ""
+}
+
+func (x *VerifyMFARequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type VerifyMFAResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken          string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	RefreshToken         string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	AccessTokenExpiresAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=access_token_expires_at,json=accessTokenExpiresAt,proto3" json:"access_token_expires_at,omitempty"`
+	User                 *User                  `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *VerifyMFAResponse) Reset() {
+	*x = VerifyMFAResponse{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyMFAResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyMFAResponse) ProtoMessage() {}
+
+func (x *VerifyMFAResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyMFAResponse.ProtoReflect.Descriptor instead.
+func (*VerifyMFAResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *VerifyMFAResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *VerifyMFAResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *VerifyMFAResponse) GetAccessTokenExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AccessTokenExpiresAt
+	}
+	return nil
+}
+
+func (x *VerifyMFAResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
 type RefreshTokenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
@@ -152,7 +300,7 @@ type RefreshTokenRequest struct {
 
 func (x *RefreshTokenRequest) Reset() {
 	*x = RefreshTokenRequest{}
-	mi := &file_api_v1_auth_service_proto_msgTypes[2]
+	mi := &file_api_v1_auth_service_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -164,7 +312,7 @@ func (x *RefreshTokenRequest) String() string {
 func (*RefreshTokenRequest) ProtoMessage() {}
 
 func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_auth_service_proto_msgTypes[2]
+	mi := &file_api_v1_auth_service_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -177,7 +325,7 @@ func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
 func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{2}
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *RefreshTokenRequest) GetRefreshToken() string {
@@ -199,7 +347,7 @@ type RefreshTokenResponse struct {
 
 func (x *RefreshTokenResponse) Reset() {
 	*x = RefreshTokenResponse{}
-	mi := &file_api_v1_auth_service_proto_msgTypes[3]
+	mi := &file_api_v1_auth_service_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -211,7 +359,7 @@ func (x *RefreshTokenResponse) String() string {
 func (*RefreshTokenResponse) ProtoMessage() {}
 
 func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_auth_service_proto_msgTypes[3]
+	mi := &file_api_v1_auth_service_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -224,7 +372,7 @@ func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshTokenResponse.ProtoReflect.Descriptor instead.
 func (*RefreshTokenResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{3}
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *RefreshTokenResponse) GetAccessToken() string {
@@ -264,7 +412,7 @@ type ValidateTokenRequest struct {
 
 func (x *ValidateTokenRequest) Reset() {
 	*x = ValidateTokenRequest{}
-	mi := &file_api_v1_auth_service_proto_msgTypes[4]
+	mi := &file_api_v1_auth_service_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -276,7 +424,7 @@ func (x *ValidateTokenRequest) String() string {
 func (*ValidateTokenRequest) ProtoMessage() {}
 
 func (x *ValidateTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_auth_service_proto_msgTypes[4]
+	mi := &file_api_v1_auth_service_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -289,7 +437,7 @@ func (x *ValidateTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValidateTokenRequest.ProtoReflect.Descriptor instead.
 func (*ValidateTokenRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{4}
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ValidateTokenRequest) GetToken() string {
@@ -312,7 +460,7 @@ type ValidateTokenResponse struct {
 
 func (x *ValidateTokenResponse) Reset() {
 	*x = ValidateTokenResponse{}
-	mi := &file_api_v1_auth_service_proto_msgTypes[5]
+	mi := &file_api_v1_auth_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -324,7 +472,7 @@ func (x *ValidateTokenResponse) String() string {
 func (*ValidateTokenResponse) ProtoMessage() {}
 
 func (x *ValidateTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_auth_service_proto_msgTypes[5]
+	mi := &file_api_v1_auth_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -337,7 +485,7 @@ func (x *ValidateTokenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValidateTokenResponse.ProtoReflect.Descriptor instead.
 func (*ValidateTokenResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{5}
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ValidateTokenResponse) GetValid() bool {
@@ -376,15 +524,18 @@ func (x *ValidateTokenResponse) GetExpiresAt() *timestamppb.Timestamp {
 }
 
 type LogoutRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// The refresh token of the current session. When empty, the refresh tokens of the session the
+	// access token was issued for are revoked, or all of the user's refresh tokens if it has none.
+	RefreshToken  string `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LogoutRequest) Reset() {
 	*x = LogoutRequest{}
-	mi := &file_api_v1_auth_service_proto_msgTypes[6]
+	mi := &file_api_v1_auth_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -396,7 +547,7 @@ func (x *LogoutRequest) String() string {
 func (*LogoutRequest) ProtoMessage() {}
 
 func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_auth_service_proto_msgTypes[6]
+	mi := &file_api_v1_auth_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -409,7 +560,7 @@ func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
 func (*LogoutRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{6}
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *LogoutRequest) GetToken() string {
@@ -419,6 +570,13 @@ func (x *LogoutRequest) GetToken() string {
 	return ""
 }
 
+func (x *LogoutRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
 type LogoutResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -428,7 +586,7 @@ type LogoutResponse struct {
 
 func (x *LogoutResponse) Reset() {
 	*x = LogoutResponse{}
-	mi := &file_api_v1_auth_service_proto_msgTypes[7]
+	mi := &file_api_v1_auth_service_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -440,7 +598,7 @@ func (x *LogoutResponse) String() string {
 func (*LogoutResponse) ProtoMessage() {}
 
 func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_auth_service_proto_msgTypes[7]
+	mi := &file_api_v1_auth_service_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -453,7 +611,7 @@ func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogoutResponse.ProtoReflect.Descriptor instead.
 func (*LogoutResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{7}
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *LogoutResponse) GetSuccess() bool {
@@ -463,18 +621,342 @@ func (x *LogoutResponse) GetSuccess() bool {
 	return false
 }
 
+type RequestPasswordResetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPasswordResetRequest) Reset() {
+	*x = RequestPasswordResetRequest{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPasswordResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPasswordResetRequest) ProtoMessage() {}
+
+func (x *RequestPasswordResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPasswordResetRequest.ProtoReflect.Descriptor instead.
+func (*RequestPasswordResetRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RequestPasswordResetRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type RequestPasswordResetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPasswordResetResponse) Reset() {
+	*x = RequestPasswordResetResponse{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPasswordResetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPasswordResetResponse) ProtoMessage() {}
+
+func (x *RequestPasswordResetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPasswordResetResponse.ProtoReflect.Descriptor instead.
+func (*RequestPasswordResetResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{11}
+}
+
+type ConfirmPasswordResetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	NewPassword   string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmPasswordResetRequest) Reset() {
+	*x = ConfirmPasswordResetRequest{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmPasswordResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmPasswordResetRequest) ProtoMessage() {}
+
+func (x *ConfirmPasswordResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmPasswordResetRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmPasswordResetRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ConfirmPasswordResetRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ConfirmPasswordResetRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type ConfirmPasswordResetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmPasswordResetResponse) Reset() {
+	*x = ConfirmPasswordResetResponse{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmPasswordResetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmPasswordResetResponse) ProtoMessage() {}
+
+func (x *ConfirmPasswordResetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmPasswordResetResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmPasswordResetResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{13}
+}
+
+type ListLoginProvidersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLoginProvidersRequest) Reset() {
+	*x = ListLoginProvidersRequest{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLoginProvidersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLoginProvidersRequest) ProtoMessage() {}
+
+func (x *ListLoginProvidersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLoginProvidersRequest.ProtoReflect.Descriptor instead.
+func (*ListLoginProvidersRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{14}
+}
+
+type ListLoginProvidersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Providers     []*LoginProvider       `protobuf:"bytes,1,rep,name=providers,proto3" json:"providers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLoginProvidersResponse) Reset() {
+	*x = ListLoginProvidersResponse{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLoginProvidersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLoginProvidersResponse) ProtoMessage() {}
+
+func (x *ListLoginProvidersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLoginProvidersResponse.ProtoReflect.Descriptor instead.
+func (*ListLoginProvidersResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListLoginProvidersResponse) GetProviders() []*LoginProvider {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+type LoginProvider struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// The path that starts a sign-in with the provider in the browser. It takes an optional
+	// redirect query parameter, the local path returned to afterwards. The result is passed
+	// in the fragment of that path: access_token, refresh_token and access_token_expires_at,
+	// mfa_token and mfa_token_expires_at when two-factor authentication is required, or
+	// error and error_description.
+	LoginUrl      string `protobuf:"bytes,3,opt,name=login_url,json=loginUrl,proto3" json:"login_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginProvider) Reset() {
+	*x = LoginProvider{}
+	mi := &file_api_v1_auth_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginProvider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginProvider) ProtoMessage() {}
+
+func (x *LoginProvider) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_auth_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginProvider.ProtoReflect.Descriptor instead.
+func (*LoginProvider) Descriptor() ([]byte, []int) {
+	return file_api_v1_auth_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *LoginProvider) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LoginProvider) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *LoginProvider) GetLoginUrl() string {
+	if x != nil {
+		return x.LoginUrl
+	}
+	return ""
+}
+
 var File_api_v1_auth_service_proto protoreflect.FileDescriptor
 
 const file_api_v1_auth_service_proto_rawDesc = "" +
 	"\n" +
-	"\x19api/v1/auth_service.proto\x12\x0fgoserver.api.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13api/v1/common.proto\"P\n" +
+	"\x19api/v1/auth_service.proto\x12\x0fgoserver.api.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13api/v1/common.proto\x1a\x14api/v1/options.proto\"P\n" +
 	"\fLoginRequest\x12\x1f\n" +
 	"\busername\x18\x01 \x01(\tB\x03\xe0A\x02R\busername\x12\x1f\n" +
-	"\bpassword\x18\x02 \x01(\tB\x03\xe0A\x02R\bpassword\"\xe9\x01\n" +
+	"\bpassword\x18\x02 \x01(\tB\x03\xe0A\x02R\bpassword\"\x85\x03\n" +
 	"\rLoginResponse\x12&\n" +
 	"\faccess_token\x18\x01 \x01(\tB\x03\xe0A\x03R\vaccessToken\x12(\n" +
 	"\rrefresh_token\x18\x02 \x01(\tB\x03\xe0A\x03R\frefreshToken\x12V\n" +
 	"\x17access_token_expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\x14accessTokenExpiresAt\x12.\n" +
+	"\x04user\x18\x04 \x01(\v2\x15.goserver.api.v1.UserB\x03\xe0A\x03R\x04user\x12&\n" +
+	"\fmfa_required\x18\x05 \x01(\bB\x03\xe0A\x03R\vmfaRequired\x12 \n" +
+	"\tmfa_token\x18\x06 \x01(\tB\x03\xe0A\x03R\bmfaToken\x12P\n" +
+	"\x14mfa_token_expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\x11mfaTokenExpiresAt\"M\n" +
+	"\x10VerifyMFARequest\x12 \n" +
+	"\tmfa_token\x18\x01 \x01(\tB\x03\xe0A\x02R\bmfaToken\x12\x17\n" +
+	"\x04code\x18\x02 \x01(\tB\x03\xe0A\x02R\x04code\"\xed\x01\n" +
+	"\x11VerifyMFAResponse\x12&\n" +
+	"\faccess_token\x18\x01 \x01(\tB\x03\xe0A\x03R\vaccessToken\x12(\n" +
+	"\rrefresh_token\x18\x02 \x01(\tB\x03\xe0A\x03R\frefreshToken\x12V\n" +
+	"\x17access_token_expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\x14accessTokenExpiresAt\x12.\n" +
 	"\x04user\x18\x04 \x01(\v2\x15.goserver.api.v1.UserB\x03\xe0A\x03R\x04user\"?\n" +
 	"\x13RefreshTokenRequest\x12(\n" +
 	"\rrefresh_token\x18\x01 \x01(\tB\x03\xe0A\x02R\frefreshToken\"\xf0\x01\n" +
@@ -491,16 +973,35 @@ const file_api_v1_auth_service_proto_rawDesc = "" +
 	"\busername\x18\x03 \x01(\tB\x03\xe0A\x03R\busername\x12\x17\n" +
 	"\x04role\x18\x04 \x01(\tB\x03\xe0A\x03R\x04role\x12>\n" +
 	"\n" +
-	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\texpiresAt\"*\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\texpiresAt\"T\n" +
 	"\rLogoutRequest\x12\x19\n" +
-	"\x05token\x18\x01 \x01(\tB\x03\xe0A\x02R\x05token\"/\n" +
+	"\x05token\x18\x01 \x01(\tB\x03\xe0A\x02R\x05token\x12(\n" +
+	"\rrefresh_token\x18\x02 \x01(\tB\x03\xe0A\x01R\frefreshToken\"/\n" +
 	"\x0eLogoutResponse\x12\x1d\n" +
-	"\asuccess\x18\x01 \x01(\bB\x03\xe0A\x03R\asuccess2\x95\x04\n" +
-	"\vAuthService\x12y\n" +
-	"\x05Login\x12\x1d.goserver.api.v1.LoginRequest\x1a\x1e.goserver.api.v1.LoginResponse\"1\xdaA\x11username,password\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/api/v1/auth/login\x12\x8c\x01\n" +
-	"\fRefreshToken\x12$.goserver.api.v1.RefreshTokenRequest\x1a%.goserver.api.v1.RefreshTokenResponse\"/\xdaA\rrefresh_token\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/api/v1/auth/refresh\x12\x88\x01\n" +
-	"\rValidateToken\x12%.goserver.api.v1.ValidateTokenRequest\x1a&.goserver.api.v1.ValidateTokenResponse\"(\xdaA\x05token\x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/api/v1/auth/validate\x12q\n" +
-	"\x06Logout\x12\x1e.goserver.api.v1.LogoutRequest\x1a\x1f.goserver.api.v1.LogoutResponse\"&\xdaA\x05token\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/api/v1/auth/logoutB\xb7\x01\n" +
+	"\asuccess\x18\x01 \x01(\bB\x03\xe0A\x03R\asuccess\"8\n" +
+	"\x1bRequestPasswordResetRequest\x12\x19\n" +
+	"\x05email\x18\x01 \x01(\tB\x03\xe0A\x02R\x05email\"\x1e\n" +
+	"\x1cRequestPasswordResetResponse\"`\n" +
+	"\x1bConfirmPasswordResetRequest\x12\x19\n" +
+	"\x05token\x18\x01 \x01(\tB\x03\xe0A\x02R\x05token\x12&\n" +
+	"\fnew_password\x18\x02 \x01(\tB\x03\xe0A\x02R\vnewPassword\"\x1e\n" +
+	"\x1cConfirmPasswordResetResponse\"\x1b\n" +
+	"\x19ListLoginProvidersRequest\"_\n" +
+	"\x1aListLoginProvidersResponse\x12A\n" +
+	"\tproviders\x18\x01 \x03(\v2\x1e.goserver.api.v1.LoginProviderB\x03\xe0A\x03R\tproviders\"a\n" +
+	"\rLoginProvider\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\tB\x03\xe0A\x03R\x02id\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tB\x03\xe0A\x03R\x05title\x12 \n" +
+	"\tlogin_url\x18\x03 \x01(\tB\x03\xe0A\x03R\bloginUrl2\xc3\t\n" +
+	"\vAuthService\x12\x7f\n" +
+	"\x05Login\x12\x1d.goserver.api.v1.LoginRequest\x1a\x1e.goserver.api.v1.LoginResponse\"7\xdaA\x11username,password\x8a\xb5\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/api/v1/auth/login\x12\x8d\x01\n" +
+	"\tVerifyMFA\x12!.goserver.api.v1.VerifyMFARequest\x1a\".goserver.api.v1.VerifyMFAResponse\"9\xdaA\x0emfa_token,code\x8a\xb5\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/api/v1/auth/mfa/verify\x12\x92\x01\n" +
+	"\fRefreshToken\x12$.goserver.api.v1.RefreshTokenRequest\x1a%.goserver.api.v1.RefreshTokenResponse\"5\xdaA\rrefresh_token\x8a\xb5\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/api/v1/auth/refresh\x12\x94\x01\n" +
+	"\rValidateToken\x12%.goserver.api.v1.ValidateTokenRequest\x1a&.goserver.api.v1.ValidateTokenResponse\"4\xdaA\x05token\x8a\xb5\x18\x02\b\x01\x92\xb5\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/api/v1/auth/validate\x12q\n" +
+	"\x06Logout\x12\x1e.goserver.api.v1.LogoutRequest\x1a\x1f.goserver.api.v1.LogoutResponse\"&\xdaA\x05token\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/api/v1/auth/logout\x12\xa9\x01\n" +
+	"\x14RequestPasswordReset\x12,.goserver.api.v1.RequestPasswordResetRequest\x1a-.goserver.api.v1.RequestPasswordResetResponse\"4\xdaA\x05email\x8a\xb5\x18\x02\b\x01\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/auth/password-reset\x12\xbe\x01\n" +
+	"\x14ConfirmPasswordReset\x12,.goserver.api.v1.ConfirmPasswordResetRequest\x1a-.goserver.api.v1.ConfirmPasswordResetResponse\"I\xdaA\x12token,new_password\x8a\xb5\x18\x02\b\x01\x82\xd3\xe4\x93\x02(:\x01*\"#/api/v1/auth/password-reset/confirm\x12\x96\x01\n" +
+	"\x12ListLoginProviders\x12*.goserver.api.v1.ListLoginProvidersRequest\x1a+.goserver.api.v1.ListLoginProvidersResponse\"'\xdaA\x00\x8a\xb5\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x18\x12\x16/api/v1/auth/providersB\xb7\x01\n" +
 	"\x13com.goserver.api.v1B\x10AuthServiceProtoP\x01Z0github.com/pixb/go-server/proto/gen/api/v1;apiv1\xa2\x02\x03GAX\xaa\x02\x0fGoserver.Api.V1\xca\x02\x0fGoserver\\Api\\V1\xe2\x02\x1bGoserver\\Api\\V1\\GPBMetadata\xea\x02\x11Goserver::Api::V1b\x06proto3"
 
 var (
@@ -515,38 +1016,59 @@ func file_api_v1_auth_service_proto_rawDescGZIP() []byte {
 	return file_api_v1_auth_service_proto_rawDescData
 }
 
-var file_api_v1_auth_service_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_api_v1_auth_service_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_api_v1_auth_service_proto_goTypes = []any{
-	(*LoginRequest)(nil),          // 0: goserver.api.v1.LoginRequest
-	(*LoginResponse)(nil),         // 1: goserver.api.v1.LoginResponse
-	(*RefreshTokenRequest)(nil),   // 2: goserver.api.v1.RefreshTokenRequest
-	(*RefreshTokenResponse)(nil),  // 3: goserver.api.v1.RefreshTokenResponse
-	(*ValidateTokenRequest)(nil),  // 4: goserver.api.v1.ValidateTokenRequest
-	(*ValidateTokenResponse)(nil), // 5: goserver.api.v1.ValidateTokenResponse
-	(*LogoutRequest)(nil),         // 6: goserver.api.v1.LogoutRequest
-	(*LogoutResponse)(nil),        // 7: goserver.api.v1.LogoutResponse
-	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
-	(*User)(nil),                  // 9: goserver.api.v1.User
+	(*LoginRequest)(nil),                 // 0: goserver.api.v1.LoginRequest
+	(*LoginResponse)(nil),                // 1: goserver.api.v1.LoginResponse
+	(*VerifyMFARequest)(nil),             // 2: goserver.api.v1.VerifyMFARequest
+	(*VerifyMFAResponse)(nil),            // 3: goserver.api.v1.VerifyMFAResponse
+	(*RefreshTokenRequest)(nil),          // 4: goserver.api.v1.RefreshTokenRequest
+	(*RefreshTokenResponse)(nil),         // 5: goserver.api.v1.RefreshTokenResponse
+	(*ValidateTokenRequest)(nil),         // 6: goserver.api.v1.ValidateTokenRequest
+	(*ValidateTokenResponse)(nil),        // 7: goserver.api.v1.ValidateTokenResponse
+	(*LogoutRequest)(nil),                // 8: goserver.api.v1.LogoutRequest
+	(*LogoutResponse)(nil),               // 9: goserver.api.v1.LogoutResponse
+	(*RequestPasswordResetRequest)(nil),  // 10: goserver.api.v1.RequestPasswordResetRequest
+	(*RequestPasswordResetResponse)(nil), // 11: goserver.api.v1.RequestPasswordResetResponse
+	(*ConfirmPasswordResetRequest)(nil),  // 12: goserver.api.v1.ConfirmPasswordResetRequest
+	(*ConfirmPasswordResetResponse)(nil), // 13: goserver.api.v1.ConfirmPasswordResetResponse
+	(*ListLoginProvidersRequest)(nil),    // 14: goserver.api.v1.ListLoginProvidersRequest
+	(*ListLoginProvidersResponse)(nil),   // 15: goserver.api.v1.ListLoginProvidersResponse
+	(*LoginProvider)(nil),                // 16: goserver.api.v1.LoginProvider
+	(*timestamppb.Timestamp)(nil),        // 17: google.protobuf.Timestamp
+	(*User)(nil),                         // 18: goserver.api.v1.User
 }
 var file_api_v1_auth_service_proto_depIdxs = []int32{
-	8, // 0: goserver.api.v1.LoginResponse.access_token_expires_at:type_name -> google.protobuf.Timestamp
-	9, // 1: goserver.api.v1.LoginResponse.user:type_name -> goserver.api.v1.User
-	8, // 2: goserver.api.v1.RefreshTokenResponse.access_token_expires_at:type_name -> google.protobuf.Timestamp
-	9, // 3: goserver.api.v1.RefreshTokenResponse.user:type_name -> goserver.api.v1.User
-	8, // 4: goserver.api.v1.ValidateTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
-	0, // 5: goserver.api.v1.AuthService.Login:input_type -> goserver.api.v1.LoginRequest
-	2, // 6: goserver.api.v1.AuthService.RefreshToken:input_type -> goserver.api.v1.RefreshTokenRequest
-	4, // 7: goserver.api.v1.AuthService.ValidateToken:input_type -> goserver.api.v1.ValidateTokenRequest
-	6, // 8: goserver.api.v1.AuthService.Logout:input_type -> goserver.api.v1.LogoutRequest
-	1, // 9: goserver.api.v1.AuthService.Login:output_type -> goserver.api.v1.LoginResponse
-	3, // 10: goserver.api.v1.AuthService.RefreshToken:output_type -> goserver.api.v1.RefreshTokenResponse
-	5, // 11: goserver.api.v1.AuthService.ValidateToken:output_type -> goserver.api.v1.ValidateTokenResponse
-	7, // 12: goserver.api.v1.AuthService.Logout:output_type -> goserver.api.v1.LogoutResponse
-	9, // [9:13] is the sub-list for method output_type
-	5, // [5:9] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	17, // 0: goserver.api.v1.LoginResponse.access_token_expires_at:type_name -> google.protobuf.Timestamp
+	18, // 1: goserver.api.v1.LoginResponse.user:type_name -> goserver.api.v1.User
+	17, // 2: goserver.api.v1.LoginResponse.mfa_token_expires_at:type_name -> google.protobuf.Timestamp
+	17, // 3: goserver.api.v1.VerifyMFAResponse.access_token_expires_at:type_name -> google.protobuf.Timestamp
+	18, // 4: goserver.api.v1.VerifyMFAResponse.user:type_name -> goserver.api.v1.User
+	17, // 5: goserver.api.v1.RefreshTokenResponse.access_token_expires_at:type_name -> google.protobuf.Timestamp
+	18, // 6: goserver.api.v1.RefreshTokenResponse.user:type_name -> goserver.api.v1.User
+	17, // 7: goserver.api.v1.ValidateTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
+	16, // 8: goserver.api.v1.ListLoginProvidersResponse.providers:type_name -> goserver.api.v1.LoginProvider
+	0,  // 9: goserver.api.v1.AuthService.Login:input_type -> goserver.api.v1.LoginRequest
+	2,  // 10: goserver.api.v1.AuthService.VerifyMFA:input_type -> goserver.api.v1.VerifyMFARequest
+	4,  // 11: goserver.api.v1.AuthService.RefreshToken:input_type -> goserver.api.v1.RefreshTokenRequest
+	6,  // 12: goserver.api.v1.AuthService.ValidateToken:input_type -> goserver.api.v1.ValidateTokenRequest
+	8,  // 13: goserver.api.v1.AuthService.Logout:input_type -> goserver.api.v1.LogoutRequest
+	10, // 14: goserver.api.v1.AuthService.RequestPasswordReset:input_type -> goserver.api.v1.RequestPasswordResetRequest
+	12, // 15: goserver.api.v1.AuthService.ConfirmPasswordReset:input_type -> goserver.api.v1.ConfirmPasswordResetRequest
+	14, // 16: goserver.api.v1.AuthService.ListLoginProviders:input_type -> goserver.api.v1.ListLoginProvidersRequest
+	1,  // 17: goserver.api.v1.AuthService.Login:output_type -> goserver.api.v1.LoginResponse
+	3,  // 18: goserver.api.v1.AuthService.VerifyMFA:output_type -> goserver.api.v1.VerifyMFAResponse
+	5,  // 19: goserver.api.v1.AuthService.RefreshToken:output_type -> goserver.api.v1.RefreshTokenResponse
+	7,  // 20: goserver.api.v1.AuthService.ValidateToken:output_type -> goserver.api.v1.ValidateTokenResponse
+	9,  // 21: goserver.api.v1.AuthService.Logout:output_type -> goserver.api.v1.LogoutResponse
+	11, // 22: goserver.api.v1.AuthService.RequestPasswordReset:output_type -> goserver.api.v1.RequestPasswordResetResponse
+	13, // 23: goserver.api.v1.AuthService.ConfirmPasswordReset:output_type -> goserver.api.v1.ConfirmPasswordResetResponse
+	15, // 24: goserver.api.v1.AuthService.ListLoginProviders:output_type -> goserver.api.v1.ListLoginProvidersResponse
+	17, // [17:25] is the sub-list for method output_type
+	9,  // [9:17] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_auth_service_proto_init() }
@@ -555,13 +1077,14 @@ func file_api_v1_auth_service_proto_init() {
 		return
 	}
 	file_api_v1_common_proto_init()
+	file_api_v1_options_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_auth_service_proto_rawDesc), len(file_api_v1_auth_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,753 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: api/v1/identity_provider_service.proto
+
+package apiv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type IdentityProvider struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Lowercase letters, digits, "-" and "_", unique across providers.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The name shown on the sign-in page.
+	Title string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// The issuer URL, the provider configuration is discovered from {issuer}/.well-known/openid-configuration.
+	Issuer   string `protobuf:"bytes,3,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	ClientId string `protobuf:"bytes,4,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// Empty for public clients. It is never returned.
+	ClientSecret string `protobuf:"bytes,5,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"`
+	// The scopes requested in addition to openid, "profile" and "email" by default.
+	Scopes       []string                       `protobuf:"bytes,6,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	ClaimMapping *IdentityProvider_ClaimMapping `protobuf:"bytes,7,opt,name=claim_mapping,json=claimMapping,proto3" json:"claim_mapping,omitempty"`
+	// Whether a client secret is set.
+	HasClientSecret bool `protobuf:"varint,8,opt,name=has_client_secret,json=hasClientSecret,proto3" json:"has_client_secret,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *IdentityProvider) Reset() {
+	*x = IdentityProvider{}
+	mi := &file_api_v1_identity_provider_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdentityProvider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentityProvider) ProtoMessage() {}
+
+func (x *IdentityProvider) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_identity_provider_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentityProvider.ProtoReflect.Descriptor instead.
+func (*IdentityProvider) Descriptor() ([]byte, []int) {
+	return file_api_v1_identity_provider_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *IdentityProvider) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *IdentityProvider) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *IdentityProvider) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *IdentityProvider) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *IdentityProvider) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+func (x *IdentityProvider) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *IdentityProvider) GetClaimMapping() *IdentityProvider_ClaimMapping {
+	if x != nil {
+		return x.ClaimMapping
+	}
+	return nil
+}
+
+func (x *IdentityProvider) GetHasClientSecret() bool {
+	if x != nil {
+		return x.HasClientSecret
+	}
+	return false
+}
+
+type ListIdentityProvidersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIdentityProvidersRequest) Reset() {
+	*x = ListIdentityProvidersRequest{}
+	mi := &file_api_v1_identity_provider_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIdentityProvidersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIdentityProvidersRequest) ProtoMessage() {}
+
+func (x *ListIdentityProvidersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_identity_provider_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIdentityProvidersRequest.ProtoReflect.Descriptor instead.
+func (*ListIdentityProvidersRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_identity_provider_service_proto_rawDescGZIP(), []int{1}
+}
+
+type ListIdentityProvidersResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	IdentityProviders []*IdentityProvider    `protobuf:"bytes,1,rep,name=identity_providers,json=identityProviders,proto3" json:"identity_providers,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ListIdentityProvidersResponse) Reset() {
+	*x = ListIdentityProvidersResponse{}
+	mi := &file_api_v1_identity_provider_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIdentityProvidersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIdentityProvidersResponse) ProtoMessage() {}
+
+func (x *ListIdentityProvidersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_identity_provider_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIdentityProvidersResponse.ProtoReflect.Descriptor instead.
+func (*ListIdentityProvidersResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_identity_provider_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListIdentityProvidersResponse) GetIdentityProviders() []*IdentityProvider {
+	if x != nil {
+		return x.IdentityProviders
+	}
+	return nil
+}
+
+type GetIdentityProviderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIdentityProviderRequest) Reset() {
+	*x = GetIdentityProviderRequest{}
+	mi := &file_api_v1_identity_provider_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIdentityProviderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIdentityProviderRequest) ProtoMessage() {}
+
+func (x *GetIdentityProviderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_identity_provider_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIdentityProviderRequest.ProtoReflect.Descriptor instead.
+func (*GetIdentityProviderRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_identity_provider_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetIdentityProviderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetIdentityProviderResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	IdentityProvider *IdentityProvider      `protobuf:"bytes,1,opt,name=identity_provider,json=identityProvider,proto3" json:"identity_provider,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GetIdentityProviderResponse) Reset() {
+	*x = GetIdentityProviderResponse{}
+	mi := &file_api_v1_identity_provider_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIdentityProviderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIdentityProviderResponse) ProtoMessage() {}
+
+func (x *GetIdentityProviderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_identity_provider_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIdentityProviderResponse.ProtoReflect.Descriptor instead.
+func (*GetIdentityProviderResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_identity_provider_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetIdentityProviderResponse) GetIdentityProvider() *IdentityProvider {
+	if x != nil {
+		return x.IdentityProvider
+	}
+	return nil
+}
+
+type CreateIdentityProviderRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	IdentityProvider *IdentityProvider      `protobuf:"bytes,1,opt,name=identity_provider,json=identityProvider,proto3" json:"identity_provider,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateIdentityProviderRequest) Reset() {
+	*x = CreateIdentityProviderRequest{}
+	mi := &file_api_v1_identity_provider_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateIdentityProviderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateIdentityProviderRequest) ProtoMessage() {}
+
+func (x *CreateIdentityProviderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_identity_provider_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateIdentityProviderRequest.ProtoReflect.Descriptor instead.
+func (*CreateIdentityProviderRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_identity_provider_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateIdentityProviderRequest) GetIdentityProvider() *IdentityProvider {
+	if x != nil {
+		return x.IdentityProvider
+	}
+	return nil
+}
+
+type CreateIdentityProviderResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	IdentityProvider *IdentityProvider      `protobuf:"bytes,1,opt,name=identity_provider,json=identityProvider,proto3" json:"identity_provider,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateIdentityProviderResponse) Reset() {
+	*x = CreateIdentityProviderResponse{}
+	mi := &file_api_v1_identity_provider_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateIdentityProviderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateIdentityProviderResponse) ProtoMessage() {}
+
+func (x *CreateIdentityProviderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_identity_provider_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateIdentityProviderResponse.ProtoReflect.Descriptor instead.
+func (*CreateIdentityProviderResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_identity_provider_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateIdentityProviderResponse) GetIdentityProvider() *IdentityProvider {
+	if x != nil {
+		return x.IdentityProvider
+	}
+	return nil
+}
+
+type UpdateIdentityProviderRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	IdentityProvider *IdentityProvider      `protobuf:"bytes,1,opt,name=identity_provider,json=identityProvider,proto3" json:"identity_provider,omitempty"`
+	// The fields to update: "title", "issuer", "client_id", "client_secret", "scopes" and "claim_mapping".
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateIdentityProviderRequest) Reset() {
+	*x = UpdateIdentityProviderRequest{}
+	mi := &file_api_v1_identity_provider_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateIdentityProviderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateIdentityProviderRequest) ProtoMessage() {}
+
+func (x *UpdateIdentityProviderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_identity_provider_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateIdentityProviderRequest.ProtoReflect.Descriptor instead.
+func (*UpdateIdentityProviderRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_identity_provider_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateIdentityProviderRequest) GetIdentityProvider() *IdentityProvider {
+	if x != nil {
+		return x.IdentityProvider
+	}
+	return nil
+}
+
+func (x *UpdateIdentityProviderRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateIdentityProviderResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	IdentityProvider *IdentityProvider      `protobuf:"bytes,1,opt,name=identity_provider,json=identityProvider,proto3" json:"identity_provider,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UpdateIdentityProviderResponse) Reset() {
+	*x = UpdateIdentityProviderResponse{}
+	mi := &file_api_v1_identity_provider_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateIdentityProviderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateIdentityProviderResponse) ProtoMessage() {}
+
+func (x *UpdateIdentityProviderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_identity_provider_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateIdentityProviderResponse.ProtoReflect.Descriptor instead.
+func (*UpdateIdentityProviderResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_identity_provider_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateIdentityProviderResponse) GetIdentityProvider() *IdentityProvider {
+	if x != nil {
+		return x.IdentityProvider
+	}
+	return nil
+}
+
+type DeleteIdentityProviderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteIdentityProviderRequest) Reset() {
+	*x = DeleteIdentityProviderRequest{}
+	mi := &file_api_v1_identity_provider_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteIdentityProviderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteIdentityProviderRequest) ProtoMessage() {}
+
+func (x *DeleteIdentityProviderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_identity_provider_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteIdentityProviderRequest.ProtoReflect.Descriptor instead.
+func (*DeleteIdentityProviderRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_identity_provider_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteIdentityProviderRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteIdentityProviderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteIdentityProviderResponse) Reset() {
+	*x = DeleteIdentityProviderResponse{}
+	mi := &file_api_v1_identity_provider_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteIdentityProviderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteIdentityProviderResponse) ProtoMessage() {}
+
+func (x *DeleteIdentityProviderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_identity_provider_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteIdentityProviderResponse.ProtoReflect.Descriptor instead.
+func (*DeleteIdentityProviderResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_identity_provider_service_proto_rawDescGZIP(), []int{10}
+}
+
+// ClaimMapping names the ID token claims the fields of new users are taken from.
+// Empty values fall back to the standard claims.
+type IdentityProvider_ClaimMapping struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "preferred_username" by default.
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// "name" by default.
+	Nickname string `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	// "email" by default.
+	Email         string `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IdentityProvider_ClaimMapping) Reset() {
+	*x = IdentityProvider_ClaimMapping{}
+	mi := &file_api_v1_identity_provider_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdentityProvider_ClaimMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentityProvider_ClaimMapping) ProtoMessage() {}
+
+func (x *IdentityProvider_ClaimMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_identity_provider_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentityProvider_ClaimMapping.ProtoReflect.Descriptor instead.
+func (*IdentityProvider_ClaimMapping) Descriptor() ([]byte, []int) {
+	return file_api_v1_identity_provider_service_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *IdentityProvider_ClaimMapping) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *IdentityProvider_ClaimMapping) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *IdentityProvider_ClaimMapping) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+var File_api_v1_identity_provider_service_proto protoreflect.FileDescriptor
+
+const file_api_v1_identity_provider_service_proto_rawDesc = "" +
+	"\n" +
+	"&api/v1/identity_provider_service.proto\x12\x0fgoserver.api.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a google/protobuf/field_mask.proto\x1a\x14api/v1/options.proto\"\xb1\x03\n" +
+	"\x10IdentityProvider\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\tB\x03\xe0A\x05R\x02id\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tB\x03\xe0A\x02R\x05title\x12\x1b\n" +
+	"\x06issuer\x18\x03 \x01(\tB\x03\xe0A\x02R\x06issuer\x12 \n" +
+	"\tclient_id\x18\x04 \x01(\tB\x03\xe0A\x02R\bclientId\x12(\n" +
+	"\rclient_secret\x18\x05 \x01(\tB\x03\xe0A\x04R\fclientSecret\x12\x1b\n" +
+	"\x06scopes\x18\x06 \x03(\tB\x03\xe0A\x01R\x06scopes\x12X\n" +
+	"\rclaim_mapping\x18\a \x01(\v2..goserver.api.v1.IdentityProvider.ClaimMappingB\x03\xe0A\x01R\fclaimMapping\x12/\n" +
+	"\x11has_client_secret\x18\b \x01(\bB\x03\xe0A\x03R\x0fhasClientSecret\x1a\\\n" +
+	"\fClaimMapping\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
+	"\bnickname\x18\x02 \x01(\tR\bnickname\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\"\x1e\n" +
+	"\x1cListIdentityProvidersRequest\"v\n" +
+	"\x1dListIdentityProvidersResponse\x12U\n" +
+	"\x12identity_providers\x18\x01 \x03(\v2!.goserver.api.v1.IdentityProviderB\x03\xe0A\x03R\x11identityProviders\"1\n" +
+	"\x1aGetIdentityProviderRequest\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\tB\x03\xe0A\x02R\x02id\"r\n" +
+	"\x1bGetIdentityProviderResponse\x12S\n" +
+	"\x11identity_provider\x18\x01 \x01(\v2!.goserver.api.v1.IdentityProviderB\x03\xe0A\x03R\x10identityProvider\"t\n" +
+	"\x1dCreateIdentityProviderRequest\x12S\n" +
+	"\x11identity_provider\x18\x01 \x01(\v2!.goserver.api.v1.IdentityProviderB\x03\xe0A\x02R\x10identityProvider\"u\n" +
+	"\x1eCreateIdentityProviderResponse\x12S\n" +
+	"\x11identity_provider\x18\x01 \x01(\v2!.goserver.api.v1.IdentityProviderB\x03\xe0A\x03R\x10identityProvider\"\xb6\x01\n" +
+	"\x1dUpdateIdentityProviderRequest\x12S\n" +
+	"\x11identity_provider\x18\x01 \x01(\v2!.goserver.api.v1.IdentityProviderB\x03\xe0A\x02R\x10identityProvider\x12@\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskB\x03\xe0A\x02R\n" +
+	"updateMask\"u\n" +
+	"\x1eUpdateIdentityProviderResponse\x12S\n" +
+	"\x11identity_provider\x18\x01 \x01(\v2!.goserver.api.v1.IdentityProviderB\x03\xe0A\x03R\x10identityProvider\"4\n" +
+	"\x1dDeleteIdentityProviderRequest\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\tB\x03\xe0A\x02R\x02id\" \n" +
+	"\x1eDeleteIdentityProviderResponse2\x9b\b\n" +
+	"\x17IdentityProviderService\x12\xb0\x01\n" +
+	"\x15ListIdentityProviders\x12-.goserver.api.v1.ListIdentityProvidersRequest\x1a..goserver.api.v1.ListIdentityProvidersResponse\"8\xdaA\x00\x8a\xb5\x18\x0f\x1a\rsettings.read\x82\xd3\xe4\x93\x02\x1c\x12\x1a/api/v1/identity-providers\x12\xb1\x01\n" +
+	"\x13GetIdentityProvider\x12+.goserver.api.v1.GetIdentityProviderRequest\x1a,.goserver.api.v1.GetIdentityProviderResponse\"?\xdaA\x02id\x8a\xb5\x18\x0f\x1a\rsettings.read\x82\xd3\xe4\x93\x02!\x12\x1f/api/v1/identity-providers/{id}\x12\xd9\x01\n" +
+	"\x16CreateIdentityProvider\x12..goserver.api.v1.CreateIdentityProviderRequest\x1a/.goserver.api.v1.CreateIdentityProviderResponse\"^\xdaA\x11identity_provider\x8a\xb5\x18\x11\x1a\x0fsettings.update\x82\xd3\xe4\x93\x02/:\x11identity_provider\"\x1a/api/v1/identity-providers\x12\xfd\x01\n" +
+	"\x16UpdateIdentityProvider\x12..goserver.api.v1.UpdateIdentityProviderRequest\x1a/.goserver.api.v1.UpdateIdentityProviderResponse\"\x81\x01\xdaA\x1didentity_provider,update_mask\x8a\xb5\x18\x11\x1a\x0fsettings.update\x82\xd3\xe4\x93\x02F:\x11identity_provider21/api/v1/identity-providers/{identity_provider.id}\x12\xbc\x01\n" +
+	"\x16DeleteIdentityProvider\x12..goserver.api.v1.DeleteIdentityProviderRequest\x1a/.goserver.api.v1.DeleteIdentityProviderResponse\"A\xdaA\x02id\x8a\xb5\x18\x11\x1a\x0fsettings.update\x82\xd3\xe4\x93\x02!*\x1f/api/v1/identity-providers/{id}B\xc3\x01\n" +
+	"\x13com.goserver.api.v1B\x1cIdentityProviderServiceProtoP\x01Z0github.com/pixb/go-server/proto/gen/api/v1;apiv1\xa2\x02\x03GAX\xaa\x02\x0fGoserver.Api.V1\xca\x02\x0fGoserver\\Api\\V1\xe2\x02\x1bGoserver\\Api\\V1\\GPBMetadata\xea\x02\x11Goserver::Api::V1b\x06proto3"
+
+var (
+	file_api_v1_identity_provider_service_proto_rawDescOnce sync.Once
+	file_api_v1_identity_provider_service_proto_rawDescData []byte
+)
+
+func file_api_v1_identity_provider_service_proto_rawDescGZIP() []byte {
+	file_api_v1_identity_provider_service_proto_rawDescOnce.Do(func() {
+		file_api_v1_identity_provider_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_v1_identity_provider_service_proto_rawDesc), len(file_api_v1_identity_provider_service_proto_rawDesc)))
+	})
+	return file_api_v1_identity_provider_service_proto_rawDescData
+}
+
+var file_api_v1_identity_provider_service_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_api_v1_identity_provider_service_proto_goTypes = []any{
+	(*IdentityProvider)(nil),               // 0: goserver.api.v1.IdentityProvider
+	(*ListIdentityProvidersRequest)(nil),   // 1: goserver.api.v1.ListIdentityProvidersRequest
+	(*ListIdentityProvidersResponse)(nil),  // 2: goserver.api.v1.ListIdentityProvidersResponse
+	(*GetIdentityProviderRequest)(nil),     // 3: goserver.api.v1.GetIdentityProviderRequest
+	(*GetIdentityProviderResponse)(nil),    // 4: goserver.api.v1.GetIdentityProviderResponse
+	(*CreateIdentityProviderRequest)(nil),  // 5: goserver.api.v1.CreateIdentityProviderRequest
+	(*CreateIdentityProviderResponse)(nil), // 6: goserver.api.v1.CreateIdentityProviderResponse
+	(*UpdateIdentityProviderRequest)(nil),  // 7: goserver.api.v1.UpdateIdentityProviderRequest
+	(*UpdateIdentityProviderResponse)(nil), // 8: goserver.api.v1.UpdateIdentityProviderResponse
+	(*DeleteIdentityProviderRequest)(nil),  // 9: goserver.api.v1.DeleteIdentityProviderRequest
+	(*DeleteIdentityProviderResponse)(nil), // 10: goserver.api.v1.DeleteIdentityProviderResponse
+	(*IdentityProvider_ClaimMapping)(nil),  // 11: goserver.api.v1.IdentityProvider.ClaimMapping
+	(*fieldmaskpb.FieldMask)(nil),          // 12: google.protobuf.FieldMask
+}
+var file_api_v1_identity_provider_service_proto_depIdxs = []int32{
+	11, // 0: goserver.api.v1.IdentityProvider.claim_mapping:type_name -> goserver.api.v1.IdentityProvider.ClaimMapping
+	0,  // 1: goserver.api.v1.ListIdentityProvidersResponse.identity_providers:type_name -> goserver.api.v1.IdentityProvider
+	0,  // 2: goserver.api.v1.GetIdentityProviderResponse.identity_provider:type_name -> goserver.api.v1.IdentityProvider
+	0,  // 3: goserver.api.v1.CreateIdentityProviderRequest.identity_provider:type_name -> goserver.api.v1.IdentityProvider
+	0,  // 4: goserver.api.v1.CreateIdentityProviderResponse.identity_provider:type_name -> goserver.api.v1.IdentityProvider
+	0,  // 5: goserver.api.v1.UpdateIdentityProviderRequest.identity_provider:type_name -> goserver.api.v1.IdentityProvider
+	12, // 6: goserver.api.v1.UpdateIdentityProviderRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 7: goserver.api.v1.UpdateIdentityProviderResponse.identity_provider:type_name -> goserver.api.v1.IdentityProvider
+	1,  // 8: goserver.api.v1.IdentityProviderService.ListIdentityProviders:input_type -> goserver.api.v1.ListIdentityProvidersRequest
+	3,  // 9: goserver.api.v1.IdentityProviderService.GetIdentityProvider:input_type -> goserver.api.v1.GetIdentityProviderRequest
+	5,  // 10: goserver.api.v1.IdentityProviderService.CreateIdentityProvider:input_type -> goserver.api.v1.CreateIdentityProviderRequest
+	7,  // 11: goserver.api.v1.IdentityProviderService.UpdateIdentityProvider:input_type -> goserver.api.v1.UpdateIdentityProviderRequest
+	9,  // 12: goserver.api.v1.IdentityProviderService.DeleteIdentityProvider:input_type -> goserver.api.v1.DeleteIdentityProviderRequest
+	2,  // 13: goserver.api.v1.IdentityProviderService.ListIdentityProviders:output_type -> goserver.api.v1.ListIdentityProvidersResponse
+	4,  // 14: goserver.api.v1.IdentityProviderService.GetIdentityProvider:output_type -> goserver.api.v1.GetIdentityProviderResponse
+	6,  // 15: goserver.api.v1.IdentityProviderService.CreateIdentityProvider:output_type -> goserver.api.v1.CreateIdentityProviderResponse
+	8,  // 16: goserver.api.v1.IdentityProviderService.UpdateIdentityProvider:output_type -> goserver.api.v1.UpdateIdentityProviderResponse
+	10, // 17: goserver.api.v1.IdentityProviderService.DeleteIdentityProvider:output_type -> goserver.api.v1.DeleteIdentityProviderResponse
+	13, // [13:18] is the sub-list for method output_type
+	8,  // [8:13] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_api_v1_identity_provider_service_proto_init() }
+func file_api_v1_identity_provider_service_proto_init() {
+	if File_api_v1_identity_provider_service_proto != nil {
+		return
+	}
+	file_api_v1_options_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_identity_provider_service_proto_rawDesc), len(file_api_v1_identity_provider_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_v1_identity_provider_service_proto_goTypes,
+		DependencyIndexes: file_api_v1_identity_provider_service_proto_depIdxs,
+		MessageInfos:      file_api_v1_identity_provider_service_proto_msgTypes,
+	}.Build()
+	File_api_v1_identity_provider_service_proto = out.File
+	file_api_v1_identity_provider_service_proto_goTypes = nil
+	file_api_v1_identity_provider_service_proto_depIdxs = nil
+}
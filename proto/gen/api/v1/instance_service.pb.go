@@ -10,6 +10,7 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,6 +23,228 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type InstanceProfile_Maintenance_Mode int32
+
+const (
+	InstanceProfile_Maintenance_MODE_UNSPECIFIED InstanceProfile_Maintenance_Mode = 0
+	// Methods that change data are rejected with UNAVAILABLE.
+	InstanceProfile_Maintenance_READ_ONLY InstanceProfile_Maintenance_Mode = 1
+	// Only health checks and the instance profile are served.
+	InstanceProfile_Maintenance_FULL InstanceProfile_Maintenance_Mode = 2
+)
+
+// Enum value maps for InstanceProfile_Maintenance_Mode.
+var (
+	InstanceProfile_Maintenance_Mode_name = map[int32]string{
+		0: "MODE_UNSPECIFIED",
+		1: "READ_ONLY",
+		2: "FULL",
+	}
+	InstanceProfile_Maintenance_Mode_value = map[string]int32{
+		"MODE_UNSPECIFIED": 0,
+		"READ_ONLY":        1,
+		"FULL":             2,
+	}
+)
+
+func (x InstanceProfile_Maintenance_Mode) Enum() *InstanceProfile_Maintenance_Mode {
+	p := new(InstanceProfile_Maintenance_Mode)
+	*p = x
+	return p
+}
+
+func (x InstanceProfile_Maintenance_Mode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InstanceProfile_Maintenance_Mode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_instance_service_proto_enumTypes[0].Descriptor()
+}
+
+func (InstanceProfile_Maintenance_Mode) Type() protoreflect.EnumType {
+	return &file_api_v1_instance_service_proto_enumTypes[0]
+}
+
+func (x InstanceProfile_Maintenance_Mode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InstanceProfile_Maintenance_Mode.Descriptor instead.
+func (InstanceProfile_Maintenance_Mode) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{0, 0, 0}
+}
+
+type InstanceSetting_Key int32
+
+const (
+	InstanceSetting_KEY_UNSPECIFIED InstanceSetting_Key = 0
+	// The name and presentation of the instance.
+	InstanceSetting_GENERAL InstanceSetting_Key = 1
+	// Token lifetimes and who can register.
+	InstanceSetting_SECURITY InstanceSetting_Key = 2
+	// Where uploaded files are kept.
+	InstanceSetting_STORAGE InstanceSetting_Key = 3
+)
+
+// Enum value maps for InstanceSetting_Key.
+var (
+	InstanceSetting_Key_name = map[int32]string{
+		0: "KEY_UNSPECIFIED",
+		1: "GENERAL",
+		2: "SECURITY",
+		3: "STORAGE",
+	}
+	InstanceSetting_Key_value = map[string]int32{
+		"KEY_UNSPECIFIED": 0,
+		"GENERAL":         1,
+		"SECURITY":        2,
+		"STORAGE":         3,
+	}
+)
+
+func (x InstanceSetting_Key) Enum() *InstanceSetting_Key {
+	p := new(InstanceSetting_Key)
+	*p = x
+	return p
+}
+
+func (x InstanceSetting_Key) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InstanceSetting_Key) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_instance_service_proto_enumTypes[1].Descriptor()
+}
+
+func (InstanceSetting_Key) Type() protoreflect.EnumType {
+	return &file_api_v1_instance_service_proto_enumTypes[1]
+}
+
+func (x InstanceSetting_Key) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InstanceSetting_Key.Descriptor instead.
+func (InstanceSetting_Key) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{4, 0}
+}
+
+type InstanceSetting_RegistrationPolicy_Mode int32
+
+const (
+	// Anyone can register, the default.
+	InstanceSetting_RegistrationPolicy_MODE_UNSPECIFIED InstanceSetting_RegistrationPolicy_Mode = 0
+	InstanceSetting_RegistrationPolicy_OPEN             InstanceSetting_RegistrationPolicy_Mode = 1
+	// Nobody can register, not even with an invite.
+	InstanceSetting_RegistrationPolicy_DISABLED InstanceSetting_RegistrationPolicy_Mode = 2
+	// Only holders of an invite code can register.
+	InstanceSetting_RegistrationPolicy_INVITE_ONLY InstanceSetting_RegistrationPolicy_Mode = 3
+	// Only addresses of allowed_email_domains or holders of an invite code can register.
+	// Users then have to verify their email address before signing in, and identity
+	// providers have to vouch for the addresses of the users they sign in.
+	InstanceSetting_RegistrationPolicy_ALLOWED_EMAIL_DOMAINS InstanceSetting_RegistrationPolicy_Mode = 4
+)
+
+// Enum value maps for InstanceSetting_RegistrationPolicy_Mode.
+var (
+	InstanceSetting_RegistrationPolicy_Mode_name = map[int32]string{
+		0: "MODE_UNSPECIFIED",
+		1: "OPEN",
+		2: "DISABLED",
+		3: "INVITE_ONLY",
+		4: "ALLOWED_EMAIL_DOMAINS",
+	}
+	InstanceSetting_RegistrationPolicy_Mode_value = map[string]int32{
+		"MODE_UNSPECIFIED":      0,
+		"OPEN":                  1,
+		"DISABLED":              2,
+		"INVITE_ONLY":           3,
+		"ALLOWED_EMAIL_DOMAINS": 4,
+	}
+)
+
+func (x InstanceSetting_RegistrationPolicy_Mode) Enum() *InstanceSetting_RegistrationPolicy_Mode {
+	p := new(InstanceSetting_RegistrationPolicy_Mode)
+	*p = x
+	return p
+}
+
+func (x InstanceSetting_RegistrationPolicy_Mode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InstanceSetting_RegistrationPolicy_Mode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_instance_service_proto_enumTypes[2].Descriptor()
+}
+
+func (InstanceSetting_RegistrationPolicy_Mode) Type() protoreflect.EnumType {
+	return &file_api_v1_instance_service_proto_enumTypes[2]
+}
+
+func (x InstanceSetting_RegistrationPolicy_Mode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InstanceSetting_RegistrationPolicy_Mode.Descriptor instead.
+func (InstanceSetting_RegistrationPolicy_Mode) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{4, 3, 0}
+}
+
+type InstanceSetting_StorageSetting_StorageType int32
+
+const (
+	// Files are kept in the database, the default.
+	InstanceSetting_StorageSetting_STORAGE_TYPE_UNSPECIFIED InstanceSetting_StorageSetting_StorageType = 0
+	InstanceSetting_StorageSetting_DATABASE                 InstanceSetting_StorageSetting_StorageType = 1
+	// Files are kept in a directory of the server.
+	InstanceSetting_StorageSetting_LOCAL InstanceSetting_StorageSetting_StorageType = 2
+	// Files are kept in an S3 compatible bucket.
+	InstanceSetting_StorageSetting_S3 InstanceSetting_StorageSetting_StorageType = 3
+)
+
+// Enum value maps for InstanceSetting_StorageSetting_StorageType.
+var (
+	InstanceSetting_StorageSetting_StorageType_name = map[int32]string{
+		0: "STORAGE_TYPE_UNSPECIFIED",
+		1: "DATABASE",
+		2: "LOCAL",
+		3: "S3",
+	}
+	InstanceSetting_StorageSetting_StorageType_value = map[string]int32{
+		"STORAGE_TYPE_UNSPECIFIED": 0,
+		"DATABASE":                 1,
+		"LOCAL":                    2,
+		"S3":                       3,
+	}
+)
+
+func (x InstanceSetting_StorageSetting_StorageType) Enum() *InstanceSetting_StorageSetting_StorageType {
+	p := new(InstanceSetting_StorageSetting_StorageType)
+	*p = x
+	return p
+}
+
+func (x InstanceSetting_StorageSetting_StorageType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InstanceSetting_StorageSetting_StorageType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_instance_service_proto_enumTypes[3].Descriptor()
+}
+
+func (InstanceSetting_StorageSetting_StorageType) Type() protoreflect.EnumType {
+	return &file_api_v1_instance_service_proto_enumTypes[3]
+}
+
+func (x InstanceSetting_StorageSetting_StorageType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InstanceSetting_StorageSetting_StorageType.Descriptor instead.
+func (InstanceSetting_StorageSetting_StorageType) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{4, 4, 0}
+}
+
 // Instance profile message containing basic instance information.
 type InstanceProfile struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -31,7 +254,10 @@ type InstanceProfile struct {
 	Demo bool `protobuf:"varint,2,opt,name=demo,proto3" json:"demo,omitempty"`
 	// The first administrator who set up this instance.
 	// When null, instance requires initial setup (creating the first admin account).
-	Admin         *User `protobuf:"bytes,3,opt,name=admin,proto3" json:"admin,omitempty"`
+	// It is not looked up in full maintenance, check maintenance first.
+	Admin *User `protobuf:"bytes,3,opt,name=admin,proto3" json:"admin,omitempty"`
+	// The maintenance mode, unset while the instance is in service.
+	Maintenance   *InstanceProfile_Maintenance `protobuf:"bytes,4,opt,name=maintenance,proto3" json:"maintenance,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -87,6 +313,13 @@ func (x *InstanceProfile) GetAdmin() *User {
 	return nil
 }
 
+func (x *InstanceProfile) GetMaintenance() *InstanceProfile_Maintenance {
+	if x != nil {
+		return x.Maintenance
+	}
+	return nil
+}
+
 // Request for instance profile.
 type GetInstanceProfileRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -124,67 +357,1113 @@ func (*GetInstanceProfileRequest) Descriptor() ([]byte, []int) {
 	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{1}
 }
 
-var File_api_v1_instance_service_proto protoreflect.FileDescriptor
+type SetupInstanceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The account of the first administrator, validated like a registration.
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Nickname string `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Password string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	Phone    string `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
+	Email    string `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	// The name of the instance shown to users.
+	InstanceName  string `protobuf:"bytes,6,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_api_v1_instance_service_proto_rawDesc = "" +
-	"\n" +
-	"\x1dapi/v1/instance_service.proto\x12\x0fgoserver.api.v1\x1a\x13api/v1/common.proto\x1a\x1cgoogle/api/annotations.proto\"l\n" +
-	"\x0fInstanceProfile\x12\x18\n" +
-	"\aversion\x18\x01 \x01(\tR\aversion\x12\x12\n" +
-	"\x04demo\x18\x02 \x01(\bR\x04demo\x12+\n" +
-	"\x05admin\x18\x03 \x01(\v2\x15.goserver.api.v1.UserR\x05admin\"\x1b\n" +
-	"\x19GetInstanceProfileRequest2\x98\x01\n" +
-	"\x0fInstanceService\x12\x84\x01\n" +
-	"\x12GetInstanceProfile\x12*.goserver.api.v1.GetInstanceProfileRequest\x1a .goserver.api.v1.InstanceProfile\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/api/v1/instance/profileB\xbb\x01\n" +
-	"\x13com.goserver.api.v1B\x14InstanceServiceProtoP\x01Z0github.com/pixb/go-server/proto/gen/api/v1;apiv1\xa2\x02\x03GAX\xaa\x02\x0fGoserver.Api.V1\xca\x02\x0fGoserver\\Api\\V1\xe2\x02\x1bGoserver\\Api\\V1\\GPBMetadata\xea\x02\x11Goserver::Api::V1b\x06proto3"
+func (x *SetupInstanceRequest) Reset() {
+	*x = SetupInstanceRequest{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-var (
-	file_api_v1_instance_service_proto_rawDescOnce sync.Once
-	file_api_v1_instance_service_proto_rawDescData []byte
-)
+func (x *SetupInstanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func file_api_v1_instance_service_proto_rawDescGZIP() []byte {
-	file_api_v1_instance_service_proto_rawDescOnce.Do(func() {
-		file_api_v1_instance_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_v1_instance_service_proto_rawDesc), len(file_api_v1_instance_service_proto_rawDesc)))
-	})
-	return file_api_v1_instance_service_proto_rawDescData
+func (*SetupInstanceRequest) ProtoMessage() {}
+
+func (x *SetupInstanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var file_api_v1_instance_service_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
-var file_api_v1_instance_service_proto_goTypes = []any{
-	(*InstanceProfile)(nil),           // 0: goserver.api.v1.InstanceProfile
-	(*GetInstanceProfileRequest)(nil), // 1: goserver.api.v1.GetInstanceProfileRequest
-	(*User)(nil),                      // 2: goserver.api.v1.User
+// Deprecated: Use SetupInstanceRequest.ProtoReflect.Descriptor instead.
+func (*SetupInstanceRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{2}
 }
-var file_api_v1_instance_service_proto_depIdxs = []int32{
-	2, // 0: goserver.api.v1.InstanceProfile.admin:type_name -> goserver.api.v1.User
-	1, // 1: goserver.api.v1.InstanceService.GetInstanceProfile:input_type -> goserver.api.v1.GetInstanceProfileRequest
-	0, // 2: goserver.api.v1.InstanceService.GetInstanceProfile:output_type -> goserver.api.v1.InstanceProfile
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+
+func (x *SetupInstanceRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
 }
 
-func init() { file_api_v1_instance_service_proto_init() }
-func file_api_v1_instance_service_proto_init() {
-	if File_api_v1_instance_service_proto != nil {
-		return
+func (x *SetupInstanceRequest) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *SetupInstanceRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *SetupInstanceRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *SetupInstanceRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *SetupInstanceRequest) GetInstanceName() string {
+	if x != nil {
+		return x.InstanceName
+	}
+	return ""
+}
+
+type SetupInstanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Admin         *User                  `protobuf:"bytes,1,opt,name=admin,proto3" json:"admin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetupInstanceResponse) Reset() {
+	*x = SetupInstanceResponse{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetupInstanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetupInstanceResponse) ProtoMessage() {}
+
+func (x *SetupInstanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetupInstanceResponse.ProtoReflect.Descriptor instead.
+func (*SetupInstanceResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SetupInstanceResponse) GetAdmin() *User {
+	if x != nil {
+		return x.Admin
+	}
+	return nil
+}
+
+// InstanceSetting is a setting of the instance that admins can change at runtime.
+// Internal settings such as the secret key are not available through the API.
+type InstanceSetting struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   InstanceSetting_Key    `protobuf:"varint,1,opt,name=key,proto3,enum=goserver.api.v1.InstanceSetting_Key" json:"key,omitempty"`
+	// Types that are valid to be assigned to Value:
+	//
+	//	*InstanceSetting_GeneralSetting_
+	//	*InstanceSetting_SecuritySetting_
+	//	*InstanceSetting_StorageSetting_
+	Value         isInstanceSetting_Value `protobuf_oneof:"value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstanceSetting) Reset() {
+	*x = InstanceSetting{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceSetting) ProtoMessage() {}
+
+func (x *InstanceSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceSetting.ProtoReflect.Descriptor instead.
+func (*InstanceSetting) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *InstanceSetting) GetKey() InstanceSetting_Key {
+	if x != nil {
+		return x.Key
+	}
+	return InstanceSetting_KEY_UNSPECIFIED
+}
+
+func (x *InstanceSetting) GetValue() isInstanceSetting_Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *InstanceSetting) GetGeneralSetting() *InstanceSetting_GeneralSetting {
+	if x != nil {
+		if x, ok := x.Value.(*InstanceSetting_GeneralSetting_); ok {
+			return x.GeneralSetting
+		}
+	}
+	return nil
+}
+
+func (x *InstanceSetting) GetSecuritySetting() *InstanceSetting_SecuritySetting {
+	if x != nil {
+		if x, ok := x.Value.(*InstanceSetting_SecuritySetting_); ok {
+			return x.SecuritySetting
+		}
+	}
+	return nil
+}
+
+func (x *InstanceSetting) GetStorageSetting() *InstanceSetting_StorageSetting {
+	if x != nil {
+		if x, ok := x.Value.(*InstanceSetting_StorageSetting_); ok {
+			return x.StorageSetting
+		}
+	}
+	return nil
+}
+
+type isInstanceSetting_Value interface {
+	isInstanceSetting_Value()
+}
+
+type InstanceSetting_GeneralSetting_ struct {
+	GeneralSetting *InstanceSetting_GeneralSetting `protobuf:"bytes,2,opt,name=general_setting,json=generalSetting,proto3,oneof"`
+}
+
+type InstanceSetting_SecuritySetting_ struct {
+	SecuritySetting *InstanceSetting_SecuritySetting `protobuf:"bytes,3,opt,name=security_setting,json=securitySetting,proto3,oneof"`
+}
+
+type InstanceSetting_StorageSetting_ struct {
+	StorageSetting *InstanceSetting_StorageSetting `protobuf:"bytes,4,opt,name=storage_setting,json=storageSetting,proto3,oneof"`
+}
+
+func (*InstanceSetting_GeneralSetting_) isInstanceSetting_Value() {}
+
+func (*InstanceSetting_SecuritySetting_) isInstanceSetting_Value() {}
+
+func (*InstanceSetting_StorageSetting_) isInstanceSetting_Value() {}
+
+type GetInstanceSettingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           InstanceSetting_Key    `protobuf:"varint,1,opt,name=key,proto3,enum=goserver.api.v1.InstanceSetting_Key" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInstanceSettingRequest) Reset() {
+	*x = GetInstanceSettingRequest{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInstanceSettingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInstanceSettingRequest) ProtoMessage() {}
+
+func (x *GetInstanceSettingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInstanceSettingRequest.ProtoReflect.Descriptor instead.
+func (*GetInstanceSettingRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetInstanceSettingRequest) GetKey() InstanceSetting_Key {
+	if x != nil {
+		return x.Key
+	}
+	return InstanceSetting_KEY_UNSPECIFIED
+}
+
+type GetInstanceSettingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Setting       *InstanceSetting       `protobuf:"bytes,1,opt,name=setting,proto3" json:"setting,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInstanceSettingResponse) Reset() {
+	*x = GetInstanceSettingResponse{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInstanceSettingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInstanceSettingResponse) ProtoMessage() {}
+
+func (x *GetInstanceSettingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInstanceSettingResponse.ProtoReflect.Descriptor instead.
+func (*GetInstanceSettingResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetInstanceSettingResponse) GetSetting() *InstanceSetting {
+	if x != nil {
+		return x.Setting
+	}
+	return nil
+}
+
+type UpdateInstanceSettingRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Setting *InstanceSetting       `protobuf:"bytes,1,opt,name=setting,proto3" json:"setting,omitempty"`
+	// The fields of the typed setting to update:
+	// GENERAL: "name", "description" and "locale".
+	// SECURITY: "access_token_lifetime_seconds", "refresh_token_lifetime_seconds" and "registration".
+	// STORAGE: "storage_type", "filepath_template", "upload_size_limit_mb", "s3_config" and
+	// "s3_config.access_key_secret". "s3_config" keeps the stored access key secret.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateInstanceSettingRequest) Reset() {
+	*x = UpdateInstanceSettingRequest{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateInstanceSettingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateInstanceSettingRequest) ProtoMessage() {}
+
+func (x *UpdateInstanceSettingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateInstanceSettingRequest.ProtoReflect.Descriptor instead.
+func (*UpdateInstanceSettingRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateInstanceSettingRequest) GetSetting() *InstanceSetting {
+	if x != nil {
+		return x.Setting
+	}
+	return nil
+}
+
+func (x *UpdateInstanceSettingRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateInstanceSettingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Setting       *InstanceSetting       `protobuf:"bytes,1,opt,name=setting,proto3" json:"setting,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateInstanceSettingResponse) Reset() {
+	*x = UpdateInstanceSettingResponse{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateInstanceSettingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateInstanceSettingResponse) ProtoMessage() {}
+
+func (x *UpdateInstanceSettingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateInstanceSettingResponse.ProtoReflect.Descriptor instead.
+func (*UpdateInstanceSettingResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateInstanceSettingResponse) GetSetting() *InstanceSetting {
+	if x != nil {
+		return x.Setting
+	}
+	return nil
+}
+
+type InstanceProfile_Maintenance struct {
+	state protoimpl.MessageState           `protogen:"open.v1"`
+	Mode  InstanceProfile_Maintenance_Mode `protobuf:"varint,1,opt,name=mode,proto3,enum=goserver.api.v1.InstanceProfile_Maintenance_Mode" json:"mode,omitempty"`
+	// Why the instance is in maintenance, if the administrator said so.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// How long callers should wait before retrying rejected requests.
+	RetryAfterSeconds int32 `protobuf:"varint,3,opt,name=retry_after_seconds,json=retryAfterSeconds,proto3" json:"retry_after_seconds,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *InstanceProfile_Maintenance) Reset() {
+	*x = InstanceProfile_Maintenance{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceProfile_Maintenance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceProfile_Maintenance) ProtoMessage() {}
+
+func (x *InstanceProfile_Maintenance) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceProfile_Maintenance.ProtoReflect.Descriptor instead.
+func (*InstanceProfile_Maintenance) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *InstanceProfile_Maintenance) GetMode() InstanceProfile_Maintenance_Mode {
+	if x != nil {
+		return x.Mode
+	}
+	return InstanceProfile_Maintenance_MODE_UNSPECIFIED
+}
+
+func (x *InstanceProfile_Maintenance) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *InstanceProfile_Maintenance) GetRetryAfterSeconds() int32 {
+	if x != nil {
+		return x.RetryAfterSeconds
+	}
+	return 0
+}
+
+type InstanceSetting_GeneralSetting struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the instance shown to users, at most 100 characters.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// At most 1000 characters.
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// The default locale of the web app as a BCP 47 language tag, such as "en" or "zh-Hans".
+	Locale        string `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstanceSetting_GeneralSetting) Reset() {
+	*x = InstanceSetting_GeneralSetting{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceSetting_GeneralSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceSetting_GeneralSetting) ProtoMessage() {}
+
+func (x *InstanceSetting_GeneralSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceSetting_GeneralSetting.ProtoReflect.Descriptor instead.
+func (*InstanceSetting_GeneralSetting) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{4, 0}
+}
+
+func (x *InstanceSetting_GeneralSetting) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InstanceSetting_GeneralSetting) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *InstanceSetting_GeneralSetting) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type InstanceSetting_SecuritySetting struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The lifetime of access tokens issued at sign-in, between 5 minutes and 24 hours.
+	// 0 means the default of 24 hours.
+	AccessTokenLifetimeSeconds int32 `protobuf:"varint,1,opt,name=access_token_lifetime_seconds,json=accessTokenLifetimeSeconds,proto3" json:"access_token_lifetime_seconds,omitempty"`
+	// The lifetime of refresh tokens, between 1 hour and 90 days and at least the access
+	// token lifetime. 0 means the default of 7 days.
+	RefreshTokenLifetimeSeconds int32                               `protobuf:"varint,2,opt,name=refresh_token_lifetime_seconds,json=refreshTokenLifetimeSeconds,proto3" json:"refresh_token_lifetime_seconds,omitempty"`
+	Registration                *InstanceSetting_RegistrationPolicy `protobuf:"bytes,3,opt,name=registration,proto3" json:"registration,omitempty"`
+	// Limits HTTP requests per client address. Requests are not limited when unset.
+	RateLimit *InstanceSetting_RateLimitPolicy `protobuf:"bytes,4,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`
+	// Origins allowed to make cross-origin requests, such as "https://app.example.com".
+	// Any origin is allowed when empty.
+	CorsAllowedOrigins []string `protobuf:"bytes,5,rep,name=cors_allowed_origins,json=corsAllowedOrigins,proto3" json:"cors_allowed_origins,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *InstanceSetting_SecuritySetting) Reset() {
+	*x = InstanceSetting_SecuritySetting{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceSetting_SecuritySetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceSetting_SecuritySetting) ProtoMessage() {}
+
+func (x *InstanceSetting_SecuritySetting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceSetting_SecuritySetting.ProtoReflect.Descriptor instead.
+func (*InstanceSetting_SecuritySetting) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{4, 1}
+}
+
+func (x *InstanceSetting_SecuritySetting) GetAccessTokenLifetimeSeconds() int32 {
+	if x != nil {
+		return x.AccessTokenLifetimeSeconds
+	}
+	return 0
+}
+
+func (x *InstanceSetting_SecuritySetting) GetRefreshTokenLifetimeSeconds() int32 {
+	if x != nil {
+		return x.RefreshTokenLifetimeSeconds
+	}
+	return 0
+}
+
+func (x *InstanceSetting_SecuritySetting) GetRegistration() *InstanceSetting_RegistrationPolicy {
+	if x != nil {
+		return x.Registration
+	}
+	return nil
+}
+
+func (x *InstanceSetting_SecuritySetting) GetRateLimit() *InstanceSetting_RateLimitPolicy {
+	if x != nil {
+		return x.RateLimit
+	}
+	return nil
+}
+
+func (x *InstanceSetting_SecuritySetting) GetCorsAllowedOrigins() []string {
+	if x != nil {
+		return x.CorsAllowedOrigins
+	}
+	return nil
+}
+
+// RateLimitPolicy is a token bucket per client address.
+type InstanceSetting_RateLimitPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Between 0 and 10000, 0 disables the limit.
+	RequestsPerSecond float64 `protobuf:"fixed64,1,opt,name=requests_per_second,json=requestsPerSecond,proto3" json:"requests_per_second,omitempty"`
+	// The number of requests allowed at once, requests_per_second rounded up when 0.
+	Burst         int32 `protobuf:"varint,2,opt,name=burst,proto3" json:"burst,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstanceSetting_RateLimitPolicy) Reset() {
+	*x = InstanceSetting_RateLimitPolicy{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceSetting_RateLimitPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceSetting_RateLimitPolicy) ProtoMessage() {}
+
+func (x *InstanceSetting_RateLimitPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceSetting_RateLimitPolicy.ProtoReflect.Descriptor instead.
+func (*InstanceSetting_RateLimitPolicy) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{4, 2}
+}
+
+func (x *InstanceSetting_RateLimitPolicy) GetRequestsPerSecond() float64 {
+	if x != nil {
+		return x.RequestsPerSecond
+	}
+	return 0
+}
+
+func (x *InstanceSetting_RateLimitPolicy) GetBurst() int32 {
+	if x != nil {
+		return x.Burst
+	}
+	return 0
+}
+
+// RegistrationPolicy decides who can sign up with UserService.RegisterUser.
+type InstanceSetting_RegistrationPolicy struct {
+	state protoimpl.MessageState                  `protogen:"open.v1"`
+	Mode  InstanceSetting_RegistrationPolicy_Mode `protobuf:"varint,1,opt,name=mode,proto3,enum=goserver.api.v1.InstanceSetting_RegistrationPolicy_Mode" json:"mode,omitempty"`
+	// The domains accepted in ALLOWED_EMAIL_DOMAINS mode, such as "example.com".
+	// Subdomains are not included.
+	AllowedEmailDomains []string `protobuf:"bytes,2,rep,name=allowed_email_domains,json=allowedEmailDomains,proto3" json:"allowed_email_domains,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *InstanceSetting_RegistrationPolicy) Reset() {
+	*x = InstanceSetting_RegistrationPolicy{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceSetting_RegistrationPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceSetting_RegistrationPolicy) ProtoMessage() {}
+
+func (x *InstanceSetting_RegistrationPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceSetting_RegistrationPolicy.ProtoReflect.Descriptor instead.
+func (*InstanceSetting_RegistrationPolicy) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{4, 3}
+}
+
+func (x *InstanceSetting_RegistrationPolicy) GetMode() InstanceSetting_RegistrationPolicy_Mode {
+	if x != nil {
+		return x.Mode
+	}
+	return InstanceSetting_RegistrationPolicy_MODE_UNSPECIFIED
+}
+
+func (x *InstanceSetting_RegistrationPolicy) GetAllowedEmailDomains() []string {
+	if x != nil {
+		return x.AllowedEmailDomains
+	}
+	return nil
+}
+
+type InstanceSetting_StorageSetting struct {
+	state       protoimpl.MessageState                     `protogen:"open.v1"`
+	StorageType InstanceSetting_StorageSetting_StorageType `protobuf:"varint,1,opt,name=storage_type,json=storageType,proto3,enum=goserver.api.v1.InstanceSetting_StorageSetting_StorageType" json:"storage_type,omitempty"`
+	// The path of files kept in LOCAL storage, relative to the data directory.
+	// It may contain {filename}, {timestamp} and {uuid}, "assets/{timestamp}_{filename}" by default.
+	FilepathTemplate string `protobuf:"bytes,2,opt,name=filepath_template,json=filepathTemplate,proto3" json:"filepath_template,omitempty"`
+	// The maximum size of an uploaded file in MiB, between 1 and 1024. 0 means the default of 32.
+	UploadSizeLimitMb int32 `protobuf:"varint,3,opt,name=upload_size_limit_mb,json=uploadSizeLimitMb,proto3" json:"upload_size_limit_mb,omitempty"`
+	// Required for S3 storage.
+	S3Config      *InstanceSetting_S3Config `protobuf:"bytes,4,opt,name=s3_config,json=s3Config,proto3" json:"s3_config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstanceSetting_StorageSetting) Reset() {
+	*x = InstanceSetting_StorageSetting{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceSetting_StorageSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceSetting_StorageSetting) ProtoMessage() {}
+
+func (x *InstanceSetting_StorageSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceSetting_StorageSetting.ProtoReflect.Descriptor instead.
+func (*InstanceSetting_StorageSetting) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{4, 4}
+}
+
+func (x *InstanceSetting_StorageSetting) GetStorageType() InstanceSetting_StorageSetting_StorageType {
+	if x != nil {
+		return x.StorageType
+	}
+	return InstanceSetting_StorageSetting_STORAGE_TYPE_UNSPECIFIED
+}
+
+func (x *InstanceSetting_StorageSetting) GetFilepathTemplate() string {
+	if x != nil {
+		return x.FilepathTemplate
+	}
+	return ""
+}
+
+func (x *InstanceSetting_StorageSetting) GetUploadSizeLimitMb() int32 {
+	if x != nil {
+		return x.UploadSizeLimitMb
+	}
+	return 0
+}
+
+func (x *InstanceSetting_StorageSetting) GetS3Config() *InstanceSetting_S3Config {
+	if x != nil {
+		return x.S3Config
+	}
+	return nil
+}
+
+type InstanceSetting_S3Config struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	AccessKeyId string                 `protobuf:"bytes,1,opt,name=access_key_id,json=accessKeyId,proto3" json:"access_key_id,omitempty"`
+	// It is never returned.
+	AccessKeySecret string `protobuf:"bytes,2,opt,name=access_key_secret,json=accessKeySecret,proto3" json:"access_key_secret,omitempty"`
+	// The URL of the S3 endpoint, such as "https://s3.us-east-1.amazonaws.com".
+	Endpoint string `protobuf:"bytes,3,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Region   string `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	Bucket   string `protobuf:"bytes,5,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// Addresses the bucket in the path instead of the host name.
+	UsePathStyle bool `protobuf:"varint,6,opt,name=use_path_style,json=usePathStyle,proto3" json:"use_path_style,omitempty"`
+	// Whether an access key secret is set.
+	HasAccessKeySecret bool `protobuf:"varint,7,opt,name=has_access_key_secret,json=hasAccessKeySecret,proto3" json:"has_access_key_secret,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *InstanceSetting_S3Config) Reset() {
+	*x = InstanceSetting_S3Config{}
+	mi := &file_api_v1_instance_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceSetting_S3Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceSetting_S3Config) ProtoMessage() {}
+
+func (x *InstanceSetting_S3Config) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_instance_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceSetting_S3Config.ProtoReflect.Descriptor instead.
+func (*InstanceSetting_S3Config) Descriptor() ([]byte, []int) {
+	return file_api_v1_instance_service_proto_rawDescGZIP(), []int{4, 5}
+}
+
+func (x *InstanceSetting_S3Config) GetAccessKeyId() string {
+	if x != nil {
+		return x.AccessKeyId
+	}
+	return ""
+}
+
+func (x *InstanceSetting_S3Config) GetAccessKeySecret() string {
+	if x != nil {
+		return x.AccessKeySecret
+	}
+	return ""
+}
+
+func (x *InstanceSetting_S3Config) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *InstanceSetting_S3Config) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *InstanceSetting_S3Config) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *InstanceSetting_S3Config) GetUsePathStyle() bool {
+	if x != nil {
+		return x.UsePathStyle
+	}
+	return false
+}
+
+func (x *InstanceSetting_S3Config) GetHasAccessKeySecret() bool {
+	if x != nil {
+		return x.HasAccessKeySecret
+	}
+	return false
+}
+
+var File_api_v1_instance_service_proto protoreflect.FileDescriptor
+
+const file_api_v1_instance_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1dapi/v1/instance_service.proto\x12\x0fgoserver.api.v1\x1a\x13api/v1/common.proto\x1a\x14api/v1/options.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a google/protobuf/field_mask.proto\"\x94\x03\n" +
+	"\x0fInstanceProfile\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x12\n" +
+	"\x04demo\x18\x02 \x01(\bR\x04demo\x12+\n" +
+	"\x05admin\x18\x03 \x01(\v2\x15.goserver.api.v1.UserR\x05admin\x12N\n" +
+	"\vmaintenance\x18\x04 \x01(\v2,.goserver.api.v1.InstanceProfile.MaintenanceR\vmaintenance\x1a\xd5\x01\n" +
+	"\vMaintenance\x12E\n" +
+	"\x04mode\x18\x01 \x01(\x0e21.goserver.api.v1.InstanceProfile.Maintenance.ModeR\x04mode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12.\n" +
+	"\x13retry_after_seconds\x18\x03 \x01(\x05R\x11retryAfterSeconds\"5\n" +
+	"\x04Mode\x12\x14\n" +
+	"\x10MODE_UNSPECIFIED\x10\x00\x12\r\n" +
+	"\tREAD_ONLY\x10\x01\x12\b\n" +
+	"\x04FULL\x10\x02\"\x1b\n" +
+	"\x19GetInstanceProfileRequest\"\xd9\x01\n" +
+	"\x14SetupInstanceRequest\x12\x1f\n" +
+	"\busername\x18\x01 \x01(\tB\x03\xe0A\x02R\busername\x12\x1f\n" +
+	"\bnickname\x18\x02 \x01(\tB\x03\xe0A\x02R\bnickname\x12\x1f\n" +
+	"\bpassword\x18\x03 \x01(\tB\x03\xe0A\x02R\bpassword\x12\x19\n" +
+	"\x05phone\x18\x04 \x01(\tB\x03\xe0A\x02R\x05phone\x12\x19\n" +
+	"\x05email\x18\x05 \x01(\tB\x03\xe0A\x02R\x05email\x12(\n" +
+	"\rinstance_name\x18\x06 \x01(\tB\x03\xe0A\x02R\finstanceName\"I\n" +
+	"\x15SetupInstanceResponse\x120\n" +
+	"\x05admin\x18\x01 \x01(\v2\x15.goserver.api.v1.UserB\x03\xe0A\x03R\x05admin\"\xa1\x0f\n" +
+	"\x0fInstanceSetting\x12;\n" +
+	"\x03key\x18\x01 \x01(\x0e2$.goserver.api.v1.InstanceSetting.KeyB\x03\xe0A\x02R\x03key\x12Z\n" +
+	"\x0fgeneral_setting\x18\x02 \x01(\v2/.goserver.api.v1.InstanceSetting.GeneralSettingH\x00R\x0egeneralSetting\x12]\n" +
+	"\x10security_setting\x18\x03 \x01(\v20.goserver.api.v1.InstanceSetting.SecuritySettingH\x00R\x0fsecuritySetting\x12Z\n" +
+	"\x0fstorage_setting\x18\x04 \x01(\v2/.goserver.api.v1.InstanceSetting.StorageSettingH\x00R\x0estorageSetting\x1am\n" +
+	"\x0eGeneralSetting\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tB\x03\xe0A\x02R\x04name\x12%\n" +
+	"\vdescription\x18\x02 \x01(\tB\x03\xe0A\x01R\vdescription\x12\x1b\n" +
+	"\x06locale\x18\x03 \x01(\tB\x03\xe0A\x01R\x06locale\x1a\x8e\x03\n" +
+	"\x0fSecuritySetting\x12F\n" +
+	"\x1daccess_token_lifetime_seconds\x18\x01 \x01(\x05B\x03\xe0A\x01R\x1aaccessTokenLifetimeSeconds\x12H\n" +
+	"\x1erefresh_token_lifetime_seconds\x18\x02 \x01(\x05B\x03\xe0A\x01R\x1brefreshTokenLifetimeSeconds\x12\\\n" +
+	"\fregistration\x18\x03 \x01(\v23.goserver.api.v1.InstanceSetting.RegistrationPolicyB\x03\xe0A\x01R\fregistration\x12T\n" +
+	"\n" +
+	"rate_limit\x18\x04 \x01(\v20.goserver.api.v1.InstanceSetting.RateLimitPolicyB\x03\xe0A\x01R\trateLimit\x125\n" +
+	"\x14cors_allowed_origins\x18\x05 \x03(\tB\x03\xe0A\x01R\x12corsAllowedOrigins\x1aW\n" +
+	"\x0fRateLimitPolicy\x12.\n" +
+	"\x13requests_per_second\x18\x01 \x01(\x01R\x11requestsPerSecond\x12\x14\n" +
+	"\x05burst\x18\x02 \x01(\x05R\x05burst\x1a\xf8\x01\n" +
+	"\x12RegistrationPolicy\x12L\n" +
+	"\x04mode\x18\x01 \x01(\x0e28.goserver.api.v1.InstanceSetting.RegistrationPolicy.ModeR\x04mode\x122\n" +
+	"\x15allowed_email_domains\x18\x02 \x03(\tR\x13allowedEmailDomains\"`\n" +
+	"\x04Mode\x12\x14\n" +
+	"\x10MODE_UNSPECIFIED\x10\x00\x12\b\n" +
+	"\x04OPEN\x10\x01\x12\f\n" +
+	"\bDISABLED\x10\x02\x12\x0f\n" +
+	"\vINVITE_ONLY\x10\x03\x12\x19\n" +
+	"\x15ALLOWED_EMAIL_DOMAINS\x10\x04\x1a\xf3\x02\n" +
+	"\x0eStorageSetting\x12^\n" +
+	"\fstorage_type\x18\x01 \x01(\x0e2;.goserver.api.v1.InstanceSetting.StorageSetting.StorageTypeR\vstorageType\x120\n" +
+	"\x11filepath_template\x18\x02 \x01(\tB\x03\xe0A\x01R\x10filepathTemplate\x124\n" +
+	"\x14upload_size_limit_mb\x18\x03 \x01(\x05B\x03\xe0A\x01R\x11uploadSizeLimitMb\x12K\n" +
+	"\ts3_config\x18\x04 \x01(\v2).goserver.api.v1.InstanceSetting.S3ConfigB\x03\xe0A\x01R\bs3Config\"L\n" +
+	"\vStorageType\x12\x1c\n" +
+	"\x18STORAGE_TYPE_UNSPECIFIED\x10\x00\x12\f\n" +
+	"\bDATABASE\x10\x01\x12\t\n" +
+	"\x05LOCAL\x10\x02\x12\x06\n" +
+	"\x02S3\x10\x03\x1a\xa2\x02\n" +
+	"\bS3Config\x12'\n" +
+	"\raccess_key_id\x18\x01 \x01(\tB\x03\xe0A\x02R\vaccessKeyId\x12/\n" +
+	"\x11access_key_secret\x18\x02 \x01(\tB\x03\xe0A\x04R\x0faccessKeySecret\x12\x1f\n" +
+	"\bendpoint\x18\x03 \x01(\tB\x03\xe0A\x02R\bendpoint\x12\x1b\n" +
+	"\x06region\x18\x04 \x01(\tB\x03\xe0A\x02R\x06region\x12\x1b\n" +
+	"\x06bucket\x18\x05 \x01(\tB\x03\xe0A\x02R\x06bucket\x12)\n" +
+	"\x0euse_path_style\x18\x06 \x01(\bB\x03\xe0A\x01R\fusePathStyle\x126\n" +
+	"\x15has_access_key_secret\x18\a \x01(\bB\x03\xe0A\x03R\x12hasAccessKeySecret\"B\n" +
+	"\x03Key\x12\x13\n" +
+	"\x0fKEY_UNSPECIFIED\x10\x00\x12\v\n" +
+	"\aGENERAL\x10\x01\x12\f\n" +
+	"\bSECURITY\x10\x02\x12\v\n" +
+	"\aSTORAGE\x10\x03B\a\n" +
+	"\x05value\"X\n" +
+	"\x19GetInstanceSettingRequest\x12;\n" +
+	"\x03key\x18\x01 \x01(\x0e2$.goserver.api.v1.InstanceSetting.KeyB\x03\xe0A\x02R\x03key\"]\n" +
+	"\x1aGetInstanceSettingResponse\x12?\n" +
+	"\asetting\x18\x01 \x01(\v2 .goserver.api.v1.InstanceSettingB\x03\xe0A\x03R\asetting\"\xa1\x01\n" +
+	"\x1cUpdateInstanceSettingRequest\x12?\n" +
+	"\asetting\x18\x01 \x01(\v2 .goserver.api.v1.InstanceSettingB\x03\xe0A\x02R\asetting\x12@\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskB\x03\xe0A\x02R\n" +
+	"updateMask\"`\n" +
+	"\x1dUpdateInstanceSettingResponse\x12?\n" +
+	"\asetting\x18\x01 \x01(\v2 .goserver.api.v1.InstanceSettingB\x03\xe0A\x03R\asetting2\xbe\x05\n" +
+	"\x0fInstanceService\x12\x90\x01\n" +
+	"\x12GetInstanceProfile\x12*.goserver.api.v1.GetInstanceProfileRequest\x1a .goserver.api.v1.InstanceProfile\",\x8a\xb5\x18\x02\b\x01\x92\xb5\x18\x02\x10\x01\x82\xd3\xe4\x93\x02\x1a\x12\x18/api/v1/instance/profile\x12\x87\x01\n" +
+	"\rSetupInstance\x12%.goserver.api.v1.SetupInstanceRequest\x1a&.goserver.api.v1.SetupInstanceResponse\"'\x8a\xb5\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/api/v1/instance/setup\x12\xaf\x01\n" +
+	"\x12GetInstanceSetting\x12*.goserver.api.v1.GetInstanceSettingRequest\x1a+.goserver.api.v1.GetInstanceSettingResponse\"@\xdaA\x03key\x8a\xb5\x18\x0f\x1a\rsettings.read\x82\xd3\xe4\x93\x02!\x12\x1f/api/v1/instance/settings/{key}\x12\xdb\x01\n" +
+	"\x15UpdateInstanceSetting\x12-.goserver.api.v1.UpdateInstanceSettingRequest\x1a..goserver.api.v1.UpdateInstanceSettingResponse\"c\xdaA\x13setting,update_mask\x8a\xb5\x18\x11\x1a\x0fsettings.update\x82\xd3\xe4\x93\x022:\asetting2'/api/v1/instance/settings/{setting.key}B\xbb\x01\n" +
+	"\x13com.goserver.api.v1B\x14InstanceServiceProtoP\x01Z0github.com/pixb/go-server/proto/gen/api/v1;apiv1\xa2\x02\x03GAX\xaa\x02\x0fGoserver.Api.V1\xca\x02\x0fGoserver\\Api\\V1\xe2\x02\x1bGoserver\\Api\\V1\\GPBMetadata\xea\x02\x11Goserver::Api::V1b\x06proto3"
+
+var (
+	file_api_v1_instance_service_proto_rawDescOnce sync.Once
+	file_api_v1_instance_service_proto_rawDescData []byte
+)
+
+func file_api_v1_instance_service_proto_rawDescGZIP() []byte {
+	file_api_v1_instance_service_proto_rawDescOnce.Do(func() {
+		file_api_v1_instance_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_v1_instance_service_proto_rawDesc), len(file_api_v1_instance_service_proto_rawDesc)))
+	})
+	return file_api_v1_instance_service_proto_rawDescData
+}
+
+var file_api_v1_instance_service_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_api_v1_instance_service_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_api_v1_instance_service_proto_goTypes = []any{
+	(InstanceProfile_Maintenance_Mode)(0),           // 0: goserver.api.v1.InstanceProfile.Maintenance.Mode
+	(InstanceSetting_Key)(0),                        // 1: goserver.api.v1.InstanceSetting.Key
+	(InstanceSetting_RegistrationPolicy_Mode)(0),    // 2: goserver.api.v1.InstanceSetting.RegistrationPolicy.Mode
+	(InstanceSetting_StorageSetting_StorageType)(0), // 3: goserver.api.v1.InstanceSetting.StorageSetting.StorageType
+	(*InstanceProfile)(nil),                         // 4: goserver.api.v1.InstanceProfile
+	(*GetInstanceProfileRequest)(nil),               // 5: goserver.api.v1.GetInstanceProfileRequest
+	(*SetupInstanceRequest)(nil),                    // 6: goserver.api.v1.SetupInstanceRequest
+	(*SetupInstanceResponse)(nil),                   // 7: goserver.api.v1.SetupInstanceResponse
+	(*InstanceSetting)(nil),                         // 8: goserver.api.v1.InstanceSetting
+	(*GetInstanceSettingRequest)(nil),               // 9: goserver.api.v1.GetInstanceSettingRequest
+	(*GetInstanceSettingResponse)(nil),              // 10: goserver.api.v1.GetInstanceSettingResponse
+	(*UpdateInstanceSettingRequest)(nil),            // 11: goserver.api.v1.UpdateInstanceSettingRequest
+	(*UpdateInstanceSettingResponse)(nil),           // 12: goserver.api.v1.UpdateInstanceSettingResponse
+	(*InstanceProfile_Maintenance)(nil),             // 13: goserver.api.v1.InstanceProfile.Maintenance
+	(*InstanceSetting_GeneralSetting)(nil),          // 14: goserver.api.v1.InstanceSetting.GeneralSetting
+	(*InstanceSetting_SecuritySetting)(nil),         // 15: goserver.api.v1.InstanceSetting.SecuritySetting
+	(*InstanceSetting_RateLimitPolicy)(nil),         // 16: goserver.api.v1.InstanceSetting.RateLimitPolicy
+	(*InstanceSetting_RegistrationPolicy)(nil),      // 17: goserver.api.v1.InstanceSetting.RegistrationPolicy
+	(*InstanceSetting_StorageSetting)(nil),          // 18: goserver.api.v1.InstanceSetting.StorageSetting
+	(*InstanceSetting_S3Config)(nil),                // 19: goserver.api.v1.InstanceSetting.S3Config
+	(*User)(nil),                                    // 20: goserver.api.v1.User
+	(*fieldmaskpb.FieldMask)(nil),                   // 21: google.protobuf.FieldMask
+}
+var file_api_v1_instance_service_proto_depIdxs = []int32{
+	20, // 0: goserver.api.v1.InstanceProfile.admin:type_name -> goserver.api.v1.User
+	13, // 1: goserver.api.v1.InstanceProfile.maintenance:type_name -> goserver.api.v1.InstanceProfile.Maintenance
+	20, // 2: goserver.api.v1.SetupInstanceResponse.admin:type_name -> goserver.api.v1.User
+	1,  // 3: goserver.api.v1.InstanceSetting.key:type_name -> goserver.api.v1.InstanceSetting.Key
+	14, // 4: goserver.api.v1.InstanceSetting.general_setting:type_name -> goserver.api.v1.InstanceSetting.GeneralSetting
+	15, // 5: goserver.api.v1.InstanceSetting.security_setting:type_name -> goserver.api.v1.InstanceSetting.SecuritySetting
+	18, // 6: goserver.api.v1.InstanceSetting.storage_setting:type_name -> goserver.api.v1.InstanceSetting.StorageSetting
+	1,  // 7: goserver.api.v1.GetInstanceSettingRequest.key:type_name -> goserver.api.v1.InstanceSetting.Key
+	8,  // 8: goserver.api.v1.GetInstanceSettingResponse.setting:type_name -> goserver.api.v1.InstanceSetting
+	8,  // 9: goserver.api.v1.UpdateInstanceSettingRequest.setting:type_name -> goserver.api.v1.InstanceSetting
+	21, // 10: goserver.api.v1.UpdateInstanceSettingRequest.update_mask:type_name -> google.protobuf.FieldMask
+	8,  // 11: goserver.api.v1.UpdateInstanceSettingResponse.setting:type_name -> goserver.api.v1.InstanceSetting
+	0,  // 12: goserver.api.v1.InstanceProfile.Maintenance.mode:type_name -> goserver.api.v1.InstanceProfile.Maintenance.Mode
+	17, // 13: goserver.api.v1.InstanceSetting.SecuritySetting.registration:type_name -> goserver.api.v1.InstanceSetting.RegistrationPolicy
+	16, // 14: goserver.api.v1.InstanceSetting.SecuritySetting.rate_limit:type_name -> goserver.api.v1.InstanceSetting.RateLimitPolicy
+	2,  // 15: goserver.api.v1.InstanceSetting.RegistrationPolicy.mode:type_name -> goserver.api.v1.InstanceSetting.RegistrationPolicy.Mode
+	3,  // 16: goserver.api.v1.InstanceSetting.StorageSetting.storage_type:type_name -> goserver.api.v1.InstanceSetting.StorageSetting.StorageType
+	19, // 17: goserver.api.v1.InstanceSetting.StorageSetting.s3_config:type_name -> goserver.api.v1.InstanceSetting.S3Config
+	5,  // 18: goserver.api.v1.InstanceService.GetInstanceProfile:input_type -> goserver.api.v1.GetInstanceProfileRequest
+	6,  // 19: goserver.api.v1.InstanceService.SetupInstance:input_type -> goserver.api.v1.SetupInstanceRequest
+	9,  // 20: goserver.api.v1.InstanceService.GetInstanceSetting:input_type -> goserver.api.v1.GetInstanceSettingRequest
+	11, // 21: goserver.api.v1.InstanceService.UpdateInstanceSetting:input_type -> goserver.api.v1.UpdateInstanceSettingRequest
+	4,  // 22: goserver.api.v1.InstanceService.GetInstanceProfile:output_type -> goserver.api.v1.InstanceProfile
+	7,  // 23: goserver.api.v1.InstanceService.SetupInstance:output_type -> goserver.api.v1.SetupInstanceResponse
+	10, // 24: goserver.api.v1.InstanceService.GetInstanceSetting:output_type -> goserver.api.v1.GetInstanceSettingResponse
+	12, // 25: goserver.api.v1.InstanceService.UpdateInstanceSetting:output_type -> goserver.api.v1.UpdateInstanceSettingResponse
+	22, // [22:26] is the sub-list for method output_type
+	18, // [18:22] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_api_v1_instance_service_proto_init() }
+func file_api_v1_instance_service_proto_init() {
+	if File_api_v1_instance_service_proto != nil {
+		return
+	}
+	file_api_v1_common_proto_init()
+	file_api_v1_options_proto_init()
+	file_api_v1_instance_service_proto_msgTypes[4].OneofWrappers = []any{
+		(*InstanceSetting_GeneralSetting_)(nil),
+		(*InstanceSetting_SecuritySetting_)(nil),
+		(*InstanceSetting_StorageSetting_)(nil),
 	}
-	file_api_v1_common_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_instance_service_proto_rawDesc), len(file_api_v1_instance_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   2,
+			NumEnums:      4,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_api_v1_instance_service_proto_goTypes,
 		DependencyIndexes: file_api_v1_instance_service_proto_depIdxs,
+		EnumInfos:         file_api_v1_instance_service_proto_enumTypes,
 		MessageInfos:      file_api_v1_instance_service_proto_msgTypes,
 	}.Build()
 	File_api_v1_instance_service_proto = out.File
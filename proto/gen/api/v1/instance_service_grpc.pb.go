@@ -19,7 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	InstanceService_GetInstanceProfile_FullMethodName = "/goserver.api.v1.InstanceService/GetInstanceProfile"
+	InstanceService_GetInstanceProfile_FullMethodName    = "/goserver.api.v1.InstanceService/GetInstanceProfile"
+	InstanceService_SetupInstance_FullMethodName         = "/goserver.api.v1.InstanceService/SetupInstance"
+	InstanceService_GetInstanceSetting_FullMethodName    = "/goserver.api.v1.InstanceService/GetInstanceSetting"
+	InstanceService_UpdateInstanceSetting_FullMethodName = "/goserver.api.v1.InstanceService/UpdateInstanceSetting"
 )
 
 // InstanceServiceClient is the client API for InstanceService service.
@@ -28,6 +31,13 @@ const (
 type InstanceServiceClient interface {
 	// Gets the instance profile.
 	GetInstanceProfile(ctx context.Context, in *GetInstanceProfileRequest, opts ...grpc.CallOption) (*InstanceProfile, error)
+	// Creates the first administrator and names a new instance. It is only allowed while
+	// the instance has no administrator and succeeds at most once.
+	SetupInstance(ctx context.Context, in *SetupInstanceRequest, opts ...grpc.CallOption) (*SetupInstanceResponse, error)
+	// Gets an instance setting. Settings that were never changed are returned with their defaults.
+	GetInstanceSetting(ctx context.Context, in *GetInstanceSettingRequest, opts ...grpc.CallOption) (*GetInstanceSettingResponse, error)
+	// Updates the fields of an instance setting listed in update_mask.
+	UpdateInstanceSetting(ctx context.Context, in *UpdateInstanceSettingRequest, opts ...grpc.CallOption) (*UpdateInstanceSettingResponse, error)
 }
 
 type instanceServiceClient struct {
@@ -48,12 +58,49 @@ func (c *instanceServiceClient) GetInstanceProfile(ctx context.Context, in *GetI
 	return out, nil
 }
 
+func (c *instanceServiceClient) SetupInstance(ctx context.Context, in *SetupInstanceRequest, opts ...grpc.CallOption) (*SetupInstanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetupInstanceResponse)
+	err := c.cc.Invoke(ctx, InstanceService_SetupInstance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *instanceServiceClient) GetInstanceSetting(ctx context.Context, in *GetInstanceSettingRequest, opts ...grpc.CallOption) (*GetInstanceSettingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetInstanceSettingResponse)
+	err := c.cc.Invoke(ctx, InstanceService_GetInstanceSetting_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *instanceServiceClient) UpdateInstanceSetting(ctx context.Context, in *UpdateInstanceSettingRequest, opts ...grpc.CallOption) (*UpdateInstanceSettingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateInstanceSettingResponse)
+	err := c.cc.Invoke(ctx, InstanceService_UpdateInstanceSetting_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InstanceServiceServer is the server API for InstanceService service.
 // All implementations must embed UnimplementedInstanceServiceServer
 // for forward compatibility.
 type InstanceServiceServer interface {
 	// Gets the instance profile.
 	GetInstanceProfile(context.Context, *GetInstanceProfileRequest) (*InstanceProfile, error)
+	// Creates the first administrator and names a new instance. It is only allowed while
+	// the instance has no administrator and succeeds at most once.
+	SetupInstance(context.Context, *SetupInstanceRequest) (*SetupInstanceResponse, error)
+	// Gets an instance setting. Settings that were never changed are returned with their defaults.
+	GetInstanceSetting(context.Context, *GetInstanceSettingRequest) (*GetInstanceSettingResponse, error)
+	// Updates the fields of an instance setting listed in update_mask.
+	UpdateInstanceSetting(context.Context, *UpdateInstanceSettingRequest) (*UpdateInstanceSettingResponse, error)
 	mustEmbedUnimplementedInstanceServiceServer()
 }
 
@@ -67,6 +114,15 @@ type UnimplementedInstanceServiceServer struct{}
 func (UnimplementedInstanceServiceServer) GetInstanceProfile(context.Context, *GetInstanceProfileRequest) (*InstanceProfile, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetInstanceProfile not implemented")
 }
+func (UnimplementedInstanceServiceServer) SetupInstance(context.Context, *SetupInstanceRequest) (*SetupInstanceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetupInstance not implemented")
+}
+func (UnimplementedInstanceServiceServer) GetInstanceSetting(context.Context, *GetInstanceSettingRequest) (*GetInstanceSettingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetInstanceSetting not implemented")
+}
+func (UnimplementedInstanceServiceServer) UpdateInstanceSetting(context.Context, *UpdateInstanceSettingRequest) (*UpdateInstanceSettingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateInstanceSetting not implemented")
+}
 func (UnimplementedInstanceServiceServer) mustEmbedUnimplementedInstanceServiceServer() {}
 func (UnimplementedInstanceServiceServer) testEmbeddedByValue()                         {}
 
@@ -106,6 +162,60 @@ func _InstanceService_GetInstanceProfile_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InstanceService_SetupInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetupInstanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstanceServiceServer).SetupInstance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InstanceService_SetupInstance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstanceServiceServer).SetupInstance(ctx, req.(*SetupInstanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InstanceService_GetInstanceSetting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInstanceSettingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstanceServiceServer).GetInstanceSetting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InstanceService_GetInstanceSetting_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstanceServiceServer).GetInstanceSetting(ctx, req.(*GetInstanceSettingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InstanceService_UpdateInstanceSetting_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateInstanceSettingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InstanceServiceServer).UpdateInstanceSetting(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InstanceService_UpdateInstanceSetting_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InstanceServiceServer).UpdateInstanceSetting(ctx, req.(*UpdateInstanceSettingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // InstanceService_ServiceDesc is the grpc.ServiceDesc for InstanceService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -117,6 +227,18 @@ var InstanceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetInstanceProfile",
 			Handler:    _InstanceService_GetInstanceProfile_Handler,
 		},
+		{
+			MethodName: "SetupInstance",
+			Handler:    _InstanceService_SetupInstance_Handler,
+		},
+		{
+			MethodName: "GetInstanceSetting",
+			Handler:    _InstanceService_GetInstanceSetting_Handler,
+		},
+		{
+			MethodName: "UpdateInstanceSetting",
+			Handler:    _InstanceService_UpdateInstanceSetting_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/instance_service.proto",
@@ -0,0 +1,1405 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: api/v1/oauth_service.proto
+
+package apiv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type OAuthClient struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The identifier of the client in OAuth requests, generated on creation.
+	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// The name shown to users when they are asked for consent.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// The exact redirect URIs of the client, https or http on a loopback host.
+	RedirectUris []string `protobuf:"bytes,3,rep,name=redirect_uris,json=redirectUris,proto3" json:"redirect_uris,omitempty"`
+	// "authorization_code" and "client_credentials", the latter requires a confidential client.
+	GrantTypes []string `protobuf:"bytes,4,rep,name=grant_types,json=grantTypes,proto3" json:"grant_types,omitempty"`
+	// The scopes the client may request, "openid", "profile" and "email" by default.
+	Scopes []string `protobuf:"bytes,5,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// Confidential clients authenticate with their secret at the token endpoint.
+	// Public clients, such as single-page and native apps, only use PKCE.
+	Confidential  bool                   `protobuf:"varint,6,opt,name=confidential,proto3" json:"confidential,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OAuthClient) Reset() {
+	*x = OAuthClient{}
+	mi := &file_api_v1_oauth_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OAuthClient) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OAuthClient) ProtoMessage() {}
+
+func (x *OAuthClient) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_oauth_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OAuthClient.ProtoReflect.Descriptor instead.
+func (*OAuthClient) Descriptor() ([]byte, []int) {
+	return file_api_v1_oauth_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OAuthClient) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *OAuthClient) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OAuthClient) GetRedirectUris() []string {
+	if x != nil {
+		return x.RedirectUris
+	}
+	return nil
+}
+
+func (x *OAuthClient) GetGrantTypes() []string {
+	if x != nil {
+		return x.GrantTypes
+	}
+	return nil
+}
+
+func (x *OAuthClient) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *OAuthClient) GetConfidential() bool {
+	if x != nil {
+		return x.Confidential
+	}
+	return false
+}
+
+func (x *OAuthClient) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *OAuthClient) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// OAuthAuthorization is an authorization request as it was sent to /oauth/authorize.
+type OAuthAuthorization struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ClientId    string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	RedirectUri string                 `protobuf:"bytes,2,opt,name=redirect_uri,json=redirectUri,proto3" json:"redirect_uri,omitempty"`
+	// Only "code" is supported.
+	ResponseType string `protobuf:"bytes,3,opt,name=response_type,json=responseType,proto3" json:"response_type,omitempty"`
+	// Space-separated scopes, all scopes of the client when empty.
+	Scope         string `protobuf:"bytes,4,opt,name=scope,proto3" json:"scope,omitempty"`
+	State         string `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	Nonce         string `protobuf:"bytes,6,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	CodeChallenge string `protobuf:"bytes,7,opt,name=code_challenge,json=codeChallenge,proto3" json:"code_challenge,omitempty"`
+	// Only "S256" is supported.
+	CodeChallengeMethod string `protobuf:"bytes,8,opt,name=code_challenge_method,json=codeChallengeMethod,proto3" json:"code_challenge_method,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *OAuthAuthorization) Reset() {
+	*x = OAuthAuthorization{}
+	mi := &file_api_v1_oauth_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OAuthAuthorization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OAuthAuthorization) ProtoMessage() {}
+
+func (x *OAuthAuthorization) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_oauth_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OAuthAuthorization.ProtoReflect.Descriptor instead.
+func (*OAuthAuthorization) Descriptor() ([]byte, []int) {
+	return file_api_v1_oauth_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OAuthAuthorization) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *OAuthAuthorization) GetRedirectUri() string {
+	if x != nil {
+		return x.RedirectUri
+	}
+	return ""
+}
+
+func (x *OAuthAuthorization) GetResponseType() string {
+	if x != nil {
+		return x.ResponseType
+	}
+	return ""
+}
+
+func (x *OAuthAuthorization) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *OAuthAuthorization) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *OAuthAuthorization) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+func (x *OAuthAuthorization) GetCodeChallenge() string {
+	if x != nil {
+		return x.CodeChallenge
+	}
+	return ""
+}
+
+func (x *OAuthAuthorization) GetCodeChallengeMethod() string {
+	if x != nil {
+		return x.CodeChallengeMethod
+	}
+	return ""
+}
+
+type OAuthConsent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientName    string                 `protobuf:"bytes,2,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`
+	Scopes        []string               `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OAuthConsent) Reset() {
+	*x = OAuthConsent{}
+	mi := &file_api_v1_oauth_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OAuthConsent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OAuthConsent) ProtoMessage() {}
+
+func (x *OAuthConsent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_oauth_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OAuthConsent.ProtoReflect.Descriptor instead.
+func (*OAuthConsent) Descriptor() ([]byte, []int) {
+	return file_api_v1_oauth_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OAuthConsent) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *OAuthConsent) GetClientName() string {
+	if x != nil {
+		return x.ClientName
+	}
+	return ""
+}
+
+func (x *OAuthConsent) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *OAuthConsent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *OAuthConsent) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListOAuthClientsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOAuthClientsRequest) Reset() {
+	*x = ListOAuthClientsRequest{}
+	mi := &file_api_v1_oauth_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOAuthClientsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOAuthClientsRequest) ProtoMessage() {}
+
+func (x *ListOAuthClientsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_oauth_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOAuthClientsRequest.ProtoReflect.Descriptor instead.
+func (*ListOAuthClientsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_oauth_service_proto_rawDescGZIP(), []int{3}
+}
+
+type ListOAuthClientsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OauthClients  []*OAuthClient         `protobuf:"bytes,1,rep,name=oauth_clients,json=oauthClients,proto3" json:"oauth_clients,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOAuthClientsResponse) Reset() {
+	*x = ListOAuthClientsResponse{}
+	mi := &file_api_v1_oauth_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOAuthClientsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOAuthClientsResponse) ProtoMessage() {}
+
+func (x *ListOAuthClientsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_oauth_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOAuthClientsResponse.ProtoReflect.Descriptor instead.
+func (*ListOAuthClientsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_oauth_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListOAuthClientsResponse) GetOauthClients() []*OAuthClient {
+	if x != nil {
+		return x.OauthClients
+	}
+	return nil
+}
+
+type GetOAuthClientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOAuthClientRequest) Reset() {
+	*x = GetOAuthClientRequest{}
+	mi := &file_api_v1_oauth_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOAuthClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOAuthClientRequest) ProtoMessage() {}
+
+func (x *GetOAuthClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_oauth_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOAuthClientRequest.ProtoReflect.Descriptor instead.
+func (*GetOAuthClientRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_oauth_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetOAuthClientRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type GetOAuthClientResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OauthClient   *OAuthClient           `protobuf:"bytes,1,opt,name=oauth_client,json=oauthClient,proto3" json:"oauth_client,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOAuthClientResponse) Reset() {
+	*x = GetOAuthClientResponse{}
+	mi := &file_api_v1_oauth_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOAuthClientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOAuthClientResponse) ProtoMessage() {}
+
+func (x *GetOAuthClientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_oauth_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOAuthClientResponse.ProtoReflect.Descriptor instead.
+func (*GetOAuthClientResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_oauth_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetOAuthClientResponse) GetOauthClient() *OAuthClient {
+	if x != nil {
+		return x.OauthClient
+	}
+	return nil
+}
+
+type CreateOAuthClientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OauthClient   *OAuthClient           `protobuf:"bytes,1,opt,name=oauth_client,json=oauthClient,proto3" json:"oauth_client,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOAuthClientRequest) Reset() {
+	*x = CreateOAuthClientRequest{}
+	mi := &file_api_v1_oauth_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOAuthClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOAuthClientRequest) ProtoMessage() {}
+
+func (x *CreateOAuthClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_oauth_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOAuthClientRequest.ProtoReflect.Descriptor instead.
+func (*CreateOAuthClientRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_oauth_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateOAuthClientRequest) GetOauthClient() *OAuthClient {
+	if x != nil {
+		return x.OauthClient
+	}
+	return nil
+}
+
+type CreateOAuthClientResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	OauthClient *OAuthClient           `protobuf:"bytes,1,opt,name=oauth_client,json=oauthClient,proto3" json:"oauth_client,omitempty"`
+	// Empty for public clients. It cannot be retrieved later.
+	ClientSecret  string `protobuf:"bytes,2,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOAuthClientResponse) Reset() {
+	*x = CreateOAuthClientResponse{}
+	mi := &file_api_v1_oauth_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOAuthClientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOAuthClientResponse) ProtoMessage() {}
+
+func (x *CreateOAuthClientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_oauth_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOAuthClientResponse.ProtoReflect.Descriptor instead.
+func (*CreateOAuthClientResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_oauth_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateOAuthClientResponse) GetOauthClient() *OAuthClient {
+	if x != nil {
+		return x.OauthClient
+	}
+	return nil
+}
+
+func (x *CreateOAuthClientResponse) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+type UpdateOAuthClientRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	OauthClient *OAuthClient           `protobuf:"bytes,1,opt,name=oauth_client,json=oauthClient,proto3" json:"oauth_client,omitempty"`
+	// The fields to update: "name", "redirect_uris", "grant_types" and "scopes".
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOAuthClientRequest) Reset() {
+	*x = UpdateOAuthClientRequest{}
+	mi := &file_api_v1_oauth_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOAuthClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOAuthClientRequest) ProtoMessage() {}
+
+func (x *UpdateOAuthClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_oauth_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOAuthClientRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOAuthClientRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_oauth_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateOAuthClientRequest) GetOauthClient() *OAuthClient {
+	if x != nil {
+		return x.OauthClient
+	}
+	return nil
+}
+
+func (x *UpdateOAuthClientRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateOAuthClientResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OauthClient   *OAuthClient           `protobuf:"bytes,1,opt,name=oauth_client,json=oauthClient,proto3" json:"oauth_client,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOAuthClientResponse) Reset() {
+	*x = UpdateOAuthClientResponse{}
+	mi := &file_api_v1_oauth_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOAuthClientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOAuthClientResponse) ProtoMessage() {}
+
+func (x *UpdateOAuthClientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_oauth_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOAuthClientResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOAuthClientResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_oauth_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateOAuthClientResponse) GetOauthClient() *OAuthClient {
+	if x != nil {
+		return x.OauthClient
+	}
+	return nil
+}
+
+type RotateOAuthClientSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateOAuthClientSecretRequest) Reset() {
+	*x = RotateOAuthClientSecretRequest{}
+	mi := &file_api_v1_oauth_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateOAuthClientSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateOAuthClientSecretRequest) ProtoMessage() {}
+
+func (x *RotateOAuthClientSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_oauth_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateOAuthClientSecretRequest.ProtoReflect.Descriptor instead.
+func (*RotateOAuthClientSecretRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_oauth_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RotateOAuthClientSecretRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type RotateOAuthClientSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientSecret  string                 `protobuf:"bytes,1,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateOAuthClientSecretResponse) Reset() {
+	*x = RotateOAuthClientSecretResponse{}
+	mi := &file_api_v1_oauth_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateOAuthClientSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateOAuthClientSecretResponse) ProtoMessage() {}
+
+func (x *RotateOAuthClientSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_oauth_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateOAuthClientSecretResponse.ProtoReflect.Descriptor instead.
+func (*RotateOAuthClientSecretResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_oauth_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RotateOAuthClientSecretResponse) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+type DeleteOAuthClientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOAuthClientRequest) Reset() {
+	*x = DeleteOAuthClientRequest{}
+	mi := &file_api_v1_oauth_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOAuthClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOAuthClientRequest) ProtoMessage() {}
+
+func (x *DeleteOAuthClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_oauth_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOAuthClientRequest.ProtoReflect.Descriptor instead.
+func (*DeleteOAuthClientRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_oauth_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteOAuthClientRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type DeleteOAuthClientResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOAuthClientResponse) Reset() {
+	*x = DeleteOAuthClientResponse{}
+	mi := &file_api_v1_oauth_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOAuthClientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOAuthClientResponse) ProtoMessage() {}
+
+func (x *DeleteOAuthClientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_oauth_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOAuthClientResponse.ProtoReflect.Descriptor instead.
+func (*DeleteOAuthClientResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_oauth_service_proto_rawDescGZIP(), []int{14}
+}
+
+type GetOAuthAuthorizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Authorization *OAuthAuthorization    `protobuf:"bytes,1,opt,name=authorization,proto3" json:"authorization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOAuthAuthorizationRequest) Reset() {
+	*x = GetOAuthAuthorizationRequest{}
+	mi := &file_api_v1_oauth_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOAuthAuthorizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOAuthAuthorizationRequest) ProtoMessage() {}
+
+func (x *GetOAuthAuthorizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_oauth_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOAuthAuthorizationRequest.ProtoReflect.Descriptor instead.
+func (*GetOAuthAuthorizationRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_oauth_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetOAuthAuthorizationRequest) GetAuthorization() *OAuthAuthorization {
+	if x != nil {
+		return x.Authorization
+	}
+	return nil
+}
+
+type GetOAuthAuthorizationResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ClientName string                 `protobuf:"bytes,1,opt,name=client_name,json=clientName,proto3" json:"client_name,omitempty"`
+	// The scopes that will be granted.
+	Scopes []string `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// False when the user has already granted all of the scopes to the client,
+	// the request can then be approved without asking the user.
+	ConsentRequired bool `protobuf:"varint,3,opt,name=consent_required,json=consentRequired,proto3" json:"consent_required,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetOAuthAuthorizationResponse) Reset() {
+	*x = GetOAuthAuthorizationResponse{}
+	mi := &file_api_v1_oauth_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOAuthAuthorizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOAuthAuthorizationResponse) ProtoMessage() {}
+
+func (x *GetOAuthAuthorizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_oauth_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOAuthAuthorizationResponse.ProtoReflect.Descriptor instead.
+func (*GetOAuthAuthorizationResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_oauth_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetOAuthAuthorizationResponse) GetClientName() string {
+	if x != nil {
+		return x.ClientName
+	}
+	return ""
+}
+
+func (x *GetOAuthAuthorizationResponse) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *GetOAuthAuthorizationResponse) GetConsentRequired() bool {
+	if x != nil {
+		return x.ConsentRequired
+	}
+	return false
+}
+
+type ApproveOAuthAuthorizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Authorization *OAuthAuthorization    `protobuf:"bytes,1,opt,name=authorization,proto3" json:"authorization,omitempty"`
+	// False denies the request, the client receives an access_denied error.
+	Approved      bool `protobuf:"varint,2,opt,name=approved,proto3" json:"approved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveOAuthAuthorizationRequest) Reset() {
+	*x = ApproveOAuthAuthorizationRequest{}
+	mi := &file_api_v1_oauth_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveOAuthAuthorizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveOAuthAuthorizationRequest) ProtoMessage() {}
+
+func (x *ApproveOAuthAuthorizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_oauth_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveOAuthAuthorizationRequest.ProtoReflect.Descriptor instead.
+func (*ApproveOAuthAuthorizationRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_oauth_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ApproveOAuthAuthorizationRequest) GetAuthorization() *OAuthAuthorization {
+	if x != nil {
+		return x.Authorization
+	}
+	return nil
+}
+
+func (x *ApproveOAuthAuthorizationRequest) GetApproved() bool {
+	if x != nil {
+		return x.Approved
+	}
+	return false
+}
+
+type ApproveOAuthAuthorizationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The redirect URI of the client with the authorization code or the error.
+	RedirectUrl   string `protobuf:"bytes,1,opt,name=redirect_url,json=redirectUrl,proto3" json:"redirect_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveOAuthAuthorizationResponse) Reset() {
+	*x = ApproveOAuthAuthorizationResponse{}
+	mi := &file_api_v1_oauth_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveOAuthAuthorizationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveOAuthAuthorizationResponse) ProtoMessage() {}
+
+func (x *ApproveOAuthAuthorizationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_oauth_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveOAuthAuthorizationResponse.ProtoReflect.Descriptor instead.
+func (*ApproveOAuthAuthorizationResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_oauth_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ApproveOAuthAuthorizationResponse) GetRedirectUrl() string {
+	if x != nil {
+		return x.RedirectUrl
+	}
+	return ""
+}
+
+type ListOAuthConsentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOAuthConsentsRequest) Reset() {
+	*x = ListOAuthConsentsRequest{}
+	mi := &file_api_v1_oauth_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOAuthConsentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOAuthConsentsRequest) ProtoMessage() {}
+
+func (x *ListOAuthConsentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_oauth_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOAuthConsentsRequest.ProtoReflect.Descriptor instead.
+func (*ListOAuthConsentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_oauth_service_proto_rawDescGZIP(), []int{19}
+}
+
+type ListOAuthConsentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Consents      []*OAuthConsent        `protobuf:"bytes,1,rep,name=consents,proto3" json:"consents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOAuthConsentsResponse) Reset() {
+	*x = ListOAuthConsentsResponse{}
+	mi := &file_api_v1_oauth_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOAuthConsentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOAuthConsentsResponse) ProtoMessage() {}
+
+func (x *ListOAuthConsentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_oauth_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOAuthConsentsResponse.ProtoReflect.Descriptor instead.
+func (*ListOAuthConsentsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_oauth_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListOAuthConsentsResponse) GetConsents() []*OAuthConsent {
+	if x != nil {
+		return x.Consents
+	}
+	return nil
+}
+
+type RevokeOAuthConsentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeOAuthConsentRequest) Reset() {
+	*x = RevokeOAuthConsentRequest{}
+	mi := &file_api_v1_oauth_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeOAuthConsentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeOAuthConsentRequest) ProtoMessage() {}
+
+func (x *RevokeOAuthConsentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_oauth_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeOAuthConsentRequest.ProtoReflect.Descriptor instead.
+func (*RevokeOAuthConsentRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_oauth_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RevokeOAuthConsentRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type RevokeOAuthConsentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeOAuthConsentResponse) Reset() {
+	*x = RevokeOAuthConsentResponse{}
+	mi := &file_api_v1_oauth_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeOAuthConsentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeOAuthConsentResponse) ProtoMessage() {}
+
+func (x *RevokeOAuthConsentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_oauth_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeOAuthConsentResponse.ProtoReflect.Descriptor instead.
+func (*RevokeOAuthConsentResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_oauth_service_proto_rawDescGZIP(), []int{22}
+}
+
+var File_api_v1_oauth_service_proto protoreflect.FileDescriptor
+
+const file_api_v1_oauth_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1aapi/v1/oauth_service.proto\x12\x0fgoserver.api.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x14api/v1/options.proto\"\xde\x02\n" +
+	"\vOAuthClient\x12 \n" +
+	"\tclient_id\x18\x01 \x01(\tB\x03\xe0A\x03R\bclientId\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tB\x03\xe0A\x02R\x04name\x12(\n" +
+	"\rredirect_uris\x18\x03 \x03(\tB\x03\xe0A\x01R\fredirectUris\x12$\n" +
+	"\vgrant_types\x18\x04 \x03(\tB\x03\xe0A\x02R\n" +
+	"grantTypes\x12\x1b\n" +
+	"\x06scopes\x18\x05 \x03(\tB\x03\xe0A\x01R\x06scopes\x12'\n" +
+	"\fconfidential\x18\x06 \x01(\bB\x03\xe0A\x05R\fconfidential\x12>\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\tcreatedAt\x12>\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\tupdatedAt\"\xbe\x02\n" +
+	"\x12OAuthAuthorization\x12 \n" +
+	"\tclient_id\x18\x01 \x01(\tB\x03\xe0A\x02R\bclientId\x12&\n" +
+	"\fredirect_uri\x18\x02 \x01(\tB\x03\xe0A\x02R\vredirectUri\x12(\n" +
+	"\rresponse_type\x18\x03 \x01(\tB\x03\xe0A\x02R\fresponseType\x12\x19\n" +
+	"\x05scope\x18\x04 \x01(\tB\x03\xe0A\x01R\x05scope\x12\x19\n" +
+	"\x05state\x18\x05 \x01(\tB\x03\xe0A\x01R\x05state\x12\x19\n" +
+	"\x05nonce\x18\x06 \x01(\tB\x03\xe0A\x01R\x05nonce\x12*\n" +
+	"\x0ecode_challenge\x18\a \x01(\tB\x03\xe0A\x02R\rcodeChallenge\x127\n" +
+	"\x15code_challenge_method\x18\b \x01(\tB\x03\xe0A\x02R\x13codeChallengeMethod\"\xf3\x01\n" +
+	"\fOAuthConsent\x12 \n" +
+	"\tclient_id\x18\x01 \x01(\tB\x03\xe0A\x03R\bclientId\x12$\n" +
+	"\vclient_name\x18\x02 \x01(\tB\x03\xe0A\x03R\n" +
+	"clientName\x12\x1b\n" +
+	"\x06scopes\x18\x03 \x03(\tB\x03\xe0A\x03R\x06scopes\x12>\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\tcreatedAt\x12>\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\tupdatedAt\"\x19\n" +
+	"\x17ListOAuthClientsRequest\"b\n" +
+	"\x18ListOAuthClientsResponse\x12F\n" +
+	"\roauth_clients\x18\x01 \x03(\v2\x1c.goserver.api.v1.OAuthClientB\x03\xe0A\x03R\foauthClients\"9\n" +
+	"\x15GetOAuthClientRequest\x12 \n" +
+	"\tclient_id\x18\x01 \x01(\tB\x03\xe0A\x02R\bclientId\"^\n" +
+	"\x16GetOAuthClientResponse\x12D\n" +
+	"\foauth_client\x18\x01 \x01(\v2\x1c.goserver.api.v1.OAuthClientB\x03\xe0A\x03R\voauthClient\"`\n" +
+	"\x18CreateOAuthClientRequest\x12D\n" +
+	"\foauth_client\x18\x01 \x01(\v2\x1c.goserver.api.v1.OAuthClientB\x03\xe0A\x02R\voauthClient\"\x8b\x01\n" +
+	"\x19CreateOAuthClientResponse\x12D\n" +
+	"\foauth_client\x18\x01 \x01(\v2\x1c.goserver.api.v1.OAuthClientB\x03\xe0A\x03R\voauthClient\x12(\n" +
+	"\rclient_secret\x18\x02 \x01(\tB\x03\xe0A\x03R\fclientSecret\"\xa2\x01\n" +
+	"\x18UpdateOAuthClientRequest\x12D\n" +
+	"\foauth_client\x18\x01 \x01(\v2\x1c.goserver.api.v1.OAuthClientB\x03\xe0A\x02R\voauthClient\x12@\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskB\x03\xe0A\x02R\n" +
+	"updateMask\"a\n" +
+	"\x19UpdateOAuthClientResponse\x12D\n" +
+	"\foauth_client\x18\x01 \x01(\v2\x1c.goserver.api.v1.OAuthClientB\x03\xe0A\x03R\voauthClient\"B\n" +
+	"\x1eRotateOAuthClientSecretRequest\x12 \n" +
+	"\tclient_id\x18\x01 \x01(\tB\x03\xe0A\x02R\bclientId\"K\n" +
+	"\x1fRotateOAuthClientSecretResponse\x12(\n" +
+	"\rclient_secret\x18\x01 \x01(\tB\x03\xe0A\x03R\fclientSecret\"<\n" +
+	"\x18DeleteOAuthClientRequest\x12 \n" +
+	"\tclient_id\x18\x01 \x01(\tB\x03\xe0A\x02R\bclientId\"\x1b\n" +
+	"\x19DeleteOAuthClientResponse\"n\n" +
+	"\x1cGetOAuthAuthorizationRequest\x12N\n" +
+	"\rauthorization\x18\x01 \x01(\v2#.goserver.api.v1.OAuthAuthorizationB\x03\xe0A\x02R\rauthorization\"\x92\x01\n" +
+	"\x1dGetOAuthAuthorizationResponse\x12$\n" +
+	"\vclient_name\x18\x01 \x01(\tB\x03\xe0A\x03R\n" +
+	"clientName\x12\x1b\n" +
+	"\x06scopes\x18\x02 \x03(\tB\x03\xe0A\x03R\x06scopes\x12.\n" +
+	"\x10consent_required\x18\x03 \x01(\bB\x03\xe0A\x03R\x0fconsentRequired\"\x93\x01\n" +
+	" ApproveOAuthAuthorizationRequest\x12N\n" +
+	"\rauthorization\x18\x01 \x01(\v2#.goserver.api.v1.OAuthAuthorizationB\x03\xe0A\x02R\rauthorization\x12\x1f\n" +
+	"\bapproved\x18\x02 \x01(\bB\x03\xe0A\x02R\bapproved\"K\n" +
+	"!ApproveOAuthAuthorizationResponse\x12&\n" +
+	"\fredirect_url\x18\x01 \x01(\tB\x03\xe0A\x03R\vredirectUrl\"\x1a\n" +
+	"\x18ListOAuthConsentsRequest\"[\n" +
+	"\x19ListOAuthConsentsResponse\x12>\n" +
+	"\bconsents\x18\x01 \x03(\v2\x1d.goserver.api.v1.OAuthConsentB\x03\xe0A\x03R\bconsents\"=\n" +
+	"\x19RevokeOAuthConsentRequest\x12 \n" +
+	"\tclient_id\x18\x01 \x01(\tB\x03\xe0A\x02R\bclientId\"\x1c\n" +
+	"\x1aRevokeOAuthConsentResponse2\xf1\x0e\n" +
+	"\fOAuthService\x12\xa1\x01\n" +
+	"\x10ListOAuthClients\x12(.goserver.api.v1.ListOAuthClientsRequest\x1a).goserver.api.v1.ListOAuthClientsResponse\"8\xdaA\x00\x8a\xb5\x18\x14\x1a\x12oauth_clients.read\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/oauth-clients\x12\xb0\x01\n" +
+	"\x0eGetOAuthClient\x12&.goserver.api.v1.GetOAuthClientRequest\x1a'.goserver.api.v1.GetOAuthClientResponse\"M\xdaA\tclient_id\x8a\xb5\x18\x14\x1a\x12oauth_clients.read\x82\xd3\xe4\x93\x02#\x12!/api/v1/oauth-clients/{client_id}\x12\xbf\x01\n" +
+	"\x11CreateOAuthClient\x12).goserver.api.v1.CreateOAuthClientRequest\x1a*.goserver.api.v1.CreateOAuthClientResponse\"S\xdaA\foauth_client\x8a\xb5\x18\x15\x1a\x13oauth_clients.write\x82\xd3\xe4\x93\x02%:\foauth_client\"\x15/api/v1/oauth-clients\x12\xe4\x01\n" +
+	"\x11UpdateOAuthClient\x12).goserver.api.v1.UpdateOAuthClientRequest\x1a*.goserver.api.v1.UpdateOAuthClientResponse\"x\xdaA\x18oauth_client,update_mask\x8a\xb5\x18\x15\x1a\x13oauth_clients.write\x82\xd3\xe4\x93\x02>:\foauth_client2./api/v1/oauth-clients/{oauth_client.client_id}\x12\xdd\x01\n" +
+	"\x17RotateOAuthClientSecret\x12/.goserver.api.v1.RotateOAuthClientSecretRequest\x1a0.goserver.api.v1.RotateOAuthClientSecretResponse\"_\xdaA\tclient_id\x8a\xb5\x18\x15\x1a\x13oauth_clients.write\x82\xd3\xe4\x93\x024:\x01*\"//api/v1/oauth-clients/{client_id}/rotate-secret\x12\xba\x01\n" +
+	"\x11DeleteOAuthClient\x12).goserver.api.v1.DeleteOAuthClientRequest\x1a*.goserver.api.v1.DeleteOAuthClientResponse\"N\xdaA\tclient_id\x8a\xb5\x18\x15\x1a\x13oauth_clients.write\x82\xd3\xe4\x93\x02#*!/api/v1/oauth-clients/{client_id}\x12\xab\x01\n" +
+	"\x15GetOAuthAuthorization\x12-.goserver.api.v1.GetOAuthAuthorizationRequest\x1a..goserver.api.v1.GetOAuthAuthorizationResponse\"3\xdaA\rauthorization\x82\xd3\xe4\x93\x02\x1d\x12\x1b/api/v1/oauth-authorization\x12\xcb\x01\n" +
+	"\x19ApproveOAuthAuthorization\x121.goserver.api.v1.ApproveOAuthAuthorizationRequest\x1a2.goserver.api.v1.ApproveOAuthAuthorizationResponse\"G\xdaA\x16authorization,approved\x82\xd3\xe4\x93\x02(:\x01*\"#/api/v1/oauth-authorization/approve\x12\x96\x01\n" +
+	"\x11ListOAuthConsents\x12).goserver.api.v1.ListOAuthConsentsRequest\x1a*.goserver.api.v1.ListOAuthConsentsResponse\"*\xdaA\x00\x82\xd3\xe4\x93\x02!\x12\x1f/api/v1/users/me/oauth-consents\x12\xae\x01\n" +
+	"\x12RevokeOAuthConsent\x12*.goserver.api.v1.RevokeOAuthConsentRequest\x1a+.goserver.api.v1.RevokeOAuthConsentResponse\"?\xdaA\tclient_id\x82\xd3\xe4\x93\x02-*+/api/v1/users/me/oauth-consents/{client_id}B\xb8\x01\n" +
+	"\x13com.goserver.api.v1B\x11OauthServiceProtoP\x01Z0github.com/pixb/go-server/proto/gen/api/v1;apiv1\xa2\x02\x03GAX\xaa\x02\x0fGoserver.Api.V1\xca\x02\x0fGoserver\\Api\\V1\xe2\x02\x1bGoserver\\Api\\V1\\GPBMetadata\xea\x02\x11Goserver::Api::V1b\x06proto3"
+
+var (
+	file_api_v1_oauth_service_proto_rawDescOnce sync.Once
+	file_api_v1_oauth_service_proto_rawDescData []byte
+)
+
+func file_api_v1_oauth_service_proto_rawDescGZIP() []byte {
+	file_api_v1_oauth_service_proto_rawDescOnce.Do(func() {
+		file_api_v1_oauth_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_v1_oauth_service_proto_rawDesc), len(file_api_v1_oauth_service_proto_rawDesc)))
+	})
+	return file_api_v1_oauth_service_proto_rawDescData
+}
+
+var file_api_v1_oauth_service_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_api_v1_oauth_service_proto_goTypes = []any{
+	(*OAuthClient)(nil),                       // 0: goserver.api.v1.OAuthClient
+	(*OAuthAuthorization)(nil),                // 1: goserver.api.v1.OAuthAuthorization
+	(*OAuthConsent)(nil),                      // 2: goserver.api.v1.OAuthConsent
+	(*ListOAuthClientsRequest)(nil),           // 3: goserver.api.v1.ListOAuthClientsRequest
+	(*ListOAuthClientsResponse)(nil),          // 4: goserver.api.v1.ListOAuthClientsResponse
+	(*GetOAuthClientRequest)(nil),             // 5: goserver.api.v1.GetOAuthClientRequest
+	(*GetOAuthClientResponse)(nil),            // 6: goserver.api.v1.GetOAuthClientResponse
+	(*CreateOAuthClientRequest)(nil),          // 7: goserver.api.v1.CreateOAuthClientRequest
+	(*CreateOAuthClientResponse)(nil),         // 8: goserver.api.v1.CreateOAuthClientResponse
+	(*UpdateOAuthClientRequest)(nil),          // 9: goserver.api.v1.UpdateOAuthClientRequest
+	(*UpdateOAuthClientResponse)(nil),         // 10: goserver.api.v1.UpdateOAuthClientResponse
+	(*RotateOAuthClientSecretRequest)(nil),    // 11: goserver.api.v1.RotateOAuthClientSecretRequest
+	(*RotateOAuthClientSecretResponse)(nil),   // 12: goserver.api.v1.RotateOAuthClientSecretResponse
+	(*DeleteOAuthClientRequest)(nil),          // 13: goserver.api.v1.DeleteOAuthClientRequest
+	(*DeleteOAuthClientResponse)(nil),         // 14: goserver.api.v1.DeleteOAuthClientResponse
+	(*GetOAuthAuthorizationRequest)(nil),      // 15: goserver.api.v1.GetOAuthAuthorizationRequest
+	(*GetOAuthAuthorizationResponse)(nil),     // 16: goserver.api.v1.GetOAuthAuthorizationResponse
+	(*ApproveOAuthAuthorizationRequest)(nil),  // 17: goserver.api.v1.ApproveOAuthAuthorizationRequest
+	(*ApproveOAuthAuthorizationResponse)(nil), // 18: goserver.api.v1.ApproveOAuthAuthorizationResponse
+	(*ListOAuthConsentsRequest)(nil),          // 19: goserver.api.v1.ListOAuthConsentsRequest
+	(*ListOAuthConsentsResponse)(nil),         // 20: goserver.api.v1.ListOAuthConsentsResponse
+	(*RevokeOAuthConsentRequest)(nil),         // 21: goserver.api.v1.RevokeOAuthConsentRequest
+	(*RevokeOAuthConsentResponse)(nil),        // 22: goserver.api.v1.RevokeOAuthConsentResponse
+	(*timestamppb.Timestamp)(nil),             // 23: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),             // 24: google.protobuf.FieldMask
+}
+var file_api_v1_oauth_service_proto_depIdxs = []int32{
+	23, // 0: goserver.api.v1.OAuthClient.created_at:type_name -> google.protobuf.Timestamp
+	23, // 1: goserver.api.v1.OAuthClient.updated_at:type_name -> google.protobuf.Timestamp
+	23, // 2: goserver.api.v1.OAuthConsent.created_at:type_name -> google.protobuf.Timestamp
+	23, // 3: goserver.api.v1.OAuthConsent.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: goserver.api.v1.ListOAuthClientsResponse.oauth_clients:type_name -> goserver.api.v1.OAuthClient
+	0,  // 5: goserver.api.v1.GetOAuthClientResponse.oauth_client:type_name -> goserver.api.v1.OAuthClient
+	0,  // 6: goserver.api.v1.CreateOAuthClientRequest.oauth_client:type_name -> goserver.api.v1.OAuthClient
+	0,  // 7: goserver.api.v1.CreateOAuthClientResponse.oauth_client:type_name -> goserver.api.v1.OAuthClient
+	0,  // 8: goserver.api.v1.UpdateOAuthClientRequest.oauth_client:type_name -> goserver.api.v1.OAuthClient
+	24, // 9: goserver.api.v1.UpdateOAuthClientRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 10: goserver.api.v1.UpdateOAuthClientResponse.oauth_client:type_name -> goserver.api.v1.OAuthClient
+	1,  // 11: goserver.api.v1.GetOAuthAuthorizationRequest.authorization:type_name -> goserver.api.v1.OAuthAuthorization
+	1,  // 12: goserver.api.v1.ApproveOAuthAuthorizationRequest.authorization:type_name -> goserver.api.v1.OAuthAuthorization
+	2,  // 13: goserver.api.v1.ListOAuthConsentsResponse.consents:type_name -> goserver.api.v1.OAuthConsent
+	3,  // 14: goserver.api.v1.OAuthService.ListOAuthClients:input_type -> goserver.api.v1.ListOAuthClientsRequest
+	5,  // 15: goserver.api.v1.OAuthService.GetOAuthClient:input_type -> goserver.api.v1.GetOAuthClientRequest
+	7,  // 16: goserver.api.v1.OAuthService.CreateOAuthClient:input_type -> goserver.api.v1.CreateOAuthClientRequest
+	9,  // 17: goserver.api.v1.OAuthService.UpdateOAuthClient:input_type -> goserver.api.v1.UpdateOAuthClientRequest
+	11, // 18: goserver.api.v1.OAuthService.RotateOAuthClientSecret:input_type -> goserver.api.v1.RotateOAuthClientSecretRequest
+	13, // 19: goserver.api.v1.OAuthService.DeleteOAuthClient:input_type -> goserver.api.v1.DeleteOAuthClientRequest
+	15, // 20: goserver.api.v1.OAuthService.GetOAuthAuthorization:input_type -> goserver.api.v1.GetOAuthAuthorizationRequest
+	17, // 21: goserver.api.v1.OAuthService.ApproveOAuthAuthorization:input_type -> goserver.api.v1.ApproveOAuthAuthorizationRequest
+	19, // 22: goserver.api.v1.OAuthService.ListOAuthConsents:input_type -> goserver.api.v1.ListOAuthConsentsRequest
+	21, // 23: goserver.api.v1.OAuthService.RevokeOAuthConsent:input_type -> goserver.api.v1.RevokeOAuthConsentRequest
+	4,  // 24: goserver.api.v1.OAuthService.ListOAuthClients:output_type -> goserver.api.v1.ListOAuthClientsResponse
+	6,  // 25: goserver.api.v1.OAuthService.GetOAuthClient:output_type -> goserver.api.v1.GetOAuthClientResponse
+	8,  // 26: goserver.api.v1.OAuthService.CreateOAuthClient:output_type -> goserver.api.v1.CreateOAuthClientResponse
+	10, // 27: goserver.api.v1.OAuthService.UpdateOAuthClient:output_type -> goserver.api.v1.UpdateOAuthClientResponse
+	12, // 28: goserver.api.v1.OAuthService.RotateOAuthClientSecret:output_type -> goserver.api.v1.RotateOAuthClientSecretResponse
+	14, // 29: goserver.api.v1.OAuthService.DeleteOAuthClient:output_type -> goserver.api.v1.DeleteOAuthClientResponse
+	16, // 30: goserver.api.v1.OAuthService.GetOAuthAuthorization:output_type -> goserver.api.v1.GetOAuthAuthorizationResponse
+	18, // 31: goserver.api.v1.OAuthService.ApproveOAuthAuthorization:output_type -> goserver.api.v1.ApproveOAuthAuthorizationResponse
+	20, // 32: goserver.api.v1.OAuthService.ListOAuthConsents:output_type -> goserver.api.v1.ListOAuthConsentsResponse
+	22, // 33: goserver.api.v1.OAuthService.RevokeOAuthConsent:output_type -> goserver.api.v1.RevokeOAuthConsentResponse
+	24, // [24:34] is the sub-list for method output_type
+	14, // [14:24] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_api_v1_oauth_service_proto_init() }
+func file_api_v1_oauth_service_proto_init() {
+	if File_api_v1_oauth_service_proto != nil {
+		return
+	}
+	file_api_v1_options_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_oauth_service_proto_rawDesc), len(file_api_v1_oauth_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   23,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_v1_oauth_service_proto_goTypes,
+		DependencyIndexes: file_api_v1_oauth_service_proto_depIdxs,
+		MessageInfos:      file_api_v1_oauth_service_proto_msgTypes,
+	}.Build()
+	File_api_v1_oauth_service_proto = out.File
+	file_api_v1_oauth_service_proto_goTypes = nil
+	file_api_v1_oauth_service_proto_depIdxs = nil
+}
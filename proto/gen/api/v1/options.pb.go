@@ -0,0 +1,250 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: api/v1/options.proto
+
+package apiv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// AuthRule declares who may call an RPC method. Methods without a rule can be
+// called by any authenticated user.
+type AuthRule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The method can be called without authentication.
+	Public bool `protobuf:"varint,1,opt,name=public,proto3" json:"public,omitempty"`
+	// The caller must have one of the roles. Any authenticated caller is allowed when empty.
+	Roles []Role `protobuf:"varint,2,rep,packed,name=roles,proto3,enum=goserver.api.v1.Role" json:"roles,omitempty"`
+	// The caller must have all of the permissions, such as "users.read".
+	Permissions   []string `protobuf:"bytes,3,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthRule) Reset() {
+	*x = AuthRule{}
+	mi := &file_api_v1_options_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthRule) ProtoMessage() {}
+
+func (x *AuthRule) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_options_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthRule.ProtoReflect.Descriptor instead.
+func (*AuthRule) Descriptor() ([]byte, []int) {
+	return file_api_v1_options_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuthRule) GetPublic() bool {
+	if x != nil {
+		return x.Public
+	}
+	return false
+}
+
+func (x *AuthRule) GetRoles() []Role {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *AuthRule) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+// MaintenanceRule declares the maintenance modes an RPC method is served in. Methods
+// are served in read-only mode if their HTTP rule is a GET, and only while the instance
+// is in service otherwise.
+type MaintenanceRule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The method does not change data and is served in read-only mode.
+	ReadOnly bool `protobuf:"varint,1,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	// The method is served in all modes, including full maintenance.
+	Always        bool `protobuf:"varint,2,opt,name=always,proto3" json:"always,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MaintenanceRule) Reset() {
+	*x = MaintenanceRule{}
+	mi := &file_api_v1_options_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaintenanceRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaintenanceRule) ProtoMessage() {}
+
+func (x *MaintenanceRule) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_options_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaintenanceRule.ProtoReflect.Descriptor instead.
+func (*MaintenanceRule) Descriptor() ([]byte, []int) {
+	return file_api_v1_options_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MaintenanceRule) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+func (x *MaintenanceRule) GetAlways() bool {
+	if x != nil {
+		return x.Always
+	}
+	return false
+}
+
+var file_api_v1_options_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*AuthRule)(nil),
+		Field:         50001,
+		Name:          "goserver.api.v1.auth",
+		Tag:           "bytes,50001,opt,name=auth",
+		Filename:      "api/v1/options.proto",
+	},
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*MaintenanceRule)(nil),
+		Field:         50002,
+		Name:          "goserver.api.v1.maintenance",
+		Tag:           "bytes,50002,opt,name=maintenance",
+		Filename:      "api/v1/options.proto",
+	},
+}
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// The authorization rule enforced on all protocols before the method is called.
+	//
+	// optional goserver.api.v1.AuthRule auth = 50001;
+	E_Auth = &file_api_v1_options_proto_extTypes[0]
+	// The maintenance rule enforced on all protocols before authentication.
+	//
+	// optional goserver.api.v1.MaintenanceRule maintenance = 50002;
+	E_Maintenance = &file_api_v1_options_proto_extTypes[1]
+)
+
+var File_api_v1_options_proto protoreflect.FileDescriptor
+
+const file_api_v1_options_proto_rawDesc = "" +
+	"\n" +
+	"\x14api/v1/options.proto\x12\x0fgoserver.api.v1\x1a\x13api/v1/common.proto\x1a google/protobuf/descriptor.proto\"q\n" +
+	"\bAuthRule\x12\x16\n" +
+	"\x06public\x18\x01 \x01(\bR\x06public\x12+\n" +
+	"\x05roles\x18\x02 \x03(\x0e2\x15.goserver.api.v1.RoleR\x05roles\x12 \n" +
+	"\vpermissions\x18\x03 \x03(\tR\vpermissions\"F\n" +
+	"\x0fMaintenanceRule\x12\x1b\n" +
+	"\tread_only\x18\x01 \x01(\bR\breadOnly\x12\x16\n" +
+	"\x06always\x18\x02 \x01(\bR\x06always:O\n" +
+	"\x04auth\x12\x1e.google.protobuf.MethodOptions\x18ц\x03 \x01(\v2\x19.goserver.api.v1.AuthRuleR\x04auth:d\n" +
+	"\vmaintenance\x12\x1e.google.protobuf.MethodOptions\x18҆\x03 \x01(\v2 .goserver.api.v1.MaintenanceRuleR\vmaintenanceB\xb3\x01\n" +
+	"\x13com.goserver.api.v1B\fOptionsProtoP\x01Z0github.com/pixb/go-server/proto/gen/api/v1;apiv1\xa2\x02\x03GAX\xaa\x02\x0fGoserver.Api.V1\xca\x02\x0fGoserver\\Api\\V1\xe2\x02\x1bGoserver\\Api\\V1\\GPBMetadata\xea\x02\x11Goserver::Api::V1b\x06proto3"
+
+var (
+	file_api_v1_options_proto_rawDescOnce sync.Once
+	file_api_v1_options_proto_rawDescData []byte
+)
+
+func file_api_v1_options_proto_rawDescGZIP() []byte {
+	file_api_v1_options_proto_rawDescOnce.Do(func() {
+		file_api_v1_options_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_v1_options_proto_rawDesc), len(file_api_v1_options_proto_rawDesc)))
+	})
+	return file_api_v1_options_proto_rawDescData
+}
+
+var file_api_v1_options_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_api_v1_options_proto_goTypes = []any{
+	(*AuthRule)(nil),                   // 0: goserver.api.v1.AuthRule
+	(*MaintenanceRule)(nil),            // 1: goserver.api.v1.MaintenanceRule
+	(Role)(0),                          // 2: goserver.api.v1.Role
+	(*descriptorpb.MethodOptions)(nil), // 3: google.protobuf.MethodOptions
+}
+var file_api_v1_options_proto_depIdxs = []int32{
+	2, // 0: goserver.api.v1.AuthRule.roles:type_name -> goserver.api.v1.Role
+	3, // 1: goserver.api.v1.auth:extendee -> google.protobuf.MethodOptions
+	3, // 2: goserver.api.v1.maintenance:extendee -> google.protobuf.MethodOptions
+	0, // 3: goserver.api.v1.auth:type_name -> goserver.api.v1.AuthRule
+	1, // 4: goserver.api.v1.maintenance:type_name -> goserver.api.v1.MaintenanceRule
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	3, // [3:5] is the sub-list for extension type_name
+	1, // [1:3] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_api_v1_options_proto_init() }
+func file_api_v1_options_proto_init() {
+	if File_api_v1_options_proto != nil {
+		return
+	}
+	file_api_v1_common_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_options_proto_rawDesc), len(file_api_v1_options_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 2,
+			NumServices:   0,
+		},
+		GoTypes:           file_api_v1_options_proto_goTypes,
+		DependencyIndexes: file_api_v1_options_proto_depIdxs,
+		MessageInfos:      file_api_v1_options_proto_msgTypes,
+		ExtensionInfos:    file_api_v1_options_proto_extTypes,
+	}.Build()
+	File_api_v1_options_proto = out.File
+	file_api_v1_options_proto_goTypes = nil
+	file_api_v1_options_proto_depIdxs = nil
+}
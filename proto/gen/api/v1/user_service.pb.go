@@ -24,12 +24,15 @@ const (
 )
 
 type RegisterUserRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Nickname      string                 `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
-	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
-	Phone         string                 `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
-	Email         string                 `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Username string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Nickname string                 `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Password string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	Phone    string                 `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
+	Email    string                 `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	// An invite code from AdminService.CreateInvite. It is required when the registration
+	// policy is invite-only and grants the role of the invite.
+	InviteCode    string `protobuf:"bytes,6,opt,name=invite_code,json=inviteCode,proto3" json:"invite_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -99,6 +102,13 @@ func (x *RegisterUserRequest) GetEmail() string {
 	return ""
 }
 
+func (x *RegisterUserRequest) GetInviteCode() string {
+	if x != nil {
+		return x.InviteCode
+	}
+	return ""
+}
+
 type RegisterUserResponse struct {
 	state                protoimpl.MessageState `protogen:"open.v1"`
 	AccessToken          string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
@@ -308,8 +318,10 @@ func (x *UpdateUserProfileRequest) GetEmail() string {
 }
 
 type UpdateUserProfileResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	User  *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	// 待验证的新邮箱，验证前 user.email 仍为原邮箱
+	PendingEmail  string `protobuf:"bytes,2,opt,name=pending_email,json=pendingEmail,proto3" json:"pending_email,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -351,6 +363,13 @@ func (x *UpdateUserProfileResponse) GetUser() *User {
 	return nil
 }
 
+func (x *UpdateUserProfileResponse) GetPendingEmail() string {
+	if x != nil {
+		return x.PendingEmail
+	}
+	return ""
+}
+
 type ChangePasswordRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OldPassword   string                 `protobuf:"bytes,1,opt,name=old_password,json=oldPassword,proto3" json:"old_password,omitempty"`
@@ -447,41 +466,1310 @@ func (x *ChangePasswordResponse) GetUser() *User {
 	return nil
 }
 
-var File_api_v1_user_service_proto protoreflect.FileDescriptor
+// 个人访问令牌，令牌明文只在创建时返回一次
+type PersonalAccessToken struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// 为空表示永不过期
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_api_v1_user_service_proto_rawDesc = "" +
-	"\n" +
-	"\x19api/v1/user_service.proto\x12\x0fgoserver.api.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13api/v1/common.proto\"\xae\x01\n" +
-	"\x13RegisterUserRequest\x12\x1f\n" +
-	"\busername\x18\x01 \x01(\tB\x03\xe0A\x02R\busername\x12\x1f\n" +
-	"\bnickname\x18\x02 \x01(\tB\x03\xe0A\x02R\bnickname\x12\x1f\n" +
-	"\bpassword\x18\x03 \x01(\tB\x03\xe0A\x02R\bpassword\x12\x19\n" +
-	"\x05phone\x18\x04 \x01(\tB\x03\xe0A\x02R\x05phone\x12\x19\n" +
-	"\x05email\x18\x05 \x01(\tB\x03\xe0A\x02R\x05email\"\xf0\x01\n" +
-	"\x14RegisterUserResponse\x12&\n" +
-	"\faccess_token\x18\x01 \x01(\tB\x03\xe0A\x03R\vaccessToken\x12(\n" +
-	"\rrefresh_token\x18\x02 \x01(\tB\x03\xe0A\x03R\frefreshToken\x12V\n" +
-	"\x17access_token_expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\x14accessTokenExpiresAt\x12.\n" +
-	"\x04user\x18\x04 \x01(\v2\x15.goserver.api.v1.UserB\x03\xe0A\x03R\x04user\"\x17\n" +
-	"\x15GetUserProfileRequest\"H\n" +
-	"\x16GetUserProfileResponse\x12.\n" +
-	"\x04user\x18\x01 \x01(\v2\x15.goserver.api.v1.UserB\x03\xe0A\x03R\x04user\"q\n" +
-	"\x18UpdateUserProfileRequest\x12\x1f\n" +
-	"\bnickname\x18\x01 \x01(\tB\x03\xe0A\x01R\bnickname\x12\x19\n" +
-	"\x05phone\x18\x02 \x01(\tB\x03\xe0A\x01R\x05phone\x12\x19\n" +
-	"\x05email\x18\x03 \x01(\tB\x03\xe0A\x01R\x05email\"K\n" +
-	"\x19UpdateUserProfileResponse\x12.\n" +
-	"\x04user\x18\x01 \x01(\v2\x15.goserver.api.v1.UserB\x03\xe0A\x03R\x04user\"g\n" +
-	"\x15ChangePasswordRequest\x12&\n" +
-	"\fold_password\x18\x01 \x01(\tB\x03\xe0A\x02R\voldPassword\x12&\n" +
-	"\fnew_password\x18\x02 \x01(\tB\x03\xe0A\x02R\vnewPassword\"H\n" +
-	"\x16ChangePasswordResponse\x12.\n" +
-	"\x04user\x18\x01 \x01(\v2\x15.goserver.api.v1.UserB\x03\xe0A\x03R\x04user2\xf5\x04\n" +
-	"\vUserService\x12\x9e\x01\n" +
-	"\fRegisterUser\x12$.goserver.api.v1.RegisterUserRequest\x1a%.goserver.api.v1.RegisterUserResponse\"A\xdaA&username,nickname,password,phone,email\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/api/v1/users\x12~\n" +
-	"\x0eGetUserProfile\x12&.goserver.api.v1.GetUserProfileRequest\x1a'.goserver.api.v1.GetUserProfileResponse\"\x1b\xdaA\x00\x82\xd3\xe4\x93\x02\x12\x12\x10/api/v1/users/me\x12\x9e\x01\n" +
-	"\x11UpdateUserProfile\x12).goserver.api.v1.UpdateUserProfileRequest\x1a*.goserver.api.v1.UpdateUserProfileResponse\"2\xdaA\x14nickname,phone,email\x82\xd3\xe4\x93\x02\x15:\x01*2\x10/api/v1/users/me\x12\xa3\x01\n" +
-	"\x0eChangePassword\x12&.goserver.api.v1.ChangePasswordRequest\x1a'.goserver.api.v1.ChangePasswordResponse\"@\xdaA\x19old_password,new_password\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/api/v1/users/me/passwordB\xb7\x01\n" +
+func (x *PersonalAccessToken) Reset() {
+	*x = PersonalAccessToken{}
+	mi := &file_api_v1_user_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PersonalAccessToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PersonalAccessToken) ProtoMessage() {}
+
+func (x *PersonalAccessToken) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PersonalAccessToken.ProtoReflect.Descriptor instead.
+func (*PersonalAccessToken) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PersonalAccessToken) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PersonalAccessToken) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PersonalAccessToken) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *PersonalAccessToken) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *PersonalAccessToken) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreatePersonalAccessTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Description   string                 `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePersonalAccessTokenRequest) Reset() {
+	*x = CreatePersonalAccessTokenRequest{}
+	mi := &file_api_v1_user_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePersonalAccessTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePersonalAccessTokenRequest) ProtoMessage() {}
+
+func (x *CreatePersonalAccessTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePersonalAccessTokenRequest.ProtoReflect.Descriptor instead.
+func (*CreatePersonalAccessTokenRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreatePersonalAccessTokenRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreatePersonalAccessTokenRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type CreatePersonalAccessTokenResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	PersonalAccessToken *PersonalAccessToken   `protobuf:"bytes,1,opt,name=personal_access_token,json=personalAccessToken,proto3" json:"personal_access_token,omitempty"`
+	// 令牌明文，以 pat_ 开头
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePersonalAccessTokenResponse) Reset() {
+	*x = CreatePersonalAccessTokenResponse{}
+	mi := &file_api_v1_user_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePersonalAccessTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePersonalAccessTokenResponse) ProtoMessage() {}
+
+func (x *CreatePersonalAccessTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePersonalAccessTokenResponse.ProtoReflect.Descriptor instead.
+func (*CreatePersonalAccessTokenResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreatePersonalAccessTokenResponse) GetPersonalAccessToken() *PersonalAccessToken {
+	if x != nil {
+		return x.PersonalAccessToken
+	}
+	return nil
+}
+
+func (x *CreatePersonalAccessTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ListPersonalAccessTokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPersonalAccessTokensRequest) Reset() {
+	*x = ListPersonalAccessTokensRequest{}
+	mi := &file_api_v1_user_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPersonalAccessTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPersonalAccessTokensRequest) ProtoMessage() {}
+
+func (x *ListPersonalAccessTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPersonalAccessTokensRequest.ProtoReflect.Descriptor instead.
+func (*ListPersonalAccessTokensRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{11}
+}
+
+type ListPersonalAccessTokensResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	PersonalAccessTokens []*PersonalAccessToken `protobuf:"bytes,1,rep,name=personal_access_tokens,json=personalAccessTokens,proto3" json:"personal_access_tokens,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ListPersonalAccessTokensResponse) Reset() {
+	*x = ListPersonalAccessTokensResponse{}
+	mi := &file_api_v1_user_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPersonalAccessTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPersonalAccessTokensResponse) ProtoMessage() {}
+
+func (x *ListPersonalAccessTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPersonalAccessTokensResponse.ProtoReflect.Descriptor instead.
+func (*ListPersonalAccessTokensResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListPersonalAccessTokensResponse) GetPersonalAccessTokens() []*PersonalAccessToken {
+	if x != nil {
+		return x.PersonalAccessTokens
+	}
+	return nil
+}
+
+type DeletePersonalAccessTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePersonalAccessTokenRequest) Reset() {
+	*x = DeletePersonalAccessTokenRequest{}
+	mi := &file_api_v1_user_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePersonalAccessTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePersonalAccessTokenRequest) ProtoMessage() {}
+
+func (x *DeletePersonalAccessTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePersonalAccessTokenRequest.ProtoReflect.Descriptor instead.
+func (*DeletePersonalAccessTokenRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeletePersonalAccessTokenRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeletePersonalAccessTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePersonalAccessTokenResponse) Reset() {
+	*x = DeletePersonalAccessTokenResponse{}
+	mi := &file_api_v1_user_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePersonalAccessTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePersonalAccessTokenResponse) ProtoMessage() {}
+
+func (x *DeletePersonalAccessTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePersonalAccessTokenResponse.ProtoReflect.Descriptor instead.
+func (*DeletePersonalAccessTokenResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{14}
+}
+
+// 登录会话，每次登录产生一个会话，刷新令牌时会话保持不变
+type Session struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserAgent string                 `protobuf:"bytes,2,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	IpAddress string                 `protobuf:"bytes,3,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// 最近一次登录或刷新令牌的时间
+	LastUsedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	ExpiresAt  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// 是否为发起本次请求的会话
+	Current       bool `protobuf:"varint,7,opt,name=current,proto3" json:"current,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_api_v1_user_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Session) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Session) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *Session) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *Session) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Session) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *Session) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Session) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+type ListSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsRequest) Reset() {
+	*x = ListSessionsRequest{}
+	mi := &file_api_v1_user_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsRequest) ProtoMessage() {}
+
+func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{16}
+}
+
+type ListSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*Session             `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	mi := &file_api_v1_user_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsResponse) ProtoMessage() {}
+
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListSessionsResponse) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type RevokeSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionRequest) Reset() {
+	*x = RevokeSessionRequest{}
+	mi := &file_api_v1_user_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionRequest) ProtoMessage() {}
+
+func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSessionRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RevokeSessionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RevokeSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionResponse) Reset() {
+	*x = RevokeSessionResponse{}
+	mi := &file_api_v1_user_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionResponse) ProtoMessage() {}
+
+func (x *RevokeSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionResponse.ProtoReflect.Descriptor instead.
+func (*RevokeSessionResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{19}
+}
+
+type RevokeAllOtherSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAllOtherSessionsRequest) Reset() {
+	*x = RevokeAllOtherSessionsRequest{}
+	mi := &file_api_v1_user_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAllOtherSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAllOtherSessionsRequest) ProtoMessage() {}
+
+func (x *RevokeAllOtherSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAllOtherSessionsRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAllOtherSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{20}
+}
+
+type RevokeAllOtherSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RevokedCount  int32                  `protobuf:"varint,1,opt,name=revoked_count,json=revokedCount,proto3" json:"revoked_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAllOtherSessionsResponse) Reset() {
+	*x = RevokeAllOtherSessionsResponse{}
+	mi := &file_api_v1_user_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAllOtherSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAllOtherSessionsResponse) ProtoMessage() {}
+
+func (x *RevokeAllOtherSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAllOtherSessionsResponse.ProtoReflect.Descriptor instead.
+func (*RevokeAllOtherSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RevokeAllOtherSessionsResponse) GetRevokedCount() int32 {
+	if x != nil {
+		return x.RevokedCount
+	}
+	return 0
+}
+
+type EnrollTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollTOTPRequest) Reset() {
+	*x = EnrollTOTPRequest{}
+	mi := &file_api_v1_user_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTOTPRequest) ProtoMessage() {}
+
+func (x *EnrollTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTOTPRequest.ProtoReflect.Descriptor instead.
+func (*EnrollTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{22}
+}
+
+type EnrollTOTPResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Base32 编码的 TOTP 密钥，用于手动输入验证器
+	Secret string `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	// otpauth:// 格式的 URI，用于生成二维码
+	OtpauthUri string `protobuf:"bytes,2,opt,name=otpauth_uri,json=otpauthUri,proto3" json:"otpauth_uri,omitempty"`
+	// 一次性恢复码，只在绑定时返回一次
+	RecoveryCodes []string `protobuf:"bytes,3,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollTOTPResponse) Reset() {
+	*x = EnrollTOTPResponse{}
+	mi := &file_api_v1_user_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTOTPResponse) ProtoMessage() {}
+
+func (x *EnrollTOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTOTPResponse.ProtoReflect.Descriptor instead.
+func (*EnrollTOTPResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *EnrollTOTPResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *EnrollTOTPResponse) GetOtpauthUri() string {
+	if x != nil {
+		return x.OtpauthUri
+	}
+	return ""
+}
+
+func (x *EnrollTOTPResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+type ConfirmTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTOTPRequest) Reset() {
+	*x = ConfirmTOTPRequest{}
+	mi := &file_api_v1_user_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTOTPRequest) ProtoMessage() {}
+
+func (x *ConfirmTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTOTPRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ConfirmTOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ConfirmTOTPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTOTPResponse) Reset() {
+	*x = ConfirmTOTPResponse{}
+	mi := &file_api_v1_user_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTOTPResponse) ProtoMessage() {}
+
+func (x *ConfirmTOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTOTPResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmTOTPResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{25}
+}
+
+type DisableTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Password      string                 `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTOTPRequest) Reset() {
+	*x = DisableTOTPRequest{}
+	mi := &file_api_v1_user_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTOTPRequest) ProtoMessage() {}
+
+func (x *DisableTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTOTPRequest.ProtoReflect.Descriptor instead.
+func (*DisableTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DisableTOTPRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type DisableTOTPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTOTPResponse) Reset() {
+	*x = DisableTOTPResponse{}
+	mi := &file_api_v1_user_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTOTPResponse) ProtoMessage() {}
+
+func (x *DisableTOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTOTPResponse.ProtoReflect.Descriptor instead.
+func (*DisableTOTPResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{27}
+}
+
+type UnlockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockUserRequest) Reset() {
+	*x = UnlockUserRequest{}
+	mi := &file_api_v1_user_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockUserRequest) ProtoMessage() {}
+
+func (x *UnlockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockUserRequest.ProtoReflect.Descriptor instead.
+func (*UnlockUserRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UnlockUserRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type UnlockUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockUserResponse) Reset() {
+	*x = UnlockUserResponse{}
+	mi := &file_api_v1_user_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockUserResponse) ProtoMessage() {}
+
+func (x *UnlockUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockUserResponse.ProtoReflect.Descriptor instead.
+func (*UnlockUserResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{29}
+}
+
+type VerifyEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyEmailRequest) Reset() {
+	*x = VerifyEmailRequest{}
+	mi := &file_api_v1_user_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmailRequest) ProtoMessage() {}
+
+func (x *VerifyEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmailRequest.ProtoReflect.Descriptor instead.
+func (*VerifyEmailRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *VerifyEmailRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type VerifyEmailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyEmailResponse) Reset() {
+	*x = VerifyEmailResponse{}
+	mi := &file_api_v1_user_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmailResponse) ProtoMessage() {}
+
+func (x *VerifyEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmailResponse.ProtoReflect.Descriptor instead.
+func (*VerifyEmailResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *VerifyEmailResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type ResendVerificationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 未验证的邮箱，或待验证的新邮箱
+	Email         string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResendVerificationRequest) Reset() {
+	*x = ResendVerificationRequest{}
+	mi := &file_api_v1_user_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResendVerificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResendVerificationRequest) ProtoMessage() {}
+
+func (x *ResendVerificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResendVerificationRequest.ProtoReflect.Descriptor instead.
+func (*ResendVerificationRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ResendVerificationRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type ResendVerificationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResendVerificationResponse) Reset() {
+	*x = ResendVerificationResponse{}
+	mi := &file_api_v1_user_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResendVerificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResendVerificationResponse) ProtoMessage() {}
+
+func (x *ResendVerificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_user_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResendVerificationResponse.ProtoReflect.Descriptor instead.
+func (*ResendVerificationResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_user_service_proto_rawDescGZIP(), []int{33}
+}
+
+var File_api_v1_user_service_proto protoreflect.FileDescriptor
+
+const file_api_v1_user_service_proto_rawDesc = "" +
+	"\n" +
+	"\x19api/v1/user_service.proto\x12\x0fgoserver.api.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x17google/api/client.proto\x1a\x1fgoogle/api/field_behavior.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13api/v1/common.proto\x1a\x14api/v1/options.proto\"\xd4\x01\n" +
+	"\x13RegisterUserRequest\x12\x1f\n" +
+	"\busername\x18\x01 \x01(\tB\x03\xe0A\x02R\busername\x12\x1f\n" +
+	"\bnickname\x18\x02 \x01(\tB\x03\xe0A\x02R\bnickname\x12\x1f\n" +
+	"\bpassword\x18\x03 \x01(\tB\x03\xe0A\x02R\bpassword\x12\x19\n" +
+	"\x05phone\x18\x04 \x01(\tB\x03\xe0A\x02R\x05phone\x12\x19\n" +
+	"\x05email\x18\x05 \x01(\tB\x03\xe0A\x02R\x05email\x12$\n" +
+	"\vinvite_code\x18\x06 \x01(\tB\x03\xe0A\x01R\n" +
+	"inviteCode\"\xf0\x01\n" +
+	"\x14RegisterUserResponse\x12&\n" +
+	"\faccess_token\x18\x01 \x01(\tB\x03\xe0A\x03R\vaccessToken\x12(\n" +
+	"\rrefresh_token\x18\x02 \x01(\tB\x03\xe0A\x03R\frefreshToken\x12V\n" +
+	"\x17access_token_expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\x14accessTokenExpiresAt\x12.\n" +
+	"\x04user\x18\x04 \x01(\v2\x15.goserver.api.v1.UserB\x03\xe0A\x03R\x04user\"\x17\n" +
+	"\x15GetUserProfileRequest\"H\n" +
+	"\x16GetUserProfileResponse\x12.\n" +
+	"\x04user\x18\x01 \x01(\v2\x15.goserver.api.v1.UserB\x03\xe0A\x03R\x04user\"q\n" +
+	"\x18UpdateUserProfileRequest\x12\x1f\n" +
+	"\bnickname\x18\x01 \x01(\tB\x03\xe0A\x01R\bnickname\x12\x19\n" +
+	"\x05phone\x18\x02 \x01(\tB\x03\xe0A\x01R\x05phone\x12\x19\n" +
+	"\x05email\x18\x03 \x01(\tB\x03\xe0A\x01R\x05email\"u\n" +
+	"\x19UpdateUserProfileResponse\x12.\n" +
+	"\x04user\x18\x01 \x01(\v2\x15.goserver.api.v1.UserB\x03\xe0A\x03R\x04user\x12(\n" +
+	"\rpending_email\x18\x02 \x01(\tB\x03\xe0A\x03R\fpendingEmail\"g\n" +
+	"\x15ChangePasswordRequest\x12&\n" +
+	"\fold_password\x18\x01 \x01(\tB\x03\xe0A\x02R\voldPassword\x12&\n" +
+	"\fnew_password\x18\x02 \x01(\tB\x03\xe0A\x02R\vnewPassword\"H\n" +
+	"\x16ChangePasswordResponse\x12.\n" +
+	"\x04user\x18\x01 \x01(\v2\x15.goserver.api.v1.UserB\x03\xe0A\x03R\x04user\"\x8a\x02\n" +
+	"\x13PersonalAccessToken\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\x03B\x03\xe0A\x03R\x02id\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12A\n" +
+	"\flast_used_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
+	"lastUsedAt\x12>\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\tcreatedAt\"\x89\x01\n" +
+	" CreatePersonalAccessTokenRequest\x12%\n" +
+	"\vdescription\x18\x01 \x01(\tB\x03\xe0A\x02R\vdescription\x12>\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x01R\texpiresAt\"\x9d\x01\n" +
+	"!CreatePersonalAccessTokenResponse\x12]\n" +
+	"\x15personal_access_token\x18\x01 \x01(\v2$.goserver.api.v1.PersonalAccessTokenB\x03\xe0A\x03R\x13personalAccessToken\x12\x19\n" +
+	"\x05token\x18\x02 \x01(\tB\x03\xe0A\x03R\x05token\"!\n" +
+	"\x1fListPersonalAccessTokensRequest\"\x83\x01\n" +
+	" ListPersonalAccessTokensResponse\x12_\n" +
+	"\x16personal_access_tokens\x18\x01 \x03(\v2$.goserver.api.v1.PersonalAccessTokenB\x03\xe0A\x03R\x14personalAccessTokens\"7\n" +
+	" DeletePersonalAccessTokenRequest\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\x03B\x03\xe0A\x02R\x02id\"#\n" +
+	"!DeletePersonalAccessTokenResponse\"\xc8\x02\n" +
+	"\aSession\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\tB\x03\xe0A\x03R\x02id\x12\"\n" +
+	"\n" +
+	"user_agent\x18\x02 \x01(\tB\x03\xe0A\x03R\tuserAgent\x12\"\n" +
+	"\n" +
+	"ip_address\x18\x03 \x01(\tB\x03\xe0A\x03R\tipAddress\x12>\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\tcreatedAt\x12A\n" +
+	"\flast_used_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\n" +
+	"lastUsedAt\x12>\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampB\x03\xe0A\x03R\texpiresAt\x12\x1d\n" +
+	"\acurrent\x18\a \x01(\bB\x03\xe0A\x03R\acurrent\"\x15\n" +
+	"\x13ListSessionsRequest\"Q\n" +
+	"\x14ListSessionsResponse\x129\n" +
+	"\bsessions\x18\x01 \x03(\v2\x18.goserver.api.v1.SessionB\x03\xe0A\x03R\bsessions\"+\n" +
+	"\x14RevokeSessionRequest\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\tB\x03\xe0A\x02R\x02id\"\x17\n" +
+	"\x15RevokeSessionResponse\"\x1f\n" +
+	"\x1dRevokeAllOtherSessionsRequest\"J\n" +
+	"\x1eRevokeAllOtherSessionsResponse\x12(\n" +
+	"\rrevoked_count\x18\x01 \x01(\x05B\x03\xe0A\x03R\frevokedCount\"\x13\n" +
+	"\x11EnrollTOTPRequest\"\x83\x01\n" +
+	"\x12EnrollTOTPResponse\x12\x1b\n" +
+	"\x06secret\x18\x01 \x01(\tB\x03\xe0A\x03R\x06secret\x12$\n" +
+	"\votpauth_uri\x18\x02 \x01(\tB\x03\xe0A\x03R\n" +
+	"otpauthUri\x12*\n" +
+	"\x0erecovery_codes\x18\x03 \x03(\tB\x03\xe0A\x03R\rrecoveryCodes\"-\n" +
+	"\x12ConfirmTOTPRequest\x12\x17\n" +
+	"\x04code\x18\x01 \x01(\tB\x03\xe0A\x02R\x04code\"\x15\n" +
+	"\x13ConfirmTOTPResponse\"5\n" +
+	"\x12DisableTOTPRequest\x12\x1f\n" +
+	"\bpassword\x18\x01 \x01(\tB\x03\xe0A\x02R\bpassword\"\x15\n" +
+	"\x13DisableTOTPResponse\"(\n" +
+	"\x11UnlockUserRequest\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\x03B\x03\xe0A\x02R\x02id\"\x14\n" +
+	"\x12UnlockUserResponse\"/\n" +
+	"\x12VerifyEmailRequest\x12\x19\n" +
+	"\x05token\x18\x01 \x01(\tB\x03\xe0A\x02R\x05token\"E\n" +
+	"\x13VerifyEmailResponse\x12.\n" +
+	"\x04user\x18\x01 \x01(\v2\x15.goserver.api.v1.UserB\x03\xe0A\x03R\x04user\"6\n" +
+	"\x19ResendVerificationRequest\x12\x19\n" +
+	"\x05email\x18\x01 \x01(\tB\x03\xe0A\x02R\x05email\"\x1c\n" +
+	"\x1aResendVerificationResponse2\xf6\x13\n" +
+	"\vUserService\x12\xa4\x01\n" +
+	"\fRegisterUser\x12$.goserver.api.v1.RegisterUserRequest\x1a%.goserver.api.v1.RegisterUserResponse\"G\xdaA&username,nickname,password,phone,email\x8a\xb5\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/api/v1/users\x12~\n" +
+	"\x0eGetUserProfile\x12&.goserver.api.v1.GetUserProfileRequest\x1a'.goserver.api.v1.GetUserProfileResponse\"\x1b\xdaA\x00\x82\xd3\xe4\x93\x02\x12\x12\x10/api/v1/users/me\x12\x9e\x01\n" +
+	"\x11UpdateUserProfile\x12).goserver.api.v1.UpdateUserProfileRequest\x1a*.goserver.api.v1.UpdateUserProfileResponse\"2\xdaA\x14nickname,phone,email\x82\xd3\xe4\x93\x02\x15:\x01*2\x10/api/v1/users/me\x12\xa3\x01\n" +
+	"\x0eChangePassword\x12&.goserver.api.v1.ChangePasswordRequest\x1a'.goserver.api.v1.ChangePasswordResponse\"@\xdaA\x19old_password,new_password\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/api/v1/users/me/password\x12\xcf\x01\n" +
+	"\x19CreatePersonalAccessToken\x121.goserver.api.v1.CreatePersonalAccessTokenRequest\x1a2.goserver.api.v1.CreatePersonalAccessTokenResponse\"K\xdaA\x16description,expires_at\x82\xd3\xe4\x93\x02,:\x01*\"'/api/v1/users/me/personal-access-tokens\x12\xb3\x01\n" +
+	"\x18ListPersonalAccessTokens\x120.goserver.api.v1.ListPersonalAccessTokensRequest\x1a1.goserver.api.v1.ListPersonalAccessTokensResponse\"2\xdaA\x00\x82\xd3\xe4\x93\x02)\x12'/api/v1/users/me/personal-access-tokens\x12\xbd\x01\n" +
+	"\x19DeletePersonalAccessToken\x121.goserver.api.v1.DeletePersonalAccessTokenRequest\x1a2.goserver.api.v1.DeletePersonalAccessTokenResponse\"9\xdaA\x02id\x82\xd3\xe4\x93\x02.*,/api/v1/users/me/personal-access-tokens/{id}\x12\x81\x01\n" +
+	"\fListSessions\x12$.goserver.api.v1.ListSessionsRequest\x1a%.goserver.api.v1.ListSessionsResponse\"$\xdaA\x00\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/users/me/sessions\x12\x8b\x01\n" +
+	"\rRevokeSession\x12%.goserver.api.v1.RevokeSessionRequest\x1a&.goserver.api.v1.RevokeSessionResponse\"+\xdaA\x02id\x82\xd3\xe4\x93\x02 *\x1e/api/v1/users/me/sessions/{id}\x12\xb0\x01\n" +
+	"\x16RevokeAllOtherSessions\x12..goserver.api.v1.RevokeAllOtherSessionsRequest\x1a/.goserver.api.v1.RevokeAllOtherSessionsResponse\"5\xdaA\x00\x82\xd3\xe4\x93\x02,:\x01*\"'/api/v1/users/me/sessions/revoke-others\x12z\n" +
+	"\n" +
+	"EnrollTOTP\x12\".goserver.api.v1.EnrollTOTPRequest\x1a#.goserver.api.v1.EnrollTOTPResponse\"#\xdaA\x00\x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/api/v1/users/me/totp\x12\x89\x01\n" +
+	"\vConfirmTOTP\x12#.goserver.api.v1.ConfirmTOTPRequest\x1a$.goserver.api.v1.ConfirmTOTPResponse\"/\xdaA\x04code\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/api/v1/users/me/totp/confirm\x12\x8d\x01\n" +
+	"\vDisableTOTP\x12#.goserver.api.v1.DisableTOTPRequest\x1a$.goserver.api.v1.DisableTOTPResponse\"3\xdaA\bpassword\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/api/v1/users/me/totp/disable\x12\x91\x01\n" +
+	"\n" +
+	"UnlockUser\x12\".goserver.api.v1.UnlockUserRequest\x1a#.goserver.api.v1.UnlockUserResponse\":\xdaA\x02id\x8a\xb5\x18\r\x1a\vusers.write\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/api/v1/users/{id}/unlock\x12\x8d\x01\n" +
+	"\vVerifyEmail\x12#.goserver.api.v1.VerifyEmailRequest\x1a$.goserver.api.v1.VerifyEmailResponse\"3\xdaA\x05token\x8a\xb5\x18\x02\b\x01\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/api/v1/users/email/verify\x12\xaf\x01\n" +
+	"\x12ResendVerification\x12*.goserver.api.v1.ResendVerificationRequest\x1a+.goserver.api.v1.ResendVerificationResponse\"@\xdaA\x05email\x8a\xb5\x18\x02\b\x01\x82\xd3\xe4\x93\x02,:\x01*\"'/api/v1/users/email/resend-verificationB\xb7\x01\n" +
 	"\x13com.goserver.api.v1B\x10UserServiceProtoP\x01Z0github.com/pixb/go-server/proto/gen/api/v1;apiv1\xa2\x02\x03GAX\xaa\x02\x0fGoserver.Api.V1\xca\x02\x0fGoserver\\Api\\V1\xe2\x02\x1bGoserver\\Api\\V1\\GPBMetadata\xea\x02\x11Goserver::Api::V1b\x06proto3"
 
 var (
@@ -496,38 +1784,99 @@ func file_api_v1_user_service_proto_rawDescGZIP() []byte {
 	return file_api_v1_user_service_proto_rawDescData
 }
 
-var file_api_v1_user_service_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_api_v1_user_service_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
 var file_api_v1_user_service_proto_goTypes = []any{
-	(*RegisterUserRequest)(nil),       // 0: goserver.api.v1.RegisterUserRequest
-	(*RegisterUserResponse)(nil),      // 1: goserver.api.v1.RegisterUserResponse
-	(*GetUserProfileRequest)(nil),     // 2: goserver.api.v1.GetUserProfileRequest
-	(*GetUserProfileResponse)(nil),    // 3: goserver.api.v1.GetUserProfileResponse
-	(*UpdateUserProfileRequest)(nil),  // 4: goserver.api.v1.UpdateUserProfileRequest
-	(*UpdateUserProfileResponse)(nil), // 5: goserver.api.v1.UpdateUserProfileResponse
-	(*ChangePasswordRequest)(nil),     // 6: goserver.api.v1.ChangePasswordRequest
-	(*ChangePasswordResponse)(nil),    // 7: goserver.api.v1.ChangePasswordResponse
-	(*timestamppb.Timestamp)(nil),     // 8: google.protobuf.Timestamp
-	(*User)(nil),                      // 9: goserver.api.v1.User
+	(*RegisterUserRequest)(nil),               // 0: goserver.api.v1.RegisterUserRequest
+	(*RegisterUserResponse)(nil),              // 1: goserver.api.v1.RegisterUserResponse
+	(*GetUserProfileRequest)(nil),             // 2: goserver.api.v1.GetUserProfileRequest
+	(*GetUserProfileResponse)(nil),            // 3: goserver.api.v1.GetUserProfileResponse
+	(*UpdateUserProfileRequest)(nil),          // 4: goserver.api.v1.UpdateUserProfileRequest
+	(*UpdateUserProfileResponse)(nil),         // 5: goserver.api.v1.UpdateUserProfileResponse
+	(*ChangePasswordRequest)(nil),             // 6: goserver.api.v1.ChangePasswordRequest
+	(*ChangePasswordResponse)(nil),            // 7: goserver.api.v1.ChangePasswordResponse
+	(*PersonalAccessToken)(nil),               // 8: goserver.api.v1.PersonalAccessToken
+	(*CreatePersonalAccessTokenRequest)(nil),  // 9: goserver.api.v1.CreatePersonalAccessTokenRequest
+	(*CreatePersonalAccessTokenResponse)(nil), // 10: goserver.api.v1.CreatePersonalAccessTokenResponse
+	(*ListPersonalAccessTokensRequest)(nil),   // 11: goserver.api.v1.ListPersonalAccessTokensRequest
+	(*ListPersonalAccessTokensResponse)(nil),  // 12: goserver.api.v1.ListPersonalAccessTokensResponse
+	(*DeletePersonalAccessTokenRequest)(nil),  // 13: goserver.api.v1.DeletePersonalAccessTokenRequest
+	(*DeletePersonalAccessTokenResponse)(nil), // 14: goserver.api.v1.DeletePersonalAccessTokenResponse
+	(*Session)(nil),                           // 15: goserver.api.v1.Session
+	(*ListSessionsRequest)(nil),               // 16: goserver.api.v1.ListSessionsRequest
+	(*ListSessionsResponse)(nil),              // 17: goserver.api.v1.ListSessionsResponse
+	(*RevokeSessionRequest)(nil),              // 18: goserver.api.v1.RevokeSessionRequest
+	(*RevokeSessionResponse)(nil),             // 19: goserver.api.v1.RevokeSessionResponse
+	(*RevokeAllOtherSessionsRequest)(nil),     // 20: goserver.api.v1.RevokeAllOtherSessionsRequest
+	(*RevokeAllOtherSessionsResponse)(nil),    // 21: goserver.api.v1.RevokeAllOtherSessionsResponse
+	(*EnrollTOTPRequest)(nil),                 // 22: goserver.api.v1.EnrollTOTPRequest
+	(*EnrollTOTPResponse)(nil),                // 23: goserver.api.v1.EnrollTOTPResponse
+	(*ConfirmTOTPRequest)(nil),                // 24: goserver.api.v1.ConfirmTOTPRequest
+	(*ConfirmTOTPResponse)(nil),               // 25: goserver.api.v1.ConfirmTOTPResponse
+	(*DisableTOTPRequest)(nil),                // 26: goserver.api.v1.DisableTOTPRequest
+	(*DisableTOTPResponse)(nil),               // 27: goserver.api.v1.DisableTOTPResponse
+	(*UnlockUserRequest)(nil),                 // 28: goserver.api.v1.UnlockUserRequest
+	(*UnlockUserResponse)(nil),                // 29: goserver.api.v1.UnlockUserResponse
+	(*VerifyEmailRequest)(nil),                // 30: goserver.api.v1.VerifyEmailRequest
+	(*VerifyEmailResponse)(nil),               // 31: goserver.api.v1.VerifyEmailResponse
+	(*ResendVerificationRequest)(nil),         // 32: goserver.api.v1.ResendVerificationRequest
+	(*ResendVerificationResponse)(nil),        // 33: goserver.api.v1.ResendVerificationResponse
+	(*timestamppb.Timestamp)(nil),             // 34: google.protobuf.Timestamp
+	(*User)(nil),                              // 35: goserver.api.v1.User
 }
 var file_api_v1_user_service_proto_depIdxs = []int32{
-	8, // 0: goserver.api.v1.RegisterUserResponse.access_token_expires_at:type_name -> google.protobuf.Timestamp
-	9, // 1: goserver.api.v1.RegisterUserResponse.user:type_name -> goserver.api.v1.User
-	9, // 2: goserver.api.v1.GetUserProfileResponse.user:type_name -> goserver.api.v1.User
-	9, // 3: goserver.api.v1.UpdateUserProfileResponse.user:type_name -> goserver.api.v1.User
-	9, // 4: goserver.api.v1.ChangePasswordResponse.user:type_name -> goserver.api.v1.User
-	0, // 5: goserver.api.v1.UserService.RegisterUser:input_type -> goserver.api.v1.RegisterUserRequest
-	2, // 6: goserver.api.v1.UserService.GetUserProfile:input_type -> goserver.api.v1.GetUserProfileRequest
-	4, // 7: goserver.api.v1.UserService.UpdateUserProfile:input_type -> goserver.api.v1.UpdateUserProfileRequest
-	6, // 8: goserver.api.v1.UserService.ChangePassword:input_type -> goserver.api.v1.ChangePasswordRequest
-	1, // 9: goserver.api.v1.UserService.RegisterUser:output_type -> goserver.api.v1.RegisterUserResponse
-	3, // 10: goserver.api.v1.UserService.GetUserProfile:output_type -> goserver.api.v1.GetUserProfileResponse
-	5, // 11: goserver.api.v1.UserService.UpdateUserProfile:output_type -> goserver.api.v1.UpdateUserProfileResponse
-	7, // 12: goserver.api.v1.UserService.ChangePassword:output_type -> goserver.api.v1.ChangePasswordResponse
-	9, // [9:13] is the sub-list for method output_type
-	5, // [5:9] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	34, // 0: goserver.api.v1.RegisterUserResponse.access_token_expires_at:type_name -> google.protobuf.Timestamp
+	35, // 1: goserver.api.v1.RegisterUserResponse.user:type_name -> goserver.api.v1.User
+	35, // 2: goserver.api.v1.GetUserProfileResponse.user:type_name -> goserver.api.v1.User
+	35, // 3: goserver.api.v1.UpdateUserProfileResponse.user:type_name -> goserver.api.v1.User
+	35, // 4: goserver.api.v1.ChangePasswordResponse.user:type_name -> goserver.api.v1.User
+	34, // 5: goserver.api.v1.PersonalAccessToken.expires_at:type_name -> google.protobuf.Timestamp
+	34, // 6: goserver.api.v1.PersonalAccessToken.last_used_at:type_name -> google.protobuf.Timestamp
+	34, // 7: goserver.api.v1.PersonalAccessToken.created_at:type_name -> google.protobuf.Timestamp
+	34, // 8: goserver.api.v1.CreatePersonalAccessTokenRequest.expires_at:type_name -> google.protobuf.Timestamp
+	8,  // 9: goserver.api.v1.CreatePersonalAccessTokenResponse.personal_access_token:type_name -> goserver.api.v1.PersonalAccessToken
+	8,  // 10: goserver.api.v1.ListPersonalAccessTokensResponse.personal_access_tokens:type_name -> goserver.api.v1.PersonalAccessToken
+	34, // 11: goserver.api.v1.Session.created_at:type_name -> google.protobuf.Timestamp
+	34, // 12: goserver.api.v1.Session.last_used_at:type_name -> google.protobuf.Timestamp
+	34, // 13: goserver.api.v1.Session.expires_at:type_name -> google.protobuf.Timestamp
+	15, // 14: goserver.api.v1.ListSessionsResponse.sessions:type_name -> goserver.api.v1.Session
+	35, // 15: goserver.api.v1.VerifyEmailResponse.user:type_name -> goserver.api.v1.User
+	0,  // 16: goserver.api.v1.UserService.RegisterUser:input_type -> goserver.api.v1.RegisterUserRequest
+	2,  // 17: goserver.api.v1.UserService.GetUserProfile:input_type -> goserver.api.v1.GetUserProfileRequest
+	4,  // 18: goserver.api.v1.UserService.UpdateUserProfile:input_type -> goserver.api.v1.UpdateUserProfileRequest
+	6,  // 19: goserver.api.v1.UserService.ChangePassword:input_type -> goserver.api.v1.ChangePasswordRequest
+	9,  // 20: goserver.api.v1.UserService.CreatePersonalAccessToken:input_type -> goserver.api.v1.CreatePersonalAccessTokenRequest
+	11, // 21: goserver.api.v1.UserService.ListPersonalAccessTokens:input_type -> goserver.api.v1.ListPersonalAccessTokensRequest
+	13, // 22: goserver.api.v1.UserService.DeletePersonalAccessToken:input_type -> goserver.api.v1.DeletePersonalAccessTokenRequest
+	16, // 23: goserver.api.v1.UserService.ListSessions:input_type -> goserver.api.v1.ListSessionsRequest
+	18, // 24: goserver.api.v1.UserService.RevokeSession:input_type -> goserver.api.v1.RevokeSessionRequest
+	20, // 25: goserver.api.v1.UserService.RevokeAllOtherSessions:input_type -> goserver.api.v1.RevokeAllOtherSessionsRequest
+	22, // 26: goserver.api.v1.UserService.EnrollTOTP:input_type -> goserver.api.v1.EnrollTOTPRequest
+	24, // 27: goserver.api.v1.UserService.ConfirmTOTP:input_type -> goserver.api.v1.ConfirmTOTPRequest
+	26, // 28: goserver.api.v1.UserService.DisableTOTP:input_type -> goserver.api.v1.DisableTOTPRequest
+	28, // 29: goserver.api.v1.UserService.UnlockUser:input_type -> goserver.api.v1.UnlockUserRequest
+	30, // 30: goserver.api.v1.UserService.VerifyEmail:input_type -> goserver.api.v1.VerifyEmailRequest
+	32, // 31: goserver.api.v1.UserService.ResendVerification:input_type -> goserver.api.v1.ResendVerificationRequest
+	1,  // 32: goserver.api.v1.UserService.RegisterUser:output_type -> goserver.api.v1.RegisterUserResponse
+	3,  // 33: goserver.api.v1.UserService.GetUserProfile:output_type -> goserver.api.v1.GetUserProfileResponse
+	5,  // 34: goserver.api.v1.UserService.UpdateUserProfile:output_type -> goserver.api.v1.UpdateUserProfileResponse
+	7,  // 35: goserver.api.v1.UserService.ChangePassword:output_type -> goserver.api.v1.ChangePasswordResponse
+	10, // 36: goserver.api.v1.UserService.CreatePersonalAccessToken:output_type -> goserver.api.v1.CreatePersonalAccessTokenResponse
+	12, // 37: goserver.api.v1.UserService.ListPersonalAccessTokens:output_type -> goserver.api.v1.ListPersonalAccessTokensResponse
+	14, // 38: goserver.api.v1.UserService.DeletePersonalAccessToken:output_type -> goserver.api.v1.DeletePersonalAccessTokenResponse
+	17, // 39: goserver.api.v1.UserService.ListSessions:output_type -> goserver.api.v1.ListSessionsResponse
+	19, // 40: goserver.api.v1.UserService.RevokeSession:output_type -> goserver.api.v1.RevokeSessionResponse
+	21, // 41: goserver.api.v1.UserService.RevokeAllOtherSessions:output_type -> goserver.api.v1.RevokeAllOtherSessionsResponse
+	23, // 42: goserver.api.v1.UserService.EnrollTOTP:output_type -> goserver.api.v1.EnrollTOTPResponse
+	25, // 43: goserver.api.v1.UserService.ConfirmTOTP:output_type -> goserver.api.v1.ConfirmTOTPResponse
+	27, // 44: goserver.api.v1.UserService.DisableTOTP:output_type -> goserver.api.v1.DisableTOTPResponse
+	29, // 45: goserver.api.v1.UserService.UnlockUser:output_type -> goserver.api.v1.UnlockUserResponse
+	31, // 46: goserver.api.v1.UserService.VerifyEmail:output_type -> goserver.api.v1.VerifyEmailResponse
+	33, // 47: goserver.api.v1.UserService.ResendVerification:output_type -> goserver.api.v1.ResendVerificationResponse
+	32, // [32:48] is the sub-list for method output_type
+	16, // [16:32] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_user_service_proto_init() }
@@ -536,13 +1885,14 @@ func file_api_v1_user_service_proto_init() {
 		return
 	}
 	file_api_v1_common_proto_init()
+	file_api_v1_options_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_user_service_proto_rawDesc), len(file_api_v1_user_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -9,6 +9,7 @@ package store
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -27,6 +28,24 @@ const (
 	InstanceSettingKey_INSTANCE_SETTING_KEY_UNSPECIFIED InstanceSettingKey = 0
 	// BASIC is the key for basic settings.
 	InstanceSettingKey_BASIC InstanceSettingKey = 1
+	// JWT_SIGNING_KEYS is the key for the access token signing keys.
+	InstanceSettingKey_JWT_SIGNING_KEYS InstanceSettingKey = 2
+	// SECURITY is the key for security policies.
+	InstanceSettingKey_SECURITY InstanceSettingKey = 3
+	// PASSWORD_POLICY is the key for the rules passwords must satisfy.
+	InstanceSettingKey_PASSWORD_POLICY InstanceSettingKey = 4
+	// IDENTITY_PROVIDERS is the key for the external OpenID Connect providers users can sign in with.
+	InstanceSettingKey_IDENTITY_PROVIDERS InstanceSettingKey = 5
+	// GENERAL is the key for the name and presentation of the instance.
+	InstanceSettingKey_GENERAL InstanceSettingKey = 6
+	// SETUP is the key for the record of the first-run setup. It is written once by the
+	// setup and never changed, its presence locks the setup.
+	InstanceSettingKey_SETUP InstanceSettingKey = 7
+	// STORAGE is the key for where uploaded files are kept.
+	InstanceSettingKey_STORAGE InstanceSettingKey = 8
+	// MAINTENANCE is the key for taking the instance out of service, set with the
+	// maintenance command.
+	InstanceSettingKey_MAINTENANCE InstanceSettingKey = 9
 )
 
 // Enum value maps for InstanceSettingKey.
@@ -34,10 +53,26 @@ var (
 	InstanceSettingKey_name = map[int32]string{
 		0: "INSTANCE_SETTING_KEY_UNSPECIFIED",
 		1: "BASIC",
+		2: "JWT_SIGNING_KEYS",
+		3: "SECURITY",
+		4: "PASSWORD_POLICY",
+		5: "IDENTITY_PROVIDERS",
+		6: "GENERAL",
+		7: "SETUP",
+		8: "STORAGE",
+		9: "MAINTENANCE",
 	}
 	InstanceSettingKey_value = map[string]int32{
 		"INSTANCE_SETTING_KEY_UNSPECIFIED": 0,
 		"BASIC":                            1,
+		"JWT_SIGNING_KEYS":                 2,
+		"SECURITY":                         3,
+		"PASSWORD_POLICY":                  4,
+		"IDENTITY_PROVIDERS":               5,
+		"GENERAL":                          6,
+		"SETUP":                            7,
+		"STORAGE":                          8,
+		"MAINTENANCE":                      9,
 	}
 )
 
@@ -68,12 +103,188 @@ func (InstanceSettingKey) EnumDescriptor() ([]byte, []int) {
 	return file_store_instance_setting_proto_rawDescGZIP(), []int{0}
 }
 
+type RegistrationPolicy_Mode int32
+
+const (
+	// Anyone can register, the default.
+	RegistrationPolicy_MODE_UNSPECIFIED RegistrationPolicy_Mode = 0
+	RegistrationPolicy_OPEN             RegistrationPolicy_Mode = 1
+	// Nobody can register, not even with an invite.
+	RegistrationPolicy_DISABLED RegistrationPolicy_Mode = 2
+	// Only holders of an invite code can register.
+	RegistrationPolicy_INVITE_ONLY RegistrationPolicy_Mode = 3
+	// Only addresses of allowed_email_domains or holders of an invite code can register.
+	// Users then have to verify their email address before signing in, and identity
+	// providers have to vouch for the addresses of the users they sign in.
+	RegistrationPolicy_ALLOWED_EMAIL_DOMAINS RegistrationPolicy_Mode = 4
+)
+
+// Enum value maps for RegistrationPolicy_Mode.
+var (
+	RegistrationPolicy_Mode_name = map[int32]string{
+		0: "MODE_UNSPECIFIED",
+		1: "OPEN",
+		2: "DISABLED",
+		3: "INVITE_ONLY",
+		4: "ALLOWED_EMAIL_DOMAINS",
+	}
+	RegistrationPolicy_Mode_value = map[string]int32{
+		"MODE_UNSPECIFIED":      0,
+		"OPEN":                  1,
+		"DISABLED":              2,
+		"INVITE_ONLY":           3,
+		"ALLOWED_EMAIL_DOMAINS": 4,
+	}
+)
+
+func (x RegistrationPolicy_Mode) Enum() *RegistrationPolicy_Mode {
+	p := new(RegistrationPolicy_Mode)
+	*p = x
+	return p
+}
+
+func (x RegistrationPolicy_Mode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RegistrationPolicy_Mode) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_instance_setting_proto_enumTypes[1].Descriptor()
+}
+
+func (RegistrationPolicy_Mode) Type() protoreflect.EnumType {
+	return &file_store_instance_setting_proto_enumTypes[1]
+}
+
+func (x RegistrationPolicy_Mode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RegistrationPolicy_Mode.Descriptor instead.
+func (RegistrationPolicy_Mode) EnumDescriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{6, 0}
+}
+
+type InstanceStorageSetting_StorageType int32
+
+const (
+	// Files are kept in the database, the default.
+	InstanceStorageSetting_STORAGE_TYPE_UNSPECIFIED InstanceStorageSetting_StorageType = 0
+	InstanceStorageSetting_DATABASE                 InstanceStorageSetting_StorageType = 1
+	// Files are kept in a directory of the server.
+	InstanceStorageSetting_LOCAL InstanceStorageSetting_StorageType = 2
+	// Files are kept in an S3 compatible bucket.
+	InstanceStorageSetting_S3 InstanceStorageSetting_StorageType = 3
+)
+
+// Enum value maps for InstanceStorageSetting_StorageType.
+var (
+	InstanceStorageSetting_StorageType_name = map[int32]string{
+		0: "STORAGE_TYPE_UNSPECIFIED",
+		1: "DATABASE",
+		2: "LOCAL",
+		3: "S3",
+	}
+	InstanceStorageSetting_StorageType_value = map[string]int32{
+		"STORAGE_TYPE_UNSPECIFIED": 0,
+		"DATABASE":                 1,
+		"LOCAL":                    2,
+		"S3":                       3,
+	}
+)
+
+func (x InstanceStorageSetting_StorageType) Enum() *InstanceStorageSetting_StorageType {
+	p := new(InstanceStorageSetting_StorageType)
+	*p = x
+	return p
+}
+
+func (x InstanceStorageSetting_StorageType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InstanceStorageSetting_StorageType) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_instance_setting_proto_enumTypes[2].Descriptor()
+}
+
+func (InstanceStorageSetting_StorageType) Type() protoreflect.EnumType {
+	return &file_store_instance_setting_proto_enumTypes[2]
+}
+
+func (x InstanceStorageSetting_StorageType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InstanceStorageSetting_StorageType.Descriptor instead.
+func (InstanceStorageSetting_StorageType) EnumDescriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{14, 0}
+}
+
+type InstanceMaintenanceSetting_Mode int32
+
+const (
+	// The instance is in service.
+	InstanceMaintenanceSetting_MODE_UNSPECIFIED InstanceMaintenanceSetting_Mode = 0
+	// Methods that change data are rejected.
+	InstanceMaintenanceSetting_READ_ONLY InstanceMaintenanceSetting_Mode = 1
+	// Only health checks and the instance profile are served.
+	InstanceMaintenanceSetting_FULL InstanceMaintenanceSetting_Mode = 2
+)
+
+// Enum value maps for InstanceMaintenanceSetting_Mode.
+var (
+	InstanceMaintenanceSetting_Mode_name = map[int32]string{
+		0: "MODE_UNSPECIFIED",
+		1: "READ_ONLY",
+		2: "FULL",
+	}
+	InstanceMaintenanceSetting_Mode_value = map[string]int32{
+		"MODE_UNSPECIFIED": 0,
+		"READ_ONLY":        1,
+		"FULL":             2,
+	}
+)
+
+func (x InstanceMaintenanceSetting_Mode) Enum() *InstanceMaintenanceSetting_Mode {
+	p := new(InstanceMaintenanceSetting_Mode)
+	*p = x
+	return p
+}
+
+func (x InstanceMaintenanceSetting_Mode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InstanceMaintenanceSetting_Mode) Descriptor() protoreflect.EnumDescriptor {
+	return file_store_instance_setting_proto_enumTypes[3].Descriptor()
+}
+
+func (InstanceMaintenanceSetting_Mode) Type() protoreflect.EnumType {
+	return &file_store_instance_setting_proto_enumTypes[3]
+}
+
+func (x InstanceMaintenanceSetting_Mode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InstanceMaintenanceSetting_Mode.Descriptor instead.
+func (InstanceMaintenanceSetting_Mode) EnumDescriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{16, 0}
+}
+
 type InstanceSetting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	Key   InstanceSettingKey     `protobuf:"varint,1,opt,name=key,proto3,enum=goserver.store.InstanceSettingKey" json:"key,omitempty"`
 	// Types that are valid to be assigned to Value:
 	//
 	//	*InstanceSetting_BasicSetting
+	//	*InstanceSetting_JwtSigningKeySetting
+	//	*InstanceSetting_SecuritySetting
+	//	*InstanceSetting_PasswordPolicySetting
+	//	*InstanceSetting_IdentityProviderSetting
+	//	*InstanceSetting_GeneralSetting
+	//	*InstanceSetting_SetupSetting
+	//	*InstanceSetting_StorageSetting
+	//	*InstanceSetting_MaintenanceSetting
 	Value         isInstanceSetting_Value `protobuf_oneof:"value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -132,6 +343,78 @@ func (x *InstanceSetting) GetBasicSetting() *InstanceBasicSetting {
 	return nil
 }
 
+func (x *InstanceSetting) GetJwtSigningKeySetting() *InstanceJWTSigningKeySetting {
+	if x != nil {
+		if x, ok := x.Value.(*InstanceSetting_JwtSigningKeySetting); ok {
+			return x.JwtSigningKeySetting
+		}
+	}
+	return nil
+}
+
+func (x *InstanceSetting) GetSecuritySetting() *InstanceSecuritySetting {
+	if x != nil {
+		if x, ok := x.Value.(*InstanceSetting_SecuritySetting); ok {
+			return x.SecuritySetting
+		}
+	}
+	return nil
+}
+
+func (x *InstanceSetting) GetPasswordPolicySetting() *InstancePasswordPolicySetting {
+	if x != nil {
+		if x, ok := x.Value.(*InstanceSetting_PasswordPolicySetting); ok {
+			return x.PasswordPolicySetting
+		}
+	}
+	return nil
+}
+
+func (x *InstanceSetting) GetIdentityProviderSetting() *InstanceIdentityProviderSetting {
+	if x != nil {
+		if x, ok := x.Value.(*InstanceSetting_IdentityProviderSetting); ok {
+			return x.IdentityProviderSetting
+		}
+	}
+	return nil
+}
+
+func (x *InstanceSetting) GetGeneralSetting() *InstanceGeneralSetting {
+	if x != nil {
+		if x, ok := x.Value.(*InstanceSetting_GeneralSetting); ok {
+			return x.GeneralSetting
+		}
+	}
+	return nil
+}
+
+func (x *InstanceSetting) GetSetupSetting() *InstanceSetupSetting {
+	if x != nil {
+		if x, ok := x.Value.(*InstanceSetting_SetupSetting); ok {
+			return x.SetupSetting
+		}
+	}
+	return nil
+}
+
+func (x *InstanceSetting) GetStorageSetting() *InstanceStorageSetting {
+	if x != nil {
+		if x, ok := x.Value.(*InstanceSetting_StorageSetting); ok {
+			return x.StorageSetting
+		}
+	}
+	return nil
+}
+
+func (x *InstanceSetting) GetMaintenanceSetting() *InstanceMaintenanceSetting {
+	if x != nil {
+		if x, ok := x.Value.(*InstanceSetting_MaintenanceSetting); ok {
+			return x.MaintenanceSetting
+		}
+	}
+	return nil
+}
+
 type isInstanceSetting_Value interface {
 	isInstanceSetting_Value()
 }
@@ -140,16 +423,68 @@ type InstanceSetting_BasicSetting struct {
 	BasicSetting *InstanceBasicSetting `protobuf:"bytes,2,opt,name=basic_setting,json=basicSetting,proto3,oneof"`
 }
 
+type InstanceSetting_JwtSigningKeySetting struct {
+	JwtSigningKeySetting *InstanceJWTSigningKeySetting `protobuf:"bytes,3,opt,name=jwt_signing_key_setting,json=jwtSigningKeySetting,proto3,oneof"`
+}
+
+type InstanceSetting_SecuritySetting struct {
+	SecuritySetting *InstanceSecuritySetting `protobuf:"bytes,4,opt,name=security_setting,json=securitySetting,proto3,oneof"`
+}
+
+type InstanceSetting_PasswordPolicySetting struct {
+	PasswordPolicySetting *InstancePasswordPolicySetting `protobuf:"bytes,5,opt,name=password_policy_setting,json=passwordPolicySetting,proto3,oneof"`
+}
+
+type InstanceSetting_IdentityProviderSetting struct {
+	IdentityProviderSetting *InstanceIdentityProviderSetting `protobuf:"bytes,6,opt,name=identity_provider_setting,json=identityProviderSetting,proto3,oneof"`
+}
+
+type InstanceSetting_GeneralSetting struct {
+	GeneralSetting *InstanceGeneralSetting `protobuf:"bytes,7,opt,name=general_setting,json=generalSetting,proto3,oneof"`
+}
+
+type InstanceSetting_SetupSetting struct {
+	SetupSetting *InstanceSetupSetting `protobuf:"bytes,8,opt,name=setup_setting,json=setupSetting,proto3,oneof"`
+}
+
+type InstanceSetting_StorageSetting struct {
+	StorageSetting *InstanceStorageSetting `protobuf:"bytes,9,opt,name=storage_setting,json=storageSetting,proto3,oneof"`
+}
+
+type InstanceSetting_MaintenanceSetting struct {
+	MaintenanceSetting *InstanceMaintenanceSetting `protobuf:"bytes,10,opt,name=maintenance_setting,json=maintenanceSetting,proto3,oneof"`
+}
+
 func (*InstanceSetting_BasicSetting) isInstanceSetting_Value() {}
 
+func (*InstanceSetting_JwtSigningKeySetting) isInstanceSetting_Value() {}
+
+func (*InstanceSetting_SecuritySetting) isInstanceSetting_Value() {}
+
+func (*InstanceSetting_PasswordPolicySetting) isInstanceSetting_Value() {}
+
+func (*InstanceSetting_IdentityProviderSetting) isInstanceSetting_Value() {}
+
+func (*InstanceSetting_GeneralSetting) isInstanceSetting_Value() {}
+
+func (*InstanceSetting_SetupSetting) isInstanceSetting_Value() {}
+
+func (*InstanceSetting_StorageSetting) isInstanceSetting_Value() {}
+
+func (*InstanceSetting_MaintenanceSetting) isInstanceSetting_Value() {}
+
 type InstanceBasicSetting struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The secret key for instance. Mainly used for session management.
 	SecretKey string `protobuf:"bytes,1,opt,name=secret_key,json=secretKey,proto3" json:"secret_key,omitempty"`
 	// The current schema version of database.
 	SchemaVersion string `protobuf:"bytes,2,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// The secret key replaced by the last rotation. Servers decrypt with it as well, until the
+	// rotation is finished by re-encrypting the two-factor secrets and signing keys with the
+	// current key.
+	PreviousSecretKey string `protobuf:"bytes,3,opt,name=previous_secret_key,json=previousSecretKey,proto3" json:"previous_secret_key,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *InstanceBasicSetting) Reset() {
@@ -196,22 +531,1231 @@ func (x *InstanceBasicSetting) GetSchemaVersion() string {
 	return ""
 }
 
-var File_store_instance_setting_proto protoreflect.FileDescriptor
+func (x *InstanceBasicSetting) GetPreviousSecretKey() string {
+	if x != nil {
+		return x.PreviousSecretKey
+	}
+	return ""
+}
 
-const file_store_instance_setting_proto_rawDesc = "" +
-	"\n" +
-	"\x1cstore/instance_setting.proto\x12\x0egoserver.store\"\x9d\x01\n" +
-	"\x0fInstanceSetting\x124\n" +
-	"\x03key\x18\x01 \x01(\x0e2\".goserver.store.InstanceSettingKeyR\x03key\x12K\n" +
-	"\rbasic_setting\x18\x02 \x01(\v2$.goserver.store.InstanceBasicSettingH\x00R\fbasicSettingB\a\n" +
-	"\x05value\"\\\n" +
-	"\x14InstanceBasicSetting\x12\x1d\n" +
-	"\n" +
-	"secret_key\x18\x01 \x01(\tR\tsecretKey\x12%\n" +
-	"\x0eschema_version\x18\x02 \x01(\tR\rschemaVersion*E\n" +
+type InstanceJWTSigningKeySetting struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The signing keys, the active key is the one without expires_at.
+	Keys []*JWTSigningKey `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	// HS256 tokens signed with the instance secret are accepted until this time.
+	// It is set when the first signing key is generated.
+	LegacySecretExpiresAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=legacy_secret_expires_at,json=legacySecretExpiresAt,proto3" json:"legacy_secret_expires_at,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *InstanceJWTSigningKeySetting) Reset() {
+	*x = InstanceJWTSigningKeySetting{}
+	mi := &file_store_instance_setting_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceJWTSigningKeySetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceJWTSigningKeySetting) ProtoMessage() {}
+
+func (x *InstanceJWTSigningKeySetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_instance_setting_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceJWTSigningKeySetting.ProtoReflect.Descriptor instead.
+func (*InstanceJWTSigningKeySetting) Descriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *InstanceJWTSigningKeySetting) GetKeys() []*JWTSigningKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *InstanceJWTSigningKeySetting) GetLegacySecretExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LegacySecretExpiresAt
+	}
+	return nil
+}
+
+type JWTSigningKey struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The key id, sent as the kid header of tokens signed with this key.
+	Kid string `protobuf:"bytes,1,opt,name=kid,proto3" json:"kid,omitempty"`
+	// The JWS algorithm of the key, either RS256 or EdDSA.
+	Algorithm string `protobuf:"bytes,2,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	// The PEM encoded PKCS #8 private key of keys stored before private keys were encrypted.
+	// It is moved to encrypted_private_key the next time the keys are saved.
+	PrivateKey string                 `protobuf:"bytes,3,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Set when the key is rotated out. Tokens signed with it are accepted until then.
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// The PEM encoded PKCS #8 private key, encrypted with AES-GCM using a key derived from
+	// the instance secret.
+	EncryptedPrivateKey string `protobuf:"bytes,6,opt,name=encrypted_private_key,json=encryptedPrivateKey,proto3" json:"encrypted_private_key,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *JWTSigningKey) Reset() {
+	*x = JWTSigningKey{}
+	mi := &file_store_instance_setting_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JWTSigningKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JWTSigningKey) ProtoMessage() {}
+
+func (x *JWTSigningKey) ProtoReflect() protoreflect.Message {
+	mi := &file_store_instance_setting_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JWTSigningKey.ProtoReflect.Descriptor instead.
+func (*JWTSigningKey) Descriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *JWTSigningKey) GetKid() string {
+	if x != nil {
+		return x.Kid
+	}
+	return ""
+}
+
+func (x *JWTSigningKey) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *JWTSigningKey) GetPrivateKey() string {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return ""
+}
+
+func (x *JWTSigningKey) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *JWTSigningKey) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *JWTSigningKey) GetEncryptedPrivateKey() string {
+	if x != nil {
+		return x.EncryptedPrivateKey
+	}
+	return ""
+}
+
+type InstanceSecuritySetting struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AccountLockout *AccountLockoutPolicy  `protobuf:"bytes,1,opt,name=account_lockout,json=accountLockout,proto3" json:"account_lockout,omitempty"`
+	// Refuses sign-in until the user has verified their email address.
+	RequireEmailVerification bool                `protobuf:"varint,2,opt,name=require_email_verification,json=requireEmailVerification,proto3" json:"require_email_verification,omitempty"`
+	Registration             *RegistrationPolicy `protobuf:"bytes,3,opt,name=registration,proto3" json:"registration,omitempty"`
+	// The lifetime of access tokens issued at sign-in, 24 hours by default and at most.
+	AccessTokenLifetimeSeconds int32 `protobuf:"varint,4,opt,name=access_token_lifetime_seconds,json=accessTokenLifetimeSeconds,proto3" json:"access_token_lifetime_seconds,omitempty"`
+	// The lifetime of refresh tokens, 7 days by default.
+	RefreshTokenLifetimeSeconds int32 `protobuf:"varint,5,opt,name=refresh_token_lifetime_seconds,json=refreshTokenLifetimeSeconds,proto3" json:"refresh_token_lifetime_seconds,omitempty"`
+	// Limits HTTP requests per client address. Requests are not limited when unset.
+	RateLimit *RateLimitPolicy `protobuf:"bytes,6,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`
+	// Origins allowed to make cross-origin requests, such as "https://app.example.com".
+	// Any origin is allowed when empty.
+	CorsAllowedOrigins []string `protobuf:"bytes,7,rep,name=cors_allowed_origins,json=corsAllowedOrigins,proto3" json:"cors_allowed_origins,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *InstanceSecuritySetting) Reset() {
+	*x = InstanceSecuritySetting{}
+	mi := &file_store_instance_setting_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceSecuritySetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceSecuritySetting) ProtoMessage() {}
+
+func (x *InstanceSecuritySetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_instance_setting_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceSecuritySetting.ProtoReflect.Descriptor instead.
+func (*InstanceSecuritySetting) Descriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *InstanceSecuritySetting) GetAccountLockout() *AccountLockoutPolicy {
+	if x != nil {
+		return x.AccountLockout
+	}
+	return nil
+}
+
+func (x *InstanceSecuritySetting) GetRequireEmailVerification() bool {
+	if x != nil {
+		return x.RequireEmailVerification
+	}
+	return false
+}
+
+func (x *InstanceSecuritySetting) GetRegistration() *RegistrationPolicy {
+	if x != nil {
+		return x.Registration
+	}
+	return nil
+}
+
+func (x *InstanceSecuritySetting) GetAccessTokenLifetimeSeconds() int32 {
+	if x != nil {
+		return x.AccessTokenLifetimeSeconds
+	}
+	return 0
+}
+
+func (x *InstanceSecuritySetting) GetRefreshTokenLifetimeSeconds() int32 {
+	if x != nil {
+		return x.RefreshTokenLifetimeSeconds
+	}
+	return 0
+}
+
+func (x *InstanceSecuritySetting) GetRateLimit() *RateLimitPolicy {
+	if x != nil {
+		return x.RateLimit
+	}
+	return nil
+}
+
+func (x *InstanceSecuritySetting) GetCorsAllowedOrigins() []string {
+	if x != nil {
+		return x.CorsAllowedOrigins
+	}
+	return nil
+}
+
+// RateLimitPolicy is a token bucket per client address.
+type RateLimitPolicy struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	RequestsPerSecond float64                `protobuf:"fixed64,1,opt,name=requests_per_second,json=requestsPerSecond,proto3" json:"requests_per_second,omitempty"`
+	// The number of requests allowed at once, requests_per_second rounded up when 0.
+	Burst         int32 `protobuf:"varint,2,opt,name=burst,proto3" json:"burst,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateLimitPolicy) Reset() {
+	*x = RateLimitPolicy{}
+	mi := &file_store_instance_setting_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateLimitPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimitPolicy) ProtoMessage() {}
+
+func (x *RateLimitPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_store_instance_setting_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimitPolicy.ProtoReflect.Descriptor instead.
+func (*RateLimitPolicy) Descriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RateLimitPolicy) GetRequestsPerSecond() float64 {
+	if x != nil {
+		return x.RequestsPerSecond
+	}
+	return 0
+}
+
+func (x *RateLimitPolicy) GetBurst() int32 {
+	if x != nil {
+		return x.Burst
+	}
+	return 0
+}
+
+// RegistrationPolicy decides who can sign up with UserService.RegisterUser.
+type RegistrationPolicy struct {
+	state protoimpl.MessageState  `protogen:"open.v1"`
+	Mode  RegistrationPolicy_Mode `protobuf:"varint,1,opt,name=mode,proto3,enum=goserver.store.RegistrationPolicy_Mode" json:"mode,omitempty"`
+	// The domains accepted in ALLOWED_EMAIL_DOMAINS mode, such as "example.com".
+	// Subdomains are not included.
+	AllowedEmailDomains []string `protobuf:"bytes,2,rep,name=allowed_email_domains,json=allowedEmailDomains,proto3" json:"allowed_email_domains,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *RegistrationPolicy) Reset() {
+	*x = RegistrationPolicy{}
+	mi := &file_store_instance_setting_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegistrationPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistrationPolicy) ProtoMessage() {}
+
+func (x *RegistrationPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_store_instance_setting_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistrationPolicy.ProtoReflect.Descriptor instead.
+func (*RegistrationPolicy) Descriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RegistrationPolicy) GetMode() RegistrationPolicy_Mode {
+	if x != nil {
+		return x.Mode
+	}
+	return RegistrationPolicy_MODE_UNSPECIFIED
+}
+
+func (x *RegistrationPolicy) GetAllowedEmailDomains() []string {
+	if x != nil {
+		return x.AllowedEmailDomains
+	}
+	return nil
+}
+
+// AccountLockoutPolicy limits failed sign-in attempts. Zero values fall back to the defaults.
+type AccountLockoutPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The failed attempts for one account after which it is locked.
+	MaxAccountFailures int32 `protobuf:"varint,1,opt,name=max_account_failures,json=maxAccountFailures,proto3" json:"max_account_failures,omitempty"`
+	// The failed attempts from one IP address after which it is locked.
+	MaxIpFailures int32 `protobuf:"varint,2,opt,name=max_ip_failures,json=maxIpFailures,proto3" json:"max_ip_failures,omitempty"`
+	// How long an account or IP address stays locked.
+	LockoutDurationSeconds int32 `protobuf:"varint,3,opt,name=lockout_duration_seconds,json=lockoutDurationSeconds,proto3" json:"lockout_duration_seconds,omitempty"`
+	// Failed attempts older than this are forgotten.
+	FailureWindowSeconds int32 `protobuf:"varint,4,opt,name=failure_window_seconds,json=failureWindowSeconds,proto3" json:"failure_window_seconds,omitempty"`
+	// The delay after the first failed attempt for an account, doubled with every further one.
+	BaseDelaySeconds int32 `protobuf:"varint,5,opt,name=base_delay_seconds,json=baseDelaySeconds,proto3" json:"base_delay_seconds,omitempty"`
+	// The upper bound of the delay between attempts.
+	MaxDelaySeconds int32 `protobuf:"varint,6,opt,name=max_delay_seconds,json=maxDelaySeconds,proto3" json:"max_delay_seconds,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AccountLockoutPolicy) Reset() {
+	*x = AccountLockoutPolicy{}
+	mi := &file_store_instance_setting_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountLockoutPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountLockoutPolicy) ProtoMessage() {}
+
+func (x *AccountLockoutPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_store_instance_setting_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountLockoutPolicy.ProtoReflect.Descriptor instead.
+func (*AccountLockoutPolicy) Descriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AccountLockoutPolicy) GetMaxAccountFailures() int32 {
+	if x != nil {
+		return x.MaxAccountFailures
+	}
+	return 0
+}
+
+func (x *AccountLockoutPolicy) GetMaxIpFailures() int32 {
+	if x != nil {
+		return x.MaxIpFailures
+	}
+	return 0
+}
+
+func (x *AccountLockoutPolicy) GetLockoutDurationSeconds() int32 {
+	if x != nil {
+		return x.LockoutDurationSeconds
+	}
+	return 0
+}
+
+func (x *AccountLockoutPolicy) GetFailureWindowSeconds() int32 {
+	if x != nil {
+		return x.FailureWindowSeconds
+	}
+	return 0
+}
+
+func (x *AccountLockoutPolicy) GetBaseDelaySeconds() int32 {
+	if x != nil {
+		return x.BaseDelaySeconds
+	}
+	return 0
+}
+
+func (x *AccountLockoutPolicy) GetMaxDelaySeconds() int32 {
+	if x != nil {
+		return x.MaxDelaySeconds
+	}
+	return 0
+}
+
+// InstancePasswordPolicySetting are the rules new passwords must satisfy.
+// Zero values fall back to the defaults.
+type InstancePasswordPolicySetting struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The minimum length in characters, 8 by default.
+	MinLength        int32 `protobuf:"varint,1,opt,name=min_length,json=minLength,proto3" json:"min_length,omitempty"`
+	RequireUppercase bool  `protobuf:"varint,2,opt,name=require_uppercase,json=requireUppercase,proto3" json:"require_uppercase,omitempty"`
+	RequireLowercase bool  `protobuf:"varint,3,opt,name=require_lowercase,json=requireLowercase,proto3" json:"require_lowercase,omitempty"`
+	RequireDigit     bool  `protobuf:"varint,4,opt,name=require_digit,json=requireDigit,proto3" json:"require_digit,omitempty"`
+	RequireSymbol    bool  `protobuf:"varint,5,opt,name=require_symbol,json=requireSymbol,proto3" json:"require_symbol,omitempty"`
+	// Allows passwords from the built-in list of common and breached passwords.
+	AllowCommonPasswords bool `protobuf:"varint,6,opt,name=allow_common_passwords,json=allowCommonPasswords,proto3" json:"allow_common_passwords,omitempty"`
+	// The number of most recent passwords, including the current one, that cannot be reused.
+	HistoryCount int32 `protobuf:"varint,7,opt,name=history_count,json=historyCount,proto3" json:"history_count,omitempty"`
+	// The number of days after which a password expires, 90 by default.
+	// A negative value disables expiry.
+	ExpiryDays    int32 `protobuf:"varint,8,opt,name=expiry_days,json=expiryDays,proto3" json:"expiry_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstancePasswordPolicySetting) Reset() {
+	*x = InstancePasswordPolicySetting{}
+	mi := &file_store_instance_setting_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstancePasswordPolicySetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstancePasswordPolicySetting) ProtoMessage() {}
+
+func (x *InstancePasswordPolicySetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_instance_setting_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstancePasswordPolicySetting.ProtoReflect.Descriptor instead.
+func (*InstancePasswordPolicySetting) Descriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *InstancePasswordPolicySetting) GetMinLength() int32 {
+	if x != nil {
+		return x.MinLength
+	}
+	return 0
+}
+
+func (x *InstancePasswordPolicySetting) GetRequireUppercase() bool {
+	if x != nil {
+		return x.RequireUppercase
+	}
+	return false
+}
+
+func (x *InstancePasswordPolicySetting) GetRequireLowercase() bool {
+	if x != nil {
+		return x.RequireLowercase
+	}
+	return false
+}
+
+func (x *InstancePasswordPolicySetting) GetRequireDigit() bool {
+	if x != nil {
+		return x.RequireDigit
+	}
+	return false
+}
+
+func (x *InstancePasswordPolicySetting) GetRequireSymbol() bool {
+	if x != nil {
+		return x.RequireSymbol
+	}
+	return false
+}
+
+func (x *InstancePasswordPolicySetting) GetAllowCommonPasswords() bool {
+	if x != nil {
+		return x.AllowCommonPasswords
+	}
+	return false
+}
+
+func (x *InstancePasswordPolicySetting) GetHistoryCount() int32 {
+	if x != nil {
+		return x.HistoryCount
+	}
+	return 0
+}
+
+func (x *InstancePasswordPolicySetting) GetExpiryDays() int32 {
+	if x != nil {
+		return x.ExpiryDays
+	}
+	return 0
+}
+
+type InstanceIdentityProviderSetting struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Providers     []*IdentityProvider    `protobuf:"bytes,1,rep,name=providers,proto3" json:"providers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstanceIdentityProviderSetting) Reset() {
+	*x = InstanceIdentityProviderSetting{}
+	mi := &file_store_instance_setting_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceIdentityProviderSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceIdentityProviderSetting) ProtoMessage() {}
+
+func (x *InstanceIdentityProviderSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_instance_setting_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceIdentityProviderSetting.ProtoReflect.Descriptor instead.
+func (*InstanceIdentityProviderSetting) Descriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *InstanceIdentityProviderSetting) GetProviders() []*IdentityProvider {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+// IdentityProvider is an OpenID Connect provider users sign in with through the
+// authorization code flow with PKCE.
+type IdentityProvider struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The identifier used in the sign-in URLs and stored with the linked identities.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The name shown on the sign-in page.
+	Title string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// The issuer URL, the provider configuration is discovered from
+	// {issuer}/.well-known/openid-configuration.
+	Issuer   string `protobuf:"bytes,3,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	ClientId string `protobuf:"bytes,4,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// Empty for public clients.
+	ClientSecret string `protobuf:"bytes,5,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"`
+	// The scopes requested in addition to openid, "profile" and "email" by default.
+	Scopes        []string                      `protobuf:"bytes,6,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	ClaimMapping  *IdentityProviderClaimMapping `protobuf:"bytes,7,opt,name=claim_mapping,json=claimMapping,proto3" json:"claim_mapping,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IdentityProvider) Reset() {
+	*x = IdentityProvider{}
+	mi := &file_store_instance_setting_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdentityProvider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentityProvider) ProtoMessage() {}
+
+func (x *IdentityProvider) ProtoReflect() protoreflect.Message {
+	mi := &file_store_instance_setting_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentityProvider.ProtoReflect.Descriptor instead.
+func (*IdentityProvider) Descriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *IdentityProvider) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *IdentityProvider) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *IdentityProvider) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *IdentityProvider) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *IdentityProvider) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+func (x *IdentityProvider) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *IdentityProvider) GetClaimMapping() *IdentityProviderClaimMapping {
+	if x != nil {
+		return x.ClaimMapping
+	}
+	return nil
+}
+
+// IdentityProviderClaimMapping names the ID token claims the fields of new users are taken from.
+// Empty values fall back to the standard claims.
+type IdentityProviderClaimMapping struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The claim holding the username, "preferred_username" by default.
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	// The claim holding the nickname, "name" by default.
+	Nickname string `protobuf:"bytes,2,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	// The claim holding the email address, "email" by default.
+	Email         string `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IdentityProviderClaimMapping) Reset() {
+	*x = IdentityProviderClaimMapping{}
+	mi := &file_store_instance_setting_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdentityProviderClaimMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdentityProviderClaimMapping) ProtoMessage() {}
+
+func (x *IdentityProviderClaimMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_store_instance_setting_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdentityProviderClaimMapping.ProtoReflect.Descriptor instead.
+func (*IdentityProviderClaimMapping) Descriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *IdentityProviderClaimMapping) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *IdentityProviderClaimMapping) GetNickname() string {
+	if x != nil {
+		return x.Nickname
+	}
+	return ""
+}
+
+func (x *IdentityProviderClaimMapping) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type InstanceGeneralSetting struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the instance shown to users.
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// The default locale of the web app as a BCP 47 language tag, such as "en" or "zh-Hans".
+	Locale        string `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstanceGeneralSetting) Reset() {
+	*x = InstanceGeneralSetting{}
+	mi := &file_store_instance_setting_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceGeneralSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceGeneralSetting) ProtoMessage() {}
+
+func (x *InstanceGeneralSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_instance_setting_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceGeneralSetting.ProtoReflect.Descriptor instead.
+func (*InstanceGeneralSetting) Descriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *InstanceGeneralSetting) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InstanceGeneralSetting) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *InstanceGeneralSetting) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type InstanceSetupSetting struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The time the first administrator was created.
+	SetupTime     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=setup_time,json=setupTime,proto3" json:"setup_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstanceSetupSetting) Reset() {
+	*x = InstanceSetupSetting{}
+	mi := &file_store_instance_setting_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceSetupSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceSetupSetting) ProtoMessage() {}
+
+func (x *InstanceSetupSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_instance_setting_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceSetupSetting.ProtoReflect.Descriptor instead.
+func (*InstanceSetupSetting) Descriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *InstanceSetupSetting) GetSetupTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SetupTime
+	}
+	return nil
+}
+
+type InstanceStorageSetting struct {
+	state       protoimpl.MessageState             `protogen:"open.v1"`
+	StorageType InstanceStorageSetting_StorageType `protobuf:"varint,1,opt,name=storage_type,json=storageType,proto3,enum=goserver.store.InstanceStorageSetting_StorageType" json:"storage_type,omitempty"`
+	// The path of files kept in LOCAL storage, relative to the data directory.
+	// It may contain {filename}, {timestamp} and {uuid}, "assets/{timestamp}_{filename}" by default.
+	FilepathTemplate string `protobuf:"bytes,2,opt,name=filepath_template,json=filepathTemplate,proto3" json:"filepath_template,omitempty"`
+	// The maximum size of an uploaded file in MiB, between 1 and 1024. 0 means the default of 32.
+	UploadSizeLimitMb int32            `protobuf:"varint,3,opt,name=upload_size_limit_mb,json=uploadSizeLimitMb,proto3" json:"upload_size_limit_mb,omitempty"`
+	S3Config          *StorageS3Config `protobuf:"bytes,4,opt,name=s3_config,json=s3Config,proto3" json:"s3_config,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *InstanceStorageSetting) Reset() {
+	*x = InstanceStorageSetting{}
+	mi := &file_store_instance_setting_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceStorageSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceStorageSetting) ProtoMessage() {}
+
+func (x *InstanceStorageSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_instance_setting_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceStorageSetting.ProtoReflect.Descriptor instead.
+func (*InstanceStorageSetting) Descriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *InstanceStorageSetting) GetStorageType() InstanceStorageSetting_StorageType {
+	if x != nil {
+		return x.StorageType
+	}
+	return InstanceStorageSetting_STORAGE_TYPE_UNSPECIFIED
+}
+
+func (x *InstanceStorageSetting) GetFilepathTemplate() string {
+	if x != nil {
+		return x.FilepathTemplate
+	}
+	return ""
+}
+
+func (x *InstanceStorageSetting) GetUploadSizeLimitMb() int32 {
+	if x != nil {
+		return x.UploadSizeLimitMb
+	}
+	return 0
+}
+
+func (x *InstanceStorageSetting) GetS3Config() *StorageS3Config {
+	if x != nil {
+		return x.S3Config
+	}
+	return nil
+}
+
+type StorageS3Config struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccessKeyId     string                 `protobuf:"bytes,1,opt,name=access_key_id,json=accessKeyId,proto3" json:"access_key_id,omitempty"`
+	AccessKeySecret string                 `protobuf:"bytes,2,opt,name=access_key_secret,json=accessKeySecret,proto3" json:"access_key_secret,omitempty"`
+	Endpoint        string                 `protobuf:"bytes,3,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Region          string                 `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	Bucket          string                 `protobuf:"bytes,5,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// Addresses the bucket in the path instead of the host name.
+	UsePathStyle  bool `protobuf:"varint,6,opt,name=use_path_style,json=usePathStyle,proto3" json:"use_path_style,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StorageS3Config) Reset() {
+	*x = StorageS3Config{}
+	mi := &file_store_instance_setting_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageS3Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageS3Config) ProtoMessage() {}
+
+func (x *StorageS3Config) ProtoReflect() protoreflect.Message {
+	mi := &file_store_instance_setting_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageS3Config.ProtoReflect.Descriptor instead.
+func (*StorageS3Config) Descriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *StorageS3Config) GetAccessKeyId() string {
+	if x != nil {
+		return x.AccessKeyId
+	}
+	return ""
+}
+
+func (x *StorageS3Config) GetAccessKeySecret() string {
+	if x != nil {
+		return x.AccessKeySecret
+	}
+	return ""
+}
+
+func (x *StorageS3Config) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *StorageS3Config) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *StorageS3Config) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *StorageS3Config) GetUsePathStyle() bool {
+	if x != nil {
+		return x.UsePathStyle
+	}
+	return false
+}
+
+// InstanceMaintenanceSetting takes the instance out of service, for writes or fully.
+type InstanceMaintenanceSetting struct {
+	state protoimpl.MessageState          `protogen:"open.v1"`
+	Mode  InstanceMaintenanceSetting_Mode `protobuf:"varint,1,opt,name=mode,proto3,enum=goserver.store.InstanceMaintenanceSetting_Mode" json:"mode,omitempty"`
+	// Told to rejected callers instead of the default message.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// How long rejected callers are told to wait before retrying, 5 minutes when 0.
+	RetryAfterSeconds int32 `protobuf:"varint,3,opt,name=retry_after_seconds,json=retryAfterSeconds,proto3" json:"retry_after_seconds,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *InstanceMaintenanceSetting) Reset() {
+	*x = InstanceMaintenanceSetting{}
+	mi := &file_store_instance_setting_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceMaintenanceSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceMaintenanceSetting) ProtoMessage() {}
+
+func (x *InstanceMaintenanceSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_store_instance_setting_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceMaintenanceSetting.ProtoReflect.Descriptor instead.
+func (*InstanceMaintenanceSetting) Descriptor() ([]byte, []int) {
+	return file_store_instance_setting_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *InstanceMaintenanceSetting) GetMode() InstanceMaintenanceSetting_Mode {
+	if x != nil {
+		return x.Mode
+	}
+	return InstanceMaintenanceSetting_MODE_UNSPECIFIED
+}
+
+func (x *InstanceMaintenanceSetting) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *InstanceMaintenanceSetting) GetRetryAfterSeconds() int32 {
+	if x != nil {
+		return x.RetryAfterSeconds
+	}
+	return 0
+}
+
+var File_store_instance_setting_proto protoreflect.FileDescriptor
+
+const file_store_instance_setting_proto_rawDesc = "" +
+	"\n" +
+	"\x1cstore/instance_setting.proto\x12\x0egoserver.store\x1a\x1fgoogle/protobuf/timestamp.proto\"\x84\a\n" +
+	"\x0fInstanceSetting\x124\n" +
+	"\x03key\x18\x01 \x01(\x0e2\".goserver.store.InstanceSettingKeyR\x03key\x12K\n" +
+	"\rbasic_setting\x18\x02 \x01(\v2$.goserver.store.InstanceBasicSettingH\x00R\fbasicSetting\x12e\n" +
+	"\x17jwt_signing_key_setting\x18\x03 \x01(\v2,.goserver.store.InstanceJWTSigningKeySettingH\x00R\x14jwtSigningKeySetting\x12T\n" +
+	"\x10security_setting\x18\x04 \x01(\v2'.goserver.store.InstanceSecuritySettingH\x00R\x0fsecuritySetting\x12g\n" +
+	"\x17password_policy_setting\x18\x05 \x01(\v2-.goserver.store.InstancePasswordPolicySettingH\x00R\x15passwordPolicySetting\x12m\n" +
+	"\x19identity_provider_setting\x18\x06 \x01(\v2/.goserver.store.InstanceIdentityProviderSettingH\x00R\x17identityProviderSetting\x12Q\n" +
+	"\x0fgeneral_setting\x18\a \x01(\v2&.goserver.store.InstanceGeneralSettingH\x00R\x0egeneralSetting\x12K\n" +
+	"\rsetup_setting\x18\b \x01(\v2$.goserver.store.InstanceSetupSettingH\x00R\fsetupSetting\x12Q\n" +
+	"\x0fstorage_setting\x18\t \x01(\v2&.goserver.store.InstanceStorageSettingH\x00R\x0estorageSetting\x12]\n" +
+	"\x13maintenance_setting\x18\n" +
+	" \x01(\v2*.goserver.store.InstanceMaintenanceSettingH\x00R\x12maintenanceSettingB\a\n" +
+	"\x05value\"\x8c\x01\n" +
+	"\x14InstanceBasicSetting\x12\x1d\n" +
+	"\n" +
+	"secret_key\x18\x01 \x01(\tR\tsecretKey\x12%\n" +
+	"\x0eschema_version\x18\x02 \x01(\tR\rschemaVersion\x12.\n" +
+	"\x13previous_secret_key\x18\x03 \x01(\tR\x11previousSecretKey\"\xa6\x01\n" +
+	"\x1cInstanceJWTSigningKeySetting\x121\n" +
+	"\x04keys\x18\x01 \x03(\v2\x1d.goserver.store.JWTSigningKeyR\x04keys\x12S\n" +
+	"\x18legacy_secret_expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x15legacySecretExpiresAt\"\x8a\x02\n" +
+	"\rJWTSigningKey\x12\x10\n" +
+	"\x03kid\x18\x01 \x01(\tR\x03kid\x12\x1c\n" +
+	"\talgorithm\x18\x02 \x01(\tR\talgorithm\x12\x1f\n" +
+	"\vprivate_key\x18\x03 \x01(\tR\n" +
+	"privateKey\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x122\n" +
+	"\x15encrypted_private_key\x18\x06 \x01(\tR\x13encryptedPrivateKey\"\xe8\x03\n" +
+	"\x17InstanceSecuritySetting\x12M\n" +
+	"\x0faccount_lockout\x18\x01 \x01(\v2$.goserver.store.AccountLockoutPolicyR\x0eaccountLockout\x12<\n" +
+	"\x1arequire_email_verification\x18\x02 \x01(\bR\x18requireEmailVerification\x12F\n" +
+	"\fregistration\x18\x03 \x01(\v2\".goserver.store.RegistrationPolicyR\fregistration\x12A\n" +
+	"\x1daccess_token_lifetime_seconds\x18\x04 \x01(\x05R\x1aaccessTokenLifetimeSeconds\x12C\n" +
+	"\x1erefresh_token_lifetime_seconds\x18\x05 \x01(\x05R\x1brefreshTokenLifetimeSeconds\x12>\n" +
+	"\n" +
+	"rate_limit\x18\x06 \x01(\v2\x1f.goserver.store.RateLimitPolicyR\trateLimit\x120\n" +
+	"\x14cors_allowed_origins\x18\a \x03(\tR\x12corsAllowedOrigins\"W\n" +
+	"\x0fRateLimitPolicy\x12.\n" +
+	"\x13requests_per_second\x18\x01 \x01(\x01R\x11requestsPerSecond\x12\x14\n" +
+	"\x05burst\x18\x02 \x01(\x05R\x05burst\"\xe7\x01\n" +
+	"\x12RegistrationPolicy\x12;\n" +
+	"\x04mode\x18\x01 \x01(\x0e2'.goserver.store.RegistrationPolicy.ModeR\x04mode\x122\n" +
+	"\x15allowed_email_domains\x18\x02 \x03(\tR\x13allowedEmailDomains\"`\n" +
+	"\x04Mode\x12\x14\n" +
+	"\x10MODE_UNSPECIFIED\x10\x00\x12\b\n" +
+	"\x04OPEN\x10\x01\x12\f\n" +
+	"\bDISABLED\x10\x02\x12\x0f\n" +
+	"\vINVITE_ONLY\x10\x03\x12\x19\n" +
+	"\x15ALLOWED_EMAIL_DOMAINS\x10\x04\"\xba\x02\n" +
+	"\x14AccountLockoutPolicy\x120\n" +
+	"\x14max_account_failures\x18\x01 \x01(\x05R\x12maxAccountFailures\x12&\n" +
+	"\x0fmax_ip_failures\x18\x02 \x01(\x05R\rmaxIpFailures\x128\n" +
+	"\x18lockout_duration_seconds\x18\x03 \x01(\x05R\x16lockoutDurationSeconds\x124\n" +
+	"\x16failure_window_seconds\x18\x04 \x01(\x05R\x14failureWindowSeconds\x12,\n" +
+	"\x12base_delay_seconds\x18\x05 \x01(\x05R\x10baseDelaySeconds\x12*\n" +
+	"\x11max_delay_seconds\x18\x06 \x01(\x05R\x0fmaxDelaySeconds\"\xe0\x02\n" +
+	"\x1dInstancePasswordPolicySetting\x12\x1d\n" +
+	"\n" +
+	"min_length\x18\x01 \x01(\x05R\tminLength\x12+\n" +
+	"\x11require_uppercase\x18\x02 \x01(\bR\x10requireUppercase\x12+\n" +
+	"\x11require_lowercase\x18\x03 \x01(\bR\x10requireLowercase\x12#\n" +
+	"\rrequire_digit\x18\x04 \x01(\bR\frequireDigit\x12%\n" +
+	"\x0erequire_symbol\x18\x05 \x01(\bR\rrequireSymbol\x124\n" +
+	"\x16allow_common_passwords\x18\x06 \x01(\bR\x14allowCommonPasswords\x12#\n" +
+	"\rhistory_count\x18\a \x01(\x05R\fhistoryCount\x12\x1f\n" +
+	"\vexpiry_days\x18\b \x01(\x05R\n" +
+	"expiryDays\"a\n" +
+	"\x1fInstanceIdentityProviderSetting\x12>\n" +
+	"\tproviders\x18\x01 \x03(\v2 .goserver.store.IdentityProviderR\tproviders\"\xfd\x01\n" +
+	"\x10IdentityProvider\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06issuer\x18\x03 \x01(\tR\x06issuer\x12\x1b\n" +
+	"\tclient_id\x18\x04 \x01(\tR\bclientId\x12#\n" +
+	"\rclient_secret\x18\x05 \x01(\tR\fclientSecret\x12\x16\n" +
+	"\x06scopes\x18\x06 \x03(\tR\x06scopes\x12Q\n" +
+	"\rclaim_mapping\x18\a \x01(\v2,.goserver.store.IdentityProviderClaimMappingR\fclaimMapping\"l\n" +
+	"\x1cIdentityProviderClaimMapping\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
+	"\bnickname\x18\x02 \x01(\tR\bnickname\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\"f\n" +
+	"\x16InstanceGeneralSetting\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06locale\x18\x03 \x01(\tR\x06locale\"Q\n" +
+	"\x14InstanceSetupSetting\x129\n" +
+	"\n" +
+	"setup_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tsetupTime\"\xd9\x02\n" +
+	"\x16InstanceStorageSetting\x12U\n" +
+	"\fstorage_type\x18\x01 \x01(\x0e22.goserver.store.InstanceStorageSetting.StorageTypeR\vstorageType\x12+\n" +
+	"\x11filepath_template\x18\x02 \x01(\tR\x10filepathTemplate\x12/\n" +
+	"\x14upload_size_limit_mb\x18\x03 \x01(\x05R\x11uploadSizeLimitMb\x12<\n" +
+	"\ts3_config\x18\x04 \x01(\v2\x1f.goserver.store.StorageS3ConfigR\bs3Config\"L\n" +
+	"\vStorageType\x12\x1c\n" +
+	"\x18STORAGE_TYPE_UNSPECIFIED\x10\x00\x12\f\n" +
+	"\bDATABASE\x10\x01\x12\t\n" +
+	"\x05LOCAL\x10\x02\x12\x06\n" +
+	"\x02S3\x10\x03\"\xd3\x01\n" +
+	"\x0fStorageS3Config\x12\"\n" +
+	"\raccess_key_id\x18\x01 \x01(\tR\vaccessKeyId\x12*\n" +
+	"\x11access_key_secret\x18\x02 \x01(\tR\x0faccessKeySecret\x12\x1a\n" +
+	"\bendpoint\x18\x03 \x01(\tR\bendpoint\x12\x16\n" +
+	"\x06region\x18\x04 \x01(\tR\x06region\x12\x16\n" +
+	"\x06bucket\x18\x05 \x01(\tR\x06bucket\x12$\n" +
+	"\x0euse_path_style\x18\x06 \x01(\bR\fusePathStyle\"\xe2\x01\n" +
+	"\x1aInstanceMaintenanceSetting\x12C\n" +
+	"\x04mode\x18\x01 \x01(\x0e2/.goserver.store.InstanceMaintenanceSetting.ModeR\x04mode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12.\n" +
+	"\x13retry_after_seconds\x18\x03 \x01(\x05R\x11retryAfterSeconds\"5\n" +
+	"\x04Mode\x12\x14\n" +
+	"\x10MODE_UNSPECIFIED\x10\x00\x12\r\n" +
+	"\tREAD_ONLY\x10\x01\x12\b\n" +
+	"\x04FULL\x10\x02*\xcc\x01\n" +
 	"\x12InstanceSettingKey\x12$\n" +
 	" INSTANCE_SETTING_KEY_UNSPECIFIED\x10\x00\x12\t\n" +
-	"\x05BASIC\x10\x01B\xae\x01\n" +
+	"\x05BASIC\x10\x01\x12\x14\n" +
+	"\x10JWT_SIGNING_KEYS\x10\x02\x12\f\n" +
+	"\bSECURITY\x10\x03\x12\x13\n" +
+	"\x0fPASSWORD_POLICY\x10\x04\x12\x16\n" +
+	"\x12IDENTITY_PROVIDERS\x10\x05\x12\v\n" +
+	"\aGENERAL\x10\x06\x12\t\n" +
+	"\x05SETUP\x10\a\x12\v\n" +
+	"\aSTORAGE\x10\b\x12\x0f\n" +
+	"\vMAINTENANCE\x10\tB\xae\x01\n" +
 	"\x12com.goserver.storeB\x14InstanceSettingProtoP\x01Z)github.com/pixb/go-server/proto/gen/store\xa2\x02\x03GSX\xaa\x02\x0eGoserver.Store\xca\x02\x0eGoserver\\Store\xe2\x02\x1aGoserver\\Store\\GPBMetadata\xea\x02\x0fGoserver::Storeb\x06proto3"
 
 var (
@@ -226,21 +1770,62 @@ func file_store_instance_setting_proto_rawDescGZIP() []byte {
 	return file_store_instance_setting_proto_rawDescData
 }
 
-var file_store_instance_setting_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_store_instance_setting_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_store_instance_setting_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_store_instance_setting_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_store_instance_setting_proto_goTypes = []any{
-	(InstanceSettingKey)(0),      // 0: goserver.store.InstanceSettingKey
-	(*InstanceSetting)(nil),      // 1: goserver.store.InstanceSetting
-	(*InstanceBasicSetting)(nil), // 2: goserver.store.InstanceBasicSetting
+	(InstanceSettingKey)(0),                 // 0: goserver.store.InstanceSettingKey
+	(RegistrationPolicy_Mode)(0),            // 1: goserver.store.RegistrationPolicy.Mode
+	(InstanceStorageSetting_StorageType)(0), // 2: goserver.store.InstanceStorageSetting.StorageType
+	(InstanceMaintenanceSetting_Mode)(0),    // 3: goserver.store.InstanceMaintenanceSetting.Mode
+	(*InstanceSetting)(nil),                 // 4: goserver.store.InstanceSetting
+	(*InstanceBasicSetting)(nil),            // 5: goserver.store.InstanceBasicSetting
+	(*InstanceJWTSigningKeySetting)(nil),    // 6: goserver.store.InstanceJWTSigningKeySetting
+	(*JWTSigningKey)(nil),                   // 7: goserver.store.JWTSigningKey
+	(*InstanceSecuritySetting)(nil),         // 8: goserver.store.InstanceSecuritySetting
+	(*RateLimitPolicy)(nil),                 // 9: goserver.store.RateLimitPolicy
+	(*RegistrationPolicy)(nil),              // 10: goserver.store.RegistrationPolicy
+	(*AccountLockoutPolicy)(nil),            // 11: goserver.store.AccountLockoutPolicy
+	(*InstancePasswordPolicySetting)(nil),   // 12: goserver.store.InstancePasswordPolicySetting
+	(*InstanceIdentityProviderSetting)(nil), // 13: goserver.store.InstanceIdentityProviderSetting
+	(*IdentityProvider)(nil),                // 14: goserver.store.IdentityProvider
+	(*IdentityProviderClaimMapping)(nil),    // 15: goserver.store.IdentityProviderClaimMapping
+	(*InstanceGeneralSetting)(nil),          // 16: goserver.store.InstanceGeneralSetting
+	(*InstanceSetupSetting)(nil),            // 17: goserver.store.InstanceSetupSetting
+	(*InstanceStorageSetting)(nil),          // 18: goserver.store.InstanceStorageSetting
+	(*StorageS3Config)(nil),                 // 19: goserver.store.StorageS3Config
+	(*InstanceMaintenanceSetting)(nil),      // 20: goserver.store.InstanceMaintenanceSetting
+	(*timestamppb.Timestamp)(nil),           // 21: google.protobuf.Timestamp
 }
 var file_store_instance_setting_proto_depIdxs = []int32{
-	0, // 0: goserver.store.InstanceSetting.key:type_name -> goserver.store.InstanceSettingKey
-	2, // 1: goserver.store.InstanceSetting.basic_setting:type_name -> goserver.store.InstanceBasicSetting
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0,  // 0: goserver.store.InstanceSetting.key:type_name -> goserver.store.InstanceSettingKey
+	5,  // 1: goserver.store.InstanceSetting.basic_setting:type_name -> goserver.store.InstanceBasicSetting
+	6,  // 2: goserver.store.InstanceSetting.jwt_signing_key_setting:type_name -> goserver.store.InstanceJWTSigningKeySetting
+	8,  // 3: goserver.store.InstanceSetting.security_setting:type_name -> goserver.store.InstanceSecuritySetting
+	12, // 4: goserver.store.InstanceSetting.password_policy_setting:type_name -> goserver.store.InstancePasswordPolicySetting
+	13, // 5: goserver.store.InstanceSetting.identity_provider_setting:type_name -> goserver.store.InstanceIdentityProviderSetting
+	16, // 6: goserver.store.InstanceSetting.general_setting:type_name -> goserver.store.InstanceGeneralSetting
+	17, // 7: goserver.store.InstanceSetting.setup_setting:type_name -> goserver.store.InstanceSetupSetting
+	18, // 8: goserver.store.InstanceSetting.storage_setting:type_name -> goserver.store.InstanceStorageSetting
+	20, // 9: goserver.store.InstanceSetting.maintenance_setting:type_name -> goserver.store.InstanceMaintenanceSetting
+	7,  // 10: goserver.store.InstanceJWTSigningKeySetting.keys:type_name -> goserver.store.JWTSigningKey
+	21, // 11: goserver.store.InstanceJWTSigningKeySetting.legacy_secret_expires_at:type_name -> google.protobuf.Timestamp
+	21, // 12: goserver.store.JWTSigningKey.created_at:type_name -> google.protobuf.Timestamp
+	21, // 13: goserver.store.JWTSigningKey.expires_at:type_name -> google.protobuf.Timestamp
+	11, // 14: goserver.store.InstanceSecuritySetting.account_lockout:type_name -> goserver.store.AccountLockoutPolicy
+	10, // 15: goserver.store.InstanceSecuritySetting.registration:type_name -> goserver.store.RegistrationPolicy
+	9,  // 16: goserver.store.InstanceSecuritySetting.rate_limit:type_name -> goserver.store.RateLimitPolicy
+	1,  // 17: goserver.store.RegistrationPolicy.mode:type_name -> goserver.store.RegistrationPolicy.Mode
+	14, // 18: goserver.store.InstanceIdentityProviderSetting.providers:type_name -> goserver.store.IdentityProvider
+	15, // 19: goserver.store.IdentityProvider.claim_mapping:type_name -> goserver.store.IdentityProviderClaimMapping
+	21, // 20: goserver.store.InstanceSetupSetting.setup_time:type_name -> google.protobuf.Timestamp
+	2,  // 21: goserver.store.InstanceStorageSetting.storage_type:type_name -> goserver.store.InstanceStorageSetting.StorageType
+	19, // 22: goserver.store.InstanceStorageSetting.s3_config:type_name -> goserver.store.StorageS3Config
+	3,  // 23: goserver.store.InstanceMaintenanceSetting.mode:type_name -> goserver.store.InstanceMaintenanceSetting.Mode
+	24, // [24:24] is the sub-list for method output_type
+	24, // [24:24] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_store_instance_setting_proto_init() }
@@ -250,14 +1835,22 @@ func file_store_instance_setting_proto_init() {
 	}
 	file_store_instance_setting_proto_msgTypes[0].OneofWrappers = []any{
 		(*InstanceSetting_BasicSetting)(nil),
+		(*InstanceSetting_JwtSigningKeySetting)(nil),
+		(*InstanceSetting_SecuritySetting)(nil),
+		(*InstanceSetting_PasswordPolicySetting)(nil),
+		(*InstanceSetting_IdentityProviderSetting)(nil),
+		(*InstanceSetting_GeneralSetting)(nil),
+		(*InstanceSetting_SetupSetting)(nil),
+		(*InstanceSetting_StorageSetting)(nil),
+		(*InstanceSetting_MaintenanceSetting)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_store_instance_setting_proto_rawDesc), len(file_store_instance_setting_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   2,
+			NumEnums:      4,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
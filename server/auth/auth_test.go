@@ -1,14 +1,49 @@
 package auth
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"connectrpc.com/connect"
 	"github.com/golang-jwt/jwt/v5"
+	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	storepb "github.com/pixb/go-server/proto/gen/store"
 	"github.com/pixb/go-server/store"
+	"github.com/pixb/go-server/store/storetest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// createTestUser creates a user with the role in the store.
+func createTestUser(t *testing.T, s *store.Store, username string, role store.Role) *store.User {
+	t.Helper()
+	user, err := s.CreateUser(context.Background(), &store.User{
+		Username: username,
+		Password: "hashed",
+		Email:    username + "@example.com",
+		Role:     role,
+	})
+	require.NoError(t, err)
+	return user
+}
+
 func TestGenerateAccessToken(t *testing.T) {
 	// Test data
 	userID := int64(1)
@@ -93,31 +128,833 @@ func TestHashPassword(t *testing.T) {
 
 func TestAuthenticator_Authenticate(t *testing.T) {
 	// Test data
-	userID := int64(1)
+	s := storetest.NewStore(t)
 	username := "testuser"
 	role := store.RoleUser
 	secret := "testsecret"
+	userID := createTestUser(t, s, username, role).ID
 
 	// Generate access token
 	token, err := GenerateAccessToken(userID, username, role, secret)
 	assert.NoError(t, err)
 
 	// Create authenticator
-	authenticator := NewAuthenticator(&store.Store{}, secret)
+	authenticator := NewAuthenticator(s, secret)
 
 	// Test authentication with valid token
-	result := authenticator.Authenticate(nil, "Bearer "+token)
+	result := authenticator.Authenticate(context.Background(), "Bearer "+token)
 	assert.NotNil(t, result)
 	assert.NotNil(t, result.Claims)
 	assert.Equal(t, userID, result.Claims.UserID)
 	assert.Equal(t, username, result.Claims.Username)
 	assert.Equal(t, string(role), result.Claims.Role)
 
+	assert.Empty(t, result.Claims.Permissions)
+
 	// Test authentication with invalid token
-	result = authenticator.Authenticate(nil, "Bearer invalidtoken")
+	result = authenticator.Authenticate(context.Background(), "Bearer invalidtoken")
 	assert.Nil(t, result)
 
 	// Test authentication with empty header
-	result = authenticator.Authenticate(nil, "")
+	result = authenticator.Authenticate(context.Background(), "")
+	assert.Nil(t, result)
+
+	// Test authentication of a deleted user
+	require.NoError(t, s.DeleteUser(context.Background(), &store.DeleteUser{ID: userID}))
+	result = authenticator.Authenticate(context.Background(), "Bearer "+token)
 	assert.Nil(t, result)
 }
+
+func TestAuthenticator_Permissions(t *testing.T) {
+	ctx := context.Background()
+	s := storetest.NewStore(t)
+	secret := "testsecret"
+	authenticator := NewAuthenticator(s, secret)
+	user := createTestUser(t, s, "testuser", store.RoleUser)
+	admin := createTestUser(t, s, "admin", store.RoleAdmin)
+
+	// The role is read from the store rather than the access token
+	token, err := GenerateAccessToken(admin.ID, admin.Username, store.RoleUser, secret)
+	require.NoError(t, err)
+	result := authenticator.Authenticate(ctx, "Bearer "+token)
+	require.NotNil(t, result)
+	assert.Equal(t, store.RoleAdmin, result.Role())
+	assert.ElementsMatch(t, store.Permissions, result.Claims.Permissions)
+
+	// Custom roles add their permissions to the ones of the built-in role
+	auditor, err := s.CreateRoleDefinition(ctx, &store.CreateRoleDefinition{
+		Name:        "auditor",
+		Permissions: []store.Permission{store.PermissionUsersRead},
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.SetUserRoles(ctx, &store.SetUserRoles{UserID: user.ID, RoleIDs: []int64{auditor.ID}}))
+	token, err = GenerateAccessToken(user.ID, user.Username, store.RoleUser, secret)
+	require.NoError(t, err)
+	result = authenticator.Authenticate(ctx, "Bearer "+token)
+	require.NotNil(t, result)
+	assert.Equal(t, []store.Permission{store.PermissionUsersRead}, result.Claims.Permissions)
+
+	userCtx := SetUserClaimsInContext(ctx, result.Claims)
+	assert.NoError(t, Require(userCtx, store.PermissionUsersRead))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(Require(userCtx, store.PermissionUsersWrite)))
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(Require(ctx, store.PermissionUsersRead)))
+}
+
+func TestResolvePermissions(t *testing.T) {
+	assert.Equal(t, []store.Permission{store.PermissionRolesRead, store.PermissionUsersRead, store.PermissionUsersWrite}, ResolvePermissions([]*store.RoleDefinition{
+		{Permissions: []store.Permission{store.PermissionUsersWrite, store.PermissionUsersRead}},
+		{Permissions: []store.Permission{store.PermissionUsersRead, store.PermissionRolesRead}},
+		{},
+	}))
+	assert.Empty(t, ResolvePermissions(nil))
+}
+
+func TestInterceptor_AdminMethod(t *testing.T) {
+	secret := "testsecret"
+	s := storetest.NewStore(t)
+	interceptor := NewInterceptor(s, secret).GRPCUnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/goserver.api.v1.AdminService/ListUsers"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+
+	for role, code := range map[store.Role]codes.Code{
+		store.RoleUser:  codes.PermissionDenied,
+		store.RoleAdmin: codes.OK,
+	} {
+		user := createTestUser(t, s, "test"+string(role), role)
+		token, err := GenerateAccessToken(user.ID, user.Username, role, secret)
+		require.NoError(t, err)
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+		_, err = interceptor(ctx, nil, info, handler)
+		assert.Equal(t, code, status.Code(err), role)
+	}
+
+	_, err := interceptor(metadata.NewIncomingContext(context.Background(), metadata.MD{}), nil, info, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestAuthorizer(t *testing.T) {
+	authorizer := NewAuthorizer()
+	user := &AuthResult{Claims: &UserClaims{UserID: 2, Role: string(store.RoleUser)}}
+	admin := &AuthResult{Claims: &UserClaims{UserID: 1, Role: string(store.RoleAdmin), Permissions: store.Permissions}}
+	auditor := &AuthResult{Claims: &UserClaims{UserID: 3, Role: string(store.RoleUser), Permissions: []store.Permission{store.PermissionUsersRead}}}
+
+	// Rules come from the (goserver.api.v1.auth) option
+	assert.True(t, authorizer.Rule("/goserver.api.v1.AuthService/Login").Public)
+	assert.Equal(t, []store.Permission{store.PermissionUsersRead}, authorizer.Rule("/goserver.api.v1.AdminService/ListUsers").Permissions)
+	assert.Equal(t, &MethodRule{ReadOnly: true}, authorizer.Rule("/goserver.api.v1.UserService/GetUserProfile"))
+
+	for _, tc := range []struct {
+		procedure string
+		result    *AuthResult
+		err       error
+	}{
+		{"/goserver.api.v1.AuthService/Login", nil, nil},
+		{"/goserver.api.v1.UserService/GetUserProfile", nil, ErrAuthenticationRequired},
+		{"/goserver.api.v1.UserService/GetUserProfile", user, nil},
+		{"/goserver.api.v1.AdminService/ListUsers", nil, ErrAuthenticationRequired},
+		{"/goserver.api.v1.AdminService/ListUsers", user, ErrPermissionDenied},
+		{"/goserver.api.v1.AdminService/ListUsers", admin, nil},
+		{"/goserver.api.v1.AdminService/ListUsers", auditor, nil},
+		{"/goserver.api.v1.AdminService/DeleteUser", auditor, ErrPermissionDenied},
+		{"/goserver.api.v1.RoleService/CreateRole", admin, nil},
+		{"/goserver.api.v1.UserService/UnlockUser", &AuthResult{User: &store.User{ID: 2, Role: store.RoleUser}}, ErrPermissionDenied},
+		{"/goserver.api.v1.UnknownService/Unknown", nil, ErrAuthenticationRequired},
+	} {
+		assert.Equal(t, tc.err, authorizer.Authorize(tc.procedure, tc.result), tc.procedure)
+	}
+
+	for _, tc := range []struct {
+		method, path, procedure string
+	}{
+		{http.MethodGet, "/api/v1/users/me", "/goserver.api.v1.UserService/GetUserProfile"},
+		{http.MethodPost, "/api/v1/users/7/unlock", "/goserver.api.v1.UserService/UnlockUser"},
+		{http.MethodGet, "/api/v1/admin/users", "/goserver.api.v1.AdminService/ListUsers"},
+		{http.MethodPost, "/api/v1/admin/users", "/goserver.api.v1.AdminService/CreateUser"},
+		{http.MethodDelete, "/api/v1/admin/users/7", "/goserver.api.v1.AdminService/DeleteUser"},
+	} {
+		procedure, ok := authorizer.GatewayRPCMethod(httptest.NewRequest(tc.method, tc.path, nil))
+		assert.True(t, ok, tc.path)
+		assert.Equal(t, tc.procedure, procedure)
+	}
+	_, ok := authorizer.GatewayRPCMethod(httptest.NewRequest(http.MethodGet, "/api/v1/unknown", nil))
+	assert.False(t, ok)
+}
+
+func TestGatewayAuthMiddleware(t *testing.T) {
+	secret := "testsecret"
+	s := storetest.NewStore(t)
+	middleware := NewGatewayAuthMiddleware(NewAuthenticator(s, secret), NewAuthorizer(), nil)
+	handler := middleware(func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		w.WriteHeader(http.StatusOK)
+	})
+	user := createTestUser(t, s, "testuser", store.RoleUser)
+	userToken, err := GenerateAccessToken(user.ID, user.Username, store.RoleUser, secret)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		method, path, token, contentType string
+		statusCode                       int
+	}{
+		{http.MethodPost, "/api/v1/auth/login", "", "", http.StatusOK},
+		{http.MethodGet, "/api/v1/users/me", "", "", http.StatusUnauthorized},
+		{http.MethodGet, "/api/v1/users/me", userToken, "", http.StatusOK},
+		{http.MethodGet, "/api/v1/admin/users", userToken, "", http.StatusForbidden},
+		// A form POST to a GET-only route matches no method and is denied
+		{http.MethodPost, "/api/v1/users/me/sessions", "", "application/x-www-form-urlencoded", http.StatusForbidden},
+		{http.MethodPost, "/api/v1/users/me/sessions", userToken, "application/x-www-form-urlencoded", http.StatusForbidden},
+	} {
+		r := httptest.NewRequest(tc.method, tc.path, nil)
+		if tc.contentType != "" {
+			r.Header.Set("Content-Type", tc.contentType)
+		}
+		if tc.token != "" {
+			r.Header.Set("Authorization", "Bearer "+tc.token)
+		}
+		w := httptest.NewRecorder()
+		handler(w, r, nil)
+		assert.Equal(t, tc.statusCode, w.Code, tc.path)
+	}
+}
+
+func TestMaintenance(t *testing.T) {
+	s := storetest.NewStore(t)
+	authorizer := NewAuthorizer()
+	assert.True(t, authorizer.Rule("/goserver.api.v1.InstanceService/GetInstanceProfile").AlwaysAvailable)
+	assert.True(t, authorizer.Rule("/goserver.api.v1.AuthService/ValidateToken").ReadOnly)
+	assert.True(t, authorizer.Rule("/goserver.api.v1.UserService/GetUserProfile").ReadOnly)
+	assert.False(t, authorizer.Rule("/goserver.api.v1.UserService/RegisterUser").ReadOnly)
+
+	maintenance := &Maintenance{}
+	interceptor := NewInterceptor(s, "testsecret")
+	interceptor.SetMaintenance(maintenance)
+	grpcInterceptor := interceptor.GRPCUnaryInterceptor()
+	connectInterceptor := interceptor.ConnectUnaryInterceptor()
+	gatewayHandler := NewGatewayAuthMiddleware(NewAuthenticator(s, "testsecret"), authorizer, maintenance)(
+		func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+			w.WriteHeader(http.StatusOK)
+		})
+	callGRPC := func(procedure string) error {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{})
+		_, err := grpcInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: procedure}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		return err
+	}
+	callGateway := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		gatewayHandler(w, httptest.NewRequest(method, path, nil), nil)
+		return w
+	}
+
+	// Public methods are served while the instance is in service
+	require.NoError(t, callGRPC("/goserver.api.v1.UserService/RegisterUser"))
+	assert.Equal(t, http.StatusOK, callGateway(http.MethodPost, "/api/v1/users").Code)
+
+	maintenance.Set(&storepb.InstanceMaintenanceSetting{Mode: storepb.InstanceMaintenanceSetting_READ_ONLY, RetryAfterSeconds: 120})
+	err := callGRPC("/goserver.api.v1.UserService/RegisterUser")
+	st := status.Convert(err)
+	assert.Equal(t, codes.Unavailable, st.Code())
+	require.Len(t, st.Details(), 1)
+	assert.Equal(t, 120*time.Second, st.Details()[0].(*errdetails.RetryInfo).RetryDelay.AsDuration())
+	require.NoError(t, callGRPC("/goserver.api.v1.AuthService/ValidateToken"))
+
+	_, err = connectInterceptor(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		return nil, nil
+	})(context.Background(), connect.NewRequest(&v1pb.RegisterUserRequest{}))
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, connect.CodeUnavailable, connectErr.Code())
+	assert.Equal(t, "120", connectErr.Meta().Get("Retry-After"))
+
+	w := callGateway(http.MethodPost, "/api/v1/users")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "120", w.Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusOK, callGateway(http.MethodGet, "/api/v1/instance/profile").Code)
+
+	// Only the instance profile is served in full maintenance
+	maintenance.Set(&storepb.InstanceMaintenanceSetting{Mode: storepb.InstanceMaintenanceSetting_FULL})
+	assert.Equal(t, codes.Unavailable, status.Code(callGRPC("/goserver.api.v1.AuthService/ValidateToken")))
+	assert.Equal(t, http.StatusServiceUnavailable, callGateway(http.MethodGet, "/api/v1/auth/providers").Code)
+	require.NoError(t, callGRPC("/goserver.api.v1.InstanceService/GetInstanceProfile"))
+
+	maintenance.Set(nil)
+	require.NoError(t, callGRPC("/goserver.api.v1.UserService/RegisterUser"))
+}
+
+func TestAuthenticator_PersonalAccessToken(t *testing.T) {
+	ctx := context.Background()
+	s := storetest.NewStore(t)
+	user, err := s.CreateUser(ctx, &store.User{
+		Username: "patuser",
+		Password: "hashed",
+		Email:    "pat@example.com",
+		Role:     store.RoleUser,
+	})
+	require.NoError(t, err)
+
+	token, err := GeneratePersonalAccessToken()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(token, PersonalAccessTokenPrefix))
+	pat, err := s.CreatePersonalAccessToken(ctx, &store.CreatePersonalAccessToken{
+		UserID:      user.ID,
+		TokenHash:   HashToken(token),
+		Description: "ci",
+	})
+	require.NoError(t, err)
+
+	authenticator := NewAuthenticator(s, "testsecret")
+
+	// Valid token resolves to its owner and records last use
+	result := authenticator.Authenticate(ctx, "Bearer "+token)
+	require.NotNil(t, result)
+	require.NotNil(t, result.User)
+	assert.Equal(t, user.ID, result.User.ID)
+	require.NotNil(t, result.Claims)
+	assert.Equal(t, user.ID, result.Claims.UserID)
+	assert.Equal(t, string(store.RoleUser), result.Claims.Role)
+	pat, err = s.GetPersonalAccessToken(ctx, &store.FindPersonalAccessToken{ID: &pat.ID})
+	require.NoError(t, err)
+	require.NotNil(t, pat.LastUsedAt)
+
+	// The last use is recorded again only once it is older than a minute
+	lastUsedAt := pat.LastUsedAt.Add(-30 * time.Second)
+	_, err = s.UpdatePersonalAccessToken(ctx, &store.UpdatePersonalAccessToken{ID: pat.ID, LastUsedAt: &lastUsedAt})
+	require.NoError(t, err)
+	require.NotNil(t, authenticator.Authenticate(ctx, "Bearer "+token))
+	pat, err = s.GetPersonalAccessToken(ctx, &store.FindPersonalAccessToken{ID: &pat.ID})
+	require.NoError(t, err)
+	assert.WithinDuration(t, lastUsedAt, *pat.LastUsedAt, time.Millisecond)
+	lastUsedAt = lastUsedAt.Add(-time.Minute)
+	_, err = s.UpdatePersonalAccessToken(ctx, &store.UpdatePersonalAccessToken{ID: pat.ID, LastUsedAt: &lastUsedAt})
+	require.NoError(t, err)
+	require.NotNil(t, authenticator.Authenticate(ctx, "Bearer "+token))
+	pat, err = s.GetPersonalAccessToken(ctx, &store.FindPersonalAccessToken{ID: &pat.ID})
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), *pat.LastUsedAt, 10*time.Second)
+
+	// Unknown token is rejected
+	assert.Nil(t, authenticator.Authenticate(ctx, "Bearer "+PersonalAccessTokenPrefix+"unknown"))
+
+	// Expired token is rejected
+	expired, err := GeneratePersonalAccessToken()
+	require.NoError(t, err)
+	expiresAt := time.Now().Add(-time.Minute)
+	_, err = s.CreatePersonalAccessToken(ctx, &store.CreatePersonalAccessToken{
+		UserID:    user.ID,
+		TokenHash: HashToken(expired),
+		ExpiresAt: &expiresAt,
+	})
+	require.NoError(t, err)
+	assert.Nil(t, authenticator.Authenticate(ctx, "Bearer "+expired))
+
+	// Deleted token is rejected
+	require.NoError(t, s.DeletePersonalAccessToken(ctx, &store.DeletePersonalAccessToken{ID: pat.ID}))
+	assert.Nil(t, authenticator.Authenticate(ctx, "Bearer "+token))
+}
+
+func TestAuthenticator_RevokedAccessToken(t *testing.T) {
+	ctx := context.Background()
+	s := storetest.NewStore(t)
+	secret := "testsecret"
+	user := createTestUser(t, s, "testuser", store.RoleUser)
+
+	token, err := GenerateAccessToken(user.ID, user.Username, store.RoleUser, secret)
+	require.NoError(t, err)
+	claims, err := ValidateAccessToken(token, secret)
+	require.NoError(t, err)
+	require.NotEmpty(t, claims.ID)
+
+	authenticator := NewAuthenticator(s, secret)
+	require.NotNil(t, authenticator.Authenticate(ctx, "Bearer "+token))
+
+	// Revoking the jti rejects the token even though the negative lookup was cached
+	_, err = s.CreateRevokedToken(ctx, &store.CreateRevokedToken{
+		JTI:       claims.ID,
+		UserID:    claims.UserID,
+		ExpiresAt: claims.ExpiresAt.Time,
+	})
+	require.NoError(t, err)
+	assert.Nil(t, authenticator.Authenticate(ctx, "Bearer "+token))
+
+	// Other tokens of the same user are unaffected
+	other, err := GenerateAccessToken(user.ID, user.Username, store.RoleUser, secret)
+	require.NoError(t, err)
+	assert.NotNil(t, authenticator.Authenticate(ctx, "Bearer "+other))
+}
+
+func TestAuthenticator_RevokedSession(t *testing.T) {
+	ctx := context.Background()
+	s := storetest.NewStore(t)
+	secret := "testsecret"
+	authenticator := NewAuthenticator(s, secret)
+	user := createTestUser(t, s, "testuser", store.RoleUser)
+
+	token, err := GenerateSessionAccessToken(user.ID, user.Username, store.RoleUser, "session-1", secret)
+	require.NoError(t, err)
+	result := authenticator.Authenticate(ctx, "Bearer "+token)
+	require.NotNil(t, result)
+	assert.Equal(t, "session-1", result.Claims.SessionID)
+
+	// Revoking the session denies every access token issued for it
+	_, err = s.CreateRevokedToken(ctx, &store.CreateRevokedToken{
+		JTI:       "session-1",
+		UserID:    1,
+		ExpiresAt: time.Now().Add(AccessTokenDuration),
+	})
+	require.NoError(t, err)
+	assert.Nil(t, authenticator.Authenticate(ctx, "Bearer "+token))
+}
+
+func TestKeyManager_SignAndValidate(t *testing.T) {
+	ctx := context.Background()
+	for _, algorithm := range []string{SigningAlgorithmEdDSA, SigningAlgorithmRS256} {
+		t.Run(algorithm, func(t *testing.T) {
+			keys := NewKeyManager(storetest.NewStore(t), "testsecret")
+			key, err := keys.Rotate(ctx, algorithm)
+			require.NoError(t, err)
+
+			token, err := keys.GenerateAccessToken(ctx, 1, "testuser", store.RoleUser, "session-1", AccessTokenDuration)
+			require.NoError(t, err)
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &JWTClaims{})
+			require.NoError(t, err)
+			assert.Equal(t, key.Kid, parsed.Header["kid"])
+			assert.Equal(t, algorithm, parsed.Header["alg"])
+
+			claims, err := keys.ValidateAccessToken(ctx, token)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), claims.UserID)
+			assert.Equal(t, "session-1", claims.SessionID)
+
+			// Signed tokens are not accepted by the shared secret
+			_, err = ValidateAccessToken(token, "testsecret")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestKeyManager_Rotate(t *testing.T) {
+	ctx := context.Background()
+	s := storetest.NewStore(t)
+	keys := NewKeyManager(s, "testsecret")
+
+	oldToken, err := keys.GenerateAccessToken(ctx, 1, "testuser", store.RoleUser, "", AccessTokenDuration)
+	require.NoError(t, err)
+	newKey, err := keys.Rotate(ctx, SigningAlgorithmEdDSA)
+	require.NoError(t, err)
+
+	// New tokens use the new key, old tokens remain valid during the grace period
+	newToken, err := keys.GenerateAccessToken(ctx, 1, "testuser", store.RoleUser, "", AccessTokenDuration)
+	require.NoError(t, err)
+	parsed, _, err := jwt.NewParser().ParseUnverified(newToken, &JWTClaims{})
+	require.NoError(t, err)
+	assert.Equal(t, newKey.Kid, parsed.Header["kid"])
+	_, err = keys.ValidateAccessToken(ctx, newToken)
+	assert.NoError(t, err)
+	_, err = keys.ValidateAccessToken(ctx, oldToken)
+	assert.NoError(t, err)
+
+	setting, err := s.GetInstanceJWTSigningKeySetting(ctx)
+	require.NoError(t, err)
+	require.Len(t, setting.Keys, 2)
+	require.NotNil(t, setting.Keys[0].ExpiresAt)
+	assert.Nil(t, setting.Keys[1].ExpiresAt)
+
+	jwks, err := keys.JWKS(ctx)
+	require.NoError(t, err)
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "OKP", jwks.Keys[1].Kty)
+	assert.Equal(t, newKey.Kid, jwks.Keys[1].Kid)
+	assert.NotEmpty(t, jwks.Keys[1].X)
+
+	// Once the grace period is over the old key no longer verifies
+	setting.Keys[0].ExpiresAt = timestamppb.New(time.Now().Add(-time.Minute))
+	_, err = s.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+		Key:   storepb.InstanceSettingKey_JWT_SIGNING_KEYS,
+		Value: &storepb.InstanceSetting_JwtSigningKeySetting{JwtSigningKeySetting: setting},
+	})
+	require.NoError(t, err)
+	_, err = keys.ValidateAccessToken(ctx, oldToken)
+	assert.Error(t, err)
+	jwks, err = keys.JWKS(ctx)
+	require.NoError(t, err)
+	assert.Len(t, jwks.Keys, 1)
+
+	// Expired keys are dropped on the next rotation
+	_, err = keys.Rotate(ctx, SigningAlgorithmRS256)
+	require.NoError(t, err)
+	setting, err = s.GetInstanceJWTSigningKeySetting(ctx)
+	require.NoError(t, err)
+	require.Len(t, setting.Keys, 2)
+	assert.Equal(t, newKey.Kid, setting.Keys[0].Kid)
+}
+
+func TestKeyManager_LegacySecret(t *testing.T) {
+	ctx := context.Background()
+	s := storetest.NewStore(t)
+	keys := NewKeyManager(s, "testsecret")
+
+	legacyToken, err := GenerateAccessToken(1, "testuser", store.RoleUser, "testsecret")
+	require.NoError(t, err)
+	_, err = keys.ValidateAccessToken(ctx, legacyToken)
+	assert.NoError(t, err)
+
+	// HS256 tokens stay valid until the legacy grace period is over
+	_, err = keys.EnsureSigningKey(ctx)
+	require.NoError(t, err)
+	_, err = keys.ValidateAccessToken(ctx, legacyToken)
+	assert.NoError(t, err)
+
+	setting, err := s.GetInstanceJWTSigningKeySetting(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, setting.LegacySecretExpiresAt)
+	setting.LegacySecretExpiresAt = timestamppb.New(time.Now().Add(-time.Minute))
+	_, err = s.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+		Key:   storepb.InstanceSettingKey_JWT_SIGNING_KEYS,
+		Value: &storepb.InstanceSetting_JwtSigningKeySetting{JwtSigningKeySetting: setting},
+	})
+	require.NoError(t, err)
+	_, err = keys.ValidateAccessToken(ctx, legacyToken)
+	assert.Error(t, err)
+}
+
+func TestKeyManager_ConcurrentRotate(t *testing.T) {
+	ctx := context.Background()
+	s := storetest.NewStore(t)
+	// Two replicas sharing the database.
+	replicas := []*KeyManager{NewKeyManager(s, "testsecret"), NewKeyManager(store.New(s.GetDriver(), nil), "testsecret")}
+
+	const rotations = 8
+	kids := make(chan string, rotations)
+	var wg sync.WaitGroup
+	for i := range rotations {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			key, err := replicas[i%len(replicas)].Rotate(ctx, SigningAlgorithmEdDSA)
+			if assert.NoError(t, err) {
+				kids <- key.Kid
+			}
+		}()
+	}
+	wg.Wait()
+	close(kids)
+
+	// No rotation overwrote another one
+	setting, err := replicas[0].reloadSetting(ctx)
+	require.NoError(t, err)
+	assert.Len(t, setting.Keys, rotations)
+	for kid := range kids {
+		assert.NotNil(t, findSigningKey(setting, kid), kid)
+	}
+	active := 0
+	for _, key := range setting.Keys {
+		if key.ExpiresAt == nil {
+			active++
+		}
+	}
+	assert.Equal(t, 1, active)
+}
+
+func TestKeyManager_EncryptedSigningKeys(t *testing.T) {
+	ctx := context.Background()
+	s := storetest.NewStore(t)
+	basicSetting, err := s.GetInstanceBasicSetting(ctx)
+	require.NoError(t, err)
+	oldSecret := basicSetting.SecretKey
+	keys := NewKeyManager(s, oldSecret)
+
+	// Private keys stored in plain text by earlier versions are encrypted
+	legacy, err := GenerateSigningKey(SigningAlgorithmEdDSA, oldSecret)
+	require.NoError(t, err)
+	legacy.PrivateKey, err = decrypt(legacy.EncryptedPrivateKey, oldSecret, signingKeyEncryptionPurpose)
+	require.NoError(t, err)
+	legacy.EncryptedPrivateKey = ""
+	_, err = s.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+		Key:   storepb.InstanceSettingKey_JWT_SIGNING_KEYS,
+		Value: &storepb.InstanceSetting_JwtSigningKeySetting{JwtSigningKeySetting: &storepb.InstanceJWTSigningKeySetting{Keys: []*storepb.JWTSigningKey{legacy}}},
+	})
+	require.NoError(t, err)
+	token, err := keys.GenerateAccessToken(ctx, 1, "testuser", store.RoleUser, "", AccessTokenDuration)
+	require.NoError(t, err)
+	setting, err := s.GetInstanceJWTSigningKeySetting(ctx)
+	require.NoError(t, err)
+	require.Len(t, setting.Keys, 1)
+	assert.Equal(t, legacy.Kid, setting.Keys[0].Kid)
+	assert.Empty(t, setting.Keys[0].PrivateKey)
+	assert.NotContains(t, setting.Keys[0].EncryptedPrivateKey, "PRIVATE KEY")
+
+	// During a rotation of the instance secret key, servers with either key use all signing keys
+	newSecret, err := s.RotateInstanceSecretKey(ctx)
+	require.NoError(t, err)
+	restarted := NewKeyManager(s, newSecret)
+	_, err = restarted.ValidateAccessToken(ctx, token)
+	assert.NoError(t, err)
+	_, err = restarted.Rotate(ctx, SigningAlgorithmRS256)
+	require.NoError(t, err)
+	newToken, err := restarted.GenerateAccessToken(ctx, 1, "testuser", store.RoleUser, "", AccessTokenDuration)
+	require.NoError(t, err)
+	_, err = keys.ValidateAccessToken(ctx, newToken)
+	assert.NoError(t, err)
+
+	// Finishing the rotation re-encrypts them with the new key
+	require.NoError(t, ReencryptSigningKeys(ctx, s))
+	setting, err = s.GetInstanceJWTSigningKeySetting(ctx)
+	require.NoError(t, err)
+	require.Len(t, setting.Keys, 2)
+	for _, key := range setting.Keys {
+		_, err := decrypt(key.EncryptedPrivateKey, newSecret, signingKeyEncryptionPurpose)
+		assert.NoError(t, err, key.Kid)
+	}
+}
+
+func TestTOTP(t *testing.T) {
+	// RFC 6238 test vector, truncated to six digits
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	code, err := GenerateTOTPCode(secret, time.Unix(59, 0))
+	require.NoError(t, err)
+	assert.Equal(t, "287082", code)
+
+	step, ok := ValidateTOTPCode(secret, code, time.Unix(59, 0), 0)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), step)
+	// Codes of the neighbouring steps are accepted, older ones and replays are not
+	_, ok = ValidateTOTPCode(secret, code, time.Unix(89, 0), 0)
+	assert.True(t, ok)
+	_, ok = ValidateTOTPCode(secret, code, time.Unix(120, 0), 0)
+	assert.False(t, ok)
+	_, ok = ValidateTOTPCode(secret, code, time.Unix(59, 0), step)
+	assert.False(t, ok)
+
+	uri := TOTPURI(secret, "testuser")
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/go-server:testuser?"))
+	assert.Contains(t, uri, "secret="+secret)
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes()
+	require.NoError(t, err)
+	assert.Len(t, codes, 10)
+
+	remaining, ok := UseRecoveryCode(codes, strings.ToUpper(codes[3]))
+	assert.True(t, ok)
+	assert.Len(t, remaining, 9)
+	assert.NotContains(t, remaining, codes[3])
+	_, ok = UseRecoveryCode(remaining, codes[3])
+	assert.False(t, ok)
+}
+
+func TestEncryptTOTPSecret(t *testing.T) {
+	ciphertext, err := EncryptTOTPSecret("totp-secret", "testsecret")
+	require.NoError(t, err)
+	assert.NotContains(t, ciphertext, "totp-secret")
+
+	plaintext, err := DecryptTOTPSecret(ciphertext, "testsecret")
+	require.NoError(t, err)
+	assert.Equal(t, "totp-secret", plaintext)
+
+	_, err = DecryptTOTPSecret(ciphertext, "othersecret")
+	assert.Error(t, err)
+}
+
+func TestReencryptTOTPCredentials(t *testing.T) {
+	ctx := context.Background()
+	s := storetest.NewStore(t)
+	basicSetting, err := s.GetInstanceBasicSetting(ctx)
+	require.NoError(t, err)
+	oldSecret := basicSetting.SecretKey
+	createCredential := func(username, secret string) *store.TOTPCredential {
+		user := createTestUser(t, s, username, store.RoleUser)
+		encryptedSecret, err := EncryptTOTPSecret("totp-secret", secret)
+		require.NoError(t, err)
+		recoveryCodes, err := EncryptTOTPSecret("recovery-codes", secret)
+		require.NoError(t, err)
+		credential, err := s.CreateTOTPCredential(ctx, &store.CreateTOTPCredential{UserID: user.ID, Secret: encryptedSecret, RecoveryCodes: recoveryCodes})
+		require.NoError(t, err)
+		return credential
+	}
+	credential := createCredential("alice", oldSecret)
+	unreadable := createCredential("bob", "othersecret")
+
+	// Without a rotation nothing is re-encrypted
+	require.NoError(t, ReencryptTOTPCredentials(ctx, s))
+	stored, err := s.GetTOTPCredential(ctx, &store.FindTOTPCredential{ID: &credential.ID})
+	require.NoError(t, err)
+	assert.Equal(t, credential.Secret, stored.Secret)
+
+	// The rotation keeps the previous key until it is finished, and is not repeated before
+	newSecret, err := s.RotateInstanceSecretKey(ctx)
+	require.NoError(t, err)
+	_, err = s.RotateInstanceSecretKey(ctx)
+	assert.Error(t, err)
+	basicSetting, err = s.GetInstanceBasicSetting(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, oldSecret, basicSetting.PreviousSecretKey)
+	assert.Equal(t, newSecret, basicSetting.SecretKey)
+
+	require.NoError(t, ReencryptTOTPCredentials(ctx, s))
+	stored, err = s.GetTOTPCredential(ctx, &store.FindTOTPCredential{ID: &credential.ID})
+	require.NoError(t, err)
+	plaintext, err := DecryptTOTPSecret(stored.Secret, newSecret)
+	require.NoError(t, err)
+	assert.Equal(t, "totp-secret", plaintext)
+	plaintext, err = DecryptTOTPSecret(stored.RecoveryCodes, newSecret)
+	require.NoError(t, err)
+	assert.Equal(t, "recovery-codes", plaintext)
+	stored, err = s.GetTOTPCredential(ctx, &store.FindTOTPCredential{ID: &unreadable.ID})
+	require.NoError(t, err)
+	assert.Equal(t, unreadable.Secret, stored.Secret)
+	basicSetting, err = s.GetInstanceBasicSetting(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, basicSetting.PreviousSecretKey)
+	assert.Equal(t, newSecret, basicSetting.SecretKey)
+	_, err = s.RotateInstanceSecretKey(ctx)
+	assert.NoError(t, err)
+}
+
+func TestMFAChallengeToken(t *testing.T) {
+	token, claims, err := GenerateMFAChallengeToken(1, "testsecret")
+	require.NoError(t, err)
+	assert.NotEmpty(t, claims.ID)
+
+	validated, err := ValidateMFAChallengeToken(token, "testsecret")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), validated.UserID)
+
+	// A challenge token is not an access token
+	_, err = ValidateAccessToken(token, "testsecret")
+	assert.Error(t, err)
+	authenticator := NewAuthenticator(storetest.NewStore(t), "testsecret")
+	assert.Nil(t, authenticator.Authenticate(context.Background(), "Bearer "+token))
+}
+
+func TestLockoutPolicy(t *testing.T) {
+	// Unset fields fall back to the defaults
+	policy := NewLockoutPolicy(nil)
+	assert.Equal(t, int32(DefaultMaxAccountFailures), policy.MaxAccountFailures)
+	assert.Equal(t, DefaultLockoutDuration, policy.LockoutDuration)
+
+	policy = NewLockoutPolicy(&storepb.AccountLockoutPolicy{MaxAccountFailures: 4, BaseDelaySeconds: 2, MaxDelaySeconds: 5})
+	now := time.Now()
+	// The delay doubles with every failure up to the maximum
+	assert.Equal(t, now.Add(2*time.Second), policy.LockedUntil(store.LoginAttemptScopeAccount, 1, now))
+	assert.Equal(t, now.Add(4*time.Second), policy.LockedUntil(store.LoginAttemptScopeAccount, 2, now))
+	assert.Equal(t, now.Add(5*time.Second), policy.LockedUntil(store.LoginAttemptScopeAccount, 3, now))
+	// From the threshold on the lockout duration applies
+	assert.Equal(t, now.Add(DefaultLockoutDuration), policy.LockedUntil(store.LoginAttemptScopeAccount, 4, now))
+	// IP addresses are not delayed, only locked at their own threshold
+	assert.Equal(t, now, policy.LockedUntil(store.LoginAttemptScopeIP, 4, now))
+	assert.Equal(t, now.Add(DefaultLockoutDuration), policy.LockedUntil(store.LoginAttemptScopeIP, DefaultMaxIPFailures, now))
+}
+
+func TestTokenLifetimes(t *testing.T) {
+	// Unset fields fall back to the defaults
+	lifetimes := NewTokenLifetimes(nil)
+	assert.Equal(t, AccessTokenDuration, lifetimes.AccessToken)
+	assert.Equal(t, RefreshTokenDuration, lifetimes.RefreshToken)
+
+	lifetimes = NewTokenLifetimes(&storepb.InstanceSecuritySetting{AccessTokenLifetimeSeconds: 600, RefreshTokenLifetimeSeconds: 3600})
+	assert.Equal(t, 10*time.Minute, lifetimes.AccessToken)
+	assert.Equal(t, time.Hour, lifetimes.RefreshToken)
+
+	// Access tokens never outlive the signing key grace period
+	lifetimes = NewTokenLifetimes(&storepb.InstanceSecuritySetting{AccessTokenLifetimeSeconds: 7 * 86400})
+	assert.Equal(t, AccessTokenDuration, lifetimes.AccessToken)
+}
+
+func TestPasswordPolicy(t *testing.T) {
+	// Unset fields fall back to the defaults
+	policy := NewPasswordPolicy(nil)
+	assert.Equal(t, DefaultPasswordMinLength, policy.MinLength)
+	assert.Equal(t, DefaultPasswordExpiry, policy.Expiry)
+	assert.NoError(t, policy.Validate("correct horse battery", nil))
+
+	policy = NewPasswordPolicy(&storepb.InstancePasswordPolicySetting{
+		MinLength:        10,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSymbol:    true,
+		HistoryCount:     1,
+		ExpiryDays:       -1,
+	})
+	for password, rule := range map[string]PasswordRule{
+		"Sh0rt!":                   PasswordRuleMinLength,
+		strings.Repeat("Aa1!", 19): PasswordRuleMaxLength,
+		"lowercase-only1":          PasswordRuleUppercase,
+		"UPPERCASE-ONLY1":          PasswordRuleLowercase,
+		"No-digits-here":           PasswordRuleDigit,
+		"NoSymbols1234":            PasswordRuleSymbol,
+		"P@ssw0rd":                 PasswordRuleMinLength,
+		"P@ssword123":              PasswordRuleCommon,
+		"Tr0ub4dor&3-reused":       PasswordRuleHistory,
+	} {
+		previous, err := HashPassword("Tr0ub4dor&3-reused")
+		require.NoError(t, err)
+		err = policy.Validate(password, []string{previous})
+		var policyErr *PasswordPolicyError
+		if assert.ErrorAs(t, err, &policyErr, password) {
+			assert.Equal(t, rule, policyErr.Rule, password)
+		}
+	}
+	assert.NoError(t, policy.Validate("Tr0ub4dor&3-fresh", nil))
+
+	// Only the configured number of previous passwords is checked
+	older, err := HashPassword("Older-passw0rd!")
+	require.NoError(t, err)
+	newer, err := HashPassword("Newer-passw0rd!")
+	require.NoError(t, err)
+	assert.NoError(t, policy.Validate("Older-passw0rd!", []string{newer, older}))
+
+	// Negative expiry disables it
+	assert.Equal(t, PasswordNeverExpires, policy.ExpiresAt(time.Now()))
+	now := time.Now()
+	assert.Equal(t, now.Add(DefaultPasswordExpiry), NewPasswordPolicy(nil).ExpiresAt(now))
+
+	assert.True(t, IsCommonPassword("QWERTY"))
+	assert.False(t, IsCommonPassword("Tr0ub4dor&3-fresh"))
+}
+
+func TestAuthenticator_ClientCertificate(t *testing.T) {
+	s := storetest.NewStore(t)
+	ctx := context.Background()
+	_, err := s.CreateRoleDefinition(ctx, &store.CreateRoleDefinition{Name: "auditor", Permissions: []store.Permission{store.PermissionUsersRead}})
+	require.NoError(t, err)
+	authenticator := NewAuthenticator(s, "testsecret")
+	for _, entry := range []string{"spiffe://example.com/billing=admin", "reports=auditor, user"} {
+		principal, err := ParseServicePrincipal(entry)
+		require.NoError(t, err)
+		authenticator.ServicePrincipals = append(authenticator.ServicePrincipals, principal)
+	}
+	verified := func(cert *x509.Certificate) *tls.ConnectionState {
+		return &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}, VerifiedChains: [][]*x509.Certificate{{cert}}}
+	}
+	billingURI, _ := url.Parse("spiffe://example.com/billing")
+
+	// Principals are named by a subject alternative name or the common name
+	result := authenticator.Authenticate(WithTLSConnectionState(ctx, verified(&x509.Certificate{URIs: []*url.URL{billingURI}})), "")
+	require.NotNil(t, result)
+	assert.Equal(t, "spiffe://example.com/billing", result.Claims.ServicePrincipal)
+	assert.Equal(t, int64(0), result.Claims.UserID)
+	assert.Equal(t, store.RoleAdmin, result.Role())
+	assert.True(t, result.HasPermission(store.PermissionSettingsUpdate))
+
+	// gRPC connections carry the state in the peer
+	reports := &x509.Certificate{Subject: pkix.Name{CommonName: "reports"}}
+	result = authenticator.Authenticate(peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: *verified(reports)}}), "")
+	require.NotNil(t, result)
+	assert.Equal(t, store.RoleUser, result.Role())
+	assert.Equal(t, []store.Permission{store.PermissionUsersRead}, result.Claims.Permissions)
+
+	// Unknown names and certificates that were not verified are anonymous
+	assert.Nil(t, authenticator.Authenticate(WithTLSConnectionState(ctx, verified(&x509.Certificate{Subject: pkix.Name{CommonName: "unknown"}})), ""))
+	assert.Nil(t, authenticator.Authenticate(WithTLSConnectionState(ctx, &tls.ConnectionState{PeerCertificates: []*x509.Certificate{reports}}), ""))
+	// A bearer token takes precedence over the certificate
+	assert.Nil(t, authenticator.Authenticate(WithTLSConnectionState(ctx, verified(reports)), "Bearer invalid"))
+
+	for _, entry := range []string{"reports", "=admin", "reports=", "reports= ,"} {
+		_, err := ParseServicePrincipal(entry)
+		assert.Error(t, err, entry)
+	}
+}
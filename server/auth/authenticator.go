@@ -2,55 +2,181 @@ package auth
 
 import (
 	"context"
+	"errors"
+	"log/slog"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/pixb/go-server/store"
 )
 
 const PersonalAccessTokenPrefix = "pat_"
 
+// personalAccessTokenUsageInterval is how old the recorded last use of a personal access
+// token has to be before it is recorded again, so that busy tokens do not write on every request.
+const personalAccessTokenUsageInterval = time.Minute
+
 type AuthResult struct {
 	User        *store.User
 	Claims      *UserClaims
 	AccessToken string
 }
 
+// Role returns the role of the authenticated user, or an empty role for a nil result.
+func (r *AuthResult) Role() store.Role {
+	switch {
+	case r == nil:
+		return ""
+	case r.Claims != nil:
+		return store.Role(r.Claims.Role)
+	case r.User != nil:
+		return r.User.Role
+	default:
+		return ""
+	}
+}
+
+// HasPermission reports whether the authenticated user has been granted the permission.
+func (r *AuthResult) HasPermission(permission store.Permission) bool {
+	return r != nil && r.Claims.HasPermission(permission)
+}
+
 type Authenticator struct {
 	Store  *store.Store
 	Secret string
+	Keys   *KeyManager
+	// ServicePrincipals are authenticated by verified client certificates when a request
+	// has no bearer token.
+	ServicePrincipals []*ServicePrincipal
 }
 
 func NewAuthenticator(store *store.Store, secret string) *Authenticator {
-	return &Authenticator{Store: store, Secret: secret}
+	return &Authenticator{Store: store, Secret: secret, Keys: NewKeyManager(store, secret)}
 }
 
 func (a *Authenticator) Authenticate(ctx context.Context, authHeader string) *AuthResult {
 	token := ExtractBearerToken(authHeader)
 	if token == "" {
-		return nil
+		return a.authenticateClientCertificate(ctx)
 	}
 
-	if !strings.HasPrefix(token, PersonalAccessTokenPrefix) {
-		claims, err := a.AuthenticateByAccessTokenV2(token)
-		if err == nil && claims != nil {
-			return &AuthResult{
-				Claims:      claims,
-				AccessToken: token,
-			}
+	if strings.HasPrefix(token, PersonalAccessTokenPrefix) {
+		user, err := a.AuthenticateByPersonalAccessToken(ctx, token)
+		if err != nil || user == nil {
+			return nil
+		}
+		claims := &UserClaims{UserID: user.ID, Username: user.Username}
+		if err := a.resolveClaims(ctx, user, claims); err != nil {
+			return nil
+		}
+		return &AuthResult{
+			User:        user,
+			Claims:      claims,
+			AccessToken: token,
 		}
 	}
 
+	claims, err := a.AuthenticateByAccessTokenV2(ctx, token)
+	if err != nil || claims == nil {
+		return nil
+	}
+	user, err := a.Store.GetUser(ctx, &store.FindUser{ID: &claims.UserID})
+	if err != nil {
+		return nil
+	}
+	if err := a.resolveClaims(ctx, user, claims); err != nil {
+		return nil
+	}
+	return &AuthResult{
+		Claims:      claims,
+		AccessToken: token,
+	}
+}
+
+// resolveClaims sets the role and the permissions of the claims from the store,
+// the role in an access token may be out of date.
+func (a *Authenticator) resolveClaims(ctx context.Context, user *store.User, claims *UserClaims) error {
+	roles, err := a.Store.ListUserRoleDefinitions(ctx, user)
+	if err != nil {
+		return err
+	}
+	claims.Role = string(user.Role)
+	claims.Permissions = ResolvePermissions(roles)
 	return nil
 }
 
-func (a *Authenticator) AuthenticateByAccessTokenV2(token string) (*UserClaims, error) {
-	claims, err := ValidateAccessToken(token, a.Secret)
+// ResolvePermissions returns the sorted union of the permissions of the roles.
+func ResolvePermissions(roles []*store.RoleDefinition) []store.Permission {
+	permissions := []store.Permission{}
+	for _, role := range roles {
+		permissions = append(permissions, role.Permissions...)
+	}
+	slices.Sort(permissions)
+	return slices.Compact(permissions)
+}
+
+// AuthenticateByPersonalAccessToken resolves a pat_ token to its owner and records its use.
+// Recording the use is best effort, a failure does not fail the authentication.
+func (a *Authenticator) AuthenticateByPersonalAccessToken(ctx context.Context, token string) (*store.User, error) {
+	tokenHash := HashToken(token)
+	pat, err := a.Store.GetPersonalAccessToken(ctx, &store.FindPersonalAccessToken{TokenHash: &tokenHash})
 	if err != nil {
 		return nil, err
 	}
+	if pat == nil {
+		return nil, errors.New("personal access token not found")
+	}
+	now := time.Now()
+	if pat.ExpiresAt != nil && now.After(*pat.ExpiresAt) {
+		return nil, errors.New("personal access token expired")
+	}
+
+	user, err := a.Store.GetUser(ctx, &store.FindUser{ID: &pat.UserID})
+	if err != nil {
+		return nil, err
+	}
+
+	if pat.LastUsedAt == nil || now.Sub(*pat.LastUsedAt) >= personalAccessTokenUsageInterval {
+		if _, err := a.Store.UpdatePersonalAccessToken(ctx, &store.UpdatePersonalAccessToken{
+			ID:         pat.ID,
+			LastUsedAt: &now,
+		}); err != nil {
+			slog.Warn("failed to record the use of a personal access token",
+				slog.Int64("token_id", pat.ID), slog.Any("error", err))
+		}
+	}
+	return user, nil
+}
+
+// AuthenticateByAccessTokenV2 validates a JWT access token and rejects it if it has been revoked.
+func (a *Authenticator) AuthenticateByAccessTokenV2(ctx context.Context, token string) (*UserClaims, error) {
+	claims, err := a.Keys.ValidateAccessToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	// Tokens of OAuth clients are limited to their scopes, they do not grant access to the API.
+	if claims.ClientID != "" {
+		return nil, errors.New("access token issued to an oauth client")
+	}
+	// Tokens are denied by their own jti or by the session they belong to.
+	// Tokens issued before jti was introduced cannot be revoked individually.
+	for _, id := range []string{claims.ID, claims.SessionID} {
+		if id == "" {
+			continue
+		}
+		revoked, err := a.Store.IsTokenRevoked(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, errors.New("access token revoked")
+		}
+	}
 	return &UserClaims{
-		UserID:   claims.UserID,
-		Username: claims.Username,
-		Role:     claims.Role,
+		UserID:    claims.UserID,
+		Username:  claims.Username,
+		Role:      claims.Role,
+		SessionID: claims.SessionID,
 	}, nil
 }
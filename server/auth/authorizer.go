@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	"github.com/pixb/go-server/store"
+)
+
+// apiPackage is the proto package of the services the authorizer knows about.
+const apiPackage = "goserver.api.v1"
+
+var (
+	// ErrAuthenticationRequired is returned by Authorize for anonymous callers of non-public methods.
+	ErrAuthenticationRequired = errors.New("authentication required")
+	// ErrPermissionDenied is returned by Authorize for callers without the required roles or permissions.
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+// MethodRule is the authorization rule of an RPC method, declared with the
+// (goserver.api.v1.auth) method option.
+type MethodRule struct {
+	// Public methods can be called without authentication.
+	Public bool
+	// Roles the caller must have one of. Any authenticated caller is allowed when empty.
+	Roles []store.Role
+	// Permissions the caller must have all of.
+	Permissions []store.Permission
+	// ReadOnly methods do not change data and are served in read-only maintenance.
+	ReadOnly bool
+	// AlwaysAvailable methods are served in full maintenance too.
+	AlwaysAvailable bool
+}
+
+// Authorizer enforces the method rules of the API, for gRPC and Connect by the
+// procedure name and for the gateway by the HTTP rule the request matches.
+type Authorizer struct {
+	rules map[string]*MethodRule
+	// routes are ordered with the templates that have more literal segments first,
+	// so that "/users/me" wins over "/users/{id}".
+	routes []*gatewayRoute
+}
+
+// NewAuthorizer reads the rules and HTTP rules of all methods of the API from the registered descriptors.
+func NewAuthorizer() *Authorizer {
+	a := &Authorizer{rules: map[string]*MethodRule{}}
+	protoregistry.GlobalFiles.RangeFilesByPackage(apiPackage, func(file protoreflect.FileDescriptor) bool {
+		for i := 0; i < file.Services().Len(); i++ {
+			service := file.Services().Get(i)
+			for j := 0; j < service.Methods().Len(); j++ {
+				method := service.Methods().Get(j)
+				procedure := fmt.Sprintf("/%s/%s", service.FullName(), method.Name())
+				a.rules[procedure] = newMethodRule(method)
+				if rule, ok := proto.GetExtension(method.Options(), annotations.E_Http).(*annotations.HttpRule); ok && rule != nil {
+					if route := newGatewayRoute(rule, procedure); route != nil {
+						a.routes = append(a.routes, route)
+					}
+				}
+			}
+		}
+		return true
+	})
+	sort.SliceStable(a.routes, func(i, j int) bool {
+		return a.routes[i].literals() > a.routes[j].literals()
+	})
+	return a
+}
+
+func newMethodRule(method protoreflect.MethodDescriptor) *MethodRule {
+	rule := &MethodRule{}
+	if httpRule, ok := proto.GetExtension(method.Options(), annotations.E_Http).(*annotations.HttpRule); ok && httpRule != nil {
+		rule.ReadOnly = httpRule.GetGet() != ""
+	}
+	if maintenanceRule, ok := proto.GetExtension(method.Options(), v1pb.E_Maintenance).(*v1pb.MaintenanceRule); ok && maintenanceRule != nil {
+		rule.ReadOnly = rule.ReadOnly || maintenanceRule.ReadOnly || maintenanceRule.Always
+		rule.AlwaysAvailable = maintenanceRule.Always
+	}
+	authRule, ok := proto.GetExtension(method.Options(), v1pb.E_Auth).(*v1pb.AuthRule)
+	if !ok || authRule == nil {
+		return rule
+	}
+	rule.Public = authRule.Public
+	for _, role := range authRule.Roles {
+		rule.Roles = append(rule.Roles, RoleToString(role))
+	}
+	for _, permission := range authRule.Permissions {
+		rule.Permissions = append(rule.Permissions, store.Permission(permission))
+	}
+	return rule
+}
+
+// Rule returns the rule of a procedure. Procedures without a declared rule, including
+// unknown ones, require authentication.
+func (a *Authorizer) Rule(procedure string) *MethodRule {
+	if rule, ok := a.rules[procedure]; ok {
+		return rule
+	}
+	return &MethodRule{}
+}
+
+// Authorize checks that the rule of the procedure lets the caller in. result is nil for
+// anonymous callers. It returns ErrAuthenticationRequired or ErrPermissionDenied otherwise.
+func (a *Authorizer) Authorize(procedure string, result *AuthResult) error {
+	rule := a.Rule(procedure)
+	if rule.Public {
+		return nil
+	}
+	if result == nil {
+		return ErrAuthenticationRequired
+	}
+	if len(rule.Roles) > 0 && !slices.Contains(rule.Roles, result.Role()) {
+		return ErrPermissionDenied
+	}
+	for _, permission := range rule.Permissions {
+		if !result.HasPermission(permission) {
+			return ErrPermissionDenied
+		}
+	}
+	return nil
+}
+
+// GatewayRPCMethod returns the procedure a gateway request is routed to.
+func (a *Authorizer) GatewayRPCMethod(r *http.Request) (string, bool) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	for _, route := range a.routes {
+		if route.match(r.Method, segments) {
+			return route.procedure, true
+		}
+	}
+	return "", false
+}
+
+// authorizationCode returns the status code of an error returned by Authorize.
+func authorizationCode(err error) codes.Code {
+	if errors.Is(err, ErrAuthenticationRequired) {
+		return codes.Unauthenticated
+	}
+	return codes.PermissionDenied
+}
+
+// gatewayRoute is the HTTP rule of an RPC method.
+type gatewayRoute struct {
+	httpMethod string
+	// segments of the path template, variables are empty and match any one segment.
+	segments  []string
+	procedure string
+}
+
+func newGatewayRoute(rule *annotations.HttpRule, procedure string) *gatewayRoute {
+	var httpMethod, template string
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		httpMethod, template = http.MethodGet, pattern.Get
+	case *annotations.HttpRule_Post:
+		httpMethod, template = http.MethodPost, pattern.Post
+	case *annotations.HttpRule_Put:
+		httpMethod, template = http.MethodPut, pattern.Put
+	case *annotations.HttpRule_Patch:
+		httpMethod, template = http.MethodPatch, pattern.Patch
+	case *annotations.HttpRule_Delete:
+		httpMethod, template = http.MethodDelete, pattern.Delete
+	default:
+		return nil
+	}
+	segments := strings.Split(strings.Trim(template, "/"), "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") {
+			segments[i] = ""
+		}
+	}
+	return &gatewayRoute{httpMethod: httpMethod, segments: segments, procedure: procedure}
+}
+
+func (r *gatewayRoute) literals() int {
+	count := 0
+	for _, segment := range r.segments {
+		if segment != "" {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *gatewayRoute) match(httpMethod string, segments []string) bool {
+	if r.httpMethod != httpMethod || len(r.segments) != len(segments) {
+		return false
+	}
+	for i, segment := range r.segments {
+		if segment != "" && segment != segments[i] {
+			return false
+		}
+	}
+	return true
+}
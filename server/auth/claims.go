@@ -2,14 +2,28 @@ package auth
 
 import (
 	"context"
+	"slices"
+
+	"connectrpc.com/connect"
 
 	"github.com/pixb/go-server/store"
 )
 
 type UserClaims struct {
-	UserID   int64
-	Username string
-	Role     string
+	UserID    int64
+	Username  string
+	Role      string
+	SessionID string
+	// Permissions granted by the built-in role and the custom roles of the user.
+	Permissions []store.Permission
+	// ServicePrincipal is the name of the service authenticated by a client certificate,
+	// UserID is zero for services.
+	ServicePrincipal string
+}
+
+// HasPermission reports whether the user has been granted the permission.
+func (c *UserClaims) HasPermission(permission store.Permission) bool {
+	return c != nil && slices.Contains(c.Permissions, permission)
 }
 
 type ContextKey int
@@ -45,3 +59,16 @@ func SetUserInContext(ctx context.Context, user *store.User, accessToken string)
 	}
 	return ctx
 }
+
+// Require returns nil if the caller has been granted the permission, or an Unauthenticated
+// or PermissionDenied error for services to return as is.
+func Require(ctx context.Context, permission store.Permission) error {
+	claims := GetUserClaims(ctx)
+	if claims == nil {
+		return connect.NewError(connect.CodeUnauthenticated, ErrAuthenticationRequired)
+	}
+	if !claims.HasPermission(permission) {
+		return connect.NewError(connect.CodePermissionDenied, ErrPermissionDenied)
+	}
+	return nil
+}
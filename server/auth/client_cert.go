@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/pixb/go-server/store"
+)
+
+// ServicePrincipal is a backend service that authenticates with a client certificate
+// issued by the client CA instead of a bearer token.
+type ServicePrincipal struct {
+	// Name is matched against the DNS, URI and email subject alternative names of the
+	// certificate and its common name.
+	Name string
+	// Roles are the built-in and custom roles granted to the service.
+	Roles []store.Role
+}
+
+// ParseServicePrincipal parses a principal given as "name=role[,role...]".
+func ParseServicePrincipal(s string) (*ServicePrincipal, error) {
+	name, roles, ok := strings.Cut(s, "=")
+	if !ok || name == "" || roles == "" {
+		return nil, fmt.Errorf("service principal %q must have the form name=role[,role...]", s)
+	}
+	principal := &ServicePrincipal{Name: name}
+	for _, role := range strings.Split(roles, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			principal.Roles = append(principal.Roles, store.Role(role))
+		}
+	}
+	if len(principal.Roles) == 0 {
+		return nil, fmt.Errorf("service principal %q has no roles", s)
+	}
+	return principal, nil
+}
+
+// matches reports whether the certificate names the principal.
+func (p *ServicePrincipal) matches(cert *x509.Certificate) bool {
+	if slices.Contains(cert.DNSNames, p.Name) || slices.Contains(cert.EmailAddresses, p.Name) {
+		return true
+	}
+	for _, uri := range cert.URIs {
+		if uri.String() == p.Name {
+			return true
+		}
+	}
+	return cert.Subject.CommonName == p.Name
+}
+
+type tlsStateContextKey struct{}
+
+// WithTLSConnectionState returns a context carrying the TLS state of the connection of an
+// HTTP request, so that Connect and gateway handlers can see the client certificate.
+func WithTLSConnectionState(ctx context.Context, state *tls.ConnectionState) context.Context {
+	if state == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, tlsStateContextKey{}, state)
+}
+
+// ClientCertificate returns the client certificate of the connection if it has been
+// verified against the client CA, or nil.
+func ClientCertificate(ctx context.Context) *x509.Certificate {
+	state, _ := ctx.Value(tlsStateContextKey{}).(*tls.ConnectionState)
+	if state == nil {
+		if p, ok := peer.FromContext(ctx); ok {
+			if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+				state = &info.State
+			}
+		}
+	}
+	// Certificates are only verified when a client CA is configured.
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return state.VerifiedChains[0][0]
+}
+
+// authenticateClientCertificate authenticates the service principal named by the verified
+// client certificate of the connection, or returns nil.
+func (a *Authenticator) authenticateClientCertificate(ctx context.Context) *AuthResult {
+	cert := ClientCertificate(ctx)
+	if cert == nil {
+		return nil
+	}
+	index := slices.IndexFunc(a.ServicePrincipals, func(p *ServicePrincipal) bool { return p.matches(cert) })
+	if index < 0 {
+		return nil
+	}
+	principal := a.ServicePrincipals[index]
+
+	roles, err := a.Store.ListRoleDefinitions(ctx, &store.FindRoleDefinition{})
+	if err != nil {
+		return nil
+	}
+	principalRoles := []*store.RoleDefinition{}
+	for _, role := range roles {
+		if slices.Contains(principal.Roles, role.Name) {
+			principalRoles = append(principalRoles, role)
+		}
+	}
+	claims := &UserClaims{
+		Username:         principal.Name,
+		ServicePrincipal: principal.Name,
+		Permissions:      ResolvePermissions(principalRoles),
+	}
+	// The built-in role is checked by the method rules that list roles.
+	if slices.Contains(principal.Roles, store.RoleAdmin) {
+		claims.Role = string(store.RoleAdmin)
+	} else if slices.Contains(principal.Roles, store.RoleUser) {
+		claims.Role = string(store.RoleUser)
+	}
+	return &AuthResult{Claims: claims}
+}
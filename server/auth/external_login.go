@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ExternalLoginStateDuration is how long a user has to complete a sign-in at an identity provider.
+const ExternalLoginStateDuration = 10 * time.Minute
+
+const externalLoginKeyPurpose = "go-server external login state"
+
+// ExternalLoginClaims carry a sign-in at an identity provider from the redirect to the
+// provider to its callback. The token is kept in a cookie of the user agent, so the
+// callback can check that it completes a sign-in the same user agent started.
+type ExternalLoginClaims struct {
+	jwt.RegisteredClaims
+	Provider     string `json:"provider"`
+	State        string `json:"state"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+	// RedirectPath is the local path the user agent returns to afterwards.
+	RedirectPath string `json:"redirect_path"`
+}
+
+// GenerateExternalLoginToken signs the state of a sign-in with a key derived from the secret.
+func GenerateExternalLoginToken(claims *ExternalLoginClaims, secret string) (string, error) {
+	key, err := deriveKey(secret, externalLoginKeyPurpose)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(ExternalLoginStateDuration))
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(key)
+}
+
+func ValidateExternalLoginToken(tokenString, secret string) (*ExternalLoginClaims, error) {
+	key, err := deriveKey(secret, externalLoginKeyPurpose)
+	if err != nil {
+		return nil, err
+	}
+	token, err := jwt.ParseWithClaims(tokenString, &ExternalLoginClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return key, nil
+	}, jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, err
+	}
+	if claims, ok := token.Claims.(*ExternalLoginClaims); ok && token.Valid {
+		return claims, nil
+	}
+	return nil, fmt.Errorf("invalid token")
+}
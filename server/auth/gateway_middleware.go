@@ -2,20 +2,29 @@ package auth
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
-	"github.com/pixb/go-server/server/common"
+	"google.golang.org/grpc/codes"
 )
 
-// NewGatewayAuthMiddleware creates a gRPC-Gateway authentication middleware
-func NewGatewayAuthMiddleware(authenticator *Authenticator) func(next runtime.HandlerFunc) runtime.HandlerFunc {
+// NewGatewayAuthMiddleware creates a gRPC-Gateway authentication middleware. Requests are
+// checked against maintenance first, it may be nil.
+func NewGatewayAuthMiddleware(authenticator *Authenticator, authorizer *Authorizer, maintenance *Maintenance) func(next runtime.HandlerFunc) runtime.HandlerFunc {
 	return func(next runtime.HandlerFunc) runtime.HandlerFunc {
 		return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
 			ctx := r.Context()
 
-			// Get the RPC method name from context
-			rpcMethod, ok := runtime.RPCMethod(ctx)
+			// Get the RPC method name from the HTTP rules, the gateway only annotates
+			// the context with it after the middlewares have run
+			rpcMethod, ok := authorizer.GatewayRPCMethod(r)
+
+			// Reject methods not served in maintenance before authentication reads the database
+			if err := maintenance.Check(authorizer.Rule(rpcMethod)); err != nil {
+				err.WriteHTTP(w)
+				return
+			}
 
 			// Extract credentials from HTTP headers
 			authHeader := r.Header.Get("Authorization")
@@ -23,9 +32,14 @@ func NewGatewayAuthMiddleware(authenticator *Authenticator) func(next runtime.Ha
 			// Execute authentication
 			result := authenticator.Authenticate(ctx, authHeader)
 
-			// Enforce authentication for non-public methods
-			if result == nil && ok && !common.IsPublicMethod(rpcMethod) {
-				http.Error(w, `{"state": 401, "message": "authentication required", "data": null}`, http.StatusUnauthorized)
+			// Enforce the authorization rule of the method. Requests that match no HTTP rule
+			// have no rule to check and are denied.
+			if !ok {
+				writeGatewayError(w, ErrPermissionDenied)
+				return
+			}
+			if err := authorizer.Authorize(rpcMethod, result); err != nil {
+				writeGatewayError(w, err)
 				return
 			}
 
@@ -45,6 +59,18 @@ func NewGatewayAuthMiddleware(authenticator *Authenticator) func(next runtime.Ha
 	}
 }
 
+// writeGatewayError writes an error returned by Authorize as JSON, so that the response
+// wrapper keeps the status code.
+func writeGatewayError(w http.ResponseWriter, err error) {
+	statusCode := http.StatusForbidden
+	if authorizationCode(err) == codes.Unauthenticated {
+		statusCode = http.StatusUnauthorized
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	fmt.Fprintf(w, `{"state": %d, "message": %q, "data": null}`, statusCode, err.Error())
+}
+
 // SetUserIDInContext sets the user ID in the context
 func SetUserIDInContext(ctx context.Context, userID int64) context.Context {
 	return context.WithValue(ctx, UserIDContextKey, userID)
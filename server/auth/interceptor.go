@@ -2,7 +2,6 @@ package auth
 
 import (
 	"context"
-	"errors"
 
 	"connectrpc.com/connect"
 	"google.golang.org/grpc"
@@ -10,25 +9,42 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
-	"github.com/pixb/go-server/server/common"
 	"github.com/pixb/go-server/store"
 )
 
 // Interceptor is a common authentication interceptor that can be used for both gRPC and Connect-Go
 type Interceptor struct {
 	authenticator *Authenticator
+	authorizer    *Authorizer
+	maintenance   *Maintenance
 }
 
 // NewInterceptor creates a new authentication interceptor
 func NewInterceptor(store *store.Store, secret string) *Interceptor {
 	return &Interceptor{
 		authenticator: NewAuthenticator(store, secret),
+		authorizer:    NewAuthorizer(),
 	}
 }
 
+// SetServicePrincipals sets the services that authenticate with client certificates.
+func (i *Interceptor) SetServicePrincipals(principals []*ServicePrincipal) {
+	i.authenticator.ServicePrincipals = principals
+}
+
+// SetMaintenance sets the maintenance setting requests are checked against.
+func (i *Interceptor) SetMaintenance(maintenance *Maintenance) {
+	i.maintenance = maintenance
+}
+
 // GRPCUnaryInterceptor returns a gRPC unary interceptor
 func (i *Interceptor) GRPCUnaryInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		// Reject methods not served in maintenance before authentication reads the database
+		if err := i.maintenance.Check(i.authorizer.Rule(info.FullMethod)); err != nil {
+			return nil, err
+		}
+
 		// Extract metadata from context
 		md, ok := metadata.FromIncomingContext(ctx)
 		if !ok {
@@ -44,9 +60,9 @@ func (i *Interceptor) GRPCUnaryInterceptor() grpc.UnaryServerInterceptor {
 		// Execute authentication
 		result := i.authenticator.Authenticate(ctx, authHeader)
 
-		// Enforce authentication for non-public methods
-		if result == nil && !common.IsPublicMethod(info.FullMethod) {
-			return nil, status.Error(codes.Unauthenticated, "authentication required")
+		// Enforce the authorization rule of the method
+		if err := i.authorizer.Authorize(info.FullMethod, result); err != nil {
+			return nil, status.Error(authorizationCode(err), err.Error())
 		}
 
 		// Set context based on auth result
@@ -68,15 +84,20 @@ func (i *Interceptor) GRPCUnaryInterceptor() grpc.UnaryServerInterceptor {
 func (i *Interceptor) ConnectUnaryInterceptor() connect.UnaryInterceptorFunc {
 	return func(next connect.UnaryFunc) connect.UnaryFunc {
 		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			// Reject methods not served in maintenance before authentication reads the database
+			if err := i.maintenance.Check(i.authorizer.Rule(req.Spec().Procedure)); err != nil {
+				return nil, err.ConnectError()
+			}
+
 			// Extract authorization header
 			authHeader := req.Header().Get("Authorization")
 
 			// Execute authentication
 			result := i.authenticator.Authenticate(ctx, authHeader)
 
-			// Enforce authentication for non-public methods
-			if result == nil && !common.IsPublicMethod(req.Spec().Procedure) {
-				return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+			// Enforce the authorization rule of the method
+			if err := i.authorizer.Authorize(req.Spec().Procedure, result); err != nil {
+				return nil, connect.NewError(connect.Code(authorizationCode(err)), err)
 			}
 
 			// Set context based on auth result
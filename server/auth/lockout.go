@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"time"
+
+	storepb "github.com/pixb/go-server/proto/gen/store"
+	"github.com/pixb/go-server/store"
+)
+
+// Defaults of the account lockout policy, used for every field the instance setting leaves unset.
+const (
+	DefaultMaxAccountFailures = 5
+	DefaultMaxIPFailures      = 20
+	DefaultLockoutDuration    = 15 * time.Minute
+	DefaultFailureWindow      = 15 * time.Minute
+	DefaultBaseDelay          = time.Second
+	DefaultMaxDelay           = 30 * time.Second
+)
+
+// LockoutPolicy decides how long sign-in is refused after failed attempts.
+type LockoutPolicy struct {
+	MaxAccountFailures int32
+	MaxIPFailures      int32
+	LockoutDuration    time.Duration
+	FailureWindow      time.Duration
+	BaseDelay          time.Duration
+	MaxDelay           time.Duration
+}
+
+// NewLockoutPolicy builds the policy from the instance setting, filling in the defaults.
+func NewLockoutPolicy(setting *storepb.AccountLockoutPolicy) *LockoutPolicy {
+	seconds := func(value int32, fallback time.Duration) time.Duration {
+		if value <= 0 {
+			return fallback
+		}
+		return time.Duration(value) * time.Second
+	}
+	count := func(value, fallback int32) int32 {
+		if value <= 0 {
+			return fallback
+		}
+		return value
+	}
+	return &LockoutPolicy{
+		MaxAccountFailures: count(setting.GetMaxAccountFailures(), DefaultMaxAccountFailures),
+		MaxIPFailures:      count(setting.GetMaxIpFailures(), DefaultMaxIPFailures),
+		LockoutDuration:    seconds(setting.GetLockoutDurationSeconds(), DefaultLockoutDuration),
+		FailureWindow:      seconds(setting.GetFailureWindowSeconds(), DefaultFailureWindow),
+		BaseDelay:          seconds(setting.GetBaseDelaySeconds(), DefaultBaseDelay),
+		MaxDelay:           seconds(setting.GetMaxDelaySeconds(), DefaultMaxDelay),
+	}
+}
+
+// LockedUntil returns until when attempts are refused after the given number of consecutive failures.
+// Accounts are delayed progressively, every failure doubling the delay. IP addresses, which may be
+// shared by many users, are only locked once they reach their threshold.
+func (p *LockoutPolicy) LockedUntil(scope store.LoginAttemptScope, failures int32, lastFailedAt time.Time) time.Time {
+	if failures >= p.MaxFailures(scope) {
+		return lastFailedAt.Add(p.LockoutDuration)
+	}
+	if scope == store.LoginAttemptScopeIP {
+		return lastFailedAt
+	}
+	delay := p.BaseDelay
+	for i := int32(1); i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	return lastFailedAt.Add(min(delay, p.MaxDelay))
+}
+
+// MaxFailures returns the failed attempts after which the account or IP address is locked.
+func (p *LockoutPolicy) MaxFailures(scope store.LoginAttemptScope) int32 {
+	if scope == store.LoginAttemptScopeIP {
+		return p.MaxIPFailures
+	}
+	return p.MaxAccountFailures
+}
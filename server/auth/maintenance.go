@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	storepb "github.com/pixb/go-server/proto/gen/store"
+)
+
+// DefaultMaintenanceRetryAfter is how long rejected callers are told to wait when the
+// maintenance setting does not say.
+const DefaultMaintenanceRetryAfter = 5 * time.Minute
+
+// Maintenance holds the maintenance setting of the instance. Requests are checked against
+// it before authentication and without reading the database, which may be migrated
+// meanwhile. A nil Maintenance is always in service.
+type Maintenance struct {
+	setting atomic.Pointer[storepb.InstanceMaintenanceSetting]
+}
+
+// Set replaces the maintenance setting, nil puts the instance back in service.
+func (m *Maintenance) Set(setting *storepb.InstanceMaintenanceSetting) {
+	m.setting.Store(setting)
+}
+
+// Setting returns the maintenance setting, or nil while the instance is in service.
+func (m *Maintenance) Setting() *storepb.InstanceMaintenanceSetting {
+	if m == nil {
+		return nil
+	}
+	setting := m.setting.Load()
+	if setting.GetMode() == storepb.InstanceMaintenanceSetting_MODE_UNSPECIFIED {
+		return nil
+	}
+	return setting
+}
+
+// Check returns an error if methods with the rule are not served in the current mode.
+func (m *Maintenance) Check(rule *MethodRule) *MaintenanceError {
+	setting := m.Setting()
+	if setting == nil || rule.AlwaysAvailable {
+		return nil
+	}
+	if setting.Mode == storepb.InstanceMaintenanceSetting_READ_ONLY && rule.ReadOnly {
+		return nil
+	}
+	return NewMaintenanceError(setting)
+}
+
+// MaintenanceError rejects a request during maintenance. It converts to UNAVAILABLE with
+// a RetryInfo detail, and to 503 with a Retry-After header over HTTP.
+type MaintenanceError struct {
+	Message    string
+	RetryAfter time.Duration
+}
+
+// NewMaintenanceError returns the error for requests rejected by the maintenance setting.
+func NewMaintenanceError(setting *storepb.InstanceMaintenanceSetting) *MaintenanceError {
+	message := setting.GetMessage()
+	if message == "" && setting.GetMode() == storepb.InstanceMaintenanceSetting_READ_ONLY {
+		message = "the instance is read-only for maintenance"
+	} else if message == "" {
+		message = "the instance is down for maintenance"
+	}
+	retryAfter := time.Duration(setting.GetRetryAfterSeconds()) * time.Second
+	if retryAfter <= 0 {
+		retryAfter = DefaultMaintenanceRetryAfter
+	}
+	return &MaintenanceError{Message: message, RetryAfter: retryAfter}
+}
+
+func (e *MaintenanceError) Error() string {
+	return e.Message
+}
+
+// GRPCStatus is used by gRPC for the status of the error.
+func (e *MaintenanceError) GRPCStatus() *status.Status {
+	st := status.New(codes.Unavailable, e.Message)
+	if detailed, err := st.WithDetails(e.retryInfo()); err == nil {
+		return detailed
+	}
+	return st
+}
+
+// ConnectError returns the error for Connect, the retry delay is also sent as Retry-After.
+func (e *MaintenanceError) ConnectError() *connect.Error {
+	err := connect.NewError(connect.CodeUnavailable, e)
+	if detail, detailErr := connect.NewErrorDetail(e.retryInfo()); detailErr == nil {
+		err.AddDetail(detail)
+	}
+	err.Meta().Set("Retry-After", e.retryAfterSeconds())
+	return err
+}
+
+// WriteHTTP writes the error in the format of the unified JSON responses.
+func (e *MaintenanceError) WriteHTTP(w http.ResponseWriter) {
+	w.Header().Set("Retry-After", e.retryAfterSeconds())
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusServiceUnavailable)
+	fmt.Fprintf(w, `{"state": %d, "message": %q, "data": null}`, http.StatusServiceUnavailable, e.Message)
+}
+
+func (e *MaintenanceError) retryInfo() *errdetails.RetryInfo {
+	return &errdetails.RetryInfo{RetryDelay: durationpb.New(e.RetryAfter)}
+}
+
+func (e *MaintenanceError) retryAfterSeconds() string {
+	return strconv.Itoa(int(e.RetryAfter.Seconds()))
+}
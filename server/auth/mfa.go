@@ -0,0 +1,200 @@
+package auth
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/protobuf/proto"
+
+	storepb "github.com/pixb/go-server/proto/gen/store"
+	"github.com/pixb/go-server/store"
+)
+
+// MFAChallengeTokenDuration is how long a user has to complete a login with a second factor.
+const MFAChallengeTokenDuration = 5 * time.Minute
+
+const (
+	totpEncryptionPurpose   = "go-server totp encryption"
+	mfaChallengeKeyPurpose  = "go-server mfa challenge"
+	mfaChallengeTokenIssuer = "go-server"
+)
+
+// MFAChallengeClaims are the claims of the token returned by a login that still needs a second factor.
+type MFAChallengeClaims struct {
+	jwt.RegisteredClaims
+	UserID int64
+}
+
+// GenerateMFAChallengeToken issues a short-lived token proving that userID passed the password check.
+// It is signed with a key derived from the secret, so it is never accepted as an access token.
+func GenerateMFAChallengeToken(userID int64, secret string) (string, *MFAChallengeClaims, error) {
+	tokenID, err := GenerateTokenID()
+	if err != nil {
+		return "", nil, err
+	}
+	key, err := deriveKey(secret, mfaChallengeKeyPurpose)
+	if err != nil {
+		return "", nil, err
+	}
+	now := time.Now()
+	claims := &MFAChallengeClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			ExpiresAt: jwt.NewNumericDate(now.Add(MFAChallengeTokenDuration)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Issuer:    mfaChallengeTokenIssuer,
+		},
+		UserID: userID,
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(key)
+	if err != nil {
+		return "", nil, err
+	}
+	return token, claims, nil
+}
+
+func ValidateMFAChallengeToken(tokenString, secret string) (*MFAChallengeClaims, error) {
+	key, err := deriveKey(secret, mfaChallengeKeyPurpose)
+	if err != nil {
+		return nil, err
+	}
+	token, err := jwt.ParseWithClaims(tokenString, &MFAChallengeClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return key, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if claims, ok := token.Claims.(*MFAChallengeClaims); ok && token.Valid {
+		return claims, nil
+	}
+	return nil, fmt.Errorf("invalid token")
+}
+
+// EncryptTOTPSecret encrypts a TOTP secret or recovery codes for storage with AES-GCM,
+// using a key derived from the instance secret.
+func EncryptTOTPSecret(plaintext, secret string) (string, error) {
+	return encrypt(plaintext, secret, totpEncryptionPurpose)
+}
+
+func DecryptTOTPSecret(ciphertext, secret string) (string, error) {
+	return decrypt(ciphertext, secret, totpEncryptionPurpose)
+}
+
+// encrypt encrypts plaintext with AES-GCM, using the key derived from secret for purpose.
+func encrypt(plaintext, secret, purpose string) (string, error) {
+	gcm, err := newCipher(secret, purpose)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	ciphertext := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+func decrypt(ciphertext, secret, purpose string) (string, error) {
+	gcm, err := newCipher(secret, purpose)
+	if err != nil {
+		return "", err
+	}
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode encrypted secret: %w", err)
+	}
+	if len(data) < gcm.NonceSize() {
+		return "", errors.New("encrypted secret is too short")
+	}
+	plaintext, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt secret: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+func newCipher(secret, purpose string) (cipher.AEAD, error) {
+	key, err := deriveKey(secret, purpose)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// deriveKey derives a 256-bit key for a single purpose from the instance secret.
+func deriveKey(secret, purpose string) ([]byte, error) {
+	if secret == "" {
+		return nil, errors.New("secret is required")
+	}
+	return hkdf.Key(sha256.New, []byte(secret), nil, purpose, 32)
+}
+
+// ReencryptTOTPCredentials finishes a rotation of the instance secret key: it re-encrypts the
+// stored TOTP secrets and recovery code lists that are encrypted with the previous key, and
+// forgets the previous key in the same transaction. Credentials that neither key decrypts are
+// left as they are. Servers still running with the previous key encrypt new credentials with
+// it, so they have to be restarted before.
+func ReencryptTOTPCredentials(ctx context.Context, s *store.Store) error {
+	instanceBasicSetting, err := s.GetInstanceBasicSetting(ctx)
+	if err != nil {
+		return err
+	}
+	oldSecret, newSecret := instanceBasicSetting.PreviousSecretKey, instanceBasicSetting.SecretKey
+	if oldSecret == "" {
+		return nil
+	}
+	credentials, err := s.ListTOTPCredentials(ctx, &store.FindTOTPCredential{})
+	if err != nil {
+		return err
+	}
+	updates := []*store.UpdateTOTPCredential{}
+	for _, credential := range credentials {
+		if _, err := DecryptTOTPSecret(credential.Secret, newSecret); err == nil {
+			continue
+		}
+		update := &store.UpdateTOTPCredential{ID: credential.ID}
+		for _, field := range []struct {
+			value  string
+			target **string
+		}{
+			{credential.Secret, &update.Secret},
+			{credential.RecoveryCodes, &update.RecoveryCodes},
+		} {
+			plaintext, err := DecryptTOTPSecret(field.value, oldSecret)
+			if err != nil {
+				slog.Warn("failed to decrypt totp credential, the user has to enroll again",
+					slog.Int64("user_id", credential.UserID), slog.Any("error", err))
+				update = nil
+				break
+			}
+			ciphertext, err := EncryptTOTPSecret(plaintext, newSecret)
+			if err != nil {
+				return err
+			}
+			*field.target = &ciphertext
+		}
+		if update != nil {
+			updates = append(updates, update)
+		}
+	}
+	instanceBasicSetting = proto.Clone(instanceBasicSetting).(*storepb.InstanceBasicSetting)
+	instanceBasicSetting.PreviousSecretKey = ""
+	return s.ReencryptTOTPCredentials(ctx, updates, instanceBasicSetting)
+}
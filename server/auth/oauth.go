@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/pixb/go-server/store"
+)
+
+const (
+	// OAuthAccessTokenDuration is the lifetime of the access and ID tokens issued to OAuth clients.
+	OAuthAccessTokenDuration = time.Hour
+	// OAuthAuthorizationCodeDuration is how long a client has to exchange an authorization code.
+	OAuthAuthorizationCodeDuration = 10 * time.Minute
+)
+
+// The OpenID Connect scopes, clients may be registered with other scopes on top of them.
+const (
+	ScopeOpenID  = "openid"
+	ScopeProfile = "profile"
+	ScopeEmail   = "email"
+)
+
+// NewOAuthAccessTokenClaims builds the claims of an access token issued to an OAuth client,
+// on behalf of the user or, when user is nil, of the client itself.
+func NewOAuthAccessTokenClaims(issuer, clientID string, user *store.User, scopes []string) (*JWTClaims, error) {
+	tokenID, err := GenerateTokenID()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	claims := &JWTClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			Subject:   clientID,
+			ExpiresAt: jwt.NewNumericDate(now.Add(OAuthAccessTokenDuration)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    issuer,
+		},
+		ClientID: clientID,
+		Scope:    strings.Join(scopes, " "),
+	}
+	if user != nil {
+		claims.Subject = strconv.FormatInt(user.ID, 10)
+		claims.UserID = user.ID
+		claims.Username = user.Username
+	}
+	return claims, nil
+}
+
+// Scopes returns the scopes of a token issued to an OAuth client.
+func (c *JWTClaims) Scopes() []string {
+	return strings.Fields(c.Scope)
+}
+
+// NewIDTokenClaims builds the claims of an OpenID Connect ID token for the client,
+// including the claims about the user the scopes grant access to.
+func NewIDTokenClaims(issuer, clientID string, user *store.User, scopes []string, nonce string) jwt.MapClaims {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss": issuer,
+		"aud": clientID,
+		"exp": now.Add(OAuthAccessTokenDuration).Unix(),
+		"iat": now.Unix(),
+	}
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+	for name, value := range UserInfoClaims(user, scopes) {
+		claims[name] = value
+	}
+	return claims
+}
+
+// UserInfoClaims returns the standard claims about the user that the scopes grant access to.
+func UserInfoClaims(user *store.User, scopes []string) map[string]any {
+	claims := map[string]any{
+		"sub": strconv.FormatInt(user.ID, 10),
+	}
+	if slices.Contains(scopes, ScopeProfile) {
+		claims["preferred_username"] = user.Username
+		claims["name"] = user.Nickname
+		claims["updated_at"] = user.UpdatedAt.Unix()
+	}
+	if slices.Contains(scopes, ScopeEmail) && user.Email != "" {
+		claims["email"] = user.Email
+		claims["email_verified"] = user.EmailVerified
+	}
+	return claims
+}
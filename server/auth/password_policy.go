@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	storepb "github.com/pixb/go-server/proto/gen/store"
+)
+
+// Defaults of the password policy, used for every field the instance setting leaves unset.
+const (
+	DefaultPasswordMinLength = 8
+	DefaultPasswordExpiry    = 90 * 24 * time.Hour
+
+	// passwordMaxBytes is the most bcrypt can hash.
+	passwordMaxBytes = 72
+)
+
+// PasswordNeverExpires is stored as the expiry of passwords set while expiry is disabled.
+var PasswordNeverExpires = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// PasswordRule names a rule of the password policy.
+type PasswordRule string
+
+const (
+	PasswordRuleMinLength PasswordRule = "min_length"
+	PasswordRuleMaxLength PasswordRule = "max_length"
+	PasswordRuleUppercase PasswordRule = "uppercase"
+	PasswordRuleLowercase PasswordRule = "lowercase"
+	PasswordRuleDigit     PasswordRule = "digit"
+	PasswordRuleSymbol    PasswordRule = "symbol"
+	PasswordRuleCommon    PasswordRule = "common_password"
+	PasswordRuleHistory   PasswordRule = "history"
+)
+
+// PasswordPolicyError is returned for a password that breaks a rule of the policy.
+type PasswordPolicyError struct {
+	Rule   PasswordRule
+	Reason string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return fmt.Sprintf("password does not satisfy %s: %s", e.Rule, e.Reason)
+}
+
+// PasswordPolicy are the rules new passwords must satisfy.
+type PasswordPolicy struct {
+	MinLength            int
+	RequireUppercase     bool
+	RequireLowercase     bool
+	RequireDigit         bool
+	RequireSymbol        bool
+	AllowCommonPasswords bool
+	HistoryCount         int
+	// Expiry is zero when passwords never expire.
+	Expiry time.Duration
+}
+
+// NewPasswordPolicy builds the policy from the instance setting, filling in the defaults.
+func NewPasswordPolicy(setting *storepb.InstancePasswordPolicySetting) *PasswordPolicy {
+	policy := &PasswordPolicy{
+		MinLength:            int(setting.GetMinLength()),
+		RequireUppercase:     setting.GetRequireUppercase(),
+		RequireLowercase:     setting.GetRequireLowercase(),
+		RequireDigit:         setting.GetRequireDigit(),
+		RequireSymbol:        setting.GetRequireSymbol(),
+		AllowCommonPasswords: setting.GetAllowCommonPasswords(),
+		HistoryCount:         max(int(setting.GetHistoryCount()), 0),
+		Expiry:               time.Duration(setting.GetExpiryDays()) * 24 * time.Hour,
+	}
+	if policy.MinLength <= 0 {
+		policy.MinLength = DefaultPasswordMinLength
+	}
+	switch {
+	case setting.GetExpiryDays() == 0:
+		policy.Expiry = DefaultPasswordExpiry
+	case setting.GetExpiryDays() < 0:
+		policy.Expiry = 0
+	}
+	return policy
+}
+
+// Validate checks password against the policy. previousHashes are the hashes of the user's
+// recent passwords, most recent first, of which the first HistoryCount cannot be reused.
+// It returns a *PasswordPolicyError naming the first rule that is broken.
+func (p *PasswordPolicy) Validate(password string, previousHashes []string) error {
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return &PasswordPolicyError{Rule: PasswordRuleMinLength, Reason: fmt.Sprintf("must be at least %d characters", p.MinLength)}
+	}
+	if len(password) > passwordMaxBytes {
+		return &PasswordPolicyError{Rule: PasswordRuleMaxLength, Reason: fmt.Sprintf("must be at most %d bytes", passwordMaxBytes)}
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	for _, class := range []struct {
+		required bool
+		present  bool
+		rule     PasswordRule
+		reason   string
+	}{
+		{p.RequireUppercase, hasUpper, PasswordRuleUppercase, "must contain an uppercase letter"},
+		{p.RequireLowercase, hasLower, PasswordRuleLowercase, "must contain a lowercase letter"},
+		{p.RequireDigit, hasDigit, PasswordRuleDigit, "must contain a digit"},
+		{p.RequireSymbol, hasSymbol, PasswordRuleSymbol, "must contain a symbol"},
+	} {
+		if class.required && !class.present {
+			return &PasswordPolicyError{Rule: class.rule, Reason: class.reason}
+		}
+	}
+
+	if !p.AllowCommonPasswords && IsCommonPassword(password) {
+		return &PasswordPolicyError{Rule: PasswordRuleCommon, Reason: "is too common"}
+	}
+
+	for i, hash := range previousHashes {
+		if i >= p.HistoryCount {
+			break
+		}
+		if CheckPassword(password, hash) {
+			return &PasswordPolicyError{Rule: PasswordRuleHistory, Reason: fmt.Sprintf("must not be one of the last %d passwords", p.HistoryCount)}
+		}
+	}
+	return nil
+}
+
+// ExpiresAt returns when a password set at t expires.
+func (p *PasswordPolicy) ExpiresAt(t time.Time) time.Time {
+	if p.Expiry == 0 {
+		return PasswordNeverExpires
+	}
+	return t.Add(p.Expiry)
+}
+
+//go:embed common_passwords.txt
+var commonPasswordList string
+
+var commonPasswords = sync.OnceValue(func() map[string]struct{} {
+	passwords := map[string]struct{}{}
+	scanner := bufio.NewScanner(strings.NewReader(commonPasswordList))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			passwords[line] = struct{}{}
+		}
+	}
+	return passwords
+})
+
+// IsCommonPassword reports whether password is on the built-in list of common and breached passwords.
+func IsCommonPassword(password string) bool {
+	_, ok := commonPasswords()[strings.ToLower(password)]
+	return ok
+}
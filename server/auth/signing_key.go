@@ -0,0 +1,549 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	storepb "github.com/pixb/go-server/proto/gen/store"
+	"github.com/pixb/go-server/store"
+)
+
+const (
+	// SigningAlgorithmRS256 signs access tokens with a 2048-bit RSA key.
+	SigningAlgorithmRS256 = "RS256"
+	// SigningAlgorithmEdDSA signs access tokens with an Ed25519 key.
+	SigningAlgorithmEdDSA = "EdDSA"
+
+	// DefaultSigningAlgorithm is used for keys generated without an explicit algorithm.
+	DefaultSigningAlgorithm = SigningAlgorithmEdDSA
+
+	// SigningKeyGracePeriod is how long a rotated-out key keeps verifying tokens.
+	// It matches the access token lifetime, so every token signed before a rotation stays valid.
+	SigningKeyGracePeriod = AccessTokenDuration
+
+	rsaKeyBits = 2048
+
+	signingKeyEncryptionPurpose = "go-server signing key encryption"
+)
+
+// SigningKeyStore persists the JWT signing keys as an instance setting.
+type SigningKeyStore interface {
+	GetInstanceJWTSigningKeySetting(ctx context.Context) (*storepb.InstanceJWTSigningKeySetting, error)
+	GetInstanceBasicSetting(ctx context.Context) (*storepb.InstanceBasicSetting, error)
+	ListInstanceSettings(ctx context.Context, find *store.FindInstanceSetting) ([]*storepb.InstanceSetting, error)
+	UpdateInstanceSetting(ctx context.Context, key storepb.InstanceSettingKey, update func(*storepb.InstanceSetting) (*storepb.InstanceSetting, error)) (*storepb.InstanceSetting, error)
+}
+
+// KeyManager signs and verifies access tokens with the instance signing keys.
+// Tokens carry the kid of the key that signed them, so keys can be rotated
+// while tokens signed with the previous key are still in use.
+type KeyManager struct {
+	Store SigningKeyStore
+	// Secret verifies HS256 tokens issued before the signing keys were generated, and
+	// encrypts the private keys.
+	Secret string
+
+	// signers caches parsed private keys by kid, keys never change once generated.
+	signers sync.Map
+}
+
+func NewKeyManager(store SigningKeyStore, secret string) *KeyManager {
+	return &KeyManager{Store: store, Secret: secret}
+}
+
+// EnsureSigningKey returns the active signing key, generating the first one if there is none.
+// Private keys stored before they were encrypted are encrypted on the way.
+func (m *KeyManager) EnsureSigningKey(ctx context.Context) (*storepb.JWTSigningKey, error) {
+	setting, err := m.Store.GetInstanceJWTSigningKeySetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if key := activeSigningKey(setting); key != nil && !hasUnencryptedSigningKeys(setting) {
+		return key, nil
+	}
+
+	var key *storepb.JWTSigningKey
+	if err := m.updateSetting(ctx, func(setting *storepb.InstanceJWTSigningKeySetting) (bool, error) {
+		// Another server may have generated the key in the meantime.
+		if key = activeSigningKey(setting); key != nil {
+			return false, nil
+		}
+		var err error
+		if key, err = GenerateSigningKey(DefaultSigningAlgorithm, m.Secret); err != nil {
+			return false, err
+		}
+		addSigningKey(setting, key)
+		return true, nil
+	}); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Rotate generates a new active signing key with the given algorithm. The previous
+// active key is kept for SigningKeyGracePeriod and keys past their grace period are removed.
+func (m *KeyManager) Rotate(ctx context.Context, algorithm string) (*storepb.JWTSigningKey, error) {
+	key, err := GenerateSigningKey(algorithm, m.Secret)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.updateSetting(ctx, func(setting *storepb.InstanceJWTSigningKeySetting) (bool, error) {
+		addSigningKey(setting, key)
+		return true, nil
+	}); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// updateSetting saves the signing keys if update reports that it changed them. update starts
+// from the stored keys and runs again if another server changed them before they were saved,
+// so that no server signs with a key missing from the stored ones.
+func (m *KeyManager) updateSetting(ctx context.Context, update func(setting *storepb.InstanceJWTSigningKeySetting) (bool, error)) error {
+	if _, err := m.Store.UpdateInstanceSetting(ctx, storepb.InstanceSettingKey_JWT_SIGNING_KEYS, func(instanceSetting *storepb.InstanceSetting) (*storepb.InstanceSetting, error) {
+		setting := instanceSetting.GetJwtSigningKeySetting()
+		if setting == nil {
+			setting = &storepb.InstanceJWTSigningKeySetting{}
+		}
+		encrypted, err := m.encryptSigningKeys(setting)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := update(setting)
+		if err != nil {
+			return nil, err
+		}
+		if !encrypted && !updated {
+			return nil, nil
+		}
+		return &storepb.InstanceSetting{
+			Key:   storepb.InstanceSettingKey_JWT_SIGNING_KEYS,
+			Value: &storepb.InstanceSetting_JwtSigningKeySetting{JwtSigningKeySetting: setting},
+		}, nil
+	}); err != nil {
+		return fmt.Errorf("failed to save signing keys: %w", err)
+	}
+	return nil
+}
+
+// encryptSigningKeys encrypts the private keys stored in plain text, it reports whether there were any.
+func (m *KeyManager) encryptSigningKeys(setting *storepb.InstanceJWTSigningKeySetting) (bool, error) {
+	encrypted := false
+	for _, key := range setting.Keys {
+		if key.PrivateKey == "" {
+			continue
+		}
+		ciphertext, err := encrypt(key.PrivateKey, m.Secret, signingKeyEncryptionPurpose)
+		if err != nil {
+			return false, fmt.Errorf("failed to encrypt signing key %s: %w", key.Kid, err)
+		}
+		key.EncryptedPrivateKey, key.PrivateKey = ciphertext, ""
+		encrypted = true
+	}
+	return encrypted, nil
+}
+
+// ReencryptSigningKeys re-encrypts the private keys encrypted with the previous instance secret
+// key with the current one. It is part of finishing a rotation of the instance secret key and
+// has to run before ReencryptTOTPCredentials forgets the previous key. Keys that neither key
+// decrypts are left as they are.
+func ReencryptSigningKeys(ctx context.Context, s *store.Store) error {
+	instanceBasicSetting, err := s.GetInstanceBasicSetting(ctx)
+	if err != nil {
+		return err
+	}
+	oldSecret, newSecret := instanceBasicSetting.PreviousSecretKey, instanceBasicSetting.SecretKey
+	if oldSecret == "" {
+		return nil
+	}
+	return NewKeyManager(s, newSecret).updateSetting(ctx, func(setting *storepb.InstanceJWTSigningKeySetting) (bool, error) {
+		reencrypted := false
+		for _, key := range setting.Keys {
+			if _, err := decrypt(key.EncryptedPrivateKey, newSecret, signingKeyEncryptionPurpose); err == nil {
+				continue
+			}
+			plaintext, err := decrypt(key.EncryptedPrivateKey, oldSecret, signingKeyEncryptionPurpose)
+			if err != nil {
+				slog.Warn("failed to decrypt signing key", slog.String("kid", key.Kid), slog.Any("error", err))
+				continue
+			}
+			if key.EncryptedPrivateKey, err = encrypt(plaintext, newSecret, signingKeyEncryptionPurpose); err != nil {
+				return false, err
+			}
+			reencrypted = true
+		}
+		return reencrypted, nil
+	})
+}
+
+// GenerateAccessToken issues an access token signed with the active signing key. It expires
+// after lifetime, which is capped at AccessTokenDuration.
+func (m *KeyManager) GenerateAccessToken(ctx context.Context, userID int64, username string, role store.Role, sessionID string, lifetime time.Duration) (string, error) {
+	claims, err := newAccessTokenClaims(userID, username, role, sessionID, min(lifetime, AccessTokenDuration))
+	if err != nil {
+		return "", err
+	}
+	return m.Sign(ctx, claims)
+}
+
+// Sign signs any claims with the active signing key, such as the claims of an ID token.
+func (m *KeyManager) Sign(ctx context.Context, claims jwt.Claims) (string, error) {
+	key, err := m.EnsureSigningKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	signer, err := m.signer(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(signingMethod(key.Algorithm), claims)
+	token.Header["kid"] = key.Kid
+	return token.SignedString(signer)
+}
+
+// ValidateAccessToken verifies an access token against the signing key named by its kid.
+// Tokens without a kid are HS256 tokens signed with the secret, accepted until the
+// legacy grace period that started with the first signing key is over.
+func (m *KeyManager) ValidateAccessToken(ctx context.Context, tokenString string) (*JWTClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+			setting, err := m.Store.GetInstanceJWTSigningKeySetting(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if len(setting.Keys) > 0 && (setting.LegacySecretExpiresAt == nil || time.Now().After(setting.LegacySecretExpiresAt.AsTime())) {
+				return nil, errors.New("tokens signed with the secret are no longer accepted")
+			}
+			return []byte(m.Secret), nil
+		}
+
+		key, err := m.verificationKey(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+		if token.Method.Alg() != key.Algorithm {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		signer, err := m.signer(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		return signer.Public(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if claims, ok := token.Claims.(*JWTClaims); ok && token.Valid {
+		return claims, nil
+	}
+	return nil, fmt.Errorf("invalid token")
+}
+
+// JWKS returns the public keys that currently verify access tokens.
+func (m *KeyManager) JWKS(ctx context.Context) (*JSONWebKeySet, error) {
+	setting, err := m.Store.GetInstanceJWTSigningKeySetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	jwks := &JSONWebKeySet{Keys: []JSONWebKey{}}
+	now := time.Now()
+	for _, key := range setting.Keys {
+		if key.ExpiresAt != nil && now.After(key.ExpiresAt.AsTime()) {
+			continue
+		}
+		signer, err := m.signer(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		jwk := JSONWebKey{
+			Kid: key.Kid,
+			Use: "sig",
+			Alg: key.Algorithm,
+		}
+		switch pub := signer.Public().(type) {
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks, nil
+}
+
+// JSONWebKeySet is the RFC 7517 representation of the public signing keys.
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// Ed25519 or, together with Y, elliptic curve public key.
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	// RSA public key.
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+}
+
+// PublicKey decodes the public key of an RSA, P-256/P-384/P-521 or Ed25519 JWK.
+func (k *JSONWebKey) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rsa modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rsa exponent: %w", err)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > math.MaxInt32 {
+			return nil, errors.New("invalid rsa exponent")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ec x coordinate: %w", err)
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ec y coordinate: %w", err)
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) != size || len(y) != size {
+			return nil, errors.New("invalid ec public key")
+		}
+		// The uncompressed SEC 1 encoding is checked to be on the curve.
+		return ecdsa.ParseUncompressedPublicKey(curve, append(append([]byte{4}, x...), y...))
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid ed25519 public key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// GenerateSigningKey creates a new signing key for the given algorithm, its private key
+// encrypted with the instance secret.
+func GenerateSigningKey(algorithm, secret string) (*storepb.JWTSigningKey, error) {
+	var privateKey crypto.Signer
+	var err error
+	switch algorithm {
+	case SigningAlgorithmEdDSA:
+		_, privateKey, err = ed25519.GenerateKey(rand.Reader)
+	case SigningAlgorithmRS256:
+		privateKey, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm: %s", algorithm)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal signing key: %w", err)
+	}
+	encryptedPrivateKey, err := encrypt(string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), secret, signingKeyEncryptionPurpose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt signing key: %w", err)
+	}
+	kid, err := GenerateTokenID()
+	if err != nil {
+		return nil, err
+	}
+	return &storepb.JWTSigningKey{
+		Kid:                 kid,
+		Algorithm:           algorithm,
+		EncryptedPrivateKey: encryptedPrivateKey,
+		CreatedAt:           timestamppb.Now(),
+	}, nil
+}
+
+// verificationKey finds an unexpired key by kid, reloading the keys once in case
+// the kid was generated by another instance after the setting was cached.
+func (m *KeyManager) verificationKey(ctx context.Context, kid string) (*storepb.JWTSigningKey, error) {
+	setting, err := m.Store.GetInstanceJWTSigningKeySetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	key := findSigningKey(setting, kid)
+	if key == nil {
+		if setting, err = m.reloadSetting(ctx); err != nil {
+			return nil, err
+		}
+		key = findSigningKey(setting, kid)
+	}
+	if key == nil {
+		return nil, errors.New("unknown signing key")
+	}
+	if key.ExpiresAt != nil && time.Now().After(key.ExpiresAt.AsTime()) {
+		return nil, errors.New("signing key expired")
+	}
+	return key, nil
+}
+
+func (m *KeyManager) reloadSetting(ctx context.Context) (*storepb.InstanceJWTSigningKeySetting, error) {
+	list, err := m.Store.ListInstanceSettings(ctx, &store.FindInstanceSetting{
+		Name: storepb.InstanceSettingKey_JWT_SIGNING_KEYS.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return &storepb.InstanceJWTSigningKeySetting{}, nil
+	}
+	return list[0].GetJwtSigningKeySetting(), nil
+}
+
+func (m *KeyManager) signer(ctx context.Context, key *storepb.JWTSigningKey) (crypto.Signer, error) {
+	if signer, ok := m.signers.Load(key.Kid); ok {
+		return signer.(crypto.Signer), nil
+	}
+	privateKey := key.PrivateKey
+	if key.EncryptedPrivateKey != "" {
+		var err error
+		if privateKey, err = m.decryptPrivateKey(ctx, key.EncryptedPrivateKey); err != nil {
+			return nil, fmt.Errorf("failed to decrypt signing key %s: %w", key.Kid, err)
+		}
+	}
+	block, _ := pem.Decode([]byte(privateKey))
+	if block == nil {
+		return nil, fmt.Errorf("invalid signing key %s", key.Kid)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key %s: %w", key.Kid, err)
+	}
+	signer, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("invalid signing key %s", key.Kid)
+	}
+	m.signers.Store(key.Kid, signer)
+	return signer, nil
+}
+
+// decryptPrivateKey decrypts with the secret of the server or, during a rotation of the instance
+// secret key, with the new or the previous key that other servers may still be running with.
+func (m *KeyManager) decryptPrivateKey(ctx context.Context, ciphertext string) (string, error) {
+	plaintext, err := decrypt(ciphertext, m.Secret, signingKeyEncryptionPurpose)
+	if err == nil {
+		return plaintext, nil
+	}
+	instanceBasicSetting, settingErr := m.Store.GetInstanceBasicSetting(ctx)
+	if settingErr != nil {
+		return "", settingErr
+	}
+	for _, secret := range []string{instanceBasicSetting.SecretKey, instanceBasicSetting.PreviousSecretKey} {
+		if secret == "" || secret == m.Secret {
+			continue
+		}
+		if plaintext, err := decrypt(ciphertext, secret, signingKeyEncryptionPurpose); err == nil {
+			return plaintext, nil
+		}
+	}
+	return "", err
+}
+
+// addSigningKey makes key the active signing key. The active key is rotated out and keys past
+// their grace period are removed.
+func addSigningKey(setting *storepb.InstanceJWTSigningKeySetting, key *storepb.JWTSigningKey) {
+	now := time.Now()
+	if len(setting.Keys) == 0 && setting.LegacySecretExpiresAt == nil {
+		setting.LegacySecretExpiresAt = timestamppb.New(now.Add(AccessTokenDuration))
+	}
+	keys := []*storepb.JWTSigningKey{}
+	for _, k := range setting.Keys {
+		if k.ExpiresAt == nil {
+			k.ExpiresAt = timestamppb.New(now.Add(SigningKeyGracePeriod))
+		}
+		if k.ExpiresAt.AsTime().After(now) {
+			keys = append(keys, k)
+		}
+	}
+	setting.Keys = append(keys, key)
+}
+
+func hasUnencryptedSigningKeys(setting *storepb.InstanceJWTSigningKeySetting) bool {
+	for _, key := range setting.Keys {
+		if key.PrivateKey != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func activeSigningKey(setting *storepb.InstanceJWTSigningKeySetting) *storepb.JWTSigningKey {
+	for i := len(setting.Keys) - 1; i >= 0; i-- {
+		if setting.Keys[i].ExpiresAt == nil {
+			return setting.Keys[i]
+		}
+	}
+	return nil
+}
+
+func findSigningKey(setting *storepb.InstanceJWTSigningKeySetting, kid string) *storepb.JWTSigningKey {
+	for _, key := range setting.Keys {
+		if key.Kid == kid {
+			return key
+		}
+	}
+	return nil
+}
+
+func signingMethod(algorithm string) jwt.SigningMethod {
+	if algorithm == SigningAlgorithmRS256 {
+		return jwt.SigningMethodRS256
+	}
+	return jwt.SigningMethodEdDSA
+}
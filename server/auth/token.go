@@ -3,42 +3,94 @@ package auth
 import (
 	"crypto/rand"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/pixb/go-server/internal/tokenhash"
+	storepb "github.com/pixb/go-server/proto/gen/store"
 	"github.com/pixb/go-server/store"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// Default lifetimes of the tokens issued at sign-in. AccessTokenDuration is also the longest
+// lifetime of an access token, signing keys and revocations are kept that long.
 const (
 	AccessTokenDuration  = 24 * time.Hour
 	RefreshTokenDuration = 7 * 24 * time.Hour
 )
 
+// TokenLifetimes are the lifetimes of the tokens issued at sign-in.
+type TokenLifetimes struct {
+	AccessToken  time.Duration
+	RefreshToken time.Duration
+}
+
+// NewTokenLifetimes builds the lifetimes from the instance setting, filling in the defaults.
+func NewTokenLifetimes(setting *storepb.InstanceSecuritySetting) *TokenLifetimes {
+	lifetimes := &TokenLifetimes{AccessToken: AccessTokenDuration, RefreshToken: RefreshTokenDuration}
+	if seconds := setting.GetAccessTokenLifetimeSeconds(); seconds > 0 {
+		lifetimes.AccessToken = min(time.Duration(seconds)*time.Second, AccessTokenDuration)
+	}
+	if seconds := setting.GetRefreshTokenLifetimeSeconds(); seconds > 0 {
+		lifetimes.RefreshToken = time.Duration(seconds) * time.Second
+	}
+	return lifetimes
+}
+
 type JWTClaims struct {
 	jwt.RegisteredClaims
 	UserID   int64
 	Username string
 	Role     string
+	// SessionID is the refresh token family the access token was issued for, if any.
+	SessionID string `json:",omitempty"`
+	// ClientID and Scope are set on tokens issued to OAuth clients, see NewOAuthAccessTokenClaims.
+	ClientID string `json:"client_id,omitempty"`
+	Scope    string `json:"scope,omitempty"`
 }
 
 func GenerateAccessToken(userID int64, username string, role store.Role, secret string) (string, error) {
+	return GenerateSessionAccessToken(userID, username, role, "", secret)
+}
+
+// GenerateSessionAccessToken issues an access token bound to a login session,
+// so that revoking the session also revokes the token.
+func GenerateSessionAccessToken(userID int64, username string, role store.Role, sessionID, secret string) (string, error) {
+	claims, err := NewAccessTokenClaims(userID, username, role, sessionID)
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(secret))
+}
+
+// NewAccessTokenClaims builds the claims of a new access token with the default lifetime,
+// sessionID may be empty.
+func NewAccessTokenClaims(userID int64, username string, role store.Role, sessionID string) (*JWTClaims, error) {
+	return newAccessTokenClaims(userID, username, role, sessionID, AccessTokenDuration)
+}
+
+func newAccessTokenClaims(userID int64, username string, role store.Role, sessionID string, lifetime time.Duration) (*JWTClaims, error) {
+	tokenID, err := GenerateTokenID()
+	if err != nil {
+		return nil, err
+	}
 	now := time.Now()
-	claims := JWTClaims{
+	return &JWTClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(now.Add(AccessTokenDuration)),
+			ID:        tokenID,
+			ExpiresAt: jwt.NewNumericDate(now.Add(lifetime)),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
 			Issuer:    "go-server",
 		},
-		UserID:   userID,
-		Username: username,
-		Role:     string(role),
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret))
+		UserID:    userID,
+		Username:  username,
+		Role:      string(role),
+		SessionID: sessionID,
+	}, nil
 }
 
 func ValidateAccessToken(tokenString, secret string) (*JWTClaims, error) {
@@ -59,6 +111,16 @@ func ValidateAccessToken(tokenString, secret string) (*JWTClaims, error) {
 	return nil, fmt.Errorf("invalid token")
 }
 
+// GenerateTokenID returns a random identifier used as the jti claim of an access token.
+func GenerateTokenID() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
 func GenerateRefreshToken() (string, error) {
 	b := make([]byte, 32)
 	_, err := rand.Read(b)
@@ -68,6 +130,32 @@ func GenerateRefreshToken() (string, error) {
 	return base64.StdEncoding.EncodeToString(b), nil
 }
 
+// GeneratePersonalAccessToken returns a new random token carrying PersonalAccessTokenPrefix.
+func GeneratePersonalAccessToken() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return PersonalAccessTokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// GenerateOneTimeToken returns a new random token for links sent by email,
+// such as password reset and email verification tokens.
+func GenerateOneTimeToken() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken returns the hex encoded SHA-256 digest of an opaque token, used as its storage key.
+func HashToken(token string) string {
+	return tokenhash.Hash(token)
+}
+
 func HashPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	return string(bytes), err
@@ -0,0 +1,97 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// ClientInfo describes the client that issued a request.
+type ClientInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+// TrustedProxies are the addresses of the reverse proxies in front of the server, whose
+// X-Forwarded-For entries are believed.
+type TrustedProxies []netip.Prefix
+
+// ParseTrustedProxies parses IP addresses and CIDR ranges such as 10.0.0.0/8.
+func ParseTrustedProxies(entries []string) (TrustedProxies, error) {
+	proxies := TrustedProxies{}
+	for _, entry := range entries {
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			proxies = append(proxies, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(entry)
+		if err != nil {
+			return nil, fmt.Errorf("trusted proxy %q must be an IP address or a CIDR range", entry)
+		}
+		proxies = append(proxies, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return proxies, nil
+}
+
+// Contains reports whether the address is one of the trusted proxies.
+func (p TrustedProxies) Contains(address string) bool {
+	addr, err := netip.ParseAddr(address)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range p {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetClientInfo extracts the user agent and client IP from the incoming metadata.
+// It understands the metadata written by the gRPC-Gateway, interceptor.MetadataInterceptor
+// and plain gRPC clients.
+//
+// X-Forwarded-For entries are sent by the client, so the address is the nearest hop that
+// is not a trusted proxy: the transport peer, unless it is one of the trusted proxies.
+func GetClientInfo(ctx context.Context, trustedProxies TrustedProxies) ClientInfo {
+	info := ClientInfo{}
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	for _, key := range []string{"user-agent", "grpcgateway-user-agent"} {
+		if vals := md.Get(key); len(vals) > 0 && vals[0] != "" {
+			info.UserAgent = vals[0]
+			break
+		}
+	}
+
+	// The gRPC-Gateway and the interceptor append the peer address to X-Forwarded-For,
+	// the gRPC server reports it as the transport peer.
+	hops := []string{}
+	for _, val := range md.Get("x-forwarded-for") {
+		for _, hop := range strings.Split(val, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		address := p.Addr.String()
+		if host, _, err := net.SplitHostPort(address); err == nil {
+			address = host
+		}
+		hops = append(hops, address)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		info.IPAddress = hops[i]
+		if !trustedProxies.Contains(hops[i]) {
+			break
+		}
+	}
+	return info
+}
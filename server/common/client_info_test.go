@@ -0,0 +1,52 @@
+package common
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestGetClientInfo(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.0.2.1"})
+	require.NoError(t, err)
+	_, err = ParseTrustedProxies([]string{"proxy.example.com"})
+	assert.Error(t, err)
+
+	grpcPeer := func(ctx context.Context, ip string) context.Context {
+		return peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 50000}})
+	}
+	withXFF := func(xff string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", xff, "user-agent", "test"))
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		proxies TrustedProxies
+		want    string
+	}{
+		// The gRPC-Gateway and the interceptor append the peer address
+		{"gateway without proxy", withXFF("198.51.100.7"), nil, "198.51.100.7"},
+		{"forged entry without proxy", withXFF("203.0.113.9, 198.51.100.7"), nil, "198.51.100.7"},
+		{"forged entry through proxy", withXFF("203.0.113.9, 198.51.100.7, 10.1.2.3"), proxies, "198.51.100.7"},
+		{"chained proxies", withXFF("198.51.100.7, 192.0.2.1, 10.1.2.3"), proxies, "198.51.100.7"},
+		{"untrusted proxy", withXFF("198.51.100.7, 192.0.2.99"), proxies, "192.0.2.99"},
+		// Plain gRPC clients set any metadata, the transport peer is what counts
+		{"grpc peer", grpcPeer(context.Background(), "198.51.100.7"), nil, "198.51.100.7"},
+		{"forged grpc metadata", grpcPeer(withXFF("203.0.113.9"), "198.51.100.7"), nil, "198.51.100.7"},
+		{"forged grpc metadata of trusted proxy", grpcPeer(withXFF("203.0.113.9"), "192.0.2.99"), proxies, "192.0.2.99"},
+		{"grpc through proxy", grpcPeer(withXFF("198.51.100.7"), "10.1.2.3"), proxies, "198.51.100.7"},
+		{"in-process call", context.Background(), nil, ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, GetClientInfo(tc.ctx, tc.proxies).IPAddress)
+		})
+	}
+	assert.Equal(t, "test", GetClientInfo(withXFF("198.51.100.7"), nil).UserAgent)
+}
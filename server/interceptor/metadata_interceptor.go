@@ -2,6 +2,7 @@ package interceptor
 
 import (
 	"context"
+	"net"
 
 	"connectrpc.com/connect"
 	"google.golang.org/grpc/metadata"
@@ -21,11 +22,17 @@ func (*MetadataInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc
 		if ua := header.Get("User-Agent"); ua != "" {
 			md.Set("user-agent", ua)
 		}
-		if xff := header.Get("X-Forwarded-For"); xff != "" {
-			md.Set("x-forwarded-for", xff)
+		// Append the peer address the same way the gRPC-Gateway does, so the
+		// client IP is available even when there is no proxy in front.
+		xff := header.Get("X-Forwarded-For")
+		if host, _, err := net.SplitHostPort(req.Peer().Addr); err == nil {
+			if xff != "" {
+				xff += ", "
+			}
+			xff += host
 		}
-		if xri := header.Get("X-Real-Ip"); xri != "" {
-			md.Set("x-real-ip", xri)
+		if xff != "" {
+			md.Set("x-forwarded-for", xff)
 		}
 		if cookie := header.Get("Cookie"); cookie != "" {
 			md.Set("cookie", cookie)
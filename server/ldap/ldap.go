@@ -0,0 +1,240 @@
+// Package ldap verifies the passwords of users in an LDAP directory, such as OpenLDAP or
+// Active Directory, with the search and bind method: the user entry is searched with a
+// service account and the password is checked by binding as the entry.
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// DefaultUserFilter matches the user entry of a username, {username} is replaced
+	// with the escaped username.
+	DefaultUserFilter = "(&(objectClass=person)(uid={username}))"
+	// DefaultGroupFilter matches the groups of a user, {dn} is replaced with the escaped
+	// DN of the user entry.
+	DefaultGroupFilter = "(|(member={dn})(uniqueMember={dn}))"
+
+	// DefaultTimeout bounds each request to the directory.
+	DefaultTimeout = 10 * time.Second
+)
+
+// ErrInvalidCredentials is returned when the user does not exist or the password is wrong.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// Config is the connection to a directory and how its entries map to users.
+type Config struct {
+	// URL is the ldap:// or ldaps:// URL of the directory.
+	URL string
+	// StartTLS upgrades an ldap:// connection with the StartTLS operation.
+	StartTLS  bool
+	TLSConfig *tls.Config
+	// BindDN and BindPassword are the service account used for searches,
+	// searches are anonymous when BindDN is empty.
+	BindDN       string
+	BindPassword string
+	// BaseDN is searched for user entries with UserFilter, DefaultUserFilter by default.
+	BaseDN     string
+	UserFilter string
+	// The attributes read from user entries, the username attribute defaults to uid.
+	UsernameAttribute string
+	NicknameAttribute string
+	EmailAttribute    string
+	PhoneAttribute    string
+	// GroupBaseDN is searched for the groups of a user with GroupFilter, DefaultGroupFilter
+	// by default. The memberOf attribute of the user entry is used when it is empty.
+	GroupBaseDN string
+	GroupFilter string
+	Timeout     time.Duration
+}
+
+// Entry is a user entry whose password has been verified.
+type Entry struct {
+	DN       string
+	Username string
+	Nickname string
+	Email    string
+	Phone    string
+	// Groups are the DNs of the groups the user is a member of.
+	Groups []string
+}
+
+// Client verifies passwords against a directory. A connection is opened for each request.
+type Client struct {
+	config Config
+}
+
+// NewClient checks the configuration and returns a client for the directory.
+func NewClient(config Config) (*Client, error) {
+	if !strings.HasPrefix(config.URL, "ldap://") && !strings.HasPrefix(config.URL, "ldaps://") {
+		return nil, fmt.Errorf("ldap url %q must start with ldap:// or ldaps://", config.URL)
+	}
+	if config.BaseDN == "" {
+		return nil, errors.New("ldap base dn is required")
+	}
+	if config.UserFilter == "" {
+		config.UserFilter = DefaultUserFilter
+	}
+	if !strings.Contains(config.UserFilter, "{username}") {
+		return nil, errors.New("ldap user filter must contain {username}")
+	}
+	if config.GroupFilter == "" {
+		config.GroupFilter = DefaultGroupFilter
+	}
+	if config.UsernameAttribute == "" {
+		config.UsernameAttribute = "uid"
+	}
+	if config.Timeout == 0 {
+		config.Timeout = DefaultTimeout
+	}
+	return &Client{config: config}, nil
+}
+
+// Authenticate looks up the entry of username and checks the password by binding as it.
+// It returns ErrInvalidCredentials when there is no single entry for the username or the
+// directory rejects the password.
+func (c *Client) Authenticate(ctx context.Context, username, password string) (*Entry, error) {
+	// Most directories treat a bind without password as an anonymous bind that succeeds,
+	// see RFC 4513 section 5.1.2.
+	if username == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	conn, err := c.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	// Requests are aborted when the caller goes away.
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	if err := c.bindServiceAccount(conn); err != nil {
+		return nil, err
+	}
+	entry, err := c.findUser(conn, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := conn.Bind(entry.DN, password); err != nil {
+		if goldap.IsErrorWithCode(err, goldap.LDAPResultInvalidCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("failed to bind as user: %w", err)
+	}
+
+	result := &Entry{
+		DN:       entry.DN,
+		Username: entry.GetEqualFoldAttributeValue(c.config.UsernameAttribute),
+		Groups:   entry.GetEqualFoldAttributeValues("memberOf"),
+	}
+	if c.config.NicknameAttribute != "" {
+		result.Nickname = entry.GetEqualFoldAttributeValue(c.config.NicknameAttribute)
+	}
+	if c.config.EmailAttribute != "" {
+		result.Email = entry.GetEqualFoldAttributeValue(c.config.EmailAttribute)
+	}
+	if c.config.PhoneAttribute != "" {
+		result.Phone = entry.GetEqualFoldAttributeValue(c.config.PhoneAttribute)
+	}
+	if c.config.GroupBaseDN != "" {
+		// The user may not be allowed to search the groups.
+		if err := c.bindServiceAccount(conn); err != nil {
+			return nil, err
+		}
+		if result.Groups, err = c.findGroups(conn, entry.DN); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (c *Client) dial() (*goldap.Conn, error) {
+	conn, err := goldap.DialURL(c.config.URL,
+		goldap.DialWithDialer(&net.Dialer{Timeout: c.config.Timeout}),
+		goldap.DialWithTLSConfig(c.config.TLSConfig),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to directory: %w", err)
+	}
+	conn.SetTimeout(c.config.Timeout)
+
+	if c.config.StartTLS {
+		if err := conn.StartTLS(c.config.TLSConfig); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+	return conn, nil
+}
+
+func (c *Client) bindServiceAccount(conn *goldap.Conn) error {
+	var err error
+	if c.config.BindDN == "" {
+		err = conn.UnauthenticatedBind("")
+	} else {
+		err = conn.Bind(c.config.BindDN, c.config.BindPassword)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to bind service account: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) findUser(conn *goldap.Conn, username string) (*goldap.Entry, error) {
+	attributes := []string{c.config.UsernameAttribute, "memberOf"}
+	for _, attribute := range []string{c.config.NicknameAttribute, c.config.EmailAttribute, c.config.PhoneAttribute} {
+		if attribute != "" {
+			attributes = append(attributes, attribute)
+		}
+	}
+	filter := strings.ReplaceAll(c.config.UserFilter, "{username}", goldap.EscapeFilter(username))
+	// A size limit of 2 is enough to tell that the username is ambiguous.
+	result, err := conn.Search(goldap.NewSearchRequest(
+		c.config.BaseDN, goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 2, int(c.config.Timeout.Seconds()), false,
+		filter, attributes, nil,
+	))
+	if err != nil && !goldap.IsErrorWithCode(err, goldap.LDAPResultSizeLimitExceeded) {
+		return nil, fmt.Errorf("failed to search user: %w", err)
+	}
+	if result == nil || len(result.Entries) != 1 {
+		return nil, ErrInvalidCredentials
+	}
+	return result.Entries[0], nil
+}
+
+func (c *Client) findGroups(conn *goldap.Conn, dn string) ([]string, error) {
+	filter := strings.ReplaceAll(c.config.GroupFilter, "{dn}", goldap.EscapeFilter(dn))
+	// Only the DNs are needed, 1.1 requests no attributes, see RFC 4511 section 4.5.1.8.
+	result, err := conn.Search(goldap.NewSearchRequest(
+		c.config.GroupBaseDN, goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 0, int(c.config.Timeout.Seconds()), false,
+		filter, []string{"1.1"}, nil,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search groups: %w", err)
+	}
+	groups := make([]string, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		groups = append(groups, entry.DN)
+	}
+	return groups, nil
+}
+
+// SameDN reports whether two DNs name the same entry, ignoring case and spacing.
+func SameDN(a, b string) bool {
+	dnA, errA := goldap.ParseDN(a)
+	dnB, errB := goldap.ParseDN(b)
+	if errA != nil || errB != nil {
+		return strings.EqualFold(a, b)
+	}
+	return dnA.EqualFold(dnB)
+}
@@ -0,0 +1,126 @@
+package ldap_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pixb/go-server/server/ldap"
+	"github.com/pixb/go-server/server/ldap/ldaptest"
+)
+
+const (
+	serviceDN   = "cn=service,dc=example,dc=com"
+	aliceDN     = "uid=alice,ou=people,dc=example,dc=com"
+	developerDN = "cn=developers,ou=groups,dc=example,dc=com"
+)
+
+func newDirectory(t *testing.T) *ldaptest.Server {
+	t.Helper()
+	directory := ldaptest.NewServer(
+		&ldaptest.Entry{DN: serviceDN, Password: "service-secret", Attributes: map[string][]string{"objectClass": {"person"}}},
+		&ldaptest.Entry{DN: aliceDN, Password: "alice-secret", Attributes: map[string][]string{
+			"objectClass":     {"person", "inetOrgPerson"},
+			"uid":             {"alice"},
+			"displayName":     {"Alice Liddell"},
+			"mail":            {"alice@example.com"},
+			"telephoneNumber": {"+1 555 0100"},
+			"memberOf":        {"cn=admins,ou=groups,dc=example,dc=com"},
+		}},
+		&ldaptest.Entry{DN: developerDN, Attributes: map[string][]string{
+			"objectClass": {"groupOfNames"},
+			"member":      {aliceDN},
+		}},
+	)
+	t.Cleanup(directory.Close)
+	return directory
+}
+
+func newClient(t *testing.T, directory *ldaptest.Server, modify func(*ldap.Config)) *ldap.Client {
+	t.Helper()
+	config := ldap.Config{
+		URL:               directory.URL(),
+		BindDN:            serviceDN,
+		BindPassword:      "service-secret",
+		BaseDN:            "ou=people,dc=example,dc=com",
+		NicknameAttribute: "displayName",
+		EmailAttribute:    "mail",
+		PhoneAttribute:    "telephoneNumber",
+	}
+	if modify != nil {
+		modify(&config)
+	}
+	client, err := ldap.NewClient(config)
+	require.NoError(t, err)
+	return client
+}
+
+func TestClient_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	directory := newDirectory(t)
+	client := newClient(t, directory, nil)
+
+	entry, err := client.Authenticate(ctx, "alice", "alice-secret")
+	require.NoError(t, err)
+	assert.Equal(t, aliceDN, entry.DN)
+	assert.Equal(t, "alice", entry.Username)
+	assert.Equal(t, "Alice Liddell", entry.Nickname)
+	assert.Equal(t, "alice@example.com", entry.Email)
+	assert.Equal(t, "+1 555 0100", entry.Phone)
+	assert.Equal(t, []string{"cn=admins,ou=groups,dc=example,dc=com"}, entry.Groups)
+	assert.Equal(t, []string{serviceDN, aliceDN}, directory.Binds())
+
+	for _, credentials := range [][2]string{
+		{"alice", "wrong"},
+		{"alice", ""},
+		{"bob", "alice-secret"},
+		// The username is escaped in the filter
+		{"*", "alice-secret"},
+	} {
+		_, err := client.Authenticate(ctx, credentials[0], credentials[1])
+		assert.ErrorIs(t, err, ldap.ErrInvalidCredentials, credentials[0])
+	}
+}
+
+func TestClient_AmbiguousUsername(t *testing.T) {
+	directory := newDirectory(t)
+	directory.AddEntry(&ldaptest.Entry{DN: "uid=alice,ou=contractors,ou=people,dc=example,dc=com", Password: "other", Attributes: map[string][]string{
+		"objectClass": {"person"},
+		"uid":         {"alice"},
+	}})
+	client := newClient(t, directory, nil)
+
+	_, err := client.Authenticate(context.Background(), "alice", "alice-secret")
+	assert.ErrorIs(t, err, ldap.ErrInvalidCredentials)
+}
+
+func TestClient_GroupSearch(t *testing.T) {
+	directory := newDirectory(t)
+	client := newClient(t, directory, func(config *ldap.Config) {
+		config.GroupBaseDN = "ou=groups,dc=example,dc=com"
+	})
+
+	entry, err := client.Authenticate(context.Background(), "alice", "alice-secret")
+	require.NoError(t, err)
+	assert.Equal(t, []string{developerDN}, entry.Groups)
+	assert.True(t, ldap.SameDN("CN=Developers, OU=Groups,DC=example,DC=com", entry.Groups[0]))
+}
+
+func TestClient_ServiceAccount(t *testing.T) {
+	directory := newDirectory(t)
+	client := newClient(t, directory, func(config *ldap.Config) {
+		config.BindPassword = "wrong"
+	})
+
+	// A broken service account is an error, not a wrong password
+	_, err := client.Authenticate(context.Background(), "alice", "alice-secret")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ldap.ErrInvalidCredentials)
+
+	_, err = ldap.NewClient(ldap.Config{URL: "http://directory.example.com", BaseDN: "dc=example,dc=com"})
+	assert.Error(t, err)
+	_, err = ldap.NewClient(ldap.Config{URL: directory.URL(), BaseDN: "dc=example,dc=com", UserFilter: "(uid=alice)"})
+	assert.Error(t, err)
+}
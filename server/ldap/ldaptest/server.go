@@ -0,0 +1,297 @@
+// Package ldaptest provides an in-process LDAP directory for tests. It serves simple binds
+// and searches with the and, or, not, equality and presence filters over a fixed set of
+// entries, which is what the search and bind method of package ldap needs.
+package ldaptest
+
+import (
+	"bufio"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+// Entry is an entry of the directory. Entries with a password accept simple binds.
+type Entry struct {
+	DN         string
+	Password   string
+	Attributes map[string][]string
+}
+
+// Server is a directory listening on a loopback address.
+type Server struct {
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu      sync.Mutex
+	entries []*Entry
+	conns   map[net.Conn]struct{}
+	binds   []string
+}
+
+// NewServer starts a directory with the entries.
+func NewServer(entries ...*Entry) *Server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+	s := &Server{listener: listener, entries: entries, conns: map[net.Conn]struct{}{}}
+	s.wg.Add(1)
+	go s.serve()
+	return s
+}
+
+// URL is the ldap:// URL of the directory.
+func (s *Server) URL() string {
+	return "ldap://" + s.listener.Addr().String()
+}
+
+// AddEntry adds an entry to the directory.
+func (s *Server) AddEntry(entry *Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, entry)
+}
+
+// SetAttribute replaces the values of an attribute of the entry with the DN.
+func (s *Server) SetAttribute(dn, attribute string, values ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, entry := range s.entries {
+		if sameDN(entry.DN, dn) {
+			entry.Attributes[attribute] = values
+		}
+	}
+}
+
+// Binds returns the DNs of the successful non-anonymous binds so far.
+func (s *Server) Binds() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.binds)
+}
+
+// Close stops the directory and closes open connections.
+func (s *Server) Close() {
+	s.listener.Close()
+	s.mu.Lock()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+			s.mu.Lock()
+			delete(s.conns, conn)
+			s.mu.Unlock()
+			conn.Close()
+		}()
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	reader := bufio.NewReader(conn)
+	for {
+		packet, err := ber.ReadPacket(reader)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		messageID, _ := packet.Children[0].Value.(int64)
+		request := packet.Children[1]
+		if request.ClassType != ber.ClassApplication {
+			return
+		}
+		var responses []*ber.Packet
+		switch request.Tag {
+		case goldap.ApplicationBindRequest:
+			responses = []*ber.Packet{result(goldap.ApplicationBindResponse, s.bind(request))}
+		case goldap.ApplicationSearchRequest:
+			responses = s.search(request)
+		case goldap.ApplicationExtendedRequest:
+			responses = []*ber.Packet{result(goldap.ApplicationExtendedResponse, goldap.LDAPResultProtocolError)}
+		default:
+			return
+		}
+		for _, response := range responses {
+			envelope := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+			envelope.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "Message ID"))
+			envelope.AppendChild(response)
+			if _, err := conn.Write(envelope.Bytes()); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (s *Server) bind(request *ber.Packet) uint16 {
+	if len(request.Children) < 3 {
+		return goldap.LDAPResultProtocolError
+	}
+	name, _ := request.Children[1].Value.(string)
+	password := request.Children[2].Data.String()
+	if name == "" && password == "" {
+		return goldap.LDAPResultSuccess
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, entry := range s.entries {
+		if sameDN(entry.DN, name) && entry.Password != "" && entry.Password == password {
+			s.binds = append(s.binds, entry.DN)
+			return goldap.LDAPResultSuccess
+		}
+	}
+	return goldap.LDAPResultInvalidCredentials
+}
+
+func (s *Server) search(request *ber.Packet) []*ber.Packet {
+	if len(request.Children) < 8 {
+		return []*ber.Packet{result(goldap.ApplicationSearchResultDone, goldap.LDAPResultProtocolError)}
+	}
+	baseDN, _ := request.Children[0].Value.(string)
+	scope, _ := request.Children[1].Value.(int64)
+	sizeLimit, _ := request.Children[3].Value.(int64)
+	filter := request.Children[6]
+	var attributes []string
+	for _, attribute := range request.Children[7].Children {
+		name, _ := attribute.Value.(string)
+		attributes = append(attributes, name)
+	}
+
+	base, err := goldap.ParseDN(baseDN)
+	if err != nil {
+		return []*ber.Packet{result(goldap.ApplicationSearchResultDone, goldap.LDAPResultInvalidDNSyntax)}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var responses []*ber.Packet
+	for _, entry := range s.entries {
+		dn, err := goldap.ParseDN(entry.DN)
+		if err != nil || !inScope(base, dn, scope) || !matches(entry, filter) {
+			continue
+		}
+		if sizeLimit > 0 && int64(len(responses)) == sizeLimit {
+			return append(responses, result(goldap.ApplicationSearchResultDone, goldap.LDAPResultSizeLimitExceeded))
+		}
+		responses = append(responses, searchResultEntry(entry, attributes))
+	}
+	return append(responses, result(goldap.ApplicationSearchResultDone, goldap.LDAPResultSuccess))
+}
+
+func inScope(base, dn *goldap.DN, scope int64) bool {
+	switch scope {
+	case goldap.ScopeBaseObject:
+		return base.EqualFold(dn)
+	case goldap.ScopeSingleLevel:
+		return len(dn.RDNs) == len(base.RDNs)+1 && base.AncestorOfFold(dn)
+	default:
+		return base.EqualFold(dn) || base.AncestorOfFold(dn)
+	}
+}
+
+func matches(entry *Entry, filter *ber.Packet) bool {
+	switch filter.Tag {
+	case goldap.FilterAnd:
+		for _, child := range filter.Children {
+			if !matches(entry, child) {
+				return false
+			}
+		}
+		return true
+	case goldap.FilterOr:
+		for _, child := range filter.Children {
+			if matches(entry, child) {
+				return true
+			}
+		}
+		return false
+	case goldap.FilterNot:
+		return len(filter.Children) == 1 && !matches(entry, filter.Children[0])
+	case goldap.FilterEqualityMatch:
+		if len(filter.Children) != 2 {
+			return false
+		}
+		attribute, _ := filter.Children[0].Value.(string)
+		value, _ := filter.Children[1].Value.(string)
+		return slices.ContainsFunc(values(entry, attribute), func(v string) bool {
+			return strings.EqualFold(v, value) || (strings.Contains(v, "=") && sameDN(v, value))
+		})
+	case goldap.FilterPresent:
+		return len(values(entry, filter.Data.String())) > 0
+	default:
+		return false
+	}
+}
+
+// values returns the values of an attribute, whose name is case-insensitive.
+func values(entry *Entry, attribute string) []string {
+	for name, values := range entry.Attributes {
+		if strings.EqualFold(name, attribute) {
+			return values
+		}
+	}
+	return nil
+}
+
+func searchResultEntry(entry *Entry, attributes []string) *ber.Packet {
+	packet := ber.Encode(ber.ClassApplication, ber.TypeConstructed, goldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, entry.DN, "Object Name"))
+	attributeList := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+	for name, entryValues := range entry.Attributes {
+		if !requested(attributes, name) {
+			continue
+		}
+		attribute := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+		attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		for _, value := range entryValues {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "Value"))
+		}
+		attribute.AppendChild(set)
+		attributeList.AppendChild(attribute)
+	}
+	packet.AppendChild(attributeList)
+	return packet
+}
+
+// requested reports whether an attribute is in the attribute selection of a search,
+// see RFC 4511 section 4.5.1.8.
+func requested(attributes []string, name string) bool {
+	if len(attributes) == 0 || slices.Contains(attributes, "*") {
+		return true
+	}
+	return slices.ContainsFunc(attributes, func(attribute string) bool {
+		return strings.EqualFold(attribute, name)
+	})
+}
+
+func result(application ber.Tag, code uint16) *ber.Packet {
+	packet := ber.Encode(ber.ClassApplication, ber.TypeConstructed, application, nil, goldap.ApplicationMap[uint8(application)])
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "Result Code"))
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	return packet
+}
+
+func sameDN(a, b string) bool {
+	dnA, errA := goldap.ParseDN(a)
+	dnB, errB := goldap.ParseDN(b)
+	return errA == nil && errB == nil && dnA.EqualFold(dnB)
+}
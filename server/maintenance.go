@@ -0,0 +1,46 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/pixb/go-server/server/auth"
+)
+
+// newMaintenanceHandler rejects requests to the routes outside the API during maintenance.
+// Health checks are always served, and API methods are checked by the interceptors with the
+// rule of each method.
+func newMaintenanceHandler(maintenance *auth.Maintenance) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			r := c.Request()
+			if isAPIOrHealthRoute(r.URL.Path) {
+				return next(c)
+			}
+			if err := maintenance.Check(&auth.MethodRule{ReadOnly: isReadOnlyRoute(r)}); err != nil {
+				err.WriteHTTP(c.Response())
+				return nil
+			}
+			return next(c)
+		}
+	}
+}
+
+func isAPIOrHealthRoute(path string) bool {
+	return path == "/healthz" || path == "/readyz" ||
+		strings.HasPrefix(path, "/api/v1/") || strings.HasPrefix(path, "/goserver.api.v1.")
+}
+
+// isReadOnlyRoute reports whether a request to a route outside the API leaves data unchanged.
+func isReadOnlyRoute(r *http.Request) bool {
+	switch {
+	case r.URL.Path == "/oauth/introspect", r.URL.Path == "/userinfo":
+		return true
+	case strings.HasPrefix(r.URL.Path, "/auth/oidc/"):
+		// Signing in creates the user and the session.
+		return false
+	}
+	return r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions
+}
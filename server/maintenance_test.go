@@ -0,0 +1,52 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	storepb "github.com/pixb/go-server/proto/gen/store"
+	"github.com/pixb/go-server/server/auth"
+)
+
+func TestMaintenanceHandler(t *testing.T) {
+	e := echo.New()
+	maintenance := &auth.Maintenance{}
+	handler := newMaintenanceHandler(maintenance)(func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+	serve := func(method, path string) int {
+		rec := httptest.NewRecorder()
+		assert.NoError(t, handler(e.NewContext(httptest.NewRequest(method, path, nil), rec)))
+		return rec.Code
+	}
+
+	maintenance.Set(&storepb.InstanceMaintenanceSetting{Mode: storepb.InstanceMaintenanceSetting_READ_ONLY})
+	for _, tc := range []struct {
+		method, path string
+		code         int
+	}{
+		{http.MethodGet, "/.well-known/jwks.json", http.StatusOK},
+		{http.MethodPost, "/oauth/introspect", http.StatusOK},
+		{http.MethodPost, "/oauth/token", http.StatusServiceUnavailable},
+		{http.MethodGet, "/auth/oidc/google/callback", http.StatusServiceUnavailable},
+		// The interceptors check API methods
+		{http.MethodPost, "/api/v1/users", http.StatusOK},
+	} {
+		assert.Equal(t, tc.code, serve(tc.method, tc.path), tc.path)
+	}
+
+	maintenance.Set(&storepb.InstanceMaintenanceSetting{Mode: storepb.InstanceMaintenanceSetting_FULL})
+	assert.Equal(t, http.StatusServiceUnavailable, serve(http.MethodGet, "/.well-known/jwks.json"))
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/healthz"))
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/goserver.api.v1.InstanceService/GetInstanceProfile"))
+
+	// The body carries the HTTP status, like the gateway errors
+	rec := httptest.NewRecorder()
+	assert.NoError(t, handler(e.NewContext(httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil), rec)))
+	assert.Equal(t, "300", rec.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"state": 503, "message": "the instance is down for maintenance", "data": null}`, rec.Body.String())
+}
@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"connectrpc.com/connect"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -19,14 +22,21 @@ func NewGatewayErrorHandler() func(ctx context.Context, mux *runtime.ServeMux, m
 		var statusCode int
 		var state int
 		var message string
+		var retryAfter string
 
 		// Default values
 		statusCode = http.StatusInternalServerError
 		state = 2
 		message = "internal server error"
 
-		// Check if it's a gRPC status error
-		if grpcErr, ok := status.FromError(err); ok {
+		// Routing errors of the mux carry their own HTTP status
+		var httpStatusErr *runtime.HTTPStatusError
+		if errors.As(err, &httpStatusErr) {
+			grpcErr := status.Convert(httpStatusErr.Err)
+			message = grpcErr.Message()
+			state = int(grpcErr.Code())
+			statusCode = httpStatusErr.HTTPStatus
+		} else if grpcErr, ok := status.FromError(err); ok {
 			message = grpcErr.Message()
 			state = int(grpcErr.Code())
 
@@ -40,9 +50,18 @@ func NewGatewayErrorHandler() func(ctx context.Context, mux *runtime.ServeMux, m
 				statusCode = http.StatusNotFound
 			case codes.AlreadyExists:
 				statusCode = http.StatusConflict
+			case codes.PermissionDenied:
+				statusCode = http.StatusForbidden
+			case codes.FailedPrecondition:
+				statusCode = http.StatusBadRequest
+			case codes.ResourceExhausted:
+				statusCode = http.StatusTooManyRequests
+			case codes.Unavailable:
+				statusCode = http.StatusServiceUnavailable
 			default:
 				statusCode = http.StatusInternalServerError
 			}
+			retryAfter = retryAfterSeconds(grpcErr.Details())
 		} else if connectErr, ok := err.(*connect.Error); ok {
 			// Handle Connect errors
 			message = connectErr.Message()
@@ -58,9 +77,24 @@ func NewGatewayErrorHandler() func(ctx context.Context, mux *runtime.ServeMux, m
 				statusCode = http.StatusNotFound
 			case connect.CodeAlreadyExists:
 				statusCode = http.StatusConflict
+			case connect.CodePermissionDenied:
+				statusCode = http.StatusForbidden
+			case connect.CodeFailedPrecondition:
+				statusCode = http.StatusBadRequest
+			case connect.CodeResourceExhausted:
+				statusCode = http.StatusTooManyRequests
+			case connect.CodeUnavailable:
+				statusCode = http.StatusServiceUnavailable
 			default:
 				statusCode = http.StatusInternalServerError
 			}
+			details := []any{}
+			for _, detail := range connectErr.Details() {
+				if value, err := detail.Value(); err == nil {
+					details = append(details, value)
+				}
+			}
+			retryAfter = retryAfterSeconds(details)
 		} else {
 			// Handle other errors
 			message = err.Error()
@@ -68,6 +102,11 @@ func NewGatewayErrorHandler() func(ctx context.Context, mux *runtime.ServeMux, m
 			statusCode = http.StatusInternalServerError
 		}
 
+		// Rejected callers are told when to retry
+		if retryAfter != "" && (statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable) {
+			w.Header().Set("Retry-After", retryAfter)
+		}
+
 		// Write unified error response
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(statusCode)
@@ -80,6 +119,38 @@ func NewGatewayErrorHandler() func(ctx context.Context, mux *runtime.ServeMux, m
 	}
 }
 
+// retryAfterSeconds returns the delay of the RetryInfo among the error details in seconds,
+// or an empty string if there is none
+func retryAfterSeconds(details []any) string {
+	for _, detail := range details {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok && retryInfo.GetRetryDelay() != nil {
+			return strconv.Itoa(int(retryInfo.GetRetryDelay().AsDuration().Seconds()))
+		}
+	}
+	return ""
+}
+
+// NewGatewayRoutingErrorHandler creates an error handler for requests that match no route of
+// the gRPC-Gateway mux, they are answered with the HTTP status of the routing error
+func NewGatewayRoutingErrorHandler() runtime.RoutingErrorHandlerFunc {
+	errorHandler := NewGatewayErrorHandler()
+	return func(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, httpStatus int) {
+		code := codes.Internal
+		switch httpStatus {
+		case http.StatusBadRequest:
+			code = codes.InvalidArgument
+		case http.StatusNotFound:
+			code = codes.NotFound
+		case http.StatusMethodNotAllowed:
+			code = codes.Unimplemented
+		}
+		errorHandler(ctx, mux, marshaler, w, r, &runtime.HTTPStatusError{
+			HTTPStatus: httpStatus,
+			Err:        status.Error(code, http.StatusText(httpStatus)),
+		})
+	}
+}
+
 // NewGatewayResponseWrapper creates a response wrapper for gRPC-Gateway
 func NewGatewayResponseWrapper(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
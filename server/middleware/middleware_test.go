@@ -1,12 +1,21 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"connectrpc.com/connect"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func TestRateLimiter(t *testing.T) {
@@ -47,6 +56,68 @@ func TestRateLimiter(t *testing.T) {
 	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
 }
 
+func TestRateLimiter_SetRate(t *testing.T) {
+	e := echo.New()
+	config := DefaultRateLimiterConfig()
+	config.Rate = 0
+	config.KeyFunc = func(c echo.Context) string {
+		return "test-key"
+	}
+	rateLimiter := NewAdjustableRateLimiter(config)
+	handler := rateLimiter.Middleware()(func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+	request := func() int {
+		rec := httptest.NewRecorder()
+		_ = handler(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec))
+		return rec.Code
+	}
+
+	// A zero rate does not limit requests
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusOK, request())
+	}
+
+	// The new rate applies to the following requests
+	rateLimiter.SetRate(0.1, 2)
+	assert.Equal(t, http.StatusOK, request())
+	assert.Equal(t, http.StatusOK, request())
+	assert.Equal(t, http.StatusTooManyRequests, request())
+
+	// Existing clients are given the new rate too
+	rateLimiter.SetRate(1000, 1)
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, http.StatusOK, request())
+
+	rateLimiter.SetRate(0, 0)
+	assert.Equal(t, http.StatusOK, request())
+}
+
+func TestCORSOrigins(t *testing.T) {
+	e := echo.New()
+	origins := &CORSOrigins{}
+	handler := NewCORSHandler(origins)(func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+	allowOrigin := func(origin string) string {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(echo.HeaderOrigin, origin)
+		rec := httptest.NewRecorder()
+		assert.NoError(t, handler(e.NewContext(req, rec)))
+		return rec.Header().Get(echo.HeaderAccessControlAllowOrigin)
+	}
+
+	// Any origin is allowed without origins
+	assert.Equal(t, "https://a.example.com", allowOrigin("https://a.example.com"))
+
+	origins.Set([]string{"https://b.example.com"})
+	assert.Empty(t, allowOrigin("https://a.example.com"))
+	assert.Equal(t, "https://b.example.com", allowOrigin("https://b.example.com"))
+
+	origins.Set(nil)
+	assert.Equal(t, "https://a.example.com", allowOrigin("https://a.example.com"))
+}
+
 func TestCSRFTokenMiddleware(t *testing.T) {
 	// Create echo instance
 	e := echo.New()
@@ -182,3 +253,31 @@ func TestUnifiedResponseMiddleware(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 }
+
+func TestGatewayErrorHandler(t *testing.T) {
+	retryInfo := &errdetails.RetryInfo{RetryDelay: durationpb.New(90 * time.Second)}
+	lockedOut, err := status.New(codes.ResourceExhausted, "too many failed login attempts").WithDetails(retryInfo)
+	require.NoError(t, err)
+	connectLockedOut := connect.NewError(connect.CodeResourceExhausted, errors.New("too many failed login attempts"))
+	detail, err := connect.NewErrorDetail(retryInfo)
+	require.NoError(t, err)
+	connectLockedOut.AddDetail(detail)
+
+	handler := NewGatewayErrorHandler()
+	for _, tc := range []struct {
+		err        error
+		statusCode int
+		retryAfter string
+	}{
+		{lockedOut.Err(), http.StatusTooManyRequests, "90"},
+		{connectLockedOut, http.StatusTooManyRequests, "90"},
+		{status.Error(codes.Unavailable, "down for maintenance"), http.StatusServiceUnavailable, ""},
+		{connect.NewError(connect.CodeUnavailable, errors.New("down for maintenance")), http.StatusServiceUnavailable, ""},
+		{status.Error(codes.NotFound, "user not found"), http.StatusNotFound, ""},
+	} {
+		rec := httptest.NewRecorder()
+		handler(context.Background(), nil, nil, rec, httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", nil), tc.err)
+		assert.Equal(t, tc.statusCode, rec.Code, tc.err.Error())
+		assert.Equal(t, tc.retryAfter, rec.Header().Get("Retry-After"), tc.err.Error())
+	}
+}
@@ -11,7 +11,7 @@ import (
 
 // RateLimiterConfig defines the configuration for the rate limiter middleware
 type RateLimiterConfig struct {
-	// Rate is the number of requests allowed per second
+	// Rate is the number of requests allowed per second, requests are not limited when 0
 	Rate float64
 	// Burst is the maximum number of requests allowed in a burst
 	Burst int
@@ -44,6 +44,12 @@ type RateLimiter struct {
 
 // NewRateLimiter creates a new rate limiter middleware
 func NewRateLimiter(config RateLimiterConfig) echo.MiddlewareFunc {
+	return NewAdjustableRateLimiter(config).Middleware()
+}
+
+// NewAdjustableRateLimiter creates a rate limiter whose rate can be changed with SetRate
+// while it is serving.
+func NewAdjustableRateLimiter(config RateLimiterConfig) *RateLimiter {
 	// Use default config if not provided
 	if config.Rate < 0 {
 		config.Rate = DefaultRateLimiterConfig().Rate
@@ -71,12 +77,17 @@ func NewRateLimiter(config RateLimiterConfig) echo.MiddlewareFunc {
 		}
 	}()
 
+	return rl
+}
+
+// Middleware returns the middleware that applies the limiter.
+func (rl *RateLimiter) Middleware() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			key := rl.config.KeyFunc(c)
 			limiter := rl.getLimiter(key)
 
-			if !limiter.Allow() {
+			if limiter != nil && !limiter.Allow() {
 				return rl.config.ErrorHandler(c)
 			}
 
@@ -85,11 +96,29 @@ func NewRateLimiter(config RateLimiterConfig) echo.MiddlewareFunc {
 	}
 }
 
-// getLimiter returns a rate limiter for the given key, creating a new one if needed
+// SetRate changes the rate and burst for all clients, the tokens they have left are kept.
+func (rl *RateLimiter) SetRate(r float64, burst int) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.config.Rate = r
+	rl.config.Burst = burst
+	for _, limiter := range rl.rateLimiters {
+		limiter.SetLimit(rate.Limit(r))
+		limiter.SetBurst(burst)
+	}
+}
+
+// getLimiter returns a rate limiter for the given key, creating a new one if needed. It
+// returns nil when requests are not limited.
 func (rl *RateLimiter) getLimiter(key string) *rate.Limiter {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
+	if rl.config.Rate == 0 {
+		return nil
+	}
+
 	limiter, exists := rl.rateLimiters[key]
 	if !exists {
 		limiter = rate.NewLimiter(rate.Limit(rl.config.Rate), rl.config.Burst)
@@ -2,6 +2,8 @@ package middleware
 
 import (
 	"net/http"
+	"slices"
+	"sync/atomic"
 
 	"connectrpc.com/connect"
 	"github.com/labstack/echo/v4"
@@ -33,11 +35,33 @@ func NewErrorResponse(state int, message string) UnifiedResponse {
 	}
 }
 
-// NewCORSHandler creates a CORS middleware with proper configuration
-func NewCORSHandler() echo.MiddlewareFunc {
+// CORSOrigins holds the origins allowed to make cross-origin requests. They can be changed
+// while serving, any origin is allowed while there are none.
+type CORSOrigins struct {
+	origins atomic.Pointer[[]string]
+}
+
+// Set replaces the allowed origins.
+func (o *CORSOrigins) Set(origins []string) {
+	origins = slices.Clone(origins)
+	o.origins.Store(&origins)
+}
+
+// Allow reports whether the origin is allowed. A nil CORSOrigins allows any origin.
+func (o *CORSOrigins) Allow(origin string) bool {
+	if o == nil {
+		return true
+	}
+	origins := o.origins.Load()
+	return origins == nil || len(*origins) == 0 || slices.Contains(*origins, origin)
+}
+
+// NewCORSHandler creates a CORS middleware with proper configuration, allowing the given
+// origins or any origin if nil.
+func NewCORSHandler(origins *CORSOrigins) echo.MiddlewareFunc {
 	return echomiddleware.CORSWithConfig(echomiddleware.CORSConfig{
-		AllowOriginFunc: func(_ string) (bool, error) {
-			return true, nil
+		AllowOriginFunc: func(origin string) (bool, error) {
+			return origins.Allow(origin), nil
 		},
 		AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodOptions, http.MethodPut, http.MethodPatch},
 		AllowHeaders:     []string{"*"},
@@ -0,0 +1,77 @@
+// Package notify delivers messages such as password reset links to users.
+package notify
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+)
+
+// Message is a notification for a single recipient.
+type Message struct {
+	// To is the email address of the recipient.
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier delivers messages to users.
+type Notifier interface {
+	Send(ctx context.Context, message *Message) error
+}
+
+// ErrDisabled is returned by DisabledNotifier.
+var ErrDisabled = errors.New("no mail server is configured")
+
+// DisabledNotifier refuses to deliver messages. It is used when no mail server is configured,
+// so that the tokens in messages are not exposed anywhere else.
+type DisabledNotifier struct{}
+
+func NewDisabledNotifier() *DisabledNotifier {
+	return &DisabledNotifier{}
+}
+
+func (*DisabledNotifier) Send(context.Context, *Message) error {
+	return ErrDisabled
+}
+
+// LogNotifier writes messages to the log instead of delivering them. Messages carry tokens
+// such as password reset tokens, so it is only meant for development.
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (*LogNotifier) Send(_ context.Context, message *Message) error {
+	slog.Info("notification not delivered, no mail server configured",
+		slog.String("to", message.To),
+		slog.String("subject", message.Subject),
+		slog.String("body", message.Body))
+	return nil
+}
+
+// MemoryNotifier keeps the messages it is given, for tests to inspect.
+type MemoryNotifier struct {
+	mu       sync.Mutex
+	messages []*Message
+}
+
+func NewMemoryNotifier() *MemoryNotifier {
+	return &MemoryNotifier{}
+}
+
+func (n *MemoryNotifier) Send(_ context.Context, message *Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.messages = append(n.messages, message)
+	return nil
+}
+
+// Messages returns the messages sent so far, oldest first.
+func (n *MemoryNotifier) Messages() []*Message {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]*Message(nil), n.messages...)
+}
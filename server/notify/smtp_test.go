@@ -0,0 +1,132 @@
+package notify
+
+import (
+	"context"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveSMTP accepts a single mail transaction and returns the envelope and data it received.
+func serveSMTP(listener net.Listener) <-chan []string {
+	received := make(chan []string, 1)
+	go func() {
+		defer close(received)
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		text := textproto.NewConn(conn)
+		var lines []string
+		_ = text.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch command {
+			case "EHLO", "HELO":
+				_ = text.PrintfLine("250 localhost")
+			case "MAIL", "RCPT":
+				lines = append(lines, line)
+				_ = text.PrintfLine("250 OK")
+			case "DATA":
+				_ = text.PrintfLine("354 go ahead")
+				data, err := text.ReadDotLines()
+				if err != nil {
+					return
+				}
+				lines = append(lines, data...)
+				_ = text.PrintfLine("250 OK")
+			case "QUIT":
+				_ = text.PrintfLine("221 bye")
+				received <- lines
+				return
+			default:
+				_ = text.PrintfLine("250 OK")
+			}
+		}
+	}()
+	return received
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	received := serveSMTP(listener)
+
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	portNumber, err := net.LookupPort("tcp", port)
+	require.NoError(t, err)
+
+	notifier, err := NewSMTPNotifier(SMTPConfig{Host: host, Port: portNumber, From: "go-server <noreply@example.com>"})
+	require.NoError(t, err)
+	require.NoError(t, notifier.Send(context.Background(), &Message{
+		To:      "user@example.com",
+		Subject: "Reset your password",
+		Body:    "first line\nsecond line\n",
+	}))
+
+	lines := <-received
+	require.NotEmpty(t, lines)
+	assert.Equal(t, "MAIL FROM:<noreply@example.com>", strings.SplitN(lines[0], " BODY", 2)[0])
+	assert.Equal(t, "RCPT TO:<user@example.com>", lines[1])
+	message := strings.Join(lines[2:], "\n")
+	assert.Contains(t, message, "To: <user@example.com>")
+	assert.Contains(t, message, "Subject: Reset your password")
+	assert.Contains(t, message, "first line\nsecond line")
+
+	_, err = NewSMTPNotifier(SMTPConfig{Host: host, From: "not an address"})
+	assert.Error(t, err)
+}
+
+func TestSMTPNotifier_StalledServer(t *testing.T) {
+	// The server accepts connections but never greets
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		if conn, err := listener.Accept(); err == nil {
+			<-done
+			conn.Close()
+		}
+	}()
+
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	portNumber, err := net.LookupPort("tcp", port)
+	require.NoError(t, err)
+	notifier, err := NewSMTPNotifier(SMTPConfig{Host: host, Port: portNumber, From: "noreply@example.com"})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = notifier.Send(ctx, &Message{To: "user@example.com", Subject: "hello"})
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestDisabledNotifier(t *testing.T) {
+	err := NewDisabledNotifier().Send(context.Background(), &Message{To: "user@example.com", Body: "token"})
+	assert.ErrorIs(t, err, ErrDisabled)
+}
+
+func TestMemoryNotifier(t *testing.T) {
+	notifier := NewMemoryNotifier()
+	require.NoError(t, notifier.Send(context.Background(), &Message{To: "user@example.com", Subject: "hello"}))
+	messages := notifier.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "hello", messages[0].Subject)
+}
@@ -0,0 +1,135 @@
+package oidc_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pixb/go-server/server/oidc"
+	"github.com/pixb/go-server/server/oidc/oidctest"
+)
+
+const redirectURL = "http://localhost/auth/oidc/test/callback"
+
+// authorize follows the authorization URL and returns the code and state of the redirect.
+func authorize(t *testing.T, authURL string) (string, string) {
+	t.Helper()
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+func TestProvider_AuthorizationCodeFlow(t *testing.T) {
+	ctx := context.Background()
+	idp := oidctest.NewProvider("client", "secret")
+	defer idp.Close()
+	idp.SetClaims(map[string]any{
+		"sub":            "user-1",
+		"email":          "alice@example.com",
+		"email_verified": "true",
+	})
+
+	provider, err := oidc.NewProvider(ctx, oidc.Config{
+		Issuer:       idp.Issuer(),
+		ClientID:     "client",
+		ClientSecret: "secret",
+		Scopes:       []string{"email"},
+		RedirectURL:  redirectURL,
+	})
+	require.NoError(t, err)
+
+	verifier, err := oidc.RandomString()
+	require.NoError(t, err)
+	code, state := authorize(t, provider.AuthCodeURL("state-1", "nonce-1", verifier))
+	assert.Equal(t, "state-1", state)
+
+	token, err := provider.Exchange(ctx, code, verifier)
+	require.NoError(t, err)
+	claims, err := provider.VerifyIDToken(ctx, token.IDToken, "nonce-1")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.Subject())
+	assert.Equal(t, "alice@example.com", claims.String("email"))
+	assert.True(t, claims.Bool("email_verified"))
+
+	// The nonce binds the ID token to the sign-in it was requested for
+	_, err = provider.VerifyIDToken(ctx, token.IDToken, "nonce-2")
+	assert.Error(t, err)
+
+	// Codes are single-use
+	_, err = provider.Exchange(ctx, code, verifier)
+	var tokenErr *oidc.Error
+	require.True(t, errors.As(err, &tokenErr))
+	assert.Equal(t, "invalid_grant", tokenErr.Code)
+}
+
+func TestProvider_RejectsWrongVerifierAndClient(t *testing.T) {
+	ctx := context.Background()
+	idp := oidctest.NewProvider("client", "secret")
+	defer idp.Close()
+	idp.SetClaims(map[string]any{"sub": "user-1"})
+
+	provider, err := oidc.NewProvider(ctx, oidc.Config{
+		Issuer:       idp.Issuer(),
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RedirectURL:  redirectURL,
+	})
+	require.NoError(t, err)
+
+	// The code cannot be redeemed without the verifier of the challenge
+	verifier, err := oidc.RandomString()
+	require.NoError(t, err)
+	code, _ := authorize(t, provider.AuthCodeURL("state", "nonce", verifier))
+	_, err = provider.Exchange(ctx, code, "another-verifier")
+	var tokenErr *oidc.Error
+	require.True(t, errors.As(err, &tokenErr))
+	assert.Equal(t, "invalid_grant", tokenErr.Code)
+
+	wrongSecret, err := oidc.NewProvider(ctx, oidc.Config{
+		Issuer:       idp.Issuer(),
+		ClientID:     "client",
+		ClientSecret: "wrong",
+		RedirectURL:  redirectURL,
+	})
+	require.NoError(t, err)
+	code, _ = authorize(t, wrongSecret.AuthCodeURL("state", "nonce", verifier))
+	_, err = wrongSecret.Exchange(ctx, code, verifier)
+	require.True(t, errors.As(err, &tokenErr))
+	assert.Equal(t, "invalid_client", tokenErr.Code)
+
+	// The discovered issuer has to match the configured one exactly
+	_, err = oidc.NewProvider(ctx, oidc.Config{Issuer: idp.Issuer() + "/", ClientID: "client"})
+	assert.Error(t, err)
+}
+
+func TestProvider_RejectsTokenForAnotherClient(t *testing.T) {
+	ctx := context.Background()
+	idp := oidctest.NewProvider("other-client", "")
+	defer idp.Close()
+	idp.SetClaims(map[string]any{"sub": "user-1"})
+
+	other, err := oidc.NewProvider(ctx, oidc.Config{Issuer: idp.Issuer(), ClientID: "other-client", RedirectURL: redirectURL})
+	require.NoError(t, err)
+	verifier, err := oidc.RandomString()
+	require.NoError(t, err)
+	code, _ := authorize(t, other.AuthCodeURL("state", "nonce", verifier))
+	token, err := other.Exchange(ctx, code, verifier)
+	require.NoError(t, err)
+
+	provider, err := oidc.NewProvider(ctx, oidc.Config{Issuer: idp.Issuer(), ClientID: "client", RedirectURL: redirectURL})
+	require.NoError(t, err)
+	_, err = provider.VerifyIDToken(ctx, token.IDToken, "nonce")
+	assert.Error(t, err)
+}
@@ -30,6 +30,22 @@ func (s *ConnectServiceHandler) RegisterConnectHandlers(mux *http.ServeMux, opts
 	// Register InstanceService handler
 	instancePath, instanceHandler := v1connect.NewInstanceServiceHandler(s, opts...)
 	mux.Handle(instancePath, instanceHandler)
+
+	// Register AdminService handler
+	adminPath, adminHandler := v1connect.NewAdminServiceHandler(s, opts...)
+	mux.Handle(adminPath, adminHandler)
+
+	// Register RoleService handler
+	rolePath, roleHandler := v1connect.NewRoleServiceHandler(s, opts...)
+	mux.Handle(rolePath, roleHandler)
+
+	// Register IdentityProviderService handler
+	identityProviderPath, identityProviderHandler := v1connect.NewIdentityProviderServiceHandler(s, opts...)
+	mux.Handle(identityProviderPath, identityProviderHandler)
+
+	// Register OAuthService handler
+	oauthPath, oauthHandler := v1connect.NewOAuthServiceHandler(s, opts...)
+	mux.Handle(oauthPath, oauthHandler)
 }
 
 func (s *ConnectServiceHandler) RegisterUser(ctx context.Context, req *connect.Request[v1pb.RegisterUserRequest]) (*connect.Response[v1pb.RegisterUserResponse], error) {
@@ -48,6 +64,14 @@ func (s *ConnectServiceHandler) Login(ctx context.Context, req *connect.Request[
 	return connect.NewResponse(resp), nil
 }
 
+func (s *ConnectServiceHandler) VerifyMFA(ctx context.Context, req *connect.Request[v1pb.VerifyMFARequest]) (*connect.Response[v1pb.VerifyMFAResponse], error) {
+	resp, err := s.APIV1Service.VerifyMFA(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
 func (s *ConnectServiceHandler) RefreshToken(ctx context.Context, req *connect.Request[v1pb.RefreshTokenRequest]) (*connect.Response[v1pb.RefreshTokenResponse], error) {
 	resp, err := s.APIV1Service.RefreshToken(ctx, req.Msg)
 	if err != nil {
@@ -72,6 +96,30 @@ func (s *ConnectServiceHandler) Logout(ctx context.Context, req *connect.Request
 	return connect.NewResponse(resp), nil
 }
 
+func (s *ConnectServiceHandler) RequestPasswordReset(ctx context.Context, req *connect.Request[v1pb.RequestPasswordResetRequest]) (*connect.Response[v1pb.RequestPasswordResetResponse], error) {
+	resp, err := s.APIV1Service.RequestPasswordReset(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) ConfirmPasswordReset(ctx context.Context, req *connect.Request[v1pb.ConfirmPasswordResetRequest]) (*connect.Response[v1pb.ConfirmPasswordResetResponse], error) {
+	resp, err := s.APIV1Service.ConfirmPasswordReset(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) ListLoginProviders(ctx context.Context, req *connect.Request[v1pb.ListLoginProvidersRequest]) (*connect.Response[v1pb.ListLoginProvidersResponse], error) {
+	resp, err := s.APIV1Service.ListLoginProviders(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
 func (s *ConnectServiceHandler) ChangePassword(ctx context.Context, req *connect.Request[v1pb.ChangePasswordRequest]) (*connect.Response[v1pb.ChangePasswordResponse], error) {
 	resp, err := s.APIV1Service.ChangePassword(ctx, req.Msg)
 	if err != nil {
@@ -96,6 +144,102 @@ func (s *ConnectServiceHandler) UpdateUserProfile(ctx context.Context, req *conn
 	return connect.NewResponse(resp), nil
 }
 
+func (s *ConnectServiceHandler) CreatePersonalAccessToken(ctx context.Context, req *connect.Request[v1pb.CreatePersonalAccessTokenRequest]) (*connect.Response[v1pb.CreatePersonalAccessTokenResponse], error) {
+	resp, err := s.APIV1Service.CreatePersonalAccessToken(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) ListPersonalAccessTokens(ctx context.Context, req *connect.Request[v1pb.ListPersonalAccessTokensRequest]) (*connect.Response[v1pb.ListPersonalAccessTokensResponse], error) {
+	resp, err := s.APIV1Service.ListPersonalAccessTokens(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) DeletePersonalAccessToken(ctx context.Context, req *connect.Request[v1pb.DeletePersonalAccessTokenRequest]) (*connect.Response[v1pb.DeletePersonalAccessTokenResponse], error) {
+	resp, err := s.APIV1Service.DeletePersonalAccessToken(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) ListSessions(ctx context.Context, req *connect.Request[v1pb.ListSessionsRequest]) (*connect.Response[v1pb.ListSessionsResponse], error) {
+	resp, err := s.APIV1Service.ListSessions(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) RevokeSession(ctx context.Context, req *connect.Request[v1pb.RevokeSessionRequest]) (*connect.Response[v1pb.RevokeSessionResponse], error) {
+	resp, err := s.APIV1Service.RevokeSession(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) RevokeAllOtherSessions(ctx context.Context, req *connect.Request[v1pb.RevokeAllOtherSessionsRequest]) (*connect.Response[v1pb.RevokeAllOtherSessionsResponse], error) {
+	resp, err := s.APIV1Service.RevokeAllOtherSessions(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) EnrollTOTP(ctx context.Context, req *connect.Request[v1pb.EnrollTOTPRequest]) (*connect.Response[v1pb.EnrollTOTPResponse], error) {
+	resp, err := s.APIV1Service.EnrollTOTP(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) ConfirmTOTP(ctx context.Context, req *connect.Request[v1pb.ConfirmTOTPRequest]) (*connect.Response[v1pb.ConfirmTOTPResponse], error) {
+	resp, err := s.APIV1Service.ConfirmTOTP(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) DisableTOTP(ctx context.Context, req *connect.Request[v1pb.DisableTOTPRequest]) (*connect.Response[v1pb.DisableTOTPResponse], error) {
+	resp, err := s.APIV1Service.DisableTOTP(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) UnlockUser(ctx context.Context, req *connect.Request[v1pb.UnlockUserRequest]) (*connect.Response[v1pb.UnlockUserResponse], error) {
+	resp, err := s.APIV1Service.UnlockUser(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) VerifyEmail(ctx context.Context, req *connect.Request[v1pb.VerifyEmailRequest]) (*connect.Response[v1pb.VerifyEmailResponse], error) {
+	resp, err := s.APIV1Service.VerifyEmail(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) ResendVerification(ctx context.Context, req *connect.Request[v1pb.ResendVerificationRequest]) (*connect.Response[v1pb.ResendVerificationResponse], error) {
+	resp, err := s.APIV1Service.ResendVerification(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
 func (s *ConnectServiceHandler) GetInstanceProfile(ctx context.Context, req *connect.Request[v1pb.GetInstanceProfileRequest]) (*connect.Response[v1pb.InstanceProfile], error) {
 	resp, err := s.APIV1Service.GetInstanceProfile(ctx, req.Msg)
 	if err != nil {
@@ -103,3 +247,291 @@ func (s *ConnectServiceHandler) GetInstanceProfile(ctx context.Context, req *con
 	}
 	return connect.NewResponse(resp), nil
 }
+
+func (s *ConnectServiceHandler) SetupInstance(ctx context.Context, req *connect.Request[v1pb.SetupInstanceRequest]) (*connect.Response[v1pb.SetupInstanceResponse], error) {
+	resp, err := s.APIV1Service.SetupInstance(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) GetInstanceSetting(ctx context.Context, req *connect.Request[v1pb.GetInstanceSettingRequest]) (*connect.Response[v1pb.GetInstanceSettingResponse], error) {
+	resp, err := s.APIV1Service.GetInstanceSetting(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) UpdateInstanceSetting(ctx context.Context, req *connect.Request[v1pb.UpdateInstanceSettingRequest]) (*connect.Response[v1pb.UpdateInstanceSettingResponse], error) {
+	resp, err := s.APIV1Service.UpdateInstanceSetting(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) ListUsers(ctx context.Context, req *connect.Request[v1pb.ListUsersRequest]) (*connect.Response[v1pb.ListUsersResponse], error) {
+	resp, err := s.APIV1Service.ListUsers(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) GetUser(ctx context.Context, req *connect.Request[v1pb.GetUserRequest]) (*connect.Response[v1pb.GetUserResponse], error) {
+	resp, err := s.APIV1Service.GetUser(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) CreateUser(ctx context.Context, req *connect.Request[v1pb.CreateUserRequest]) (*connect.Response[v1pb.CreateUserResponse], error) {
+	resp, err := s.APIV1Service.CreateUser(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) UpdateUser(ctx context.Context, req *connect.Request[v1pb.UpdateUserRequest]) (*connect.Response[v1pb.UpdateUserResponse], error) {
+	resp, err := s.APIV1Service.UpdateUser(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) DeleteUser(ctx context.Context, req *connect.Request[v1pb.DeleteUserRequest]) (*connect.Response[v1pb.DeleteUserResponse], error) {
+	resp, err := s.APIV1Service.DeleteUser(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) RestoreUser(ctx context.Context, req *connect.Request[v1pb.RestoreUserRequest]) (*connect.Response[v1pb.RestoreUserResponse], error) {
+	resp, err := s.APIV1Service.RestoreUser(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) ResetUserPassword(ctx context.Context, req *connect.Request[v1pb.ResetUserPasswordRequest]) (*connect.Response[v1pb.ResetUserPasswordResponse], error) {
+	resp, err := s.APIV1Service.ResetUserPassword(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) CreateInvite(ctx context.Context, req *connect.Request[v1pb.CreateInviteRequest]) (*connect.Response[v1pb.CreateInviteResponse], error) {
+	resp, err := s.APIV1Service.CreateInvite(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) ListInvites(ctx context.Context, req *connect.Request[v1pb.ListInvitesRequest]) (*connect.Response[v1pb.ListInvitesResponse], error) {
+	resp, err := s.APIV1Service.ListInvites(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) RevokeInvite(ctx context.Context, req *connect.Request[v1pb.RevokeInviteRequest]) (*connect.Response[v1pb.RevokeInviteResponse], error) {
+	resp, err := s.APIV1Service.RevokeInvite(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) ListPermissions(ctx context.Context, req *connect.Request[v1pb.ListPermissionsRequest]) (*connect.Response[v1pb.ListPermissionsResponse], error) {
+	resp, err := s.APIV1Service.ListPermissions(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) ListRoles(ctx context.Context, req *connect.Request[v1pb.ListRolesRequest]) (*connect.Response[v1pb.ListRolesResponse], error) {
+	resp, err := s.APIV1Service.ListRoles(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) GetRole(ctx context.Context, req *connect.Request[v1pb.GetRoleRequest]) (*connect.Response[v1pb.GetRoleResponse], error) {
+	resp, err := s.APIV1Service.GetRole(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) CreateRole(ctx context.Context, req *connect.Request[v1pb.CreateRoleRequest]) (*connect.Response[v1pb.CreateRoleResponse], error) {
+	resp, err := s.APIV1Service.CreateRole(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) UpdateRole(ctx context.Context, req *connect.Request[v1pb.UpdateRoleRequest]) (*connect.Response[v1pb.UpdateRoleResponse], error) {
+	resp, err := s.APIV1Service.UpdateRole(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) DeleteRole(ctx context.Context, req *connect.Request[v1pb.DeleteRoleRequest]) (*connect.Response[v1pb.DeleteRoleResponse], error) {
+	resp, err := s.APIV1Service.DeleteRole(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) ListUserRoles(ctx context.Context, req *connect.Request[v1pb.ListUserRolesRequest]) (*connect.Response[v1pb.ListUserRolesResponse], error) {
+	resp, err := s.APIV1Service.ListUserRoles(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) SetUserRoles(ctx context.Context, req *connect.Request[v1pb.SetUserRolesRequest]) (*connect.Response[v1pb.SetUserRolesResponse], error) {
+	resp, err := s.APIV1Service.SetUserRoles(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) ListIdentityProviders(ctx context.Context, req *connect.Request[v1pb.ListIdentityProvidersRequest]) (*connect.Response[v1pb.ListIdentityProvidersResponse], error) {
+	resp, err := s.APIV1Service.ListIdentityProviders(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) GetIdentityProvider(ctx context.Context, req *connect.Request[v1pb.GetIdentityProviderRequest]) (*connect.Response[v1pb.GetIdentityProviderResponse], error) {
+	resp, err := s.APIV1Service.GetIdentityProvider(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) CreateIdentityProvider(ctx context.Context, req *connect.Request[v1pb.CreateIdentityProviderRequest]) (*connect.Response[v1pb.CreateIdentityProviderResponse], error) {
+	resp, err := s.APIV1Service.CreateIdentityProvider(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) UpdateIdentityProvider(ctx context.Context, req *connect.Request[v1pb.UpdateIdentityProviderRequest]) (*connect.Response[v1pb.UpdateIdentityProviderResponse], error) {
+	resp, err := s.APIV1Service.UpdateIdentityProvider(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) DeleteIdentityProvider(ctx context.Context, req *connect.Request[v1pb.DeleteIdentityProviderRequest]) (*connect.Response[v1pb.DeleteIdentityProviderResponse], error) {
+	resp, err := s.APIV1Service.DeleteIdentityProvider(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) ListOAuthClients(ctx context.Context, req *connect.Request[v1pb.ListOAuthClientsRequest]) (*connect.Response[v1pb.ListOAuthClientsResponse], error) {
+	resp, err := s.APIV1Service.ListOAuthClients(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) GetOAuthClient(ctx context.Context, req *connect.Request[v1pb.GetOAuthClientRequest]) (*connect.Response[v1pb.GetOAuthClientResponse], error) {
+	resp, err := s.APIV1Service.GetOAuthClient(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) CreateOAuthClient(ctx context.Context, req *connect.Request[v1pb.CreateOAuthClientRequest]) (*connect.Response[v1pb.CreateOAuthClientResponse], error) {
+	resp, err := s.APIV1Service.CreateOAuthClient(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) UpdateOAuthClient(ctx context.Context, req *connect.Request[v1pb.UpdateOAuthClientRequest]) (*connect.Response[v1pb.UpdateOAuthClientResponse], error) {
+	resp, err := s.APIV1Service.UpdateOAuthClient(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) RotateOAuthClientSecret(ctx context.Context, req *connect.Request[v1pb.RotateOAuthClientSecretRequest]) (*connect.Response[v1pb.RotateOAuthClientSecretResponse], error) {
+	resp, err := s.APIV1Service.RotateOAuthClientSecret(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) DeleteOAuthClient(ctx context.Context, req *connect.Request[v1pb.DeleteOAuthClientRequest]) (*connect.Response[v1pb.DeleteOAuthClientResponse], error) {
+	resp, err := s.APIV1Service.DeleteOAuthClient(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) GetOAuthAuthorization(ctx context.Context, req *connect.Request[v1pb.GetOAuthAuthorizationRequest]) (*connect.Response[v1pb.GetOAuthAuthorizationResponse], error) {
+	resp, err := s.APIV1Service.GetOAuthAuthorization(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) ApproveOAuthAuthorization(ctx context.Context, req *connect.Request[v1pb.ApproveOAuthAuthorizationRequest]) (*connect.Response[v1pb.ApproveOAuthAuthorizationResponse], error) {
+	resp, err := s.APIV1Service.ApproveOAuthAuthorization(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) ListOAuthConsents(ctx context.Context, req *connect.Request[v1pb.ListOAuthConsentsRequest]) (*connect.Response[v1pb.ListOAuthConsentsResponse], error) {
+	resp, err := s.APIV1Service.ListOAuthConsents(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *ConnectServiceHandler) RevokeOAuthConsent(ctx context.Context, req *connect.Request[v1pb.RevokeOAuthConsentRequest]) (*connect.Response[v1pb.RevokeOAuthConsentResponse], error) {
+	resp, err := s.APIV1Service.RevokeOAuthConsent(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
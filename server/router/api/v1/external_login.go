@@ -0,0 +1,127 @@
+package v1
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/metadata"
+
+	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	"github.com/pixb/go-server/server/auth"
+)
+
+// externalLoginCookie holds the state of a sign-in with an identity provider between
+// the login and callback requests.
+const externalLoginCookie = "goserver_external_login"
+
+// RegisterExternalLoginRoutes serves the authorization code flow with the identity
+// providers of the instance. The result of a sign-in is passed to the redirect path
+// in the URL fragment, see LoginProvider in auth_service.proto.
+func (s *APIV1Service) RegisterExternalLoginRoutes(echoServer *echo.Echo) {
+	group := echoServer.Group("/auth/oidc")
+	group.GET("/:provider/login", s.handleExternalLogin)
+	group.GET("/:provider/callback", s.handleExternalCallback)
+}
+
+func (s *APIV1Service) handleExternalLogin(c echo.Context) error {
+	redirectPath := localRedirectPath(c.QueryParam("redirect"))
+	authURL, stateToken, err := s.AuthService.StartExternalLogin(c.Request().Context(), c.Param("provider"), s.Profile.InstanceURL, redirectPath)
+	if err != nil {
+		return redirectWithError(c, redirectPath, err)
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:     externalLoginCookie,
+		Value:    stateToken,
+		Path:     "/auth/oidc/",
+		MaxAge:   int(auth.ExternalLoginStateDuration / time.Second),
+		HttpOnly: true,
+		Secure:   c.Scheme() == "https",
+		// Lax, so that the cookie is sent on the top-level redirect back from the provider.
+		SameSite: http.SameSiteLaxMode,
+	})
+	return c.Redirect(http.StatusFound, authURL)
+}
+
+func (s *APIV1Service) handleExternalCallback(c echo.Context) error {
+	stateToken := ""
+	if cookie, err := c.Cookie(externalLoginCookie); err == nil {
+		stateToken = cookie.Value
+	}
+	// The state is single-use.
+	c.SetCookie(&http.Cookie{
+		Name:     externalLoginCookie,
+		Path:     "/auth/oidc/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   c.Scheme() == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	// The services read the client of the request from the incoming metadata, the peer
+	// address is appended to X-Forwarded-For the same way the gRPC-Gateway does.
+	md := metadata.Pairs("user-agent", c.Request().UserAgent())
+	xff := c.Request().Header.Values(echo.HeaderXForwardedFor)
+	if host, _, err := net.SplitHostPort(c.Request().RemoteAddr); err == nil {
+		xff = append(xff, host)
+	}
+	if len(xff) > 0 {
+		md.Set("x-forwarded-for", strings.Join(xff, ", "))
+	}
+	ctx := metadata.NewIncomingContext(c.Request().Context(), md)
+	response, redirectPath, err := s.AuthService.FinishExternalLogin(ctx, c.Param("provider"), s.Profile.InstanceURL, stateToken, c.QueryParams())
+	if redirectPath == "" {
+		redirectPath = "/"
+	}
+	if err != nil {
+		return redirectWithError(c, redirectPath, err)
+	}
+	return c.Redirect(http.StatusFound, redirectPath+"#"+loginFragment(response).Encode())
+}
+
+func loginFragment(response *v1pb.LoginResponse) url.Values {
+	if response.MfaRequired {
+		return url.Values{
+			"mfa_token":            {response.MfaToken},
+			"mfa_token_expires_at": {response.MfaTokenExpiresAt.AsTime().Format(time.RFC3339)},
+		}
+	}
+	return url.Values{
+		"access_token":            {response.AccessToken},
+		"refresh_token":           {response.RefreshToken},
+		"access_token_expires_at": {response.AccessTokenExpiresAt.AsTime().Format(time.RFC3339)},
+	}
+}
+
+func redirectWithError(c echo.Context, redirectPath string, err error) error {
+	code, message := connect.CodeInternal, "sign-in failed"
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		code, message = connectErr.Code(), connectErr.Message()
+	}
+	fragment := url.Values{
+		"error":             {code.String()},
+		"error_description": {message},
+	}
+	return c.Redirect(http.StatusFound, redirectPath+"#"+fragment.Encode())
+}
+
+// localRedirectPath only accepts paths on this server, so that the tokens cannot be
+// sent elsewhere.
+func localRedirectPath(redirect string) string {
+	if !strings.HasPrefix(redirect, "/") || strings.HasPrefix(redirect, "//") || strings.Contains(redirect, "\\") {
+		return "/"
+	}
+	u, err := url.Parse(redirect)
+	if err != nil || u.Scheme != "" || u.Host != "" {
+		return "/"
+	}
+	u.Fragment = ""
+	return u.String()
+}
@@ -0,0 +1,181 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pixb/go-server/internal/profile"
+	storepb "github.com/pixb/go-server/proto/gen/store"
+	"github.com/pixb/go-server/server/oidc/oidctest"
+	"github.com/pixb/go-server/store"
+	"github.com/pixb/go-server/store/db/sqlite"
+)
+
+type externalLoginTest struct {
+	store  *store.Store
+	server *httptest.Server
+	idp    *oidctest.Provider
+}
+
+func newExternalLoginTest(t *testing.T) *externalLoginTest {
+	t.Helper()
+	ctx := context.Background()
+	prof := &profile.Profile{
+		Driver: "sqlite",
+		DSN:    filepath.Join(t.TempDir(), "test.db"),
+	}
+	driver, err := sqlite.NewDriver(prof)
+	require.NoError(t, err)
+	s := store.New(driver, prof)
+	t.Cleanup(func() { s.Close() })
+	require.NoError(t, s.Migrate(ctx))
+	basicSetting, err := s.GetInstanceBasicSetting(ctx)
+	require.NoError(t, err)
+
+	idp := oidctest.NewProvider("go-server", "client-secret")
+	t.Cleanup(idp.Close)
+	_, err = s.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+		Key: storepb.InstanceSettingKey_IDENTITY_PROVIDERS,
+		Value: &storepb.InstanceSetting_IdentityProviderSetting{IdentityProviderSetting: &storepb.InstanceIdentityProviderSetting{
+			Providers: []*storepb.IdentityProvider{{
+				Id:           "example",
+				Title:        "Example",
+				Issuer:       idp.Issuer(),
+				ClientId:     "go-server",
+				ClientSecret: "client-secret",
+			}},
+		}},
+	})
+	require.NoError(t, err)
+
+	echoServer := echo.New()
+	NewAPIV1Service(basicSetting.SecretKey, prof, s).RegisterExternalLoginRoutes(echoServer)
+	server := httptest.NewServer(echoServer)
+	t.Cleanup(server.Close)
+	prof.InstanceURL = server.URL
+	return &externalLoginTest{store: s, server: server, idp: idp}
+}
+
+// login signs in through the provider and returns the fragment passed to the redirect path.
+func (e *externalLoginTest) login(t *testing.T, redirect string) (string, url.Values) {
+	t.Helper()
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+	client := &http.Client{
+		Jar: jar,
+		// Stop at the redirect back to the application.
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if strings.HasPrefix(req.URL.String(), e.server.URL) && !strings.HasPrefix(req.URL.Path, "/auth/oidc/") {
+				return http.ErrUseLastResponse
+			}
+			return nil
+		},
+	}
+	resp, err := client.Get(e.server.URL + "/auth/oidc/example/login?redirect=" + url.QueryEscape(redirect))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	fragment, err := url.ParseQuery(location.Fragment)
+	require.NoError(t, err)
+	return location.Path, fragment
+}
+
+func TestExternalLogin(t *testing.T) {
+	ctx := context.Background()
+	e := newExternalLoginTest(t)
+	e.idp.SetClaims(map[string]any{
+		"sub":                "subject-1",
+		"preferred_username": "alice",
+		"name":               "Alice",
+		"email":              "alice@example.com",
+		"email_verified":     true,
+	})
+
+	// The first sign-in provisions a user and links the identity
+	path, fragment := e.login(t, "/home?tab=1")
+	assert.Equal(t, "/home", path)
+	require.Empty(t, fragment.Get("error"), fragment.Get("error_description"))
+	assert.NotEmpty(t, fragment.Get("access_token"))
+	assert.NotEmpty(t, fragment.Get("refresh_token"))
+	assert.NotEmpty(t, fragment.Get("access_token_expires_at"))
+
+	user, err := e.store.GetUserByUsername(ctx, "alice")
+	require.NoError(t, err)
+	require.NotNil(t, user)
+	assert.Equal(t, "Alice", user.Nickname)
+	assert.Equal(t, "alice@example.com", user.Email)
+	assert.True(t, user.EmailVerified)
+	assert.Equal(t, store.RoleUser, user.Role)
+	identities, err := e.store.ListUserIdentities(ctx, &store.FindUserIdentity{UserID: &user.ID})
+	require.NoError(t, err)
+	require.Len(t, identities, 1)
+	assert.Equal(t, "example", identities[0].Provider)
+	assert.Equal(t, "subject-1", identities[0].Subject)
+
+	// Later sign-ins of the same subject use the linked user
+	e.idp.SetClaims(map[string]any{
+		"sub":                "subject-1",
+		"preferred_username": "alice2",
+		"email":              "alice2@example.com",
+	})
+	_, fragment = e.login(t, "/")
+	assert.NotEmpty(t, fragment.Get("access_token"))
+	users, err := e.store.ListUsers(ctx, &store.FindUser{})
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+
+	// Another subject with the address of an existing account is refused
+	e.idp.SetClaims(map[string]any{
+		"sub":   "subject-2",
+		"email": "alice@example.com",
+	})
+	_, fragment = e.login(t, "/")
+	assert.Equal(t, "already_exists", fragment.Get("error"))
+	assert.Empty(t, fragment.Get("access_token"))
+}
+
+func TestExternalLogin_RejectsInvalidState(t *testing.T) {
+	e := newExternalLoginTest(t)
+	e.idp.SetClaims(map[string]any{"sub": "subject-1", "email": "alice@example.com"})
+
+	// Only local redirect paths are accepted
+	path, _ := e.login(t, "//evil.example.com/")
+	assert.Equal(t, "/", path)
+
+	// A callback without the state cookie of the sign-in is refused
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(e.server.URL + "/auth/oidc/example/callback?code=code&state=state")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	fragment, err := url.ParseQuery(location.Fragment)
+	require.NoError(t, err)
+	assert.Equal(t, "invalid_argument", fragment.Get("error"))
+
+	// Unknown providers are reported to the redirect path
+	resp, err = client.Get(e.server.URL + "/auth/oidc/missing/login?redirect=/signin")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	location, err = url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, "/signin", location.Path)
+	fragment, err = url.ParseQuery(location.Fragment)
+	require.NoError(t, err)
+	assert.Equal(t, "not_found", fragment.Get("error"))
+}
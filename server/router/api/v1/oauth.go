@@ -0,0 +1,168 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"connectrpc.com/connect"
+	"github.com/labstack/echo/v4"
+
+	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/server/oidc"
+	"github.com/pixb/go-server/server/service"
+)
+
+// oauthConsentPath is the page of the web app that asks the user for consent.
+const oauthConsentPath = "/oauth/consent"
+
+// RegisterOAuthRoutes serves the protocol endpoints of the built-in authorization server,
+// see OAuthService in oauth_service.proto. The issuer is the configured instance URL.
+func (s *APIV1Service) RegisterOAuthRoutes(echoServer *echo.Echo) {
+	echoServer.GET("/.well-known/openid-configuration", s.handleOAuthMetadata)
+	echoServer.GET("/oauth/authorize", s.handleOAuthAuthorize)
+	echoServer.POST("/oauth/token", s.handleOAuthToken)
+	echoServer.POST("/oauth/introspect", s.handleOAuthIntrospect)
+	echoServer.GET("/userinfo", s.handleOAuthUserInfo)
+	echoServer.POST("/userinfo", s.handleOAuthUserInfo)
+}
+
+func (s *APIV1Service) handleOAuthMetadata(c echo.Context) error {
+	c.Response().Header().Set("Cache-Control", "public, max-age=300")
+	return c.JSON(http.StatusOK, s.OAuthService.Metadata(s.Profile.InstanceURL))
+}
+
+func (s *APIV1Service) handleOAuthAuthorize(c echo.Context) error {
+	query := c.QueryParams()
+	authorization := &v1pb.OAuthAuthorization{
+		ClientId:            query.Get("client_id"),
+		RedirectUri:         query.Get("redirect_uri"),
+		ResponseType:        query.Get("response_type"),
+		Scope:               query.Get("scope"),
+		State:               query.Get("state"),
+		Nonce:               query.Get("nonce"),
+		CodeChallenge:       query.Get("code_challenge"),
+		CodeChallengeMethod: query.Get("code_challenge_method"),
+	}
+	err := s.OAuthService.CheckAuthorization(c.Request().Context(), authorization)
+	var oauthErr *oidc.Error
+	var connectErr *connect.Error
+	switch {
+	case err == nil:
+		// The web app signs the user in and asks for consent with the same request.
+		return c.Redirect(http.StatusFound, oauthConsentPath+"?"+c.Request().URL.RawQuery)
+	case errors.As(err, &oauthErr):
+		return c.Redirect(http.StatusFound, service.AuthorizationErrorURL(authorization.RedirectUri, authorization.State, oauthErr))
+	case errors.As(err, &connectErr) && connectErr.Code() == connect.CodeInvalidArgument:
+		// The client or the redirect URI is unknown, the error must not be redirected.
+		return c.JSON(http.StatusBadRequest, &oidc.Error{Code: "invalid_request", Description: connectErr.Message()})
+	default:
+		slog.Error("failed to check authorization request", "error", err)
+		return c.JSON(http.StatusInternalServerError, &oidc.Error{Code: "server_error"})
+	}
+}
+
+func (s *APIV1Service) handleOAuthToken(c echo.Context) error {
+	// Token responses must not be cached, see RFC 6749 section 5.1.
+	c.Response().Header().Set("Cache-Control", "no-store")
+	c.Response().Header().Set("Pragma", "no-cache")
+
+	// Parameters are only accepted in the body, see RFC 6749 section 3.2.
+	if err := c.Request().ParseForm(); err != nil {
+		return c.JSON(http.StatusBadRequest, &oidc.Error{Code: "invalid_request", Description: "malformed request body"})
+	}
+	form := c.Request().PostForm
+	clientID, clientSecret, basic, err := oauthClientCredentials(c.Request(), form)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, &oidc.Error{Code: "invalid_request", Description: err.Error()})
+	}
+
+	token, err := s.OAuthService.Token(c.Request().Context(), s.Profile.InstanceURL, clientID, clientSecret, form)
+	if err != nil {
+		return oauthClientError(c, err, basic, "failed to issue oauth token")
+	}
+	return c.JSON(http.StatusOK, token)
+}
+
+func (s *APIV1Service) handleOAuthIntrospect(c echo.Context) error {
+	c.Response().Header().Set("Cache-Control", "no-store")
+
+	// The token is only accepted in the body, see RFC 7662 section 2.1.
+	if err := c.Request().ParseForm(); err != nil {
+		return c.JSON(http.StatusBadRequest, &oidc.Error{Code: "invalid_request", Description: "malformed request body"})
+	}
+	form := c.Request().PostForm
+	clientID, clientSecret, basic, err := oauthClientCredentials(c.Request(), form)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, &oidc.Error{Code: "invalid_request", Description: err.Error()})
+	}
+
+	introspection, err := s.OAuthService.Introspect(c.Request().Context(), clientID, clientSecret, form)
+	if err != nil {
+		return oauthClientError(c, err, basic, "failed to introspect token")
+	}
+	return c.JSON(http.StatusOK, introspection)
+}
+
+// oauthClientError writes an error of an endpoint that authenticates clients, see
+// RFC 6749 section 5.2. Errors other than *oidc.Error are logged with message.
+func oauthClientError(c echo.Context, err error, basic bool, message string) error {
+	var oauthErr *oidc.Error
+	if !errors.As(err, &oauthErr) {
+		slog.Error(message, "error", err)
+		return c.JSON(http.StatusInternalServerError, &oidc.Error{Code: "server_error"})
+	}
+	if oauthErr.Code == "invalid_client" {
+		if basic {
+			c.Response().Header().Set("WWW-Authenticate", `Basic realm="oauth"`)
+		}
+		return c.JSON(http.StatusUnauthorized, oauthErr)
+	}
+	return c.JSON(http.StatusBadRequest, oauthErr)
+}
+
+func (s *APIV1Service) handleOAuthUserInfo(c echo.Context) error {
+	claims, err := s.OAuthService.UserInfo(c.Request().Context(), auth.ExtractBearerToken(c.Request().Header.Get("Authorization")))
+	if err != nil {
+		var oauthErr *oidc.Error
+		if !errors.As(err, &oauthErr) {
+			slog.Error("failed to get oauth user info", "error", err)
+			return c.JSON(http.StatusInternalServerError, &oidc.Error{Code: "server_error"})
+		}
+		// See RFC 6750 section 3.
+		c.Response().Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error=%q, error_description=%q`, oauthErr.Code, oauthErr.Description))
+		status := http.StatusUnauthorized
+		if oauthErr.Code == "insufficient_scope" {
+			status = http.StatusForbidden
+		}
+		return c.JSON(status, oauthErr)
+	}
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusOK, claims)
+}
+
+// oauthClientCredentials returns the credentials of the client from the Authorization header
+// or the form. Clients must not use both methods, see RFC 6749 section 2.3.
+func oauthClientCredentials(r *http.Request, form url.Values) (clientID, clientSecret string, basic bool, err error) {
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		return form.Get("client_id"), form.Get("client_secret"), false, nil
+	}
+	if form.Get("client_secret") != "" {
+		return "", "", true, errors.New("multiple client authentication methods")
+	}
+	// The credentials are form-encoded before they are put into the header.
+	if clientID, err = url.QueryUnescape(username); err != nil {
+		return "", "", true, errors.New("malformed client credentials")
+	}
+	if clientSecret, err = url.QueryUnescape(password); err != nil {
+		return "", "", true, errors.New("malformed client credentials")
+	}
+	if formClientID := form.Get("client_id"); formClientID != "" && formClientID != clientID {
+		return "", "", true, errors.New("client_id does not match the credentials")
+	}
+	return clientID, clientSecret, true, nil
+}
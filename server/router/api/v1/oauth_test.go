@@ -0,0 +1,476 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pixb/go-server/internal/profile"
+	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/server/oidc"
+	"github.com/pixb/go-server/store"
+	"github.com/pixb/go-server/store/db/sqlite"
+)
+
+const oauthTestRedirectURI = "http://127.0.0.1:3000/callback"
+
+type oauthTest struct {
+	store   *store.Store
+	service *APIV1Service
+	server  *httptest.Server
+	user    *store.User
+	// client is a confidential client registered for both grants.
+	client       *v1pb.OAuthClient
+	clientSecret string
+}
+
+func newOAuthTest(t *testing.T) *oauthTest {
+	t.Helper()
+	ctx := context.Background()
+	prof := &profile.Profile{
+		Driver: "sqlite",
+		DSN:    filepath.Join(t.TempDir(), "test.db"),
+	}
+	driver, err := sqlite.NewDriver(prof)
+	require.NoError(t, err)
+	s := store.New(driver, prof)
+	t.Cleanup(func() { s.Close() })
+	require.NoError(t, s.Migrate(ctx))
+	basicSetting, err := s.GetInstanceBasicSetting(ctx)
+	require.NoError(t, err)
+
+	user, err := s.CreateUser(ctx, &store.User{
+		Username:        "alice",
+		Email:           "alice@example.com",
+		Password:        "hash",
+		Nickname:        "Alice",
+		Role:            store.RoleAdmin,
+		PasswordExpires: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	apiV1Service := NewAPIV1Service(basicSetting.SecretKey, prof, s)
+	created, err := apiV1Service.CreateOAuthClient(userContext(user.ID, store.PermissionOAuthClientsWrite), &v1pb.CreateOAuthClientRequest{
+		OauthClient: &v1pb.OAuthClient{
+			Name:         "Wiki",
+			RedirectUris: []string{oauthTestRedirectURI},
+			GrantTypes:   []string{"authorization_code", "client_credentials"},
+			Scopes:       []string{"openid", "profile", "email", "wiki.read"},
+			Confidential: true,
+		},
+	})
+	require.NoError(t, err)
+
+	echoServer := echo.New()
+	echoServer.GET("/.well-known/jwks.json", func(c echo.Context) error {
+		jwks, err := apiV1Service.OAuthService.Keys.JWKS(c.Request().Context())
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, jwks)
+	})
+	apiV1Service.RegisterOAuthRoutes(echoServer)
+	server := httptest.NewServer(echoServer)
+	t.Cleanup(server.Close)
+	prof.InstanceURL = server.URL
+	return &oauthTest{
+		store:        s,
+		service:      apiV1Service,
+		server:       server,
+		user:         user,
+		client:       created.OauthClient,
+		clientSecret: created.ClientSecret,
+	}
+}
+
+func userContext(userID int64, permissions ...store.Permission) context.Context {
+	ctx := auth.SetUserIDInContext(context.Background(), userID)
+	return auth.SetUserClaimsInContext(ctx, &auth.UserClaims{UserID: userID, Permissions: permissions})
+}
+
+func (o *oauthTest) provider(t *testing.T) *oidc.Provider {
+	t.Helper()
+	provider, err := oidc.NewProvider(context.Background(), oidc.Config{
+		Issuer:       o.server.URL,
+		ClientID:     o.client.ClientId,
+		ClientSecret: o.clientSecret,
+		Scopes:       []string{"profile", "email"},
+		RedirectURL:  oauthTestRedirectURI,
+	})
+	require.NoError(t, err)
+	return provider
+}
+
+// get requests the URL without following redirects.
+func (o *oauthTest) get(t *testing.T, rawURL string) *http.Response {
+	t.Helper()
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(rawURL)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+// authorize sends the authorization request of the provider, approves it as the user and
+// returns the authorization code.
+func (o *oauthTest) authorize(t *testing.T, provider *oidc.Provider, state, nonce, verifier string) string {
+	t.Helper()
+	resp := o.get(t, provider.AuthCodeURL(state, nonce, verifier))
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+	consentURL, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	require.Equal(t, "/oauth/consent", consentURL.Path)
+
+	query := consentURL.Query()
+	approved, err := o.service.ApproveOAuthAuthorization(userContext(o.user.ID), &v1pb.ApproveOAuthAuthorizationRequest{
+		Authorization: &v1pb.OAuthAuthorization{
+			ClientId:            query.Get("client_id"),
+			RedirectUri:         query.Get("redirect_uri"),
+			ResponseType:        query.Get("response_type"),
+			Scope:               query.Get("scope"),
+			State:               query.Get("state"),
+			Nonce:               query.Get("nonce"),
+			CodeChallenge:       query.Get("code_challenge"),
+			CodeChallengeMethod: query.Get("code_challenge_method"),
+		},
+		Approved: true,
+	})
+	require.NoError(t, err)
+	redirectURL, err := url.Parse(approved.RedirectUrl)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(approved.RedirectUrl, oauthTestRedirectURI+"?"))
+	assert.Equal(t, state, redirectURL.Query().Get("state"))
+	return redirectURL.Query().Get("code")
+}
+
+func (o *oauthTest) postToken(t *testing.T, form url.Values, clientID, clientSecret string) (int, map[string]any) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, o.server.URL+"/oauth/token", strings.NewReader(form.Encode()))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(clientID, clientSecret)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
+	body := map[string]any{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return resp.StatusCode, body
+}
+
+func (o *oauthTest) userInfo(t *testing.T, accessToken string) (int, map[string]any) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, o.server.URL+"/userinfo", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body := map[string]any{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return resp.StatusCode, body
+}
+
+func TestOAuthAuthorizationCodeFlow(t *testing.T) {
+	ctx := context.Background()
+	o := newOAuthTest(t)
+	provider := o.provider(t)
+
+	verifier, err := oidc.RandomString()
+	require.NoError(t, err)
+	code := o.authorize(t, provider, "state-1", "nonce-1", verifier)
+
+	token, err := provider.Exchange(ctx, code, verifier)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer", token.TokenType)
+	assert.Equal(t, "openid profile email", token.Scope)
+
+	claims, err := provider.VerifyIDToken(ctx, token.IDToken, "nonce-1")
+	require.NoError(t, err)
+	assert.Equal(t, strconv.FormatInt(o.user.ID, 10), claims.Subject())
+	assert.Equal(t, "alice", claims.String("preferred_username"))
+	assert.Equal(t, "alice@example.com", claims.String("email"))
+
+	status, userInfo := o.userInfo(t, token.AccessToken)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, claims.Subject(), userInfo["sub"])
+	assert.Equal(t, "Alice", userInfo["name"])
+
+	// Tokens of clients do not grant access to the API
+	assert.Nil(t, auth.NewAuthenticator(o.store, o.service.Secret).Authenticate(ctx, "Bearer "+token.AccessToken))
+
+	// Codes can only be redeemed once
+	_, err = provider.Exchange(ctx, code, verifier)
+	var oauthErr *oidc.Error
+	require.ErrorAs(t, err, &oauthErr)
+	assert.Equal(t, "invalid_grant", oauthErr.Code)
+
+	// The verifier has to match the challenge of the authorization request
+	code = o.authorize(t, provider, "state-2", "nonce-2", verifier)
+	_, err = provider.Exchange(ctx, code, "other-verifier")
+	require.ErrorAs(t, err, &oauthErr)
+	assert.Equal(t, "invalid_grant", oauthErr.Code)
+
+	// The consent of the user has been recorded
+	consents, err := o.service.ListOAuthConsents(userContext(o.user.ID), &v1pb.ListOAuthConsentsRequest{})
+	require.NoError(t, err)
+	require.Len(t, consents.Consents, 1)
+	assert.Equal(t, "Wiki", consents.Consents[0].ClientName)
+
+	// Deleting the client invalidates its tokens
+	_, err = o.service.DeleteOAuthClient(userContext(o.user.ID, store.PermissionOAuthClientsWrite), &v1pb.DeleteOAuthClientRequest{ClientId: o.client.ClientId})
+	require.NoError(t, err)
+	status, body := o.userInfo(t, token.AccessToken)
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, "invalid_token", body["error"])
+}
+
+func TestOAuthDeletedUser(t *testing.T) {
+	ctx := context.Background()
+	o := newOAuthTest(t)
+	provider := o.provider(t)
+	verifier, err := oidc.RandomString()
+	require.NoError(t, err)
+	token, err := provider.Exchange(ctx, o.authorize(t, provider, "state-1", "nonce-1", verifier), verifier)
+	require.NoError(t, err)
+	code := o.authorize(t, provider, "state-2", "nonce-2", verifier)
+	require.NoError(t, o.store.DeleteUser(ctx, &store.DeleteUser{ID: o.user.ID}))
+
+	// Codes of deleted users are not redeemed
+	_, err = provider.Exchange(ctx, code, verifier)
+	var oauthErr *oidc.Error
+	require.ErrorAs(t, err, &oauthErr)
+	assert.Equal(t, "invalid_grant", oauthErr.Code)
+
+	// Tokens of deleted users are not accepted
+	status, body := o.userInfo(t, token.AccessToken)
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, "invalid_token", body["error"])
+}
+
+func TestOAuthAuthorizeErrors(t *testing.T) {
+	o := newOAuthTest(t)
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {o.client.ClientId},
+		"redirect_uri":          {oauthTestRedirectURI},
+		"state":                 {"state"},
+		"code_challenge":        {oidc.CodeChallenge("verifier")},
+		"code_challenge_method": {"S256"},
+	}
+
+	// An unregistered redirect URI is not redirected to
+	unknown := url.Values{}
+	for name, values := range query {
+		unknown[name] = values
+	}
+	unknown.Set("redirect_uri", "http://127.0.0.1:3000/other")
+	resp := o.get(t, o.server.URL+"/oauth/authorize?"+unknown.Encode())
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Other errors are sent to the client
+	query.Set("code_challenge_method", "plain")
+	resp = o.get(t, o.server.URL+"/oauth/authorize?"+query.Encode())
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, "/callback", location.Path)
+	assert.Equal(t, "invalid_request", location.Query().Get("error"))
+	assert.Equal(t, "state", location.Query().Get("state"))
+}
+
+func TestOAuthClientCredentials(t *testing.T) {
+	o := newOAuthTest(t)
+	form := url.Values{"grant_type": {"client_credentials"}}
+
+	status, body := o.postToken(t, form, o.client.ClientId, "wrong-secret")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, "invalid_client", body["error"])
+
+	status, body = o.postToken(t, url.Values{"grant_type": {"password"}}, o.client.ClientId, o.clientSecret)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "unsupported_grant_type", body["error"])
+
+	status, body = o.postToken(t, form, o.client.ClientId, o.clientSecret)
+	require.Equal(t, http.StatusOK, status, body)
+	// The OpenID Connect scopes are not granted without a user
+	assert.Equal(t, "wiki.read", body["scope"])
+	accessToken, _ := body["access_token"].(string)
+	require.NotEmpty(t, accessToken)
+
+	claims, err := o.service.OAuthService.Keys.ValidateAccessToken(context.Background(), accessToken)
+	require.NoError(t, err)
+	assert.Equal(t, o.client.ClientId, claims.Subject)
+	assert.Zero(t, claims.UserID)
+
+	status, body = o.userInfo(t, accessToken)
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, "invalid_token", body["error"])
+}
+
+func TestOAuthIssuerIgnoresHost(t *testing.T) {
+	o := newOAuthTest(t)
+	spoofed := func(req *http.Request) *http.Request {
+		req.Host = "evil.example.com"
+		req.Header.Set("X-Forwarded-Proto", "https")
+		return req
+	}
+
+	// The issuer is the instance URL however the server is reached
+	req, err := http.NewRequest(http.MethodGet, o.server.URL+"/.well-known/openid-configuration", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(spoofed(req))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	metadata := map[string]any{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&metadata))
+	assert.Equal(t, o.server.URL, metadata["issuer"])
+	assert.Equal(t, o.server.URL+"/oauth/token", metadata["token_endpoint"])
+
+	req, err = http.NewRequest(http.MethodPost, o.server.URL+"/oauth/token", strings.NewReader(url.Values{"grant_type": {"client_credentials"}}.Encode()))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(o.client.ClientId, o.clientSecret)
+	resp, err = http.DefaultClient.Do(spoofed(req))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body := map[string]any{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	accessToken, _ := body["access_token"].(string)
+	require.NotEmpty(t, accessToken, body)
+	claims, err := o.service.OAuthService.Keys.ValidateAccessToken(context.Background(), accessToken)
+	require.NoError(t, err)
+	assert.Equal(t, o.server.URL, claims.Issuer)
+}
+
+func (o *oauthTest) introspect(t *testing.T, token, clientID, clientSecret string) (int, map[string]any) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, o.server.URL+"/oauth/introspect", strings.NewReader(url.Values{"token": {token}}.Encode()))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(clientID, clientSecret)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body := map[string]any{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return resp.StatusCode, body
+}
+
+func TestOAuthIntrospect(t *testing.T) {
+	o := newOAuthTest(t)
+	ctx := context.Background()
+	userID := strconv.FormatInt(o.user.ID, 10)
+
+	// Access tokens of the API
+	accessToken, err := o.service.OAuthService.Keys.GenerateAccessToken(ctx, o.user.ID, o.user.Username, o.user.Role, "session-1", auth.AccessTokenDuration)
+	require.NoError(t, err)
+	status, body := o.introspect(t, accessToken, o.client.ClientId, o.clientSecret)
+	require.Equal(t, http.StatusOK, status, body)
+	assert.Equal(t, true, body["active"])
+	assert.Equal(t, "access_token", body["token_use"])
+	assert.Equal(t, "Bearer", body["token_type"])
+	assert.Equal(t, userID, body["sub"])
+	assert.Equal(t, "alice", body["username"])
+	assert.Equal(t, "admin", body["role"])
+	assert.NotEmpty(t, body["jti"])
+	assert.Greater(t, body["exp"], float64(time.Now().Unix()))
+
+	// Revoking the session deactivates its access tokens
+	_, err = o.store.CreateRevokedToken(ctx, &store.CreateRevokedToken{JTI: "session-1", UserID: o.user.ID, ExpiresAt: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	_, body = o.introspect(t, accessToken, o.client.ClientId, o.clientSecret)
+	assert.Equal(t, map[string]any{"active": false}, body)
+
+	// Access tokens of OAuth clients
+	_, token := o.postToken(t, url.Values{"grant_type": {"client_credentials"}}, o.client.ClientId, o.clientSecret)
+	_, body = o.introspect(t, token["access_token"].(string), o.client.ClientId, o.clientSecret)
+	assert.Equal(t, true, body["active"])
+	assert.Equal(t, o.client.ClientId, body["client_id"])
+	assert.Equal(t, o.client.ClientId, body["sub"])
+	assert.Equal(t, "wiki.read", body["scope"])
+	assert.Equal(t, o.server.URL, body["iss"])
+	assert.Nil(t, body["username"])
+
+	// Refresh tokens
+	_, err = o.store.CreateRefreshToken(ctx, &store.CreateRefreshToken{UserID: o.user.ID, Token: "refresh-token", FamilyID: "session-2", ExpiresAt: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	_, body = o.introspect(t, "refresh-token", o.client.ClientId, o.clientSecret)
+	assert.Equal(t, true, body["active"])
+	assert.Equal(t, "refresh_token", body["token_use"])
+	assert.Equal(t, userID, body["sub"])
+	refreshToken, err := o.store.GetRefreshToken(ctx, "refresh-token")
+	require.NoError(t, err)
+	revoked := true
+	_, err = o.store.UpdateRefreshToken(ctx, &store.UpdateRefreshToken{ID: refreshToken.ID, Revoked: &revoked})
+	require.NoError(t, err)
+	_, body = o.introspect(t, "refresh-token", o.client.ClientId, o.clientSecret)
+	assert.Equal(t, map[string]any{"active": false}, body)
+
+	// Personal access tokens
+	pat, err := o.service.CreatePersonalAccessToken(userContext(o.user.ID), &v1pb.CreatePersonalAccessTokenRequest{Description: "ci"})
+	require.NoError(t, err)
+	_, body = o.introspect(t, pat.Token, o.client.ClientId, o.clientSecret)
+	assert.Equal(t, true, body["active"])
+	assert.Equal(t, "personal_access_token", body["token_use"])
+	assert.Equal(t, "alice", body["username"])
+	_, err = o.service.DeletePersonalAccessToken(userContext(o.user.ID), &v1pb.DeletePersonalAccessTokenRequest{Id: pat.PersonalAccessToken.Id})
+	require.NoError(t, err)
+	_, body = o.introspect(t, pat.Token, o.client.ClientId, o.clientSecret)
+	assert.Equal(t, map[string]any{"active": false}, body)
+
+	_, body = o.introspect(t, "unknown", o.client.ClientId, o.clientSecret)
+	assert.Equal(t, map[string]any{"active": false}, body)
+
+	// Only confidential clients may introspect tokens
+	status, body = o.introspect(t, accessToken, o.client.ClientId, "wrong-secret")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, "invalid_client", body["error"])
+	public, err := o.service.CreateOAuthClient(userContext(o.user.ID, store.PermissionOAuthClientsWrite), &v1pb.CreateOAuthClientRequest{
+		OauthClient: &v1pb.OAuthClient{Name: "SPA", RedirectUris: []string{oauthTestRedirectURI}, GrantTypes: []string{"authorization_code"}},
+	})
+	require.NoError(t, err)
+	status, body = o.introspect(t, accessToken, public.OauthClient.ClientId, "")
+	assert.Equal(t, http.StatusUnauthorized, status)
+	assert.Equal(t, "invalid_client", body["error"])
+}
+
+func TestOAuthIntrospect_DeletedUser(t *testing.T) {
+	o := newOAuthTest(t)
+	ctx := context.Background()
+	user, err := o.store.CreateUser(ctx, &store.User{
+		Username:        "bob",
+		Email:           "bob@example.com",
+		Password:        "hash",
+		Role:            store.RoleUser,
+		PasswordExpires: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	accessToken, err := o.service.OAuthService.Keys.GenerateAccessToken(ctx, user.ID, user.Username, user.Role, "session-1", auth.AccessTokenDuration)
+	require.NoError(t, err)
+	_, err = o.store.CreateRefreshToken(ctx, &store.CreateRefreshToken{UserID: user.ID, Token: "refresh-token", FamilyID: "session-1", ExpiresAt: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	pat, err := o.service.CreatePersonalAccessToken(userContext(user.ID), &v1pb.CreatePersonalAccessTokenRequest{Description: "ci"})
+	require.NoError(t, err)
+	require.NoError(t, o.store.DeleteUser(ctx, &store.DeleteUser{ID: user.ID}))
+
+	// Tokens of deleted users are not active
+	for _, token := range []string{accessToken, "refresh-token", pat.Token} {
+		status, body := o.introspect(t, token, o.client.ClientId, o.clientSecret)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, map[string]any{"active": false}, body)
+	}
+}
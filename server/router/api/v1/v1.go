@@ -20,6 +20,10 @@ type APIV1Service struct {
 	v1pb.UnimplementedUserServiceServer
 	v1pb.UnimplementedAuthServiceServer
 	v1pb.UnimplementedInstanceServiceServer
+	v1pb.UnimplementedAdminServiceServer
+	v1pb.UnimplementedRoleServiceServer
+	v1pb.UnimplementedIdentityProviderServiceServer
+	v1pb.UnimplementedOAuthServiceServer
 
 	Secret          string
 	Profile         *profile.Profile
@@ -27,12 +31,28 @@ type APIV1Service struct {
 	UserService     *service.UserService
 	AuthService     *service.AuthService
 	InstanceService *service.InstanceService
+	AdminService    *service.AdminService
+	RoleService     *service.RoleService
+
+	IdentityProviderService *service.IdentityProviderService
+	OAuthService            *service.OAuthService
+
+	// ServicePrincipals are the services that authenticate with client certificates.
+	ServicePrincipals []*auth.ServicePrincipal
+	// CORSOrigins are the origins allowed to call the API from browsers, any origin if nil.
+	CORSOrigins *middleware.CORSOrigins
+	// Maintenance rejects requests while the instance is in maintenance, never if nil.
+	Maintenance *auth.Maintenance
 }
 
 func NewAPIV1Service(secret string, profile *profile.Profile, store *store.Store) *APIV1Service {
 	userService := service.NewUserService(secret, store)
 	authService := service.NewAuthService(secret, store)
 	instanceService := service.NewInstanceService(profile.Version, profile.Demo, store)
+	adminService := service.NewAdminService(store)
+	roleService := service.NewRoleService(store)
+	identityProviderService := service.NewIdentityProviderService(store)
+	oauthService := service.NewOAuthService(secret, store)
 	return &APIV1Service{
 		Secret:          secret,
 		Profile:         profile,
@@ -40,6 +60,11 @@ func NewAPIV1Service(secret string, profile *profile.Profile, store *store.Store
 		UserService:     userService,
 		AuthService:     authService,
 		InstanceService: instanceService,
+		AdminService:    adminService,
+		RoleService:     roleService,
+
+		IdentityProviderService: identityProviderService,
+		OAuthService:            oauthService,
 	}
 }
 
@@ -47,6 +72,8 @@ func NewAPIV1Service(secret string, profile *profile.Profile, store *store.Store
 func (s *APIV1Service) createConnectInterceptors() connect.HandlerOption {
 	logStacktraces := s.Profile.Demo
 	authInterceptor := auth.NewInterceptor(s.Store, s.Secret)
+	authInterceptor.SetServicePrincipals(s.ServicePrincipals)
+	authInterceptor.SetMaintenance(s.Maintenance)
 	return connect.WithInterceptors(
 		interceptor.NewMetadataInterceptor(),
 		interceptor.NewLoggingInterceptor(logStacktraces),
@@ -60,13 +87,17 @@ func (s *APIV1Service) RegisterGateway(ctx context.Context, echoServer *echo.Ech
 	// STEP 1: Create Authenticator
 	// =====================================================
 	authenticator := auth.NewAuthenticator(s.Store, s.Secret)
+	authenticator.ServicePrincipals = s.ServicePrincipals
 
 	// =====================================================
 	// STEP 2: Create gRPC-Gateway mux
 	// =====================================================
 	gwMux := runtime.NewServeMux(
-		runtime.WithMiddlewares(auth.NewGatewayAuthMiddleware(authenticator)),
+		runtime.WithMiddlewares(auth.NewGatewayAuthMiddleware(authenticator, auth.NewAuthorizer(), s.Maintenance)),
 		runtime.WithErrorHandler(middleware.NewGatewayErrorHandler()),
+		runtime.WithRoutingErrorHandler(middleware.NewGatewayRoutingErrorHandler()),
+		// A form POST would otherwise be routed to the GET rule of the same path.
+		runtime.WithDisablePathLengthFallback(),
 	)
 
 	// =====================================================
@@ -81,6 +112,18 @@ func (s *APIV1Service) RegisterGateway(ctx context.Context, echoServer *echo.Ech
 	if err := v1pb.RegisterInstanceServiceHandlerServer(ctx, gwMux, s); err != nil {
 		return err
 	}
+	if err := v1pb.RegisterAdminServiceHandlerServer(ctx, gwMux, s); err != nil {
+		return err
+	}
+	if err := v1pb.RegisterRoleServiceHandlerServer(ctx, gwMux, s); err != nil {
+		return err
+	}
+	if err := v1pb.RegisterIdentityProviderServiceHandlerServer(ctx, gwMux, s); err != nil {
+		return err
+	}
+	if err := v1pb.RegisterOAuthServiceHandlerServer(ctx, gwMux, s); err != nil {
+		return err
+	}
 
 	// =====================================================
 	// STEP 4: Create Connect service handler
@@ -91,7 +134,7 @@ func (s *APIV1Service) RegisterGateway(ctx context.Context, echoServer *echo.Ech
 	// STEP 5: Create common middlewares
 	// =====================================================
 	commonMiddlewares := []echo.MiddlewareFunc{
-		middleware.NewCORSHandler(),
+		middleware.NewCORSHandler(s.CORSOrigins),
 		middleware.UnifiedResponseMiddleware(),
 	}
 
@@ -112,6 +155,16 @@ func (s *APIV1Service) RegisterGateway(ctx context.Context, echoServer *echo.Ech
 	connectGroup := echoServer.Group("", commonMiddlewares...)
 	connectGroup.Any("/goserver.api.v1.*", echo.WrapHandler(connectMux))
 
+	// =====================================================
+	// STEP 8: Register external login routes
+	// =====================================================
+	s.RegisterExternalLoginRoutes(echoServer)
+
+	// =====================================================
+	// STEP 9: Register OAuth authorization server routes
+	// =====================================================
+	s.RegisterOAuthRoutes(echoServer)
+
 	return nil
 }
 
@@ -124,6 +177,10 @@ func (s *APIV1Service) Login(ctx context.Context, req *v1pb.LoginRequest) (*v1pb
 	return s.AuthService.Login(ctx, req)
 }
 
+func (s *APIV1Service) VerifyMFA(ctx context.Context, req *v1pb.VerifyMFARequest) (*v1pb.VerifyMFAResponse, error) {
+	return s.AuthService.VerifyMFA(ctx, req)
+}
+
 func (s *APIV1Service) RefreshToken(ctx context.Context, req *v1pb.RefreshTokenRequest) (*v1pb.RefreshTokenResponse, error) {
 	return s.AuthService.RefreshToken(ctx, req)
 }
@@ -136,6 +193,18 @@ func (s *APIV1Service) Logout(ctx context.Context, req *v1pb.LogoutRequest) (*v1
 	return s.AuthService.Logout(ctx, req)
 }
 
+func (s *APIV1Service) RequestPasswordReset(ctx context.Context, req *v1pb.RequestPasswordResetRequest) (*v1pb.RequestPasswordResetResponse, error) {
+	return s.AuthService.RequestPasswordReset(ctx, req)
+}
+
+func (s *APIV1Service) ConfirmPasswordReset(ctx context.Context, req *v1pb.ConfirmPasswordResetRequest) (*v1pb.ConfirmPasswordResetResponse, error) {
+	return s.AuthService.ConfirmPasswordReset(ctx, req)
+}
+
+func (s *APIV1Service) ListLoginProviders(ctx context.Context, req *v1pb.ListLoginProvidersRequest) (*v1pb.ListLoginProvidersResponse, error) {
+	return s.AuthService.ListLoginProviders(ctx, req)
+}
+
 func (s *APIV1Service) GetUserProfile(ctx context.Context, req *v1pb.GetUserProfileRequest) (*v1pb.GetUserProfileResponse, error) {
 	return s.UserService.GetUserProfile(ctx, req)
 }
@@ -148,6 +217,198 @@ func (s *APIV1Service) ChangePassword(ctx context.Context, req *v1pb.ChangePassw
 	return s.UserService.ChangePassword(ctx, req)
 }
 
+func (s *APIV1Service) CreatePersonalAccessToken(ctx context.Context, req *v1pb.CreatePersonalAccessTokenRequest) (*v1pb.CreatePersonalAccessTokenResponse, error) {
+	return s.UserService.CreatePersonalAccessToken(ctx, req)
+}
+
+func (s *APIV1Service) ListPersonalAccessTokens(ctx context.Context, req *v1pb.ListPersonalAccessTokensRequest) (*v1pb.ListPersonalAccessTokensResponse, error) {
+	return s.UserService.ListPersonalAccessTokens(ctx, req)
+}
+
+func (s *APIV1Service) DeletePersonalAccessToken(ctx context.Context, req *v1pb.DeletePersonalAccessTokenRequest) (*v1pb.DeletePersonalAccessTokenResponse, error) {
+	return s.UserService.DeletePersonalAccessToken(ctx, req)
+}
+
+func (s *APIV1Service) ListSessions(ctx context.Context, req *v1pb.ListSessionsRequest) (*v1pb.ListSessionsResponse, error) {
+	return s.UserService.ListSessions(ctx, req)
+}
+
+func (s *APIV1Service) RevokeSession(ctx context.Context, req *v1pb.RevokeSessionRequest) (*v1pb.RevokeSessionResponse, error) {
+	return s.UserService.RevokeSession(ctx, req)
+}
+
+func (s *APIV1Service) RevokeAllOtherSessions(ctx context.Context, req *v1pb.RevokeAllOtherSessionsRequest) (*v1pb.RevokeAllOtherSessionsResponse, error) {
+	return s.UserService.RevokeAllOtherSessions(ctx, req)
+}
+
+func (s *APIV1Service) EnrollTOTP(ctx context.Context, req *v1pb.EnrollTOTPRequest) (*v1pb.EnrollTOTPResponse, error) {
+	return s.UserService.EnrollTOTP(ctx, req)
+}
+
+func (s *APIV1Service) ConfirmTOTP(ctx context.Context, req *v1pb.ConfirmTOTPRequest) (*v1pb.ConfirmTOTPResponse, error) {
+	return s.UserService.ConfirmTOTP(ctx, req)
+}
+
+func (s *APIV1Service) DisableTOTP(ctx context.Context, req *v1pb.DisableTOTPRequest) (*v1pb.DisableTOTPResponse, error) {
+	return s.UserService.DisableTOTP(ctx, req)
+}
+
+func (s *APIV1Service) UnlockUser(ctx context.Context, req *v1pb.UnlockUserRequest) (*v1pb.UnlockUserResponse, error) {
+	return s.UserService.UnlockUser(ctx, req)
+}
+
+func (s *APIV1Service) VerifyEmail(ctx context.Context, req *v1pb.VerifyEmailRequest) (*v1pb.VerifyEmailResponse, error) {
+	return s.UserService.VerifyEmail(ctx, req)
+}
+
+func (s *APIV1Service) ResendVerification(ctx context.Context, req *v1pb.ResendVerificationRequest) (*v1pb.ResendVerificationResponse, error) {
+	return s.UserService.ResendVerification(ctx, req)
+}
+
 func (s *APIV1Service) GetInstanceProfile(ctx context.Context, req *v1pb.GetInstanceProfileRequest) (*v1pb.InstanceProfile, error) {
 	return s.InstanceService.GetInstanceProfile(ctx, req)
 }
+
+func (s *APIV1Service) SetupInstance(ctx context.Context, req *v1pb.SetupInstanceRequest) (*v1pb.SetupInstanceResponse, error) {
+	return s.InstanceService.SetupInstance(ctx, req)
+}
+
+func (s *APIV1Service) GetInstanceSetting(ctx context.Context, req *v1pb.GetInstanceSettingRequest) (*v1pb.GetInstanceSettingResponse, error) {
+	return s.InstanceService.GetInstanceSetting(ctx, req)
+}
+
+func (s *APIV1Service) UpdateInstanceSetting(ctx context.Context, req *v1pb.UpdateInstanceSettingRequest) (*v1pb.UpdateInstanceSettingResponse, error) {
+	return s.InstanceService.UpdateInstanceSetting(ctx, req)
+}
+
+func (s *APIV1Service) ListUsers(ctx context.Context, req *v1pb.ListUsersRequest) (*v1pb.ListUsersResponse, error) {
+	return s.AdminService.ListUsers(ctx, req)
+}
+
+func (s *APIV1Service) GetUser(ctx context.Context, req *v1pb.GetUserRequest) (*v1pb.GetUserResponse, error) {
+	return s.AdminService.GetUser(ctx, req)
+}
+
+func (s *APIV1Service) CreateUser(ctx context.Context, req *v1pb.CreateUserRequest) (*v1pb.CreateUserResponse, error) {
+	return s.AdminService.CreateUser(ctx, req)
+}
+
+func (s *APIV1Service) UpdateUser(ctx context.Context, req *v1pb.UpdateUserRequest) (*v1pb.UpdateUserResponse, error) {
+	return s.AdminService.UpdateUser(ctx, req)
+}
+
+func (s *APIV1Service) DeleteUser(ctx context.Context, req *v1pb.DeleteUserRequest) (*v1pb.DeleteUserResponse, error) {
+	return s.AdminService.DeleteUser(ctx, req)
+}
+
+func (s *APIV1Service) RestoreUser(ctx context.Context, req *v1pb.RestoreUserRequest) (*v1pb.RestoreUserResponse, error) {
+	return s.AdminService.RestoreUser(ctx, req)
+}
+
+func (s *APIV1Service) ResetUserPassword(ctx context.Context, req *v1pb.ResetUserPasswordRequest) (*v1pb.ResetUserPasswordResponse, error) {
+	return s.AdminService.ResetUserPassword(ctx, req)
+}
+
+func (s *APIV1Service) CreateInvite(ctx context.Context, req *v1pb.CreateInviteRequest) (*v1pb.CreateInviteResponse, error) {
+	return s.AdminService.CreateInvite(ctx, req)
+}
+
+func (s *APIV1Service) ListInvites(ctx context.Context, req *v1pb.ListInvitesRequest) (*v1pb.ListInvitesResponse, error) {
+	return s.AdminService.ListInvites(ctx, req)
+}
+
+func (s *APIV1Service) RevokeInvite(ctx context.Context, req *v1pb.RevokeInviteRequest) (*v1pb.RevokeInviteResponse, error) {
+	return s.AdminService.RevokeInvite(ctx, req)
+}
+
+func (s *APIV1Service) ListPermissions(ctx context.Context, req *v1pb.ListPermissionsRequest) (*v1pb.ListPermissionsResponse, error) {
+	return s.RoleService.ListPermissions(ctx, req)
+}
+
+func (s *APIV1Service) ListRoles(ctx context.Context, req *v1pb.ListRolesRequest) (*v1pb.ListRolesResponse, error) {
+	return s.RoleService.ListRoles(ctx, req)
+}
+
+func (s *APIV1Service) GetRole(ctx context.Context, req *v1pb.GetRoleRequest) (*v1pb.GetRoleResponse, error) {
+	return s.RoleService.GetRole(ctx, req)
+}
+
+func (s *APIV1Service) CreateRole(ctx context.Context, req *v1pb.CreateRoleRequest) (*v1pb.CreateRoleResponse, error) {
+	return s.RoleService.CreateRole(ctx, req)
+}
+
+func (s *APIV1Service) UpdateRole(ctx context.Context, req *v1pb.UpdateRoleRequest) (*v1pb.UpdateRoleResponse, error) {
+	return s.RoleService.UpdateRole(ctx, req)
+}
+
+func (s *APIV1Service) DeleteRole(ctx context.Context, req *v1pb.DeleteRoleRequest) (*v1pb.DeleteRoleResponse, error) {
+	return s.RoleService.DeleteRole(ctx, req)
+}
+
+func (s *APIV1Service) ListUserRoles(ctx context.Context, req *v1pb.ListUserRolesRequest) (*v1pb.ListUserRolesResponse, error) {
+	return s.RoleService.ListUserRoles(ctx, req)
+}
+
+func (s *APIV1Service) SetUserRoles(ctx context.Context, req *v1pb.SetUserRolesRequest) (*v1pb.SetUserRolesResponse, error) {
+	return s.RoleService.SetUserRoles(ctx, req)
+}
+
+func (s *APIV1Service) ListIdentityProviders(ctx context.Context, req *v1pb.ListIdentityProvidersRequest) (*v1pb.ListIdentityProvidersResponse, error) {
+	return s.IdentityProviderService.ListIdentityProviders(ctx, req)
+}
+
+func (s *APIV1Service) GetIdentityProvider(ctx context.Context, req *v1pb.GetIdentityProviderRequest) (*v1pb.GetIdentityProviderResponse, error) {
+	return s.IdentityProviderService.GetIdentityProvider(ctx, req)
+}
+
+func (s *APIV1Service) CreateIdentityProvider(ctx context.Context, req *v1pb.CreateIdentityProviderRequest) (*v1pb.CreateIdentityProviderResponse, error) {
+	return s.IdentityProviderService.CreateIdentityProvider(ctx, req)
+}
+
+func (s *APIV1Service) UpdateIdentityProvider(ctx context.Context, req *v1pb.UpdateIdentityProviderRequest) (*v1pb.UpdateIdentityProviderResponse, error) {
+	return s.IdentityProviderService.UpdateIdentityProvider(ctx, req)
+}
+
+func (s *APIV1Service) DeleteIdentityProvider(ctx context.Context, req *v1pb.DeleteIdentityProviderRequest) (*v1pb.DeleteIdentityProviderResponse, error) {
+	return s.IdentityProviderService.DeleteIdentityProvider(ctx, req)
+}
+
+func (s *APIV1Service) ListOAuthClients(ctx context.Context, req *v1pb.ListOAuthClientsRequest) (*v1pb.ListOAuthClientsResponse, error) {
+	return s.OAuthService.ListOAuthClients(ctx, req)
+}
+
+func (s *APIV1Service) GetOAuthClient(ctx context.Context, req *v1pb.GetOAuthClientRequest) (*v1pb.GetOAuthClientResponse, error) {
+	return s.OAuthService.GetOAuthClient(ctx, req)
+}
+
+func (s *APIV1Service) CreateOAuthClient(ctx context.Context, req *v1pb.CreateOAuthClientRequest) (*v1pb.CreateOAuthClientResponse, error) {
+	return s.OAuthService.CreateOAuthClient(ctx, req)
+}
+
+func (s *APIV1Service) UpdateOAuthClient(ctx context.Context, req *v1pb.UpdateOAuthClientRequest) (*v1pb.UpdateOAuthClientResponse, error) {
+	return s.OAuthService.UpdateOAuthClient(ctx, req)
+}
+
+func (s *APIV1Service) RotateOAuthClientSecret(ctx context.Context, req *v1pb.RotateOAuthClientSecretRequest) (*v1pb.RotateOAuthClientSecretResponse, error) {
+	return s.OAuthService.RotateOAuthClientSecret(ctx, req)
+}
+
+func (s *APIV1Service) DeleteOAuthClient(ctx context.Context, req *v1pb.DeleteOAuthClientRequest) (*v1pb.DeleteOAuthClientResponse, error) {
+	return s.OAuthService.DeleteOAuthClient(ctx, req)
+}
+
+func (s *APIV1Service) GetOAuthAuthorization(ctx context.Context, req *v1pb.GetOAuthAuthorizationRequest) (*v1pb.GetOAuthAuthorizationResponse, error) {
+	return s.OAuthService.GetOAuthAuthorization(ctx, req)
+}
+
+func (s *APIV1Service) ApproveOAuthAuthorization(ctx context.Context, req *v1pb.ApproveOAuthAuthorizationRequest) (*v1pb.ApproveOAuthAuthorizationResponse, error) {
+	return s.OAuthService.ApproveOAuthAuthorization(ctx, req)
+}
+
+func (s *APIV1Service) ListOAuthConsents(ctx context.Context, req *v1pb.ListOAuthConsentsRequest) (*v1pb.ListOAuthConsentsResponse, error) {
+	return s.OAuthService.ListOAuthConsents(ctx, req)
+}
+
+func (s *APIV1Service) RevokeOAuthConsent(ctx context.Context, req *v1pb.RevokeOAuthConsentRequest) (*v1pb.RevokeOAuthConsentResponse, error) {
+	return s.OAuthService.RevokeOAuthConsent(ctx, req)
+}
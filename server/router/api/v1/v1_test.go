@@ -0,0 +1,48 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/store"
+)
+
+func TestGateway_FormPostToGetRoute(t *testing.T) {
+	o := newOAuthTest(t)
+	echoServer := echo.New()
+	require.NoError(t, o.service.RegisterGateway(context.Background(), echoServer))
+	server := httptest.NewServer(echoServer)
+	t.Cleanup(server.Close)
+	token, err := auth.GenerateAccessToken(o.user.ID, o.user.Username, store.RoleAdmin, o.service.Secret)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/api/v1/users/me/sessions", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// The form POST is not served by the GET rule of the path, whether authenticated or not
+	for _, token := range []string{"", token} {
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/api/v1/users/me/sessions", strings.NewReader("page_size=10"))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	}
+}
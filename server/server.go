@@ -2,9 +2,13 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"math"
 	"net"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -12,10 +16,14 @@ import (
 	echomiddleware "github.com/labstack/echo/v4/middleware"
 	"github.com/pixb/go-server/internal/profile"
 	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	storepb "github.com/pixb/go-server/proto/gen/store"
 	"github.com/pixb/go-server/server/auth"
 	"github.com/pixb/go-server/server/common"
+	"github.com/pixb/go-server/server/ldap"
 	"github.com/pixb/go-server/server/middleware"
+	"github.com/pixb/go-server/server/notify"
 	v1 "github.com/pixb/go-server/server/router/api/v1"
+	"github.com/pixb/go-server/server/service"
 	"github.com/pixb/go-server/store"
 	"github.com/soheilhy/cmux"
 	"google.golang.org/grpc"
@@ -31,7 +39,18 @@ type Server struct {
 	grpcServer         *grpc.Server
 	apiV1Service       *v1.APIV1Service
 	healthCheckService *common.HealthCheckService
-	wg                 sync.WaitGroup
+	keyManager         *auth.KeyManager
+	// tlsConfig is set when the server serves TLS, httpServer then serves all protocols.
+	tlsConfig  *tls.Config
+	httpServer *http.Server
+	wg         sync.WaitGroup
+
+	// rateLimiter and corsOrigins follow the security setting while serving.
+	rateLimiter         *middleware.RateLimiter
+	corsOrigins         *middleware.CORSOrigins
+	maintenance         *auth.Maintenance
+	unsubscribeSettings func()
+	stopSettingsWatcher context.CancelFunc
 }
 
 // 2.创建服务实例指针的方法
@@ -47,12 +66,17 @@ func NewServer(ctx context.Context, prof *profile.Profile, store *store.Store) (
 	echoServer.HideBanner = true
 	echoServer.Use(echomiddleware.Recover())
 	echoServer.Use(echomiddleware.Logger())
-	echoServer.Use(middleware.NewCORSHandler())
+	s.corsOrigins = &middleware.CORSOrigins{}
+	echoServer.Use(middleware.NewCORSHandler(s.corsOrigins))
 
-	// Only enable rate limiter in production mode
-	// if !prof.IsDev() {
-	// 	echoServer.Use(middleware.NewRateLimiter(middleware.DefaultRateLimiterConfig()))
-	// }
+	// Requests are not limited until the security setting enables it.
+	rateLimiterConfig := middleware.DefaultRateLimiterConfig()
+	rateLimiterConfig.Rate = 0
+	s.rateLimiter = middleware.NewAdjustableRateLimiter(rateLimiterConfig)
+	echoServer.Use(s.rateLimiter.Middleware())
+
+	s.maintenance = &auth.Maintenance{}
+	echoServer.Use(newMaintenanceHandler(s.maintenance))
 
 	// Only enable CSRF protection in production mode
 	// if !prof.IsDev() {
@@ -77,32 +101,140 @@ func NewServer(ctx context.Context, prof *profile.Profile, store *store.Store) (
 		return c.String(http.StatusServiceUnavailable, "Service not ready.")
 	})
 
+	// Use the secret key generated on migration unless one is given explicitly.
 	if prof.Secret == "" {
-		prof.Secret = "your-secret-key"
+		instanceBasicSetting, err := store.GetInstanceBasicSetting(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if instanceBasicSetting.SecretKey == "" {
+			return nil, errors.New("instance secret key is not initialized")
+		}
+		prof.Secret = instanceBasicSetting.SecretKey
 	}
 	s.Secret = prof.Secret
 
+	// Generate the first token signing key before serving, so that concurrent
+	// logins do not race to create it.
+	s.keyManager = auth.NewKeyManager(store, s.Secret)
+	if _, err := s.keyManager.EnsureSigningKey(ctx); err != nil {
+		return nil, fmt.Errorf("failed to initialize signing keys: %w", err)
+	}
+
+	// Public keys for verifying access tokens, see RFC 7517.
+	echoServer.GET("/.well-known/jwks.json", func(c echo.Context) error {
+		jwks, err := s.keyManager.JWKS(c.Request().Context())
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "failed to load signing keys")
+		}
+		c.Response().Header().Set("Cache-Control", "public, max-age=300")
+		return c.JSON(http.StatusOK, jwks)
+	})
+
+	securitySetting, err := store.GetInstanceSecuritySetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.applySecuritySetting(securitySetting)
+	maintenanceSetting, err := store.GetInstanceMaintenanceSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.maintenance.Set(maintenanceSetting)
+	// Token lifetimes and the other policies are read through the setting cache when used, the
+	// store invalidates it on changes, so only the middlewares need to be told.
+	s.unsubscribeSettings = store.SubscribeInstanceSettings(func(setting *storepb.InstanceSetting) {
+		switch setting.Key {
+		case storepb.InstanceSettingKey_SECURITY:
+			s.applySecuritySetting(setting.GetSecuritySetting())
+		case storepb.InstanceSettingKey_MAINTENANCE:
+			s.maintenance.Set(setting.GetMaintenanceSetting())
+		}
+	})
+
 	s.apiV1Service = v1.NewAPIV1Service(s.Secret, prof, store)
+	s.apiV1Service.CORSOrigins = s.corsOrigins
+	s.apiV1Service.Maintenance = s.maintenance
+	s.apiV1Service.InstanceService.Maintenance = s.maintenance
+	trustedProxies, err := common.ParseTrustedProxies(prof.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	s.apiV1Service.AuthService.TrustedProxies = trustedProxies
+	if prof.SMTPHost != "" {
+		notifier, err := notify.NewSMTPNotifier(notify.SMTPConfig{
+			Host:     prof.SMTPHost,
+			Port:     prof.SMTPPort,
+			Username: prof.SMTPUsername,
+			Password: prof.SMTPPassword,
+			From:     prof.SMTPFrom,
+		})
+		if err != nil {
+			return nil, err
+		}
+		s.apiV1Service.AuthService.Notifier = notifier
+		s.apiV1Service.UserService.Notifier = notifier
+		s.apiV1Service.InstanceService.Notifier = notifier
+	} else if prof.LogEmails {
+		echoServer.Logger.Warn("emails are written to the log, their tokens give access to accounts")
+		notifier := notify.NewLogNotifier()
+		s.apiV1Service.AuthService.Notifier = notifier
+		s.apiV1Service.UserService.Notifier = notifier
+		s.apiV1Service.InstanceService.Notifier = notifier
+	}
+	if prof.LDAPURL != "" {
+		verifier, err := newLDAPVerifier(prof, store)
+		if err != nil {
+			return nil, err
+		}
+		// Local passwords are checked first, so that local accounts keep working
+		// when the directory is down.
+		s.apiV1Service.AuthService.Verifiers = append(s.apiV1Service.AuthService.Verifiers, verifier)
+	}
 
 	authInterceptor := auth.NewInterceptor(store, s.Secret)
+	authInterceptor.SetMaintenance(s.maintenance)
+	if prof.TLSCert != "" {
+		tlsConfig, err := newTLSConfig(prof)
+		if err != nil {
+			return nil, err
+		}
+		principals, err := newServicePrincipals(ctx, prof, store)
+		if err != nil {
+			return nil, err
+		}
+		s.tlsConfig = tlsConfig
+		s.apiV1Service.ServicePrincipals = principals
+		authInterceptor.SetServicePrincipals(principals)
+	}
 	s.grpcServer = grpc.NewServer(grpc.UnaryInterceptor(authInterceptor.GRPCUnaryInterceptor()))
 	v1pb.RegisterUserServiceServer(s.grpcServer, s.apiV1Service)
 	v1pb.RegisterAuthServiceServer(s.grpcServer, s.apiV1Service)
 	v1pb.RegisterInstanceServiceServer(s.grpcServer, s.apiV1Service)
+	v1pb.RegisterAdminServiceServer(s.grpcServer, s.apiV1Service)
+	v1pb.RegisterRoleServiceServer(s.grpcServer, s.apiV1Service)
+	v1pb.RegisterIdentityProviderServiceServer(s.grpcServer, s.apiV1Service)
+	v1pb.RegisterOAuthServiceServer(s.grpcServer, s.apiV1Service)
 
 	return s, nil
 }
 
 func (s *Server) Start(ctx context.Context) error {
 	address := fmt.Sprintf("%s:%d", s.Profile.Addr, s.Profile.Port)
+	s.startSettingsWatcher(ctx)
 
 	listener, err := net.Listen("tcp", address)
 	if err != nil {
 		return fmt.Errorf("failed to listen: %w", err)
 	}
 
+	if s.tlsConfig != nil {
+		return s.startTLS(ctx, listener)
+	}
+
 	m := cmux.New(listener)
-	httpListener := m.Match(cmux.HTTP1Fast())
+	// PATCH is not among the methods HTTP1Fast knows by default.
+	httpListener := m.Match(cmux.HTTP1Fast(http.MethodPatch))
 	grpcListener := m.Match(cmux.HTTP2())
 
 	s.wg.Add(1)
@@ -143,9 +275,63 @@ func (s *Server) Shutdown(ctx context.Context) error {
 
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
+	if s.httpServer != nil {
+		s.httpServer.Shutdown(ctx)
+	}
 	s.echoServer.Shutdown(ctx)
 
+	if s.stopSettingsWatcher != nil {
+		s.stopSettingsWatcher()
+	}
+	s.unsubscribeSettings()
 	s.Store.Close()
 	s.wg.Wait()
 	return nil
 }
+
+// startSettingsWatcher picks up instance settings changed by other replicas until Shutdown.
+func (s *Server) startSettingsWatcher(ctx context.Context) {
+	ctx, s.stopSettingsWatcher = context.WithCancel(context.WithoutCancel(ctx))
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.Store.WatchInstanceSettings(ctx, s.Profile.SettingsPollInterval)
+	}()
+}
+
+// applySecuritySetting configures the HTTP middlewares with the security setting.
+func (s *Server) applySecuritySetting(setting *storepb.InstanceSecuritySetting) {
+	rateLimit := setting.GetRateLimit()
+	burst := int(rateLimit.GetBurst())
+	if burst == 0 {
+		burst = int(math.Ceil(rateLimit.GetRequestsPerSecond()))
+	}
+	s.rateLimiter.SetRate(rateLimit.GetRequestsPerSecond(), burst)
+	s.corsOrigins.Set(setting.GetCorsAllowedOrigins())
+}
+
+func newLDAPVerifier(prof *profile.Profile, st *store.Store) (*service.LDAPVerifier, error) {
+	client, err := ldap.NewClient(ldap.Config{
+		URL:               prof.LDAPURL,
+		StartTLS:          prof.LDAPStartTLS,
+		BindDN:            prof.LDAPBindDN,
+		BindPassword:      prof.LDAPBindPassword,
+		BaseDN:            prof.LDAPBaseDN,
+		UserFilter:        prof.LDAPUserFilter,
+		UsernameAttribute: prof.LDAPUsernameAttr,
+		NicknameAttribute: prof.LDAPNicknameAttr,
+		EmailAttribute:    prof.LDAPEmailAttr,
+		PhoneAttribute:    prof.LDAPPhoneAttr,
+		GroupBaseDN:       prof.LDAPGroupBaseDN,
+		GroupFilter:       prof.LDAPGroupFilter,
+	})
+	if err != nil {
+		return nil, err
+	}
+	groupRoles := map[string]store.Role{}
+	for _, groupRole := range prof.LDAPGroupRoles {
+		role, groupDN, _ := strings.Cut(groupRole, "=")
+		groupRoles[groupDN] = store.Role(role)
+	}
+	return service.NewLDAPVerifier(client, st, groupRoles), nil
+}
@@ -0,0 +1,405 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+
+	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/store"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultAdminListUsersPageSize = 50
+	maxAdminListUsersPageSize     = 1000
+)
+
+// AdminStore is an interface that defines the methods needed by AdminService
+type AdminStore interface {
+	CreateUser(ctx context.Context, create *store.User) (*store.User, error)
+	UpdateUser(ctx context.Context, update *store.UpdateUser) (*store.User, error)
+	ListUsers(ctx context.Context, find *store.FindUser) ([]*store.User, error)
+	DeleteUser(ctx context.Context, delete *store.DeleteUser) error
+	RestoreUser(ctx context.Context, restore *store.RestoreUser) error
+	GetUserByUsername(ctx context.Context, username string) (*store.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*store.User, error)
+	CreateSecurityEvent(ctx context.Context, create *store.CreateSecurityEvent) (*store.SecurityEvent, error)
+	passwordStore
+	sessionStore
+	roleStore
+	inviteStore
+}
+
+// AdminService lets admins manage the users of the instance. Every method requires the
+// users.read or users.write permission, and users can only be managed by callers who
+// have all of their permissions.
+type AdminService struct {
+	Store AdminStore
+}
+
+func NewAdminService(store AdminStore) *AdminService {
+	return &AdminService{
+		Store: store,
+	}
+}
+
+func (s *AdminService) ListUsers(ctx context.Context, req *v1pb.ListUsersRequest) (*v1pb.ListUsersResponse, error) {
+	if err := auth.Require(ctx, store.PermissionUsersRead); err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultAdminListUsersPageSize
+	}
+	pageSize = min(pageSize, maxAdminListUsersPageSize)
+	offset := 0
+	if req.PageToken != "" {
+		var err error
+		offset, err = strconv.Atoi(req.PageToken)
+		if err != nil || offset < 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid page token"))
+		}
+	}
+
+	find := &store.FindUser{
+		ShowDeleted: req.ShowDeleted,
+		// One more than requested tells whether there is a next page.
+		Limit:  pageSize + 1,
+		Offset: offset,
+	}
+	if req.Role != v1pb.Role_ROLE_UNSPECIFIED {
+		role := auth.RoleToString(req.Role)
+		if role == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid role"))
+		}
+		find.Role = &role
+	}
+	users, err := s.Store.ListUsers(ctx, find)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to list users"))
+	}
+
+	response := &v1pb.ListUsersResponse{}
+	if len(users) > pageSize {
+		users = users[:pageSize]
+		response.NextPageToken = strconv.Itoa(offset + pageSize)
+	}
+	for _, user := range users {
+		response.Users = append(response.Users, convertUserFromStore(user))
+	}
+	return response, nil
+}
+
+func (s *AdminService) GetUser(ctx context.Context, req *v1pb.GetUserRequest) (*v1pb.GetUserResponse, error) {
+	if err := auth.Require(ctx, store.PermissionUsersRead); err != nil {
+		return nil, err
+	}
+
+	user, err := s.findUser(ctx, req.Id, true)
+	if err != nil {
+		return nil, err
+	}
+	return &v1pb.GetUserResponse{User: convertUserFromStore(user)}, nil
+}
+
+func (s *AdminService) CreateUser(ctx context.Context, req *v1pb.CreateUserRequest) (*v1pb.CreateUserResponse, error) {
+	if err := auth.Require(ctx, store.PermissionUsersWrite); err != nil {
+		return nil, err
+	}
+
+	if len(req.Username) < 3 || len(req.Username) > 50 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("username must be between 3 and 50 characters"))
+	}
+	if req.Nickname == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("nickname is required"))
+	}
+	if len(req.Nickname) > 50 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("nickname must be at most 50 characters"))
+	}
+	if req.Phone != "" {
+		if len(req.Phone) != 11 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("phone must be 11 digits"))
+		}
+		for _, r := range req.Phone {
+			if r < '0' || r > '9' {
+				return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("phone must be only digits"))
+			}
+		}
+	}
+	if req.Email == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("email is required"))
+	}
+	if len(req.Email) > 100 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("email must be at most 100 characters"))
+	}
+	if !isValidEmail(req.Email) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("email must be a valid email address"))
+	}
+	role := store.RoleUser
+	if req.Role != v1pb.Role_ROLE_UNSPECIFIED {
+		role = auth.RoleToString(req.Role)
+		if role == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid role"))
+		}
+	}
+	if err := checkRoleGrantable(ctx, s.Store, role); err != nil {
+		return nil, err
+	}
+	if req.Password == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("password is required"))
+	}
+	passwordPolicy, err := validateNewPassword(ctx, s.Store, nil, req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	existingUser, err := s.Store.GetUserByUsername(ctx, req.Username)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user"))
+	}
+	if existingUser != nil {
+		return nil, connect.NewError(connect.CodeAlreadyExists, errors.New("username already exists"))
+	}
+	existingUser, err = s.Store.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user"))
+	}
+	if existingUser != nil {
+		return nil, connect.NewError(connect.CodeAlreadyExists, errors.New("email already exists"))
+	}
+
+	passwordHash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to hash password"))
+	}
+	user, err := s.Store.CreateUser(ctx, &store.User{
+		Username: req.Username,
+		Email:    req.Email,
+		Password: passwordHash,
+		Nickname: req.Nickname,
+		Phone:    req.Phone,
+		Role:     role,
+		// The admin vouches for the address.
+		EmailVerified:   true,
+		PasswordExpires: passwordPolicy.ExpiresAt(time.Now()),
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to create user"))
+	}
+	if err := recordPassword(ctx, s.Store, passwordPolicy, user.ID, passwordHash); err != nil {
+		return nil, err
+	}
+
+	return &v1pb.CreateUserResponse{User: convertUserFromStore(user)}, nil
+}
+
+func (s *AdminService) UpdateUser(ctx context.Context, req *v1pb.UpdateUserRequest) (*v1pb.UpdateUserResponse, error) {
+	if err := auth.Require(ctx, store.PermissionUsersWrite); err != nil {
+		return nil, err
+	}
+
+	if len(req.Nickname) > 50 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("nickname must be at most 50 characters"))
+	}
+	if req.Email != "" {
+		if len(req.Email) > 100 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("email must be at most 100 characters"))
+		}
+		if !isValidEmail(req.Email) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("email must be a valid email address"))
+		}
+	}
+
+	user, err := s.findUser(ctx, req.Id, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkUserGrantable(ctx, s.Store, user); err != nil {
+		return nil, err
+	}
+
+	update := &store.UpdateUser{ID: user.ID}
+	if req.Nickname != "" {
+		update.Nickname = &req.Nickname
+	}
+	if req.Email != "" && req.Email != user.Email {
+		existingUser, err := s.Store.GetUserByEmail(ctx, req.Email)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user"))
+		}
+		if existingUser != nil {
+			return nil, connect.NewError(connect.CodeAlreadyExists, errors.New("email already exists"))
+		}
+		// The admin vouches for the address.
+		emailVerified := true
+		update.Email = &req.Email
+		update.EmailVerified = &emailVerified
+	}
+	if req.Role != v1pb.Role_ROLE_UNSPECIFIED {
+		role := auth.RoleToString(req.Role)
+		if role == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid role"))
+		}
+		// Keeps admins from locking themselves, and possibly everyone, out of the admin API.
+		if user.ID == auth.GetUserID(ctx) && role != user.Role {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("cannot change your own role"))
+		}
+		if err := checkRoleGrantable(ctx, s.Store, role); err != nil {
+			return nil, err
+		}
+		update.Role = &role
+	}
+	if req.PasswordExpiresAt != nil {
+		if err := req.PasswordExpiresAt.CheckValid(); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid password expiry"))
+		}
+		passwordExpires := req.PasswordExpiresAt.AsTime()
+		update.PasswordExpires = &passwordExpires
+	}
+
+	updatedUser, err := s.Store.UpdateUser(ctx, update)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to update user"))
+	}
+	return &v1pb.UpdateUserResponse{User: convertUserFromStore(updatedUser)}, nil
+}
+
+func (s *AdminService) DeleteUser(ctx context.Context, req *v1pb.DeleteUserRequest) (*v1pb.DeleteUserResponse, error) {
+	if err := auth.Require(ctx, store.PermissionUsersWrite); err != nil {
+		return nil, err
+	}
+	if req.Id == auth.GetUserID(ctx) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("cannot delete yourself"))
+	}
+
+	user, err := s.findUser(ctx, req.Id, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkUserGrantable(ctx, s.Store, user); err != nil {
+		return nil, err
+	}
+	if err := s.Store.DeleteUser(ctx, &store.DeleteUser{ID: user.ID}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to delete user"))
+	}
+	if _, err := revokeAllSessions(ctx, s.Store, user.ID); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to revoke sessions"))
+	}
+
+	return &v1pb.DeleteUserResponse{}, nil
+}
+
+func (s *AdminService) RestoreUser(ctx context.Context, req *v1pb.RestoreUserRequest) (*v1pb.RestoreUserResponse, error) {
+	if err := auth.Require(ctx, store.PermissionUsersWrite); err != nil {
+		return nil, err
+	}
+
+	user, err := s.findUser(ctx, req.Id, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkUserGrantable(ctx, s.Store, user); err != nil {
+		return nil, err
+	}
+	if user.DeletedAt == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("user is not deleted"))
+	}
+	if err := s.Store.RestoreUser(ctx, &store.RestoreUser{ID: user.ID}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to restore user"))
+	}
+
+	restoredUser, err := s.findUser(ctx, user.ID, false)
+	if err != nil {
+		return nil, err
+	}
+	return &v1pb.RestoreUserResponse{User: convertUserFromStore(restoredUser)}, nil
+}
+
+func (s *AdminService) ResetUserPassword(ctx context.Context, req *v1pb.ResetUserPasswordRequest) (*v1pb.ResetUserPasswordResponse, error) {
+	if err := auth.Require(ctx, store.PermissionUsersWrite); err != nil {
+		return nil, err
+	}
+	if req.NewPassword == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("new password is required"))
+	}
+
+	user, err := s.findUser(ctx, req.Id, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkUserGrantable(ctx, s.Store, user); err != nil {
+		return nil, err
+	}
+	passwordPolicy, err := validateNewPassword(ctx, s.Store, user, req.NewPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	passwordHash, err := auth.HashPassword(req.NewPassword)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to hash new password"))
+	}
+	passwordExpires := passwordPolicy.ExpiresAt(time.Now())
+	if _, err := s.Store.UpdateUser(ctx, &store.UpdateUser{
+		ID:              user.ID,
+		Password:        &passwordHash,
+		PasswordExpires: &passwordExpires,
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to update password"))
+	}
+	if err := recordPassword(ctx, s.Store, passwordPolicy, user.ID, passwordHash); err != nil {
+		return nil, err
+	}
+
+	revoked, err := revokeAllSessions(ctx, s.Store, user.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to revoke sessions"))
+	}
+	if _, err := s.Store.CreateSecurityEvent(ctx, &store.CreateSecurityEvent{
+		UserID: user.ID,
+		Type:   store.SecurityEventPasswordReset,
+		Detail: fmt.Sprintf("password reset by admin %d, %d sessions revoked", auth.GetUserID(ctx), revoked),
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to record security event"))
+	}
+
+	return &v1pb.ResetUserPasswordResponse{}, nil
+}
+
+// findUser returns the user with the ID, or NotFound. Deleted users are found only with showDeleted.
+func (s *AdminService) findUser(ctx context.Context, id int64, showDeleted bool) (*store.User, error) {
+	users, err := s.Store.ListUsers(ctx, &store.FindUser{ID: &id, ShowDeleted: showDeleted})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user"))
+	}
+	if len(users) == 0 {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("user not found"))
+	}
+	return users[0], nil
+}
+
+func convertUserFromStore(user *store.User) *v1pb.User {
+	message := &v1pb.User{
+		Id:                user.ID,
+		Username:          user.Username,
+		Email:             user.Email,
+		Nickname:          user.Nickname,
+		Phone:             user.Phone,
+		Role:              auth.StringToRole(user.Role),
+		PasswordExpiresAt: timestamppb.New(user.PasswordExpires),
+		CreatedAt:         timestamppb.New(user.CreatedAt),
+		UpdatedAt:         timestamppb.New(user.UpdatedAt),
+		EmailVerified:     user.EmailVerified,
+	}
+	if user.DeletedAt != nil {
+		message.DeletedAt = timestamppb.New(*user.DeletedAt)
+	}
+	return message
+}
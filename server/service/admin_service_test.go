@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	storepb "github.com/pixb/go-server/proto/gen/store"
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// permissionContext returns the context of an authenticated user who has been granted the permissions.
+func permissionContext(userID int64, permissions ...store.Permission) context.Context {
+	ctx := auth.SetUserIDInContext(context.Background(), userID)
+	return auth.SetUserClaimsInContext(ctx, &auth.UserClaims{UserID: userID, Permissions: permissions})
+}
+
+var (
+	adminRoleDefinition = &store.RoleDefinition{ID: 1, Name: store.RoleAdmin, BuiltIn: true, Permissions: store.Permissions}
+	userRoleDefinition  = &store.RoleDefinition{ID: 2, Name: store.RoleUser, BuiltIn: true}
+)
+
+func newAdminServiceTest(t *testing.T) (*AdminService, *MockStore, context.Context) {
+	t.Helper()
+	mockStore := new(MockStore)
+	mockStore.On("ListRoleDefinitions", mock.Anything, &store.FindRoleDefinition{Name: &adminRoleDefinition.Name}).Return([]*store.RoleDefinition{adminRoleDefinition}, nil).Maybe()
+	mockStore.On("ListRoleDefinitions", mock.Anything, &store.FindRoleDefinition{Name: &userRoleDefinition.Name}).Return([]*store.RoleDefinition{userRoleDefinition}, nil).Maybe()
+	mockStore.On("ListUserRoleDefinitions", mock.Anything, mock.MatchedBy(func(user *store.User) bool {
+		return user.Role != store.RoleAdmin
+	})).Return([]*store.RoleDefinition{userRoleDefinition}, nil).Maybe()
+	mockStore.On("ListUserRoleDefinitions", mock.Anything, mock.MatchedBy(func(user *store.User) bool {
+		return user.Role == store.RoleAdmin
+	})).Return([]*store.RoleDefinition{adminRoleDefinition}, nil).Maybe()
+	return NewAdminService(mockStore), mockStore, permissionContext(1, store.Permissions...)
+}
+
+func TestAdminService_RequiresPermissions(t *testing.T) {
+	adminService, mockStore, _ := newAdminServiceTest(t)
+	adminID, userID := int64(1), int64(2)
+	mockStore.On("ListUsers", mock.Anything, &store.FindUser{ID: &adminID}).Return([]*store.User{{ID: adminID, Username: "admin", Role: store.RoleAdmin}}, nil)
+	mockStore.On("ListUsers", mock.Anything, &store.FindUser{ID: &userID}).Return([]*store.User{{ID: userID, Username: "testuser", Role: store.RoleUser}}, nil)
+
+	_, err := adminService.ListUsers(context.Background(), &v1pb.ListUsersRequest{})
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+	userCtx := permissionContext(3)
+	_, err = adminService.ListUsers(userCtx, &v1pb.ListUsersRequest{})
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	// Reading users does not allow changing them
+	readerCtx := permissionContext(3, store.PermissionUsersRead)
+	mockStore.On("ListUsers", mock.Anything, &store.FindUser{ID: &userID, ShowDeleted: true}).Return([]*store.User{{ID: userID, Username: "testuser", Role: store.RoleUser}}, nil)
+	_, err = adminService.GetUser(readerCtx, &v1pb.GetUserRequest{Id: userID})
+	require.NoError(t, err)
+	_, err = adminService.DeleteUser(readerCtx, &v1pb.DeleteUserRequest{Id: userID})
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	mockStore.AssertNotCalled(t, "DeleteUser", mock.Anything, mock.Anything)
+
+	// Users cannot be managed by callers with fewer permissions, nor be granted more than they have
+	writerCtx := permissionContext(3, store.PermissionUsersRead, store.PermissionUsersWrite)
+	_, err = adminService.ResetUserPassword(writerCtx, &v1pb.ResetUserPasswordRequest{Id: adminID, NewPassword: "Brand-new-pass1"})
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	_, err = adminService.UpdateUser(writerCtx, &v1pb.UpdateUserRequest{Id: userID, Role: v1pb.Role_ROLE_ADMIN})
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	mockStore.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+}
+
+func TestAdminService_ListUsers(t *testing.T) {
+	adminService, mockStore, ctx := newAdminServiceTest(t)
+	deletedAt := time.Now()
+	adminRole := store.RoleAdmin
+
+	mockStore.On("ListUsers", mock.Anything, &store.FindUser{Limit: 3, Offset: 0}).Return([]*store.User{
+		{ID: 1, Username: "admin", Role: store.RoleAdmin},
+		{ID: 2, Username: "user2", Role: store.RoleUser},
+		{ID: 3, Username: "user3", Role: store.RoleUser},
+	}, nil)
+	resp, err := adminService.ListUsers(ctx, &v1pb.ListUsersRequest{PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, resp.Users, 2)
+	assert.Equal(t, "2", resp.NextPageToken)
+
+	mockStore.On("ListUsers", mock.Anything, &store.FindUser{ShowDeleted: true, Limit: 3, Offset: 2}).Return([]*store.User{
+		{ID: 3, Username: "user3", Role: store.RoleUser, DeletedAt: &deletedAt},
+	}, nil)
+	resp, err = adminService.ListUsers(ctx, &v1pb.ListUsersRequest{PageSize: 2, PageToken: resp.NextPageToken, ShowDeleted: true})
+	require.NoError(t, err)
+	require.Len(t, resp.Users, 1)
+	assert.NotNil(t, resp.Users[0].DeletedAt)
+	assert.Empty(t, resp.NextPageToken)
+
+	mockStore.On("ListUsers", mock.Anything, &store.FindUser{Role: &adminRole, Limit: defaultAdminListUsersPageSize + 1}).Return([]*store.User{
+		{ID: 1, Username: "admin", Role: store.RoleAdmin},
+	}, nil)
+	resp, err = adminService.ListUsers(ctx, &v1pb.ListUsersRequest{Role: v1pb.Role_ROLE_ADMIN})
+	require.NoError(t, err)
+	require.Len(t, resp.Users, 1)
+	assert.Equal(t, v1pb.Role_ROLE_ADMIN, resp.Users[0].Role)
+
+	_, err = adminService.ListUsers(ctx, &v1pb.ListUsersRequest{PageToken: "invalid"})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestAdminService_CreateUser(t *testing.T) {
+	adminService, mockStore, ctx := newAdminServiceTest(t)
+	mockStore.On("GetInstancePasswordPolicySetting", mock.Anything).Return(&storepb.InstancePasswordPolicySetting{}, nil)
+
+	// Passwords follow the same policy as for registration
+	_, err := adminService.CreateUser(ctx, &v1pb.CreateUserRequest{
+		Username: "newadmin",
+		Nickname: "New Admin",
+		Email:    "newadmin@example.com",
+		Password: "short",
+	})
+	assertPasswordRule(t, err, auth.PasswordRuleMinLength)
+
+	var created *store.User
+	mockStore.On("GetUserByUsername", mock.Anything, "newadmin").Return(nil, nil)
+	mockStore.On("GetUserByEmail", mock.Anything, "newadmin@example.com").Return(nil, nil)
+	mockStore.On("CreateUser", mock.Anything, mock.AnythingOfType("*store.User")).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*store.User)
+	}).Return(&store.User{ID: 5, Username: "newadmin", Role: store.RoleAdmin, EmailVerified: true}, nil)
+	mockStore.On("CreatePasswordHistory", mock.Anything, mock.AnythingOfType("*store.CreatePasswordHistory")).Return(&store.PasswordHistory{ID: 1, UserID: 5}, nil)
+	mockStore.On("ListPasswordHistories", mock.Anything, &store.FindPasswordHistory{UserID: 5, Limit: 1}).Return([]*store.PasswordHistory{{ID: 1, UserID: 5}}, nil)
+	mockStore.On("DeletePasswordHistories", mock.Anything, &store.DeletePasswordHistory{UserID: 5, BeforeID: 1}).Return(nil)
+	resp, err := adminService.CreateUser(ctx, &v1pb.CreateUserRequest{
+		Username: "newadmin",
+		Nickname: "New Admin",
+		Email:    "newadmin@example.com",
+		Password: "Brand-new-pass1",
+		Role:     v1pb.Role_ROLE_ADMIN,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, v1pb.Role_ROLE_ADMIN, resp.User.Role)
+	require.NotNil(t, created)
+	assert.Equal(t, store.RoleAdmin, created.Role)
+	assert.True(t, created.EmailVerified)
+	assert.True(t, auth.CheckPassword("Brand-new-pass1", created.Password))
+
+	mockStore.On("GetUserByUsername", mock.Anything, "admin").Return(&store.User{ID: 1}, nil)
+	_, err = adminService.CreateUser(ctx, &v1pb.CreateUserRequest{
+		Username: "admin",
+		Nickname: "Admin",
+		Email:    "admin@example.com",
+		Password: "Brand-new-pass1",
+	})
+	assert.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err))
+}
+
+func TestAdminService_UpdateUser(t *testing.T) {
+	adminService, mockStore, ctx := newAdminServiceTest(t)
+	userID := int64(2)
+	user := &store.User{ID: userID, Username: "testuser", Email: "old@example.com", Role: store.RoleUser}
+	mockStore.On("ListUsers", mock.Anything, &store.FindUser{ID: &userID}).Return([]*store.User{user}, nil)
+
+	// Admins cannot demote themselves
+	adminID := int64(1)
+	mockStore.On("ListUsers", mock.Anything, &store.FindUser{ID: &adminID}).Return([]*store.User{{ID: adminID, Username: "admin", Role: store.RoleAdmin}}, nil)
+	_, err := adminService.UpdateUser(ctx, &v1pb.UpdateUserRequest{Id: 1, Role: v1pb.Role_ROLE_USER})
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	mockStore.On("GetUserByEmail", mock.Anything, "taken@example.com").Return(&store.User{ID: 3}, nil)
+	_, err = adminService.UpdateUser(ctx, &v1pb.UpdateUserRequest{Id: userID, Email: "taken@example.com"})
+	assert.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err))
+
+	var update *store.UpdateUser
+	passwordExpires := time.Now().Add(time.Hour).UTC()
+	mockStore.On("GetUserByEmail", mock.Anything, "new@example.com").Return(nil, nil)
+	mockStore.On("UpdateUser", mock.Anything, mock.AnythingOfType("*store.UpdateUser")).Run(func(args mock.Arguments) {
+		update = args.Get(1).(*store.UpdateUser)
+	}).Return(&store.User{ID: userID, Username: "testuser", Email: "new@example.com", Role: store.RoleAdmin, EmailVerified: true}, nil)
+	resp, err := adminService.UpdateUser(ctx, &v1pb.UpdateUserRequest{
+		Id:                userID,
+		Nickname:          "Renamed",
+		Email:             "new@example.com",
+		Role:              v1pb.Role_ROLE_ADMIN,
+		PasswordExpiresAt: timestamppb.New(passwordExpires),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, v1pb.Role_ROLE_ADMIN, resp.User.Role)
+	require.NotNil(t, update)
+	assert.Equal(t, "Renamed", *update.Nickname)
+	assert.Equal(t, "new@example.com", *update.Email)
+	assert.True(t, *update.EmailVerified)
+	assert.Equal(t, store.RoleAdmin, *update.Role)
+	assert.True(t, passwordExpires.Equal(*update.PasswordExpires))
+	assert.Nil(t, update.Password)
+
+	missingID := int64(9)
+	mockStore.On("ListUsers", mock.Anything, &store.FindUser{ID: &missingID}).Return([]*store.User{}, nil)
+	_, err = adminService.UpdateUser(ctx, &v1pb.UpdateUserRequest{Id: missingID, Nickname: "Nobody"})
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestAdminService_DeleteAndRestoreUser(t *testing.T) {
+	adminService, mockStore, ctx := newAdminServiceTest(t)
+	userID := int64(2)
+	familyID := "family-1"
+	deletedAt := time.Now()
+
+	_, err := adminService.DeleteUser(ctx, &v1pb.DeleteUserRequest{Id: 1})
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	// Deleting a user signs out their sessions
+	mockStore.On("ListUsers", mock.Anything, &store.FindUser{ID: &userID}).Return([]*store.User{{ID: userID, Username: "testuser", Role: store.RoleUser}}, nil).Once()
+	mockStore.On("DeleteUser", mock.Anything, &store.DeleteUser{ID: userID}).Return(nil)
+	mockStore.On("ListRefreshTokens", mock.Anything, &store.FindRefreshToken{UserID: &userID}).Return([]*store.RefreshToken{
+		{ID: 1, UserID: userID, FamilyID: familyID, ExpiresAt: time.Now().Add(time.Hour)},
+	}, nil)
+	mockStore.On("ListRefreshTokens", mock.Anything, &store.FindRefreshToken{UserID: &userID, FamilyID: &familyID}).Return([]*store.RefreshToken{
+		{ID: 1, UserID: userID, FamilyID: familyID, ExpiresAt: time.Now().Add(time.Hour)},
+	}, nil)
+	mockStore.On("UpdateRefreshToken", mock.Anything, mock.AnythingOfType("*store.UpdateRefreshToken")).Return(&store.RefreshToken{ID: 1}, nil)
+	mockStore.On("CreateRevokedToken", mock.Anything, mock.AnythingOfType("*store.CreateRevokedToken")).Return(&store.RevokedToken{ID: 1}, nil)
+	_, err = adminService.DeleteUser(ctx, &v1pb.DeleteUserRequest{Id: userID})
+	require.NoError(t, err)
+	mockStore.AssertCalled(t, "CreateRevokedToken", mock.Anything, mock.MatchedBy(func(create *store.CreateRevokedToken) bool {
+		return create.JTI == familyID && create.UserID == userID
+	}))
+
+	// Only deleted users can be restored
+	activeID := int64(3)
+	mockStore.On("ListUsers", mock.Anything, &store.FindUser{ID: &activeID, ShowDeleted: true}).Return([]*store.User{{ID: activeID, Username: "active"}}, nil)
+	_, err = adminService.RestoreUser(ctx, &v1pb.RestoreUserRequest{Id: activeID})
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	mockStore.On("ListUsers", mock.Anything, &store.FindUser{ID: &userID, ShowDeleted: true}).Return([]*store.User{{ID: userID, Username: "testuser", DeletedAt: &deletedAt}}, nil)
+	mockStore.On("RestoreUser", mock.Anything, &store.RestoreUser{ID: userID}).Return(nil)
+	mockStore.On("ListUsers", mock.Anything, &store.FindUser{ID: &userID}).Return([]*store.User{{ID: userID, Username: "testuser", Role: store.RoleUser}}, nil).Once()
+	resp, err := adminService.RestoreUser(ctx, &v1pb.RestoreUserRequest{Id: userID})
+	require.NoError(t, err)
+	assert.Equal(t, userID, resp.User.Id)
+	assert.Nil(t, resp.User.DeletedAt)
+	mockStore.AssertExpectations(t)
+}
+
+func TestAdminService_ResetUserPassword(t *testing.T) {
+	adminService, mockStore, ctx := newAdminServiceTest(t)
+	userID := int64(2)
+	currentHash, err := auth.HashPassword("Current-pass1")
+	require.NoError(t, err)
+	user := &store.User{ID: userID, Username: "testuser", Password: currentHash, Role: store.RoleUser}
+	mockStore.On("ListUsers", mock.Anything, &store.FindUser{ID: &userID}).Return([]*store.User{user}, nil)
+	mockStore.On("GetInstancePasswordPolicySetting", mock.Anything).Return(&storepb.InstancePasswordPolicySetting{HistoryCount: 1}, nil)
+	mockStore.On("ListPasswordHistories", mock.Anything, &store.FindPasswordHistory{UserID: userID, Limit: 1}).Return([]*store.PasswordHistory{}, nil)
+
+	_, err = adminService.ResetUserPassword(ctx, &v1pb.ResetUserPasswordRequest{Id: userID, NewPassword: "Current-pass1"})
+	assertPasswordRule(t, err, auth.PasswordRuleHistory)
+
+	var update *store.UpdateUser
+	var event *store.CreateSecurityEvent
+	mockStore.On("UpdateUser", mock.Anything, mock.AnythingOfType("*store.UpdateUser")).Run(func(args mock.Arguments) {
+		update = args.Get(1).(*store.UpdateUser)
+	}).Return(user, nil)
+	mockStore.On("CreatePasswordHistory", mock.Anything, mock.AnythingOfType("*store.CreatePasswordHistory")).Return(&store.PasswordHistory{ID: 1, UserID: userID}, nil)
+	mockStore.On("DeletePasswordHistories", mock.Anything, mock.AnythingOfType("*store.DeletePasswordHistory")).Return(nil)
+	mockStore.On("ListRefreshTokens", mock.Anything, &store.FindRefreshToken{UserID: &userID}).Return([]*store.RefreshToken{}, nil)
+	mockStore.On("CreateSecurityEvent", mock.Anything, mock.AnythingOfType("*store.CreateSecurityEvent")).Run(func(args mock.Arguments) {
+		event = args.Get(1).(*store.CreateSecurityEvent)
+	}).Return(&store.SecurityEvent{ID: 1}, nil)
+	_, err = adminService.ResetUserPassword(ctx, &v1pb.ResetUserPasswordRequest{Id: userID, NewPassword: "Brand-new-pass1"})
+	require.NoError(t, err)
+	require.NotNil(t, update)
+	assert.True(t, auth.CheckPassword("Brand-new-pass1", *update.Password))
+	assert.WithinDuration(t, time.Now().Add(auth.DefaultPasswordExpiry), *update.PasswordExpires, time.Minute)
+	require.NotNil(t, event)
+	assert.Equal(t, store.SecurityEventPasswordReset, event.Type)
+	assert.Equal(t, userID, event.UserID)
+}
@@ -2,13 +2,19 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"log/slog"
 	"time"
 
 	"connectrpc.com/connect"
 
 	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	storepb "github.com/pixb/go-server/proto/gen/store"
 	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/server/common"
+	"github.com/pixb/go-server/server/notify"
 	"github.com/pixb/go-server/store"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -18,8 +24,32 @@ type AuthStore interface {
 	GetUserByUsername(ctx context.Context, username string) (*store.User, error)
 	CreateRefreshToken(ctx context.Context, create *store.CreateRefreshToken) (*store.RefreshToken, error)
 	UpdateRefreshToken(ctx context.Context, update *store.UpdateRefreshToken) (*store.RefreshToken, error)
+	RevokeRefreshToken(ctx context.Context, id int64) (bool, error)
+	ListRefreshTokens(ctx context.Context, find *store.FindRefreshToken) ([]*store.RefreshToken, error)
 	GetRefreshToken(ctx context.Context, token string) (*store.RefreshToken, error)
+	CreateSecurityEvent(ctx context.Context, create *store.CreateSecurityEvent) (*store.SecurityEvent, error)
+	CreateRevokedToken(ctx context.Context, create *store.CreateRevokedToken) (*store.RevokedToken, error)
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
 	GetUser(ctx context.Context, find *store.FindUser) (*store.User, error)
+	GetTOTPCredential(ctx context.Context, find *store.FindTOTPCredential) (*store.TOTPCredential, error)
+	UpdateTOTPCredential(ctx context.Context, update *store.UpdateTOTPCredential) (*store.TOTPCredential, error)
+	GetInstanceSecuritySetting(ctx context.Context) (*storepb.InstanceSecuritySetting, error)
+	GetInstanceBasicSetting(ctx context.Context) (*storepb.InstanceBasicSetting, error)
+	RecordLoginFailure(ctx context.Context, record *store.RecordLoginFailure) (*store.LoginAttempt, error)
+	UpdateLoginAttempt(ctx context.Context, update *store.UpdateLoginAttempt) (*store.LoginAttempt, error)
+	GetLoginAttempt(ctx context.Context, find *store.FindLoginAttempt) (*store.LoginAttempt, error)
+	DeleteLoginAttempts(ctx context.Context, delete *store.DeleteLoginAttempt) error
+	GetUserByEmail(ctx context.Context, email string) (*store.User, error)
+	UpdateUser(ctx context.Context, update *store.UpdateUser) (*store.User, error)
+	CreatePasswordResetToken(ctx context.Context, create *store.CreatePasswordResetToken) (*store.PasswordResetToken, error)
+	GetPasswordResetToken(ctx context.Context, find *store.FindPasswordResetToken) (*store.PasswordResetToken, error)
+	UsePasswordResetToken(ctx context.Context, id int64) (bool, error)
+	DeletePasswordResetTokens(ctx context.Context, delete *store.DeletePasswordResetToken) error
+	GetInstanceIdentityProviderSetting(ctx context.Context) (*storepb.InstanceIdentityProviderSetting, error)
+	GetUserIdentity(ctx context.Context, find *store.FindUserIdentity) (*store.UserIdentity, error)
+	CreateUserIdentity(ctx context.Context, create *store.CreateUserIdentity) (*store.UserIdentity, error)
+	passwordStore
+	auth.SigningKeyStore
 	Ping(ctx context.Context) error
 	Close() error
 }
@@ -27,12 +57,25 @@ type AuthStore interface {
 type AuthService struct {
 	Secret string
 	Store  AuthStore
+	Keys   *auth.KeyManager
+	// Notifier delivers password reset tokens, none are sent unless it is replaced.
+	Notifier notify.Notifier
+	// Verifiers check the passwords of Login, only the local password by default.
+	Verifiers []CredentialVerifier
+	// TrustedProxies are the reverse proxies whose X-Forwarded-For entries give the
+	// client address, the peer address is used when there are none.
+	TrustedProxies common.TrustedProxies
 }
 
 func NewAuthService(secret string, store AuthStore) *AuthService {
 	return &AuthService{
-		Secret: secret,
-		Store:  store,
+		Secret:   secret,
+		Store:    store,
+		Keys:     auth.NewKeyManager(store, secret),
+		Notifier: notify.NewDisabledNotifier(),
+		Verifiers: []CredentialVerifier{
+			NewLocalVerifier(store),
+		},
 	}
 }
 
@@ -41,47 +84,89 @@ func (s *AuthService) Login(ctx context.Context, req *v1pb.LoginRequest) (*v1pb.
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("username and password are required"))
 	}
 
-	user, err := s.Store.GetUserByUsername(ctx, req.Username)
+	clientInfo := common.GetClientInfo(ctx, s.TrustedProxies)
+	if err := checkLoginAllowed(ctx, s.Store, req.Username, clientInfo.IPAddress); err != nil {
+		return nil, err
+	}
+
+	user, err := s.verifyCredentials(ctx, req.Username, req.Password)
+	if errors.Is(err, ErrInvalidCredentials) {
+		// Failures count against the local account of the username, if there is one.
+		existingUser, err := s.Store.GetUserByUsername(ctx, req.Username)
+		if err != nil {
+			return nil, err
+		}
+		if err := recordLoginFailure(ctx, s.Store, req.Username, clientInfo.IPAddress, existingUser); err != nil {
+			return nil, err
+		}
+		return nil, errInvalidCredentials()
+	}
 	if err != nil {
 		return nil, err
 	}
-	if user == nil {
-		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("username not found"))
+
+	if err := s.checkEmailVerified(ctx, user); err != nil {
+		return nil, err
 	}
 
-	if !auth.CheckPassword(req.Password, user.Password) {
-		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("invalid password"))
+	// Users with two-factor authentication get a challenge to complete with VerifyMFA instead of tokens
+	if response, err := s.mfaChallenge(ctx, user); err != nil || response != nil {
+		return response, err
 	}
 
-	// Check if password has expired
-	if time.Now().After(user.PasswordExpires) {
-		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("password expired"))
+	if err := resetLoginFailures(ctx, s.Store, user.Username); err != nil {
+		return nil, err
 	}
 
-	// Generate access token
-	accessToken, err := auth.GenerateAccessToken(user.ID, user.Username, user.Role, s.Secret)
+	return s.loginResponse(ctx, user)
+}
+
+// checkEmailVerified refuses sign-in for users with an unverified email address when the
+// instance requires verification. Allowed email domains require it as well, since anyone can
+// register with an address of the domains.
+func (s *AuthService) checkEmailVerified(ctx context.Context, user *store.User) error {
+	if user.EmailVerified {
+		return nil
+	}
+	securitySetting, err := s.Store.GetInstanceSecuritySetting(ctx)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to generate access token"))
+		return connect.NewError(connect.CodeInternal, errors.New("failed to get security setting"))
+	}
+	if securitySetting.GetRequireEmailVerification() ||
+		securitySetting.GetRegistration().GetMode() == storepb.RegistrationPolicy_ALLOWED_EMAIL_DOMAINS {
+		return connect.NewError(connect.CodeFailedPrecondition, errors.New("email address is not verified"))
 	}
+	return nil
+}
 
-	// Generate refresh token
-	refreshTokenString, err := auth.GenerateRefreshToken()
+// mfaChallenge returns the response of a login that has to be completed with VerifyMFA,
+// or nil if the user has no two-factor authentication.
+func (s *AuthService) mfaChallenge(ctx context.Context, user *store.User) (*v1pb.LoginResponse, error) {
+	enabled := true
+	credential, err := s.Store.GetTOTPCredential(ctx, &store.FindTOTPCredential{UserID: &user.ID, Enabled: &enabled})
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to generate refresh token"))
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get totp credential"))
 	}
-
-	// Save refresh token to database
-	_, err = s.Store.CreateRefreshToken(ctx, &store.CreateRefreshToken{
-		UserID:    user.ID,
-		Token:     refreshTokenString,
-		ExpiresAt: time.Now().Add(auth.RefreshTokenDuration),
-	})
+	if credential == nil {
+		return nil, nil
+	}
+	mfaToken, claims, err := auth.GenerateMFAChallengeToken(user.ID, s.Secret)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to save refresh token"))
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to generate mfa token"))
 	}
+	return &v1pb.LoginResponse{
+		MfaRequired:       true,
+		MfaToken:          mfaToken,
+		MfaTokenExpiresAt: timestamppb.New(claims.ExpiresAt.Time),
+	}, nil
+}
 
-	// Calculate access token expiration time
-	accessTokenExpiresAt := time.Now().Add(auth.AccessTokenDuration)
+// loginResponse starts a session for a signed in user.
+func (s *AuthService) loginResponse(ctx context.Context, user *store.User) (*v1pb.LoginResponse, error) {
+	accessToken, refreshTokenString, accessTokenExpiresAt, err := s.createSession(ctx, user)
+	if err != nil {
+		return nil, err
+	}
 
 	return &v1pb.LoginResponse{
 		AccessToken:          accessToken,
@@ -97,10 +182,155 @@ func (s *AuthService) Login(ctx context.Context, req *v1pb.LoginRequest) (*v1pb.
 			PasswordExpiresAt: timestamppb.New(user.PasswordExpires),
 			CreatedAt:         timestamppb.New(user.CreatedAt),
 			UpdatedAt:         timestamppb.New(user.UpdatedAt),
+			EmailVerified:     user.EmailVerified,
+		},
+	}, nil
+}
+
+func (s *AuthService) VerifyMFA(ctx context.Context, req *v1pb.VerifyMFARequest) (*v1pb.VerifyMFAResponse, error) {
+	if req.MfaToken == "" || req.Code == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("mfa token and code are required"))
+	}
+
+	// The challenge may have been issued by a server running with another secret key.
+	var claims *auth.MFAChallengeClaims
+	if err := withInstanceSecrets(ctx, s.Store, s.Secret, func(secret string) error {
+		var err error
+		claims, err = auth.ValidateMFAChallengeToken(req.MfaToken, secret)
+		return err
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("invalid or expired mfa token"))
+	}
+
+	// A challenge allows a single attempt, so codes cannot be guessed without the password
+	revoked, err := s.Store.IsTokenRevoked(ctx, claims.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to validate mfa token"))
+	}
+	if revoked {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("invalid or expired mfa token"))
+	}
+	if _, err := s.Store.CreateRevokedToken(ctx, &store.CreateRevokedToken{
+		JTI:       claims.ID,
+		UserID:    claims.UserID,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to consume mfa token"))
+	}
+
+	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &claims.UserID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user"))
+	}
+	if user == nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("user not found"))
+	}
+
+	// Failed codes count towards the account lockout as well, so they cannot be guessed by
+	// signing in with the password again and again.
+	clientInfo := common.GetClientInfo(ctx, s.TrustedProxies)
+	if err := checkLoginAllowed(ctx, s.Store, user.Username, clientInfo.IPAddress); err != nil {
+		return nil, err
+	}
+
+	enabled := true
+	credential, err := s.Store.GetTOTPCredential(ctx, &store.FindTOTPCredential{UserID: &user.ID, Enabled: &enabled})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get totp credential"))
+	}
+	if credential == nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("two-factor authentication is not enabled"))
+	}
+	ok, err := verifyTOTPCode(ctx, s.Store, credential, req.Code, s.Secret, true)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to verify code"))
+	}
+	if !ok {
+		if err := recordLoginFailure(ctx, s.Store, user.Username, clientInfo.IPAddress, user); err != nil {
+			return nil, err
+		}
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("invalid verification code"))
+	}
+
+	if err := resetLoginFailures(ctx, s.Store, user.Username); err != nil {
+		return nil, err
+	}
+
+	accessToken, refreshTokenString, accessTokenExpiresAt, err := s.createSession(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1pb.VerifyMFAResponse{
+		AccessToken:          accessToken,
+		RefreshToken:         refreshTokenString,
+		AccessTokenExpiresAt: timestamppb.New(accessTokenExpiresAt),
+		User: &v1pb.User{
+			Id:                user.ID,
+			Username:          user.Username,
+			Email:             user.Email,
+			Nickname:          user.Nickname,
+			Phone:             user.Phone,
+			Role:              auth.StringToRole(user.Role),
+			PasswordExpiresAt: timestamppb.New(user.PasswordExpires),
+			CreatedAt:         timestamppb.New(user.CreatedAt),
+			UpdatedAt:         timestamppb.New(user.UpdatedAt),
+			EmailVerified:     user.EmailVerified,
 		},
 	}, nil
 }
 
+// tokenLifetimes returns the lifetimes of newly issued tokens from the security setting.
+func (s *AuthService) tokenLifetimes(ctx context.Context) (*auth.TokenLifetimes, error) {
+	securitySetting, err := s.Store.GetInstanceSecuritySetting(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get security setting"))
+	}
+	return auth.NewTokenLifetimes(securitySetting), nil
+}
+
+// createSession starts a new session for a signed in user and issues its tokens.
+func (s *AuthService) createSession(ctx context.Context, user *store.User) (string, string, time.Time, error) {
+	lifetimes, err := s.tokenLifetimes(ctx)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	// Every login starts a new session, i.e. a new refresh token family
+	familyID, err := auth.GenerateTokenID()
+	if err != nil {
+		return "", "", time.Time{}, connect.NewError(connect.CodeInternal, errors.New("failed to generate session id"))
+	}
+
+	// Generate access token
+	accessToken, err := s.Keys.GenerateAccessToken(ctx, user.ID, user.Username, user.Role, familyID, lifetimes.AccessToken)
+	if err != nil {
+		return "", "", time.Time{}, connect.NewError(connect.CodeInternal, errors.New("failed to generate access token"))
+	}
+
+	// Generate refresh token
+	refreshTokenString, err := auth.GenerateRefreshToken()
+	if err != nil {
+		return "", "", time.Time{}, connect.NewError(connect.CodeInternal, errors.New("failed to generate refresh token"))
+	}
+
+	// Save refresh token to database
+	clientInfo := common.GetClientInfo(ctx, s.TrustedProxies)
+	_, err = s.Store.CreateRefreshToken(ctx, &store.CreateRefreshToken{
+		UserID:    user.ID,
+		Token:     refreshTokenString,
+		FamilyID:  familyID,
+		UserAgent: clientInfo.UserAgent,
+		IPAddress: clientInfo.IPAddress,
+		ExpiresAt: time.Now().Add(lifetimes.RefreshToken),
+	})
+	if err != nil {
+		return "", "", time.Time{}, connect.NewError(connect.CodeInternal, errors.New("failed to save refresh token"))
+	}
+
+	// Calculate access token expiration time
+	return accessToken, refreshTokenString, time.Now().Add(lifetimes.AccessToken), nil
+}
+
 func (s *AuthService) RefreshToken(ctx context.Context, req *v1pb.RefreshTokenRequest) (*v1pb.RefreshTokenResponse, error) {
 	if req.RefreshToken == "" {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("refresh token is required"))
@@ -115,8 +345,17 @@ func (s *AuthService) RefreshToken(ctx context.Context, req *v1pb.RefreshTokenRe
 		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("invalid refresh token"))
 	}
 
-	// Check if refresh token is expired or revoked
-	if refreshToken.Revoked || time.Now().After(refreshToken.ExpiresAt) {
+	// A revoked token being presented again means it was rotated and has leaked,
+	// so nothing issued from the same login can be trusted any more.
+	if refreshToken.Revoked {
+		if err := s.revokeRefreshTokenFamily(ctx, refreshToken); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to revoke refresh token family"))
+		}
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("refresh token expired or revoked"))
+	}
+
+	// Check if refresh token is expired
+	if time.Now().After(refreshToken.ExpiresAt) {
 		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("refresh token expired or revoked"))
 	}
 
@@ -129,18 +368,25 @@ func (s *AuthService) RefreshToken(ctx context.Context, req *v1pb.RefreshTokenRe
 		return nil, connect.NewError(connect.CodeNotFound, errors.New("user not found"))
 	}
 
-	// Revoke old refresh token
-	revoked := true
-	_, err = s.Store.UpdateRefreshToken(ctx, &store.UpdateRefreshToken{
-		ID:      refreshToken.ID,
-		Revoked: &revoked,
-	})
+	// Revoke old refresh token. A concurrent request with the same token loses here, which
+	// is a reuse as well.
+	rotated, err := s.Store.RevokeRefreshToken(ctx, refreshToken.ID)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to revoke old refresh token"))
 	}
+	if !rotated {
+		if err := s.revokeRefreshTokenFamily(ctx, refreshToken); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to revoke refresh token family"))
+		}
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("refresh token expired or revoked"))
+	}
 
 	// Generate new access token
-	newAccessToken, err := auth.GenerateAccessToken(user.ID, user.Username, user.Role, s.Secret)
+	lifetimes, err := s.tokenLifetimes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	newAccessToken, err := s.Keys.GenerateAccessToken(ctx, user.ID, user.Username, user.Role, refreshToken.FamilyID, lifetimes.AccessToken)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to generate access token"))
 	}
@@ -151,18 +397,22 @@ func (s *AuthService) RefreshToken(ctx context.Context, req *v1pb.RefreshTokenRe
 		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to generate refresh token"))
 	}
 
-	// Save new refresh token to database
+	// Save new refresh token to database, staying in the same family
+	clientInfo := common.GetClientInfo(ctx, s.TrustedProxies)
 	_, err = s.Store.CreateRefreshToken(ctx, &store.CreateRefreshToken{
 		UserID:    user.ID,
 		Token:     newRefreshTokenString,
-		ExpiresAt: time.Now().Add(auth.RefreshTokenDuration),
+		FamilyID:  refreshToken.FamilyID,
+		UserAgent: clientInfo.UserAgent,
+		IPAddress: clientInfo.IPAddress,
+		ExpiresAt: time.Now().Add(lifetimes.RefreshToken),
 	})
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to save refresh token"))
 	}
 
 	// Calculate access token expiration time
-	accessTokenExpiresAt := time.Now().Add(auth.AccessTokenDuration)
+	accessTokenExpiresAt := time.Now().Add(lifetimes.AccessToken)
 
 	return &v1pb.RefreshTokenResponse{
 		AccessToken:          newAccessToken,
@@ -178,6 +428,7 @@ func (s *AuthService) RefreshToken(ctx context.Context, req *v1pb.RefreshTokenRe
 			PasswordExpiresAt: timestamppb.New(user.PasswordExpires),
 			CreatedAt:         timestamppb.New(user.CreatedAt),
 			UpdatedAt:         timestamppb.New(user.UpdatedAt),
+			EmailVerified:     user.EmailVerified,
 		},
 	}, nil
 }
@@ -187,17 +438,51 @@ func (s *AuthService) ValidateToken(ctx context.Context, req *v1pb.ValidateToken
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("token is required"))
 	}
 
-	// Validate token
-	_, err := auth.ValidateAccessToken(req.Token, s.Secret)
-	if err != nil {
+	// Validate token, tokens of OAuth clients are not valid for the API
+	claims, err := s.Keys.ValidateAccessToken(ctx, req.Token)
+	if err != nil || claims.ClientID != "" {
 		return &v1pb.ValidateTokenResponse{
 			Valid: false,
 		}, nil
 	}
 
-	return &v1pb.ValidateTokenResponse{
-		Valid: true,
-	}, nil
+	// Check the denylist, tokens are revoked by their own jti or by their session
+	for _, id := range []string{claims.ID, claims.SessionID} {
+		if id == "" {
+			continue
+		}
+		revoked, err := s.Store.IsTokenRevoked(ctx, id)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to check token revocation"))
+		}
+		if revoked {
+			return &v1pb.ValidateTokenResponse{
+				Valid: false,
+			}, nil
+		}
+	}
+
+	// The user may have been deleted or given another role since the token was issued
+	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &claims.UserID})
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && user == nil) {
+		return &v1pb.ValidateTokenResponse{
+			Valid: false,
+		}, nil
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user"))
+	}
+
+	response := &v1pb.ValidateTokenResponse{
+		Valid:    true,
+		UserId:   user.ID,
+		Username: user.Username,
+		Role:     string(user.Role),
+	}
+	if claims.ExpiresAt != nil {
+		response.ExpiresAt = timestamppb.New(claims.ExpiresAt.Time)
+	}
+	return response, nil
 }
 
 func (s *AuthService) Logout(ctx context.Context, req *v1pb.LogoutRequest) (*v1pb.LogoutResponse, error) {
@@ -206,13 +491,73 @@ func (s *AuthService) Logout(ctx context.Context, req *v1pb.LogoutRequest) (*v1p
 	}
 
 	// Validate token
-	_, err := auth.ValidateAccessToken(req.Token, s.Secret)
-	if err != nil {
+	claims, err := s.Keys.ValidateAccessToken(ctx, req.Token)
+	if err != nil || claims.ClientID != "" {
 		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("invalid token"))
 	}
 
-	// For now, just return success (implement token revocation later)
+	// Deny the access token until it would have expired anyway
+	if claims.ID != "" {
+		expiresAt := time.Now().Add(auth.AccessTokenDuration)
+		if claims.ExpiresAt != nil {
+			expiresAt = claims.ExpiresAt.Time
+		}
+		_, err = s.Store.CreateRevokedToken(ctx, &store.CreateRevokedToken{
+			JTI:       claims.ID,
+			UserID:    claims.UserID,
+			ExpiresAt: expiresAt,
+		})
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to revoke access token"))
+		}
+	}
+
+	// Revoke the given refresh token, else those of the token's session, else all of the user's
+	find := &store.FindRefreshToken{UserID: &claims.UserID}
+	switch {
+	case req.RefreshToken != "":
+		find.Token = &req.RefreshToken
+	case claims.SessionID != "":
+		find.FamilyID = &claims.SessionID
+	}
+	refreshTokens, err := s.Store.ListRefreshTokens(ctx, find)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to list refresh tokens"))
+	}
+	revoked := true
+	for _, refreshToken := range refreshTokens {
+		if refreshToken.Revoked {
+			continue
+		}
+		_, err = s.Store.UpdateRefreshToken(ctx, &store.UpdateRefreshToken{
+			ID:      refreshToken.ID,
+			Revoked: &revoked,
+		})
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to revoke refresh token"))
+		}
+	}
+
 	return &v1pb.LogoutResponse{
 		Success: true,
 	}, nil
 }
+
+// revokeRefreshTokenFamily revokes the whole session of a reused refresh token
+// and records the reuse as a security event.
+func (s *AuthService) revokeRefreshTokenFamily(ctx context.Context, reused *store.RefreshToken) error {
+	slog.Warn("refresh token reuse detected, revoking token family",
+		slog.Int64("userID", reused.UserID),
+		slog.String("familyID", reused.FamilyID))
+
+	if err := revokeSession(ctx, s.Store, reused.UserID, reused.FamilyID); err != nil {
+		return err
+	}
+
+	_, err := s.Store.CreateSecurityEvent(ctx, &store.CreateSecurityEvent{
+		UserID: reused.UserID,
+		Type:   store.SecurityEventRefreshTokenReuse,
+		Detail: fmt.Sprintf("revoked refresh token %d of family %s was reused", reused.ID, reused.FamilyID),
+	})
+	return err
+}
@@ -2,19 +2,28 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
+	"connectrpc.com/connect"
 	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	storepb "github.com/pixb/go-server/proto/gen/store"
 	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/server/notify"
 	"github.com/pixb/go-server/store"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
 )
 
 func TestAuthService_Login(t *testing.T) {
 	// Create mock store
 	mockStore := new(MockStore)
+	mockStore.On("GetUserIdentity", mock.Anything, mock.AnythingOfType("*store.FindUserIdentity")).Return(nil, nil)
 
 	// Test data
 	req := &v1pb.LoginRequest{
@@ -32,6 +41,7 @@ func TestAuthService_Login(t *testing.T) {
 		Nickname:        "Test User",
 		Phone:           "13800138000",
 		Role:            store.RoleUser,
+		EmailVerified:   true,
 		PasswordExpires: time.Now().AddDate(0, 0, 90),
 		CreatedAt:       time.Now(),
 		UpdatedAt:       time.Now(),
@@ -44,6 +54,12 @@ func TestAuthService_Login(t *testing.T) {
 		CreatedAt: time.Now(),
 	}, nil)
 
+	mockStore.On("GetTOTPCredential", mock.Anything, mock.AnythingOfType("*store.FindTOTPCredential")).Return(nil, nil)
+	mockStore.On("GetInstanceJWTSigningKeySetting", mock.Anything).Return(newSigningKeySetting(t, "testsecret"), nil)
+	mockStore.On("GetLoginAttempt", mock.Anything, mock.AnythingOfType("*store.FindLoginAttempt")).Return(nil, nil)
+	mockStore.On("DeleteLoginAttempts", mock.Anything, &store.DeleteLoginAttempt{Scope: store.LoginAttemptScopeAccount, Identifier: req.Username}).Return(nil)
+	mockStore.On("GetInstanceSecuritySetting", mock.Anything).Return(&storepb.InstanceSecuritySetting{}, nil)
+
 	// Create auth service
 	authService := NewAuthService("testsecret", mockStore)
 
@@ -80,6 +96,7 @@ func TestAuthService_RefreshToken(t *testing.T) {
 		ID:        1,
 		UserID:    1,
 		Token:     req.RefreshToken,
+		FamilyID:  "family-1",
 		ExpiresAt: time.Now().AddDate(0, 0, 7),
 		CreatedAt: time.Now(),
 	}, nil)
@@ -95,21 +112,23 @@ func TestAuthService_RefreshToken(t *testing.T) {
 		CreatedAt:       time.Now(),
 		UpdatedAt:       time.Now(),
 	}, nil)
-	mockStore.On("UpdateRefreshToken", mock.Anything, mock.AnythingOfType("*store.UpdateRefreshToken")).Return(&store.RefreshToken{
-		ID:        1,
-		UserID:    1,
-		Token:     req.RefreshToken,
-		Revoked:   true,
-		ExpiresAt: time.Now().AddDate(0, 0, 7),
-		CreatedAt: time.Now(),
-	}, nil)
-	mockStore.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("*store.CreateRefreshToken")).Return(&store.RefreshToken{
+	mockStore.On("RevokeRefreshToken", mock.Anything, int64(1)).Return(true, nil)
+	// Tokens are issued with the lifetimes of the security setting
+	mockStore.On("CreateRefreshToken", mock.Anything, mock.MatchedBy(func(create *store.CreateRefreshToken) bool {
+		return create.FamilyID == "family-1" && time.Until(create.ExpiresAt) > 59*time.Minute && time.Until(create.ExpiresAt) <= time.Hour
+	})).Return(&store.RefreshToken{
 		ID:        2,
 		UserID:    1,
 		Token:     "newrefreshtoken",
-		ExpiresAt: time.Now().AddDate(0, 0, 7),
+		ExpiresAt: time.Now().Add(time.Hour),
 		CreatedAt: time.Now(),
 	}, nil)
+	mockStore.On("GetInstanceSecuritySetting", mock.Anything).Return(&storepb.InstanceSecuritySetting{
+		AccessTokenLifetimeSeconds:  600,
+		RefreshTokenLifetimeSeconds: 3600,
+	}, nil)
+
+	mockStore.On("GetInstanceJWTSigningKeySetting", mock.Anything).Return(newSigningKeySetting(t, "testsecret"), nil)
 
 	// Create auth service
 	authService := NewAuthService("testsecret", mockStore)
@@ -120,7 +139,10 @@ func TestAuthService_RefreshToken(t *testing.T) {
 	assert.NotNil(t, resp)
 	assert.NotEmpty(t, resp.AccessToken)
 	assert.NotEmpty(t, resp.RefreshToken)
-	assert.NotNil(t, resp.AccessTokenExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), resp.AccessTokenExpiresAt.AsTime(), time.Minute)
+	claims, err := authService.Keys.ValidateAccessToken(context.Background(), resp.AccessToken)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), claims.ExpiresAt.Time, time.Minute)
 	assert.NotNil(t, resp.User)
 	assert.Equal(t, int64(1), resp.User.Id)
 	assert.Equal(t, "testuser", resp.User.Username)
@@ -132,3 +154,561 @@ func TestAuthService_RefreshToken(t *testing.T) {
 	// Verify mock calls
 	mockStore.AssertExpectations(t)
 }
+
+func newSigningKeySetting(t *testing.T, secret string) *storepb.InstanceJWTSigningKeySetting {
+	key, err := auth.GenerateSigningKey(auth.DefaultSigningAlgorithm, secret)
+	require.NoError(t, err)
+	return &storepb.InstanceJWTSigningKeySetting{Keys: []*storepb.JWTSigningKey{key}}
+}
+
+func TestAuthService_Logout(t *testing.T) {
+	// Create mock store
+	mockStore := new(MockStore)
+
+	accessToken, err := auth.GenerateAccessToken(1, "testuser", store.RoleUser, "testsecret")
+	assert.NoError(t, err)
+	claims, err := auth.ValidateAccessToken(accessToken, "testsecret")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, claims.ID)
+
+	// Mock responses
+	mockStore.On("CreateRevokedToken", mock.Anything, &store.CreateRevokedToken{
+		JTI:       claims.ID,
+		UserID:    1,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}).Return(&store.RevokedToken{ID: 1, JTI: claims.ID, UserID: 1}, nil)
+	mockStore.On("ListRefreshTokens", mock.Anything, mock.AnythingOfType("*store.FindRefreshToken")).Return([]*store.RefreshToken{
+		{ID: 1, UserID: 1, Token: "active"},
+		{ID: 2, UserID: 1, Token: "alreadyrevoked", Revoked: true},
+	}, nil)
+	mockStore.On("UpdateRefreshToken", mock.Anything, mock.MatchedBy(func(update *store.UpdateRefreshToken) bool {
+		return update.ID == 1 && update.Revoked != nil && *update.Revoked
+	})).Return(&store.RefreshToken{ID: 1, UserID: 1, Revoked: true}, nil)
+
+	// HS256 tokens are accepted while no signing key has been generated
+	mockStore.On("GetInstanceJWTSigningKeySetting", mock.Anything).Return(&storepb.InstanceJWTSigningKeySetting{}, nil)
+
+	// Create auth service
+	authService := NewAuthService("testsecret", mockStore)
+
+	// Test Logout
+	resp, err := authService.Logout(context.Background(), &v1pb.LogoutRequest{Token: accessToken})
+	assert.NoError(t, err)
+	assert.True(t, resp.Success)
+
+	// Revoked tokens no longer validate
+	mockStore.On("IsTokenRevoked", mock.Anything, claims.ID).Return(true, nil)
+	validateResp, err := authService.ValidateToken(context.Background(), &v1pb.ValidateTokenRequest{Token: accessToken})
+	assert.NoError(t, err)
+	assert.False(t, validateResp.Valid)
+
+	// Verify mock calls
+	mockStore.AssertExpectations(t)
+	mockStore.AssertNumberOfCalls(t, "UpdateRefreshToken", 1)
+}
+
+func TestAuthService_ValidateToken(t *testing.T) {
+	mockStore := new(MockStore)
+	// HS256 tokens are accepted while no signing key has been generated
+	mockStore.On("GetInstanceJWTSigningKeySetting", mock.Anything).Return(&storepb.InstanceJWTSigningKeySetting{}, nil)
+	authService := NewAuthService("testsecret", mockStore)
+
+	accessToken, err := auth.GenerateSessionAccessToken(7, "alice", store.RoleAdmin, "session-1", "testsecret")
+	require.NoError(t, err)
+	claims, err := auth.ValidateAccessToken(accessToken, "testsecret")
+	require.NoError(t, err)
+	mockStore.On("IsTokenRevoked", mock.Anything, claims.ID).Return(false, nil)
+	mockStore.On("IsTokenRevoked", mock.Anything, "session-1").Return(false, nil).Times(3)
+
+	// The role is the one of the user, not the one of the token
+	mockStore.On("GetUser", mock.Anything, &store.FindUser{ID: &claims.UserID}).Return(&store.User{ID: 7, Username: "alice", Role: store.RoleUser}, nil).Once()
+	resp, err := authService.ValidateToken(context.Background(), &v1pb.ValidateTokenRequest{Token: accessToken})
+	require.NoError(t, err)
+	assert.True(t, resp.Valid)
+	assert.Equal(t, int64(7), resp.UserId)
+	assert.Equal(t, "alice", resp.Username)
+	assert.Equal(t, string(store.RoleUser), resp.Role)
+	assert.Equal(t, claims.ExpiresAt.Unix(), resp.ExpiresAt.AsTime().Unix())
+
+	// Tokens of deleted users are invalid
+	mockStore.On("GetUser", mock.Anything, &store.FindUser{ID: &claims.UserID}).Return(nil, sql.ErrNoRows).Once()
+	resp, err = authService.ValidateToken(context.Background(), &v1pb.ValidateTokenRequest{Token: accessToken})
+	require.NoError(t, err)
+	assert.Equal(t, &v1pb.ValidateTokenResponse{Valid: false}, resp)
+
+	// Other failures are not reported as invalid tokens
+	mockStore.On("GetUser", mock.Anything, &store.FindUser{ID: &claims.UserID}).Return(nil, errors.New("database is down")).Once()
+	_, err = authService.ValidateToken(context.Background(), &v1pb.ValidateTokenRequest{Token: accessToken})
+	assert.Equal(t, connect.CodeInternal, connect.CodeOf(err))
+
+	// Revoking the session invalidates its access tokens
+	mockStore.On("IsTokenRevoked", mock.Anything, "session-1").Return(true, nil)
+	resp, err = authService.ValidateToken(context.Background(), &v1pb.ValidateTokenRequest{Token: accessToken})
+	require.NoError(t, err)
+	assert.Equal(t, &v1pb.ValidateTokenResponse{Valid: false}, resp)
+
+	resp, err = authService.ValidateToken(context.Background(), &v1pb.ValidateTokenRequest{Token: "invalid"})
+	require.NoError(t, err)
+	assert.False(t, resp.Valid)
+}
+
+func TestAuthService_RefreshTokenReuse(t *testing.T) {
+	// Create mock store
+	mockStore := new(MockStore)
+
+	// A rotated (revoked) token from family "family-1" is presented again
+	familyID := "family-1"
+	userID := int64(1)
+	mockStore.On("GetRefreshToken", mock.Anything, "rotatedtoken").Return(&store.RefreshToken{
+		ID:        1,
+		UserID:    userID,
+		Token:     "rotatedtoken",
+		FamilyID:  familyID,
+		Revoked:   true,
+		ExpiresAt: time.Now().AddDate(0, 0, 7),
+	}, nil)
+	mockStore.On("ListRefreshTokens", mock.Anything, &store.FindRefreshToken{UserID: &userID, FamilyID: &familyID}).Return([]*store.RefreshToken{
+		{ID: 1, UserID: userID, FamilyID: familyID, Revoked: true},
+		{ID: 2, UserID: userID, FamilyID: familyID},
+	}, nil)
+	mockStore.On("UpdateRefreshToken", mock.Anything, mock.MatchedBy(func(update *store.UpdateRefreshToken) bool {
+		return update.ID == 2 && update.Revoked != nil && *update.Revoked
+	})).Return(&store.RefreshToken{ID: 2, UserID: userID, FamilyID: familyID, Revoked: true}, nil)
+	mockStore.On("CreateRevokedToken", mock.Anything, mock.MatchedBy(func(create *store.CreateRevokedToken) bool {
+		return create.JTI == familyID && create.UserID == userID
+	})).Return(&store.RevokedToken{ID: 1, JTI: familyID, UserID: userID}, nil)
+	mockStore.On("CreateSecurityEvent", mock.Anything, mock.MatchedBy(func(create *store.CreateSecurityEvent) bool {
+		return create.UserID == userID && create.Type == store.SecurityEventRefreshTokenReuse
+	})).Return(&store.SecurityEvent{ID: 1}, nil)
+
+	// Create auth service
+	authService := NewAuthService("testsecret", mockStore)
+
+	// Test RefreshToken with the reused token
+	resp, err := authService.RefreshToken(context.Background(), &v1pb.RefreshTokenRequest{RefreshToken: "rotatedtoken"})
+	assert.Error(t, err)
+	assert.Nil(t, resp)
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+	// Verify mock calls
+	mockStore.AssertExpectations(t)
+	mockStore.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_RefreshTokenConcurrentReuse(t *testing.T) {
+	mockStore := new(MockStore)
+
+	// Another request rotated the token after it was read
+	familyID := "family-1"
+	userID := int64(1)
+	mockStore.On("GetRefreshToken", mock.Anything, "refreshtoken").Return(&store.RefreshToken{
+		ID:        1,
+		UserID:    userID,
+		Token:     "refreshtoken",
+		FamilyID:  familyID,
+		ExpiresAt: time.Now().AddDate(0, 0, 7),
+	}, nil)
+	mockStore.On("GetUser", mock.Anything, &store.FindUser{ID: &userID}).Return(&store.User{ID: userID, Username: "testuser", Role: store.RoleUser}, nil)
+	mockStore.On("RevokeRefreshToken", mock.Anything, int64(1)).Return(false, nil)
+	mockStore.On("ListRefreshTokens", mock.Anything, &store.FindRefreshToken{UserID: &userID, FamilyID: &familyID}).Return([]*store.RefreshToken{
+		{ID: 1, UserID: userID, FamilyID: familyID, Revoked: true},
+		{ID: 2, UserID: userID, FamilyID: familyID},
+	}, nil)
+	mockStore.On("UpdateRefreshToken", mock.Anything, mock.MatchedBy(func(update *store.UpdateRefreshToken) bool {
+		return update.ID == 2 && update.Revoked != nil && *update.Revoked
+	})).Return(&store.RefreshToken{ID: 2, UserID: userID, FamilyID: familyID, Revoked: true}, nil)
+	mockStore.On("CreateRevokedToken", mock.Anything, mock.MatchedBy(func(create *store.CreateRevokedToken) bool {
+		return create.JTI == familyID && create.UserID == userID
+	})).Return(&store.RevokedToken{ID: 1, JTI: familyID, UserID: userID}, nil)
+	mockStore.On("CreateSecurityEvent", mock.Anything, mock.MatchedBy(func(create *store.CreateSecurityEvent) bool {
+		return create.UserID == userID && create.Type == store.SecurityEventRefreshTokenReuse
+	})).Return(&store.SecurityEvent{ID: 1}, nil)
+
+	authService := NewAuthService("testsecret", mockStore)
+
+	// The request that lost the rotation revokes the family
+	resp, err := authService.RefreshToken(context.Background(), &v1pb.RefreshTokenRequest{RefreshToken: "refreshtoken"})
+	assert.Nil(t, resp)
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	mockStore.AssertExpectations(t)
+	mockStore.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_LoginWithMFA(t *testing.T) {
+	// Create mock store
+	mockStore := new(MockStore)
+	mockStore.On("GetUserIdentity", mock.Anything, mock.AnythingOfType("*store.FindUserIdentity")).Return(nil, nil)
+
+	secret := "testsecret"
+	totpSecret, err := auth.GenerateTOTPSecret()
+	require.NoError(t, err)
+	encryptedSecret, err := auth.EncryptTOTPSecret(totpSecret, secret)
+	require.NoError(t, err)
+	encryptedRecoveryCodes, err := encryptRecoveryCodes([]string{"aaaaa-bbbbb", "ccccc-ddddd"}, secret)
+	require.NoError(t, err)
+	credential := &store.TOTPCredential{
+		ID:            1,
+		UserID:        1,
+		Secret:        encryptedSecret,
+		RecoveryCodes: encryptedRecoveryCodes,
+		Enabled:       true,
+	}
+
+	// Mock responses
+	passwordHash, _ := auth.HashPassword("testpassword")
+	user := &store.User{
+		ID:              1,
+		Username:        "testuser",
+		Password:        passwordHash,
+		Role:            store.RoleUser,
+		PasswordExpires: time.Now().AddDate(0, 0, 90),
+	}
+	mockStore.On("GetUserByUsername", mock.Anything, "testuser").Return(user, nil)
+	mockStore.On("GetUser", mock.Anything, mock.AnythingOfType("*store.FindUser")).Return(user, nil)
+	mockStore.On("GetTOTPCredential", mock.Anything, mock.AnythingOfType("*store.FindTOTPCredential")).Return(credential, nil)
+	mockStore.On("CreateRevokedToken", mock.Anything, mock.AnythingOfType("*store.CreateRevokedToken")).Return(&store.RevokedToken{ID: 1}, nil)
+	mockStore.On("UpdateTOTPCredential", mock.Anything, mock.AnythingOfType("*store.UpdateTOTPCredential")).Return(credential, nil)
+	mockStore.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("*store.CreateRefreshToken")).Return(&store.RefreshToken{ID: 1, UserID: 1}, nil)
+	mockStore.On("GetInstanceJWTSigningKeySetting", mock.Anything).Return(newSigningKeySetting(t, "testsecret"), nil)
+	mockStore.On("GetLoginAttempt", mock.Anything, mock.AnythingOfType("*store.FindLoginAttempt")).Return(nil, nil)
+	mockStore.On("GetInstanceSecuritySetting", mock.Anything).Return(&storepb.InstanceSecuritySetting{}, nil)
+	mockStore.On("RecordLoginFailure", mock.Anything, mock.AnythingOfType("*store.RecordLoginFailure")).Return(&store.LoginAttempt{ID: 1, Scope: store.LoginAttemptScopeAccount, FailedCount: 1}, nil)
+	mockStore.On("UpdateLoginAttempt", mock.Anything, mock.AnythingOfType("*store.UpdateLoginAttempt")).Return(&store.LoginAttempt{ID: 1}, nil)
+	mockStore.On("DeleteLoginAttempts", mock.Anything, mock.AnythingOfType("*store.DeleteLoginAttempt")).Return(nil)
+
+	// Create auth service
+	authService := NewAuthService(secret, mockStore)
+
+	// The password alone only yields a challenge
+	loginResp, err := authService.Login(context.Background(), &v1pb.LoginRequest{Username: "testuser", Password: "testpassword"})
+	require.NoError(t, err)
+	assert.True(t, loginResp.MfaRequired)
+	assert.NotEmpty(t, loginResp.MfaToken)
+	assert.Empty(t, loginResp.AccessToken)
+	assert.Empty(t, loginResp.RefreshToken)
+	mockStore.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+
+	// A wrong code consumes the challenge
+	claims, err := auth.ValidateMFAChallengeToken(loginResp.MfaToken, secret)
+	require.NoError(t, err)
+	mockStore.On("IsTokenRevoked", mock.Anything, claims.ID).Return(false, nil).Once()
+	_, err = authService.VerifyMFA(context.Background(), &v1pb.VerifyMFARequest{MfaToken: loginResp.MfaToken, Code: "000000"})
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	mockStore.AssertCalled(t, "RecordLoginFailure", mock.Anything, mock.AnythingOfType("*store.RecordLoginFailure"))
+	mockStore.On("IsTokenRevoked", mock.Anything, claims.ID).Return(true, nil).Once()
+	code, err := auth.GenerateTOTPCode(totpSecret, time.Now())
+	require.NoError(t, err)
+	_, err = authService.VerifyMFA(context.Background(), &v1pb.VerifyMFARequest{MfaToken: loginResp.MfaToken, Code: code})
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+	// A new challenge with a valid code completes the login
+	loginResp, err = authService.Login(context.Background(), &v1pb.LoginRequest{Username: "testuser", Password: "testpassword"})
+	require.NoError(t, err)
+	claims, err = auth.ValidateMFAChallengeToken(loginResp.MfaToken, secret)
+	require.NoError(t, err)
+	mockStore.On("IsTokenRevoked", mock.Anything, claims.ID).Return(false, nil).Once()
+	resp, err := authService.VerifyMFA(context.Background(), &v1pb.VerifyMFARequest{MfaToken: loginResp.MfaToken, Code: code})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.AccessToken)
+	assert.NotEmpty(t, resp.RefreshToken)
+	assert.Equal(t, int64(1), resp.User.Id)
+
+	// Recovery codes are accepted as well and removed once used
+	loginResp, err = authService.Login(context.Background(), &v1pb.LoginRequest{Username: "testuser", Password: "testpassword"})
+	require.NoError(t, err)
+	claims, err = auth.ValidateMFAChallengeToken(loginResp.MfaToken, secret)
+	require.NoError(t, err)
+	mockStore.On("IsTokenRevoked", mock.Anything, claims.ID).Return(false, nil).Once()
+	_, err = authService.VerifyMFA(context.Background(), &v1pb.VerifyMFARequest{MfaToken: loginResp.MfaToken, Code: "ccccc-ddddd"})
+	require.NoError(t, err)
+	mockStore.AssertCalled(t, "UpdateTOTPCredential", mock.Anything, mock.MatchedBy(func(update *store.UpdateTOTPCredential) bool {
+		if update.RecoveryCodes == nil {
+			return false
+		}
+		codes, err := decryptRecoveryCodes(context.Background(), mockStore, *update.RecoveryCodes, secret)
+		return err == nil && len(codes) == 1 && codes[0] == "aaaaa-bbbbb"
+	}))
+}
+
+func TestAuthService_VerifyMFADuringSecretRotation(t *testing.T) {
+	mockStore := new(MockStore)
+	mockStore.On("GetUserIdentity", mock.Anything, mock.AnythingOfType("*store.FindUserIdentity")).Return(nil, nil)
+	passwordHash, _ := auth.HashPassword("testpassword")
+	user := &store.User{
+		ID:              1,
+		Username:        "testuser",
+		Password:        passwordHash,
+		Role:            store.RoleUser,
+		PasswordExpires: time.Now().AddDate(0, 0, 90),
+	}
+	mockStore.On("GetUserByUsername", mock.Anything, "testuser").Return(user, nil)
+	mockStore.On("GetUser", mock.Anything, mock.AnythingOfType("*store.FindUser")).Return(user, nil)
+	mockStore.On("IsTokenRevoked", mock.Anything, mock.Anything).Return(false, nil)
+	mockStore.On("CreateRevokedToken", mock.Anything, mock.AnythingOfType("*store.CreateRevokedToken")).Return(&store.RevokedToken{ID: 1}, nil)
+	mockStore.On("UpdateTOTPCredential", mock.Anything, mock.AnythingOfType("*store.UpdateTOTPCredential")).Return(&store.TOTPCredential{ID: 1}, nil)
+	mockStore.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("*store.CreateRefreshToken")).Return(&store.RefreshToken{ID: 1, UserID: 1}, nil)
+	mockStore.On("GetInstanceJWTSigningKeySetting", mock.Anything).Return(newSigningKeySetting(t, "oldsecret"), nil)
+	mockStore.On("GetLoginAttempt", mock.Anything, mock.AnythingOfType("*store.FindLoginAttempt")).Return(nil, nil)
+	mockStore.On("GetInstanceSecuritySetting", mock.Anything).Return(&storepb.InstanceSecuritySetting{}, nil)
+	mockStore.On("DeleteLoginAttempts", mock.Anything, mock.AnythingOfType("*store.DeleteLoginAttempt")).Return(nil)
+	// The key was rotated after the signing key was generated, one server has been restarted with it and the other not yet
+	mockStore.On("GetInstanceBasicSetting", mock.Anything).Return(&storepb.InstanceBasicSetting{SecretKey: "newsecret", PreviousSecretKey: "oldsecret"}, nil)
+	restarted, running := NewAuthService("newsecret", mockStore), NewAuthService("oldsecret", mockStore)
+
+	totpSecret, err := auth.GenerateTOTPSecret()
+	require.NoError(t, err)
+	for _, tc := range []struct {
+		name            string
+		login, verify   *AuthService
+		encryptedSecret string
+	}{
+		{"enrolled before the rotation", running, restarted, "oldsecret"},
+		{"enrolled on a restarted server", restarted, running, "newsecret"},
+	} {
+		encryptedSecret, err := auth.EncryptTOTPSecret(totpSecret, tc.encryptedSecret)
+		require.NoError(t, err)
+		mockStore.On("GetTOTPCredential", mock.Anything, mock.AnythingOfType("*store.FindTOTPCredential")).Return(&store.TOTPCredential{
+			ID: 1, UserID: 1, Secret: encryptedSecret, Enabled: true,
+		}, nil).Once()
+		loginResp, err := tc.login.Login(context.Background(), &v1pb.LoginRequest{Username: "testuser", Password: "testpassword"})
+		require.NoError(t, err, tc.name)
+		require.True(t, loginResp.MfaRequired, tc.name)
+
+		// The challenge and the credential are accepted by the other server
+		mockStore.On("GetTOTPCredential", mock.Anything, mock.AnythingOfType("*store.FindTOTPCredential")).Return(&store.TOTPCredential{
+			ID: 1, UserID: 1, Secret: encryptedSecret, Enabled: true,
+		}, nil).Once()
+		code, err := auth.GenerateTOTPCode(totpSecret, time.Now())
+		require.NoError(t, err)
+		resp, err := tc.verify.VerifyMFA(context.Background(), &v1pb.VerifyMFARequest{MfaToken: loginResp.MfaToken, Code: code})
+		require.NoError(t, err, tc.name)
+		assert.NotEmpty(t, resp.AccessToken, tc.name)
+	}
+}
+
+func TestAuthService_LoginLockout(t *testing.T) {
+	// Create mock store
+	mockStore := new(MockStore)
+
+	passwordHash, _ := auth.HashPassword("testpassword")
+	user := &store.User{
+		ID:              1,
+		Username:        "testuser",
+		Password:        passwordHash,
+		Role:            store.RoleUser,
+		PasswordExpires: time.Now().AddDate(0, 0, 90),
+	}
+	mockStore.On("GetUserByUsername", mock.Anything, "testuser").Return(user, nil)
+	mockStore.On("GetUserByUsername", mock.Anything, "nobody").Return(nil, nil)
+	mockStore.On("GetInstanceSecuritySetting", mock.Anything).Return(&storepb.InstanceSecuritySetting{
+		AccountLockout: &storepb.AccountLockoutPolicy{MaxAccountFailures: 3},
+	}, nil)
+	mockStore.On("UpdateLoginAttempt", mock.Anything, mock.AnythingOfType("*store.UpdateLoginAttempt")).Return(&store.LoginAttempt{ID: 1}, nil)
+	mockStore.On("CreateSecurityEvent", mock.Anything, mock.AnythingOfType("*store.CreateSecurityEvent")).Return(&store.SecurityEvent{ID: 1}, nil)
+
+	// Create auth service
+	authService := NewAuthService("testsecret", mockStore)
+
+	// Unknown usernames and wrong passwords fail the same way and are both counted
+	mockStore.On("GetLoginAttempt", mock.Anything, mock.AnythingOfType("*store.FindLoginAttempt")).Return(nil, nil).Once()
+	mockStore.On("RecordLoginFailure", mock.Anything, mock.AnythingOfType("*store.RecordLoginFailure")).Return(&store.LoginAttempt{
+		ID: 1, Scope: store.LoginAttemptScopeAccount, Identifier: "nobody", FailedCount: 1, LastFailedAt: time.Now(),
+	}, nil).Once()
+	_, unknownErr := authService.Login(context.Background(), &v1pb.LoginRequest{Username: "nobody", Password: "testpassword"})
+	mockStore.On("GetLoginAttempt", mock.Anything, mock.AnythingOfType("*store.FindLoginAttempt")).Return(nil, nil).Once()
+	mockStore.On("RecordLoginFailure", mock.Anything, mock.AnythingOfType("*store.RecordLoginFailure")).Return(&store.LoginAttempt{
+		ID: 2, Scope: store.LoginAttemptScopeAccount, Identifier: "testuser", FailedCount: 3, LastFailedAt: time.Now(),
+	}, nil).Once()
+	_, wrongErr := authService.Login(context.Background(), &v1pb.LoginRequest{Username: "testuser", Password: "wrongpassword"})
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(unknownErr))
+	assert.Equal(t, unknownErr.Error(), wrongErr.Error())
+
+	// Reaching the threshold locks the account and records a security event
+	mockStore.AssertCalled(t, "UpdateLoginAttempt", mock.Anything, mock.MatchedBy(func(update *store.UpdateLoginAttempt) bool {
+		return update.ID == 2 && update.LockedUntil != nil && time.Until(*update.LockedUntil) > 14*time.Minute
+	}))
+	mockStore.AssertCalled(t, "CreateSecurityEvent", mock.Anything, mock.MatchedBy(func(create *store.CreateSecurityEvent) bool {
+		return create.UserID == 1 && create.Type == store.SecurityEventAccountLocked
+	}))
+
+	// A locked account is refused even with the right password
+	lockedUntil := time.Now().Add(15 * time.Minute)
+	mockStore.On("GetLoginAttempt", mock.Anything, mock.AnythingOfType("*store.FindLoginAttempt")).Return(&store.LoginAttempt{
+		ID: 2, Scope: store.LoginAttemptScopeAccount, Identifier: "testuser", FailedCount: 3, LockedUntil: &lockedUntil,
+	}, nil).Once()
+	_, err := authService.Login(context.Background(), &v1pb.LoginRequest{Username: "testuser", Password: "testpassword"})
+	assert.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	assert.Equal(t, "900", connectErr.Meta().Get("Retry-After"))
+	mockStore.AssertNumberOfCalls(t, "RecordLoginFailure", 2)
+}
+
+func TestAuthService_LoginLockoutForgedForwardedFor(t *testing.T) {
+	mockStore := new(MockStore)
+	mockStore.On("GetUserByUsername", mock.Anything, "nobody").Return(nil, nil)
+	mockStore.On("GetInstanceSecuritySetting", mock.Anything).Return(&storepb.InstanceSecuritySetting{}, nil)
+	mockStore.On("GetLoginAttempt", mock.Anything, mock.AnythingOfType("*store.FindLoginAttempt")).Return(nil, nil)
+	mockStore.On("RecordLoginFailure", mock.Anything, mock.AnythingOfType("*store.RecordLoginFailure")).Return(&store.LoginAttempt{ID: 1, FailedCount: 1, LastFailedAt: time.Now()}, nil)
+	mockStore.On("UpdateLoginAttempt", mock.Anything, mock.AnythingOfType("*store.UpdateLoginAttempt")).Return(&store.LoginAttempt{ID: 1}, nil)
+	authService := NewAuthService("testsecret", mockStore)
+
+	// Each attempt claims another origin, the gateway appends the peer address
+	for _, forged := range []string{"203.0.113.1", "203.0.113.2", "192.0.2.10"} {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", forged+", 198.51.100.7"))
+		_, err := authService.Login(ctx, &v1pb.LoginRequest{Username: "nobody", Password: "wrongpassword"})
+		assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	}
+
+	// The attempts are all counted against the peer address
+	ipAddresses := []string{}
+	for _, call := range mockStore.Calls {
+		if call.Method != "RecordLoginFailure" {
+			continue
+		}
+		if record := call.Arguments.Get(1).(*store.RecordLoginFailure); record.Scope == store.LoginAttemptScopeIP {
+			ipAddresses = append(ipAddresses, record.Identifier)
+		}
+	}
+	assert.Equal(t, []string{"198.51.100.7", "198.51.100.7", "198.51.100.7"}, ipAddresses)
+}
+
+func TestAuthService_PasswordResetWithoutMailServer(t *testing.T) {
+	mockStore := new(MockStore)
+	authService := NewAuthService("testsecret", mockStore)
+
+	// No token is created when it cannot be delivered
+	_, err := authService.RequestPasswordReset(context.Background(), &v1pb.RequestPasswordResetRequest{Email: "test@example.com"})
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+	mockStore.AssertNotCalled(t, "GetUserByEmail", mock.Anything, mock.Anything)
+	mockStore.AssertNotCalled(t, "CreatePasswordResetToken", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_PasswordReset(t *testing.T) {
+	mockStore := new(MockStore)
+	notifier := notify.NewMemoryNotifier()
+	authService := NewAuthService("testsecret", mockStore)
+	authService.Notifier = notifier
+	mockStore.On("GetUserIdentity", mock.Anything, mock.AnythingOfType("*store.FindUserIdentity")).Return(nil, nil)
+	ctx := context.Background()
+
+	oldHash, err := auth.HashPassword("old-password-1")
+	require.NoError(t, err)
+	user := &store.User{
+		ID:              1,
+		Username:        "testuser",
+		Email:           "test@example.com",
+		Password:        oldHash,
+		Nickname:        "Test User",
+		Role:            store.RoleUser,
+		PasswordExpires: time.Now().Add(-time.Hour),
+	}
+
+	// Unknown addresses get the same response but no email
+	mockStore.On("GetUserByEmail", mock.Anything, "nobody@example.com").Return(nil, nil)
+	_, err = authService.RequestPasswordReset(ctx, &v1pb.RequestPasswordResetRequest{Email: "nobody@example.com"})
+	require.NoError(t, err)
+	assert.Empty(t, notifier.Messages())
+
+	// Only the digest of the emailed token is stored
+	var created *store.CreatePasswordResetToken
+	mockStore.On("GetUserByEmail", mock.Anything, user.Email).Return(user, nil)
+	mockStore.On("GetPasswordResetToken", mock.Anything, &store.FindPasswordResetToken{UserID: &user.ID}).Return(nil, nil).Once()
+	mockStore.On("DeletePasswordResetTokens", mock.Anything, &store.DeletePasswordResetToken{UserID: user.ID}).Return(nil)
+	mockStore.On("CreatePasswordResetToken", mock.Anything, mock.AnythingOfType("*store.CreatePasswordResetToken")).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*store.CreatePasswordResetToken)
+	}).Return(&store.PasswordResetToken{ID: 1, UserID: user.ID}, nil)
+	_, err = authService.RequestPasswordReset(ctx, &v1pb.RequestPasswordResetRequest{Email: user.Email})
+	require.NoError(t, err)
+	messages := notifier.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, user.Email, messages[0].To)
+	require.NotNil(t, created)
+	assert.WithinDuration(t, time.Now().Add(passwordResetTokenDuration), created.ExpiresAt, time.Minute)
+	var token string
+	for _, field := range strings.Fields(messages[0].Body) {
+		if auth.HashToken(field) == created.TokenHash {
+			token = field
+		}
+	}
+	require.NotEmpty(t, token, "the email carries the token")
+
+	// Another request right away does not send a second email
+	resetToken := &store.PasswordResetToken{ID: 1, UserID: user.ID, TokenHash: created.TokenHash, ExpiresAt: created.ExpiresAt, CreatedAt: time.Now()}
+	mockStore.On("GetPasswordResetToken", mock.Anything, &store.FindPasswordResetToken{UserID: &user.ID}).Return(resetToken, nil)
+	_, err = authService.RequestPasswordReset(ctx, &v1pb.RequestPasswordResetRequest{Email: user.Email})
+	require.NoError(t, err)
+	assert.Len(t, notifier.Messages(), 1)
+
+	// Unknown tokens are rejected
+	unknownHash := auth.HashToken("unknown")
+	mockStore.On("GetPasswordResetToken", mock.Anything, &store.FindPasswordResetToken{TokenHash: &unknownHash}).Return(nil, nil)
+	_, err = authService.ConfirmPasswordReset(ctx, &v1pb.ConfirmPasswordResetRequest{Token: "unknown", NewPassword: "new-password-1"})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// The token sets the new password and signs out every session
+	mockStore.On("GetPasswordResetToken", mock.Anything, &store.FindPasswordResetToken{TokenHash: &created.TokenHash}).Return(resetToken, nil)
+	mockStore.On("GetUser", mock.Anything, &store.FindUser{ID: &user.ID}).Return(user, nil)
+	mockStore.On("GetInstancePasswordPolicySetting", mock.Anything).Return(&storepb.InstancePasswordPolicySetting{}, nil)
+	mockStore.On("UsePasswordResetToken", mock.Anything, int64(1)).Return(true, nil).Once()
+	var update *store.UpdateUser
+	mockStore.On("UpdateUser", mock.Anything, mock.AnythingOfType("*store.UpdateUser")).Run(func(args mock.Arguments) {
+		update = args.Get(1).(*store.UpdateUser)
+	}).Return(user, nil)
+	mockStore.On("CreatePasswordHistory", mock.Anything, mock.AnythingOfType("*store.CreatePasswordHistory")).Return(&store.PasswordHistory{ID: 1, UserID: user.ID}, nil)
+	mockStore.On("ListPasswordHistories", mock.Anything, &store.FindPasswordHistory{UserID: user.ID, Limit: 1}).Return([]*store.PasswordHistory{{ID: 1, UserID: user.ID}}, nil)
+	mockStore.On("DeletePasswordHistories", mock.Anything, &store.DeletePasswordHistory{UserID: user.ID, BeforeID: 1}).Return(nil)
+	mockStore.On("ListRefreshTokens", mock.Anything, &store.FindRefreshToken{UserID: &user.ID}).Return([]*store.RefreshToken{
+		{ID: 1, UserID: user.ID, FamilyID: "family-1", ExpiresAt: time.Now().Add(time.Hour)},
+	}, nil)
+	familyID := "family-1"
+	mockStore.On("ListRefreshTokens", mock.Anything, &store.FindRefreshToken{UserID: &user.ID, FamilyID: &familyID}).Return([]*store.RefreshToken{
+		{ID: 1, UserID: user.ID, FamilyID: familyID, ExpiresAt: time.Now().Add(time.Hour)},
+	}, nil)
+	mockStore.On("UpdateRefreshToken", mock.Anything, mock.AnythingOfType("*store.UpdateRefreshToken")).Return(&store.RefreshToken{ID: 1, Revoked: true}, nil)
+	mockStore.On("CreateRevokedToken", mock.Anything, mock.AnythingOfType("*store.CreateRevokedToken")).Return(&store.RevokedToken{ID: 1}, nil)
+	mockStore.On("DeleteLoginAttempts", mock.Anything, &store.DeleteLoginAttempt{Scope: store.LoginAttemptScopeAccount, Identifier: user.Username}).Return(nil)
+	mockStore.On("CreateSecurityEvent", mock.Anything, mock.MatchedBy(func(create *store.CreateSecurityEvent) bool {
+		return create.Type == store.SecurityEventPasswordReset
+	})).Return(&store.SecurityEvent{ID: 1}, nil)
+
+	_, err = authService.ConfirmPasswordReset(ctx, &v1pb.ConfirmPasswordResetRequest{Token: token, NewPassword: "new-password-1"})
+	require.NoError(t, err)
+	require.NotNil(t, update)
+	assert.True(t, auth.CheckPassword("new-password-1", *update.Password))
+	assert.True(t, update.PasswordExpires.After(time.Now()))
+	mockStore.AssertCalled(t, "UpdateRefreshToken", mock.Anything, mock.AnythingOfType("*store.UpdateRefreshToken"))
+
+	// The token cannot be used twice, even by a concurrent request
+	mockStore.On("UsePasswordResetToken", mock.Anything, int64(1)).Return(false, nil)
+	_, err = authService.ConfirmPasswordReset(ctx, &v1pb.ConfirmPasswordResetRequest{Token: token, NewPassword: "new-password-2"})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestAuthService_LoginRequiresVerifiedEmail(t *testing.T) {
+	passwordHash, err := auth.HashPassword("testpassword")
+	require.NoError(t, err)
+	for _, setting := range []*storepb.InstanceSecuritySetting{
+		{RequireEmailVerification: true},
+		// Anyone can register with an address of the allowed domains
+		{Registration: &storepb.RegistrationPolicy{Mode: storepb.RegistrationPolicy_ALLOWED_EMAIL_DOMAINS, AllowedEmailDomains: []string{"example.com"}}},
+	} {
+		mockStore := new(MockStore)
+		authService := NewAuthService("testsecret", mockStore)
+		mockStore.On("GetUserIdentity", mock.Anything, mock.AnythingOfType("*store.FindUserIdentity")).Return(nil, nil)
+		mockStore.On("GetUserByUsername", mock.Anything, "testuser").Return(&store.User{
+			ID:              1,
+			Username:        "testuser",
+			Email:           "test@example.com",
+			Password:        passwordHash,
+			Role:            store.RoleUser,
+			PasswordExpires: time.Now().AddDate(0, 0, 90),
+		}, nil)
+		mockStore.On("GetLoginAttempt", mock.Anything, mock.AnythingOfType("*store.FindLoginAttempt")).Return(nil, nil)
+		mockStore.On("GetInstanceSecuritySetting", mock.Anything).Return(setting, nil)
+
+		_, err = authService.Login(context.Background(), &v1pb.LoginRequest{Username: "testuser", Password: "testpassword"})
+		assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err), setting.String())
+		mockStore.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+	}
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/store"
+)
+
+// ErrInvalidCredentials is returned by a CredentialVerifier that does not accept a username
+// and password, Login then asks the next verifier.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// CredentialVerifier checks the username and password of a Login request. Login asks the
+// verifiers of AuthService in order and signs in the user of the first one that accepts them.
+type CredentialVerifier interface {
+	// VerifyCredentials returns the user the credentials belong to, or ErrInvalidCredentials.
+	// Other errors end the login, they should be connect errors.
+	VerifyCredentials(ctx context.Context, username, password string) (*store.User, error)
+}
+
+// LocalVerifierStore is an interface that defines the methods needed by LocalVerifier
+type LocalVerifierStore interface {
+	directoryUserStore
+	GetUserByUsername(ctx context.Context, username string) (*store.User, error)
+}
+
+// LocalVerifier checks passwords against the hashes stored with users. It does not accept
+// directory users, their passwords are checked by the directory.
+type LocalVerifier struct {
+	Store LocalVerifierStore
+}
+
+func NewLocalVerifier(store LocalVerifierStore) *LocalVerifier {
+	return &LocalVerifier{Store: store}
+}
+
+func (v *LocalVerifier) VerifyCredentials(ctx context.Context, username, password string) (*store.User, error) {
+	user, err := v.Store.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user"))
+	}
+	if user == nil {
+		// Takes as long as a wrong password, so that usernames cannot be probed.
+		auth.CheckPassword(password, dummyPasswordHash())
+		return nil, ErrInvalidCredentials
+	}
+	if !auth.CheckPassword(password, user.Password) {
+		return nil, ErrInvalidCredentials
+	}
+	directoryUser, err := isDirectoryUser(ctx, v.Store, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if directoryUser {
+		return nil, ErrInvalidCredentials
+	}
+	if time.Now().After(user.PasswordExpires) {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("password expired, request a password reset to set a new one"))
+	}
+	return user, nil
+}
+
+// verifyCredentials asks the verifiers in order until one accepts the credentials.
+func (s *AuthService) verifyCredentials(ctx context.Context, username, password string) (*store.User, error) {
+	for _, verifier := range s.Verifiers {
+		user, err := verifier.VerifyCredentials(ctx, username, password)
+		if errors.Is(err, ErrInvalidCredentials) {
+			continue
+		}
+		return user, err
+	}
+	return nil, ErrInvalidCredentials
+}
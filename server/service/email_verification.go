@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"connectrpc.com/connect"
+
+	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/server/notify"
+	"github.com/pixb/go-server/store"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// emailVerificationTokenDuration is how long an email verification token can be used.
+	emailVerificationTokenDuration = 24 * time.Hour
+	// emailVerificationInterval is the least time between two verification emails to the same address.
+	emailVerificationInterval = time.Minute
+)
+
+// emailVerificationStore is the subset of the store needed to verify email addresses.
+type emailVerificationStore interface {
+	CreateEmailVerification(ctx context.Context, create *store.CreateEmailVerification) (*store.EmailVerification, error)
+	GetEmailVerification(ctx context.Context, find *store.FindEmailVerification) (*store.EmailVerification, error)
+	DeleteEmailVerifications(ctx context.Context, delete *store.DeleteEmailVerification) error
+}
+
+// sendEmailVerification replaces the pending verification of the user with one for email,
+// which is either their current address or the new one of an email change, and mails its token.
+func sendEmailVerification(ctx context.Context, s emailVerificationStore, notifier notify.Notifier, user *store.User, email string) error {
+	token, err := auth.GenerateOneTimeToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate email verification token: %w", err)
+	}
+	if err := s.DeleteEmailVerifications(ctx, &store.DeleteEmailVerification{UserID: user.ID}); err != nil {
+		return err
+	}
+	expiresAt := time.Now().Add(emailVerificationTokenDuration)
+	if _, err := s.CreateEmailVerification(ctx, &store.CreateEmailVerification{
+		UserID:    user.ID,
+		Email:     email,
+		TokenHash: auth.HashToken(token),
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return err
+	}
+
+	return notifier.Send(ctx, &notify.Message{
+		To:      email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nUse the following token to verify your email address:\n\n%s\n\nThe token expires at %s. If you did not expect this email, you can ignore it.\n",
+			user.Nickname, token, expiresAt.UTC().Format(time.RFC1123)),
+	})
+}
+
+func (s *UserService) VerifyEmail(ctx context.Context, req *v1pb.VerifyEmailRequest) (*v1pb.VerifyEmailResponse, error) {
+	if req.Token == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("token is required"))
+	}
+
+	tokenHash := auth.HashToken(req.Token)
+	verification, err := s.Store.GetEmailVerification(ctx, &store.FindEmailVerification{TokenHash: &tokenHash})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get email verification"))
+	}
+	if verification == nil || time.Now().After(verification.ExpiresAt) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid or expired verification token"))
+	}
+
+	users, err := s.Store.ListUsers(ctx, &store.FindUser{ID: &verification.UserID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user"))
+	}
+	if len(users) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid or expired verification token"))
+	}
+	user := users[0]
+
+	emailVerified := true
+	update := &store.UpdateUser{
+		ID:            user.ID,
+		EmailVerified: &emailVerified,
+	}
+	// A pending email change takes effect now.
+	if verification.Email != user.Email {
+		existingUser, err := s.Store.GetUserByEmail(ctx, verification.Email)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user"))
+		}
+		if existingUser != nil {
+			return nil, connect.NewError(connect.CodeAlreadyExists, errors.New("email already exists"))
+		}
+		update.Email = &verification.Email
+	}
+
+	updatedUser, err := s.Store.UpdateUser(ctx, update)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to update user"))
+	}
+	if err := s.Store.DeleteEmailVerifications(ctx, &store.DeleteEmailVerification{UserID: user.ID}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to delete email verifications"))
+	}
+
+	return &v1pb.VerifyEmailResponse{
+		User: &v1pb.User{
+			Id:                updatedUser.ID,
+			Username:          updatedUser.Username,
+			Email:             updatedUser.Email,
+			Nickname:          updatedUser.Nickname,
+			Phone:             updatedUser.Phone,
+			Role:              auth.StringToRole(updatedUser.Role),
+			PasswordExpiresAt: timestamppb.New(updatedUser.PasswordExpires),
+			CreatedAt:         timestamppb.New(updatedUser.CreatedAt),
+			UpdatedAt:         timestamppb.New(updatedUser.UpdatedAt),
+			EmailVerified:     updatedUser.EmailVerified,
+		},
+	}, nil
+}
+
+func (s *UserService) ResendVerification(ctx context.Context, req *v1pb.ResendVerificationRequest) (*v1pb.ResendVerificationResponse, error) {
+	if req.Email == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("email is required"))
+	}
+
+	// The response does not reveal whether the address belongs to a user.
+	pending, err := s.Store.GetEmailVerification(ctx, &store.FindEmailVerification{Email: &req.Email})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get email verification"))
+	}
+	if pending != nil && time.Since(pending.CreatedAt) < emailVerificationInterval {
+		return &v1pb.ResendVerificationResponse{}, nil
+	}
+
+	user, err := s.Store.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user"))
+	}
+	if user != nil && user.EmailVerified {
+		return &v1pb.ResendVerificationResponse{}, nil
+	}
+	if user == nil {
+		// The address may be the new one of a pending email change.
+		if pending == nil {
+			return &v1pb.ResendVerificationResponse{}, nil
+		}
+		users, err := s.Store.ListUsers(ctx, &store.FindUser{ID: &pending.UserID})
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user"))
+		}
+		if len(users) == 0 {
+			return &v1pb.ResendVerificationResponse{}, nil
+		}
+		user = users[0]
+	}
+
+	if err := sendEmailVerification(ctx, s.Store, s.Notifier, user, req.Email); err != nil {
+		slog.Error("failed to send verification email", slog.Int64("userID", user.ID), slog.Any("error", err))
+	}
+	return &v1pb.ResendVerificationResponse{}, nil
+}
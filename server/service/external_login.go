@@ -0,0 +1,347 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"connectrpc.com/connect"
+
+	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	storepb "github.com/pixb/go-server/proto/gen/store"
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/server/oidc"
+	"github.com/pixb/go-server/store"
+)
+
+// defaultIdentityProviderScopes are requested when a provider has no scopes configured.
+var defaultIdentityProviderScopes = []string{"profile", "email"}
+
+// ExternalLoginPath is where a sign-in with an identity provider starts in the browser.
+func ExternalLoginPath(providerID string) string {
+	return "/auth/oidc/" + url.PathEscape(providerID) + "/login"
+}
+
+// ExternalCallbackPath is the redirect URI to register at an identity provider.
+func ExternalCallbackPath(providerID string) string {
+	return "/auth/oidc/" + url.PathEscape(providerID) + "/callback"
+}
+
+// ExternalIdentity is a user signed in at an external identity provider.
+type ExternalIdentity struct {
+	Provider      string
+	Subject       string
+	Username      string
+	Nickname      string
+	Email         string
+	EmailVerified bool
+	Phone         string
+}
+
+func (s *AuthService) ListLoginProviders(ctx context.Context, req *v1pb.ListLoginProvidersRequest) (*v1pb.ListLoginProvidersResponse, error) {
+	setting, err := s.Store.GetInstanceIdentityProviderSetting(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get identity providers"))
+	}
+	response := &v1pb.ListLoginProvidersResponse{}
+	for _, provider := range setting.Providers {
+		response.Providers = append(response.Providers, &v1pb.LoginProvider{
+			Id:       provider.Id,
+			Title:    provider.Title,
+			LoginUrl: ExternalLoginPath(provider.Id),
+		})
+	}
+	return response, nil
+}
+
+// StartExternalLogin begins a sign-in with an identity provider. It returns the URL of the
+// provider to redirect the user agent to and a state token the user agent has to present
+// to FinishExternalLogin. baseURL is the external URL of this server.
+func (s *AuthService) StartExternalLogin(ctx context.Context, providerID, baseURL, redirectPath string) (string, string, error) {
+	provider, client, err := s.identityProvider(ctx, providerID, baseURL)
+	if err != nil {
+		return "", "", err
+	}
+
+	claims := &auth.ExternalLoginClaims{Provider: provider.Id, RedirectPath: redirectPath}
+	for _, value := range []*string{&claims.State, &claims.Nonce, &claims.CodeVerifier} {
+		if *value, err = oidc.RandomString(); err != nil {
+			return "", "", connect.NewError(connect.CodeInternal, errors.New("failed to generate login state"))
+		}
+	}
+	stateToken, err := auth.GenerateExternalLoginToken(claims, s.Secret)
+	if err != nil {
+		return "", "", connect.NewError(connect.CodeInternal, errors.New("failed to generate login state"))
+	}
+	return client.AuthCodeURL(claims.State, claims.Nonce, claims.CodeVerifier), stateToken, nil
+}
+
+// FinishExternalLogin completes a sign-in with the query parameters of the callback of an
+// identity provider. It returns the login response and the local path the sign-in was
+// started for.
+func (s *AuthService) FinishExternalLogin(ctx context.Context, providerID, baseURL, stateToken string, query url.Values) (*v1pb.LoginResponse, string, error) {
+	claims, err := auth.ValidateExternalLoginToken(stateToken, s.Secret)
+	if err != nil || claims.Provider != providerID || claims.State == "" || claims.State != query.Get("state") {
+		return nil, "", connect.NewError(connect.CodeInvalidArgument, errors.New("invalid or expired login state"))
+	}
+	if providerError := query.Get("error"); providerError != "" {
+		return nil, claims.RedirectPath, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("identity provider returned %s", providerError))
+	}
+	code := query.Get("code")
+	if code == "" {
+		return nil, claims.RedirectPath, connect.NewError(connect.CodeInvalidArgument, errors.New("authorization code is required"))
+	}
+
+	provider, client, err := s.identityProvider(ctx, providerID, baseURL)
+	if err != nil {
+		return nil, claims.RedirectPath, err
+	}
+	token, err := client.Exchange(ctx, code, claims.CodeVerifier)
+	if err != nil {
+		slog.Warn("failed to exchange authorization code", slog.String("provider", provider.Id), slog.Any("error", err))
+		return nil, claims.RedirectPath, connect.NewError(connect.CodeUnauthenticated, errors.New("failed to exchange authorization code"))
+	}
+	idTokenClaims, err := client.VerifyIDToken(ctx, token.IDToken, claims.Nonce)
+	if err != nil {
+		slog.Warn("failed to verify id token", slog.String("provider", provider.Id), slog.Any("error", err))
+		return nil, claims.RedirectPath, connect.NewError(connect.CodeUnauthenticated, errors.New("invalid id token"))
+	}
+
+	response, err := s.LoginWithExternalIdentity(ctx, identityFromClaims(provider, idTokenClaims))
+	return response, claims.RedirectPath, err
+}
+
+// identityProvider looks up a provider and discovers its configuration.
+func (s *AuthService) identityProvider(ctx context.Context, providerID, baseURL string) (*storepb.IdentityProvider, *oidc.Provider, error) {
+	setting, err := s.Store.GetInstanceIdentityProviderSetting(ctx)
+	if err != nil {
+		return nil, nil, connect.NewError(connect.CodeInternal, errors.New("failed to get identity providers"))
+	}
+	index := findIdentityProvider(setting, providerID)
+	if index < 0 {
+		return nil, nil, connect.NewError(connect.CodeNotFound, errors.New("identity provider not found"))
+	}
+	provider := setting.Providers[index]
+
+	scopes := provider.Scopes
+	if len(scopes) == 0 {
+		scopes = defaultIdentityProviderScopes
+	}
+	client, err := oidc.NewProvider(ctx, oidc.Config{
+		Issuer:       provider.Issuer,
+		ClientID:     provider.ClientId,
+		ClientSecret: provider.ClientSecret,
+		Scopes:       scopes,
+		RedirectURL:  strings.TrimSuffix(baseURL, "/") + ExternalCallbackPath(provider.Id),
+	})
+	if err != nil {
+		slog.Warn("failed to discover identity provider", slog.String("provider", provider.Id), slog.Any("error", err))
+		return nil, nil, connect.NewError(connect.CodeUnavailable, errors.New("identity provider is unavailable"))
+	}
+	return provider, client, nil
+}
+
+// identityFromClaims reads an external identity from ID token claims with the claim
+// mapping of the provider.
+func identityFromClaims(provider *storepb.IdentityProvider, claims oidc.Claims) *ExternalIdentity {
+	mapping := provider.GetClaimMapping()
+	claim := func(name, fallback string) string {
+		if name == "" {
+			name = fallback
+		}
+		return strings.TrimSpace(claims.String(name))
+	}
+	identity := &ExternalIdentity{
+		Provider: provider.Id,
+		Subject:  claims.Subject(),
+		Username: claim(mapping.GetUsername(), "preferred_username"),
+		Nickname: claim(mapping.GetNickname(), "name"),
+		Email:    claim(mapping.GetEmail(), "email"),
+	}
+	// Providers only vouch for the address in the standard email claim.
+	if mapping.GetEmail() == "" || mapping.GetEmail() == "email" {
+		identity.EmailVerified = claims.Bool("email_verified")
+	}
+	return identity
+}
+
+// LoginWithExternalIdentity signs in the user linked to an external identity. A new user
+// is provisioned and linked on the first sign-in. Existing accounts are never linked
+// automatically, even with the same email address, since that would let the provider
+// take them over.
+func (s *AuthService) LoginWithExternalIdentity(ctx context.Context, identity *ExternalIdentity) (*v1pb.LoginResponse, error) {
+	user, err := findOrProvisionUser(ctx, s.Store, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkEmailVerified(ctx, user); err != nil {
+		return nil, err
+	}
+	if response, err := s.mfaChallenge(ctx, user); err != nil || response != nil {
+		return response, err
+	}
+	return s.loginResponse(ctx, user)
+}
+
+// identityStore is the part of the store needed to sign in external identities.
+type identityStore interface {
+	GetUser(ctx context.Context, find *store.FindUser) (*store.User, error)
+	GetUserByUsername(ctx context.Context, username string) (*store.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*store.User, error)
+	GetUserIdentity(ctx context.Context, find *store.FindUserIdentity) (*store.UserIdentity, error)
+	CreateUserIdentity(ctx context.Context, create *store.CreateUserIdentity) (*store.UserIdentity, error)
+	GetInstanceSecuritySetting(ctx context.Context) (*storepb.InstanceSecuritySetting, error)
+}
+
+// findOrProvisionUser returns the user linked to an external identity, provisioning one on
+// the first sign-in.
+func findOrProvisionUser(ctx context.Context, s identityStore, identity *ExternalIdentity) (*store.User, error) {
+	if identity.Provider == "" || identity.Subject == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("provider and subject are required"))
+	}
+
+	userIdentity, err := s.GetUserIdentity(ctx, &store.FindUserIdentity{
+		Provider: &identity.Provider,
+		Subject:  &identity.Subject,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user identity"))
+	}
+	if userIdentity == nil {
+		return provisionUser(ctx, s, identity)
+	}
+
+	user, err := s.GetUser(ctx, &store.FindUser{ID: &userIdentity.UserID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("user not found"))
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user"))
+	}
+	return user, nil
+}
+
+// provisionUser creates a user for the first sign-in of an external identity, as allowed by
+// the registration policy.
+func provisionUser(ctx context.Context, s identityStore, identity *ExternalIdentity) (*store.User, error) {
+	if identity.Email == "" {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("identity provider did not return an email address"))
+	}
+	if len(identity.Email) > 100 || !isValidEmail(identity.Email) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("identity provider returned an invalid email address"))
+	}
+	securitySetting, err := s.GetInstanceSecuritySetting(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get security setting"))
+	}
+	if err := checkRegistrationPolicy(securitySetting.GetRegistration(), identity.Email, false); err != nil {
+		return nil, err
+	}
+	// The domain of an address nobody has proven to own admits nobody.
+	if securitySetting.GetRegistration().GetMode() == storepb.RegistrationPolicy_ALLOWED_EMAIL_DOMAINS && !identity.EmailVerified {
+		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("identity provider did not verify the email address"))
+	}
+	existingUser, err := s.GetUserByEmail(ctx, identity.Email)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user"))
+	}
+	if existingUser != nil {
+		return nil, connect.NewError(connect.CodeAlreadyExists, errors.New("an account with this email address already exists"))
+	}
+
+	username, err := availableUsername(ctx, s, identity)
+	if err != nil {
+		return nil, err
+	}
+	nickname := truncate(identity.Nickname, 50)
+	if nickname == "" {
+		nickname = username
+	}
+	// Provisioned users have no usable password until they reset it.
+	password, err := oidc.RandomString()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to generate password"))
+	}
+	passwordHash, err := auth.HashPassword(password)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to hash password"))
+	}
+
+	userIdentity, err := s.CreateUserIdentity(ctx, &store.CreateUserIdentity{
+		User: &store.User{
+			Username:        username,
+			Nickname:        nickname,
+			Password:        passwordHash,
+			Phone:           truncate(identity.Phone, 20),
+			Email:           identity.Email,
+			Role:            store.RoleUser,
+			EmailVerified:   identity.EmailVerified,
+			PasswordExpires: time.Now(),
+		},
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to create user"))
+	}
+	slog.Info("provisioned user for external identity",
+		slog.Int64("userID", userIdentity.UserID),
+		slog.String("provider", identity.Provider))
+
+	user, err := s.GetUser(ctx, &store.FindUser{ID: &userIdentity.UserID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user"))
+	}
+	return user, nil
+}
+
+// availableUsername picks an unused username from the identity, adding a random suffix
+// when the preferred one is taken.
+func availableUsername(ctx context.Context, s identityStore, identity *ExternalIdentity) (string, error) {
+	base := identity.Username
+	if base == "" {
+		base, _, _ = strings.Cut(identity.Email, "@")
+	}
+	base = truncate(base, 45)
+	if len(base) < 3 {
+		base = "user"
+	}
+
+	candidate := base
+	for range 5 {
+		existingUser, err := s.GetUserByUsername(ctx, candidate)
+		if err != nil {
+			return "", connect.NewError(connect.CodeInternal, errors.New("failed to get user"))
+		}
+		if existingUser == nil {
+			return candidate, nil
+		}
+		suffix, err := rand.Int(rand.Reader, big.NewInt(10000))
+		if err != nil {
+			return "", connect.NewError(connect.CodeInternal, errors.New("failed to generate username"))
+		}
+		candidate = fmt.Sprintf("%s-%04d", base, suffix.Int64())
+	}
+	return "", connect.NewError(connect.CodeAlreadyExists, errors.New("username already exists"))
+}
+
+// truncate shortens s to at most n bytes without splitting a character.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	s = s[:n]
+	for len(s) > 0 && !utf8.ValidString(s) {
+		s = s[:len(s)-1]
+	}
+	return s
+}
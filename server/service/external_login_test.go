@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	storepb "github.com/pixb/go-server/proto/gen/store"
+	"github.com/pixb/go-server/server/oidc"
+	"github.com/pixb/go-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newExternalLoginTest(t *testing.T) (*AuthService, *MockStore) {
+	t.Helper()
+	mockStore := new(MockStore)
+	mockStore.On("GetTOTPCredential", mock.Anything, mock.AnythingOfType("*store.FindTOTPCredential")).Return(nil, nil).Maybe()
+	mockStore.On("GetInstanceJWTSigningKeySetting", mock.Anything).Return(newSigningKeySetting(t, "testsecret"), nil).Maybe()
+	mockStore.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("*store.CreateRefreshToken")).Return(&store.RefreshToken{ID: 1}, nil).Maybe()
+	mockStore.On("GetInstanceSecuritySetting", mock.Anything).Return(&storepb.InstanceSecuritySetting{}, nil).Maybe()
+	return NewAuthService("testsecret", mockStore), mockStore
+}
+
+func TestAuthService_LoginWithExternalIdentity_Linked(t *testing.T) {
+	authService, mockStore := newExternalLoginTest(t)
+	identity := &ExternalIdentity{Provider: "example", Subject: "subject-1", Email: "changed@example.com"}
+	user := &store.User{ID: 1, Username: "alice", Email: "alice@example.com", Role: store.RoleUser, EmailVerified: true}
+
+	mockStore.On("GetUserIdentity", mock.Anything, &store.FindUserIdentity{Provider: &identity.Provider, Subject: &identity.Subject}).
+		Return(&store.UserIdentity{ID: 1, UserID: user.ID, Provider: identity.Provider, Subject: identity.Subject}, nil)
+	mockStore.On("GetUser", mock.Anything, &store.FindUser{ID: &user.ID}).Return(user, nil)
+
+	resp, err := authService.LoginWithExternalIdentity(context.Background(), identity)
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.AccessToken)
+	assert.NotEmpty(t, resp.RefreshToken)
+	// The claims of later sign-ins do not change the user
+	assert.Equal(t, "alice@example.com", resp.User.Email)
+	mockStore.AssertNotCalled(t, "CreateUserIdentity", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_LoginWithExternalIdentity_Provisions(t *testing.T) {
+	authService, mockStore := newExternalLoginTest(t)
+	identity := &ExternalIdentity{
+		Provider:      "example",
+		Subject:       "subject-1",
+		Username:      "alice",
+		Nickname:      "Alice",
+		Email:         "alice@example.com",
+		EmailVerified: true,
+	}
+
+	mockStore.On("GetUserIdentity", mock.Anything, mock.AnythingOfType("*store.FindUserIdentity")).Return(nil, nil)
+	mockStore.On("GetUserByEmail", mock.Anything, identity.Email).Return(nil, nil)
+	// The preferred username is taken, a suffix is added
+	mockStore.On("GetUserByUsername", mock.Anything, "alice").Return(&store.User{ID: 1, Username: "alice"}, nil)
+	mockStore.On("GetUserByUsername", mock.Anything, mock.MatchedBy(func(username string) bool {
+		return strings.HasPrefix(username, "alice-")
+	})).Return(nil, nil)
+	mockStore.On("CreateUserIdentity", mock.Anything, mock.MatchedBy(func(create *store.CreateUserIdentity) bool {
+		return create.Provider == identity.Provider && create.Subject == identity.Subject &&
+			strings.HasPrefix(create.User.Username, "alice-") && create.User.Nickname == "Alice" &&
+			create.User.Email == identity.Email && create.User.EmailVerified && create.User.Role == store.RoleUser
+	})).Return(&store.UserIdentity{ID: 1, UserID: 2, Provider: identity.Provider, Subject: identity.Subject}, nil)
+	userID := int64(2)
+	mockStore.On("GetUser", mock.Anything, &store.FindUser{ID: &userID}).Return(&store.User{
+		ID:              userID,
+		Username:        "alice-0042",
+		Email:           identity.Email,
+		Role:            store.RoleUser,
+		EmailVerified:   true,
+		PasswordExpires: time.Now(),
+	}, nil)
+
+	resp, err := authService.LoginWithExternalIdentity(context.Background(), identity)
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.AccessToken)
+	assert.Equal(t, userID, resp.User.Id)
+	mockStore.AssertExpectations(t)
+}
+
+func TestAuthService_LoginWithExternalIdentity_Rejects(t *testing.T) {
+	authService, mockStore := newExternalLoginTest(t)
+	mockStore.On("GetUserIdentity", mock.Anything, mock.AnythingOfType("*store.FindUserIdentity")).Return(nil, nil)
+
+	_, err := authService.LoginWithExternalIdentity(context.Background(), &ExternalIdentity{Provider: "example"})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	_, err = authService.LoginWithExternalIdentity(context.Background(), &ExternalIdentity{Provider: "example", Subject: "subject-1"})
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	// Existing accounts are not taken over by an identity with the same email address
+	mockStore.On("GetUserByEmail", mock.Anything, "alice@example.com").Return(&store.User{ID: 1, Email: "alice@example.com"}, nil)
+	_, err = authService.LoginWithExternalIdentity(context.Background(), &ExternalIdentity{
+		Provider:      "example",
+		Subject:       "subject-1",
+		Email:         "alice@example.com",
+		EmailVerified: true,
+	})
+	assert.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err))
+	mockStore.AssertNotCalled(t, "CreateUserIdentity", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_LoginWithExternalIdentity_RegistrationPolicy(t *testing.T) {
+	identity := &ExternalIdentity{Provider: "example", Subject: "subject-1", Email: "alice@example.com", EmailVerified: true}
+	for _, policy := range []*storepb.RegistrationPolicy{
+		{Mode: storepb.RegistrationPolicy_DISABLED},
+		{Mode: storepb.RegistrationPolicy_INVITE_ONLY},
+		{Mode: storepb.RegistrationPolicy_ALLOWED_EMAIL_DOMAINS, AllowedEmailDomains: []string{"corp.example.com"}},
+	} {
+		mockStore := new(MockStore)
+		authService := NewAuthService("testsecret", mockStore)
+		mockStore.On("GetUserIdentity", mock.Anything, mock.AnythingOfType("*store.FindUserIdentity")).Return(nil, nil)
+		mockStore.On("GetInstanceSecuritySetting", mock.Anything).Return(&storepb.InstanceSecuritySetting{Registration: policy}, nil)
+
+		// Identities are not provisioned when the policy does not let their users register
+		_, err := authService.LoginWithExternalIdentity(context.Background(), identity)
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err), policy.Mode.String())
+		mockStore.AssertNotCalled(t, "CreateUserIdentity", mock.Anything, mock.Anything)
+	}
+
+	// Allowed domains only admit addresses the provider vouches for
+	mockStore := new(MockStore)
+	authService := NewAuthService("testsecret", mockStore)
+	mockStore.On("GetUserIdentity", mock.Anything, mock.AnythingOfType("*store.FindUserIdentity")).Return(nil, nil)
+	mockStore.On("GetInstanceSecuritySetting", mock.Anything).Return(&storepb.InstanceSecuritySetting{
+		Registration: &storepb.RegistrationPolicy{Mode: storepb.RegistrationPolicy_ALLOWED_EMAIL_DOMAINS, AllowedEmailDomains: []string{"example.com"}},
+	}, nil)
+	_, err := authService.LoginWithExternalIdentity(context.Background(), &ExternalIdentity{Provider: "example", Subject: "subject-1", Email: "alice@example.com"})
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	mockStore.AssertNotCalled(t, "CreateUserIdentity", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_ListLoginProviders(t *testing.T) {
+	mockStore := new(MockStore)
+	authService := NewAuthService("testsecret", mockStore)
+	mockStore.On("GetInstanceIdentityProviderSetting", mock.Anything).Return(&storepb.InstanceIdentityProviderSetting{
+		Providers: []*storepb.IdentityProvider{{Id: "example", Title: "Example", ClientId: "client", ClientSecret: "secret"}},
+	}, nil)
+
+	resp, err := authService.ListLoginProviders(context.Background(), &v1pb.ListLoginProvidersRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Providers, 1)
+	assert.Equal(t, "Example", resp.Providers[0].Title)
+	assert.Equal(t, "/auth/oidc/example/login", resp.Providers[0].LoginUrl)
+}
+
+func TestIdentityFromClaims(t *testing.T) {
+	claims := oidc.Claims{
+		"sub":                "subject-1",
+		"preferred_username": "alice",
+		"login":              "alice-login",
+		"name":               " Alice ",
+		"email":              "alice@example.com",
+		"email_verified":     true,
+		"mail":               "alice@corp.example.com",
+	}
+
+	identity := identityFromClaims(&storepb.IdentityProvider{Id: "example"}, claims)
+	assert.Equal(t, &ExternalIdentity{
+		Provider:      "example",
+		Subject:       "subject-1",
+		Username:      "alice",
+		Nickname:      "Alice",
+		Email:         "alice@example.com",
+		EmailVerified: true,
+	}, identity)
+
+	// email_verified only vouches for the standard email claim
+	identity = identityFromClaims(&storepb.IdentityProvider{
+		Id:           "example",
+		ClaimMapping: &storepb.IdentityProviderClaimMapping{Username: "login", Email: "mail"},
+	}, claims)
+	assert.Equal(t, "alice-login", identity.Username)
+	assert.Equal(t, "alice@corp.example.com", identity.Email)
+	assert.False(t, identity.EmailVerified)
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
+
+	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	storepb "github.com/pixb/go-server/proto/gen/store"
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/store"
+)
+
+var identityProviderIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// IdentityProviderStore is an interface that defines the methods needed by IdentityProviderService
+type IdentityProviderStore interface {
+	GetInstanceIdentityProviderSetting(ctx context.Context) (*storepb.InstanceIdentityProviderSetting, error)
+	UpsertInstanceSetting(ctx context.Context, upsert *storepb.InstanceSetting) (*storepb.InstanceSetting, error)
+}
+
+// IdentityProviderService manages the OpenID Connect providers stored in the
+// IDENTITY_PROVIDERS instance setting. Client secrets are write-only.
+type IdentityProviderService struct {
+	Store IdentityProviderStore
+}
+
+func NewIdentityProviderService(store IdentityProviderStore) *IdentityProviderService {
+	return &IdentityProviderService{
+		Store: store,
+	}
+}
+
+func (s *IdentityProviderService) ListIdentityProviders(ctx context.Context, req *v1pb.ListIdentityProvidersRequest) (*v1pb.ListIdentityProvidersResponse, error) {
+	if err := auth.Require(ctx, store.PermissionSettingsRead); err != nil {
+		return nil, err
+	}
+
+	setting, err := s.Store.GetInstanceIdentityProviderSetting(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get identity providers"))
+	}
+	response := &v1pb.ListIdentityProvidersResponse{}
+	for _, provider := range setting.Providers {
+		response.IdentityProviders = append(response.IdentityProviders, convertIdentityProviderFromStore(provider))
+	}
+	return response, nil
+}
+
+func (s *IdentityProviderService) GetIdentityProvider(ctx context.Context, req *v1pb.GetIdentityProviderRequest) (*v1pb.GetIdentityProviderResponse, error) {
+	if err := auth.Require(ctx, store.PermissionSettingsRead); err != nil {
+		return nil, err
+	}
+
+	setting, err := s.Store.GetInstanceIdentityProviderSetting(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get identity providers"))
+	}
+	index := findIdentityProvider(setting, req.Id)
+	if index < 0 {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("identity provider not found"))
+	}
+	return &v1pb.GetIdentityProviderResponse{IdentityProvider: convertIdentityProviderFromStore(setting.Providers[index])}, nil
+}
+
+func (s *IdentityProviderService) CreateIdentityProvider(ctx context.Context, req *v1pb.CreateIdentityProviderRequest) (*v1pb.CreateIdentityProviderResponse, error) {
+	if err := auth.Require(ctx, store.PermissionSettingsUpdate); err != nil {
+		return nil, err
+	}
+	if req.IdentityProvider == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("identity provider is required"))
+	}
+
+	if !identityProviderIDPattern.MatchString(req.IdentityProvider.Id) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id must be 1 to 32 lowercase letters, digits, '-' or '_', starting with a letter or digit"))
+	}
+	provider := convertIdentityProviderToStore(req.IdentityProvider)
+	if err := validateIdentityProvider(provider); err != nil {
+		return nil, err
+	}
+
+	setting, err := s.Store.GetInstanceIdentityProviderSetting(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get identity providers"))
+	}
+	if findIdentityProvider(setting, provider.Id) >= 0 {
+		return nil, connect.NewError(connect.CodeAlreadyExists, errors.New("identity provider already exists"))
+	}
+	setting = proto.Clone(setting).(*storepb.InstanceIdentityProviderSetting)
+	setting.Providers = append(setting.Providers, provider)
+	if err := s.saveSetting(ctx, setting); err != nil {
+		return nil, err
+	}
+	return &v1pb.CreateIdentityProviderResponse{IdentityProvider: convertIdentityProviderFromStore(provider)}, nil
+}
+
+func (s *IdentityProviderService) UpdateIdentityProvider(ctx context.Context, req *v1pb.UpdateIdentityProviderRequest) (*v1pb.UpdateIdentityProviderResponse, error) {
+	if err := auth.Require(ctx, store.PermissionSettingsUpdate); err != nil {
+		return nil, err
+	}
+	if req.IdentityProvider == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("identity provider is required"))
+	}
+	if req.UpdateMask == nil || len(req.UpdateMask.Paths) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("update mask is required"))
+	}
+
+	setting, err := s.Store.GetInstanceIdentityProviderSetting(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get identity providers"))
+	}
+	index := findIdentityProvider(setting, req.IdentityProvider.Id)
+	if index < 0 {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("identity provider not found"))
+	}
+	setting = proto.Clone(setting).(*storepb.InstanceIdentityProviderSetting)
+	provider := setting.Providers[index]
+	update := convertIdentityProviderToStore(req.IdentityProvider)
+	for _, path := range req.UpdateMask.Paths {
+		switch path {
+		case "title":
+			provider.Title = update.Title
+		case "issuer":
+			provider.Issuer = update.Issuer
+		case "client_id":
+			provider.ClientId = update.ClientId
+		case "client_secret":
+			provider.ClientSecret = update.ClientSecret
+		case "scopes":
+			provider.Scopes = update.Scopes
+		case "claim_mapping":
+			provider.ClaimMapping = update.ClaimMapping
+		default:
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unsupported update mask path %q", path))
+		}
+	}
+	if err := validateIdentityProvider(provider); err != nil {
+		return nil, err
+	}
+	if err := s.saveSetting(ctx, setting); err != nil {
+		return nil, err
+	}
+	return &v1pb.UpdateIdentityProviderResponse{IdentityProvider: convertIdentityProviderFromStore(provider)}, nil
+}
+
+func (s *IdentityProviderService) DeleteIdentityProvider(ctx context.Context, req *v1pb.DeleteIdentityProviderRequest) (*v1pb.DeleteIdentityProviderResponse, error) {
+	if err := auth.Require(ctx, store.PermissionSettingsUpdate); err != nil {
+		return nil, err
+	}
+
+	setting, err := s.Store.GetInstanceIdentityProviderSetting(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get identity providers"))
+	}
+	index := findIdentityProvider(setting, req.Id)
+	if index < 0 {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("identity provider not found"))
+	}
+	setting = proto.Clone(setting).(*storepb.InstanceIdentityProviderSetting)
+	setting.Providers = slices.Delete(setting.Providers, index, index+1)
+	if err := s.saveSetting(ctx, setting); err != nil {
+		return nil, err
+	}
+	return &v1pb.DeleteIdentityProviderResponse{}, nil
+}
+
+func (s *IdentityProviderService) saveSetting(ctx context.Context, setting *storepb.InstanceIdentityProviderSetting) error {
+	if _, err := s.Store.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+		Key:   storepb.InstanceSettingKey_IDENTITY_PROVIDERS,
+		Value: &storepb.InstanceSetting_IdentityProviderSetting{IdentityProviderSetting: setting},
+	}); err != nil {
+		return connect.NewError(connect.CodeInternal, errors.New("failed to save identity providers"))
+	}
+	return nil
+}
+
+func findIdentityProvider(setting *storepb.InstanceIdentityProviderSetting, id string) int {
+	return slices.IndexFunc(setting.Providers, func(provider *storepb.IdentityProvider) bool {
+		return provider.Id == id
+	})
+}
+
+func validateIdentityProvider(provider *storepb.IdentityProvider) error {
+	if provider.Title == "" {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("title is required"))
+	}
+	if len(provider.Title) > 64 {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("title must be at most 64 characters"))
+	}
+	if err := validateIssuer(provider.Issuer); err != nil {
+		return err
+	}
+	if provider.ClientId == "" {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("client id is required"))
+	}
+	for _, scope := range provider.Scopes {
+		if scope == "" || strings.ContainsAny(scope, " \t\r\n\"\\") {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid scope %q", scope))
+		}
+	}
+	return nil
+}
+
+// validateIssuer requires an https URL, plain http is only allowed for loopback hosts.
+func validateIssuer(issuer string) error {
+	u, err := url.Parse(issuer)
+	if err != nil || u.Host == "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("issuer must be an absolute URL without query or fragment"))
+	}
+	switch u.Scheme {
+	case "https":
+	case "http":
+		if !isLoopbackHost(u.Hostname()) {
+			return connect.NewError(connect.CodeInvalidArgument, errors.New("issuer must use https"))
+		}
+	default:
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("issuer must use https"))
+	}
+	return nil
+}
+
+func isLoopbackHost(host string) bool {
+	ip := net.ParseIP(host)
+	return host == "localhost" || (ip != nil && ip.IsLoopback())
+}
+
+func convertIdentityProviderFromStore(provider *storepb.IdentityProvider) *v1pb.IdentityProvider {
+	identityProvider := &v1pb.IdentityProvider{
+		Id:              provider.Id,
+		Title:           provider.Title,
+		Issuer:          provider.Issuer,
+		ClientId:        provider.ClientId,
+		Scopes:          provider.Scopes,
+		HasClientSecret: provider.ClientSecret != "",
+	}
+	if mapping := provider.ClaimMapping; mapping != nil {
+		identityProvider.ClaimMapping = &v1pb.IdentityProvider_ClaimMapping{
+			Username: mapping.Username,
+			Nickname: mapping.Nickname,
+			Email:    mapping.Email,
+		}
+	}
+	return identityProvider
+}
+
+func convertIdentityProviderToStore(provider *v1pb.IdentityProvider) *storepb.IdentityProvider {
+	identityProvider := &storepb.IdentityProvider{
+		Id:           provider.Id,
+		Title:        provider.Title,
+		Issuer:       provider.Issuer,
+		ClientId:     provider.ClientId,
+		ClientSecret: provider.ClientSecret,
+		Scopes:       provider.Scopes,
+	}
+	if mapping := provider.ClaimMapping; mapping != nil {
+		identityProvider.ClaimMapping = &storepb.IdentityProviderClaimMapping{
+			Username: mapping.Username,
+			Nickname: mapping.Nickname,
+			Email:    mapping.Email,
+		}
+	}
+	return identityProvider
+}
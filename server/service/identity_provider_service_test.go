@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	storepb "github.com/pixb/go-server/proto/gen/store"
+	"github.com/pixb/go-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+var exampleIdentityProvider = &storepb.IdentityProvider{
+	Id:           "example",
+	Title:        "Example",
+	Issuer:       "https://accounts.example.com",
+	ClientId:     "client",
+	ClientSecret: "secret",
+}
+
+func newIdentityProviderServiceTest(t *testing.T) (*IdentityProviderService, *MockStore, context.Context) {
+	t.Helper()
+	mockStore := new(MockStore)
+	mockStore.On("GetInstanceIdentityProviderSetting", mock.Anything).Return(&storepb.InstanceIdentityProviderSetting{
+		Providers: []*storepb.IdentityProvider{exampleIdentityProvider},
+	}, nil).Maybe()
+	return NewIdentityProviderService(mockStore), mockStore, permissionContext(1, store.PermissionSettingsRead, store.PermissionSettingsUpdate)
+}
+
+// expectProviders expects the setting to be saved with the providers.
+func expectProviders(mockStore *MockStore, providers ...*storepb.IdentityProvider) {
+	mockStore.On("UpsertInstanceSetting", mock.Anything, mock.MatchedBy(func(upsert *storepb.InstanceSetting) bool {
+		return upsert.Key == storepb.InstanceSettingKey_IDENTITY_PROVIDERS &&
+			proto.Equal(upsert.GetIdentityProviderSetting(), &storepb.InstanceIdentityProviderSetting{Providers: providers})
+	})).Return(&storepb.InstanceSetting{}, nil).Once()
+}
+
+func TestIdentityProviderService_RequiresPermissions(t *testing.T) {
+	identityProviderService, _, _ := newIdentityProviderServiceTest(t)
+
+	_, err := identityProviderService.ListIdentityProviders(context.Background(), &v1pb.ListIdentityProvidersRequest{})
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+	readerCtx := permissionContext(2, store.PermissionSettingsRead)
+	resp, err := identityProviderService.ListIdentityProviders(readerCtx, &v1pb.ListIdentityProvidersRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.IdentityProviders, 1)
+	// Client secrets are never returned
+	assert.Empty(t, resp.IdentityProviders[0].ClientSecret)
+	assert.True(t, resp.IdentityProviders[0].HasClientSecret)
+
+	_, err = identityProviderService.DeleteIdentityProvider(readerCtx, &v1pb.DeleteIdentityProviderRequest{Id: "example"})
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+}
+
+func TestIdentityProviderService_CreateIdentityProvider(t *testing.T) {
+	identityProviderService, mockStore, ctx := newIdentityProviderServiceTest(t)
+
+	for _, provider := range []*v1pb.IdentityProvider{
+		{Id: "Example", Title: "Example", Issuer: "https://accounts.example.com", ClientId: "client"},
+		{Id: "corp", Issuer: "https://accounts.example.com", ClientId: "client"},
+		{Id: "corp", Title: "Corp", Issuer: "http://accounts.example.com", ClientId: "client"},
+		{Id: "corp", Title: "Corp", Issuer: "https://accounts.example.com?tenant=1", ClientId: "client"},
+		{Id: "corp", Title: "Corp", Issuer: "https://accounts.example.com"},
+		{Id: "corp", Title: "Corp", Issuer: "https://accounts.example.com", ClientId: "client", Scopes: []string{"email profile"}},
+	} {
+		_, err := identityProviderService.CreateIdentityProvider(ctx, &v1pb.CreateIdentityProviderRequest{IdentityProvider: provider})
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), provider.String())
+	}
+
+	_, err := identityProviderService.CreateIdentityProvider(ctx, &v1pb.CreateIdentityProviderRequest{IdentityProvider: &v1pb.IdentityProvider{
+		Id: "example", Title: "Example", Issuer: "https://accounts.example.com", ClientId: "client",
+	}})
+	assert.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err))
+
+	// Plain http is allowed for providers on the same host
+	corp := &storepb.IdentityProvider{
+		Id:           "corp",
+		Title:        "Corp",
+		Issuer:       "http://127.0.0.1:8080/realms/corp",
+		ClientId:     "client",
+		Scopes:       []string{"email"},
+		ClaimMapping: &storepb.IdentityProviderClaimMapping{Username: "login"},
+	}
+	expectProviders(mockStore, exampleIdentityProvider, corp)
+	resp, err := identityProviderService.CreateIdentityProvider(ctx, &v1pb.CreateIdentityProviderRequest{IdentityProvider: &v1pb.IdentityProvider{
+		Id:           "corp",
+		Title:        "Corp",
+		Issuer:       "http://127.0.0.1:8080/realms/corp",
+		ClientId:     "client",
+		Scopes:       []string{"email"},
+		ClaimMapping: &v1pb.IdentityProvider_ClaimMapping{Username: "login"},
+	}})
+	require.NoError(t, err)
+	assert.False(t, resp.IdentityProvider.HasClientSecret)
+	assert.Equal(t, "login", resp.IdentityProvider.ClaimMapping.Username)
+	mockStore.AssertExpectations(t)
+}
+
+func TestIdentityProviderService_UpdateAndDeleteIdentityProvider(t *testing.T) {
+	identityProviderService, mockStore, ctx := newIdentityProviderServiceTest(t)
+
+	_, err := identityProviderService.UpdateIdentityProvider(ctx, &v1pb.UpdateIdentityProviderRequest{
+		IdentityProvider: &v1pb.IdentityProvider{Id: "example"},
+	})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	_, err = identityProviderService.UpdateIdentityProvider(ctx, &v1pb.UpdateIdentityProviderRequest{
+		IdentityProvider: &v1pb.IdentityProvider{Id: "example"},
+		UpdateMask:       &fieldmaskpb.FieldMask{Paths: []string{"id"}},
+	})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	_, err = identityProviderService.UpdateIdentityProvider(ctx, &v1pb.UpdateIdentityProviderRequest{
+		IdentityProvider: &v1pb.IdentityProvider{Id: "missing", Title: "Missing"},
+		UpdateMask:       &fieldmaskpb.FieldMask{Paths: []string{"title"}},
+	})
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	// Only the fields in the mask are updated, the secret is kept unless it is in the mask
+	updated := proto.Clone(exampleIdentityProvider).(*storepb.IdentityProvider)
+	updated.Title = "Example SSO"
+	expectProviders(mockStore, updated)
+	resp, err := identityProviderService.UpdateIdentityProvider(ctx, &v1pb.UpdateIdentityProviderRequest{
+		IdentityProvider: &v1pb.IdentityProvider{Id: "example", Title: "Example SSO", ClientId: "other"},
+		UpdateMask:       &fieldmaskpb.FieldMask{Paths: []string{"title"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Example SSO", resp.IdentityProvider.Title)
+	assert.True(t, resp.IdentityProvider.HasClientSecret)
+	// The cached setting is not modified in place
+	assert.Equal(t, "Example", exampleIdentityProvider.Title)
+
+	_, err = identityProviderService.DeleteIdentityProvider(ctx, &v1pb.DeleteIdentityProviderRequest{Id: "missing"})
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	expectProviders(mockStore)
+	_, err = identityProviderService.DeleteIdentityProvider(ctx, &v1pb.DeleteIdentityProviderRequest{Id: "example"})
+	require.NoError(t, err)
+	mockStore.AssertExpectations(t)
+}
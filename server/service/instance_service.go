@@ -2,33 +2,50 @@ package service
 
 import (
 	"context"
+	"errors"
+	"log/slog"
+	"time"
 
 	"connectrpc.com/connect"
 
 	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	storepb "github.com/pixb/go-server/proto/gen/store"
 	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/server/notify"
 	"github.com/pixb/go-server/store"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // InstanceStore is an interface that defines the methods needed by InstanceService
 type InstanceStore interface {
+	accountStore
+	emailVerificationStore
+	instanceSettingStore
 	ListUsers(ctx context.Context, find *store.FindUser) ([]*store.User, error)
+	GetInstanceSetupSetting(ctx context.Context) (*storepb.InstanceSetupSetting, error)
+	SetupInstance(ctx context.Context, admin *store.User, settings ...*storepb.InstanceSetting) (*store.User, error)
 	Ping(ctx context.Context) error
 	Close() error
 }
 
+var errInstanceAlreadySetUp = connect.NewError(connect.CodeFailedPrecondition, errors.New("instance is already set up"))
+
 type InstanceService struct {
 	Version string
 	Demo    bool
 	Store   InstanceStore
+	// Notifier delivers the email verification token of the first admin.
+	Notifier notify.Notifier
+	// Maintenance is reported in the instance profile, the instance is in service if nil.
+	Maintenance *auth.Maintenance
 }
 
 func NewInstanceService(version string, demo bool, store InstanceStore) *InstanceService {
 	return &InstanceService{
-		Version: version,
-		Demo:    demo,
-		Store:   store,
+		Version:  version,
+		Demo:     demo,
+		Store:    store,
+		Notifier: notify.NewDisabledNotifier(),
 	}
 }
 
@@ -37,6 +54,18 @@ func (s *InstanceService) GetInstanceProfile(ctx context.Context, req *v1pb.GetI
 		Version: s.Version,
 		Demo:    s.Demo,
 	}
+	if maintenance := s.Maintenance.Setting(); maintenance != nil {
+		maintenanceErr := auth.NewMaintenanceError(maintenance)
+		profile.Maintenance = &v1pb.InstanceProfile_Maintenance{
+			Mode:              v1pb.InstanceProfile_Maintenance_Mode(maintenance.Mode),
+			Message:           maintenance.Message,
+			RetryAfterSeconds: int32(maintenanceErr.RetryAfter.Seconds()),
+		}
+		// The database may be migrated meanwhile.
+		if maintenance.Mode == storepb.InstanceMaintenanceSetting_FULL {
+			return profile, nil
+		}
+	}
 
 	// Try to find the first admin user
 	adminRole := store.RoleAdmin
@@ -60,8 +89,73 @@ func (s *InstanceService) GetInstanceProfile(ctx context.Context, req *v1pb.GetI
 			PasswordExpiresAt: timestamppb.New(user.PasswordExpires),
 			CreatedAt:         timestamppb.New(user.CreatedAt),
 			UpdatedAt:         timestamppb.New(user.UpdatedAt),
+			EmailVerified:     user.EmailVerified,
 		}
 	}
 
 	return profile, nil
 }
+
+// SetupInstance creates the first admin and the general setting of a new instance. The
+// store records the setup in the same transaction, so it succeeds once even when several
+// setups race and stays locked after the admin is gone.
+func (s *InstanceService) SetupInstance(ctx context.Context, req *v1pb.SetupInstanceRequest) (*v1pb.SetupInstanceResponse, error) {
+	// Fail early, the store checks again while it creates the admin.
+	setup, err := s.Store.GetInstanceSetupSetting(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get instance setup"))
+	}
+	adminRole := store.RoleAdmin
+	admins, err := s.Store.ListUsers(ctx, &store.FindUser{Role: &adminRole})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if setup != nil || len(admins) > 0 {
+		return nil, errInstanceAlreadySetUp
+	}
+
+	if req.InstanceName == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("instance name is required"))
+	}
+	if len(req.InstanceName) > 100 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("instance name must be at most 100 characters"))
+	}
+	passwordPolicy, err := validateNewAccount(ctx, s.Store, req.Username, req.Nickname, req.Password, req.Phone, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	passwordHash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to hash password"))
+	}
+
+	admin, err := s.Store.SetupInstance(ctx, &store.User{
+		Username:        req.Username,
+		Email:           req.Email,
+		Password:        passwordHash,
+		Nickname:        req.Nickname,
+		Phone:           req.Phone,
+		Role:            store.RoleAdmin,
+		PasswordExpires: passwordPolicy.ExpiresAt(time.Now()),
+	}, &storepb.InstanceSetting{
+		Key: storepb.InstanceSettingKey_GENERAL,
+		Value: &storepb.InstanceSetting_GeneralSetting{GeneralSetting: &storepb.InstanceGeneralSetting{
+			Name: req.InstanceName,
+		}},
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to set up instance"))
+	}
+	if admin == nil {
+		return nil, errInstanceAlreadySetUp
+	}
+	slog.Info("instance set up", slog.Int64("adminID", admin.ID), slog.String("username", admin.Username))
+
+	if err := recordPassword(ctx, s.Store, passwordPolicy, admin.ID, passwordHash); err != nil {
+		return nil, err
+	}
+	if err := sendEmailVerification(ctx, s.Store, s.Notifier, admin, admin.Email); err != nil {
+		slog.Error("failed to send verification email", slog.Int64("userID", admin.ID), slog.Any("error", err))
+	}
+	return &v1pb.SetupInstanceResponse{Admin: convertUserFromStore(admin)}, nil
+}
@@ -5,10 +5,15 @@ import (
 	"testing"
 	"time"
 
+	"connectrpc.com/connect"
 	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	storepb "github.com/pixb/go-server/proto/gen/store"
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/server/notify"
 	"github.com/pixb/go-server/store"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInstanceService_GetInstanceProfile(t *testing.T) {
@@ -114,3 +119,113 @@ func TestInstanceService_GetInstanceProfile(t *testing.T) {
 		})
 	}
 }
+
+func TestInstanceService_GetInstanceProfile_Maintenance(t *testing.T) {
+	mockStore := new(MockStore)
+	mockStore.On("ListUsers", mock.Anything, mock.AnythingOfType("*store.FindUser")).Return([]*store.User{}, nil)
+	instanceService := NewInstanceService("1.0.0", false, mockStore)
+	instanceService.Maintenance = &auth.Maintenance{}
+
+	resp, err := instanceService.GetInstanceProfile(context.Background(), &v1pb.GetInstanceProfileRequest{})
+	require.NoError(t, err)
+	assert.Nil(t, resp.Maintenance)
+
+	instanceService.Maintenance.Set(&storepb.InstanceMaintenanceSetting{Mode: storepb.InstanceMaintenanceSetting_READ_ONLY, Message: "upgrading"})
+	resp, err = instanceService.GetInstanceProfile(context.Background(), &v1pb.GetInstanceProfileRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, v1pb.InstanceProfile_Maintenance_READ_ONLY, resp.Maintenance.Mode)
+	assert.Equal(t, "upgrading", resp.Maintenance.Message)
+	assert.Equal(t, int32(300), resp.Maintenance.RetryAfterSeconds)
+	mockStore.AssertNumberOfCalls(t, "ListUsers", 2)
+
+	// The database is not read in full maintenance
+	instanceService.Maintenance.Set(&storepb.InstanceMaintenanceSetting{Mode: storepb.InstanceMaintenanceSetting_FULL, RetryAfterSeconds: 60})
+	resp, err = instanceService.GetInstanceProfile(context.Background(), &v1pb.GetInstanceProfileRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, v1pb.InstanceProfile_Maintenance_FULL, resp.Maintenance.Mode)
+	assert.Equal(t, int32(60), resp.Maintenance.RetryAfterSeconds)
+	mockStore.AssertNumberOfCalls(t, "ListUsers", 2)
+}
+
+func TestInstanceService_SetupInstance(t *testing.T) {
+	req := &v1pb.SetupInstanceRequest{
+		Username:     "admin",
+		Nickname:     "Admin",
+		Password:     "Correct-Horse-Battery-9",
+		Phone:        "13800138000",
+		Email:        "admin@example.com",
+		InstanceName: "Acme",
+	}
+	adminRole := store.RoleAdmin
+
+	t.Run("creates the first admin", func(t *testing.T) {
+		mockStore := new(MockStore)
+		mockStore.On("GetInstanceSetupSetting", mock.Anything).Return(nil, nil)
+		mockStore.On("ListUsers", mock.Anything, &store.FindUser{Role: &adminRole}).Return([]*store.User{}, nil)
+		mockStore.On("GetInstancePasswordPolicySetting", mock.Anything).Return(&storepb.InstancePasswordPolicySetting{}, nil)
+		mockStore.On("GetUserByUsername", mock.Anything, req.Username).Return(nil, nil)
+		mockStore.On("GetUserByEmail", mock.Anything, req.Email).Return(nil, nil)
+		mockStore.On("SetupInstance", mock.Anything, mock.MatchedBy(func(admin *store.User) bool {
+			return admin.Username == req.Username && admin.Role == store.RoleAdmin && admin.Password != req.Password
+		}), mock.MatchedBy(func(settings []*storepb.InstanceSetting) bool {
+			return len(settings) == 1 && settings[0].GetGeneralSetting().GetName() == req.InstanceName
+		})).Return(&store.User{ID: 1, Username: req.Username, Email: req.Email, Role: store.RoleAdmin}, nil)
+		mockStore.On("CreatePasswordHistory", mock.Anything, mock.AnythingOfType("*store.CreatePasswordHistory")).Return(&store.PasswordHistory{ID: 1, UserID: 1}, nil)
+		mockStore.On("ListPasswordHistories", mock.Anything, &store.FindPasswordHistory{UserID: 1, Limit: 1}).Return([]*store.PasswordHistory{{ID: 1, UserID: 1}}, nil)
+		mockStore.On("DeletePasswordHistories", mock.Anything, &store.DeletePasswordHistory{UserID: 1, BeforeID: 1}).Return(nil)
+		mockStore.On("DeleteEmailVerifications", mock.Anything, &store.DeleteEmailVerification{UserID: 1}).Return(nil)
+		mockStore.On("CreateEmailVerification", mock.Anything, mock.AnythingOfType("*store.CreateEmailVerification")).Return(&store.EmailVerification{ID: 1, UserID: 1, Email: req.Email}, nil)
+
+		instanceService := NewInstanceService("1.0.0", false, mockStore)
+		notifier := notify.NewMemoryNotifier()
+		instanceService.Notifier = notifier
+		resp, err := instanceService.SetupInstance(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, v1pb.Role_ROLE_ADMIN, resp.Admin.Role)
+		assert.Equal(t, req.Username, resp.Admin.Username)
+		assert.Len(t, notifier.Messages(), 1)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("refuses a set up instance", func(t *testing.T) {
+		mockStore := new(MockStore)
+		mockStore.On("GetInstanceSetupSetting", mock.Anything).Return(&storepb.InstanceSetupSetting{}, nil)
+		mockStore.On("ListUsers", mock.Anything, &store.FindUser{Role: &adminRole}).Return([]*store.User{}, nil)
+
+		_, err := NewInstanceService("1.0.0", false, mockStore).SetupInstance(context.Background(), req)
+		assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+		mockStore.AssertNotCalled(t, "SetupInstance", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("refuses an instance with an admin", func(t *testing.T) {
+		mockStore := new(MockStore)
+		mockStore.On("GetInstanceSetupSetting", mock.Anything).Return(nil, nil)
+		mockStore.On("ListUsers", mock.Anything, &store.FindUser{Role: &adminRole}).Return([]*store.User{{ID: 1, Role: store.RoleAdmin}}, nil)
+
+		_, err := NewInstanceService("1.0.0", false, mockStore).SetupInstance(context.Background(), req)
+		assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+	})
+
+	t.Run("loses a concurrent setup", func(t *testing.T) {
+		mockStore := new(MockStore)
+		mockStore.On("GetInstanceSetupSetting", mock.Anything).Return(nil, nil)
+		mockStore.On("ListUsers", mock.Anything, &store.FindUser{Role: &adminRole}).Return([]*store.User{}, nil)
+		mockStore.On("GetInstancePasswordPolicySetting", mock.Anything).Return(&storepb.InstancePasswordPolicySetting{}, nil)
+		mockStore.On("GetUserByUsername", mock.Anything, req.Username).Return(nil, nil)
+		mockStore.On("GetUserByEmail", mock.Anything, req.Email).Return(nil, nil)
+		mockStore.On("SetupInstance", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+
+		_, err := NewInstanceService("1.0.0", false, mockStore).SetupInstance(context.Background(), req)
+		assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+		mockStore.AssertNotCalled(t, "CreatePasswordHistory", mock.Anything, mock.Anything)
+	})
+
+	t.Run("requires an instance name", func(t *testing.T) {
+		mockStore := new(MockStore)
+		mockStore.On("GetInstanceSetupSetting", mock.Anything).Return(nil, nil)
+		mockStore.On("ListUsers", mock.Anything, &store.FindUser{Role: &adminRole}).Return([]*store.User{}, nil)
+
+		_, err := NewInstanceService("1.0.0", false, mockStore).SetupInstance(context.Background(), &v1pb.SetupInstanceRequest{Username: "admin"})
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+}
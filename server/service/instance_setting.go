@@ -0,0 +1,432 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
+
+	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	storepb "github.com/pixb/go-server/proto/gen/store"
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/store"
+)
+
+// Bounds of the settings admins can change.
+const (
+	minAccessTokenLifetime  = 5 * time.Minute
+	minRefreshTokenLifetime = time.Hour
+	maxRefreshTokenLifetime = 90 * 24 * time.Hour
+	maxUploadSizeLimitMB    = 1024
+	maxAllowedEmailDomains  = 100
+	maxRequestsPerSecond    = 10000
+	maxCORSAllowedOrigins   = 100
+)
+
+var (
+	localePattern      = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+	emailDomainPattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]*[a-z0-9])?\.)+[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+)
+
+// instanceSettingStore reads and writes the settings of the instance. Reads go through the
+// instance setting cache, which writes update.
+type instanceSettingStore interface {
+	GetInstanceGeneralSetting(ctx context.Context) (*storepb.InstanceGeneralSetting, error)
+	GetInstanceSecuritySetting(ctx context.Context) (*storepb.InstanceSecuritySetting, error)
+	GetInstanceStorageSetting(ctx context.Context) (*storepb.InstanceStorageSetting, error)
+	UpsertInstanceSetting(ctx context.Context, upsert *storepb.InstanceSetting) (*storepb.InstanceSetting, error)
+}
+
+func (s *InstanceService) GetInstanceSetting(ctx context.Context, req *v1pb.GetInstanceSettingRequest) (*v1pb.GetInstanceSettingResponse, error) {
+	if err := auth.Require(ctx, store.PermissionSettingsRead); err != nil {
+		return nil, err
+	}
+
+	setting, err := s.getInstanceSetting(ctx, req.Key)
+	if err != nil {
+		return nil, err
+	}
+	return &v1pb.GetInstanceSettingResponse{Setting: convertInstanceSettingFromStore(setting)}, nil
+}
+
+// UpdateInstanceSetting changes the fields of a setting listed in the update mask. Fields of the
+// stored setting that the API does not expose, such as the account lockout policy, are kept.
+func (s *InstanceService) UpdateInstanceSetting(ctx context.Context, req *v1pb.UpdateInstanceSettingRequest) (*v1pb.UpdateInstanceSettingResponse, error) {
+	if err := auth.Require(ctx, store.PermissionSettingsUpdate); err != nil {
+		return nil, err
+	}
+	if req.Setting == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("setting is required"))
+	}
+	if req.UpdateMask == nil || len(req.UpdateMask.Paths) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("update mask is required"))
+	}
+
+	setting, err := s.getInstanceSetting(ctx, req.Setting.Key)
+	if err != nil {
+		return nil, err
+	}
+	setting = proto.Clone(setting).(*storepb.InstanceSetting)
+	switch value := setting.Value.(type) {
+	case *storepb.InstanceSetting_GeneralSetting:
+		if req.Setting.Value != nil && req.Setting.GetGeneralSetting() == nil {
+			return nil, errSettingValueMismatch
+		}
+		err = updateGeneralSetting(value.GeneralSetting, req.Setting.GetGeneralSetting(), req.UpdateMask.Paths)
+	case *storepb.InstanceSetting_SecuritySetting:
+		if req.Setting.Value != nil && req.Setting.GetSecuritySetting() == nil {
+			return nil, errSettingValueMismatch
+		}
+		err = updateSecuritySetting(value.SecuritySetting, req.Setting.GetSecuritySetting(), req.UpdateMask.Paths)
+	case *storepb.InstanceSetting_StorageSetting:
+		if req.Setting.Value != nil && req.Setting.GetStorageSetting() == nil {
+			return nil, errSettingValueMismatch
+		}
+		err = updateStorageSetting(value.StorageSetting, req.Setting.GetStorageSetting(), req.UpdateMask.Paths)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.Store.UpsertInstanceSetting(ctx, setting); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to save instance setting"))
+	}
+	return &v1pb.UpdateInstanceSettingResponse{Setting: convertInstanceSettingFromStore(setting)}, nil
+}
+
+var errSettingValueMismatch = connect.NewError(connect.CodeInvalidArgument, errors.New("setting value does not match the key"))
+
+// getInstanceSetting returns the stored setting of an API key, with the defaults if it was never changed.
+func (s *InstanceService) getInstanceSetting(ctx context.Context, key v1pb.InstanceSetting_Key) (*storepb.InstanceSetting, error) {
+	var setting *storepb.InstanceSetting
+	var err error
+	switch key {
+	case v1pb.InstanceSetting_GENERAL:
+		var general *storepb.InstanceGeneralSetting
+		general, err = s.Store.GetInstanceGeneralSetting(ctx)
+		setting = &storepb.InstanceSetting{
+			Key:   storepb.InstanceSettingKey_GENERAL,
+			Value: &storepb.InstanceSetting_GeneralSetting{GeneralSetting: general},
+		}
+	case v1pb.InstanceSetting_SECURITY:
+		var security *storepb.InstanceSecuritySetting
+		security, err = s.Store.GetInstanceSecuritySetting(ctx)
+		setting = &storepb.InstanceSetting{
+			Key:   storepb.InstanceSettingKey_SECURITY,
+			Value: &storepb.InstanceSetting_SecuritySetting{SecuritySetting: security},
+		}
+	case v1pb.InstanceSetting_STORAGE:
+		var storage *storepb.InstanceStorageSetting
+		storage, err = s.Store.GetInstanceStorageSetting(ctx)
+		setting = &storepb.InstanceSetting{
+			Key:   storepb.InstanceSettingKey_STORAGE,
+			Value: &storepb.InstanceSetting_StorageSetting{StorageSetting: storage},
+		}
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("unsupported instance setting key"))
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get instance setting"))
+	}
+	return setting, nil
+}
+
+func updateGeneralSetting(general *storepb.InstanceGeneralSetting, update *v1pb.InstanceSetting_GeneralSetting, paths []string) error {
+	for _, path := range paths {
+		switch path {
+		case "name":
+			general.Name = update.GetName()
+		case "description":
+			general.Description = update.GetDescription()
+		case "locale":
+			general.Locale = update.GetLocale()
+		default:
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unsupported update mask path %q", path))
+		}
+	}
+
+	if general.Name == "" {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("instance name is required"))
+	}
+	if len(general.Name) > 100 {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("instance name must be at most 100 characters"))
+	}
+	if len(general.Description) > 1000 {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("description must be at most 1000 characters"))
+	}
+	if general.Locale != "" && !localePattern.MatchString(general.Locale) {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("locale must be a BCP 47 language tag"))
+	}
+	return nil
+}
+
+func updateSecuritySetting(security *storepb.InstanceSecuritySetting, update *v1pb.InstanceSetting_SecuritySetting, paths []string) error {
+	for _, path := range paths {
+		switch path {
+		case "access_token_lifetime_seconds":
+			security.AccessTokenLifetimeSeconds = update.GetAccessTokenLifetimeSeconds()
+		case "refresh_token_lifetime_seconds":
+			security.RefreshTokenLifetimeSeconds = update.GetRefreshTokenLifetimeSeconds()
+		case "registration":
+			security.Registration = convertRegistrationPolicyToStore(update.GetRegistration())
+		case "rate_limit":
+			security.RateLimit = convertRateLimitPolicyToStore(update.GetRateLimit())
+		case "cors_allowed_origins":
+			security.CorsAllowedOrigins = update.GetCorsAllowedOrigins()
+		default:
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unsupported update mask path %q", path))
+		}
+	}
+
+	if !isValidLifetime(security.AccessTokenLifetimeSeconds, minAccessTokenLifetime, auth.AccessTokenDuration) {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("access token lifetime must be between 5 minutes and 24 hours"))
+	}
+	if !isValidLifetime(security.RefreshTokenLifetimeSeconds, minRefreshTokenLifetime, maxRefreshTokenLifetime) {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("refresh token lifetime must be between 1 hour and 90 days"))
+	}
+	// A refresh token that expires first would end sessions before their access token.
+	if lifetimes := auth.NewTokenLifetimes(security); lifetimes.RefreshToken < lifetimes.AccessToken {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("refresh token lifetime must be at least the access token lifetime"))
+	}
+	if err := validateRateLimitPolicy(security.RateLimit); err != nil {
+		return err
+	}
+	if err := validateCORSAllowedOrigins(security.CorsAllowedOrigins); err != nil {
+		return err
+	}
+	return validateRegistrationPolicy(security.Registration)
+}
+
+func validateRateLimitPolicy(policy *storepb.RateLimitPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	// The negated comparison also rejects NaN.
+	if !(policy.RequestsPerSecond >= 0 && policy.RequestsPerSecond <= maxRequestsPerSecond) {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("requests per second must be between 0 and 10000"))
+	}
+	if policy.Burst < 0 || policy.Burst > maxRequestsPerSecond {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("burst must be between 0 and 10000"))
+	}
+	return nil
+}
+
+// validateCORSAllowedOrigins checks that each origin is a scheme and host as sent by browsers in
+// the Origin header, and lowercases them to match it.
+func validateCORSAllowedOrigins(origins []string) error {
+	if len(origins) > maxCORSAllowedOrigins {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("at most 100 cors allowed origins are supported"))
+	}
+	for i, origin := range origins {
+		u, err := url.Parse(strings.ToLower(strings.TrimSpace(origin)))
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" ||
+			u.User != nil || u.Path != "" || u.RawQuery != "" || u.Fragment != "" {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid cors allowed origin %q", origin))
+		}
+		origins[i] = u.Scheme + "://" + u.Host
+	}
+	return nil
+}
+
+func validateRegistrationPolicy(policy *storepb.RegistrationPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	if _, ok := storepb.RegistrationPolicy_Mode_name[int32(policy.Mode)]; !ok {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("invalid registration mode"))
+	}
+	if len(policy.AllowedEmailDomains) > maxAllowedEmailDomains {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("at most 100 allowed email domains are supported"))
+	}
+	for i, domain := range policy.AllowedEmailDomains {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		if len(domain) > 253 || !emailDomainPattern.MatchString(domain) {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid email domain %q", policy.AllowedEmailDomains[i]))
+		}
+		policy.AllowedEmailDomains[i] = domain
+	}
+	if policy.Mode == storepb.RegistrationPolicy_ALLOWED_EMAIL_DOMAINS && len(policy.AllowedEmailDomains) == 0 {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("allowed email domains are required"))
+	}
+	return nil
+}
+
+func updateStorageSetting(storage *storepb.InstanceStorageSetting, update *v1pb.InstanceSetting_StorageSetting, paths []string) error {
+	for _, path := range paths {
+		switch path {
+		case "storage_type":
+			storage.StorageType = storepb.InstanceStorageSetting_StorageType(update.GetStorageType())
+		case "filepath_template":
+			storage.FilepathTemplate = update.GetFilepathTemplate()
+		case "upload_size_limit_mb":
+			storage.UploadSizeLimitMb = update.GetUploadSizeLimitMb()
+		case "s3_config":
+			// The secret is only changed with its own path, it is never returned to be sent back.
+			secret := storage.GetS3Config().GetAccessKeySecret()
+			storage.S3Config = convertS3ConfigToStore(update.GetS3Config())
+			if storage.S3Config != nil {
+				storage.S3Config.AccessKeySecret = secret
+			}
+		case "s3_config.access_key_secret":
+			if storage.S3Config == nil {
+				storage.S3Config = &storepb.StorageS3Config{}
+			}
+			storage.S3Config.AccessKeySecret = update.GetS3Config().GetAccessKeySecret()
+		default:
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unsupported update mask path %q", path))
+		}
+	}
+
+	if _, ok := storepb.InstanceStorageSetting_StorageType_name[int32(storage.StorageType)]; !ok {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("invalid storage type"))
+	}
+	if template := storage.FilepathTemplate; template != "" {
+		if path.IsAbs(template) || strings.Contains(template, "\\") || slices.Contains(strings.Split(template, "/"), "..") {
+			return connect.NewError(connect.CodeInvalidArgument, errors.New("filepath template must be a relative path inside the data directory"))
+		}
+	}
+	// 0 restores the default.
+	if limit := storage.UploadSizeLimitMb; limit < 0 || limit > maxUploadSizeLimitMB {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("upload size limit must be between 1 and 1024 MiB, or 0 for the default"))
+	}
+	if storage.StorageType == storepb.InstanceStorageSetting_S3 {
+		return validateS3Config(storage.S3Config)
+	}
+	return nil
+}
+
+func validateS3Config(config *storepb.StorageS3Config) error {
+	if config == nil {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("s3 config is required for s3 storage"))
+	}
+	if config.AccessKeyId == "" || config.AccessKeySecret == "" {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("s3 access key is required"))
+	}
+	u, err := url.Parse(config.Endpoint)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("s3 endpoint must be an http or https url"))
+	}
+	if config.Region == "" {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("s3 region is required"))
+	}
+	if config.Bucket == "" {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("s3 bucket is required"))
+	}
+	return nil
+}
+
+// isValidLifetime reports whether a lifetime in seconds is 0 for the default or within the bounds.
+func isValidLifetime(seconds int32, minimum, maximum time.Duration) bool {
+	lifetime := time.Duration(seconds) * time.Second
+	return seconds == 0 || lifetime >= minimum && lifetime <= maximum
+}
+
+func convertInstanceSettingFromStore(setting *storepb.InstanceSetting) *v1pb.InstanceSetting {
+	switch value := setting.Value.(type) {
+	case *storepb.InstanceSetting_GeneralSetting:
+		return &v1pb.InstanceSetting{
+			Key: v1pb.InstanceSetting_GENERAL,
+			Value: &v1pb.InstanceSetting_GeneralSetting_{GeneralSetting: &v1pb.InstanceSetting_GeneralSetting{
+				Name:        value.GeneralSetting.GetName(),
+				Description: value.GeneralSetting.GetDescription(),
+				Locale:      value.GeneralSetting.GetLocale(),
+			}},
+		}
+	case *storepb.InstanceSetting_SecuritySetting:
+		security := value.SecuritySetting
+		return &v1pb.InstanceSetting{
+			Key: v1pb.InstanceSetting_SECURITY,
+			Value: &v1pb.InstanceSetting_SecuritySetting_{SecuritySetting: &v1pb.InstanceSetting_SecuritySetting{
+				AccessTokenLifetimeSeconds:  security.GetAccessTokenLifetimeSeconds(),
+				RefreshTokenLifetimeSeconds: security.GetRefreshTokenLifetimeSeconds(),
+				Registration:                convertRegistrationPolicyFromStore(security.GetRegistration()),
+				RateLimit:                   convertRateLimitPolicyFromStore(security.GetRateLimit()),
+				CorsAllowedOrigins:          security.GetCorsAllowedOrigins(),
+			}},
+		}
+	case *storepb.InstanceSetting_StorageSetting:
+		storage := value.StorageSetting
+		storageSetting := &v1pb.InstanceSetting_StorageSetting{
+			StorageType:       v1pb.InstanceSetting_StorageSetting_StorageType(storage.GetStorageType()),
+			FilepathTemplate:  storage.GetFilepathTemplate(),
+			UploadSizeLimitMb: storage.GetUploadSizeLimitMb(),
+		}
+		if config := storage.GetS3Config(); config != nil {
+			storageSetting.S3Config = &v1pb.InstanceSetting_S3Config{
+				AccessKeyId:        config.AccessKeyId,
+				Endpoint:           config.Endpoint,
+				Region:             config.Region,
+				Bucket:             config.Bucket,
+				UsePathStyle:       config.UsePathStyle,
+				HasAccessKeySecret: config.AccessKeySecret != "",
+			}
+		}
+		return &v1pb.InstanceSetting{
+			Key:   v1pb.InstanceSetting_STORAGE,
+			Value: &v1pb.InstanceSetting_StorageSetting_{StorageSetting: storageSetting},
+		}
+	default:
+		return nil
+	}
+}
+
+func convertRegistrationPolicyFromStore(policy *storepb.RegistrationPolicy) *v1pb.InstanceSetting_RegistrationPolicy {
+	if policy == nil {
+		return nil
+	}
+	return &v1pb.InstanceSetting_RegistrationPolicy{
+		Mode:                v1pb.InstanceSetting_RegistrationPolicy_Mode(policy.Mode),
+		AllowedEmailDomains: policy.AllowedEmailDomains,
+	}
+}
+
+func convertRegistrationPolicyToStore(policy *v1pb.InstanceSetting_RegistrationPolicy) *storepb.RegistrationPolicy {
+	if policy == nil {
+		return nil
+	}
+	return &storepb.RegistrationPolicy{
+		Mode:                storepb.RegistrationPolicy_Mode(policy.Mode),
+		AllowedEmailDomains: policy.AllowedEmailDomains,
+	}
+}
+
+func convertRateLimitPolicyFromStore(policy *storepb.RateLimitPolicy) *v1pb.InstanceSetting_RateLimitPolicy {
+	if policy == nil {
+		return nil
+	}
+	return &v1pb.InstanceSetting_RateLimitPolicy{
+		RequestsPerSecond: policy.RequestsPerSecond,
+		Burst:             policy.Burst,
+	}
+}
+
+func convertRateLimitPolicyToStore(policy *v1pb.InstanceSetting_RateLimitPolicy) *storepb.RateLimitPolicy {
+	if policy == nil {
+		return nil
+	}
+	return &storepb.RateLimitPolicy{
+		RequestsPerSecond: policy.RequestsPerSecond,
+		Burst:             policy.Burst,
+	}
+}
+
+func convertS3ConfigToStore(config *v1pb.InstanceSetting_S3Config) *storepb.StorageS3Config {
+	if config == nil {
+		return nil
+	}
+	return &storepb.StorageS3Config{
+		AccessKeyId:  config.AccessKeyId,
+		Endpoint:     config.Endpoint,
+		Region:       config.Region,
+		Bucket:       config.Bucket,
+		UsePathStyle: config.UsePathStyle,
+	}
+}
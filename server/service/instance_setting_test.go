@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	storepb "github.com/pixb/go-server/proto/gen/store"
+	"github.com/pixb/go-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// upsertedSetting returns a function that returns the last setting passed to UpsertInstanceSetting.
+func upsertedSetting(mockStore *MockStore) func() *storepb.InstanceSetting {
+	var upserted *storepb.InstanceSetting
+	mockStore.On("UpsertInstanceSetting", mock.Anything, mock.AnythingOfType("*store.InstanceSetting")).Run(func(args mock.Arguments) {
+		upserted = args.Get(1).(*storepb.InstanceSetting)
+	}).Return(&storepb.InstanceSetting{}, nil)
+	return func() *storepb.InstanceSetting { return upserted }
+}
+
+func TestInstanceService_GetInstanceSetting(t *testing.T) {
+	mockStore := new(MockStore)
+	instanceService := NewInstanceService("1.0.0", false, mockStore)
+	ctx := permissionContext(1, store.PermissionSettingsRead)
+
+	_, err := instanceService.GetInstanceSetting(context.Background(), &v1pb.GetInstanceSettingRequest{Key: v1pb.InstanceSetting_GENERAL})
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	_, err = instanceService.GetInstanceSetting(permissionContext(2, store.PermissionUsersRead), &v1pb.GetInstanceSettingRequest{Key: v1pb.InstanceSetting_GENERAL})
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	_, err = instanceService.GetInstanceSetting(ctx, &v1pb.GetInstanceSettingRequest{})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	mockStore.On("GetInstanceGeneralSetting", mock.Anything).Return(&storepb.InstanceGeneralSetting{Name: "Example", Locale: "en"}, nil)
+	resp, err := instanceService.GetInstanceSetting(ctx, &v1pb.GetInstanceSettingRequest{Key: v1pb.InstanceSetting_GENERAL})
+	require.NoError(t, err)
+	assert.Equal(t, v1pb.InstanceSetting_GENERAL, resp.Setting.Key)
+	assert.Equal(t, "Example", resp.Setting.GetGeneralSetting().Name)
+	assert.Equal(t, "en", resp.Setting.GetGeneralSetting().Locale)
+
+	// Secrets are never returned
+	mockStore.On("GetInstanceStorageSetting", mock.Anything).Return(&storepb.InstanceStorageSetting{
+		StorageType: storepb.InstanceStorageSetting_S3,
+		S3Config:    &storepb.StorageS3Config{AccessKeyId: "key", AccessKeySecret: "secret", Bucket: "files"},
+	}, nil)
+	resp, err = instanceService.GetInstanceSetting(ctx, &v1pb.GetInstanceSettingRequest{Key: v1pb.InstanceSetting_STORAGE})
+	require.NoError(t, err)
+	s3Config := resp.Setting.GetStorageSetting().S3Config
+	require.NotNil(t, s3Config)
+	assert.Empty(t, s3Config.AccessKeySecret)
+	assert.True(t, s3Config.HasAccessKeySecret)
+	assert.Equal(t, "files", s3Config.Bucket)
+}
+
+func TestInstanceService_UpdateInstanceSetting(t *testing.T) {
+	mockStore := new(MockStore)
+	instanceService := NewInstanceService("1.0.0", false, mockStore)
+	ctx := permissionContext(1, store.PermissionSettingsRead, store.PermissionSettingsUpdate)
+	upserted := upsertedSetting(mockStore)
+
+	securitySetting := &storepb.InstanceSecuritySetting{
+		AccountLockout:           &storepb.AccountLockoutPolicy{MaxAccountFailures: 3},
+		RequireEmailVerification: true,
+	}
+	mockStore.On("GetInstanceSecuritySetting", mock.Anything).Return(securitySetting, nil)
+	update := func(ctx context.Context, setting *v1pb.InstanceSetting, paths ...string) (*v1pb.UpdateInstanceSettingResponse, error) {
+		return instanceService.UpdateInstanceSetting(ctx, &v1pb.UpdateInstanceSettingRequest{
+			Setting:    setting,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: paths},
+		})
+	}
+	security := func(setting *v1pb.InstanceSetting_SecuritySetting) *v1pb.InstanceSetting {
+		return &v1pb.InstanceSetting{
+			Key:   v1pb.InstanceSetting_SECURITY,
+			Value: &v1pb.InstanceSetting_SecuritySetting_{SecuritySetting: setting},
+		}
+	}
+
+	_, err := update(permissionContext(2, store.PermissionSettingsRead), security(&v1pb.InstanceSetting_SecuritySetting{AccessTokenLifetimeSeconds: 600}), "access_token_lifetime_seconds")
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	// Only the fields of the mask change, fields the API does not expose are kept
+	resp, err := update(ctx, security(&v1pb.InstanceSetting_SecuritySetting{
+		AccessTokenLifetimeSeconds:  600,
+		RefreshTokenLifetimeSeconds: 7200,
+		Registration: &v1pb.InstanceSetting_RegistrationPolicy{
+			Mode:                v1pb.InstanceSetting_RegistrationPolicy_ALLOWED_EMAIL_DOMAINS,
+			AllowedEmailDomains: []string{"Example.com"},
+		},
+	}), "access_token_lifetime_seconds", "registration")
+	require.NoError(t, err)
+	assert.Equal(t, int32(600), resp.Setting.GetSecuritySetting().AccessTokenLifetimeSeconds)
+	assert.Zero(t, resp.Setting.GetSecuritySetting().RefreshTokenLifetimeSeconds)
+	saved := upserted().GetSecuritySetting()
+	assert.Equal(t, storepb.InstanceSettingKey_SECURITY, upserted().Key)
+	assert.Equal(t, int32(3), saved.AccountLockout.MaxAccountFailures)
+	assert.True(t, saved.RequireEmailVerification)
+	assert.Equal(t, []string{"example.com"}, saved.Registration.AllowedEmailDomains)
+	// The cached setting is not modified in place
+	assert.Zero(t, securitySetting.AccessTokenLifetimeSeconds)
+
+	for _, tc := range []struct {
+		setting *v1pb.InstanceSetting
+		paths   []string
+	}{
+		{security(&v1pb.InstanceSetting_SecuritySetting{AccessTokenLifetimeSeconds: 60}), []string{"access_token_lifetime_seconds"}},
+		{security(&v1pb.InstanceSetting_SecuritySetting{AccessTokenLifetimeSeconds: 3 * 86400}), []string{"access_token_lifetime_seconds"}},
+		{security(&v1pb.InstanceSetting_SecuritySetting{RefreshTokenLifetimeSeconds: 3600}), []string{"refresh_token_lifetime_seconds"}},
+		{security(&v1pb.InstanceSetting_SecuritySetting{Registration: &v1pb.InstanceSetting_RegistrationPolicy{Mode: v1pb.InstanceSetting_RegistrationPolicy_ALLOWED_EMAIL_DOMAINS}}), []string{"registration"}},
+		{security(&v1pb.InstanceSetting_SecuritySetting{Registration: &v1pb.InstanceSetting_RegistrationPolicy{AllowedEmailDomains: []string{"not a domain"}}}), []string{"registration"}},
+		{security(&v1pb.InstanceSetting_SecuritySetting{RateLimit: &v1pb.InstanceSetting_RateLimitPolicy{RequestsPerSecond: -1}}), []string{"rate_limit"}},
+		{security(&v1pb.InstanceSetting_SecuritySetting{RateLimit: &v1pb.InstanceSetting_RateLimitPolicy{RequestsPerSecond: 10, Burst: 20000}}), []string{"rate_limit"}},
+		{security(&v1pb.InstanceSetting_SecuritySetting{CorsAllowedOrigins: []string{"*"}}), []string{"cors_allowed_origins"}},
+		{security(&v1pb.InstanceSetting_SecuritySetting{CorsAllowedOrigins: []string{"https://app.example.com/path"}}), []string{"cors_allowed_origins"}},
+		{security(&v1pb.InstanceSetting_SecuritySetting{}), []string{"account_lockout"}},
+		{security(&v1pb.InstanceSetting_SecuritySetting{}), nil},
+		{&v1pb.InstanceSetting{Key: v1pb.InstanceSetting_SECURITY, Value: &v1pb.InstanceSetting_GeneralSetting_{GeneralSetting: &v1pb.InstanceSetting_GeneralSetting{}}}, []string{"name"}},
+	} {
+		_, err = update(ctx, tc.setting, tc.paths...)
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "%v %v", tc.setting, tc.paths)
+	}
+	mockStore.AssertNumberOfCalls(t, "UpsertInstanceSetting", 1)
+
+	// Origins are normalized to what browsers send
+	resp, err = update(ctx, security(&v1pb.InstanceSetting_SecuritySetting{
+		RateLimit:          &v1pb.InstanceSetting_RateLimitPolicy{RequestsPerSecond: 5, Burst: 10},
+		CorsAllowedOrigins: []string{"HTTPS://App.Example.com", "http://localhost:3000"},
+	}), "rate_limit", "cors_allowed_origins")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://app.example.com", "http://localhost:3000"}, resp.Setting.GetSecuritySetting().CorsAllowedOrigins)
+	saved = upserted().GetSecuritySetting()
+	assert.Equal(t, 5.0, saved.RateLimit.RequestsPerSecond)
+	assert.Equal(t, int32(10), saved.RateLimit.Burst)
+
+	mockStore.On("GetInstanceGeneralSetting", mock.Anything).Return(&storepb.InstanceGeneralSetting{Name: "Example"}, nil)
+	general := &v1pb.InstanceSetting{
+		Key: v1pb.InstanceSetting_GENERAL,
+		Value: &v1pb.InstanceSetting_GeneralSetting_{GeneralSetting: &v1pb.InstanceSetting_GeneralSetting{
+			Description: "An example instance",
+			Locale:      "zh-Hans",
+		}},
+	}
+	resp, err = update(ctx, general, "description", "locale")
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(&v1pb.InstanceSetting_GeneralSetting{Name: "Example", Description: "An example instance", Locale: "zh-Hans"}, resp.Setting.GetGeneralSetting()))
+	_, err = update(ctx, general, "name")
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestInstanceService_UpdateStorageSetting(t *testing.T) {
+	mockStore := new(MockStore)
+	instanceService := NewInstanceService("1.0.0", false, mockStore)
+	ctx := permissionContext(1, store.PermissionSettingsUpdate)
+	upserted := upsertedSetting(mockStore)
+	mockStore.On("GetInstanceStorageSetting", mock.Anything).Return(&storepb.InstanceStorageSetting{
+		S3Config: &storepb.StorageS3Config{AccessKeyId: "old", AccessKeySecret: "secret"},
+	}, nil)
+
+	s3Config := &v1pb.InstanceSetting_S3Config{
+		AccessKeyId: "new",
+		Endpoint:    "https://s3.example.com",
+		Region:      "us-east-1",
+		Bucket:      "files",
+	}
+	storage := &v1pb.InstanceSetting{
+		Key: v1pb.InstanceSetting_STORAGE,
+		Value: &v1pb.InstanceSetting_StorageSetting_{StorageSetting: &v1pb.InstanceSetting_StorageSetting{
+			StorageType: v1pb.InstanceSetting_StorageSetting_S3,
+			S3Config:    s3Config,
+		}},
+	}
+	// The stored secret is kept unless its own path is given
+	resp, err := instanceService.UpdateInstanceSetting(ctx, &v1pb.UpdateInstanceSettingRequest{
+		Setting:    storage,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"storage_type", "s3_config"}},
+	})
+	require.NoError(t, err)
+	assert.True(t, resp.Setting.GetStorageSetting().S3Config.HasAccessKeySecret)
+	assert.Equal(t, "secret", upserted().GetStorageSetting().S3Config.AccessKeySecret)
+	assert.Equal(t, "new", upserted().GetStorageSetting().S3Config.AccessKeyId)
+
+	// S3 storage cannot be used without a secret
+	_, err = instanceService.UpdateInstanceSetting(ctx, &v1pb.UpdateInstanceSettingRequest{
+		Setting:    storage,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"storage_type", "s3_config", "s3_config.access_key_secret"}},
+	})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	for _, storageSetting := range []*v1pb.InstanceSetting_StorageSetting{
+		{FilepathTemplate: "../outside/{filename}"},
+		{FilepathTemplate: "/etc/{filename}"},
+		{UploadSizeLimitMb: 4096},
+		{UploadSizeLimitMb: -1},
+	} {
+		_, err = instanceService.UpdateInstanceSetting(ctx, &v1pb.UpdateInstanceSettingRequest{
+			Setting:    &v1pb.InstanceSetting{Key: v1pb.InstanceSetting_STORAGE, Value: &v1pb.InstanceSetting_StorageSetting_{StorageSetting: storageSetting}},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"filepath_template", "upload_size_limit_mb"}},
+		})
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	}
+
+	// 0 restores the default limit
+	_, err = instanceService.UpdateInstanceSetting(ctx, &v1pb.UpdateInstanceSettingRequest{
+		Setting:    &v1pb.InstanceSetting{Key: v1pb.InstanceSetting_STORAGE, Value: &v1pb.InstanceSetting_StorageSetting_{StorageSetting: &v1pb.InstanceSetting_StorageSetting{}}},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"upload_size_limit_mb"}},
+	})
+	require.NoError(t, err)
+	assert.Zero(t, upserted().GetStorageSetting().UploadSizeLimitMb)
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+
+	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	storepb "github.com/pixb/go-server/proto/gen/store"
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/store"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// defaultInviteLifetime is how long invites created without expires_at can be used.
+	defaultInviteLifetime = 7 * 24 * time.Hour
+	maxInviteUses         = 10000
+)
+
+var errInvalidInvite = connect.NewError(connect.CodePermissionDenied, errors.New("invite code is invalid or expired"))
+
+type inviteStore interface {
+	CreateInvite(ctx context.Context, create *store.CreateInvite) (*store.Invite, error)
+	ListInvites(ctx context.Context, find *store.FindInvite) ([]*store.Invite, error)
+	GetInvite(ctx context.Context, find *store.FindInvite) (*store.Invite, error)
+	DeleteInvite(ctx context.Context, delete *store.DeleteInvite) error
+}
+
+func (s *AdminService) CreateInvite(ctx context.Context, req *v1pb.CreateInviteRequest) (*v1pb.CreateInviteResponse, error) {
+	if err := auth.Require(ctx, store.PermissionUsersWrite); err != nil {
+		return nil, err
+	}
+
+	role := store.RoleUser
+	if req.Role != v1pb.Role_ROLE_UNSPECIFIED {
+		role = auth.RoleToString(req.Role)
+		if role == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid role"))
+		}
+	}
+	if err := checkRoleGrantable(ctx, s.Store, role); err != nil {
+		return nil, err
+	}
+	maxUses := req.MaxUses
+	if maxUses == 0 {
+		maxUses = 1
+	}
+	if maxUses < 0 || maxUses > maxInviteUses {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("max_uses must be between 1 and 10000"))
+	}
+	expiresAt := time.Now().Add(defaultInviteLifetime)
+	if req.ExpiresAt != nil {
+		expiresAt = req.ExpiresAt.AsTime()
+		if !expiresAt.After(time.Now()) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("expires_at must be in the future"))
+		}
+	}
+
+	code, err := auth.GenerateOneTimeToken()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to generate invite code"))
+	}
+	invite, err := s.Store.CreateInvite(ctx, &store.CreateInvite{
+		CodeHash:  auth.HashToken(code),
+		Role:      role,
+		MaxUses:   maxUses,
+		ExpiresAt: expiresAt,
+		CreatorID: auth.GetUserID(ctx),
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to create invite"))
+	}
+
+	return &v1pb.CreateInviteResponse{
+		Invite: convertInviteFromStore(invite),
+		Code:   code,
+	}, nil
+}
+
+func (s *AdminService) ListInvites(ctx context.Context, req *v1pb.ListInvitesRequest) (*v1pb.ListInvitesResponse, error) {
+	if err := auth.Require(ctx, store.PermissionUsersRead); err != nil {
+		return nil, err
+	}
+
+	invites, err := s.Store.ListInvites(ctx, &store.FindInvite{})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to list invites"))
+	}
+	response := &v1pb.ListInvitesResponse{}
+	for _, invite := range invites {
+		response.Invites = append(response.Invites, convertInviteFromStore(invite))
+	}
+	return response, nil
+}
+
+func (s *AdminService) RevokeInvite(ctx context.Context, req *v1pb.RevokeInviteRequest) (*v1pb.RevokeInviteResponse, error) {
+	if err := auth.Require(ctx, store.PermissionUsersWrite); err != nil {
+		return nil, err
+	}
+
+	invite, err := s.Store.GetInvite(ctx, &store.FindInvite{ID: &req.Id})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get invite"))
+	}
+	if invite == nil {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("invite not found"))
+	}
+	if err := s.Store.DeleteInvite(ctx, &store.DeleteInvite{ID: req.Id}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to revoke invite"))
+	}
+	return &v1pb.RevokeInviteResponse{}, nil
+}
+
+// checkRegistrationPolicy checks that the registration policy lets someone with the email
+// address register. hasInvite tells whether an invite code was given, it is checked when
+// the user is created.
+func checkRegistrationPolicy(policy *storepb.RegistrationPolicy, email string, hasInvite bool) error {
+	switch policy.GetMode() {
+	case storepb.RegistrationPolicy_DISABLED:
+		return connect.NewError(connect.CodePermissionDenied, errors.New("registration is disabled"))
+	case storepb.RegistrationPolicy_INVITE_ONLY:
+		if !hasInvite {
+			return connect.NewError(connect.CodePermissionDenied, errors.New("an invite code is required to register"))
+		}
+	case storepb.RegistrationPolicy_ALLOWED_EMAIL_DOMAINS:
+		if !hasInvite && !isAllowedEmailDomain(policy.AllowedEmailDomains, email) {
+			return connect.NewError(connect.CodePermissionDenied, errors.New("registration is not open to this email domain"))
+		}
+	}
+	return nil
+}
+
+func isAllowedEmailDomain(domains []string, email string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := email[at+1:]
+	return slices.ContainsFunc(domains, func(allowed string) bool {
+		return strings.EqualFold(strings.TrimPrefix(allowed, "@"), domain)
+	})
+}
+
+// findUsableInvite returns the invite of a code, or errInvalidInvite if it cannot be used.
+// The store checks the uses and the expiry again when it counts the use.
+func findUsableInvite(ctx context.Context, s UserStore, code string) (*store.Invite, error) {
+	codeHash := auth.HashToken(code)
+	invite, err := s.GetInvite(ctx, &store.FindInvite{CodeHash: &codeHash})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get invite"))
+	}
+	if invite == nil || invite.UseCount >= invite.MaxUses || !time.Now().Before(invite.ExpiresAt) {
+		return nil, errInvalidInvite
+	}
+	return invite, nil
+}
+
+func convertInviteFromStore(invite *store.Invite) *v1pb.Invite {
+	return &v1pb.Invite{
+		Id:        invite.ID,
+		Role:      auth.StringToRole(invite.Role),
+		MaxUses:   invite.MaxUses,
+		UseCount:  invite.UseCount,
+		ExpiresAt: timestamppb.New(invite.ExpiresAt),
+		CreatorId: invite.CreatorID,
+		CreatedAt: timestamppb.New(invite.CreatedAt),
+	}
+}
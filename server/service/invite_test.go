@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	storepb "github.com/pixb/go-server/proto/gen/store"
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/server/notify"
+	"github.com/pixb/go-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestAdminService_Invites(t *testing.T) {
+	adminService, mockStore, ctx := newAdminServiceTest(t)
+
+	var created *store.CreateInvite
+	mockStore.On("CreateInvite", mock.Anything, mock.AnythingOfType("*store.CreateInvite")).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*store.CreateInvite)
+	}).Return(&store.Invite{ID: 1, Role: store.RoleUser, MaxUses: 1, ExpiresAt: time.Now().Add(defaultInviteLifetime), CreatorID: 1}, nil)
+	resp, err := adminService.CreateInvite(ctx, &v1pb.CreateInviteRequest{})
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	assert.NotEmpty(t, resp.Code)
+	// Only the hash of the code is stored
+	assert.Equal(t, auth.HashToken(resp.Code), created.CodeHash)
+	assert.Equal(t, store.RoleUser, created.Role)
+	assert.Equal(t, int32(1), created.MaxUses)
+	assert.Equal(t, int64(1), created.CreatorID)
+	assert.WithinDuration(t, time.Now().Add(defaultInviteLifetime), created.ExpiresAt, time.Minute)
+	assert.Equal(t, v1pb.Role_ROLE_USER, resp.Invite.Role)
+
+	for _, req := range []*v1pb.CreateInviteRequest{
+		{MaxUses: -1},
+		{MaxUses: maxInviteUses + 1},
+		{ExpiresAt: timestamppb.New(time.Now().Add(-time.Minute))},
+	} {
+		_, err = adminService.CreateInvite(ctx, req)
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	}
+
+	// Invites cannot grant more than the caller has
+	writerCtx := permissionContext(3, store.PermissionUsersRead, store.PermissionUsersWrite)
+	_, err = adminService.CreateInvite(writerCtx, &v1pb.CreateInviteRequest{Role: v1pb.Role_ROLE_ADMIN})
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	mockStore.On("ListInvites", mock.Anything, &store.FindInvite{}).Return([]*store.Invite{{ID: 1, Role: store.RoleUser, MaxUses: 1}}, nil)
+	listResp, err := adminService.ListInvites(permissionContext(3, store.PermissionUsersRead), &v1pb.ListInvitesRequest{})
+	require.NoError(t, err)
+	require.Len(t, listResp.Invites, 1)
+	assert.Equal(t, int64(1), listResp.Invites[0].Id)
+
+	inviteID, missingID := int64(1), int64(2)
+	mockStore.On("GetInvite", mock.Anything, &store.FindInvite{ID: &inviteID}).Return(&store.Invite{ID: inviteID, Role: store.RoleUser}, nil)
+	mockStore.On("GetInvite", mock.Anything, &store.FindInvite{ID: &missingID}).Return(nil, nil)
+	mockStore.On("DeleteInvite", mock.Anything, &store.DeleteInvite{ID: inviteID}).Return(nil)
+	_, err = adminService.RevokeInvite(ctx, &v1pb.RevokeInviteRequest{Id: inviteID})
+	require.NoError(t, err)
+	_, err = adminService.RevokeInvite(ctx, &v1pb.RevokeInviteRequest{Id: missingID})
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestUserService_RegistrationPolicy(t *testing.T) {
+	ctx := context.Background()
+	register := func(mockStore *MockStore, email, inviteCode string) error {
+		_, err := NewUserService("testsecret", mockStore).RegisterUser(ctx, &v1pb.RegisterUserRequest{
+			Username:   "testuser",
+			Email:      email,
+			Password:   "Brand-new-pass1",
+			Nickname:   "Test User",
+			InviteCode: inviteCode,
+		})
+		return err
+	}
+	policyStore := func(policy *storepb.RegistrationPolicy) *MockStore {
+		mockStore := new(MockStore)
+		mockStore.On("GetInstanceSecuritySetting", mock.Anything).Return(&storepb.InstanceSecuritySetting{Registration: policy}, nil)
+		return mockStore
+	}
+
+	err := register(policyStore(&storepb.RegistrationPolicy{Mode: storepb.RegistrationPolicy_DISABLED}), "test@example.com", "code")
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	err = register(policyStore(&storepb.RegistrationPolicy{Mode: storepb.RegistrationPolicy_INVITE_ONLY}), "test@example.com", "")
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	err = register(policyStore(&storepb.RegistrationPolicy{
+		Mode:                storepb.RegistrationPolicy_ALLOWED_EMAIL_DOMAINS,
+		AllowedEmailDomains: []string{"example.com"},
+	}), "test@mail.example.com", "")
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	// Unknown, expired and used up codes are rejected in every mode
+	codeHash := auth.HashToken("code")
+	for _, invite := range []*store.Invite{
+		nil,
+		{ID: 1, Role: store.RoleUser, MaxUses: 1, ExpiresAt: time.Now().Add(-time.Minute)},
+		{ID: 1, Role: store.RoleUser, MaxUses: 1, UseCount: 1, ExpiresAt: time.Now().Add(time.Hour)},
+	} {
+		mockStore := policyStore(nil)
+		if invite == nil {
+			mockStore.On("GetInvite", mock.Anything, &store.FindInvite{CodeHash: &codeHash}).Return(nil, nil)
+		} else {
+			mockStore.On("GetInvite", mock.Anything, &store.FindInvite{CodeHash: &codeHash}).Return(invite, nil)
+		}
+		err = register(mockStore, "test@example.com", "code")
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+		mockStore.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+	}
+}
+
+func TestUserService_RegisterWithInvite(t *testing.T) {
+	mockStore := new(MockStore)
+	codeHash := auth.HashToken("code")
+	invite := &store.Invite{ID: 7, Role: store.RoleAdmin, MaxUses: 2, UseCount: 1, ExpiresAt: time.Now().Add(time.Hour)}
+	mockStore.On("GetInstanceSecuritySetting", mock.Anything).Return(&storepb.InstanceSecuritySetting{
+		Registration: &storepb.RegistrationPolicy{
+			Mode:                storepb.RegistrationPolicy_ALLOWED_EMAIL_DOMAINS,
+			AllowedEmailDomains: []string{"example.com"},
+		},
+	}, nil)
+	mockStore.On("GetInvite", mock.Anything, &store.FindInvite{CodeHash: &codeHash}).Return(invite, nil)
+	mockStore.On("GetInstancePasswordPolicySetting", mock.Anything).Return(&storepb.InstancePasswordPolicySetting{}, nil)
+	mockStore.On("GetUserByUsername", mock.Anything, "testuser").Return(nil, nil)
+	mockStore.On("GetUserByEmail", mock.Anything, "test@other.org").Return(nil, nil)
+
+	// The invite is counted by the store when the user is created, a lost race is rejected
+	mockStore.On("UseInvite", mock.Anything, mock.MatchedBy(func(use *store.UseInvite) bool {
+		return use.ID == invite.ID && use.User.Role == store.RoleAdmin
+	})).Return(nil, nil).Once()
+	req := &v1pb.RegisterUserRequest{
+		Username:   "testuser",
+		Email:      "test@other.org",
+		Password:   "Brand-new-pass1",
+		Nickname:   "Test User",
+		Phone:      "13800138000",
+		InviteCode: "code",
+	}
+	userService := NewUserService("testsecret", mockStore)
+	_, err := userService.RegisterUser(context.Background(), req)
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	// Invites are accepted outside the allowed email domains and grant their role
+	mockStore.On("UseInvite", mock.Anything, mock.AnythingOfType("*store.UseInvite")).Return(&store.User{ID: 1, Username: "testuser", Email: req.Email, Role: store.RoleAdmin}, nil)
+	mockStore.On("CreatePasswordHistory", mock.Anything, mock.AnythingOfType("*store.CreatePasswordHistory")).Return(&store.PasswordHistory{ID: 1, UserID: 1}, nil)
+	mockStore.On("ListPasswordHistories", mock.Anything, &store.FindPasswordHistory{UserID: 1, Limit: 1}).Return([]*store.PasswordHistory{{ID: 1, UserID: 1}}, nil)
+	mockStore.On("DeletePasswordHistories", mock.Anything, &store.DeletePasswordHistory{UserID: 1, BeforeID: 1}).Return(nil)
+	mockStore.On("DeleteEmailVerifications", mock.Anything, &store.DeleteEmailVerification{UserID: 1}).Return(nil)
+	mockStore.On("CreateEmailVerification", mock.Anything, mock.AnythingOfType("*store.CreateEmailVerification")).Return(&store.EmailVerification{ID: 1, UserID: 1, Email: req.Email}, nil)
+	userService.Notifier = notify.NewMemoryNotifier()
+	resp, err := userService.RegisterUser(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, v1pb.Role_ROLE_ADMIN, resp.User.Role)
+	mockStore.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/pixb/go-server/server/ldap"
+	"github.com/pixb/go-server/store"
+)
+
+// LDAPIdentityProvider is the provider of the identities of directory users, their
+// subject is the DN of their entry.
+const LDAPIdentityProvider = "ldap"
+
+// directoryUserStore is an interface that defines the methods needed by isDirectoryUser
+type directoryUserStore interface {
+	GetUserIdentity(ctx context.Context, find *store.FindUserIdentity) (*store.UserIdentity, error)
+}
+
+// isDirectoryUser reports whether the user signs in through the LDAP directory. Directory
+// users have no local password, one would keep working after their account is disabled in
+// the directory.
+func isDirectoryUser(ctx context.Context, s directoryUserStore, userID int64) (bool, error) {
+	provider := LDAPIdentityProvider
+	identity, err := s.GetUserIdentity(ctx, &store.FindUserIdentity{UserID: &userID, Provider: &provider})
+	if err != nil {
+		return false, connect.NewError(connect.CodeInternal, errors.New("failed to get user identity"))
+	}
+	return identity != nil, nil
+}
+
+// LDAPVerifierStore is an interface that defines the methods needed by LDAPVerifier
+type LDAPVerifierStore interface {
+	identityStore
+	UpdateUser(ctx context.Context, update *store.UpdateUser) (*store.User, error)
+	ListRoleDefinitions(ctx context.Context, find *store.FindRoleDefinition) ([]*store.RoleDefinition, error)
+	SetUserRoles(ctx context.Context, set *store.SetUserRoles) error
+}
+
+// LDAPVerifier checks passwords against an LDAP directory. Directory users are provisioned
+// on their first sign-in and linked by the DN of their entry, and their mapped attributes
+// and roles are updated from the directory on every sign-in.
+type LDAPVerifier struct {
+	Client *ldap.Client
+	Store  LDAPVerifierStore
+	// GroupRoles maps the DNs of directory groups to roles. When it is set, the directory
+	// decides the roles of its users: admin if they are in a group mapped to it, otherwise
+	// user, and the custom roles of their groups.
+	GroupRoles map[string]store.Role
+}
+
+func NewLDAPVerifier(client *ldap.Client, store LDAPVerifierStore, groupRoles map[string]store.Role) *LDAPVerifier {
+	return &LDAPVerifier{Client: client, Store: store, GroupRoles: groupRoles}
+}
+
+func (v *LDAPVerifier) VerifyCredentials(ctx context.Context, username, password string) (*store.User, error) {
+	entry, err := v.Client.Authenticate(ctx, username, password)
+	if errors.Is(err, ldap.ErrInvalidCredentials) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		slog.Error("failed to verify credentials with the directory", slog.Any("error", err))
+		return nil, connect.NewError(connect.CodeUnavailable, errors.New("directory is unavailable"))
+	}
+
+	identity := &ExternalIdentity{
+		Provider: LDAPIdentityProvider,
+		Subject:  entry.DN,
+		Username: entry.Username,
+		Nickname: entry.Nickname,
+		Email:    entry.Email,
+		Phone:    entry.Phone,
+		// The directory is trusted with the addresses of its users.
+		EmailVerified: entry.Email != "",
+	}
+	if identity.Username == "" {
+		identity.Username = username
+	}
+	user, err := findOrProvisionUser(ctx, v.Store, identity)
+	if err != nil {
+		return nil, err
+	}
+	if user, err = v.syncAttributes(ctx, user, identity); err != nil {
+		return nil, err
+	}
+	if len(v.GroupRoles) > 0 {
+		if user, err = v.syncRoles(ctx, user, entry.Groups); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// syncAttributes updates the mapped attributes that changed in the directory.
+func (v *LDAPVerifier) syncAttributes(ctx context.Context, user *store.User, identity *ExternalIdentity) (*store.User, error) {
+	update := &store.UpdateUser{ID: user.ID}
+	changed := false
+	if nickname := truncate(identity.Nickname, 50); nickname != "" && nickname != user.Nickname {
+		update.Nickname = &nickname
+		changed = true
+	}
+	if phone := truncate(identity.Phone, 20); phone != "" && phone != user.Phone {
+		update.Phone = &phone
+		changed = true
+	}
+	if identity.Email != "" && identity.Email != user.Email && len(identity.Email) <= 100 && isValidEmail(identity.Email) {
+		existingUser, err := v.Store.GetUserByEmail(ctx, identity.Email)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user"))
+		}
+		if existingUser == nil {
+			update.Email = &identity.Email
+			update.EmailVerified = &identity.EmailVerified
+			changed = true
+		} else {
+			slog.Warn("not updating the email address of a directory user, it belongs to another user",
+				slog.Int64("userID", user.ID))
+		}
+	}
+	if !changed {
+		return user, nil
+	}
+	now := time.Now()
+	update.UpdatedAt = &now
+	user, err := v.Store.UpdateUser(ctx, update)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to update user"))
+	}
+	return user, nil
+}
+
+// syncRoles sets the roles of the user from the groups mapped in GroupRoles.
+func (v *LDAPVerifier) syncRoles(ctx context.Context, user *store.User, groups []string) (*store.User, error) {
+	role := store.RoleUser
+	var customRoles []store.Role
+	for groupDN, mappedRole := range v.GroupRoles {
+		if !slices.ContainsFunc(groups, func(group string) bool { return ldap.SameDN(group, groupDN) }) {
+			continue
+		}
+		switch mappedRole {
+		case store.RoleAdmin:
+			role = store.RoleAdmin
+		case store.RoleUser:
+		default:
+			customRoles = append(customRoles, mappedRole)
+		}
+	}
+
+	if role != user.Role {
+		now := time.Now()
+		updatedUser, err := v.Store.UpdateUser(ctx, &store.UpdateUser{ID: user.ID, Role: &role, UpdatedAt: &now})
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to update user"))
+		}
+		slog.Info("updated the role of a directory user", slog.Int64("userID", user.ID), slog.String("role", string(role)))
+		user = updatedUser
+	}
+
+	roles, err := v.Store.ListRoleDefinitions(ctx, &store.FindRoleDefinition{})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to list roles"))
+	}
+	roleIDs := []int64{}
+	for _, role := range roles {
+		if !role.BuiltIn && slices.Contains(customRoles, role.Name) {
+			roleIDs = append(roleIDs, role.ID)
+		}
+	}
+	if err := v.Store.SetUserRoles(ctx, &store.SetUserRoles{UserID: user.ID, RoleIDs: roleIDs}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to set user roles"))
+	}
+	return user, nil
+}
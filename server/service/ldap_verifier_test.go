@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	storepb "github.com/pixb/go-server/proto/gen/store"
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/server/ldap"
+	"github.com/pixb/go-server/server/ldap/ldaptest"
+	"github.com/pixb/go-server/server/notify"
+	"github.com/pixb/go-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ldapAliceDN      = "uid=alice,ou=people,dc=example,dc=com"
+	ldapAdminsDN     = "cn=admins,ou=groups,dc=example,dc=com"
+	ldapDevelopersDN = "cn=developers,ou=groups,dc=example,dc=com"
+)
+
+func newLDAPVerifierTest(t *testing.T, groupRoles map[string]store.Role) (*LDAPVerifier, *ldaptest.Server, *MockStore) {
+	t.Helper()
+	directory := ldaptest.NewServer(
+		&ldaptest.Entry{DN: "cn=service,dc=example,dc=com", Password: "service-secret"},
+		&ldaptest.Entry{DN: ldapAliceDN, Password: "alice-secret", Attributes: map[string][]string{
+			"objectClass":     {"person"},
+			"uid":             {"alice"},
+			"displayName":     {"Alice Liddell"},
+			"mail":            {"alice@example.com"},
+			"telephoneNumber": {"+1 555 0100"},
+			"memberOf":        {ldapAdminsDN, ldapDevelopersDN},
+		}},
+	)
+	t.Cleanup(directory.Close)
+	client, err := ldap.NewClient(ldap.Config{
+		URL:               directory.URL(),
+		BindDN:            "cn=service,dc=example,dc=com",
+		BindPassword:      "service-secret",
+		BaseDN:            "ou=people,dc=example,dc=com",
+		NicknameAttribute: "displayName",
+		EmailAttribute:    "mail",
+		PhoneAttribute:    "telephoneNumber",
+	})
+	require.NoError(t, err)
+	mockStore := new(MockStore)
+	return NewLDAPVerifier(client, mockStore, groupRoles), directory, mockStore
+}
+
+func TestLDAPVerifier_Provisions(t *testing.T) {
+	verifier, _, mockStore := newLDAPVerifierTest(t, map[string]store.Role{
+		// Group DNs are compared case-insensitively
+		"CN=Admins,OU=Groups,DC=example,DC=com":   store.RoleAdmin,
+		ldapDevelopersDN:                          "developer",
+		"cn=auditors,ou=groups,dc=example,dc=com": "auditor",
+	})
+	provider, subject := LDAPIdentityProvider, ldapAliceDN
+	userID := int64(2)
+	user := &store.User{
+		ID:            userID,
+		Username:      "alice",
+		Nickname:      "Alice Liddell",
+		Email:         "alice@example.com",
+		Phone:         "+1 555 0100",
+		Role:          store.RoleUser,
+		EmailVerified: true,
+	}
+
+	mockStore.On("GetUserIdentity", mock.Anything, &store.FindUserIdentity{Provider: &provider, Subject: &subject}).Return(nil, nil)
+	mockStore.On("GetInstanceSecuritySetting", mock.Anything).Return(&storepb.InstanceSecuritySetting{}, nil)
+	mockStore.On("GetUserByEmail", mock.Anything, "alice@example.com").Return(nil, nil)
+	mockStore.On("GetUserByUsername", mock.Anything, "alice").Return(nil, nil)
+	mockStore.On("CreateUserIdentity", mock.Anything, mock.MatchedBy(func(create *store.CreateUserIdentity) bool {
+		return create.Provider == provider && create.Subject == subject &&
+			create.User.Username == "alice" && create.User.Nickname == "Alice Liddell" &&
+			create.User.Email == "alice@example.com" && create.User.EmailVerified &&
+			create.User.Phone == "+1 555 0100" && create.User.Role == store.RoleUser
+	})).Return(&store.UserIdentity{ID: 1, UserID: userID, Provider: provider, Subject: subject}, nil)
+	mockStore.On("GetUser", mock.Anything, &store.FindUser{ID: &userID}).Return(user, nil)
+	mockStore.On("UpdateUser", mock.Anything, mock.MatchedBy(func(update *store.UpdateUser) bool {
+		return update.ID == userID && update.Role != nil && *update.Role == store.RoleAdmin
+	})).Return(&store.User{ID: userID, Username: "alice", Role: store.RoleAdmin}, nil)
+	mockStore.On("ListRoleDefinitions", mock.Anything, mock.Anything).Return([]*store.RoleDefinition{
+		{ID: 1, Name: store.RoleAdmin, BuiltIn: true},
+		{ID: 2, Name: store.RoleUser, BuiltIn: true},
+		{ID: 5, Name: "developer"},
+		{ID: 6, Name: "auditor"},
+	}, nil)
+	mockStore.On("SetUserRoles", mock.Anything, &store.SetUserRoles{UserID: userID, RoleIDs: []int64{5}}).Return(nil)
+
+	provisioned, err := verifier.VerifyCredentials(context.Background(), "alice", "alice-secret")
+	require.NoError(t, err)
+	assert.Equal(t, userID, provisioned.ID)
+	assert.Equal(t, store.RoleAdmin, provisioned.Role)
+	mockStore.AssertExpectations(t)
+
+	_, err = verifier.VerifyCredentials(context.Background(), "alice", "wrong")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestLDAPVerifier_RegistrationPolicy(t *testing.T) {
+	verifier, _, mockStore := newLDAPVerifierTest(t, nil)
+	mockStore.On("GetUserIdentity", mock.Anything, mock.AnythingOfType("*store.FindUserIdentity")).Return(nil, nil)
+	mockStore.On("GetInstanceSecuritySetting", mock.Anything).Return(&storepb.InstanceSecuritySetting{
+		Registration: &storepb.RegistrationPolicy{Mode: storepb.RegistrationPolicy_DISABLED},
+	}, nil)
+
+	// Directory users are not provisioned while registration is disabled
+	_, err := verifier.VerifyCredentials(context.Background(), "alice", "alice-secret")
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	mockStore.AssertNotCalled(t, "CreateUserIdentity", mock.Anything, mock.Anything)
+}
+
+func TestLDAPVerifier_SyncsAttributes(t *testing.T) {
+	verifier, directory, mockStore := newLDAPVerifierTest(t, nil)
+	directory.SetAttribute(ldapAliceDN, "displayName", "Alice L.")
+	directory.SetAttribute(ldapAliceDN, "mail", "alice@wonderland.example")
+	userID := int64(2)
+
+	mockStore.On("GetUserIdentity", mock.Anything, mock.AnythingOfType("*store.FindUserIdentity")).
+		Return(&store.UserIdentity{ID: 1, UserID: userID, Provider: LDAPIdentityProvider, Subject: ldapAliceDN}, nil)
+	mockStore.On("GetUser", mock.Anything, &store.FindUser{ID: &userID}).Return(&store.User{
+		ID:       userID,
+		Username: "alice",
+		Nickname: "Alice Liddell",
+		Email:    "alice@example.com",
+		Phone:    "+1 555 0100",
+		Role:     store.RoleAdmin,
+	}, nil)
+	mockStore.On("GetUserByEmail", mock.Anything, "alice@wonderland.example").Return(nil, nil)
+	mockStore.On("UpdateUser", mock.Anything, mock.MatchedBy(func(update *store.UpdateUser) bool {
+		return update.ID == userID && *update.Nickname == "Alice L." && *update.Email == "alice@wonderland.example" &&
+			*update.EmailVerified && update.Phone == nil && update.Role == nil
+	})).Return(&store.User{ID: userID, Username: "alice", Nickname: "Alice L.", Email: "alice@wonderland.example", Role: store.RoleAdmin}, nil)
+
+	user, err := verifier.VerifyCredentials(context.Background(), "alice", "alice-secret")
+	require.NoError(t, err)
+	assert.Equal(t, "Alice L.", user.Nickname)
+	// Without a group mapping the roles are managed locally
+	assert.Equal(t, store.RoleAdmin, user.Role)
+	mockStore.AssertExpectations(t)
+	mockStore.AssertNotCalled(t, "SetUserRoles", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_LoginWithLDAP(t *testing.T) {
+	verifier, directory, mockStore := newLDAPVerifierTest(t, nil)
+	authService := NewAuthService("testsecret", mockStore)
+	authService.Verifiers = append(authService.Verifiers, verifier)
+	userID := int64(2)
+
+	mockStore.On("GetLoginAttempt", mock.Anything, mock.AnythingOfType("*store.FindLoginAttempt")).Return(nil, nil)
+	mockStore.On("GetTOTPCredential", mock.Anything, mock.AnythingOfType("*store.FindTOTPCredential")).Return(nil, nil)
+	mockStore.On("GetInstanceJWTSigningKeySetting", mock.Anything).Return(newSigningKeySetting(t, "testsecret"), nil)
+	mockStore.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("*store.CreateRefreshToken")).Return(&store.RefreshToken{ID: 1}, nil)
+	mockStore.On("DeleteLoginAttempts", mock.Anything, mock.AnythingOfType("*store.DeleteLoginAttempt")).Return(nil)
+	mockStore.On("GetInstanceSecuritySetting", mock.Anything).Return(&storepb.InstanceSecuritySetting{}, nil)
+	mockStore.On("RecordLoginFailure", mock.Anything, mock.AnythingOfType("*store.RecordLoginFailure")).Return(&store.LoginAttempt{ID: 1, FailedCount: 1}, nil)
+	mockStore.On("UpdateLoginAttempt", mock.Anything, mock.AnythingOfType("*store.UpdateLoginAttempt")).Return(&store.LoginAttempt{ID: 1}, nil)
+	// The directory user has no local password, the local verifier passes
+	mockStore.On("GetUserByUsername", mock.Anything, "alice").Return(nil, nil)
+	mockStore.On("GetUserIdentity", mock.Anything, mock.AnythingOfType("*store.FindUserIdentity")).
+		Return(&store.UserIdentity{ID: 1, UserID: userID, Provider: LDAPIdentityProvider, Subject: ldapAliceDN}, nil)
+	mockStore.On("GetUser", mock.Anything, &store.FindUser{ID: &userID}).Return(&store.User{
+		ID:              userID,
+		Username:        "alice",
+		Nickname:        "Alice Liddell",
+		Email:           "alice@example.com",
+		Phone:           "+1 555 0100",
+		Role:            store.RoleUser,
+		EmailVerified:   true,
+		PasswordExpires: time.Now(),
+	}, nil)
+
+	resp, err := authService.Login(context.Background(), &v1pb.LoginRequest{Username: "alice", Password: "alice-secret"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.AccessToken)
+	assert.Equal(t, userID, resp.User.Id)
+
+	// Wrong passwords are rejected like local ones and counted
+	_, err = authService.Login(context.Background(), &v1pb.LoginRequest{Username: "alice", Password: "wrong"})
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	mockStore.AssertNumberOfCalls(t, "RecordLoginFailure", 1)
+
+	// An unreachable directory is not mistaken for a wrong password
+	directory.Close()
+	_, err = authService.Login(context.Background(), &v1pb.LoginRequest{Username: "alice", Password: "alice-secret"})
+	assert.Equal(t, connect.CodeUnavailable, connect.CodeOf(err))
+	mockStore.AssertNumberOfCalls(t, "RecordLoginFailure", 1)
+}
+
+func TestDirectoryUserPassword(t *testing.T) {
+	mockStore := new(MockStore)
+	authService := NewAuthService("testsecret", mockStore)
+	authService.Notifier = notify.NewMemoryNotifier()
+	userService := NewUserService("testsecret", mockStore)
+	ctx := context.Background()
+
+	// A local password set before the user signed in through the directory
+	passwordHash, err := auth.HashPassword("local-password")
+	require.NoError(t, err)
+	user := &store.User{
+		ID:              2,
+		Username:        "alice",
+		Email:           "alice@example.com",
+		Password:        passwordHash,
+		Role:            store.RoleUser,
+		EmailVerified:   true,
+		PasswordExpires: time.Now().AddDate(0, 0, 90),
+	}
+	provider := LDAPIdentityProvider
+	mockStore.On("GetUserIdentity", mock.Anything, &store.FindUserIdentity{UserID: &user.ID, Provider: &provider}).
+		Return(&store.UserIdentity{ID: 1, UserID: user.ID, Provider: LDAPIdentityProvider, Subject: ldapAliceDN}, nil)
+	mockStore.On("GetUserByUsername", mock.Anything, user.Username).Return(user, nil)
+	mockStore.On("GetUserByEmail", mock.Anything, user.Email).Return(user, nil)
+	mockStore.On("GetUser", mock.Anything, &store.FindUser{ID: &user.ID}).Return(user, nil)
+	mockStore.On("GetLoginAttempt", mock.Anything, mock.AnythingOfType("*store.FindLoginAttempt")).Return(nil, nil)
+	mockStore.On("GetInstanceSecuritySetting", mock.Anything).Return(&storepb.InstanceSecuritySetting{}, nil)
+	mockStore.On("RecordLoginFailure", mock.Anything, mock.AnythingOfType("*store.RecordLoginFailure")).Return(&store.LoginAttempt{ID: 1, FailedCount: 1}, nil)
+	mockStore.On("UpdateLoginAttempt", mock.Anything, mock.AnythingOfType("*store.UpdateLoginAttempt")).Return(&store.LoginAttempt{ID: 1}, nil)
+
+	// The local password is not accepted, only the directory checks the password
+	_, err = authService.Login(ctx, &v1pb.LoginRequest{Username: user.Username, Password: "local-password"})
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+	// A password reset sends nothing, and tokens sent before are not accepted
+	_, err = authService.RequestPasswordReset(ctx, &v1pb.RequestPasswordResetRequest{Email: user.Email})
+	require.NoError(t, err)
+	assert.Empty(t, authService.Notifier.(*notify.MemoryNotifier).Messages())
+	mockStore.AssertNotCalled(t, "CreatePasswordResetToken", mock.Anything, mock.Anything)
+	tokenHash := auth.HashToken("reset-token")
+	mockStore.On("GetPasswordResetToken", mock.Anything, &store.FindPasswordResetToken{TokenHash: &tokenHash}).
+		Return(&store.PasswordResetToken{ID: 1, UserID: user.ID, TokenHash: tokenHash, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	_, err = authService.ConfirmPasswordReset(ctx, &v1pb.ConfirmPasswordResetRequest{Token: "reset-token", NewPassword: "new-password-1"})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// Nor can the password be changed
+	_, err = userService.ChangePassword(auth.SetUserIDInContext(ctx, user.ID), &v1pb.ChangePasswordRequest{OldPassword: "local-password", NewPassword: "new-password-1"})
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+	mockStore.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+}
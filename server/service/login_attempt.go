@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	storepb "github.com/pixb/go-server/proto/gen/store"
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/store"
+)
+
+// dummyPasswordHash is compared against when the username does not exist,
+// so that unknown usernames take as long to reject as wrong passwords.
+var dummyPasswordHash = sync.OnceValue(func() string {
+	hash, _ := auth.HashPassword("go-server dummy password")
+	return hash
+})
+
+// loginAttemptStore is the subset of the store needed to throttle sign-in attempts.
+type loginAttemptStore interface {
+	GetInstanceSecuritySetting(ctx context.Context) (*storepb.InstanceSecuritySetting, error)
+	RecordLoginFailure(ctx context.Context, record *store.RecordLoginFailure) (*store.LoginAttempt, error)
+	UpdateLoginAttempt(ctx context.Context, update *store.UpdateLoginAttempt) (*store.LoginAttempt, error)
+	GetLoginAttempt(ctx context.Context, find *store.FindLoginAttempt) (*store.LoginAttempt, error)
+	DeleteLoginAttempts(ctx context.Context, delete *store.DeleteLoginAttempt) error
+	CreateSecurityEvent(ctx context.Context, create *store.CreateSecurityEvent) (*store.SecurityEvent, error)
+}
+
+// errInvalidCredentials is returned for every failed sign-in, so that it does not
+// reveal whether the username exists.
+func errInvalidCredentials() error {
+	return connect.NewError(connect.CodeUnauthenticated, errors.New("invalid credentials"))
+}
+
+func getLockoutPolicy(ctx context.Context, s loginAttemptStore) (*auth.LockoutPolicy, error) {
+	setting, err := s.GetInstanceSecuritySetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return auth.NewLockoutPolicy(setting.GetAccountLockout()), nil
+}
+
+// checkLoginAllowed refuses the attempt while the account or the client IP address is locked.
+func checkLoginAllowed(ctx context.Context, s loginAttemptStore, username, ipAddress string) error {
+	now := time.Now()
+	for _, find := range loginAttemptKeys(username, ipAddress) {
+		attempt, err := s.GetLoginAttempt(ctx, find)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, errors.New("failed to get login attempts"))
+		}
+		if attempt != nil && attempt.LockedUntil != nil && now.Before(*attempt.LockedUntil) {
+			retryAfter := max(attempt.LockedUntil.Sub(now).Round(time.Second), time.Second)
+			err := connect.NewError(connect.CodeResourceExhausted,
+				fmt.Errorf("too many failed login attempts, try again in %s", retryAfter))
+			// The delay is also sent as a RetryInfo detail and a Retry-After header.
+			if detail, detailErr := connect.NewErrorDetail(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); detailErr == nil {
+				err.AddDetail(detail)
+			}
+			err.Meta().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+			return err
+		}
+	}
+	return nil
+}
+
+// recordLoginFailure counts a failed attempt for the account and the client IP address and
+// locks them according to the policy. user is nil when the username does not exist.
+func recordLoginFailure(ctx context.Context, s loginAttemptStore, username, ipAddress string, user *store.User) error {
+	policy, err := getLockoutPolicy(ctx, s)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, errors.New("failed to get lockout policy"))
+	}
+
+	now := time.Now()
+	for _, find := range loginAttemptKeys(username, ipAddress) {
+		attempt, err := s.RecordLoginFailure(ctx, &store.RecordLoginFailure{
+			Scope:       *find.Scope,
+			Identifier:  *find.Identifier,
+			ResetBefore: now.Add(-policy.FailureWindow),
+		})
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, errors.New("failed to record login failure"))
+		}
+
+		lockedUntil := policy.LockedUntil(attempt.Scope, attempt.FailedCount, attempt.LastFailedAt)
+		if _, err := s.UpdateLoginAttempt(ctx, &store.UpdateLoginAttempt{
+			ID:          attempt.ID,
+			LockedUntil: &lockedUntil,
+		}); err != nil {
+			return connect.NewError(connect.CodeInternal, errors.New("failed to update login attempt"))
+		}
+
+		if attempt.FailedCount == policy.MaxFailures(attempt.Scope) {
+			slog.Warn("locking out after too many failed login attempts",
+				slog.String("scope", attempt.Scope.String()),
+				slog.String("identifier", attempt.Identifier),
+				slog.Time("lockedUntil", lockedUntil))
+			if attempt.Scope == store.LoginAttemptScopeAccount && user != nil {
+				if _, err := s.CreateSecurityEvent(ctx, &store.CreateSecurityEvent{
+					UserID: user.ID,
+					Type:   store.SecurityEventAccountLocked,
+					Detail: fmt.Sprintf("account locked until %s after %d failed login attempts", lockedUntil.Format(time.RFC3339), attempt.FailedCount),
+				}); err != nil {
+					return connect.NewError(connect.CodeInternal, errors.New("failed to record security event"))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// resetLoginFailures clears the account counter after a successful sign-in. The IP address
+// counter is left to expire, so one valid account does not allow guessing others.
+func resetLoginFailures(ctx context.Context, s loginAttemptStore, username string) error {
+	if err := s.DeleteLoginAttempts(ctx, &store.DeleteLoginAttempt{
+		Scope:      store.LoginAttemptScopeAccount,
+		Identifier: username,
+	}); err != nil {
+		return connect.NewError(connect.CodeInternal, errors.New("failed to reset login attempts"))
+	}
+	return nil
+}
+
+func loginAttemptKeys(username, ipAddress string) []*store.FindLoginAttempt {
+	accountScope, ipScope := store.LoginAttemptScopeAccount, store.LoginAttemptScopeIP
+	keys := []*store.FindLoginAttempt{{Scope: &accountScope, Identifier: &username}}
+	// The address is unknown for in-process calls, which are not throttled by IP address.
+	if ipAddress != "" {
+		keys = append(keys, &store.FindLoginAttempt{Scope: &ipScope, Identifier: &ipAddress})
+	}
+	return keys
+}
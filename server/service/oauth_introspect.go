@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/server/oidc"
+	"github.com/pixb/go-server/store"
+)
+
+// The kinds of tokens reported by Introspect in token_use.
+const (
+	TokenUseAccessToken         = "access_token"
+	TokenUseRefreshToken        = "refresh_token"
+	TokenUsePersonalAccessToken = "personal_access_token"
+)
+
+// inactiveToken is the response for tokens that are unknown, expired or revoked.
+var inactiveToken = &oidc.Introspection{Active: false}
+
+// Introspect serves a request to the introspection endpoint, see RFC 7662. It reports on
+// access tokens of users and OAuth clients, refresh tokens and personal access tokens,
+// which are told apart by their format, so token_type_hint is not needed. Only confidential
+// clients may introspect tokens. Protocol errors are returned as *oidc.Error.
+func (s *OAuthService) Introspect(ctx context.Context, clientID, clientSecret string, form url.Values) (*oidc.Introspection, error) {
+	client, err := s.authenticateClient(ctx, clientID, clientSecret)
+	if err != nil {
+		return nil, err
+	}
+	if client.SecretHash == "" {
+		return nil, errInvalidClient
+	}
+
+	token := form.Get("token")
+	switch {
+	case token == "":
+		return nil, &oidc.Error{Code: "invalid_request", Description: "token is required"}
+	case strings.HasPrefix(token, auth.PersonalAccessTokenPrefix):
+		return s.introspectPersonalAccessToken(ctx, token)
+	case strings.Count(token, ".") == 2:
+		return s.introspectAccessToken(ctx, token)
+	default:
+		return s.introspectRefreshToken(ctx, token)
+	}
+}
+
+func (s *OAuthService) introspectAccessToken(ctx context.Context, token string) (*oidc.Introspection, error) {
+	claims, err := s.Keys.ValidateAccessToken(ctx, token)
+	if err != nil {
+		return inactiveToken, nil
+	}
+	// Tokens are revoked by their own jti or by the session they belong to.
+	for _, id := range []string{claims.ID, claims.SessionID} {
+		if id == "" {
+			continue
+		}
+		revoked, err := s.Store.IsTokenRevoked(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check token: %w", err)
+		}
+		if revoked {
+			return inactiveToken, nil
+		}
+	}
+	introspection := &oidc.Introspection{
+		Active:    true,
+		ClientID:  claims.ClientID,
+		Scope:     claims.Scope,
+		TokenType: "Bearer",
+		Subject:   claims.Subject,
+		Issuer:    claims.Issuer,
+		TokenID:   claims.ID,
+		TokenUse:  TokenUseAccessToken,
+	}
+	if claims.ExpiresAt != nil {
+		introspection.ExpiresAt = claims.ExpiresAt.Unix()
+	}
+	if claims.IssuedAt != nil {
+		introspection.IssuedAt = claims.IssuedAt.Unix()
+	}
+	if claims.NotBefore != nil {
+		introspection.NotBefore = claims.NotBefore.Unix()
+	}
+	// Deleting a client invalidates the tokens it holds.
+	if claims.ClientID != "" {
+		client, err := s.Store.GetOAuthClient(ctx, &store.FindOAuthClient{ClientID: &claims.ClientID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get oauth client: %w", err)
+		}
+		if client == nil {
+			return inactiveToken, nil
+		}
+	}
+	if claims.UserID == 0 {
+		return introspection, nil
+	}
+	return s.withUser(ctx, introspection, claims.UserID)
+}
+
+func (s *OAuthService) introspectRefreshToken(ctx context.Context, token string) (*oidc.Introspection, error) {
+	refreshToken, err := s.Store.GetRefreshToken(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	if refreshToken == nil || refreshToken.Revoked || time.Now().After(refreshToken.ExpiresAt) {
+		return inactiveToken, nil
+	}
+	return s.withUser(ctx, &oidc.Introspection{
+		Active:    true,
+		ExpiresAt: refreshToken.ExpiresAt.Unix(),
+		IssuedAt:  refreshToken.CreatedAt.Unix(),
+		TokenUse:  TokenUseRefreshToken,
+	}, refreshToken.UserID)
+}
+
+func (s *OAuthService) introspectPersonalAccessToken(ctx context.Context, token string) (*oidc.Introspection, error) {
+	tokenHash := auth.HashToken(token)
+	pat, err := s.Store.GetPersonalAccessToken(ctx, &store.FindPersonalAccessToken{TokenHash: &tokenHash})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get personal access token: %w", err)
+	}
+	if pat == nil || (pat.ExpiresAt != nil && time.Now().After(*pat.ExpiresAt)) {
+		return inactiveToken, nil
+	}
+	introspection := &oidc.Introspection{
+		Active:    true,
+		TokenType: "Bearer",
+		IssuedAt:  pat.CreatedAt.Unix(),
+		TokenUse:  TokenUsePersonalAccessToken,
+	}
+	if pat.ExpiresAt != nil {
+		introspection.ExpiresAt = pat.ExpiresAt.Unix()
+	}
+	return s.withUser(ctx, introspection, pat.UserID)
+}
+
+// withUser adds the user of a token to its introspection, tokens of deleted users are not active.
+func (s *OAuthService) withUser(ctx context.Context, introspection *oidc.Introspection, userID int64) (*oidc.Introspection, error) {
+	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return inactiveToken, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	introspection.Subject = strconv.FormatInt(user.ID, 10)
+	introspection.Username = user.Username
+	introspection.Role = string(user.Role)
+	return introspection, nil
+}
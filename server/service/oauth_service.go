@@ -0,0 +1,532 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/server/oidc"
+	"github.com/pixb/go-server/store"
+)
+
+// The grant types a client can be registered for.
+const (
+	GrantTypeAuthorizationCode = "authorization_code"
+	GrantTypeClientCredentials = "client_credentials"
+)
+
+const maxRedirectURIs = 10
+
+var (
+	// oauthScopePattern is a subset of the scope-token of RFC 6749 section 3.3.
+	oauthScopePattern = regexp.MustCompile(`^[A-Za-z0-9_.:/-]{1,64}$`)
+	// codeChallengePattern matches an S256 code challenge, see RFC 7636 section 4.2.
+	codeChallengePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{43}$`)
+
+	defaultOAuthClientScopes = []string{auth.ScopeOpenID, auth.ScopeProfile, auth.ScopeEmail}
+)
+
+// OAuthStore is an interface that defines the methods needed by OAuthService
+type OAuthStore interface {
+	GetUser(ctx context.Context, find *store.FindUser) (*store.User, error)
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+	GetRefreshToken(ctx context.Context, token string) (*store.RefreshToken, error)
+	GetPersonalAccessToken(ctx context.Context, find *store.FindPersonalAccessToken) (*store.PersonalAccessToken, error)
+	CreateOAuthClient(ctx context.Context, create *store.CreateOAuthClient) (*store.OAuthClient, error)
+	UpdateOAuthClient(ctx context.Context, update *store.UpdateOAuthClient) (*store.OAuthClient, error)
+	ListOAuthClients(ctx context.Context, find *store.FindOAuthClient) ([]*store.OAuthClient, error)
+	GetOAuthClient(ctx context.Context, find *store.FindOAuthClient) (*store.OAuthClient, error)
+	DeleteOAuthClient(ctx context.Context, delete *store.DeleteOAuthClient) error
+	CreateOAuthAuthorizationCode(ctx context.Context, create *store.CreateOAuthAuthorizationCode) (*store.OAuthAuthorizationCode, error)
+	GetOAuthAuthorizationCode(ctx context.Context, find *store.FindOAuthAuthorizationCode) (*store.OAuthAuthorizationCode, error)
+	UseOAuthAuthorizationCode(ctx context.Context, id int64) (bool, error)
+	UpsertOAuthConsent(ctx context.Context, upsert *store.UpsertOAuthConsent) (*store.OAuthConsent, error)
+	ListOAuthConsents(ctx context.Context, find *store.FindOAuthConsent) ([]*store.OAuthConsent, error)
+	GetOAuthConsent(ctx context.Context, find *store.FindOAuthConsent) (*store.OAuthConsent, error)
+	DeleteOAuthConsent(ctx context.Context, delete *store.DeleteOAuthConsent) error
+	auth.SigningKeyStore
+}
+
+// OAuthService makes the instance the OAuth 2.0 and OpenID Connect authorization server of
+// its registered clients. The RPCs manage clients and consents, the protocol endpoints are
+// served by the router with Token, Introspect, UserInfo and Metadata.
+type OAuthService struct {
+	Store OAuthStore
+	Keys  *auth.KeyManager
+}
+
+func NewOAuthService(secret string, store OAuthStore) *OAuthService {
+	return &OAuthService{
+		Store: store,
+		Keys:  auth.NewKeyManager(store, secret),
+	}
+}
+
+func (s *OAuthService) ListOAuthClients(ctx context.Context, req *v1pb.ListOAuthClientsRequest) (*v1pb.ListOAuthClientsResponse, error) {
+	if err := auth.Require(ctx, store.PermissionOAuthClientsRead); err != nil {
+		return nil, err
+	}
+
+	clients, err := s.Store.ListOAuthClients(ctx, &store.FindOAuthClient{})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to list oauth clients"))
+	}
+	response := &v1pb.ListOAuthClientsResponse{}
+	for _, client := range clients {
+		response.OauthClients = append(response.OauthClients, convertOAuthClientFromStore(client))
+	}
+	return response, nil
+}
+
+func (s *OAuthService) GetOAuthClient(ctx context.Context, req *v1pb.GetOAuthClientRequest) (*v1pb.GetOAuthClientResponse, error) {
+	if err := auth.Require(ctx, store.PermissionOAuthClientsRead); err != nil {
+		return nil, err
+	}
+
+	client, err := s.findClient(ctx, req.ClientId)
+	if err != nil {
+		return nil, err
+	}
+	return &v1pb.GetOAuthClientResponse{OauthClient: convertOAuthClientFromStore(client)}, nil
+}
+
+func (s *OAuthService) CreateOAuthClient(ctx context.Context, req *v1pb.CreateOAuthClientRequest) (*v1pb.CreateOAuthClientResponse, error) {
+	if err := auth.Require(ctx, store.PermissionOAuthClientsWrite); err != nil {
+		return nil, err
+	}
+	if req.OauthClient == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("oauth client is required"))
+	}
+
+	create := &store.CreateOAuthClient{
+		Name:         req.OauthClient.Name,
+		RedirectURIs: req.OauthClient.RedirectUris,
+		GrantTypes:   req.OauthClient.GrantTypes,
+		Scopes:       req.OauthClient.Scopes,
+		CreatorID:    auth.GetUserID(ctx),
+	}
+	if len(create.Scopes) == 0 {
+		create.Scopes = defaultOAuthClientScopes
+	}
+	if err := validateOAuthClient(create.Name, create.RedirectURIs, create.GrantTypes, create.Scopes, req.OauthClient.Confidential); err != nil {
+		return nil, err
+	}
+
+	clientID, err := auth.GenerateTokenID()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to generate client id"))
+	}
+	create.ClientID = clientID
+	secret := ""
+	if req.OauthClient.Confidential {
+		if secret, err = auth.GenerateOneTimeToken(); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to generate client secret"))
+		}
+		create.SecretHash = auth.HashToken(secret)
+	}
+
+	client, err := s.Store.CreateOAuthClient(ctx, create)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to create oauth client"))
+	}
+	return &v1pb.CreateOAuthClientResponse{
+		OauthClient:  convertOAuthClientFromStore(client),
+		ClientSecret: secret,
+	}, nil
+}
+
+func (s *OAuthService) UpdateOAuthClient(ctx context.Context, req *v1pb.UpdateOAuthClientRequest) (*v1pb.UpdateOAuthClientResponse, error) {
+	if err := auth.Require(ctx, store.PermissionOAuthClientsWrite); err != nil {
+		return nil, err
+	}
+	if req.OauthClient == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("oauth client is required"))
+	}
+	if req.UpdateMask == nil || len(req.UpdateMask.Paths) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("update mask is required"))
+	}
+
+	client, err := s.findClient(ctx, req.OauthClient.ClientId)
+	if err != nil {
+		return nil, err
+	}
+	update := &store.UpdateOAuthClient{ID: client.ID}
+	name, redirectURIs, grantTypes, scopes := client.Name, client.RedirectURIs, client.GrantTypes, client.Scopes
+	for _, path := range req.UpdateMask.Paths {
+		switch path {
+		case "name":
+			name = req.OauthClient.Name
+			update.Name = &name
+		case "redirect_uris":
+			redirectURIs = req.OauthClient.RedirectUris
+			update.RedirectURIs = append([]string{}, redirectURIs...)
+		case "grant_types":
+			grantTypes = req.OauthClient.GrantTypes
+			update.GrantTypes = append([]string{}, grantTypes...)
+		case "scopes":
+			scopes = req.OauthClient.Scopes
+			update.Scopes = append([]string{}, scopes...)
+		default:
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unsupported update mask path %q", path))
+		}
+	}
+	if err := validateOAuthClient(name, redirectURIs, grantTypes, scopes, client.SecretHash != ""); err != nil {
+		return nil, err
+	}
+
+	client, err = s.Store.UpdateOAuthClient(ctx, update)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to update oauth client"))
+	}
+	return &v1pb.UpdateOAuthClientResponse{OauthClient: convertOAuthClientFromStore(client)}, nil
+}
+
+func (s *OAuthService) RotateOAuthClientSecret(ctx context.Context, req *v1pb.RotateOAuthClientSecretRequest) (*v1pb.RotateOAuthClientSecretResponse, error) {
+	if err := auth.Require(ctx, store.PermissionOAuthClientsWrite); err != nil {
+		return nil, err
+	}
+
+	client, err := s.findClient(ctx, req.ClientId)
+	if err != nil {
+		return nil, err
+	}
+	if client.SecretHash == "" {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("public clients have no secret"))
+	}
+	secret, err := auth.GenerateOneTimeToken()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to generate client secret"))
+	}
+	secretHash := auth.HashToken(secret)
+	if _, err := s.Store.UpdateOAuthClient(ctx, &store.UpdateOAuthClient{ID: client.ID, SecretHash: &secretHash}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to update oauth client"))
+	}
+	return &v1pb.RotateOAuthClientSecretResponse{ClientSecret: secret}, nil
+}
+
+func (s *OAuthService) DeleteOAuthClient(ctx context.Context, req *v1pb.DeleteOAuthClientRequest) (*v1pb.DeleteOAuthClientResponse, error) {
+	if err := auth.Require(ctx, store.PermissionOAuthClientsWrite); err != nil {
+		return nil, err
+	}
+
+	client, err := s.findClient(ctx, req.ClientId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Store.DeleteOAuthClient(ctx, &store.DeleteOAuthClient{ID: client.ID}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to delete oauth client"))
+	}
+	return &v1pb.DeleteOAuthClientResponse{}, nil
+}
+
+func (s *OAuthService) GetOAuthAuthorization(ctx context.Context, req *v1pb.GetOAuthAuthorizationRequest) (*v1pb.GetOAuthAuthorizationResponse, error) {
+	userID := auth.GetUserID(ctx)
+	if userID == 0 {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+
+	client, scopes, err := s.checkAuthorization(ctx, req.Authorization)
+	if err != nil {
+		return nil, authorizationError(err)
+	}
+	consent, err := s.Store.GetOAuthConsent(ctx, &store.FindOAuthConsent{UserID: &userID, ClientID: &client.ID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get consent"))
+	}
+	return &v1pb.GetOAuthAuthorizationResponse{
+		ClientName:      client.Name,
+		Scopes:          scopes,
+		ConsentRequired: consent == nil || !isSubset(scopes, consent.Scopes),
+	}, nil
+}
+
+func (s *OAuthService) ApproveOAuthAuthorization(ctx context.Context, req *v1pb.ApproveOAuthAuthorizationRequest) (*v1pb.ApproveOAuthAuthorizationResponse, error) {
+	userID := auth.GetUserID(ctx)
+	if userID == 0 {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+
+	client, scopes, err := s.checkAuthorization(ctx, req.Authorization)
+	if err != nil {
+		return nil, authorizationError(err)
+	}
+	authorization := req.Authorization
+	if !req.Approved {
+		return &v1pb.ApproveOAuthAuthorizationResponse{
+			RedirectUrl: AuthorizationErrorURL(authorization.RedirectUri, authorization.State, &oidc.Error{
+				Code:        "access_denied",
+				Description: "the user denied the request",
+			}),
+		}, nil
+	}
+
+	// Scopes granted before are kept, so that the user is not asked for them again.
+	consent, err := s.Store.GetOAuthConsent(ctx, &store.FindOAuthConsent{UserID: &userID, ClientID: &client.ID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get consent"))
+	}
+	granted := scopes
+	if consent != nil {
+		granted = appendMissing(slices.Clone(consent.Scopes), scopes)
+	}
+	if _, err := s.Store.UpsertOAuthConsent(ctx, &store.UpsertOAuthConsent{UserID: userID, ClientID: client.ID, Scopes: granted}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to save consent"))
+	}
+
+	code, err := auth.GenerateOneTimeToken()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to generate authorization code"))
+	}
+	if _, err := s.Store.CreateOAuthAuthorizationCode(ctx, &store.CreateOAuthAuthorizationCode{
+		CodeHash:      auth.HashToken(code),
+		ClientID:      client.ID,
+		UserID:        userID,
+		RedirectURI:   authorization.RedirectUri,
+		Scopes:        scopes,
+		Nonce:         authorization.Nonce,
+		CodeChallenge: authorization.CodeChallenge,
+		ExpiresAt:     time.Now().Add(auth.OAuthAuthorizationCodeDuration),
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to create authorization code"))
+	}
+	query := url.Values{"code": {code}}
+	if authorization.State != "" {
+		query.Set("state", authorization.State)
+	}
+	return &v1pb.ApproveOAuthAuthorizationResponse{RedirectUrl: redirectURL(authorization.RedirectUri, query)}, nil
+}
+
+func (s *OAuthService) ListOAuthConsents(ctx context.Context, req *v1pb.ListOAuthConsentsRequest) (*v1pb.ListOAuthConsentsResponse, error) {
+	userID := auth.GetUserID(ctx)
+	if userID == 0 {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+
+	consents, err := s.Store.ListOAuthConsents(ctx, &store.FindOAuthConsent{UserID: &userID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to list consents"))
+	}
+	response := &v1pb.ListOAuthConsentsResponse{}
+	for _, consent := range consents {
+		client, err := s.Store.GetOAuthClient(ctx, &store.FindOAuthClient{ID: &consent.ClientID})
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get oauth client"))
+		}
+		if client == nil {
+			continue
+		}
+		response.Consents = append(response.Consents, &v1pb.OAuthConsent{
+			ClientId:   client.ClientID,
+			ClientName: client.Name,
+			Scopes:     consent.Scopes,
+			CreatedAt:  timestamppb.New(consent.CreatedAt),
+			UpdatedAt:  timestamppb.New(consent.UpdatedAt),
+		})
+	}
+	return response, nil
+}
+
+func (s *OAuthService) RevokeOAuthConsent(ctx context.Context, req *v1pb.RevokeOAuthConsentRequest) (*v1pb.RevokeOAuthConsentResponse, error) {
+	userID := auth.GetUserID(ctx)
+	if userID == 0 {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+
+	client, err := s.findClient(ctx, req.ClientId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Store.DeleteOAuthConsent(ctx, &store.DeleteOAuthConsent{UserID: userID, ClientID: client.ID}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to revoke consent"))
+	}
+	return &v1pb.RevokeOAuthConsentResponse{}, nil
+}
+
+// CheckAuthorization validates an authorization request. The request cannot be answered at
+// the redirect URI when the error is a connect error, because the client or the redirect URI
+// is unknown. Other errors are an *oidc.Error to be sent to the redirect URI.
+func (s *OAuthService) CheckAuthorization(ctx context.Context, authorization *v1pb.OAuthAuthorization) error {
+	_, _, err := s.checkAuthorization(ctx, authorization)
+	return err
+}
+
+// checkAuthorization validates an authorization request and returns the client and the scopes to grant.
+func (s *OAuthService) checkAuthorization(ctx context.Context, authorization *v1pb.OAuthAuthorization) (*store.OAuthClient, []string, error) {
+	if authorization == nil || authorization.ClientId == "" {
+		return nil, nil, connect.NewError(connect.CodeInvalidArgument, errors.New("client id is required"))
+	}
+	client, err := s.Store.GetOAuthClient(ctx, &store.FindOAuthClient{ClientID: &authorization.ClientId})
+	if err != nil {
+		return nil, nil, connect.NewError(connect.CodeInternal, errors.New("failed to get oauth client"))
+	}
+	if client == nil {
+		return nil, nil, connect.NewError(connect.CodeInvalidArgument, errors.New("unknown client"))
+	}
+	// Redirect URIs are compared exactly, see RFC 9700 section 4.1.3.
+	if !slices.Contains(client.RedirectURIs, authorization.RedirectUri) {
+		return nil, nil, connect.NewError(connect.CodeInvalidArgument, errors.New("redirect uri is not registered for the client"))
+	}
+
+	if !slices.Contains(client.GrantTypes, GrantTypeAuthorizationCode) {
+		return nil, nil, &oidc.Error{Code: "unauthorized_client", Description: "the client is not allowed to use the authorization code grant"}
+	}
+	if authorization.ResponseType != "code" {
+		return nil, nil, &oidc.Error{Code: "unsupported_response_type", Description: "only the code response type is supported"}
+	}
+	if authorization.CodeChallengeMethod != "S256" || !codeChallengePattern.MatchString(authorization.CodeChallenge) {
+		return nil, nil, &oidc.Error{Code: "invalid_request", Description: "a PKCE code challenge with the S256 method is required"}
+	}
+	if len(authorization.Nonce) > 255 {
+		return nil, nil, &oidc.Error{Code: "invalid_request", Description: "nonce is too long"}
+	}
+	scopes := client.Scopes
+	if authorization.Scope != "" {
+		scopes = appendMissing(nil, strings.Fields(authorization.Scope))
+		if !isSubset(scopes, client.Scopes) {
+			return nil, nil, &oidc.Error{Code: "invalid_scope", Description: "the client is not allowed to request the scope"}
+		}
+	}
+	return client, scopes, nil
+}
+
+func (s *OAuthService) findClient(ctx context.Context, clientID string) (*store.OAuthClient, error) {
+	client, err := s.Store.GetOAuthClient(ctx, &store.FindOAuthClient{ClientID: &clientID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get oauth client"))
+	}
+	if client == nil {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("oauth client not found"))
+	}
+	return client, nil
+}
+
+// AuthorizationErrorURL returns the redirect URI with an error response of the authorization endpoint.
+func AuthorizationErrorURL(redirectURI, state string, oauthErr *oidc.Error) string {
+	query := url.Values{"error": {oauthErr.Code}}
+	if oauthErr.Description != "" {
+		query.Set("error_description", oauthErr.Description)
+	}
+	if state != "" {
+		query.Set("state", state)
+	}
+	return redirectURL(redirectURI, query)
+}
+
+// redirectURL adds the parameters to the query of a registered redirect URI, keeping its own query.
+func redirectURL(redirectURI string, params url.Values) string {
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		return redirectURI
+	}
+	query := u.Query()
+	for name, values := range params {
+		query[name] = values
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// authorizationError converts an error response for the redirect URI into an RPC error.
+func authorizationError(err error) error {
+	var oauthErr *oidc.Error
+	if errors.As(err, &oauthErr) {
+		return connect.NewError(connect.CodeInvalidArgument, oauthErr)
+	}
+	return err
+}
+
+func validateOAuthClient(name string, redirectURIs, grantTypes, scopes []string, confidential bool) error {
+	if name == "" {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("name is required"))
+	}
+	if len(name) > 64 {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("name must be at most 64 characters"))
+	}
+	if len(grantTypes) == 0 {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("grant types are required"))
+	}
+	for _, grantType := range grantTypes {
+		switch grantType {
+		case GrantTypeAuthorizationCode:
+			if len(redirectURIs) == 0 {
+				return connect.NewError(connect.CodeInvalidArgument, errors.New("redirect uris are required for the authorization code grant"))
+			}
+		case GrantTypeClientCredentials:
+			if !confidential {
+				return connect.NewError(connect.CodeInvalidArgument, errors.New("the client credentials grant requires a confidential client"))
+			}
+		default:
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unsupported grant type %q", grantType))
+		}
+	}
+	if len(redirectURIs) > maxRedirectURIs {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("at most %d redirect uris are allowed", maxRedirectURIs))
+	}
+	for _, redirectURI := range redirectURIs {
+		if err := validateRedirectURI(redirectURI); err != nil {
+			return err
+		}
+	}
+	for _, scope := range scopes {
+		if !oauthScopePattern.MatchString(scope) {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid scope %q", scope))
+		}
+	}
+	return nil
+}
+
+// validateRedirectURI requires an absolute https URL without fragment, plain http is only
+// allowed for loopback hosts, see RFC 9700 section 2.6.
+func validateRedirectURI(redirectURI string) error {
+	u, err := url.Parse(redirectURI)
+	if err != nil || u.Host == "" || u.Fragment != "" || u.User != nil || strings.ContainsAny(redirectURI, " \t\r\n") {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("redirect uri %q must be an absolute URL without fragment", redirectURI))
+	}
+	if u.Scheme != "https" && (u.Scheme != "http" || !isLoopbackHost(u.Hostname())) {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("redirect uri %q must use https", redirectURI))
+	}
+	return nil
+}
+
+// isSubset reports whether every element of list is in set.
+func isSubset(list, set []string) bool {
+	for _, element := range list {
+		if !slices.Contains(set, element) {
+			return false
+		}
+	}
+	return true
+}
+
+// appendMissing appends the elements of values that are not in list yet.
+func appendMissing(list, values []string) []string {
+	for _, value := range values {
+		if !slices.Contains(list, value) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+func convertOAuthClientFromStore(client *store.OAuthClient) *v1pb.OAuthClient {
+	return &v1pb.OAuthClient{
+		ClientId:     client.ClientID,
+		Name:         client.Name,
+		RedirectUris: client.RedirectURIs,
+		GrantTypes:   client.GrantTypes,
+		Scopes:       client.Scopes,
+		Confidential: client.SecretHash != "",
+		CreatedAt:    timestamppb.New(client.CreatedAt),
+		UpdatedAt:    timestamppb.New(client.UpdatedAt),
+	}
+}
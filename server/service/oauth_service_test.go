@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"connectrpc.com/connect"
+	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/server/oidc"
+	"github.com/pixb/go-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+var exampleOAuthClient = &store.OAuthClient{
+	ID:           1,
+	ClientID:     "example-client",
+	Name:         "Example",
+	RedirectURIs: []string{"https://app.example.com/callback"},
+	GrantTypes:   []string{GrantTypeAuthorizationCode},
+	Scopes:       []string{"openid", "profile", "email"},
+}
+
+func newOAuthServiceTest(t *testing.T) (*OAuthService, *MockStore) {
+	t.Helper()
+	mockStore := new(MockStore)
+	mockStore.On("GetOAuthClient", mock.Anything, &store.FindOAuthClient{ClientID: &exampleOAuthClient.ClientID}).Return(exampleOAuthClient, nil).Maybe()
+	mockStore.On("GetOAuthClient", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	return NewOAuthService("testsecret", mockStore), mockStore
+}
+
+func newOAuthAuthorization() *v1pb.OAuthAuthorization {
+	return &v1pb.OAuthAuthorization{
+		ClientId:            exampleOAuthClient.ClientID,
+		RedirectUri:         exampleOAuthClient.RedirectURIs[0],
+		ResponseType:        "code",
+		Scope:               "openid profile",
+		State:               "state",
+		Nonce:               "nonce",
+		CodeChallenge:       oidc.CodeChallenge("verifier"),
+		CodeChallengeMethod: "S256",
+	}
+}
+
+func TestOAuthService_RequiresPermissions(t *testing.T) {
+	oauthService, mockStore := newOAuthServiceTest(t)
+	mockStore.On("ListOAuthClients", mock.Anything, mock.Anything).Return([]*store.OAuthClient{exampleOAuthClient}, nil)
+
+	_, err := oauthService.ListOAuthClients(context.Background(), &v1pb.ListOAuthClientsRequest{})
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+	readerCtx := permissionContext(2, store.PermissionOAuthClientsRead)
+	resp, err := oauthService.ListOAuthClients(readerCtx, &v1pb.ListOAuthClientsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.OauthClients, 1)
+	assert.False(t, resp.OauthClients[0].Confidential)
+
+	_, err = oauthService.DeleteOAuthClient(readerCtx, &v1pb.DeleteOAuthClientRequest{ClientId: exampleOAuthClient.ClientID})
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	_, err = oauthService.ListOAuthConsents(context.Background(), &v1pb.ListOAuthConsentsRequest{})
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+}
+
+func TestOAuthService_CreateOAuthClient(t *testing.T) {
+	oauthService, mockStore := newOAuthServiceTest(t)
+	ctx := permissionContext(1, store.PermissionOAuthClientsWrite)
+
+	for _, client := range []*v1pb.OAuthClient{
+		{GrantTypes: []string{GrantTypeAuthorizationCode}, RedirectUris: []string{"https://app.example.com/callback"}},
+		{Name: "App", RedirectUris: []string{"https://app.example.com/callback"}},
+		{Name: "App", GrantTypes: []string{"password"}, RedirectUris: []string{"https://app.example.com/callback"}},
+		{Name: "App", GrantTypes: []string{GrantTypeAuthorizationCode}},
+		{Name: "App", GrantTypes: []string{GrantTypeAuthorizationCode}, RedirectUris: []string{"http://app.example.com/callback"}},
+		{Name: "App", GrantTypes: []string{GrantTypeAuthorizationCode}, RedirectUris: []string{"https://app.example.com/callback#fragment"}},
+		{Name: "App", GrantTypes: []string{GrantTypeAuthorizationCode}, RedirectUris: []string{"/callback"}},
+		{Name: "App", GrantTypes: []string{GrantTypeAuthorizationCode}, RedirectUris: []string{"https://app.example.com/callback"}, Scopes: []string{"openid profile"}},
+		{Name: "App", GrantTypes: []string{GrantTypeClientCredentials}},
+	} {
+		_, err := oauthService.CreateOAuthClient(ctx, &v1pb.CreateOAuthClientRequest{OauthClient: client})
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), client.String())
+	}
+
+	var secretHash string
+	mockStore.On("CreateOAuthClient", mock.Anything, mock.MatchedBy(func(create *store.CreateOAuthClient) bool {
+		secretHash = create.SecretHash
+		return len(create.ClientID) == 32 && create.CreatorID == 1 &&
+			assert.ObjectsAreEqual(defaultOAuthClientScopes, create.Scopes)
+	})).Return(&store.OAuthClient{ClientID: "new-client", Name: "App", SecretHash: "hash"}, nil).Once()
+
+	resp, err := oauthService.CreateOAuthClient(ctx, &v1pb.CreateOAuthClientRequest{OauthClient: &v1pb.OAuthClient{
+		Name:         "App",
+		GrantTypes:   []string{GrantTypeAuthorizationCode, GrantTypeClientCredentials},
+		RedirectUris: []string{"http://127.0.0.1:8080/callback"},
+		Confidential: true,
+	}})
+	require.NoError(t, err)
+	assert.True(t, resp.OauthClient.Confidential)
+	require.NotEmpty(t, resp.ClientSecret)
+	// Only the hash of the secret is stored
+	assert.Equal(t, auth.HashToken(resp.ClientSecret), secretHash)
+	mockStore.AssertExpectations(t)
+}
+
+func TestOAuthService_UpdateOAuthClient(t *testing.T) {
+	oauthService, mockStore := newOAuthServiceTest(t)
+	ctx := permissionContext(1, store.PermissionOAuthClientsWrite)
+
+	// Public clients cannot use the client credentials grant
+	_, err := oauthService.UpdateOAuthClient(ctx, &v1pb.UpdateOAuthClientRequest{
+		OauthClient: &v1pb.OAuthClient{ClientId: exampleOAuthClient.ClientID, GrantTypes: []string{GrantTypeClientCredentials}},
+		UpdateMask:  &fieldmaskpb.FieldMask{Paths: []string{"grant_types"}},
+	})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	_, err = oauthService.UpdateOAuthClient(ctx, &v1pb.UpdateOAuthClientRequest{
+		OauthClient: &v1pb.OAuthClient{ClientId: "unknown", Name: "Renamed"},
+		UpdateMask:  &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+	})
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	name := "Renamed"
+	mockStore.On("UpdateOAuthClient", mock.Anything, &store.UpdateOAuthClient{ID: exampleOAuthClient.ID, Name: &name}).
+		Return(&store.OAuthClient{ClientID: exampleOAuthClient.ClientID, Name: name}, nil).Once()
+	resp, err := oauthService.UpdateOAuthClient(ctx, &v1pb.UpdateOAuthClientRequest{
+		OauthClient: &v1pb.OAuthClient{ClientId: exampleOAuthClient.ClientID, Name: name, Scopes: []string{"ignored"}},
+		UpdateMask:  &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, name, resp.OauthClient.Name)
+
+	// Public clients have no secret to rotate
+	_, err = oauthService.RotateOAuthClientSecret(ctx, &v1pb.RotateOAuthClientSecretRequest{ClientId: exampleOAuthClient.ClientID})
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+	mockStore.AssertExpectations(t)
+}
+
+func TestOAuthService_CheckAuthorization(t *testing.T) {
+	oauthService, _ := newOAuthServiceTest(t)
+	ctx := context.Background()
+
+	require.NoError(t, oauthService.CheckAuthorization(ctx, newOAuthAuthorization()))
+
+	// Errors that cannot be sent to the redirect URI
+	for _, modify := range []func(*v1pb.OAuthAuthorization){
+		func(a *v1pb.OAuthAuthorization) { a.ClientId = "unknown" },
+		func(a *v1pb.OAuthAuthorization) { a.RedirectUri = "https://evil.example.com/callback" },
+		func(a *v1pb.OAuthAuthorization) { a.RedirectUri += "?extra=1" },
+	} {
+		authorization := newOAuthAuthorization()
+		modify(authorization)
+		err := oauthService.CheckAuthorization(ctx, authorization)
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), authorization.String())
+	}
+
+	for code, modify := range map[string]func(*v1pb.OAuthAuthorization){
+		"unsupported_response_type": func(a *v1pb.OAuthAuthorization) { a.ResponseType = "token" },
+		"invalid_request":           func(a *v1pb.OAuthAuthorization) { a.CodeChallengeMethod = "plain" },
+		"invalid_scope":             func(a *v1pb.OAuthAuthorization) { a.Scope = "openid admin" },
+	} {
+		authorization := newOAuthAuthorization()
+		modify(authorization)
+		err := oauthService.CheckAuthorization(ctx, authorization)
+		var oauthErr *oidc.Error
+		require.ErrorAs(t, err, &oauthErr, code)
+		assert.Equal(t, code, oauthErr.Code)
+	}
+}
+
+func TestOAuthService_ApproveOAuthAuthorization(t *testing.T) {
+	oauthService, mockStore := newOAuthServiceTest(t)
+	ctx := permissionContext(7)
+	userID := int64(7)
+	find := &store.FindOAuthConsent{UserID: &userID, ClientID: &exampleOAuthClient.ID}
+
+	_, err := oauthService.ApproveOAuthAuthorization(context.Background(), &v1pb.ApproveOAuthAuthorizationRequest{Authorization: newOAuthAuthorization(), Approved: true})
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+	// The user granted the profile scope before
+	mockStore.On("GetOAuthConsent", mock.Anything, find).Return(&store.OAuthConsent{UserID: userID, ClientID: 1, Scopes: []string{"profile", "email"}}, nil)
+	authResp, err := oauthService.GetOAuthAuthorization(ctx, &v1pb.GetOAuthAuthorizationRequest{Authorization: newOAuthAuthorization()})
+	require.NoError(t, err)
+	assert.Equal(t, "Example", authResp.ClientName)
+	assert.Equal(t, []string{"openid", "profile"}, authResp.Scopes)
+	assert.True(t, authResp.ConsentRequired)
+
+	denied, err := oauthService.ApproveOAuthAuthorization(ctx, &v1pb.ApproveOAuthAuthorizationRequest{Authorization: newOAuthAuthorization()})
+	require.NoError(t, err)
+	deniedURL, err := url.Parse(denied.RedirectUrl)
+	require.NoError(t, err)
+	assert.Equal(t, "access_denied", deniedURL.Query().Get("error"))
+	assert.Equal(t, "state", deniedURL.Query().Get("state"))
+
+	mockStore.On("UpsertOAuthConsent", mock.Anything, &store.UpsertOAuthConsent{
+		UserID: userID, ClientID: 1, Scopes: []string{"profile", "email", "openid"},
+	}).Return(&store.OAuthConsent{}, nil).Once()
+	var codeHash string
+	mockStore.On("CreateOAuthAuthorizationCode", mock.Anything, mock.MatchedBy(func(create *store.CreateOAuthAuthorizationCode) bool {
+		codeHash = create.CodeHash
+		return create.ClientID == 1 && create.UserID == userID && create.Nonce == "nonce" &&
+			assert.ObjectsAreEqual([]string{"openid", "profile"}, create.Scopes)
+	})).Return(&store.OAuthAuthorizationCode{}, nil).Once()
+
+	approved, err := oauthService.ApproveOAuthAuthorization(ctx, &v1pb.ApproveOAuthAuthorizationRequest{Authorization: newOAuthAuthorization(), Approved: true})
+	require.NoError(t, err)
+	approvedURL, err := url.Parse(approved.RedirectUrl)
+	require.NoError(t, err)
+	assert.Equal(t, "app.example.com", approvedURL.Host)
+	assert.Equal(t, "state", approvedURL.Query().Get("state"))
+	assert.Equal(t, auth.HashToken(approvedURL.Query().Get("code")), codeHash)
+	mockStore.AssertExpectations(t)
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/server/oidc"
+	"github.com/pixb/go-server/store"
+)
+
+// The protocol endpoints answer with the errors of RFC 6749 section 5.2 and RFC 6750 section 3.1.
+var (
+	errInvalidClient     = &oidc.Error{Code: "invalid_client", Description: "client authentication failed"}
+	errInvalidGrant      = &oidc.Error{Code: "invalid_grant", Description: "the authorization code is invalid or expired"}
+	errInvalidToken      = &oidc.Error{Code: "invalid_token", Description: "the access token is invalid or expired"}
+	errInsufficientScope = &oidc.Error{Code: "insufficient_scope", Description: "the access token has no openid scope"}
+)
+
+// Metadata returns the OpenID Connect provider configuration of the instance at issuer.
+func (s *OAuthService) Metadata(issuer string) *oidc.Metadata {
+	return &oidc.Metadata{
+		Issuer:                            issuer,
+		AuthorizationEndpoint:             issuer + "/oauth/authorize",
+		TokenEndpoint:                     issuer + "/oauth/token",
+		UserinfoEndpoint:                  issuer + "/userinfo",
+		IntrospectionEndpoint:             issuer + "/oauth/introspect",
+		JWKSURI:                           issuer + "/.well-known/jwks.json",
+		ScopesSupported:                   defaultOAuthClientScopes,
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{GrantTypeAuthorizationCode, GrantTypeClientCredentials},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{auth.SigningAlgorithmEdDSA, auth.SigningAlgorithmRS256},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{"S256"},
+		ClaimsSupported:                   []string{"iss", "sub", "aud", "exp", "iat", "nonce", "preferred_username", "name", "updated_at", "email", "email_verified"},
+	}
+}
+
+// Token serves a request to the token endpoint. The client credentials are taken from the
+// Authorization header or the form by the caller, clientSecret is empty for public clients.
+// Protocol errors are returned as *oidc.Error.
+func (s *OAuthService) Token(ctx context.Context, issuer, clientID, clientSecret string, form url.Values) (*oidc.Token, error) {
+	client, err := s.authenticateClient(ctx, clientID, clientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	grantType := form.Get("grant_type")
+	if grantType != GrantTypeAuthorizationCode && grantType != GrantTypeClientCredentials {
+		return nil, &oidc.Error{Code: "unsupported_grant_type", Description: "only the authorization_code and client_credentials grants are supported"}
+	}
+	if !slices.Contains(client.GrantTypes, grantType) {
+		return nil, &oidc.Error{Code: "unauthorized_client", Description: fmt.Sprintf("the client is not allowed to use the %s grant", grantType)}
+	}
+	if grantType == GrantTypeClientCredentials {
+		return s.clientCredentialsGrant(ctx, issuer, client, form)
+	}
+	return s.authorizationCodeGrant(ctx, issuer, client, form)
+}
+
+func (s *OAuthService) authorizationCodeGrant(ctx context.Context, issuer string, client *store.OAuthClient, form url.Values) (*oidc.Token, error) {
+	codeHash := auth.HashToken(form.Get("code"))
+	code, err := s.Store.GetOAuthAuthorizationCode(ctx, &store.FindOAuthAuthorizationCode{CodeHash: &codeHash})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get authorization code: %w", err)
+	}
+	if code == nil || code.ClientID != client.ID || code.UsedAt != nil || time.Now().After(code.ExpiresAt) {
+		return nil, errInvalidGrant
+	}
+	if form.Get("redirect_uri") != code.RedirectURI {
+		return nil, &oidc.Error{Code: "invalid_grant", Description: "redirect_uri does not match the authorization request"}
+	}
+	challenge := oidc.CodeChallenge(form.Get("code_verifier"))
+	if subtle.ConstantTimeCompare([]byte(challenge), []byte(code.CodeChallenge)) != 1 {
+		return nil, &oidc.Error{Code: "invalid_grant", Description: "code_verifier does not match the code challenge"}
+	}
+	// Codes are redeemed once, a concurrent request with the same code loses here.
+	used, err := s.Store.UseOAuthAuthorizationCode(ctx, code.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to use authorization code: %w", err)
+	}
+	if !used {
+		return nil, errInvalidGrant
+	}
+	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &code.UserID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errInvalidGrant
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	token, err := s.issueToken(ctx, issuer, client, user, code.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(code.Scopes, auth.ScopeOpenID) {
+		idToken, err := s.Keys.Sign(ctx, auth.NewIDTokenClaims(issuer, client.ClientID, user, code.Scopes, code.Nonce))
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign id token: %w", err)
+		}
+		token.IDToken = idToken
+	}
+	return token, nil
+}
+
+func (s *OAuthService) clientCredentialsGrant(ctx context.Context, issuer string, client *store.OAuthClient, form url.Values) (*oidc.Token, error) {
+	scopes := client.Scopes
+	if scope := form.Get("scope"); scope != "" {
+		scopes = appendMissing(nil, strings.Fields(scope))
+		if !isSubset(scopes, client.Scopes) {
+			return nil, &oidc.Error{Code: "invalid_scope", Description: "the client is not allowed to request the scope"}
+		}
+	}
+	// Without a user the OpenID Connect scopes have no meaning.
+	scopes = slices.DeleteFunc(slices.Clone(scopes), func(scope string) bool {
+		return slices.Contains(defaultOAuthClientScopes, scope)
+	})
+	return s.issueToken(ctx, issuer, client, nil, scopes)
+}
+
+func (s *OAuthService) issueToken(ctx context.Context, issuer string, client *store.OAuthClient, user *store.User, scopes []string) (*oidc.Token, error) {
+	claims, err := auth.NewOAuthAccessTokenClaims(issuer, client.ClientID, user, scopes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build access token: %w", err)
+	}
+	accessToken, err := s.Keys.Sign(ctx, claims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign access token: %w", err)
+	}
+	return &oidc.Token{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(auth.OAuthAccessTokenDuration.Seconds()),
+		Scope:       strings.Join(scopes, " "),
+	}, nil
+}
+
+// authenticateClient checks the secret of a confidential client. Public clients must not send one.
+func (s *OAuthService) authenticateClient(ctx context.Context, clientID, clientSecret string) (*store.OAuthClient, error) {
+	if clientID == "" {
+		return nil, errInvalidClient
+	}
+	client, err := s.Store.GetOAuthClient(ctx, &store.FindOAuthClient{ClientID: &clientID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oauth client: %w", err)
+	}
+	if client == nil {
+		return nil, errInvalidClient
+	}
+	if client.SecretHash == "" {
+		if clientSecret != "" {
+			return nil, errInvalidClient
+		}
+		return client, nil
+	}
+	if subtle.ConstantTimeCompare([]byte(auth.HashToken(clientSecret)), []byte(client.SecretHash)) != 1 {
+		return nil, errInvalidClient
+	}
+	return client, nil
+}
+
+// UserInfo returns the claims about the user of an access token issued with the openid scope.
+// Protocol errors are returned as *oidc.Error.
+func (s *OAuthService) UserInfo(ctx context.Context, accessToken string) (map[string]any, error) {
+	claims, err := s.Keys.ValidateAccessToken(ctx, accessToken)
+	if err != nil || claims.ClientID == "" || claims.UserID == 0 {
+		return nil, errInvalidToken
+	}
+	if !slices.Contains(claims.Scopes(), auth.ScopeOpenID) {
+		return nil, errInsufficientScope
+	}
+	revoked, err := s.Store.IsTokenRevoked(ctx, claims.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check token: %w", err)
+	}
+	if revoked {
+		return nil, errInvalidToken
+	}
+	// Deleting a client invalidates the tokens it holds.
+	client, err := s.Store.GetOAuthClient(ctx, &store.FindOAuthClient{ClientID: &claims.ClientID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oauth client: %w", err)
+	}
+	if client == nil {
+		return nil, errInvalidToken
+	}
+	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &claims.UserID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errInvalidToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return auth.UserInfoClaims(user, claims.Scopes()), nil
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	storepb "github.com/pixb/go-server/proto/gen/store"
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/store"
+)
+
+// passwordPolicyViolationReason is the ErrorInfo reason of errors for passwords rejected by the policy.
+const passwordPolicyViolationReason = "PASSWORD_POLICY_VIOLATION"
+
+// passwordStore is the subset of the store needed to apply the password policy.
+type passwordStore interface {
+	GetInstancePasswordPolicySetting(ctx context.Context) (*storepb.InstancePasswordPolicySetting, error)
+	CreatePasswordHistory(ctx context.Context, create *store.CreatePasswordHistory) (*store.PasswordHistory, error)
+	ListPasswordHistories(ctx context.Context, find *store.FindPasswordHistory) ([]*store.PasswordHistory, error)
+	DeletePasswordHistories(ctx context.Context, delete *store.DeletePasswordHistory) error
+}
+
+// validateNewPassword checks a password about to be set against the instance password policy.
+// user is the user whose password changes, or nil for a new user. Every path that sets a
+// password goes through here, and through recordPassword once it is stored.
+func validateNewPassword(ctx context.Context, s passwordStore, user *store.User, password string) (*auth.PasswordPolicy, error) {
+	setting, err := s.GetInstancePasswordPolicySetting(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get password policy"))
+	}
+	policy := auth.NewPasswordPolicy(setting)
+
+	var previousHashes []string
+	if user != nil && policy.HistoryCount > 0 {
+		// The current password may predate the history, so it is always checked first.
+		previousHashes = append(previousHashes, user.Password)
+		histories, err := s.ListPasswordHistories(ctx, &store.FindPasswordHistory{UserID: user.ID, Limit: policy.HistoryCount})
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to list password history"))
+		}
+		for _, history := range histories {
+			if history.PasswordHash != user.Password {
+				previousHashes = append(previousHashes, history.PasswordHash)
+			}
+		}
+	}
+
+	if err := policy.Validate(password, previousHashes); err != nil {
+		var policyErr *auth.PasswordPolicyError
+		if !errors.As(err, &policyErr) {
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to validate password"))
+		}
+		connectErr := connect.NewError(connect.CodeInvalidArgument, policyErr)
+		if detail, err := connect.NewErrorDetail(&errdetails.ErrorInfo{
+			Reason:   passwordPolicyViolationReason,
+			Domain:   "go-server",
+			Metadata: map[string]string{"rule": string(policyErr.Rule)},
+		}); err == nil {
+			connectErr.AddDetail(detail)
+		}
+		return nil, connectErr
+	}
+	return policy, nil
+}
+
+// recordPassword adds a newly stored password hash to the user's history and
+// drops the entries the policy no longer needs.
+func recordPassword(ctx context.Context, s passwordStore, policy *auth.PasswordPolicy, userID int64, passwordHash string) error {
+	if _, err := s.CreatePasswordHistory(ctx, &store.CreatePasswordHistory{
+		UserID:       userID,
+		PasswordHash: passwordHash,
+	}); err != nil {
+		return connect.NewError(connect.CodeInternal, errors.New("failed to record password history"))
+	}
+
+	keep := max(policy.HistoryCount, 1)
+	histories, err := s.ListPasswordHistories(ctx, &store.FindPasswordHistory{UserID: userID, Limit: keep})
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, errors.New("failed to list password history"))
+	}
+	if len(histories) == keep {
+		if err := s.DeletePasswordHistories(ctx, &store.DeletePasswordHistory{
+			UserID:   userID,
+			BeforeID: histories[keep-1].ID,
+		}); err != nil {
+			return connect.NewError(connect.CodeInternal, errors.New("failed to prune password history"))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"connectrpc.com/connect"
+
+	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/server/notify"
+	"github.com/pixb/go-server/store"
+)
+
+const (
+	// passwordResetTokenDuration is how long a password reset token can be used.
+	passwordResetTokenDuration = 30 * time.Minute
+	// passwordResetInterval is the least time between two reset emails to the same user.
+	passwordResetInterval = time.Minute
+)
+
+func errInvalidPasswordResetToken() error {
+	return connect.NewError(connect.CodeInvalidArgument, errors.New("invalid or expired password reset token"))
+}
+
+func (s *AuthService) RequestPasswordReset(ctx context.Context, req *v1pb.RequestPasswordResetRequest) (*v1pb.RequestPasswordResetResponse, error) {
+	if req.Email == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("email is required"))
+	}
+	if _, disabled := s.Notifier.(*notify.DisabledNotifier); disabled {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("password reset is not available, no mail server is configured"))
+	}
+
+	user, err := s.Store.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user"))
+	}
+	// The response does not reveal whether the address belongs to a user.
+	if user == nil {
+		return &v1pb.RequestPasswordResetResponse{}, nil
+	}
+	// Nor whether it belongs to a directory user, whose password is changed in the directory.
+	directoryUser, err := isDirectoryUser(ctx, s.Store, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if directoryUser {
+		return &v1pb.RequestPasswordResetResponse{}, nil
+	}
+
+	latest, err := s.Store.GetPasswordResetToken(ctx, &store.FindPasswordResetToken{UserID: &user.ID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get password reset token"))
+	}
+	if latest != nil && latest.UsedAt == nil && time.Since(latest.CreatedAt) < passwordResetInterval {
+		return &v1pb.RequestPasswordResetResponse{}, nil
+	}
+
+	token, err := auth.GenerateOneTimeToken()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to generate password reset token"))
+	}
+	// Only the most recently sent token can be used.
+	if err := s.Store.DeletePasswordResetTokens(ctx, &store.DeletePasswordResetToken{UserID: user.ID}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to delete password reset tokens"))
+	}
+	expiresAt := time.Now().Add(passwordResetTokenDuration)
+	if _, err := s.Store.CreatePasswordResetToken(ctx, &store.CreatePasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: auth.HashToken(token),
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to create password reset token"))
+	}
+
+	if err := s.Notifier.Send(ctx, &notify.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nA password reset was requested for your account. Use the following token to set a new password:\n\n%s\n\nThe token expires at %s. If you did not request a reset, you can ignore this email.\n",
+			user.Nickname, token, expiresAt.UTC().Format(time.RFC1123)),
+	}); err != nil {
+		// Failing the request would reveal that the address belongs to a user.
+		slog.Error("failed to send password reset email", slog.Int64("userID", user.ID), slog.Any("error", err))
+	}
+	return &v1pb.RequestPasswordResetResponse{}, nil
+}
+
+func (s *AuthService) ConfirmPasswordReset(ctx context.Context, req *v1pb.ConfirmPasswordResetRequest) (*v1pb.ConfirmPasswordResetResponse, error) {
+	if req.Token == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("token is required"))
+	}
+	if req.NewPassword == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("new password is required"))
+	}
+
+	tokenHash := auth.HashToken(req.Token)
+	resetToken, err := s.Store.GetPasswordResetToken(ctx, &store.FindPasswordResetToken{TokenHash: &tokenHash})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get password reset token"))
+	}
+	if resetToken == nil || resetToken.UsedAt != nil || time.Now().After(resetToken.ExpiresAt) {
+		return nil, errInvalidPasswordResetToken()
+	}
+
+	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &resetToken.UserID})
+	if err != nil || user == nil {
+		return nil, errInvalidPasswordResetToken()
+	}
+	// The token may have been sent before the user signed in through the directory.
+	directoryUser, err := isDirectoryUser(ctx, s.Store, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if directoryUser {
+		return nil, errInvalidPasswordResetToken()
+	}
+
+	// The password is checked before the token is used up, so that a rejected password can be retried.
+	passwordPolicy, err := validateNewPassword(ctx, s.Store, user, req.NewPassword)
+	if err != nil {
+		return nil, err
+	}
+	used, err := s.Store.UsePasswordResetToken(ctx, resetToken.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to use password reset token"))
+	}
+	if !used {
+		return nil, errInvalidPasswordResetToken()
+	}
+
+	passwordHash, err := auth.HashPassword(req.NewPassword)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to hash new password"))
+	}
+	passwordExpires := passwordPolicy.ExpiresAt(time.Now())
+	// The token was sent to the user's address, which proves they own it.
+	emailVerified := true
+	if _, err := s.Store.UpdateUser(ctx, &store.UpdateUser{
+		ID:              user.ID,
+		Password:        &passwordHash,
+		PasswordExpires: &passwordExpires,
+		EmailVerified:   &emailVerified,
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to update password"))
+	}
+	if err := recordPassword(ctx, s.Store, passwordPolicy, user.ID, passwordHash); err != nil {
+		return nil, err
+	}
+	if err := s.Store.DeletePasswordResetTokens(ctx, &store.DeletePasswordResetToken{UserID: user.ID}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to delete password reset tokens"))
+	}
+
+	// Whoever knew the old password must not stay signed in.
+	revoked, err := revokeAllSessions(ctx, s.Store, user.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to revoke sessions"))
+	}
+	if err := resetLoginFailures(ctx, s.Store, user.Username); err != nil {
+		return nil, err
+	}
+	if _, err := s.Store.CreateSecurityEvent(ctx, &store.CreateSecurityEvent{
+		UserID: user.ID,
+		Type:   store.SecurityEventPasswordReset,
+		Detail: fmt.Sprintf("password reset with a token, %d sessions revoked", revoked),
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to record security event"))
+	}
+
+	return &v1pb.ConfirmPasswordResetResponse{}, nil
+}
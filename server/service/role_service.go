@@ -0,0 +1,350 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"connectrpc.com/connect"
+
+	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/store"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,31}$`)
+
+// roleStore is the subset of the store needed to check that callers only grant what they have.
+type roleStore interface {
+	ListRoleDefinitions(ctx context.Context, find *store.FindRoleDefinition) ([]*store.RoleDefinition, error)
+	ListUserRoleDefinitions(ctx context.Context, user *store.User) ([]*store.RoleDefinition, error)
+}
+
+// RoleStore is an interface that defines the methods needed by RoleService
+type RoleStore interface {
+	ListUsers(ctx context.Context, find *store.FindUser) ([]*store.User, error)
+	CreateRoleDefinition(ctx context.Context, create *store.CreateRoleDefinition) (*store.RoleDefinition, error)
+	UpdateRoleDefinition(ctx context.Context, update *store.UpdateRoleDefinition) (*store.RoleDefinition, error)
+	DeleteRoleDefinition(ctx context.Context, delete *store.DeleteRoleDefinition) error
+	SetUserRoles(ctx context.Context, set *store.SetUserRoles) error
+	roleStore
+}
+
+// RoleService manages the custom roles of the instance and assigns them to users.
+// Callers can only grant, change or take away permissions they have themselves.
+type RoleService struct {
+	Store RoleStore
+}
+
+func NewRoleService(store RoleStore) *RoleService {
+	return &RoleService{
+		Store: store,
+	}
+}
+
+func (s *RoleService) ListPermissions(ctx context.Context, req *v1pb.ListPermissionsRequest) (*v1pb.ListPermissionsResponse, error) {
+	if err := auth.Require(ctx, store.PermissionRolesRead); err != nil {
+		return nil, err
+	}
+
+	return &v1pb.ListPermissionsResponse{Permissions: convertPermissionsFromStore(store.Permissions)}, nil
+}
+
+func (s *RoleService) ListRoles(ctx context.Context, req *v1pb.ListRolesRequest) (*v1pb.ListRolesResponse, error) {
+	if err := auth.Require(ctx, store.PermissionRolesRead); err != nil {
+		return nil, err
+	}
+
+	roles, err := s.Store.ListRoleDefinitions(ctx, &store.FindRoleDefinition{})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to list roles"))
+	}
+	response := &v1pb.ListRolesResponse{}
+	for _, role := range roles {
+		response.Roles = append(response.Roles, convertRoleDefinitionFromStore(role))
+	}
+	return response, nil
+}
+
+func (s *RoleService) GetRole(ctx context.Context, req *v1pb.GetRoleRequest) (*v1pb.GetRoleResponse, error) {
+	if err := auth.Require(ctx, store.PermissionRolesRead); err != nil {
+		return nil, err
+	}
+
+	role, err := s.findRole(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &v1pb.GetRoleResponse{Role: convertRoleDefinitionFromStore(role)}, nil
+}
+
+func (s *RoleService) CreateRole(ctx context.Context, req *v1pb.CreateRoleRequest) (*v1pb.CreateRoleResponse, error) {
+	if err := auth.Require(ctx, store.PermissionRolesWrite); err != nil {
+		return nil, err
+	}
+
+	if !roleNamePattern.MatchString(req.Name) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("name must be 2 to 32 lowercase letters, digits, '-' or '_', starting with a letter"))
+	}
+	permissions, err := parsePermissions(req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkGrantable(ctx, permissions); err != nil {
+		return nil, err
+	}
+
+	name := store.Role(req.Name)
+	existingRoles, err := s.Store.ListRoleDefinitions(ctx, &store.FindRoleDefinition{Name: &name})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get role"))
+	}
+	if len(existingRoles) > 0 {
+		return nil, connect.NewError(connect.CodeAlreadyExists, errors.New("role already exists"))
+	}
+
+	role, err := s.Store.CreateRoleDefinition(ctx, &store.CreateRoleDefinition{
+		Name:        name,
+		Description: req.Description,
+		Permissions: permissions,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to create role"))
+	}
+	return &v1pb.CreateRoleResponse{Role: convertRoleDefinitionFromStore(role)}, nil
+}
+
+func (s *RoleService) UpdateRole(ctx context.Context, req *v1pb.UpdateRoleRequest) (*v1pb.UpdateRoleResponse, error) {
+	if err := auth.Require(ctx, store.PermissionRolesWrite); err != nil {
+		return nil, err
+	}
+	if req.UpdateMask == nil || len(req.UpdateMask.Paths) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("update mask is required"))
+	}
+
+	role, err := s.findRole(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if role.BuiltIn {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("built-in roles cannot be changed"))
+	}
+	if err := checkGrantable(ctx, role.Permissions); err != nil {
+		return nil, err
+	}
+
+	update := &store.UpdateRoleDefinition{ID: role.ID}
+	for _, path := range req.UpdateMask.Paths {
+		switch path {
+		case "description":
+			update.Description = &req.Description
+		case "permissions":
+			permissions, err := parsePermissions(req.Permissions)
+			if err != nil {
+				return nil, err
+			}
+			if err := checkGrantable(ctx, permissions); err != nil {
+				return nil, err
+			}
+			update.Permissions = permissions
+		default:
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unsupported update mask path %q", path))
+		}
+	}
+
+	updatedRole, err := s.Store.UpdateRoleDefinition(ctx, update)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to update role"))
+	}
+	return &v1pb.UpdateRoleResponse{Role: convertRoleDefinitionFromStore(updatedRole)}, nil
+}
+
+func (s *RoleService) DeleteRole(ctx context.Context, req *v1pb.DeleteRoleRequest) (*v1pb.DeleteRoleResponse, error) {
+	if err := auth.Require(ctx, store.PermissionRolesWrite); err != nil {
+		return nil, err
+	}
+
+	role, err := s.findRole(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if role.BuiltIn {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("built-in roles cannot be deleted"))
+	}
+	if err := checkGrantable(ctx, role.Permissions); err != nil {
+		return nil, err
+	}
+	if err := s.Store.DeleteRoleDefinition(ctx, &store.DeleteRoleDefinition{ID: role.ID}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to delete role"))
+	}
+
+	return &v1pb.DeleteRoleResponse{}, nil
+}
+
+func (s *RoleService) ListUserRoles(ctx context.Context, req *v1pb.ListUserRolesRequest) (*v1pb.ListUserRolesResponse, error) {
+	if err := auth.Require(ctx, store.PermissionRolesRead); err != nil {
+		return nil, err
+	}
+
+	user, err := s.findUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := s.Store.ListUserRoleDefinitions(ctx, user)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user roles"))
+	}
+
+	response := &v1pb.ListUserRolesResponse{
+		Permissions: convertPermissionsFromStore(auth.ResolvePermissions(roles)),
+	}
+	for _, role := range roles {
+		if !role.BuiltIn {
+			response.Roles = append(response.Roles, convertRoleDefinitionFromStore(role))
+		}
+	}
+	return response, nil
+}
+
+func (s *RoleService) SetUserRoles(ctx context.Context, req *v1pb.SetUserRolesRequest) (*v1pb.SetUserRolesResponse, error) {
+	if err := auth.Require(ctx, store.PermissionRolesWrite); err != nil {
+		return nil, err
+	}
+
+	user, err := s.findUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkUserGrantable(ctx, s.Store, user); err != nil {
+		return nil, err
+	}
+
+	allRoles, err := s.Store.ListRoleDefinitions(ctx, &store.FindRoleDefinition{})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to list roles"))
+	}
+	var roles []*store.RoleDefinition
+	for _, name := range req.Roles {
+		index := slices.IndexFunc(allRoles, func(role *store.RoleDefinition) bool {
+			return role.Name == store.Role(name)
+		})
+		if index < 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("role %q not found", name))
+		}
+		role := allRoles[index]
+		if role.BuiltIn {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("built-in role %q is set with the role of the user", name))
+		}
+		if !slices.Contains(roles, role) {
+			roles = append(roles, role)
+		}
+	}
+
+	roleIDs := []int64{}
+	for _, role := range roles {
+		if err := checkGrantable(ctx, role.Permissions); err != nil {
+			return nil, err
+		}
+		roleIDs = append(roleIDs, role.ID)
+	}
+	if err := s.Store.SetUserRoles(ctx, &store.SetUserRoles{UserID: user.ID, RoleIDs: roleIDs}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to set user roles"))
+	}
+
+	response := &v1pb.SetUserRolesResponse{}
+	for _, role := range roles {
+		response.Roles = append(response.Roles, convertRoleDefinitionFromStore(role))
+	}
+	return response, nil
+}
+
+// findRole returns the role with the ID, or NotFound.
+func (s *RoleService) findRole(ctx context.Context, id int64) (*store.RoleDefinition, error) {
+	roles, err := s.Store.ListRoleDefinitions(ctx, &store.FindRoleDefinition{ID: &id})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get role"))
+	}
+	if len(roles) == 0 {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("role not found"))
+	}
+	return roles[0], nil
+}
+
+// findUser returns the user with the ID, or NotFound.
+func (s *RoleService) findUser(ctx context.Context, id int64) (*store.User, error) {
+	users, err := s.Store.ListUsers(ctx, &store.FindUser{ID: &id})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user"))
+	}
+	if len(users) == 0 {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("user not found"))
+	}
+	return users[0], nil
+}
+
+// parsePermissions validates permission names and returns them sorted and without duplicates.
+func parsePermissions(names []string) ([]store.Permission, error) {
+	permissions := []store.Permission{}
+	for _, name := range names {
+		permission := store.Permission(name)
+		if !store.IsValidPermission(permission) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unknown permission %q", name))
+		}
+		permissions = append(permissions, permission)
+	}
+	slices.Sort(permissions)
+	return slices.Compact(permissions), nil
+}
+
+// checkGrantable returns PermissionDenied unless the caller has all of the permissions, so
+// that nobody can grant more than they have or manage users who have more than they do.
+func checkGrantable(ctx context.Context, permissions []store.Permission) error {
+	claims := auth.GetUserClaims(ctx)
+	for _, permission := range permissions {
+		if !claims.HasPermission(permission) {
+			return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission %q is required", permission))
+		}
+	}
+	return nil
+}
+
+// checkRoleGrantable checks that the caller has all of the permissions of a built-in role.
+func checkRoleGrantable(ctx context.Context, s roleStore, role store.Role) error {
+	roles, err := s.ListRoleDefinitions(ctx, &store.FindRoleDefinition{Name: &role})
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, errors.New("failed to get role"))
+	}
+	return checkGrantable(ctx, auth.ResolvePermissions(roles))
+}
+
+// checkUserGrantable checks that the caller has all of the permissions of a user.
+func checkUserGrantable(ctx context.Context, s roleStore, user *store.User) error {
+	roles, err := s.ListUserRoleDefinitions(ctx, user)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, errors.New("failed to get user roles"))
+	}
+	return checkGrantable(ctx, auth.ResolvePermissions(roles))
+}
+
+func convertRoleDefinitionFromStore(role *store.RoleDefinition) *v1pb.RoleDefinition {
+	return &v1pb.RoleDefinition{
+		Id:          role.ID,
+		Name:        string(role.Name),
+		Description: role.Description,
+		BuiltIn:     role.BuiltIn,
+		Permissions: convertPermissionsFromStore(role.Permissions),
+		CreatedAt:   timestamppb.New(role.CreatedAt),
+		UpdatedAt:   timestamppb.New(role.UpdatedAt),
+	}
+}
+
+func convertPermissionsFromStore(permissions []store.Permission) []string {
+	names := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		names = append(names, string(permission))
+	}
+	return names
+}
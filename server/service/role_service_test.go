@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	"github.com/pixb/go-server/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+var auditorRoleDefinition = &store.RoleDefinition{ID: 3, Name: "auditor", Permissions: []store.Permission{store.PermissionUsersRead}}
+
+func newRoleServiceTest(t *testing.T) (*RoleService, *MockStore, context.Context) {
+	t.Helper()
+	mockStore := new(MockStore)
+	roles := []*store.RoleDefinition{adminRoleDefinition, userRoleDefinition, auditorRoleDefinition}
+	mockStore.On("ListRoleDefinitions", mock.Anything, &store.FindRoleDefinition{}).Return(roles, nil).Maybe()
+	for _, role := range roles {
+		mockStore.On("ListRoleDefinitions", mock.Anything, &store.FindRoleDefinition{ID: &role.ID}).Return([]*store.RoleDefinition{role}, nil).Maybe()
+	}
+	return NewRoleService(mockStore), mockStore, permissionContext(1, store.Permissions...)
+}
+
+func TestRoleService_RequiresPermissions(t *testing.T) {
+	roleService, mockStore, _ := newRoleServiceTest(t)
+
+	_, err := roleService.ListRoles(context.Background(), &v1pb.ListRolesRequest{})
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+	readerCtx := permissionContext(2, store.PermissionRolesRead)
+	resp, err := roleService.ListRoles(readerCtx, &v1pb.ListRolesRequest{})
+	require.NoError(t, err)
+	assert.Len(t, resp.Roles, 3)
+	_, err = roleService.DeleteRole(readerCtx, &v1pb.DeleteRoleRequest{Id: auditorRoleDefinition.ID})
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	mockStore.AssertNotCalled(t, "DeleteRoleDefinition", mock.Anything, mock.Anything)
+}
+
+func TestRoleService_CreateRole(t *testing.T) {
+	roleService, mockStore, ctx := newRoleServiceTest(t)
+
+	_, err := roleService.CreateRole(ctx, &v1pb.CreateRoleRequest{Name: "Support Team"})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	_, err = roleService.CreateRole(ctx, &v1pb.CreateRoleRequest{Name: "support", Permissions: []string{"users.delete"}})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// Callers cannot grant permissions they do not have
+	limitedCtx := permissionContext(2, store.PermissionRolesWrite, store.PermissionUsersRead)
+	_, err = roleService.CreateRole(limitedCtx, &v1pb.CreateRoleRequest{Name: "support", Permissions: []string{"users.write"}})
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	auditorName := auditorRoleDefinition.Name
+	mockStore.On("ListRoleDefinitions", mock.Anything, &store.FindRoleDefinition{Name: &auditorName}).Return([]*store.RoleDefinition{auditorRoleDefinition}, nil)
+	_, err = roleService.CreateRole(ctx, &v1pb.CreateRoleRequest{Name: "auditor", Permissions: []string{"users.read"}})
+	assert.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err))
+
+	// Permissions are sorted and deduplicated
+	supportName := store.Role("support")
+	mockStore.On("ListRoleDefinitions", mock.Anything, &store.FindRoleDefinition{Name: &supportName}).Return([]*store.RoleDefinition{}, nil)
+	mockStore.On("CreateRoleDefinition", mock.Anything, &store.CreateRoleDefinition{
+		Name:        supportName,
+		Description: "Helps users",
+		Permissions: []store.Permission{store.PermissionUsersRead, store.PermissionUsersWrite},
+	}).Return(&store.RoleDefinition{ID: 4, Name: supportName, Description: "Helps users", Permissions: []store.Permission{store.PermissionUsersRead, store.PermissionUsersWrite}}, nil)
+	resp, err := roleService.CreateRole(ctx, &v1pb.CreateRoleRequest{
+		Name:        "support",
+		Description: "Helps users",
+		Permissions: []string{"users.write", "users.read", "users.write"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "support", resp.Role.Name)
+	assert.Equal(t, []string{"users.read", "users.write"}, resp.Role.Permissions)
+	assert.False(t, resp.Role.BuiltIn)
+}
+
+func TestRoleService_UpdateAndDeleteRole(t *testing.T) {
+	roleService, mockStore, ctx := newRoleServiceTest(t)
+
+	// Built-in roles are read-only
+	_, err := roleService.UpdateRole(ctx, &v1pb.UpdateRoleRequest{
+		Id:         adminRoleDefinition.ID,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"permissions"}},
+	})
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+	_, err = roleService.DeleteRole(ctx, &v1pb.DeleteRoleRequest{Id: userRoleDefinition.ID})
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	_, err = roleService.UpdateRole(ctx, &v1pb.UpdateRoleRequest{Id: auditorRoleDefinition.ID})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	_, err = roleService.UpdateRole(ctx, &v1pb.UpdateRoleRequest{
+		Id:         auditorRoleDefinition.ID,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+	})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// Only the fields in the mask are updated
+	description := "Reads users and roles"
+	mockStore.On("UpdateRoleDefinition", mock.Anything, &store.UpdateRoleDefinition{
+		ID:          auditorRoleDefinition.ID,
+		Description: &description,
+	}).Return(&store.RoleDefinition{ID: auditorRoleDefinition.ID, Name: "auditor", Description: description}, nil)
+	resp, err := roleService.UpdateRole(ctx, &v1pb.UpdateRoleRequest{
+		Id:          auditorRoleDefinition.ID,
+		Description: description,
+		Permissions: []string{"roles.read"},
+		UpdateMask:  &fieldmaskpb.FieldMask{Paths: []string{"description"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, description, resp.Role.Description)
+
+	mockStore.On("DeleteRoleDefinition", mock.Anything, &store.DeleteRoleDefinition{ID: auditorRoleDefinition.ID}).Return(nil)
+	_, err = roleService.DeleteRole(ctx, &v1pb.DeleteRoleRequest{Id: auditorRoleDefinition.ID})
+	require.NoError(t, err)
+
+	missingID := int64(9)
+	mockStore.On("ListRoleDefinitions", mock.Anything, &store.FindRoleDefinition{ID: &missingID}).Return([]*store.RoleDefinition{}, nil)
+	_, err = roleService.DeleteRole(ctx, &v1pb.DeleteRoleRequest{Id: missingID})
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestRoleService_UserRoles(t *testing.T) {
+	roleService, mockStore, ctx := newRoleServiceTest(t)
+	adminID, userID := int64(1), int64(2)
+	admin := &store.User{ID: adminID, Username: "admin", Role: store.RoleAdmin}
+	user := &store.User{ID: userID, Username: "testuser", Role: store.RoleUser}
+	mockStore.On("ListUsers", mock.Anything, &store.FindUser{ID: &adminID}).Return([]*store.User{admin}, nil)
+	mockStore.On("ListUsers", mock.Anything, &store.FindUser{ID: &userID}).Return([]*store.User{user}, nil)
+	mockStore.On("ListUserRoleDefinitions", mock.Anything, admin).Return([]*store.RoleDefinition{adminRoleDefinition}, nil)
+	mockStore.On("ListUserRoleDefinitions", mock.Anything, user).Return([]*store.RoleDefinition{userRoleDefinition, auditorRoleDefinition}, nil)
+
+	// Only custom roles are listed, the permissions include the ones of the built-in role
+	resp, err := roleService.ListUserRoles(ctx, &v1pb.ListUserRolesRequest{UserId: userID})
+	require.NoError(t, err)
+	require.Len(t, resp.Roles, 1)
+	assert.Equal(t, "auditor", resp.Roles[0].Name)
+	assert.Equal(t, []string{"users.read"}, resp.Permissions)
+
+	_, err = roleService.SetUserRoles(ctx, &v1pb.SetUserRolesRequest{UserId: userID, Roles: []string{"unknown"}})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	_, err = roleService.SetUserRoles(ctx, &v1pb.SetUserRolesRequest{UserId: userID, Roles: []string{"admin"}})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// Callers cannot change the roles of users who have more permissions than they do
+	limitedCtx := permissionContext(3, store.PermissionRolesWrite, store.PermissionUsersRead)
+	_, err = roleService.SetUserRoles(limitedCtx, &v1pb.SetUserRolesRequest{UserId: adminID})
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	mockStore.AssertNotCalled(t, "SetUserRoles", mock.Anything, mock.Anything)
+
+	mockStore.On("SetUserRoles", mock.Anything, &store.SetUserRoles{UserID: userID, RoleIDs: []int64{auditorRoleDefinition.ID}}).Return(nil)
+	setResp, err := roleService.SetUserRoles(limitedCtx, &v1pb.SetUserRolesRequest{UserId: userID, Roles: []string{"auditor", "auditor"}})
+	require.NoError(t, err)
+	require.Len(t, setResp.Roles, 1)
+	assert.Equal(t, auditorRoleDefinition.ID, setResp.Roles[0].Id)
+
+	mockStore.On("SetUserRoles", mock.Anything, &store.SetUserRoles{UserID: userID, RoleIDs: []int64{}}).Return(nil)
+	setResp, err = roleService.SetUserRoles(ctx, &v1pb.SetUserRolesRequest{UserId: userID})
+	require.NoError(t, err)
+	assert.Empty(t, setResp.Roles)
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/store"
+)
+
+// sessionStore is the subset of store methods needed to manage login sessions.
+// A session is a refresh token family: it starts at login and survives rotation.
+type sessionStore interface {
+	ListRefreshTokens(ctx context.Context, find *store.FindRefreshToken) ([]*store.RefreshToken, error)
+	UpdateRefreshToken(ctx context.Context, update *store.UpdateRefreshToken) (*store.RefreshToken, error)
+	CreateRevokedToken(ctx context.Context, create *store.CreateRevokedToken) (*store.RevokedToken, error)
+}
+
+type session struct {
+	ID        string
+	UserAgent string
+	IPAddress string
+	CreatedAt time.Time
+	// LastUsedAt is the time of the latest login or refresh.
+	LastUsedAt time.Time
+	ExpiresAt  time.Time
+}
+
+// listActiveSessions groups the user's refresh tokens by family and returns the
+// families whose newest token is still usable, most recently used first.
+func listActiveSessions(ctx context.Context, s sessionStore, userID int64) ([]*session, error) {
+	refreshTokens, err := s.ListRefreshTokens(ctx, &store.FindRefreshToken{UserID: &userID})
+	if err != nil {
+		return nil, err
+	}
+
+	firsts := map[string]*store.RefreshToken{}
+	latests := map[string]*store.RefreshToken{}
+	for _, refreshToken := range refreshTokens {
+		if first, ok := firsts[refreshToken.FamilyID]; !ok || refreshToken.CreatedAt.Before(first.CreatedAt) {
+			firsts[refreshToken.FamilyID] = refreshToken
+		}
+		if latest, ok := latests[refreshToken.FamilyID]; !ok || refreshToken.ID > latest.ID {
+			latests[refreshToken.FamilyID] = refreshToken
+		}
+	}
+
+	now := time.Now()
+	sessions := []*session{}
+	for familyID, latest := range latests {
+		if latest.Revoked || now.After(latest.ExpiresAt) {
+			continue
+		}
+		sessions = append(sessions, &session{
+			ID:         familyID,
+			UserAgent:  latest.UserAgent,
+			IPAddress:  latest.IPAddress,
+			CreatedAt:  firsts[familyID].CreatedAt,
+			LastUsedAt: latest.CreatedAt,
+			ExpiresAt:  latest.ExpiresAt,
+		})
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastUsedAt.After(sessions[j].LastUsedAt)
+	})
+	return sessions, nil
+}
+
+// revokeSession revokes every refresh token of the session and denies the access
+// tokens issued for it until they would have expired anyway.
+func revokeSession(ctx context.Context, s sessionStore, userID int64, sessionID string) error {
+	refreshTokens, err := s.ListRefreshTokens(ctx, &store.FindRefreshToken{
+		UserID:   &userID,
+		FamilyID: &sessionID,
+	})
+	if err != nil {
+		return err
+	}
+	revoked := true
+	for _, refreshToken := range refreshTokens {
+		if refreshToken.Revoked {
+			continue
+		}
+		if _, err := s.UpdateRefreshToken(ctx, &store.UpdateRefreshToken{
+			ID:      refreshToken.ID,
+			Revoked: &revoked,
+		}); err != nil {
+			return err
+		}
+	}
+
+	_, err = s.CreateRevokedToken(ctx, &store.CreateRevokedToken{
+		JTI:       sessionID,
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(auth.AccessTokenDuration),
+	})
+	return err
+}
+
+// revokeAllSessions revokes every active session of the user and returns how many there were.
+func revokeAllSessions(ctx context.Context, s sessionStore, userID int64) (int, error) {
+	sessions, err := listActiveSessions(ctx, s, userID)
+	if err != nil {
+		return 0, err
+	}
+	for _, session := range sessions {
+		if err := revokeSession(ctx, s, userID, session.ID); err != nil {
+			return 0, err
+		}
+	}
+	return len(sessions), nil
+}
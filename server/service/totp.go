@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	storepb "github.com/pixb/go-server/proto/gen/store"
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/store"
+)
+
+// totpStore is the subset of the store needed to verify TOTP codes.
+type totpStore interface {
+	UpdateTOTPCredential(ctx context.Context, update *store.UpdateTOTPCredential) (*store.TOTPCredential, error)
+	instanceSecretStore
+}
+
+// instanceSecretStore gives the secret keys of the instance.
+type instanceSecretStore interface {
+	GetInstanceBasicSetting(ctx context.Context) (*storepb.InstanceBasicSetting, error)
+}
+
+// withInstanceSecrets calls fn with the secret of the server and, until it succeeds, with the
+// current and previous secret keys of the instance. They differ while a key rotation is rolled
+// out: restarted servers use the new key, the others still the previous one.
+func withInstanceSecrets(ctx context.Context, s instanceSecretStore, secret string, fn func(secret string) error) error {
+	err := fn(secret)
+	if err == nil {
+		return nil
+	}
+	basicSetting, settingErr := s.GetInstanceBasicSetting(ctx)
+	if settingErr != nil {
+		return settingErr
+	}
+	for _, key := range []string{basicSetting.SecretKey, basicSetting.PreviousSecretKey} {
+		if key == "" || key == secret {
+			continue
+		}
+		if fn(key) == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// decryptTOTPSecret decrypts a TOTP secret or recovery code list with the secret of the
+// server, or the instance secret keys during a rotation.
+func decryptTOTPSecret(ctx context.Context, s instanceSecretStore, ciphertext, secret string) (string, error) {
+	var plaintext string
+	err := withInstanceSecrets(ctx, s, secret, func(secret string) error {
+		var err error
+		plaintext, err = auth.DecryptTOTPSecret(ciphertext, secret)
+		return err
+	})
+	return plaintext, err
+}
+
+// verifyTOTPCode checks code against the credential and records its use so that it cannot
+// be replayed. With allowRecoveryCode, an unused recovery code is accepted as well and consumed.
+func verifyTOTPCode(ctx context.Context, s totpStore, credential *store.TOTPCredential, code, secret string, allowRecoveryCode bool) (bool, error) {
+	totpSecret, err := decryptTOTPSecret(ctx, s, credential.Secret, secret)
+	if err != nil {
+		return false, err
+	}
+	if step, ok := auth.ValidateTOTPCode(totpSecret, code, time.Now(), credential.LastUsedStep); ok {
+		_, err := s.UpdateTOTPCredential(ctx, &store.UpdateTOTPCredential{
+			ID:           credential.ID,
+			LastUsedStep: &step,
+		})
+		return err == nil, err
+	}
+	if !allowRecoveryCode {
+		return false, nil
+	}
+
+	recoveryCodes, err := decryptRecoveryCodes(ctx, s, credential.RecoveryCodes, secret)
+	if err != nil {
+		return false, err
+	}
+	remaining, ok := auth.UseRecoveryCode(recoveryCodes, code)
+	if !ok {
+		return false, nil
+	}
+	encrypted, err := encryptRecoveryCodes(remaining, secret)
+	if err != nil {
+		return false, err
+	}
+	_, err = s.UpdateTOTPCredential(ctx, &store.UpdateTOTPCredential{
+		ID:            credential.ID,
+		RecoveryCodes: &encrypted,
+	})
+	return err == nil, err
+}
+
+func encryptRecoveryCodes(codes []string, secret string) (string, error) {
+	return auth.EncryptTOTPSecret(strings.Join(codes, ","), secret)
+}
+
+func decryptRecoveryCodes(ctx context.Context, s instanceSecretStore, encrypted, secret string) ([]string, error) {
+	codes, err := decryptTOTPSecret(ctx, s, encrypted, secret)
+	if err != nil {
+		return nil, err
+	}
+	if codes == "" {
+		return []string{}, nil
+	}
+	return strings.Split(codes, ","), nil
+}
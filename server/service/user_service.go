@@ -3,12 +3,16 @@ package service
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"regexp"
+	"time"
 
 	"connectrpc.com/connect"
 
 	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	storepb "github.com/pixb/go-server/proto/gen/store"
 	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/server/notify"
 	"github.com/pixb/go-server/store"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -22,6 +26,24 @@ type UserStore interface {
 	GetUserByUsername(ctx context.Context, username string) (*store.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*store.User, error)
 	GetUser(ctx context.Context, find *store.FindUser) (*store.User, error)
+	GetUserIdentity(ctx context.Context, find *store.FindUserIdentity) (*store.UserIdentity, error)
+	CreatePersonalAccessToken(ctx context.Context, create *store.CreatePersonalAccessToken) (*store.PersonalAccessToken, error)
+	ListPersonalAccessTokens(ctx context.Context, find *store.FindPersonalAccessToken) ([]*store.PersonalAccessToken, error)
+	DeletePersonalAccessToken(ctx context.Context, delete *store.DeletePersonalAccessToken) error
+	ListRefreshTokens(ctx context.Context, find *store.FindRefreshToken) ([]*store.RefreshToken, error)
+	UpdateRefreshToken(ctx context.Context, update *store.UpdateRefreshToken) (*store.RefreshToken, error)
+	CreateRevokedToken(ctx context.Context, create *store.CreateRevokedToken) (*store.RevokedToken, error)
+	CreateTOTPCredential(ctx context.Context, create *store.CreateTOTPCredential) (*store.TOTPCredential, error)
+	UpdateTOTPCredential(ctx context.Context, update *store.UpdateTOTPCredential) (*store.TOTPCredential, error)
+	GetTOTPCredential(ctx context.Context, find *store.FindTOTPCredential) (*store.TOTPCredential, error)
+	DeleteTOTPCredential(ctx context.Context, delete *store.DeleteTOTPCredential) error
+	DeleteLoginAttempts(ctx context.Context, delete *store.DeleteLoginAttempt) error
+	GetInstanceSecuritySetting(ctx context.Context) (*storepb.InstanceSecuritySetting, error)
+	GetInstanceBasicSetting(ctx context.Context) (*storepb.InstanceBasicSetting, error)
+	GetInvite(ctx context.Context, find *store.FindInvite) (*store.Invite, error)
+	UseInvite(ctx context.Context, use *store.UseInvite) (*store.User, error)
+	passwordStore
+	emailVerificationStore
 	Ping(ctx context.Context) error
 	Close() error
 }
@@ -35,64 +57,152 @@ func isValidEmail(email string) bool {
 type UserService struct {
 	Secret string
 	Store  UserStore
+	// Notifier delivers email verification tokens, none are sent unless it is replaced.
+	Notifier notify.Notifier
 }
 
 func NewUserService(secret string, store UserStore) *UserService {
 	return &UserService{
-		Secret: secret,
-		Store:  store,
+		Secret:   secret,
+		Store:    store,
+		Notifier: notify.NewDisabledNotifier(),
 	}
 }
 
 func (s *UserService) RegisterUser(ctx context.Context, req *v1pb.RegisterUserRequest) (*v1pb.RegisterUserResponse, error) {
+	securitySetting, err := s.Store.GetInstanceSecuritySetting(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get security setting"))
+	}
+	if err := checkRegistrationPolicy(securitySetting.GetRegistration(), req.Email, req.InviteCode != ""); err != nil {
+		return nil, err
+	}
+	var invite *store.Invite
+	if req.InviteCode != "" {
+		if invite, err = findUsableInvite(ctx, s.Store, req.InviteCode); err != nil {
+			return nil, err
+		}
+	}
+
+	passwordPolicy, err := validateNewAccount(ctx, s.Store, req.Username, req.Nickname, req.Password, req.Phone, req.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	passwordHash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to hash password"))
+	}
+
+	create := &store.User{
+		Username: req.Username,
+		Email:    req.Email,
+		Password: passwordHash,
+		Nickname: req.Nickname,
+		Phone:    req.Phone,
+		Role:     store.RoleUser, // Default role
+		// Expiry follows the password policy
+		PasswordExpires: passwordPolicy.ExpiresAt(time.Now()),
+	}
+	var newUser *store.User
+	if invite != nil {
+		// The use is counted together with the creation of the user, so concurrent
+		// registrations cannot exceed the uses of the invite.
+		create.Role = invite.Role
+		newUser, err = s.Store.UseInvite(ctx, &store.UseInvite{ID: invite.ID, User: create})
+		if err == nil && newUser == nil {
+			return nil, errInvalidInvite
+		}
+	} else {
+		newUser, err = s.Store.CreateUser(ctx, create)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := recordPassword(ctx, s.Store, passwordPolicy, newUser.ID, passwordHash); err != nil {
+		return nil, err
+	}
+	// The address is verified later with the emailed token. Until then, sign-in is refused if
+	// the instance requires verified addresses or admits users by their email domain.
+	if err := sendEmailVerification(ctx, s.Store, s.Notifier, newUser, newUser.Email); err != nil {
+		slog.Error("failed to send verification email", slog.Int64("userID", newUser.ID), slog.Any("error", err))
+	}
+
+	return &v1pb.RegisterUserResponse{
+		User: &v1pb.User{
+			Id:                newUser.ID,
+			Username:          newUser.Username,
+			Email:             newUser.Email,
+			Nickname:          newUser.Nickname,
+			Phone:             newUser.Phone,
+			Role:              auth.StringToRole(newUser.Role),
+			PasswordExpiresAt: timestamppb.New(newUser.PasswordExpires),
+			CreatedAt:         timestamppb.New(newUser.CreatedAt),
+			UpdatedAt:         timestamppb.New(newUser.UpdatedAt),
+			EmailVerified:     newUser.EmailVerified,
+		},
+	}, nil
+}
+
+// accountStore is the part of the store new accounts are checked against.
+type accountStore interface {
+	passwordStore
+	GetUserByUsername(ctx context.Context, username string) (*store.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*store.User, error)
+}
+
+// validateNewAccount checks the fields of an account created by a registration or the
+// instance setup and returns the password policy the password satisfies.
+func validateNewAccount(ctx context.Context, s accountStore, username, nickname, password, phone, email string) (*auth.PasswordPolicy, error) {
 	// Validate username
-	if len(req.Username) < 3 || len(req.Username) > 50 {
+	if len(username) < 3 || len(username) > 50 {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("username must be between 3 and 50 characters"))
 	}
 
 	// Validate nickname
-	if req.Nickname == "" {
+	if nickname == "" {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("nickname is required"))
 	}
-	if len(req.Nickname) > 50 {
+	if len(nickname) > 50 {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("nickname must be at most 50 characters"))
 	}
 
 	// Validate password
-	if req.Password == "" {
+	if password == "" {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("password is required"))
 	}
-	if len(req.Password) < 6 {
-		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("password must be at least 6 characters"))
+	passwordPolicy, err := validateNewPassword(ctx, s, nil, password)
+	if err != nil {
+		return nil, err
 	}
 
 	// Validate phone
-	if req.Phone == "" {
+	if phone == "" {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("phone is required"))
 	}
-	if len(req.Phone) != 11 {
+	if len(phone) != 11 {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("phone must be 11 digits"))
 	}
-	for _, r := range req.Phone {
+	for _, r := range phone {
 		if r < '0' || r > '9' {
 			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("phone must be only digits"))
 		}
 	}
 
 	// Validate email
-	if req.Email == "" {
+	if email == "" {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("email is required"))
 	}
-	if len(req.Email) > 100 {
+	if len(email) > 100 {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("email must be at most 100 characters"))
 	}
 	// Simple email format validation
-	if !isValidEmail(req.Email) {
+	if !isValidEmail(email) {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("email must be a valid email address"))
 	}
 
 	// Check if username already exists
-	existingUser, err := s.Store.GetUserByUsername(ctx, req.Username)
+	existingUser, err := s.GetUserByUsername(ctx, username)
 	if err != nil {
 		return nil, err
 	}
@@ -101,44 +211,14 @@ func (s *UserService) RegisterUser(ctx context.Context, req *v1pb.RegisterUserRe
 	}
 
 	// Check if email already exists
-	existingUserByEmail, err := s.Store.GetUserByEmail(ctx, req.Email)
+	existingUserByEmail, err := s.GetUserByEmail(ctx, email)
 	if err != nil {
 		return nil, err
 	}
 	if existingUserByEmail != nil {
 		return nil, connect.NewError(connect.CodeAlreadyExists, errors.New("email already exists"))
 	}
-
-	passwordHash, err := auth.HashPassword(req.Password)
-	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to hash password"))
-	}
-
-	newUser, err := s.Store.CreateUser(ctx, &store.User{
-		Username: req.Username,
-		Email:    req.Email,
-		Password: passwordHash,
-		Nickname: req.Nickname,
-		Phone:    req.Phone,
-		Role:     store.RoleUser, // Default role
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return &v1pb.RegisterUserResponse{
-		User: &v1pb.User{
-			Id:                newUser.ID,
-			Username:          newUser.Username,
-			Email:             newUser.Email,
-			Nickname:          newUser.Nickname,
-			Phone:             newUser.Phone,
-			Role:              auth.StringToRole(newUser.Role),
-			PasswordExpiresAt: timestamppb.New(newUser.PasswordExpires),
-			CreatedAt:         timestamppb.New(newUser.CreatedAt),
-			UpdatedAt:         timestamppb.New(newUser.UpdatedAt),
-		},
-	}, nil
+	return passwordPolicy, nil
 }
 
 func (s *UserService) GetUserProfile(ctx context.Context, req *v1pb.GetUserProfileRequest) (*v1pb.GetUserProfileResponse, error) {
@@ -166,6 +246,7 @@ func (s *UserService) GetUserProfile(ctx context.Context, req *v1pb.GetUserProfi
 			PasswordExpiresAt: timestamppb.New(user.PasswordExpires),
 			CreatedAt:         timestamppb.New(user.CreatedAt),
 			UpdatedAt:         timestamppb.New(user.UpdatedAt),
+			EmailVerified:     user.EmailVerified,
 		},
 	}, nil
 }
@@ -214,8 +295,19 @@ func (s *UserService) UpdateUserProfile(ctx context.Context, req *v1pb.UpdateUse
 		update.Phone = &req.Phone
 	}
 
+	// A new email address only replaces the current one once it is verified
+	var pendingEmail string
 	if req.Email != "" {
-		update.Email = &req.Email
+		existingUser, err := s.Store.GetUserByEmail(ctx, req.Email)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user"))
+		}
+		if existingUser != nil && existingUser.ID != userID {
+			return nil, connect.NewError(connect.CodeAlreadyExists, errors.New("email already exists"))
+		}
+		if existingUser == nil {
+			pendingEmail = req.Email
+		}
 	}
 
 	// Update user
@@ -224,6 +316,12 @@ func (s *UserService) UpdateUserProfile(ctx context.Context, req *v1pb.UpdateUse
 		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to update user profile"))
 	}
 
+	if pendingEmail != "" {
+		if err := sendEmailVerification(ctx, s.Store, s.Notifier, updatedUser, pendingEmail); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to send verification email"))
+		}
+	}
+
 	return &v1pb.UpdateUserProfileResponse{
 		User: &v1pb.User{
 			Id:                updatedUser.ID,
@@ -235,7 +333,9 @@ func (s *UserService) UpdateUserProfile(ctx context.Context, req *v1pb.UpdateUse
 			PasswordExpiresAt: timestamppb.New(updatedUser.PasswordExpires),
 			CreatedAt:         timestamppb.New(updatedUser.CreatedAt),
 			UpdatedAt:         timestamppb.New(updatedUser.UpdatedAt),
+			EmailVerified:     updatedUser.EmailVerified,
 		},
+		PendingEmail: pendingEmail,
 	}, nil
 }
 
@@ -254,10 +354,6 @@ func (s *UserService) ChangePassword(ctx context.Context, req *v1pb.ChangePasswo
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("new password is required"))
 	}
 
-	if len(req.NewPassword) < 6 {
-		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("new password must be at least 6 characters"))
-	}
-
 	// Get user
 	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
 	if err != nil {
@@ -267,11 +363,24 @@ func (s *UserService) ChangePassword(ctx context.Context, req *v1pb.ChangePasswo
 		return nil, connect.NewError(connect.CodeNotFound, errors.New("user not found"))
 	}
 
+	directoryUser, err := isDirectoryUser(ctx, s.Store, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if directoryUser {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("the password of directory users is changed in the directory"))
+	}
+
 	// Verify old password
 	if !auth.CheckPassword(req.OldPassword, user.Password) {
 		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("old password is incorrect"))
 	}
 
+	passwordPolicy, err := validateNewPassword(ctx, s.Store, user, req.NewPassword)
+	if err != nil {
+		return nil, err
+	}
+
 	// Hash new password
 	newPasswordHash, err := auth.HashPassword(req.NewPassword)
 	if err != nil {
@@ -279,15 +388,20 @@ func (s *UserService) ChangePassword(ctx context.Context, req *v1pb.ChangePasswo
 	}
 
 	// Update password
+	passwordExpires := passwordPolicy.ExpiresAt(time.Now())
 	update := &store.UpdateUser{
-		ID:       userID,
-		Password: &newPasswordHash,
+		ID:              userID,
+		Password:        &newPasswordHash,
+		PasswordExpires: &passwordExpires,
 	}
 
 	updatedUser, err := s.Store.UpdateUser(ctx, update)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to update password"))
 	}
+	if err := recordPassword(ctx, s.Store, passwordPolicy, userID, newPasswordHash); err != nil {
+		return nil, err
+	}
 
 	return &v1pb.ChangePasswordResponse{
 		User: &v1pb.User{
@@ -300,6 +414,357 @@ func (s *UserService) ChangePassword(ctx context.Context, req *v1pb.ChangePasswo
 			PasswordExpiresAt: timestamppb.New(updatedUser.PasswordExpires),
 			CreatedAt:         timestamppb.New(updatedUser.CreatedAt),
 			UpdatedAt:         timestamppb.New(updatedUser.UpdatedAt),
+			EmailVerified:     updatedUser.EmailVerified,
 		},
 	}, nil
 }
+
+func (s *UserService) CreatePersonalAccessToken(ctx context.Context, req *v1pb.CreatePersonalAccessTokenRequest) (*v1pb.CreatePersonalAccessTokenResponse, error) {
+	userID := auth.GetUserID(ctx)
+	if userID == 0 {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+
+	// Validate parameters
+	if req.Description == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("description is required"))
+	}
+	if len(req.Description) > 255 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("description must be at most 255 characters"))
+	}
+
+	var expiresAt *time.Time
+	if req.ExpiresAt != nil {
+		t := req.ExpiresAt.AsTime()
+		if !t.After(time.Now()) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("expires_at must be in the future"))
+		}
+		expiresAt = &t
+	}
+
+	token, err := auth.GeneratePersonalAccessToken()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to generate personal access token"))
+	}
+
+	pat, err := s.Store.CreatePersonalAccessToken(ctx, &store.CreatePersonalAccessToken{
+		UserID:      userID,
+		TokenHash:   auth.HashToken(token),
+		Description: req.Description,
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to create personal access token"))
+	}
+
+	return &v1pb.CreatePersonalAccessTokenResponse{
+		PersonalAccessToken: convertPersonalAccessTokenFromStore(pat),
+		Token:               token,
+	}, nil
+}
+
+func (s *UserService) ListPersonalAccessTokens(ctx context.Context, req *v1pb.ListPersonalAccessTokensRequest) (*v1pb.ListPersonalAccessTokensResponse, error) {
+	userID := auth.GetUserID(ctx)
+	if userID == 0 {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+
+	pats, err := s.Store.ListPersonalAccessTokens(ctx, &store.FindPersonalAccessToken{UserID: &userID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to list personal access tokens"))
+	}
+
+	response := &v1pb.ListPersonalAccessTokensResponse{}
+	for _, pat := range pats {
+		response.PersonalAccessTokens = append(response.PersonalAccessTokens, convertPersonalAccessTokenFromStore(pat))
+	}
+	return response, nil
+}
+
+func (s *UserService) DeletePersonalAccessToken(ctx context.Context, req *v1pb.DeletePersonalAccessTokenRequest) (*v1pb.DeletePersonalAccessTokenResponse, error) {
+	userID := auth.GetUserID(ctx)
+	if userID == 0 {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+
+	// Only the owner may delete a token
+	pats, err := s.Store.ListPersonalAccessTokens(ctx, &store.FindPersonalAccessToken{ID: &req.Id, UserID: &userID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get personal access token"))
+	}
+	if len(pats) == 0 {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("personal access token not found"))
+	}
+
+	if err := s.Store.DeletePersonalAccessToken(ctx, &store.DeletePersonalAccessToken{ID: req.Id}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to delete personal access token"))
+	}
+
+	return &v1pb.DeletePersonalAccessTokenResponse{}, nil
+}
+
+func (s *UserService) ListSessions(ctx context.Context, req *v1pb.ListSessionsRequest) (*v1pb.ListSessionsResponse, error) {
+	userID := auth.GetUserID(ctx)
+	if userID == 0 {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+
+	sessions, err := listActiveSessions(ctx, s.Store, userID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to list sessions"))
+	}
+
+	currentSessionID := getCurrentSessionID(ctx)
+	response := &v1pb.ListSessionsResponse{}
+	for _, session := range sessions {
+		response.Sessions = append(response.Sessions, &v1pb.Session{
+			Id:         session.ID,
+			UserAgent:  session.UserAgent,
+			IpAddress:  session.IPAddress,
+			CreatedAt:  timestamppb.New(session.CreatedAt),
+			LastUsedAt: timestamppb.New(session.LastUsedAt),
+			ExpiresAt:  timestamppb.New(session.ExpiresAt),
+			Current:    session.ID == currentSessionID,
+		})
+	}
+	return response, nil
+}
+
+func (s *UserService) RevokeSession(ctx context.Context, req *v1pb.RevokeSessionRequest) (*v1pb.RevokeSessionResponse, error) {
+	userID := auth.GetUserID(ctx)
+	if userID == 0 {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+
+	if req.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("session id is required"))
+	}
+
+	// Only the owner may revoke a session
+	refreshTokens, err := s.Store.ListRefreshTokens(ctx, &store.FindRefreshToken{UserID: &userID, FamilyID: &req.Id})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get session"))
+	}
+	if len(refreshTokens) == 0 {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("session not found"))
+	}
+
+	if err := revokeSession(ctx, s.Store, userID, req.Id); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to revoke session"))
+	}
+
+	return &v1pb.RevokeSessionResponse{}, nil
+}
+
+func (s *UserService) RevokeAllOtherSessions(ctx context.Context, req *v1pb.RevokeAllOtherSessionsRequest) (*v1pb.RevokeAllOtherSessionsResponse, error) {
+	userID := auth.GetUserID(ctx)
+	if userID == 0 {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+
+	sessions, err := listActiveSessions(ctx, s.Store, userID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to list sessions"))
+	}
+
+	// Callers without a session (e.g. personal access tokens) revoke every session
+	currentSessionID := getCurrentSessionID(ctx)
+	var revokedCount int32
+	for _, session := range sessions {
+		if session.ID == currentSessionID {
+			continue
+		}
+		if err := revokeSession(ctx, s.Store, userID, session.ID); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to revoke session"))
+		}
+		revokedCount++
+	}
+
+	return &v1pb.RevokeAllOtherSessionsResponse{
+		RevokedCount: revokedCount,
+	}, nil
+}
+
+// getCurrentSessionID returns the session of the access token used for the request, if any.
+func (s *UserService) EnrollTOTP(ctx context.Context, req *v1pb.EnrollTOTPRequest) (*v1pb.EnrollTOTPResponse, error) {
+	userID := auth.GetUserID(ctx)
+	if userID == 0 {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+
+	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user"))
+	}
+	if user == nil {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("user not found"))
+	}
+
+	// An unconfirmed enrollment is replaced, an enabled one has to be disabled first
+	credential, err := s.Store.GetTOTPCredential(ctx, &store.FindTOTPCredential{UserID: &userID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get totp credential"))
+	}
+	if credential != nil {
+		if credential.Enabled {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("two-factor authentication is already enabled"))
+		}
+		if err := s.Store.DeleteTOTPCredential(ctx, &store.DeleteTOTPCredential{UserID: userID}); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to delete totp credential"))
+		}
+	}
+
+	totpSecret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to generate totp secret"))
+	}
+	recoveryCodes, err := auth.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to generate recovery codes"))
+	}
+	encryptedSecret, err := auth.EncryptTOTPSecret(totpSecret, s.Secret)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to encrypt totp secret"))
+	}
+	encryptedRecoveryCodes, err := encryptRecoveryCodes(recoveryCodes, s.Secret)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to encrypt recovery codes"))
+	}
+
+	if _, err := s.Store.CreateTOTPCredential(ctx, &store.CreateTOTPCredential{
+		UserID:        userID,
+		Secret:        encryptedSecret,
+		RecoveryCodes: encryptedRecoveryCodes,
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to create totp credential"))
+	}
+
+	return &v1pb.EnrollTOTPResponse{
+		Secret:        totpSecret,
+		OtpauthUri:    auth.TOTPURI(totpSecret, user.Username),
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+func (s *UserService) ConfirmTOTP(ctx context.Context, req *v1pb.ConfirmTOTPRequest) (*v1pb.ConfirmTOTPResponse, error) {
+	userID := auth.GetUserID(ctx)
+	if userID == 0 {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+
+	if req.Code == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("code is required"))
+	}
+
+	credential, err := s.Store.GetTOTPCredential(ctx, &store.FindTOTPCredential{UserID: &userID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get totp credential"))
+	}
+	if credential == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("two-factor authentication enrollment not found"))
+	}
+	if credential.Enabled {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("two-factor authentication is already enabled"))
+	}
+
+	// Only a code from the authenticator proves the secret was imported
+	ok, err := verifyTOTPCode(ctx, s.Store, credential, req.Code, s.Secret, false)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to verify code"))
+	}
+	if !ok {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid verification code"))
+	}
+
+	enabled := true
+	if _, err := s.Store.UpdateTOTPCredential(ctx, &store.UpdateTOTPCredential{
+		ID:      credential.ID,
+		Enabled: &enabled,
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to enable two-factor authentication"))
+	}
+
+	return &v1pb.ConfirmTOTPResponse{}, nil
+}
+
+func (s *UserService) DisableTOTP(ctx context.Context, req *v1pb.DisableTOTPRequest) (*v1pb.DisableTOTPResponse, error) {
+	userID := auth.GetUserID(ctx)
+	if userID == 0 {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+
+	if req.Password == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("password is required"))
+	}
+
+	user, err := s.Store.GetUser(ctx, &store.FindUser{ID: &userID})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user"))
+	}
+	if user == nil {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("user not found"))
+	}
+	if !auth.CheckPassword(req.Password, user.Password) {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("password is incorrect"))
+	}
+
+	enabled := true
+	credential, err := s.Store.GetTOTPCredential(ctx, &store.FindTOTPCredential{UserID: &userID, Enabled: &enabled})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get totp credential"))
+	}
+	if credential == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("two-factor authentication is not enabled"))
+	}
+
+	if err := s.Store.DeleteTOTPCredential(ctx, &store.DeleteTOTPCredential{UserID: userID}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to disable two-factor authentication"))
+	}
+
+	return &v1pb.DisableTOTPResponse{}, nil
+}
+
+func (s *UserService) UnlockUser(ctx context.Context, req *v1pb.UnlockUserRequest) (*v1pb.UnlockUserResponse, error) {
+	if err := auth.Require(ctx, store.PermissionUsersWrite); err != nil {
+		return nil, err
+	}
+
+	users, err := s.Store.ListUsers(ctx, &store.FindUser{ID: &req.Id})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user"))
+	}
+	if len(users) == 0 {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("user not found"))
+	}
+
+	if err := s.Store.DeleteLoginAttempts(ctx, &store.DeleteLoginAttempt{
+		Scope:      store.LoginAttemptScopeAccount,
+		Identifier: users[0].Username,
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to unlock user"))
+	}
+
+	return &v1pb.UnlockUserResponse{}, nil
+}
+
+func getCurrentSessionID(ctx context.Context) string {
+	if claims := auth.GetUserClaims(ctx); claims != nil {
+		return claims.SessionID
+	}
+	return ""
+}
+
+func convertPersonalAccessTokenFromStore(pat *store.PersonalAccessToken) *v1pb.PersonalAccessToken {
+	message := &v1pb.PersonalAccessToken{
+		Id:          pat.ID,
+		Description: pat.Description,
+		CreatedAt:   timestamppb.New(pat.CreatedAt),
+	}
+	if pat.ExpiresAt != nil {
+		message.ExpiresAt = timestamppb.New(*pat.ExpiresAt)
+	}
+	if pat.LastUsedAt != nil {
+		message.LastUsedAt = timestamppb.New(*pat.LastUsedAt)
+	}
+	return message
+}
@@ -2,13 +2,21 @@ package service
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
+	"connectrpc.com/connect"
 	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	storepb "github.com/pixb/go-server/proto/gen/store"
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/server/notify"
 	"github.com/pixb/go-server/store"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // MockStore is a mock implementation of store.Store
@@ -36,6 +44,11 @@ func (m *MockStore) DeleteUser(ctx context.Context, delete *store.DeleteUser) er
 	return args.Error(0)
 }
 
+func (m *MockStore) RestoreUser(ctx context.Context, restore *store.RestoreUser) error {
+	args := m.Called(ctx, restore)
+	return args.Error(0)
+}
+
 func (m *MockStore) GetUserByUsername(ctx context.Context, username string) (*store.User, error) {
 	args := m.Called(ctx, username)
 	if args.Get(0) == nil {
@@ -62,6 +75,11 @@ func (m *MockStore) UpdateRefreshToken(ctx context.Context, update *store.Update
 	return args.Get(0).(*store.RefreshToken), args.Error(1)
 }
 
+func (m *MockStore) RevokeRefreshToken(ctx context.Context, id int64) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockStore) ListRefreshTokens(ctx context.Context, find *store.FindRefreshToken) ([]*store.RefreshToken, error) {
 	args := m.Called(ctx, find)
 	return args.Get(0).([]*store.RefreshToken), args.Error(1)
@@ -80,6 +98,27 @@ func (m *MockStore) GetRefreshToken(ctx context.Context, token string) (*store.R
 	return args.Get(0).(*store.RefreshToken), args.Error(1)
 }
 
+func (m *MockStore) CreateSecurityEvent(ctx context.Context, create *store.CreateSecurityEvent) (*store.SecurityEvent, error) {
+	args := m.Called(ctx, create)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.SecurityEvent), args.Error(1)
+}
+
+func (m *MockStore) CreateRevokedToken(ctx context.Context, create *store.CreateRevokedToken) (*store.RevokedToken, error) {
+	args := m.Called(ctx, create)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.RevokedToken), args.Error(1)
+}
+
+func (m *MockStore) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	args := m.Called(ctx, jti)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockStore) Ping(ctx context.Context) error {
 	args := m.Called(ctx)
 	return args.Error(0)
@@ -93,6 +132,436 @@ func (m *MockStore) GetUser(ctx context.Context, find *store.FindUser) (*store.U
 	return args.Get(0).(*store.User), args.Error(1)
 }
 
+func (m *MockStore) CreatePersonalAccessToken(ctx context.Context, create *store.CreatePersonalAccessToken) (*store.PersonalAccessToken, error) {
+	args := m.Called(ctx, create)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.PersonalAccessToken), args.Error(1)
+}
+
+func (m *MockStore) ListPersonalAccessTokens(ctx context.Context, find *store.FindPersonalAccessToken) ([]*store.PersonalAccessToken, error) {
+	args := m.Called(ctx, find)
+	return args.Get(0).([]*store.PersonalAccessToken), args.Error(1)
+}
+
+func (m *MockStore) GetPersonalAccessToken(ctx context.Context, find *store.FindPersonalAccessToken) (*store.PersonalAccessToken, error) {
+	args := m.Called(ctx, find)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.PersonalAccessToken), args.Error(1)
+}
+
+func (m *MockStore) DeletePersonalAccessToken(ctx context.Context, delete *store.DeletePersonalAccessToken) error {
+	args := m.Called(ctx, delete)
+	return args.Error(0)
+}
+
+func (m *MockStore) CreateTOTPCredential(ctx context.Context, create *store.CreateTOTPCredential) (*store.TOTPCredential, error) {
+	args := m.Called(ctx, create)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.TOTPCredential), args.Error(1)
+}
+
+func (m *MockStore) UpdateTOTPCredential(ctx context.Context, update *store.UpdateTOTPCredential) (*store.TOTPCredential, error) {
+	args := m.Called(ctx, update)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.TOTPCredential), args.Error(1)
+}
+
+func (m *MockStore) GetTOTPCredential(ctx context.Context, find *store.FindTOTPCredential) (*store.TOTPCredential, error) {
+	args := m.Called(ctx, find)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.TOTPCredential), args.Error(1)
+}
+
+func (m *MockStore) DeleteTOTPCredential(ctx context.Context, delete *store.DeleteTOTPCredential) error {
+	args := m.Called(ctx, delete)
+	return args.Error(0)
+}
+
+func (m *MockStore) GetInstanceBasicSetting(ctx context.Context) (*storepb.InstanceBasicSetting, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*storepb.InstanceBasicSetting), args.Error(1)
+}
+
+func (m *MockStore) GetInstanceSecuritySetting(ctx context.Context) (*storepb.InstanceSecuritySetting, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*storepb.InstanceSecuritySetting), args.Error(1)
+}
+
+func (m *MockStore) RecordLoginFailure(ctx context.Context, record *store.RecordLoginFailure) (*store.LoginAttempt, error) {
+	args := m.Called(ctx, record)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.LoginAttempt), args.Error(1)
+}
+
+func (m *MockStore) UpdateLoginAttempt(ctx context.Context, update *store.UpdateLoginAttempt) (*store.LoginAttempt, error) {
+	args := m.Called(ctx, update)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.LoginAttempt), args.Error(1)
+}
+
+func (m *MockStore) GetLoginAttempt(ctx context.Context, find *store.FindLoginAttempt) (*store.LoginAttempt, error) {
+	args := m.Called(ctx, find)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.LoginAttempt), args.Error(1)
+}
+
+func (m *MockStore) DeleteLoginAttempts(ctx context.Context, delete *store.DeleteLoginAttempt) error {
+	args := m.Called(ctx, delete)
+	return args.Error(0)
+}
+
+func (m *MockStore) GetInstancePasswordPolicySetting(ctx context.Context) (*storepb.InstancePasswordPolicySetting, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*storepb.InstancePasswordPolicySetting), args.Error(1)
+}
+
+func (m *MockStore) CreatePasswordHistory(ctx context.Context, create *store.CreatePasswordHistory) (*store.PasswordHistory, error) {
+	args := m.Called(ctx, create)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.PasswordHistory), args.Error(1)
+}
+
+func (m *MockStore) ListPasswordHistories(ctx context.Context, find *store.FindPasswordHistory) ([]*store.PasswordHistory, error) {
+	args := m.Called(ctx, find)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*store.PasswordHistory), args.Error(1)
+}
+
+func (m *MockStore) DeletePasswordHistories(ctx context.Context, delete *store.DeletePasswordHistory) error {
+	args := m.Called(ctx, delete)
+	return args.Error(0)
+}
+
+func (m *MockStore) CreatePasswordResetToken(ctx context.Context, create *store.CreatePasswordResetToken) (*store.PasswordResetToken, error) {
+	args := m.Called(ctx, create)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.PasswordResetToken), args.Error(1)
+}
+
+func (m *MockStore) GetPasswordResetToken(ctx context.Context, find *store.FindPasswordResetToken) (*store.PasswordResetToken, error) {
+	args := m.Called(ctx, find)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.PasswordResetToken), args.Error(1)
+}
+
+func (m *MockStore) UsePasswordResetToken(ctx context.Context, id int64) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStore) DeletePasswordResetTokens(ctx context.Context, delete *store.DeletePasswordResetToken) error {
+	args := m.Called(ctx, delete)
+	return args.Error(0)
+}
+
+func (m *MockStore) CreateEmailVerification(ctx context.Context, create *store.CreateEmailVerification) (*store.EmailVerification, error) {
+	args := m.Called(ctx, create)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.EmailVerification), args.Error(1)
+}
+
+func (m *MockStore) GetEmailVerification(ctx context.Context, find *store.FindEmailVerification) (*store.EmailVerification, error) {
+	args := m.Called(ctx, find)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.EmailVerification), args.Error(1)
+}
+
+func (m *MockStore) DeleteEmailVerifications(ctx context.Context, delete *store.DeleteEmailVerification) error {
+	args := m.Called(ctx, delete)
+	return args.Error(0)
+}
+
+func (m *MockStore) GetInstanceJWTSigningKeySetting(ctx context.Context) (*storepb.InstanceJWTSigningKeySetting, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*storepb.InstanceJWTSigningKeySetting), args.Error(1)
+}
+
+func (m *MockStore) ListInstanceSettings(ctx context.Context, find *store.FindInstanceSetting) ([]*storepb.InstanceSetting, error) {
+	args := m.Called(ctx, find)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*storepb.InstanceSetting), args.Error(1)
+}
+
+func (m *MockStore) UpsertInstanceSetting(ctx context.Context, upsert *storepb.InstanceSetting) (*storepb.InstanceSetting, error) {
+	args := m.Called(ctx, upsert)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*storepb.InstanceSetting), args.Error(1)
+}
+
+func (m *MockStore) UpdateInstanceSetting(ctx context.Context, key storepb.InstanceSettingKey, update func(*storepb.InstanceSetting) (*storepb.InstanceSetting, error)) (*storepb.InstanceSetting, error) {
+	args := m.Called(ctx, key, update)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*storepb.InstanceSetting), args.Error(1)
+}
+
+func (m *MockStore) CreateRoleDefinition(ctx context.Context, create *store.CreateRoleDefinition) (*store.RoleDefinition, error) {
+	args := m.Called(ctx, create)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.RoleDefinition), args.Error(1)
+}
+
+func (m *MockStore) UpdateRoleDefinition(ctx context.Context, update *store.UpdateRoleDefinition) (*store.RoleDefinition, error) {
+	args := m.Called(ctx, update)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.RoleDefinition), args.Error(1)
+}
+
+func (m *MockStore) ListRoleDefinitions(ctx context.Context, find *store.FindRoleDefinition) ([]*store.RoleDefinition, error) {
+	args := m.Called(ctx, find)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*store.RoleDefinition), args.Error(1)
+}
+
+func (m *MockStore) DeleteRoleDefinition(ctx context.Context, delete *store.DeleteRoleDefinition) error {
+	args := m.Called(ctx, delete)
+	return args.Error(0)
+}
+
+func (m *MockStore) SetUserRoles(ctx context.Context, set *store.SetUserRoles) error {
+	args := m.Called(ctx, set)
+	return args.Error(0)
+}
+
+func (m *MockStore) ListUserRoleDefinitions(ctx context.Context, user *store.User) ([]*store.RoleDefinition, error) {
+	args := m.Called(ctx, user)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*store.RoleDefinition), args.Error(1)
+}
+
+func (m *MockStore) GetInstanceIdentityProviderSetting(ctx context.Context) (*storepb.InstanceIdentityProviderSetting, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*storepb.InstanceIdentityProviderSetting), args.Error(1)
+}
+
+func (m *MockStore) GetUserIdentity(ctx context.Context, find *store.FindUserIdentity) (*store.UserIdentity, error) {
+	args := m.Called(ctx, find)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.UserIdentity), args.Error(1)
+}
+
+func (m *MockStore) CreateUserIdentity(ctx context.Context, create *store.CreateUserIdentity) (*store.UserIdentity, error) {
+	args := m.Called(ctx, create)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.UserIdentity), args.Error(1)
+}
+
+func (m *MockStore) CreateOAuthClient(ctx context.Context, create *store.CreateOAuthClient) (*store.OAuthClient, error) {
+	args := m.Called(ctx, create)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.OAuthClient), args.Error(1)
+}
+
+func (m *MockStore) UpdateOAuthClient(ctx context.Context, update *store.UpdateOAuthClient) (*store.OAuthClient, error) {
+	args := m.Called(ctx, update)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.OAuthClient), args.Error(1)
+}
+
+func (m *MockStore) ListOAuthClients(ctx context.Context, find *store.FindOAuthClient) ([]*store.OAuthClient, error) {
+	args := m.Called(ctx, find)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*store.OAuthClient), args.Error(1)
+}
+
+func (m *MockStore) GetOAuthClient(ctx context.Context, find *store.FindOAuthClient) (*store.OAuthClient, error) {
+	args := m.Called(ctx, find)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.OAuthClient), args.Error(1)
+}
+
+func (m *MockStore) DeleteOAuthClient(ctx context.Context, delete *store.DeleteOAuthClient) error {
+	args := m.Called(ctx, delete)
+	return args.Error(0)
+}
+
+func (m *MockStore) CreateOAuthAuthorizationCode(ctx context.Context, create *store.CreateOAuthAuthorizationCode) (*store.OAuthAuthorizationCode, error) {
+	args := m.Called(ctx, create)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.OAuthAuthorizationCode), args.Error(1)
+}
+
+func (m *MockStore) GetOAuthAuthorizationCode(ctx context.Context, find *store.FindOAuthAuthorizationCode) (*store.OAuthAuthorizationCode, error) {
+	args := m.Called(ctx, find)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.OAuthAuthorizationCode), args.Error(1)
+}
+
+func (m *MockStore) UseOAuthAuthorizationCode(ctx context.Context, id int64) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStore) UpsertOAuthConsent(ctx context.Context, upsert *store.UpsertOAuthConsent) (*store.OAuthConsent, error) {
+	args := m.Called(ctx, upsert)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.OAuthConsent), args.Error(1)
+}
+
+func (m *MockStore) ListOAuthConsents(ctx context.Context, find *store.FindOAuthConsent) ([]*store.OAuthConsent, error) {
+	args := m.Called(ctx, find)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*store.OAuthConsent), args.Error(1)
+}
+
+func (m *MockStore) GetOAuthConsent(ctx context.Context, find *store.FindOAuthConsent) (*store.OAuthConsent, error) {
+	args := m.Called(ctx, find)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.OAuthConsent), args.Error(1)
+}
+
+func (m *MockStore) DeleteOAuthConsent(ctx context.Context, delete *store.DeleteOAuthConsent) error {
+	args := m.Called(ctx, delete)
+	return args.Error(0)
+}
+
+func (m *MockStore) GetInstanceGeneralSetting(ctx context.Context) (*storepb.InstanceGeneralSetting, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*storepb.InstanceGeneralSetting), args.Error(1)
+}
+
+func (m *MockStore) GetInstanceStorageSetting(ctx context.Context) (*storepb.InstanceStorageSetting, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*storepb.InstanceStorageSetting), args.Error(1)
+}
+
+func (m *MockStore) GetInstanceSetupSetting(ctx context.Context) (*storepb.InstanceSetupSetting, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*storepb.InstanceSetupSetting), args.Error(1)
+}
+
+func (m *MockStore) SetupInstance(ctx context.Context, admin *store.User, settings ...*storepb.InstanceSetting) (*store.User, error) {
+	args := m.Called(ctx, admin, settings)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.User), args.Error(1)
+}
+
+func (m *MockStore) CreateInvite(ctx context.Context, create *store.CreateInvite) (*store.Invite, error) {
+	args := m.Called(ctx, create)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.Invite), args.Error(1)
+}
+
+func (m *MockStore) ListInvites(ctx context.Context, find *store.FindInvite) ([]*store.Invite, error) {
+	args := m.Called(ctx, find)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*store.Invite), args.Error(1)
+}
+
+func (m *MockStore) GetInvite(ctx context.Context, find *store.FindInvite) (*store.Invite, error) {
+	args := m.Called(ctx, find)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.Invite), args.Error(1)
+}
+
+func (m *MockStore) UseInvite(ctx context.Context, use *store.UseInvite) (*store.User, error) {
+	args := m.Called(ctx, use)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.User), args.Error(1)
+}
+
+func (m *MockStore) DeleteInvite(ctx context.Context, delete *store.DeleteInvite) error {
+	args := m.Called(ctx, delete)
+	return args.Error(0)
+}
+
 func (m *MockStore) Close() error {
 	args := m.Called()
 	return args.Error(0)
@@ -112,6 +581,7 @@ func TestUserService_RegisterUser(t *testing.T) {
 	}
 
 	// Mock responses
+	mockStore.On("GetInstanceSecuritySetting", mock.Anything).Return(&storepb.InstanceSecuritySetting{}, nil)
 	mockStore.On("GetUserByUsername", mock.Anything, req.Username).Return(nil, nil)
 	mockStore.On("GetUserByEmail", mock.Anything, req.Email).Return(nil, nil)
 	mockStore.On("CreateUser", mock.Anything, mock.AnythingOfType("*store.User")).Return(&store.User{
@@ -126,9 +596,19 @@ func TestUserService_RegisterUser(t *testing.T) {
 		CreatedAt:       time.Now(),
 		UpdatedAt:       time.Now(),
 	}, nil)
+	mockStore.On("GetInstancePasswordPolicySetting", mock.Anything).Return(&storepb.InstancePasswordPolicySetting{}, nil)
+	mockStore.On("CreatePasswordHistory", mock.Anything, mock.AnythingOfType("*store.CreatePasswordHistory")).Return(&store.PasswordHistory{ID: 1, UserID: 1}, nil)
+	mockStore.On("ListPasswordHistories", mock.Anything, &store.FindPasswordHistory{UserID: 1, Limit: 1}).Return([]*store.PasswordHistory{{ID: 1, UserID: 1}}, nil)
+	mockStore.On("DeletePasswordHistories", mock.Anything, &store.DeletePasswordHistory{UserID: 1, BeforeID: 1}).Return(nil)
+	mockStore.On("DeleteEmailVerifications", mock.Anything, &store.DeleteEmailVerification{UserID: 1}).Return(nil)
+	mockStore.On("CreateEmailVerification", mock.Anything, mock.MatchedBy(func(create *store.CreateEmailVerification) bool {
+		return create.UserID == 1 && create.Email == req.Email
+	})).Return(&store.EmailVerification{ID: 1, UserID: 1, Email: req.Email}, nil)
 
 	// Create user service
 	userService := NewUserService("testsecret", mockStore)
+	notifier := notify.NewMemoryNotifier()
+	userService.Notifier = notifier
 
 	// Test RegisterUser
 	resp, err := userService.RegisterUser(context.Background(), req)
@@ -144,7 +624,338 @@ func TestUserService_RegisterUser(t *testing.T) {
 	assert.NotNil(t, resp.User.PasswordExpiresAt)
 	assert.NotNil(t, resp.User.CreatedAt)
 	assert.NotNil(t, resp.User.UpdatedAt)
+	assert.False(t, resp.User.EmailVerified)
+
+	// A verification token is sent to the new address
+	messages := notifier.Messages()
+	if assert.Len(t, messages, 1) {
+		assert.Equal(t, req.Email, messages[0].To)
+	}
 
 	// Verify mock calls
 	mockStore.AssertExpectations(t)
 }
+
+func TestUserService_PasswordPolicy(t *testing.T) {
+	mockStore := new(MockStore)
+	mockStore.On("GetUserIdentity", mock.Anything, mock.AnythingOfType("*store.FindUserIdentity")).Return(nil, nil)
+	userService := NewUserService("testsecret", mockStore)
+	ctx := auth.SetUserIDInContext(context.Background(), 1)
+
+	mockStore.On("GetInstanceSecuritySetting", mock.Anything).Return(&storepb.InstanceSecuritySetting{}, nil)
+	mockStore.On("GetInstancePasswordPolicySetting", mock.Anything).Return(&storepb.InstancePasswordPolicySetting{
+		MinLength:        10,
+		RequireDigit:     true,
+		RequireUppercase: true,
+		HistoryCount:     3,
+		ExpiryDays:       30,
+	}, nil)
+
+	// Registration is rejected with the broken rule in the error details
+	_, err := userService.RegisterUser(context.Background(), &v1pb.RegisterUserRequest{
+		Username: "testuser",
+		Email:    "test@example.com",
+		Password: "short",
+		Nickname: "Test User",
+	})
+	assertPasswordRule(t, err, auth.PasswordRuleMinLength)
+
+	_, err = userService.RegisterUser(context.Background(), &v1pb.RegisterUserRequest{
+		Username: "testuser",
+		Email:    "test@example.com",
+		Password: "Password123",
+		Nickname: "Test User",
+	})
+	assertPasswordRule(t, err, auth.PasswordRuleCommon)
+
+	currentHash, err := auth.HashPassword("Current-pass1")
+	assert.NoError(t, err)
+	previousHash, err := auth.HashPassword("Previous-pass1")
+	assert.NoError(t, err)
+	user := &store.User{ID: 1, Username: "testuser", Password: currentHash, Role: store.RoleUser}
+	mockStore.On("GetUser", mock.Anything, &store.FindUser{ID: &user.ID}).Return(user, nil)
+	mockStore.On("ListPasswordHistories", mock.Anything, &store.FindPasswordHistory{UserID: 1, Limit: 3}).Return([]*store.PasswordHistory{
+		{ID: 2, UserID: 1, PasswordHash: currentHash},
+		{ID: 1, UserID: 1, PasswordHash: previousHash},
+	}, nil)
+
+	// Recent passwords cannot be reused
+	for _, password := range []string{"Current-pass1", "Previous-pass1"} {
+		_, err = userService.ChangePassword(ctx, &v1pb.ChangePasswordRequest{
+			OldPassword: "Current-pass1",
+			NewPassword: password,
+		})
+		assertPasswordRule(t, err, auth.PasswordRuleHistory)
+	}
+
+	// A new password is stored with its expiry and recorded in the history
+	var update *store.UpdateUser
+	mockStore.On("UpdateUser", mock.Anything, mock.AnythingOfType("*store.UpdateUser")).Run(func(args mock.Arguments) {
+		update = args.Get(1).(*store.UpdateUser)
+	}).Return(user, nil)
+	mockStore.On("CreatePasswordHistory", mock.Anything, mock.AnythingOfType("*store.CreatePasswordHistory")).Return(&store.PasswordHistory{ID: 3, UserID: 1}, nil)
+	mockStore.On("DeletePasswordHistories", mock.Anything, &store.DeletePasswordHistory{UserID: 1, BeforeID: 1}).Return(nil)
+	_, err = userService.ChangePassword(ctx, &v1pb.ChangePasswordRequest{
+		OldPassword: "Current-pass1",
+		NewPassword: "Brand-new-pass1",
+	})
+	assert.NoError(t, err)
+	assert.True(t, auth.CheckPassword("Brand-new-pass1", *update.Password))
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, 30), *update.PasswordExpires, time.Minute)
+	mockStore.AssertCalled(t, "CreatePasswordHistory", mock.Anything, &store.CreatePasswordHistory{UserID: 1, PasswordHash: *update.Password})
+}
+
+func assertPasswordRule(t *testing.T, err error, rule auth.PasswordRule) {
+	t.Helper()
+	var connectErr *connect.Error
+	if !assert.ErrorAs(t, err, &connectErr) {
+		return
+	}
+	assert.Equal(t, connect.CodeInvalidArgument, connectErr.Code())
+	if assert.Len(t, connectErr.Details(), 1) {
+		detail, err := connectErr.Details()[0].Value()
+		assert.NoError(t, err)
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if assert.True(t, ok) {
+			assert.Equal(t, passwordPolicyViolationReason, info.Reason)
+			assert.Equal(t, string(rule), info.Metadata["rule"])
+		}
+	}
+}
+
+func TestUserService_PersonalAccessTokens(t *testing.T) {
+	mockStore := new(MockStore)
+	userService := NewUserService("testsecret", mockStore)
+	ctx := auth.SetUserIDInContext(context.Background(), 1)
+
+	// Create stores only the hash and returns the plain token once
+	var created *store.CreatePersonalAccessToken
+	mockStore.On("CreatePersonalAccessToken", mock.Anything, mock.AnythingOfType("*store.CreatePersonalAccessToken")).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*store.CreatePersonalAccessToken)
+	}).Return(&store.PersonalAccessToken{
+		ID:          7,
+		UserID:      1,
+		Description: "ci",
+		CreatedAt:   time.Now(),
+	}, nil)
+
+	createResp, err := userService.CreatePersonalAccessToken(ctx, &v1pb.CreatePersonalAccessTokenRequest{Description: "ci"})
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(createResp.Token, auth.PersonalAccessTokenPrefix))
+	assert.Equal(t, auth.HashToken(createResp.Token), created.TokenHash)
+	assert.Equal(t, int64(1), created.UserID)
+	assert.Nil(t, created.ExpiresAt)
+	assert.Equal(t, int64(7), createResp.PersonalAccessToken.Id)
+
+	// Expiry in the past is rejected
+	_, err = userService.CreatePersonalAccessToken(ctx, &v1pb.CreatePersonalAccessTokenRequest{
+		Description: "ci",
+		ExpiresAt:   timestamppb.New(time.Now().Add(-time.Hour)),
+	})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// Deleting a token owned by someone else reports not found
+	tokenID := int64(8)
+	userID := int64(1)
+	mockStore.On("ListPersonalAccessTokens", mock.Anything, &store.FindPersonalAccessToken{ID: &tokenID, UserID: &userID}).Return([]*store.PersonalAccessToken{}, nil)
+	_, err = userService.DeletePersonalAccessToken(ctx, &v1pb.DeletePersonalAccessTokenRequest{Id: tokenID})
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	mockStore.AssertNotCalled(t, "DeletePersonalAccessToken", mock.Anything, mock.Anything)
+
+	mockStore.AssertExpectations(t)
+}
+
+func TestUserService_Sessions(t *testing.T) {
+	mockStore := new(MockStore)
+	userService := NewUserService("testsecret", mockStore)
+	userID := int64(1)
+	ctx := auth.SetUserClaimsInContext(auth.SetUserIDInContext(context.Background(), userID), &auth.UserClaims{
+		UserID:    userID,
+		Username:  "testuser",
+		SessionID: "current",
+	})
+
+	now := time.Now()
+	expiresAt := now.Add(auth.RefreshTokenDuration)
+	mockStore.On("ListRefreshTokens", mock.Anything, &store.FindRefreshToken{UserID: &userID}).Return([]*store.RefreshToken{
+		{ID: 1, UserID: userID, FamilyID: "current", Revoked: true, ExpiresAt: expiresAt, CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: 3, UserID: userID, FamilyID: "current", UserAgent: "phone", IPAddress: "10.0.0.1", ExpiresAt: expiresAt, CreatedAt: now.Add(-time.Hour)},
+		{ID: 2, UserID: userID, FamilyID: "other", UserAgent: "laptop", IPAddress: "10.0.0.2", ExpiresAt: expiresAt, CreatedAt: now.Add(-90 * time.Minute)},
+		{ID: 4, UserID: userID, FamilyID: "loggedout", Revoked: true, ExpiresAt: expiresAt, CreatedAt: now},
+	}, nil)
+
+	// Active sessions are listed with the newest client info, most recently used first
+	listResp, err := userService.ListSessions(ctx, &v1pb.ListSessionsRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, listResp.Sessions, 2)
+	assert.Equal(t, "current", listResp.Sessions[0].Id)
+	assert.True(t, listResp.Sessions[0].Current)
+	assert.Equal(t, "phone", listResp.Sessions[0].UserAgent)
+	assert.Equal(t, "10.0.0.1", listResp.Sessions[0].IpAddress)
+	assert.Equal(t, now.Add(-2*time.Hour).Unix(), listResp.Sessions[0].CreatedAt.AsTime().Unix())
+	assert.Equal(t, "other", listResp.Sessions[1].Id)
+	assert.False(t, listResp.Sessions[1].Current)
+
+	// Revoking the others leaves the current session alone
+	otherID := "other"
+	mockStore.On("ListRefreshTokens", mock.Anything, &store.FindRefreshToken{UserID: &userID, FamilyID: &otherID}).Return([]*store.RefreshToken{
+		{ID: 2, UserID: userID, FamilyID: otherID, ExpiresAt: expiresAt},
+	}, nil)
+	mockStore.On("UpdateRefreshToken", mock.Anything, mock.MatchedBy(func(update *store.UpdateRefreshToken) bool {
+		return update.ID == 2 && update.Revoked != nil && *update.Revoked
+	})).Return(&store.RefreshToken{ID: 2, Revoked: true}, nil)
+	mockStore.On("CreateRevokedToken", mock.Anything, mock.MatchedBy(func(create *store.CreateRevokedToken) bool {
+		return create.JTI == otherID && create.UserID == userID
+	})).Return(&store.RevokedToken{ID: 1, JTI: otherID}, nil)
+
+	revokeResp, err := userService.RevokeAllOtherSessions(ctx, &v1pb.RevokeAllOtherSessionsRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), revokeResp.RevokedCount)
+
+	// Sessions of other users are not found
+	unknownID := "unknown"
+	mockStore.On("ListRefreshTokens", mock.Anything, &store.FindRefreshToken{UserID: &userID, FamilyID: &unknownID}).Return([]*store.RefreshToken{}, nil)
+	_, err = userService.RevokeSession(ctx, &v1pb.RevokeSessionRequest{Id: unknownID})
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	mockStore.AssertExpectations(t)
+	mockStore.AssertNumberOfCalls(t, "UpdateRefreshToken", 1)
+}
+
+func TestUserService_EnrollTOTP(t *testing.T) {
+	// Create mock store
+	mockStore := new(MockStore)
+	userID := int64(1)
+	ctx := context.WithValue(context.Background(), auth.UserIDContextKey, userID)
+
+	// Mock responses
+	mockStore.On("GetUser", mock.Anything, &store.FindUser{ID: &userID}).Return(&store.User{ID: userID, Username: "testuser"}, nil)
+	mockStore.On("GetTOTPCredential", mock.Anything, &store.FindTOTPCredential{UserID: &userID}).Return(nil, nil).Once()
+	var created *store.CreateTOTPCredential
+	mockStore.On("CreateTOTPCredential", mock.Anything, mock.AnythingOfType("*store.CreateTOTPCredential")).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*store.CreateTOTPCredential)
+	}).Return(&store.TOTPCredential{ID: 1, UserID: userID}, nil)
+
+	// Create user service
+	userService := NewUserService("testsecret", mockStore)
+
+	// Test EnrollTOTP
+	resp, err := userService.EnrollTOTP(ctx, &v1pb.EnrollTOTPRequest{})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.Secret)
+	assert.True(t, strings.HasPrefix(resp.OtpauthUri, "otpauth://totp/"))
+	assert.Len(t, resp.RecoveryCodes, 10)
+
+	// Secrets are stored encrypted
+	assert.NotEqual(t, resp.Secret, created.Secret)
+	decrypted, err := auth.DecryptTOTPSecret(created.Secret, "testsecret")
+	assert.NoError(t, err)
+	assert.Equal(t, resp.Secret, decrypted)
+	assert.NotContains(t, created.RecoveryCodes, resp.RecoveryCodes[0])
+
+	// Confirming requires a valid code
+	credential := &store.TOTPCredential{ID: 1, UserID: userID, Secret: created.Secret, RecoveryCodes: created.RecoveryCodes}
+	mockStore.On("GetTOTPCredential", mock.Anything, &store.FindTOTPCredential{UserID: &userID}).Return(credential, nil)
+	_, err = userService.ConfirmTOTP(ctx, &v1pb.ConfirmTOTPRequest{Code: resp.RecoveryCodes[0]})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	code, err := auth.GenerateTOTPCode(resp.Secret, time.Now())
+	assert.NoError(t, err)
+	mockStore.On("UpdateTOTPCredential", mock.Anything, mock.AnythingOfType("*store.UpdateTOTPCredential")).Return(credential, nil)
+	_, err = userService.ConfirmTOTP(ctx, &v1pb.ConfirmTOTPRequest{Code: code})
+	assert.NoError(t, err)
+	mockStore.AssertCalled(t, "UpdateTOTPCredential", mock.Anything, mock.MatchedBy(func(update *store.UpdateTOTPCredential) bool {
+		return update.Enabled != nil && *update.Enabled
+	}))
+
+	// Verify mock calls
+	mockStore.AssertExpectations(t)
+}
+
+func TestUserService_UnlockUser(t *testing.T) {
+	// Create mock store
+	mockStore := new(MockStore)
+	adminID, userID := int64(1), int64(2)
+
+	// Mock responses
+	mockStore.On("ListUsers", mock.Anything, &store.FindUser{ID: &userID}).Return([]*store.User{{ID: userID, Username: "testuser"}}, nil)
+	mockStore.On("DeleteLoginAttempts", mock.Anything, &store.DeleteLoginAttempt{Scope: store.LoginAttemptScopeAccount, Identifier: "testuser"}).Return(nil)
+
+	// Create user service
+	userService := NewUserService("testsecret", mockStore)
+
+	// Only users with the users.write permission may unlock accounts
+	userCtx := permissionContext(userID, store.PermissionUsersRead)
+	_, err := userService.UnlockUser(userCtx, &v1pb.UnlockUserRequest{Id: userID})
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	mockStore.AssertNotCalled(t, "DeleteLoginAttempts", mock.Anything, mock.Anything)
+
+	adminCtx := permissionContext(adminID, store.PermissionUsersWrite)
+	_, err = userService.UnlockUser(adminCtx, &v1pb.UnlockUserRequest{Id: userID})
+	assert.NoError(t, err)
+
+	// Verify mock calls
+	mockStore.AssertExpectations(t)
+}
+
+func TestUserService_EmailVerification(t *testing.T) {
+	mockStore := new(MockStore)
+	userService := NewUserService("testsecret", mockStore)
+	notifier := notify.NewMemoryNotifier()
+	userService.Notifier = notifier
+	ctx := auth.SetUserIDInContext(context.Background(), 1)
+
+	user := &store.User{ID: 1, Username: "testuser", Email: "old@example.com", Nickname: "Test User", Role: store.RoleUser, EmailVerified: true}
+
+	// Addresses of other users cannot be taken
+	mockStore.On("GetUserByEmail", mock.Anything, "taken@example.com").Return(&store.User{ID: 2}, nil)
+	_, err := userService.UpdateUserProfile(ctx, &v1pb.UpdateUserProfileRequest{Email: "taken@example.com"})
+	assert.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err))
+
+	// A new address stays pending until it is verified
+	var created *store.CreateEmailVerification
+	mockStore.On("GetUserByEmail", mock.Anything, "new@example.com").Return(nil, nil)
+	mockStore.On("UpdateUser", mock.Anything, &store.UpdateUser{ID: 1}).Return(user, nil).Once()
+	mockStore.On("DeleteEmailVerifications", mock.Anything, &store.DeleteEmailVerification{UserID: 1}).Return(nil)
+	mockStore.On("CreateEmailVerification", mock.Anything, mock.AnythingOfType("*store.CreateEmailVerification")).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*store.CreateEmailVerification)
+	}).Return(&store.EmailVerification{ID: 1, UserID: 1}, nil)
+	resp, err := userService.UpdateUserProfile(ctx, &v1pb.UpdateUserProfileRequest{Email: "new@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "old@example.com", resp.User.Email)
+	assert.Equal(t, "new@example.com", resp.PendingEmail)
+	require.NotNil(t, created)
+	assert.Equal(t, "new@example.com", created.Email)
+	messages := notifier.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "new@example.com", messages[0].To)
+	var token string
+	for _, field := range strings.Fields(messages[0].Body) {
+		if auth.HashToken(field) == created.TokenHash {
+			token = field
+		}
+	}
+	require.NotEmpty(t, token, "the email carries the token")
+
+	// Unknown tokens are rejected
+	unknownHash := auth.HashToken("unknown")
+	mockStore.On("GetEmailVerification", mock.Anything, &store.FindEmailVerification{TokenHash: &unknownHash}).Return(nil, nil)
+	_, err = userService.VerifyEmail(context.Background(), &v1pb.VerifyEmailRequest{Token: "unknown"})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// Verifying the token switches the address
+	mockStore.On("GetEmailVerification", mock.Anything, &store.FindEmailVerification{TokenHash: &created.TokenHash}).Return(&store.EmailVerification{
+		ID: 1, UserID: 1, Email: created.Email, TokenHash: created.TokenHash, ExpiresAt: created.ExpiresAt,
+	}, nil)
+	mockStore.On("ListUsers", mock.Anything, &store.FindUser{ID: &user.ID}).Return([]*store.User{user}, nil)
+	verified := true
+	newEmail := "new@example.com"
+	mockStore.On("UpdateUser", mock.Anything, &store.UpdateUser{ID: 1, Email: &newEmail, EmailVerified: &verified}).Return(&store.User{
+		ID: 1, Username: "testuser", Email: newEmail, EmailVerified: true,
+	}, nil)
+	verifyResp, err := userService.VerifyEmail(context.Background(), &v1pb.VerifyEmailRequest{Token: token})
+	require.NoError(t, err)
+	assert.Equal(t, newEmail, verifyResp.User.Email)
+	assert.True(t, verifyResp.User.EmailVerified)
+	mockStore.AssertNumberOfCalls(t, "DeleteEmailVerifications", 2)
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/pixb/go-server/internal/profile"
+	"github.com/pixb/go-server/server/auth"
+	"github.com/pixb/go-server/store"
+)
+
+// newTLSConfig loads the certificate of the server and the CA that client certificates
+// are verified against. Client certificates are optional, callers without one
+// authenticate with bearer tokens as usual.
+func newTLSConfig(prof *profile.Profile) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(prof.TLSCert, prof.TLSKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tls certificate: %w", err)
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if prof.TLSClientCA != "" {
+		pem, err := os.ReadFile(prof.TLSClientCA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tls client ca: %w", err)
+		}
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("tls client ca contains no certificates")
+		}
+		config.ClientCAs = clientCAs
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	return config, nil
+}
+
+// newServicePrincipals parses the service principals of the profile and checks that
+// their roles exist.
+func newServicePrincipals(ctx context.Context, prof *profile.Profile, st *store.Store) ([]*auth.ServicePrincipal, error) {
+	if len(prof.TLSClientPrincipals) == 0 {
+		return nil, nil
+	}
+	roles, err := st.ListRoleDefinitions(ctx, &store.FindRoleDefinition{})
+	if err != nil {
+		return nil, err
+	}
+	principals := []*auth.ServicePrincipal{}
+	for _, entry := range prof.TLSClientPrincipals {
+		principal, err := auth.ParseServicePrincipal(entry)
+		if err != nil {
+			return nil, err
+		}
+		for _, role := range principal.Roles {
+			if !slices.ContainsFunc(roles, func(r *store.RoleDefinition) bool { return r.Name == role }) {
+				return nil, fmt.Errorf("role %q of service principal %q does not exist", role, principal.Name)
+			}
+		}
+		principals = append(principals, principal)
+	}
+	return principals, nil
+}
+
+// startTLS serves all protocols on one TLS listener. cmux hides the TLS state of the
+// connections, so gRPC requests are told apart by their content type instead and served
+// by the gRPC server through its HTTP handler.
+func (s *Server) startTLS(ctx context.Context, listener net.Listener) error {
+	if err := s.apiV1Service.RegisterGateway(ctx, s.echoServer); err != nil {
+		listener.Close()
+		return err
+	}
+
+	s.httpServer = &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Connect and gateway handlers find the client certificate in the context.
+			r = r.WithContext(auth.WithTLSConnectionState(r.Context(), r.TLS))
+			if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+				s.grpcServer.ServeHTTP(w, r)
+				return
+			}
+			s.echoServer.ServeHTTP(w, r)
+		}),
+		TLSConfig: s.tlsConfig,
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.echoServer.Logger.Info("TLS server starting on ", listener.Addr())
+		if err := s.httpServer.ServeTLS(listener, "", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.echoServer.Logger.Error(err)
+		}
+	}()
+
+	s.echoServer.Logger.Info("Server started successfully (TLS, HTTP/1.1 + HTTP/2)")
+	return nil
+}
@@ -0,0 +1,167 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	"github.com/pixb/go-server/internal/profile"
+	v1pb "github.com/pixb/go-server/proto/gen/api/v1"
+	"github.com/pixb/go-server/proto/gen/api/v1/apiv1connect"
+	"github.com/pixb/go-server/store"
+	"github.com/pixb/go-server/store/db/sqlite"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key}
+}
+
+// issue returns a certificate signed by the CA for the template.
+func (ca *testCA) issue(t *testing.T, template *x509.Certificate) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600))
+}
+
+func TestServer_ClientCertificates(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	serverCA, clientCA, otherCA := newTestCA(t), newTestCA(t), newTestCA(t)
+	serverCert := serverCA.issue(t, &x509.Certificate{
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	keyDER, err := x509.MarshalPKCS8PrivateKey(serverCert.PrivateKey)
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, "server.crt"), "CERTIFICATE", serverCert.Certificate[0])
+	writePEM(t, filepath.Join(dir, "server.key"), "PRIVATE KEY", keyDER)
+	writePEM(t, filepath.Join(dir, "client-ca.crt"), "CERTIFICATE", clientCA.cert.Raw)
+
+	prof := &profile.Profile{
+		Driver:              "sqlite",
+		DSN:                 filepath.Join(dir, "test.db"),
+		Addr:                "127.0.0.1",
+		TLSCert:             filepath.Join(dir, "server.crt"),
+		TLSKey:              filepath.Join(dir, "server.key"),
+		TLSClientCA:         filepath.Join(dir, "client-ca.crt"),
+		TLSClientPrincipals: []string{"billing.internal=admin"},
+	}
+	driver, err := sqlite.NewDriver(prof)
+	require.NoError(t, err)
+	st := store.New(driver, prof)
+	require.NoError(t, st.Migrate(ctx))
+	s, err := NewServer(ctx, prof, st)
+	require.NoError(t, err)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, s.startTLS(ctx, listener))
+	t.Cleanup(func() { s.Shutdown(context.Background()) })
+	address := listener.Addr().String()
+
+	clientCertUsage := []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	clients := map[string]*tls.Config{}
+	for name, cert := range map[string]*tls.Certificate{
+		"billing":  ptr(clientCA.issue(t, &x509.Certificate{DNSNames: []string{"billing.internal"}, ExtKeyUsage: clientCertUsage})),
+		"unmapped": ptr(clientCA.issue(t, &x509.Certificate{Subject: pkix.Name{CommonName: "reports.internal"}, ExtKeyUsage: clientCertUsage})),
+		"none":     nil,
+	} {
+		rootCAs := x509.NewCertPool()
+		rootCAs.AddCert(serverCA.cert)
+		config := &tls.Config{RootCAs: rootCAs}
+		if cert != nil {
+			config.Certificates = []tls.Certificate{*cert}
+		}
+		clients[name] = config
+	}
+
+	for name, code := range map[string]codes.Code{
+		"billing":  codes.OK,
+		"unmapped": codes.Unauthenticated,
+		"none":     codes.Unauthenticated,
+	} {
+		conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(credentials.NewTLS(clients[name])))
+		require.NoError(t, err)
+		_, err = v1pb.NewAdminServiceClient(conn).ListUsers(ctx, &v1pb.ListUsersRequest{})
+		assert.Equal(t, code, status.Code(err), "grpc %s", name)
+		conn.Close()
+
+		httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: clients[name], ForceAttemptHTTP2: true}}
+		_, err = apiv1connect.NewAdminServiceClient(httpClient, "https://"+address).ListUsers(ctx, connect.NewRequest(&v1pb.ListUsersRequest{}))
+		if code == codes.OK {
+			assert.NoError(t, err, "connect %s", name)
+		} else {
+			assert.Equal(t, connect.Code(code), connect.CodeOf(err), "connect %s", name)
+		}
+
+		resp, err := httpClient.Get("https://" + address + "/api/v1/admin/users")
+		require.NoError(t, err)
+		resp.Body.Close()
+		if code == codes.OK {
+			assert.Equal(t, http.StatusOK, resp.StatusCode, "gateway %s", name)
+		} else {
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "gateway %s", name)
+		}
+	}
+
+	// Certificates of other CAs are refused during the handshake
+	untrusted := clients["none"].Clone()
+	untrusted.Certificates = []tls.Certificate{otherCA.issue(t, &x509.Certificate{DNSNames: []string{"billing.internal"}, ExtKeyUsage: clientCertUsage})}
+	_, err = (&http.Client{Transport: &http.Transport{TLSClientConfig: untrusted}}).Get("https://" + address + "/healthz")
+	assert.Error(t, err)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -2,6 +2,9 @@ package mysql
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"time"
 
 	"github.com/pixb/go-server/store"
 )
@@ -23,6 +26,34 @@ func (d *Driver) UpsertInstanceSetting(ctx context.Context, upsert *store.Instan
 	return upsert, nil
 }
 
+// CompareAndSwapInstanceSetting writes the setting only if its stored value is still the old
+// one, or if it is not stored when there is no old value. It reports whether it did.
+func (d *Driver) CompareAndSwapInstanceSetting(ctx context.Context, swap *store.CompareAndSwapInstanceSetting) (bool, error) {
+	setting := swap.New
+	var result sql.Result
+	var err error
+	if swap.Old == nil {
+		result, err = d.db.ExecContext(ctx, `
+			INSERT IGNORE INTO system_setting (name, value, description)
+			VALUES (?, ?, ?)`,
+			setting.Name, setting.Value, setting.Description)
+	} else {
+		result, err = d.db.ExecContext(ctx, `
+			UPDATE system_setting
+			SET value = ?, description = ?
+			WHERE name = ? AND value = ?`,
+			setting.Value, setting.Description, setting.Name, *swap.Old)
+	}
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
 func (d *Driver) ListInstanceSettings(ctx context.Context, find *store.FindInstanceSetting) ([]*store.InstanceSetting, error) {
 	query := `
 		SELECT
@@ -69,3 +100,66 @@ func (d *Driver) DeleteInstanceSetting(ctx context.Context, delete *store.Delete
 	_, err := d.db.ExecContext(ctx, stmt, delete.Name)
 	return err
 }
+
+// SetupInstance runs the setup in one transaction. A concurrent setup blocks on the
+// insert of the SETUP setting until this one commits and then skips it.
+func (d *Driver) SetupInstance(ctx context.Context, setup *store.SetupInstance) (*store.User, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT IGNORE INTO system_setting (name, value, description)
+		SELECT ?, ?, ? FROM DUAL
+		WHERE NOT EXISTS (SELECT 1 FROM users WHERE role = ?)`,
+		setup.Setup.Name, setup.Setup.Value, setup.Setup.Description, store.RoleAdmin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record setup: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return nil, err
+	}
+
+	now := time.Now()
+	admin := setup.Admin
+	result, err = tx.ExecContext(ctx,
+		`INSERT INTO users (username, nickname, password, phone, email, role, email_verified, password_expires, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		admin.Username, admin.Nickname, admin.Password, admin.Phone, admin.Email, admin.Role, admin.EmailVerified, admin.PasswordExpires, now, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	for _, setting := range setup.Settings {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO system_setting (name, value, description)
+			VALUES (?, ?, ?)
+			ON DUPLICATE KEY UPDATE
+				value = VALUES(value),
+				description = VALUES(description)`,
+			setting.Name, setting.Value, setting.Description); err != nil {
+			return nil, fmt.Errorf("failed to upsert instance setting: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &store.User{
+		ID:              id,
+		Username:        admin.Username,
+		Nickname:        admin.Nickname,
+		Password:        admin.Password,
+		Phone:           admin.Phone,
+		Email:           admin.Email,
+		Role:            admin.Role,
+		EmailVerified:   admin.EmailVerified,
+		PasswordExpires: admin.PasswordExpires,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, nil
+}
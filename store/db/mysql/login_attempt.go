@@ -0,0 +1,87 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pixb/go-server/store"
+)
+
+func (d *Driver) RecordLoginFailure(ctx context.Context, record *store.RecordLoginFailure) (*store.LoginAttempt, error) {
+	// failed_count is assigned first so that it still sees the previous last_failed_at.
+	_, err := d.db.ExecContext(ctx,
+		`INSERT INTO login_attempts (scope, identifier, failed_count, last_failed_at) VALUES (?, ?, 1, ?)
+		ON DUPLICATE KEY UPDATE
+			failed_count = IF(last_failed_at < ?, 1, failed_count + 1),
+			last_failed_at = VALUES(last_failed_at)`,
+		record.Scope, record.Identifier, time.Now(), record.ResetBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record login failure: %w", err)
+	}
+
+	list, err := d.ListLoginAttempts(ctx, &store.FindLoginAttempt{Scope: &record.Scope, Identifier: &record.Identifier})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("login attempt not found after recording failure")
+	}
+	return list[0], nil
+}
+
+func (d *Driver) UpdateLoginAttempt(ctx context.Context, update *store.UpdateLoginAttempt) (*store.LoginAttempt, error) {
+	_, err := d.db.ExecContext(ctx, `UPDATE login_attempts SET locked_until = ? WHERE id = ?`, update.LockedUntil, update.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update login attempt: %w", err)
+	}
+
+	var attempt store.LoginAttempt
+	err = d.db.QueryRowContext(ctx,
+		`SELECT id, scope, identifier, failed_count, last_failed_at, locked_until FROM login_attempts WHERE id = ?`,
+		update.ID).Scan(&attempt.ID, &attempt.Scope, &attempt.Identifier, &attempt.FailedCount, &attempt.LastFailedAt, &attempt.LockedUntil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get login attempt: %w", err)
+	}
+	return &attempt, nil
+}
+
+func (d *Driver) ListLoginAttempts(ctx context.Context, find *store.FindLoginAttempt) ([]*store.LoginAttempt, error) {
+	query := `SELECT id, scope, identifier, failed_count, last_failed_at, locked_until FROM login_attempts WHERE 1 = 1`
+	args := []interface{}{}
+
+	if find.Scope != nil {
+		query += " AND scope = ?"
+		args = append(args, *find.Scope)
+	}
+	if find.Identifier != nil {
+		query += " AND identifier = ?"
+		args = append(args, *find.Identifier)
+	}
+	query += " ORDER BY id ASC"
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list login attempts: %w", err)
+	}
+	defer rows.Close()
+
+	var attempts []*store.LoginAttempt
+	for rows.Next() {
+		var attempt store.LoginAttempt
+		if err := rows.Scan(&attempt.ID, &attempt.Scope, &attempt.Identifier, &attempt.FailedCount, &attempt.LastFailedAt, &attempt.LockedUntil); err != nil {
+			return nil, fmt.Errorf("failed to scan login attempt: %w", err)
+		}
+		attempts = append(attempts, &attempt)
+	}
+
+	return attempts, nil
+}
+
+func (d *Driver) DeleteLoginAttempts(ctx context.Context, delete *store.DeleteLoginAttempt) error {
+	_, err := d.db.ExecContext(ctx, `DELETE FROM login_attempts WHERE scope = ? AND identifier = ?`, delete.Scope, delete.Identifier)
+	if err != nil {
+		return fmt.Errorf("failed to delete login attempts: %w", err)
+	}
+	return nil
+}
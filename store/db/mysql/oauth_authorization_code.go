@@ -0,0 +1,86 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pixb/go-server/store"
+)
+
+func (d *Driver) CreateOAuthAuthorizationCode(ctx context.Context, create *store.CreateOAuthAuthorizationCode) (*store.OAuthAuthorizationCode, error) {
+	now := time.Now()
+	result, err := d.db.ExecContext(ctx,
+		`INSERT INTO oauth_authorization_codes (code_hash, client_id, user_id, redirect_uri, scopes, nonce, code_challenge, expires_at, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		create.CodeHash, create.ClientID, create.UserID, create.RedirectURI, strings.Join(create.Scopes, " "), create.Nonce, create.CodeChallenge, create.ExpiresAt, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create oauth authorization code: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	return &store.OAuthAuthorizationCode{
+		ID:            id,
+		CodeHash:      create.CodeHash,
+		ClientID:      create.ClientID,
+		UserID:        create.UserID,
+		RedirectURI:   create.RedirectURI,
+		Scopes:        create.Scopes,
+		Nonce:         create.Nonce,
+		CodeChallenge: create.CodeChallenge,
+		ExpiresAt:     create.ExpiresAt,
+		CreatedAt:     now,
+	}, nil
+}
+
+func (d *Driver) ListOAuthAuthorizationCodes(ctx context.Context, find *store.FindOAuthAuthorizationCode) ([]*store.OAuthAuthorizationCode, error) {
+	query := "SELECT id, code_hash, client_id, user_id, redirect_uri, scopes, nonce, code_challenge, expires_at, used_at, created_at FROM oauth_authorization_codes WHERE 1 = 1"
+	args := []interface{}{}
+
+	if find.ID != nil {
+		query += " AND id = ?"
+		args = append(args, *find.ID)
+	}
+	if find.CodeHash != nil {
+		query += " AND code_hash = ?"
+		args = append(args, *find.CodeHash)
+	}
+	query += " ORDER BY id DESC"
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list oauth authorization codes: %w", err)
+	}
+	defer rows.Close()
+
+	var codes []*store.OAuthAuthorizationCode
+	for rows.Next() {
+		var code store.OAuthAuthorizationCode
+		var scopes string
+		if err := rows.Scan(&code.ID, &code.CodeHash, &code.ClientID, &code.UserID, &code.RedirectURI, &scopes, &code.Nonce, &code.CodeChallenge, &code.ExpiresAt, &code.UsedAt, &code.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan oauth authorization code: %w", err)
+		}
+		code.Scopes = strings.Fields(scopes)
+		codes = append(codes, &code)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list oauth authorization codes: %w", err)
+	}
+
+	return codes, nil
+}
+
+func (d *Driver) UseOAuthAuthorizationCode(ctx context.Context, id int64) (bool, error) {
+	result, err := d.db.ExecContext(ctx, "UPDATE oauth_authorization_codes SET used_at = ? WHERE id = ? AND used_at IS NULL", time.Now(), id)
+	if err != nil {
+		return false, fmt.Errorf("failed to use oauth authorization code: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected == 1, nil
+}
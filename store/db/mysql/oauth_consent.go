@@ -0,0 +1,77 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pixb/go-server/store"
+)
+
+func (d *Driver) UpsertOAuthConsent(ctx context.Context, upsert *store.UpsertOAuthConsent) (*store.OAuthConsent, error) {
+	now := time.Now()
+	_, err := d.db.ExecContext(ctx,
+		`INSERT INTO oauth_consents (user_id, client_id, scopes, created_at, updated_at) VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			scopes = VALUES(scopes),
+			updated_at = VALUES(updated_at)`,
+		upsert.UserID, upsert.ClientID, strings.Join(upsert.Scopes, " "), now, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert oauth consent: %w", err)
+	}
+
+	list, err := d.ListOAuthConsents(ctx, &store.FindOAuthConsent{UserID: &upsert.UserID, ClientID: &upsert.ClientID})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("oauth consent not found after upsert")
+	}
+	return list[0], nil
+}
+
+func (d *Driver) ListOAuthConsents(ctx context.Context, find *store.FindOAuthConsent) ([]*store.OAuthConsent, error) {
+	query := "SELECT id, user_id, client_id, scopes, created_at, updated_at FROM oauth_consents WHERE 1 = 1"
+	args := []interface{}{}
+
+	if find.UserID != nil {
+		query += " AND user_id = ?"
+		args = append(args, *find.UserID)
+	}
+	if find.ClientID != nil {
+		query += " AND client_id = ?"
+		args = append(args, *find.ClientID)
+	}
+	query += " ORDER BY id"
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list oauth consents: %w", err)
+	}
+	defer rows.Close()
+
+	var consents []*store.OAuthConsent
+	for rows.Next() {
+		var consent store.OAuthConsent
+		var scopes string
+		if err := rows.Scan(&consent.ID, &consent.UserID, &consent.ClientID, &scopes, &consent.CreatedAt, &consent.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan oauth consent: %w", err)
+		}
+		consent.Scopes = strings.Fields(scopes)
+		consents = append(consents, &consent)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list oauth consents: %w", err)
+	}
+
+	return consents, nil
+}
+
+func (d *Driver) DeleteOAuthConsent(ctx context.Context, delete *store.DeleteOAuthConsent) error {
+	_, err := d.db.ExecContext(ctx, "DELETE FROM oauth_consents WHERE user_id = ? AND client_id = ?", delete.UserID, delete.ClientID)
+	if err != nil {
+		return fmt.Errorf("failed to delete oauth consent: %w", err)
+	}
+	return nil
+}
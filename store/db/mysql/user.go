@@ -12,11 +12,10 @@ import (
 func (d *Driver) CreateUser(ctx context.Context, create *store.User) (*store.User, error) {
 	var id int64
 	now := time.Now()
-	passwordExpires := now.AddDate(0, 0, 90) // Default 90 days expiration
 
 	err := d.db.QueryRowContext(ctx,
-		`INSERT INTO users (username, nickname, password, phone, email, role, password_expires, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id`,
-		create.Username, create.Nickname, create.Password, create.Phone, create.Email, create.Role, passwordExpires, now, now).Scan(&id)
+		`INSERT INTO users (username, nickname, password, phone, email, role, email_verified, password_expires, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id`,
+		create.Username, create.Nickname, create.Password, create.Phone, create.Email, create.Role, create.EmailVerified, create.PasswordExpires, now, now).Scan(&id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
@@ -29,7 +28,8 @@ func (d *Driver) CreateUser(ctx context.Context, create *store.User) (*store.Use
 		Phone:           create.Phone,
 		Email:           create.Email,
 		Role:            create.Role,
-		PasswordExpires: passwordExpires,
+		EmailVerified:   create.EmailVerified,
+		PasswordExpires: create.PasswordExpires,
 		CreatedAt:       now,
 		UpdatedAt:       now,
 	}, nil
@@ -50,9 +50,6 @@ func (d *Driver) UpdateUser(ctx context.Context, update *store.UpdateUser) (*sto
 	if update.Password != nil {
 		query += ", password = ?"
 		args = append(args, *update.Password)
-		// Update password expiration when password is changed
-		query += ", password_expires = ?"
-		args = append(args, time.Now().AddDate(0, 0, 90))
 	}
 	if update.Phone != nil {
 		query += ", phone = ?"
@@ -70,13 +67,17 @@ func (d *Driver) UpdateUser(ctx context.Context, update *store.UpdateUser) (*sto
 		query += ", password_expires = ?"
 		args = append(args, *update.PasswordExpires)
 	}
+	if update.EmailVerified != nil {
+		query += ", email_verified = ?"
+		args = append(args, *update.EmailVerified)
+	}
 
-	query += " WHERE id = ? AND deleted_at IS NULL RETURNING id, username, nickname, password, phone, email, role, password_expires, created_at, updated_at"
+	query += " WHERE id = ? AND deleted_at IS NULL RETURNING id, username, nickname, password, phone, email, role, email_verified, password_expires, created_at, updated_at"
 	args = append(args, update.ID)
 
 	var user store.User
 	err := d.db.QueryRowContext(ctx, query, args...).Scan(
-		&user.ID, &user.Username, &user.Nickname, &user.Password, &user.Phone, &user.Email, &user.Role, &user.PasswordExpires, &user.CreatedAt, &user.UpdatedAt)
+		&user.ID, &user.Username, &user.Nickname, &user.Password, &user.Phone, &user.Email, &user.Role, &user.EmailVerified, &user.PasswordExpires, &user.CreatedAt, &user.UpdatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
@@ -85,9 +86,13 @@ func (d *Driver) UpdateUser(ctx context.Context, update *store.UpdateUser) (*sto
 }
 
 func (d *Driver) ListUsers(ctx context.Context, find *store.FindUser) ([]*store.User, error) {
-	query := `SELECT id, username, nickname, password, phone, email, role, password_expires, created_at, updated_at, deleted_at FROM users WHERE deleted_at IS NULL`
+	query := `SELECT id, username, nickname, password, phone, email, role, email_verified, password_expires, created_at, updated_at, deleted_at FROM users WHERE 1 = 1`
 	args := []interface{}{}
 
+	if !find.ShowDeleted {
+		query += " AND deleted_at IS NULL"
+	}
+
 	if find.ID != nil {
 		query += " AND id = ?"
 		args = append(args, *find.ID)
@@ -105,6 +110,12 @@ func (d *Driver) ListUsers(ctx context.Context, find *store.FindUser) ([]*store.
 		args = append(args, *find.Role)
 	}
 
+	query += " ORDER BY id ASC"
+	if find.Limit > 0 {
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, find.Limit, find.Offset)
+	}
+
 	rows, err := d.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list users: %w", err)
@@ -115,7 +126,7 @@ func (d *Driver) ListUsers(ctx context.Context, find *store.FindUser) ([]*store.
 	for rows.Next() {
 		var user store.User
 		var deletedAt *time.Time
-		if err := rows.Scan(&user.ID, &user.Username, &user.Nickname, &user.Password, &user.Phone, &user.Email, &user.Role, &user.PasswordExpires, &user.CreatedAt, &user.UpdatedAt, &deletedAt); err != nil {
+		if err := rows.Scan(&user.ID, &user.Username, &user.Nickname, &user.Password, &user.Phone, &user.Email, &user.Role, &user.EmailVerified, &user.PasswordExpires, &user.CreatedAt, &user.UpdatedAt, &deletedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
 		user.DeletedAt = deletedAt
@@ -133,12 +144,20 @@ func (d *Driver) DeleteUser(ctx context.Context, delete *store.DeleteUser) error
 	return nil
 }
 
+func (d *Driver) RestoreUser(ctx context.Context, restore *store.RestoreUser) error {
+	_, err := d.db.ExecContext(ctx, `UPDATE users SET deleted_at = NULL, updated_at = ? WHERE id = ? AND deleted_at IS NOT NULL`, time.Now(), restore.ID)
+	if err != nil {
+		return fmt.Errorf("failed to restore user: %w", err)
+	}
+	return nil
+}
+
 func (d *Driver) GetUserByUsername(ctx context.Context, username string) (*store.User, error) {
 	var user store.User
 	var deletedAt *time.Time
 	err := d.db.QueryRowContext(ctx,
-		`SELECT id, username, nickname, password, phone, email, role, password_expires, created_at, updated_at, deleted_at FROM users WHERE username = ? AND deleted_at IS NULL`,
-		username).Scan(&user.ID, &user.Username, &user.Nickname, &user.Password, &user.Phone, &user.Email, &user.Role, &user.PasswordExpires, &user.CreatedAt, &user.UpdatedAt, &deletedAt)
+		`SELECT id, username, nickname, password, phone, email, role, email_verified, password_expires, created_at, updated_at, deleted_at FROM users WHERE username = ? AND deleted_at IS NULL`,
+		username).Scan(&user.ID, &user.Username, &user.Nickname, &user.Password, &user.Phone, &user.Email, &user.Role, &user.EmailVerified, &user.PasswordExpires, &user.CreatedAt, &user.UpdatedAt, &deletedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -153,8 +172,8 @@ func (d *Driver) GetUserByEmail(ctx context.Context, email string) (*store.User,
 	var user store.User
 	var deletedAt *time.Time
 	err := d.db.QueryRowContext(ctx,
-		`SELECT id, username, nickname, password, phone, email, role, password_expires, created_at, updated_at, deleted_at FROM users WHERE email = ? AND deleted_at IS NULL`,
-		email).Scan(&user.ID, &user.Username, &user.Nickname, &user.Password, &user.Phone, &user.Email, &user.Role, &user.PasswordExpires, &user.CreatedAt, &user.UpdatedAt, &deletedAt)
+		`SELECT id, username, nickname, password, phone, email, role, email_verified, password_expires, created_at, updated_at, deleted_at FROM users WHERE email = ? AND deleted_at IS NULL`,
+		email).Scan(&user.ID, &user.Username, &user.Nickname, &user.Password, &user.Phone, &user.Email, &user.Role, &user.EmailVerified, &user.PasswordExpires, &user.CreatedAt, &user.UpdatedAt, &deletedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -169,8 +188,8 @@ func (d *Driver) GetUserByID(ctx context.Context, id int64) (*store.User, error)
 	var user store.User
 	var deletedAt *time.Time
 	err := d.db.QueryRowContext(ctx,
-		`SELECT id, username, nickname, password, phone, email, role, password_expires, created_at, updated_at, deleted_at FROM users WHERE id = ? AND deleted_at IS NULL`,
-		id).Scan(&user.ID, &user.Username, &user.Nickname, &user.Password, &user.Phone, &user.Email, &user.Role, &user.PasswordExpires, &user.CreatedAt, &user.UpdatedAt, &deletedAt)
+		`SELECT id, username, nickname, password, phone, email, role, email_verified, password_expires, created_at, updated_at, deleted_at FROM users WHERE id = ? AND deleted_at IS NULL`,
+		id).Scan(&user.ID, &user.Username, &user.Nickname, &user.Password, &user.Phone, &user.Email, &user.Role, &user.EmailVerified, &user.PasswordExpires, &user.CreatedAt, &user.UpdatedAt, &deletedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -0,0 +1,73 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pixb/go-server/store"
+)
+
+func (d *Driver) CreateEmailVerification(ctx context.Context, create *store.CreateEmailVerification) (*store.EmailVerification, error) {
+	now := time.Now()
+	var id int64
+	err := d.db.QueryRowContext(ctx,
+		"INSERT INTO email_verifications (user_id, email, token_hash, expires_at, created_at) VALUES ($1, $2, $3, $4, $5) RETURNING id",
+		create.UserID, create.Email, create.TokenHash, create.ExpiresAt, now).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create email verification: %w", err)
+	}
+
+	return &store.EmailVerification{
+		ID:        id,
+		UserID:    create.UserID,
+		Email:     create.Email,
+		TokenHash: create.TokenHash,
+		ExpiresAt: create.ExpiresAt,
+		CreatedAt: now,
+	}, nil
+}
+
+func (d *Driver) ListEmailVerifications(ctx context.Context, find *store.FindEmailVerification) ([]*store.EmailVerification, error) {
+	query := "SELECT id, user_id, email, token_hash, expires_at, created_at FROM email_verifications WHERE 1 = 1"
+	args := []interface{}{}
+
+	if find.UserID != nil {
+		query += fmt.Sprintf(" AND user_id = $%d", len(args)+1)
+		args = append(args, *find.UserID)
+	}
+	if find.Email != nil {
+		query += fmt.Sprintf(" AND email = $%d", len(args)+1)
+		args = append(args, *find.Email)
+	}
+	if find.TokenHash != nil {
+		query += fmt.Sprintf(" AND token_hash = $%d", len(args)+1)
+		args = append(args, *find.TokenHash)
+	}
+	query += " ORDER BY id DESC"
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list email verifications: %w", err)
+	}
+	defer rows.Close()
+
+	var verifications []*store.EmailVerification
+	for rows.Next() {
+		var verification store.EmailVerification
+		if err := rows.Scan(&verification.ID, &verification.UserID, &verification.Email, &verification.TokenHash, &verification.ExpiresAt, &verification.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan email verification: %w", err)
+		}
+		verifications = append(verifications, &verification)
+	}
+
+	return verifications, nil
+}
+
+func (d *Driver) DeleteEmailVerifications(ctx context.Context, delete *store.DeleteEmailVerification) error {
+	_, err := d.db.ExecContext(ctx, "DELETE FROM email_verifications WHERE user_id = $1", delete.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to delete email verifications: %w", err)
+	}
+	return nil
+}
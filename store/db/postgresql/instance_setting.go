@@ -2,12 +2,20 @@ package postgresql
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/lib/pq"
 
 	"github.com/pixb/go-server/store"
 )
 
+// instanceSettingChannel is notified with the name of each instance setting that changes.
+const instanceSettingChannel = "instance_setting"
+
 func (d *Driver) UpsertInstanceSetting(ctx context.Context, upsert *store.InstanceSetting) (*store.InstanceSetting, error) {
 	stmt := `
 		INSERT INTO system_setting (
@@ -22,10 +30,47 @@ func (d *Driver) UpsertInstanceSetting(ctx context.Context, upsert *store.Instan
 	if _, err := d.db.ExecContext(ctx, stmt, upsert.Name, upsert.Value, upsert.Description); err != nil {
 		return nil, err
 	}
+	if _, err := d.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", instanceSettingChannel, upsert.Name); err != nil {
+		return nil, err
+	}
 
 	return upsert, nil
 }
 
+// CompareAndSwapInstanceSetting writes the setting only if its stored value is still the old
+// one, or if it is not stored when there is no old value. It reports whether it did.
+func (d *Driver) CompareAndSwapInstanceSetting(ctx context.Context, swap *store.CompareAndSwapInstanceSetting) (bool, error) {
+	setting := swap.New
+	var result sql.Result
+	var err error
+	if swap.Old == nil {
+		result, err = d.db.ExecContext(ctx, `
+			INSERT INTO system_setting (name, value, description)
+			VALUES ($1, $2, $3)
+			ON CONFLICT(name) DO NOTHING`,
+			setting.Name, setting.Value, setting.Description)
+	} else {
+		result, err = d.db.ExecContext(ctx, `
+			UPDATE system_setting
+			SET value = $1, description = $2
+			WHERE name = $3 AND value = $4`,
+			setting.Value, setting.Description, setting.Name, *swap.Old)
+	}
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 1 {
+		if _, err := d.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", instanceSettingChannel, setting.Name); err != nil {
+			return false, err
+		}
+	}
+	return affected == 1, nil
+}
+
 func (d *Driver) ListInstanceSettings(ctx context.Context, find *store.FindInstanceSetting) ([]*store.InstanceSetting, error) {
 	where, args := []string{"1 = 1"}, []any{}
 	if find.Name != "" {
@@ -68,6 +113,100 @@ func (d *Driver) ListInstanceSettings(ctx context.Context, find *store.FindInsta
 
 func (d *Driver) DeleteInstanceSetting(ctx context.Context, delete *store.DeleteInstanceSetting) error {
 	stmt := "DELETE FROM system_setting WHERE name = $1"
-	_, err := d.db.ExecContext(ctx, stmt, delete.Name)
+	if _, err := d.db.ExecContext(ctx, stmt, delete.Name); err != nil {
+		return err
+	}
+	_, err := d.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", instanceSettingChannel, delete.Name)
 	return err
 }
+
+// ListenInstanceSettings calls changed for every notification on instanceSettingChannel, and
+// after reconnecting since notifications may have been missed meanwhile.
+func (d *Driver) ListenInstanceSettings(ctx context.Context, changed func()) error {
+	listener := pq.NewListener(d.profile.DSN, time.Second, time.Minute, nil)
+	defer listener.Close()
+	if err := listener.Listen(instanceSettingChannel); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-listener.Notify:
+			// A nil notification follows a reconnect.
+			changed()
+		case <-ticker.C:
+			// Detects a broken connection that would otherwise go unnoticed while idle.
+			go listener.Ping()
+		}
+	}
+}
+
+// SetupInstance runs the setup in one transaction. A concurrent setup blocks on the
+// insert of the SETUP setting until this one commits and then skips it.
+func (d *Driver) SetupInstance(ctx context.Context, setup *store.SetupInstance) (*store.User, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO system_setting (name, value, description)
+		SELECT $1, $2, $3
+		WHERE NOT EXISTS (SELECT 1 FROM users WHERE role = $4)
+		ON CONFLICT(name) DO NOTHING`,
+		setup.Setup.Name, setup.Setup.Value, setup.Setup.Description, store.RoleAdmin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record setup: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return nil, err
+	}
+
+	now := time.Now()
+	admin := setup.Admin
+	var id int64
+	err = tx.QueryRowContext(ctx,
+		`INSERT INTO users (username, nickname, password, phone, email, role, email_verified, password_expires, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`,
+		admin.Username, admin.Nickname, admin.Password, admin.Phone, admin.Email, admin.Role, admin.EmailVerified, admin.PasswordExpires, now, now).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	for _, setting := range setup.Settings {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO system_setting (name, value, description)
+			VALUES ($1, $2, $3)
+			ON CONFLICT(name) DO UPDATE
+			SET
+				value = EXCLUDED.value,
+				description = EXCLUDED.description`,
+			setting.Name, setting.Value, setting.Description); err != nil {
+			return nil, fmt.Errorf("failed to upsert instance setting: %w", err)
+		}
+	}
+	// Delivered on commit.
+	if _, err := tx.ExecContext(ctx, "SELECT pg_notify($1, $2)", instanceSettingChannel, setup.Setup.Name); err != nil {
+		return nil, fmt.Errorf("failed to notify instance setting change: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &store.User{
+		ID:              id,
+		Username:        admin.Username,
+		Nickname:        admin.Nickname,
+		Password:        admin.Password,
+		Phone:           admin.Phone,
+		Email:           admin.Email,
+		Role:            admin.Role,
+		EmailVerified:   admin.EmailVerified,
+		PasswordExpires: admin.PasswordExpires,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, nil
+}
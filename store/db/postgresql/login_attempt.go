@@ -0,0 +1,77 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pixb/go-server/store"
+)
+
+func (d *Driver) RecordLoginFailure(ctx context.Context, record *store.RecordLoginFailure) (*store.LoginAttempt, error) {
+	var attempt store.LoginAttempt
+	err := d.db.QueryRowContext(ctx,
+		`INSERT INTO login_attempts (scope, identifier, failed_count, last_failed_at) VALUES ($1, $2, 1, $3)
+		ON CONFLICT (scope, identifier) DO UPDATE SET
+			failed_count = CASE WHEN login_attempts.last_failed_at < $4 THEN 1 ELSE login_attempts.failed_count + 1 END,
+			last_failed_at = EXCLUDED.last_failed_at
+		RETURNING id, scope, identifier, failed_count, last_failed_at, locked_until`,
+		record.Scope, record.Identifier, time.Now(), record.ResetBefore).Scan(
+		&attempt.ID, &attempt.Scope, &attempt.Identifier, &attempt.FailedCount, &attempt.LastFailedAt, &attempt.LockedUntil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record login failure: %w", err)
+	}
+	return &attempt, nil
+}
+
+func (d *Driver) UpdateLoginAttempt(ctx context.Context, update *store.UpdateLoginAttempt) (*store.LoginAttempt, error) {
+	var attempt store.LoginAttempt
+	err := d.db.QueryRowContext(ctx,
+		`UPDATE login_attempts SET locked_until = $1 WHERE id = $2 RETURNING id, scope, identifier, failed_count, last_failed_at, locked_until`,
+		update.LockedUntil, update.ID).Scan(
+		&attempt.ID, &attempt.Scope, &attempt.Identifier, &attempt.FailedCount, &attempt.LastFailedAt, &attempt.LockedUntil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update login attempt: %w", err)
+	}
+	return &attempt, nil
+}
+
+func (d *Driver) ListLoginAttempts(ctx context.Context, find *store.FindLoginAttempt) ([]*store.LoginAttempt, error) {
+	query := `SELECT id, scope, identifier, failed_count, last_failed_at, locked_until FROM login_attempts WHERE 1 = 1`
+	args := []interface{}{}
+
+	if find.Scope != nil {
+		query += fmt.Sprintf(" AND scope = $%d", len(args)+1)
+		args = append(args, *find.Scope)
+	}
+	if find.Identifier != nil {
+		query += fmt.Sprintf(" AND identifier = $%d", len(args)+1)
+		args = append(args, *find.Identifier)
+	}
+	query += " ORDER BY id ASC"
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list login attempts: %w", err)
+	}
+	defer rows.Close()
+
+	var attempts []*store.LoginAttempt
+	for rows.Next() {
+		var attempt store.LoginAttempt
+		if err := rows.Scan(&attempt.ID, &attempt.Scope, &attempt.Identifier, &attempt.FailedCount, &attempt.LastFailedAt, &attempt.LockedUntil); err != nil {
+			return nil, fmt.Errorf("failed to scan login attempt: %w", err)
+		}
+		attempts = append(attempts, &attempt)
+	}
+
+	return attempts, nil
+}
+
+func (d *Driver) DeleteLoginAttempts(ctx context.Context, delete *store.DeleteLoginAttempt) error {
+	_, err := d.db.ExecContext(ctx, `DELETE FROM login_attempts WHERE scope = $1 AND identifier = $2`, delete.Scope, delete.Identifier)
+	if err != nil {
+		return fmt.Errorf("failed to delete login attempts: %w", err)
+	}
+	return nil
+}
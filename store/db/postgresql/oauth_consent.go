@@ -0,0 +1,74 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pixb/go-server/store"
+)
+
+func (d *Driver) UpsertOAuthConsent(ctx context.Context, upsert *store.UpsertOAuthConsent) (*store.OAuthConsent, error) {
+	now := time.Now()
+	var consent store.OAuthConsent
+	var scopes string
+	err := d.db.QueryRowContext(ctx,
+		`INSERT INTO oauth_consents (user_id, client_id, scopes, created_at, updated_at) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, client_id) DO UPDATE SET
+			scopes = EXCLUDED.scopes,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id, user_id, client_id, scopes, created_at, updated_at`,
+		upsert.UserID, upsert.ClientID, strings.Join(upsert.Scopes, " "), now, now).Scan(
+		&consent.ID, &consent.UserID, &consent.ClientID, &scopes, &consent.CreatedAt, &consent.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert oauth consent: %w", err)
+	}
+	consent.Scopes = strings.Fields(scopes)
+	return &consent, nil
+}
+
+func (d *Driver) ListOAuthConsents(ctx context.Context, find *store.FindOAuthConsent) ([]*store.OAuthConsent, error) {
+	query := "SELECT id, user_id, client_id, scopes, created_at, updated_at FROM oauth_consents WHERE 1 = 1"
+	args := []interface{}{}
+
+	if find.UserID != nil {
+		query += fmt.Sprintf(" AND user_id = $%d", len(args)+1)
+		args = append(args, *find.UserID)
+	}
+	if find.ClientID != nil {
+		query += fmt.Sprintf(" AND client_id = $%d", len(args)+1)
+		args = append(args, *find.ClientID)
+	}
+	query += " ORDER BY id"
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list oauth consents: %w", err)
+	}
+	defer rows.Close()
+
+	var consents []*store.OAuthConsent
+	for rows.Next() {
+		var consent store.OAuthConsent
+		var scopes string
+		if err := rows.Scan(&consent.ID, &consent.UserID, &consent.ClientID, &scopes, &consent.CreatedAt, &consent.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan oauth consent: %w", err)
+		}
+		consent.Scopes = strings.Fields(scopes)
+		consents = append(consents, &consent)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list oauth consents: %w", err)
+	}
+
+	return consents, nil
+}
+
+func (d *Driver) DeleteOAuthConsent(ctx context.Context, delete *store.DeleteOAuthConsent) error {
+	_, err := d.db.ExecContext(ctx, "DELETE FROM oauth_consents WHERE user_id = $1 AND client_id = $2", delete.UserID, delete.ClientID)
+	if err != nil {
+		return fmt.Errorf("failed to delete oauth consent: %w", err)
+	}
+	return nil
+}
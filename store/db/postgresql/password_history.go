@@ -0,0 +1,62 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pixb/go-server/store"
+)
+
+func (d *Driver) CreatePasswordHistory(ctx context.Context, create *store.CreatePasswordHistory) (*store.PasswordHistory, error) {
+	now := time.Now()
+	var id int64
+	err := d.db.QueryRowContext(ctx,
+		`INSERT INTO password_histories (user_id, password_hash, created_at) VALUES ($1, $2, $3) RETURNING id`,
+		create.UserID, create.PasswordHash, now).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create password history: %w", err)
+	}
+
+	return &store.PasswordHistory{
+		ID:           id,
+		UserID:       create.UserID,
+		PasswordHash: create.PasswordHash,
+		CreatedAt:    now,
+	}, nil
+}
+
+func (d *Driver) ListPasswordHistories(ctx context.Context, find *store.FindPasswordHistory) ([]*store.PasswordHistory, error) {
+	query := `SELECT id, user_id, password_hash, created_at FROM password_histories WHERE user_id = $1 ORDER BY id DESC`
+	args := []interface{}{find.UserID}
+
+	if find.Limit > 0 {
+		query += " LIMIT $2"
+		args = append(args, find.Limit)
+	}
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list password histories: %w", err)
+	}
+	defer rows.Close()
+
+	var histories []*store.PasswordHistory
+	for rows.Next() {
+		var history store.PasswordHistory
+		if err := rows.Scan(&history.ID, &history.UserID, &history.PasswordHash, &history.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan password history: %w", err)
+		}
+		histories = append(histories, &history)
+	}
+
+	return histories, nil
+}
+
+func (d *Driver) DeletePasswordHistories(ctx context.Context, delete *store.DeletePasswordHistory) error {
+	_, err := d.db.ExecContext(ctx, `DELETE FROM password_histories WHERE user_id = $1 AND id < $2`, delete.UserID, delete.BeforeID)
+	if err != nil {
+		return fmt.Errorf("failed to delete password histories: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pixb/go-server/store"
+)
+
+func (d *Driver) CreatePasswordResetToken(ctx context.Context, create *store.CreatePasswordResetToken) (*store.PasswordResetToken, error) {
+	now := time.Now()
+	var id int64
+	err := d.db.QueryRowContext(ctx,
+		"INSERT INTO password_reset_tokens (user_id, token_hash, expires_at, created_at) VALUES ($1, $2, $3, $4) RETURNING id",
+		create.UserID, create.TokenHash, create.ExpiresAt, now).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create password reset token: %w", err)
+	}
+
+	return &store.PasswordResetToken{
+		ID:        id,
+		UserID:    create.UserID,
+		TokenHash: create.TokenHash,
+		ExpiresAt: create.ExpiresAt,
+		CreatedAt: now,
+	}, nil
+}
+
+func (d *Driver) ListPasswordResetTokens(ctx context.Context, find *store.FindPasswordResetToken) ([]*store.PasswordResetToken, error) {
+	query := "SELECT id, user_id, token_hash, expires_at, used_at, created_at FROM password_reset_tokens WHERE 1 = 1"
+	args := []interface{}{}
+
+	if find.ID != nil {
+		query += fmt.Sprintf(" AND id = $%d", len(args)+1)
+		args = append(args, *find.ID)
+	}
+	if find.UserID != nil {
+		query += fmt.Sprintf(" AND user_id = $%d", len(args)+1)
+		args = append(args, *find.UserID)
+	}
+	if find.TokenHash != nil {
+		query += fmt.Sprintf(" AND token_hash = $%d", len(args)+1)
+		args = append(args, *find.TokenHash)
+	}
+	query += " ORDER BY id DESC"
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list password reset tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []*store.PasswordResetToken
+	for rows.Next() {
+		var token store.PasswordResetToken
+		if err := rows.Scan(&token.ID, &token.UserID, &token.TokenHash, &token.ExpiresAt, &token.UsedAt, &token.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan password reset token: %w", err)
+		}
+		tokens = append(tokens, &token)
+	}
+
+	return tokens, nil
+}
+
+func (d *Driver) UsePasswordResetToken(ctx context.Context, id int64) (bool, error) {
+	result, err := d.db.ExecContext(ctx, "UPDATE password_reset_tokens SET used_at = $1 WHERE id = $2 AND used_at IS NULL", time.Now(), id)
+	if err != nil {
+		return false, fmt.Errorf("failed to use password reset token: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected == 1, nil
+}
+
+func (d *Driver) DeletePasswordResetTokens(ctx context.Context, delete *store.DeletePasswordResetToken) error {
+	_, err := d.db.ExecContext(ctx, "DELETE FROM password_reset_tokens WHERE user_id = $1", delete.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to delete password reset tokens: %w", err)
+	}
+	return nil
+}
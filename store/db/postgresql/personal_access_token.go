@@ -0,0 +1,99 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pixb/go-server/store"
+)
+
+func (d *Driver) CreatePersonalAccessToken(ctx context.Context, create *store.CreatePersonalAccessToken) (*store.PersonalAccessToken, error) {
+	var id int64
+	now := time.Now()
+	err := d.db.QueryRowContext(ctx,
+		`INSERT INTO personal_access_tokens (user_id, token_hash, description, expires_at, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+		create.UserID, create.TokenHash, create.Description, create.ExpiresAt, now, now).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create personal access token: %w", err)
+	}
+
+	return &store.PersonalAccessToken{
+		ID:          id,
+		UserID:      create.UserID,
+		TokenHash:   create.TokenHash,
+		Description: create.Description,
+		ExpiresAt:   create.ExpiresAt,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+func (d *Driver) UpdatePersonalAccessToken(ctx context.Context, update *store.UpdatePersonalAccessToken) (*store.PersonalAccessToken, error) {
+	query := `UPDATE personal_access_tokens SET updated_at = $1`
+	args := []interface{}{time.Now()}
+	argCount := 1
+
+	if update.LastUsedAt != nil {
+		argCount++
+		query += fmt.Sprintf(", last_used_at = $%d", argCount)
+		args = append(args, *update.LastUsedAt)
+	}
+
+	argCount++
+	query += fmt.Sprintf(" WHERE id = $%d AND deleted_at IS NULL RETURNING id, user_id, token_hash, description, expires_at, last_used_at, created_at, updated_at", argCount)
+	args = append(args, update.ID)
+
+	var token store.PersonalAccessToken
+	err := d.db.QueryRowContext(ctx, query, args...).Scan(
+		&token.ID, &token.UserID, &token.TokenHash, &token.Description, &token.ExpiresAt, &token.LastUsedAt, &token.CreatedAt, &token.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update personal access token: %w", err)
+	}
+
+	return &token, nil
+}
+
+func (d *Driver) ListPersonalAccessTokens(ctx context.Context, find *store.FindPersonalAccessToken) ([]*store.PersonalAccessToken, error) {
+	query := `SELECT id, user_id, token_hash, description, expires_at, last_used_at, created_at, updated_at, deleted_at FROM personal_access_tokens WHERE deleted_at IS NULL`
+	args := []interface{}{}
+
+	if find.ID != nil {
+		args = append(args, *find.ID)
+		query += fmt.Sprintf(" AND id = $%d", len(args))
+	}
+	if find.UserID != nil {
+		args = append(args, *find.UserID)
+		query += fmt.Sprintf(" AND user_id = $%d", len(args))
+	}
+	if find.TokenHash != nil {
+		args = append(args, *find.TokenHash)
+		query += fmt.Sprintf(" AND token_hash = $%d", len(args))
+	}
+	query += " ORDER BY created_at DESC"
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list personal access tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []*store.PersonalAccessToken
+	for rows.Next() {
+		var token store.PersonalAccessToken
+		if err := rows.Scan(&token.ID, &token.UserID, &token.TokenHash, &token.Description, &token.ExpiresAt, &token.LastUsedAt, &token.CreatedAt, &token.UpdatedAt, &token.DeletedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan personal access token: %w", err)
+		}
+		tokens = append(tokens, &token)
+	}
+
+	return tokens, nil
+}
+
+func (d *Driver) DeletePersonalAccessToken(ctx context.Context, delete *store.DeletePersonalAccessToken) error {
+	_, err := d.db.ExecContext(ctx, `UPDATE personal_access_tokens SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL`, time.Now(), delete.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete personal access token: %w", err)
+	}
+	return nil
+}
@@ -13,8 +13,8 @@ func (d *Driver) CreateRefreshToken(ctx context.Context, create *store.CreateRef
 	var id int64
 	now := time.Now()
 	err := d.db.QueryRowContext(ctx,
-		`INSERT INTO refresh_tokens (user_id, token, expires_at, revoked, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
-		create.UserID, create.Token, create.ExpiresAt, false, now, now).Scan(&id)
+		`INSERT INTO refresh_tokens (user_id, token, family_id, user_agent, ip_address, expires_at, revoked, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`,
+		create.UserID, create.Token, create.FamilyID, create.UserAgent, create.IPAddress, create.ExpiresAt, false, now, now).Scan(&id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create refresh token: %w", err)
 	}
@@ -23,6 +23,9 @@ func (d *Driver) CreateRefreshToken(ctx context.Context, create *store.CreateRef
 		ID:        id,
 		UserID:    create.UserID,
 		Token:     create.Token,
+		FamilyID:  create.FamilyID,
+		UserAgent: create.UserAgent,
+		IPAddress: create.IPAddress,
 		ExpiresAt: create.ExpiresAt,
 		Revoked:   false,
 		CreatedAt: now,
@@ -42,12 +45,12 @@ func (d *Driver) UpdateRefreshToken(ctx context.Context, update *store.UpdateRef
 	}
 
 	argCount++
-	query += fmt.Sprintf(" WHERE id = $%d AND deleted_at IS NULL RETURNING id, user_id, token, expires_at, revoked, created_at, updated_at", argCount)
+	query += fmt.Sprintf(" WHERE id = $%d AND deleted_at IS NULL RETURNING id, user_id, token, family_id, user_agent, ip_address, expires_at, revoked, created_at, updated_at", argCount)
 	args = append(args, update.ID)
 
 	var token store.RefreshToken
 	err := d.db.QueryRowContext(ctx, query, args...).Scan(
-		&token.ID, &token.UserID, &token.Token, &token.ExpiresAt, &token.Revoked, &token.CreatedAt, &token.UpdatedAt)
+		&token.ID, &token.UserID, &token.Token, &token.FamilyID, &token.UserAgent, &token.IPAddress, &token.ExpiresAt, &token.Revoked, &token.CreatedAt, &token.UpdatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update refresh token: %w", err)
 	}
@@ -55,8 +58,21 @@ func (d *Driver) UpdateRefreshToken(ctx context.Context, update *store.UpdateRef
 	return &token, nil
 }
 
+// RevokeRefreshToken revokes the token unless it is already revoked, it reports whether it did.
+func (d *Driver) RevokeRefreshToken(ctx context.Context, id int64) (bool, error) {
+	result, err := d.db.ExecContext(ctx, "UPDATE refresh_tokens SET revoked = $1, updated_at = $2 WHERE id = $3 AND revoked = $4 AND deleted_at IS NULL", true, time.Now(), id, false)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return affected == 1, nil
+}
+
 func (d *Driver) ListRefreshTokens(ctx context.Context, find *store.FindRefreshToken) ([]*store.RefreshToken, error) {
-	query := `SELECT id, user_id, token, expires_at, revoked, created_at, updated_at, deleted_at FROM refresh_tokens WHERE deleted_at IS NULL`
+	query := `SELECT id, user_id, token, family_id, user_agent, ip_address, expires_at, revoked, created_at, updated_at, deleted_at FROM refresh_tokens WHERE deleted_at IS NULL`
 	args := []interface{}{}
 
 	if find.ID != nil {
@@ -71,6 +87,10 @@ func (d *Driver) ListRefreshTokens(ctx context.Context, find *store.FindRefreshT
 		args = append(args, *find.Token)
 		query += fmt.Sprintf(" AND token = $%d", len(args))
 	}
+	if find.FamilyID != nil {
+		args = append(args, *find.FamilyID)
+		query += fmt.Sprintf(" AND family_id = $%d", len(args))
+	}
 
 	rows, err := d.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -82,7 +102,7 @@ func (d *Driver) ListRefreshTokens(ctx context.Context, find *store.FindRefreshT
 	for rows.Next() {
 		var token store.RefreshToken
 		var deletedAt *time.Time
-		if err := rows.Scan(&token.ID, &token.UserID, &token.Token, &token.ExpiresAt, &token.Revoked, &token.CreatedAt, &token.UpdatedAt, &deletedAt); err != nil {
+		if err := rows.Scan(&token.ID, &token.UserID, &token.Token, &token.FamilyID, &token.UserAgent, &token.IPAddress, &token.ExpiresAt, &token.Revoked, &token.CreatedAt, &token.UpdatedAt, &deletedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan refresh token: %w", err)
 		}
 		token.DeletedAt = deletedAt
@@ -104,8 +124,8 @@ func (d *Driver) GetRefreshToken(ctx context.Context, token string) (*store.Refr
 	var refreshToken store.RefreshToken
 	var deletedAt *time.Time
 	err := d.db.QueryRowContext(ctx,
-		`SELECT id, user_id, token, expires_at, revoked, created_at, updated_at, deleted_at FROM refresh_tokens WHERE token = $1 AND deleted_at IS NULL`,
-		token).Scan(&refreshToken.ID, &refreshToken.UserID, &refreshToken.Token, &refreshToken.ExpiresAt, &refreshToken.Revoked, &refreshToken.CreatedAt, &refreshToken.UpdatedAt, &deletedAt)
+		`SELECT id, user_id, token, family_id, user_agent, ip_address, expires_at, revoked, created_at, updated_at, deleted_at FROM refresh_tokens WHERE token = $1 AND deleted_at IS NULL`,
+		token).Scan(&refreshToken.ID, &refreshToken.UserID, &refreshToken.Token, &refreshToken.FamilyID, &refreshToken.UserAgent, &refreshToken.IPAddress, &refreshToken.ExpiresAt, &refreshToken.Revoked, &refreshToken.CreatedAt, &refreshToken.UpdatedAt, &deletedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -120,8 +140,8 @@ func (d *Driver) GetRefreshTokenByID(ctx context.Context, id int64) (*store.Refr
 	var refreshToken store.RefreshToken
 	var deletedAt *time.Time
 	err := d.db.QueryRowContext(ctx,
-		`SELECT id, user_id, token, expires_at, revoked, created_at, updated_at, deleted_at FROM refresh_tokens WHERE id = $1 AND deleted_at IS NULL`,
-		id).Scan(&refreshToken.ID, &refreshToken.UserID, &refreshToken.Token, &refreshToken.ExpiresAt, &refreshToken.Revoked, &refreshToken.CreatedAt, &refreshToken.UpdatedAt, &deletedAt)
+		`SELECT id, user_id, token, family_id, user_agent, ip_address, expires_at, revoked, created_at, updated_at, deleted_at FROM refresh_tokens WHERE id = $1 AND deleted_at IS NULL`,
+		id).Scan(&refreshToken.ID, &refreshToken.UserID, &refreshToken.Token, &refreshToken.FamilyID, &refreshToken.UserAgent, &refreshToken.IPAddress, &refreshToken.ExpiresAt, &refreshToken.Revoked, &refreshToken.CreatedAt, &refreshToken.UpdatedAt, &deletedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
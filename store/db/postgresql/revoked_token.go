@@ -0,0 +1,65 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pixb/go-server/store"
+)
+
+func (d *Driver) CreateRevokedToken(ctx context.Context, create *store.CreateRevokedToken) (*store.RevokedToken, error) {
+	now := time.Now()
+	_, err := d.db.ExecContext(ctx,
+		`INSERT INTO revoked_tokens (jti, user_id, expires_at, created_at) VALUES ($1, $2, $3, $4) ON CONFLICT (jti) DO NOTHING`,
+		create.JTI, create.UserID, create.ExpiresAt, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create revoked token: %w", err)
+	}
+
+	return &store.RevokedToken{
+		JTI:       create.JTI,
+		UserID:    create.UserID,
+		ExpiresAt: create.ExpiresAt,
+		CreatedAt: now,
+	}, nil
+}
+
+func (d *Driver) ListRevokedTokens(ctx context.Context, find *store.FindRevokedToken) ([]*store.RevokedToken, error) {
+	query := `SELECT id, jti, user_id, expires_at, created_at FROM revoked_tokens WHERE 1 = 1`
+	args := []interface{}{}
+
+	if find.JTI != nil {
+		args = append(args, *find.JTI)
+		query += fmt.Sprintf(" AND jti = $%d", len(args))
+	}
+	if find.UserID != nil {
+		args = append(args, *find.UserID)
+		query += fmt.Sprintf(" AND user_id = $%d", len(args))
+	}
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revoked tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []*store.RevokedToken
+	for rows.Next() {
+		var token store.RevokedToken
+		if err := rows.Scan(&token.ID, &token.JTI, &token.UserID, &token.ExpiresAt, &token.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan revoked token: %w", err)
+		}
+		tokens = append(tokens, &token)
+	}
+
+	return tokens, nil
+}
+
+func (d *Driver) DeleteRevokedTokens(ctx context.Context, delete *store.DeleteRevokedToken) error {
+	_, err := d.db.ExecContext(ctx, `DELETE FROM revoked_tokens WHERE expires_at < $1`, delete.ExpiresBefore)
+	if err != nil {
+		return fmt.Errorf("failed to delete revoked tokens: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pixb/go-server/store"
+)
+
+func (d *Driver) CreateRoleDefinition(ctx context.Context, create *store.CreateRoleDefinition) (*store.RoleDefinition, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	var id int64
+	err = tx.QueryRowContext(ctx,
+		`INSERT INTO roles (name, description, built_in, created_at, updated_at) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		create.Name, create.Description, false, now, now).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create role: %w", err)
+	}
+	if err := insertRolePermissions(ctx, tx, id, create.Permissions); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &store.RoleDefinition{
+		ID:          id,
+		Name:        create.Name,
+		Description: create.Description,
+		Permissions: create.Permissions,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+func (d *Driver) UpdateRoleDefinition(ctx context.Context, update *store.UpdateRoleDefinition) (*store.RoleDefinition, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := "UPDATE roles SET updated_at = $1"
+	args := []interface{}{time.Now()}
+	if update.Description != nil {
+		args = append(args, *update.Description)
+		query += fmt.Sprintf(", description = $%d", len(args))
+	}
+	args = append(args, update.ID)
+	query += fmt.Sprintf(" WHERE id = $%d", len(args))
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	} else if affected == 0 {
+		return nil, fmt.Errorf("failed to update role: %w", sql.ErrNoRows)
+	}
+
+	if update.Permissions != nil {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM role_permissions WHERE role_id = $1", update.ID); err != nil {
+			return nil, fmt.Errorf("failed to update role permissions: %w", err)
+		}
+		if err := insertRolePermissions(ctx, tx, update.ID, update.Permissions); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	roles, err := d.ListRoleDefinitions(ctx, &store.FindRoleDefinition{ID: &update.ID})
+	if err != nil {
+		return nil, err
+	}
+	if len(roles) == 0 {
+		return nil, errors.New("role not found after update")
+	}
+	return roles[0], nil
+}
+
+func (d *Driver) ListRoleDefinitions(ctx context.Context, find *store.FindRoleDefinition) ([]*store.RoleDefinition, error) {
+	query := `SELECT r.id, r.name, r.description, r.built_in, r.created_at, r.updated_at, rp.permission
+		FROM roles r LEFT JOIN role_permissions rp ON rp.role_id = r.id WHERE 1 = 1`
+	args := []interface{}{}
+
+	if find.ID != nil {
+		args = append(args, *find.ID)
+		query += fmt.Sprintf(" AND r.id = $%d", len(args))
+	}
+	if find.Name != nil {
+		args = append(args, *find.Name)
+		query += fmt.Sprintf(" AND r.name = $%d", len(args))
+	}
+	if find.UserID != nil {
+		args = append(args, *find.UserID)
+		query += fmt.Sprintf(" AND r.id IN (SELECT role_id FROM user_roles WHERE user_id = $%d)", len(args))
+	}
+	query += " ORDER BY r.id, rp.permission"
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	defer rows.Close()
+
+	var roles []*store.RoleDefinition
+	for rows.Next() {
+		var role store.RoleDefinition
+		var permission sql.NullString
+		if err := rows.Scan(&role.ID, &role.Name, &role.Description, &role.BuiltIn, &role.CreatedAt, &role.UpdatedAt, &permission); err != nil {
+			return nil, fmt.Errorf("failed to scan role: %w", err)
+		}
+		// A role spans one row per permission.
+		if len(roles) == 0 || roles[len(roles)-1].ID != role.ID {
+			roles = append(roles, &role)
+		}
+		if permission.Valid {
+			last := roles[len(roles)-1]
+			last.Permissions = append(last.Permissions, store.Permission(permission.String))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+func (d *Driver) DeleteRoleDefinition(ctx context.Context, delete *store.DeleteRoleDefinition) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range []string{
+		"DELETE FROM user_roles WHERE role_id = $1",
+		"DELETE FROM role_permissions WHERE role_id = $1",
+		"DELETE FROM roles WHERE id = $1",
+	} {
+		if _, err := tx.ExecContext(ctx, stmt, delete.ID); err != nil {
+			return fmt.Errorf("failed to delete role: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (d *Driver) SetUserRoles(ctx context.Context, set *store.SetUserRoles) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM user_roles WHERE user_id = $1", set.UserID); err != nil {
+		return fmt.Errorf("failed to set user roles: %w", err)
+	}
+	now := time.Now()
+	for _, roleID := range set.RoleIDs {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO user_roles (user_id, role_id, created_at) VALUES ($1, $2, $3)",
+			set.UserID, roleID, now); err != nil {
+			return fmt.Errorf("failed to set user roles: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func insertRolePermissions(ctx context.Context, tx *sql.Tx, roleID int64, permissions []store.Permission) error {
+	for _, permission := range permissions {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO role_permissions (role_id, permission) VALUES ($1, $2)",
+			roleID, permission); err != nil {
+			return fmt.Errorf("failed to create role permission: %w", err)
+		}
+	}
+	return nil
+}
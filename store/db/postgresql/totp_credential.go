@@ -0,0 +1,149 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pixb/go-server/store"
+)
+
+func (d *Driver) CreateTOTPCredential(ctx context.Context, create *store.CreateTOTPCredential) (*store.TOTPCredential, error) {
+	var id int64
+	now := time.Now()
+	err := d.db.QueryRowContext(ctx,
+		`INSERT INTO totp_credentials (user_id, secret, recovery_codes, created_at, updated_at) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+		create.UserID, create.Secret, create.RecoveryCodes, now, now).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create totp credential: %w", err)
+	}
+
+	return &store.TOTPCredential{
+		ID:            id,
+		UserID:        create.UserID,
+		Secret:        create.Secret,
+		RecoveryCodes: create.RecoveryCodes,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}, nil
+}
+
+func (d *Driver) UpdateTOTPCredential(ctx context.Context, update *store.UpdateTOTPCredential) (*store.TOTPCredential, error) {
+	query := `UPDATE totp_credentials SET updated_at = $1`
+	args := []interface{}{time.Now()}
+	argCount := 1
+
+	if update.Secret != nil {
+		argCount++
+		query += fmt.Sprintf(", secret = $%d", argCount)
+		args = append(args, *update.Secret)
+	}
+	if update.RecoveryCodes != nil {
+		argCount++
+		query += fmt.Sprintf(", recovery_codes = $%d", argCount)
+		args = append(args, *update.RecoveryCodes)
+	}
+	if update.Enabled != nil {
+		argCount++
+		query += fmt.Sprintf(", enabled = $%d", argCount)
+		args = append(args, *update.Enabled)
+	}
+	if update.LastUsedStep != nil {
+		argCount++
+		query += fmt.Sprintf(", last_used_step = $%d", argCount)
+		args = append(args, *update.LastUsedStep)
+	}
+
+	argCount++
+	query += fmt.Sprintf(" WHERE id = $%d RETURNING id, user_id, secret, recovery_codes, enabled, last_used_step, created_at, updated_at", argCount)
+	args = append(args, update.ID)
+
+	var credential store.TOTPCredential
+	err := d.db.QueryRowContext(ctx, query, args...).Scan(
+		&credential.ID, &credential.UserID, &credential.Secret, &credential.RecoveryCodes, &credential.Enabled, &credential.LastUsedStep, &credential.CreatedAt, &credential.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update totp credential: %w", err)
+	}
+
+	return &credential, nil
+}
+
+func (d *Driver) ListTOTPCredentials(ctx context.Context, find *store.FindTOTPCredential) ([]*store.TOTPCredential, error) {
+	query := `SELECT id, user_id, secret, recovery_codes, enabled, last_used_step, created_at, updated_at FROM totp_credentials WHERE 1 = 1`
+	args := []interface{}{}
+
+	if find.ID != nil {
+		query += fmt.Sprintf(" AND id = $%d", len(args)+1)
+		args = append(args, *find.ID)
+	}
+	if find.UserID != nil {
+		query += fmt.Sprintf(" AND user_id = $%d", len(args)+1)
+		args = append(args, *find.UserID)
+	}
+	if find.Enabled != nil {
+		query += fmt.Sprintf(" AND enabled = $%d", len(args)+1)
+		args = append(args, *find.Enabled)
+	}
+	query += " ORDER BY id ASC"
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list totp credentials: %w", err)
+	}
+	defer rows.Close()
+
+	var credentials []*store.TOTPCredential
+	for rows.Next() {
+		var credential store.TOTPCredential
+		if err := rows.Scan(&credential.ID, &credential.UserID, &credential.Secret, &credential.RecoveryCodes, &credential.Enabled, &credential.LastUsedStep, &credential.CreatedAt, &credential.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan totp credential: %w", err)
+		}
+		credentials = append(credentials, &credential)
+	}
+
+	return credentials, nil
+}
+
+func (d *Driver) DeleteTOTPCredential(ctx context.Context, delete *store.DeleteTOTPCredential) error {
+	_, err := d.db.ExecContext(ctx, `DELETE FROM totp_credentials WHERE user_id = $1`, delete.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to delete totp credential: %w", err)
+	}
+	return nil
+}
+
+// ReencryptTOTPCredentials updates the credentials and upserts the setting in one transaction.
+func (d *Driver) ReencryptTOTPCredentials(ctx context.Context, reencrypt *store.ReencryptTOTPCredentials) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	for _, update := range reencrypt.Credentials {
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE totp_credentials SET secret = COALESCE($1, secret), recovery_codes = COALESCE($2, recovery_codes), updated_at = $3 WHERE id = $4`,
+			update.Secret, update.RecoveryCodes, now, update.ID); err != nil {
+			return fmt.Errorf("failed to update totp credential: %w", err)
+		}
+	}
+	setting := reencrypt.Setting
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO system_setting (name, value, description)
+		VALUES ($1, $2, $3)
+		ON CONFLICT(name) DO UPDATE
+		SET
+			value = EXCLUDED.value,
+			description = EXCLUDED.description`,
+		setting.Name, setting.Value, setting.Description); err != nil {
+		return fmt.Errorf("failed to upsert instance setting: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "SELECT pg_notify($1, $2)", instanceSettingChannel, setting.Name); err != nil {
+		return fmt.Errorf("failed to notify instance setting change: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
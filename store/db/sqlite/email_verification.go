@@ -0,0 +1,77 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pixb/go-server/store"
+)
+
+func (d *Driver) CreateEmailVerification(ctx context.Context, create *store.CreateEmailVerification) (*store.EmailVerification, error) {
+	now := time.Now()
+	result, err := d.db.ExecContext(ctx,
+		"INSERT INTO email_verifications (user_id, email, token_hash, expires_at, created_at) VALUES (?, ?, ?, ?, ?)",
+		create.UserID, create.Email, create.TokenHash, create.ExpiresAt, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create email verification: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	return &store.EmailVerification{
+		ID:        id,
+		UserID:    create.UserID,
+		Email:     create.Email,
+		TokenHash: create.TokenHash,
+		ExpiresAt: create.ExpiresAt,
+		CreatedAt: now,
+	}, nil
+}
+
+func (d *Driver) ListEmailVerifications(ctx context.Context, find *store.FindEmailVerification) ([]*store.EmailVerification, error) {
+	query := "SELECT id, user_id, email, token_hash, expires_at, created_at FROM email_verifications WHERE 1 = 1"
+	args := []interface{}{}
+
+	if find.UserID != nil {
+		query += " AND user_id = ?"
+		args = append(args, *find.UserID)
+	}
+	if find.Email != nil {
+		query += " AND email = ?"
+		args = append(args, *find.Email)
+	}
+	if find.TokenHash != nil {
+		query += " AND token_hash = ?"
+		args = append(args, *find.TokenHash)
+	}
+	query += " ORDER BY id DESC"
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list email verifications: %w", err)
+	}
+	defer rows.Close()
+
+	var verifications []*store.EmailVerification
+	for rows.Next() {
+		var verification store.EmailVerification
+		if err := rows.Scan(&verification.ID, &verification.UserID, &verification.Email, &verification.TokenHash, &verification.ExpiresAt, &verification.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan email verification: %w", err)
+		}
+		verifications = append(verifications, &verification)
+	}
+
+	return verifications, nil
+}
+
+func (d *Driver) DeleteEmailVerifications(ctx context.Context, delete *store.DeleteEmailVerification) error {
+	_, err := d.db.ExecContext(ctx, "DELETE FROM email_verifications WHERE user_id = ?", delete.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to delete email verifications: %w", err)
+	}
+	return nil
+}
@@ -2,7 +2,10 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pixb/go-server/store"
 )
@@ -25,6 +28,34 @@ func (d *Driver) UpsertInstanceSetting(ctx context.Context, upsert *store.Instan
 	return upsert, nil
 }
 
+// CompareAndSwapInstanceSetting writes the setting only if its stored value is still the old
+// one, or if it is not stored when there is no old value. It reports whether it did.
+func (d *Driver) CompareAndSwapInstanceSetting(ctx context.Context, swap *store.CompareAndSwapInstanceSetting) (bool, error) {
+	setting := swap.New
+	var result sql.Result
+	var err error
+	if swap.Old == nil {
+		result, err = d.db.ExecContext(ctx, `
+			INSERT OR IGNORE INTO system_setting (name, value, description)
+			VALUES (?, ?, ?)`,
+			setting.Name, setting.Value, setting.Description)
+	} else {
+		result, err = d.db.ExecContext(ctx, `
+			UPDATE system_setting
+			SET value = ?, description = ?
+			WHERE name = ? AND value = ?`,
+			setting.Value, setting.Description, setting.Name, *swap.Old)
+	}
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
 func (d *Driver) ListInstanceSettings(ctx context.Context, find *store.FindInstanceSetting) ([]*store.InstanceSetting, error) {
 	where, args := []string{"1 = 1"}, []any{}
 	if find.Name != "" {
@@ -70,3 +101,68 @@ func (d *Driver) DeleteInstanceSetting(ctx context.Context, delete *store.Delete
 	_, err := d.db.ExecContext(ctx, stmt, delete.Name)
 	return err
 }
+
+// SetupInstance runs the setup in one transaction. The conditional insert of the SETUP
+// setting comes first so that the transaction holds the write lock before it looks for
+// an admin, a concurrent setup waits and then finds the setting.
+func (d *Driver) SetupInstance(ctx context.Context, setup *store.SetupInstance) (*store.User, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT OR IGNORE INTO system_setting (name, value, description)
+		SELECT ?, ?, ?
+		WHERE NOT EXISTS (SELECT 1 FROM users WHERE role = ?)`,
+		setup.Setup.Name, setup.Setup.Value, setup.Setup.Description, store.RoleAdmin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record setup: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return nil, err
+	}
+
+	now := time.Now()
+	admin := setup.Admin
+	result, err = tx.ExecContext(ctx,
+		`INSERT INTO users (username, nickname, password, phone, email, role, email_verified, password_expires, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		admin.Username, admin.Nickname, admin.Password, admin.Phone, admin.Email, admin.Role, admin.EmailVerified, admin.PasswordExpires, now, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	for _, setting := range setup.Settings {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO system_setting (name, value, description)
+			VALUES (?, ?, ?)
+			ON CONFLICT(name) DO UPDATE
+			SET
+				value = EXCLUDED.value,
+				description = EXCLUDED.description`,
+			setting.Name, setting.Value, setting.Description); err != nil {
+			return nil, fmt.Errorf("failed to upsert instance setting: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &store.User{
+		ID:              id,
+		Username:        admin.Username,
+		Nickname:        admin.Nickname,
+		Password:        admin.Password,
+		Phone:           admin.Phone,
+		Email:           admin.Email,
+		Role:            admin.Role,
+		EmailVerified:   admin.EmailVerified,
+		PasswordExpires: admin.PasswordExpires,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, nil
+}
@@ -0,0 +1,125 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pixb/go-server/store"
+)
+
+func (d *Driver) CreateInvite(ctx context.Context, create *store.CreateInvite) (*store.Invite, error) {
+	now := time.Now()
+	result, err := d.db.ExecContext(ctx,
+		"INSERT INTO invites (code_hash, role, max_uses, expires_at, creator_id, created_at) VALUES (?, ?, ?, ?, ?, ?)",
+		create.CodeHash, create.Role, create.MaxUses, create.ExpiresAt, create.CreatorID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create invite: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	return &store.Invite{
+		ID:        id,
+		CodeHash:  create.CodeHash,
+		Role:      create.Role,
+		MaxUses:   create.MaxUses,
+		ExpiresAt: create.ExpiresAt,
+		CreatorID: create.CreatorID,
+		CreatedAt: now,
+	}, nil
+}
+
+func (d *Driver) ListInvites(ctx context.Context, find *store.FindInvite) ([]*store.Invite, error) {
+	query := "SELECT id, code_hash, role, max_uses, use_count, expires_at, creator_id, created_at FROM invites WHERE 1 = 1"
+	args := []interface{}{}
+
+	if find.ID != nil {
+		query += " AND id = ?"
+		args = append(args, *find.ID)
+	}
+	if find.CodeHash != nil {
+		query += " AND code_hash = ?"
+		args = append(args, *find.CodeHash)
+	}
+	query += " ORDER BY id DESC"
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invites: %w", err)
+	}
+	defer rows.Close()
+
+	var invites []*store.Invite
+	for rows.Next() {
+		var invite store.Invite
+		if err := rows.Scan(&invite.ID, &invite.CodeHash, &invite.Role, &invite.MaxUses, &invite.UseCount, &invite.ExpiresAt, &invite.CreatorID, &invite.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan invite: %w", err)
+		}
+		invites = append(invites, &invite)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list invites: %w", err)
+	}
+
+	return invites, nil
+}
+
+// UseInvite counts the use first so that the transaction holds the write lock before
+// the user is created.
+func (d *Driver) UseInvite(ctx context.Context, use *store.UseInvite) (*store.User, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	result, err := tx.ExecContext(ctx,
+		"UPDATE invites SET use_count = use_count + 1 WHERE id = ? AND use_count < max_uses AND expires_at > ?",
+		use.ID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to use invite: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return nil, err
+	}
+
+	user := use.User
+	result, err = tx.ExecContext(ctx,
+		`INSERT INTO users (username, nickname, password, phone, email, role, email_verified, password_expires, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		user.Username, user.Nickname, user.Password, user.Phone, user.Email, user.Role, user.EmailVerified, user.PasswordExpires, now, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &store.User{
+		ID:              id,
+		Username:        user.Username,
+		Nickname:        user.Nickname,
+		Password:        user.Password,
+		Phone:           user.Phone,
+		Email:           user.Email,
+		Role:            user.Role,
+		EmailVerified:   user.EmailVerified,
+		PasswordExpires: user.PasswordExpires,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, nil
+}
+
+func (d *Driver) DeleteInvite(ctx context.Context, delete *store.DeleteInvite) error {
+	if _, err := d.db.ExecContext(ctx, "DELETE FROM invites WHERE id = ?", delete.ID); err != nil {
+		return fmt.Errorf("failed to delete invite: %w", err)
+	}
+	return nil
+}
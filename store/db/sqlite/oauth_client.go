@@ -0,0 +1,144 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pixb/go-server/store"
+)
+
+func (d *Driver) CreateOAuthClient(ctx context.Context, create *store.CreateOAuthClient) (*store.OAuthClient, error) {
+	now := time.Now()
+	result, err := d.db.ExecContext(ctx,
+		`INSERT INTO oauth_clients (client_id, name, secret_hash, redirect_uris, grant_types, scopes, creator_id, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		create.ClientID, create.Name, create.SecretHash, strings.Join(create.RedirectURIs, " "), strings.Join(create.GrantTypes, " "), strings.Join(create.Scopes, " "), create.CreatorID, now, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create oauth client: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	return &store.OAuthClient{
+		ID:           id,
+		ClientID:     create.ClientID,
+		Name:         create.Name,
+		SecretHash:   create.SecretHash,
+		RedirectURIs: create.RedirectURIs,
+		GrantTypes:   create.GrantTypes,
+		Scopes:       create.Scopes,
+		CreatorID:    create.CreatorID,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+func (d *Driver) UpdateOAuthClient(ctx context.Context, update *store.UpdateOAuthClient) (*store.OAuthClient, error) {
+	query := "UPDATE oauth_clients SET updated_at = ?"
+	args := []interface{}{time.Now()}
+	if update.Name != nil {
+		query += ", name = ?"
+		args = append(args, *update.Name)
+	}
+	if update.SecretHash != nil {
+		query += ", secret_hash = ?"
+		args = append(args, *update.SecretHash)
+	}
+	if update.RedirectURIs != nil {
+		query += ", redirect_uris = ?"
+		args = append(args, strings.Join(update.RedirectURIs, " "))
+	}
+	if update.GrantTypes != nil {
+		query += ", grant_types = ?"
+		args = append(args, strings.Join(update.GrantTypes, " "))
+	}
+	if update.Scopes != nil {
+		query += ", scopes = ?"
+		args = append(args, strings.Join(update.Scopes, " "))
+	}
+	query += " WHERE id = ?"
+	args = append(args, update.ID)
+	result, err := d.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update oauth client: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("failed to update oauth client: %w", err)
+	} else if affected == 0 {
+		return nil, fmt.Errorf("failed to update oauth client: %w", sql.ErrNoRows)
+	}
+
+	clients, err := d.ListOAuthClients(ctx, &store.FindOAuthClient{ID: &update.ID})
+	if err != nil {
+		return nil, err
+	}
+	if len(clients) == 0 {
+		return nil, fmt.Errorf("oauth client not found after update")
+	}
+	return clients[0], nil
+}
+
+func (d *Driver) ListOAuthClients(ctx context.Context, find *store.FindOAuthClient) ([]*store.OAuthClient, error) {
+	query := "SELECT id, client_id, name, secret_hash, redirect_uris, grant_types, scopes, creator_id, created_at, updated_at FROM oauth_clients WHERE 1 = 1"
+	args := []interface{}{}
+
+	if find.ID != nil {
+		query += " AND id = ?"
+		args = append(args, *find.ID)
+	}
+	if find.ClientID != nil {
+		query += " AND client_id = ?"
+		args = append(args, *find.ClientID)
+	}
+	query += " ORDER BY id"
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list oauth clients: %w", err)
+	}
+	defer rows.Close()
+
+	var clients []*store.OAuthClient
+	for rows.Next() {
+		var client store.OAuthClient
+		var redirectURIs, grantTypes, scopes string
+		if err := rows.Scan(&client.ID, &client.ClientID, &client.Name, &client.SecretHash, &redirectURIs, &grantTypes, &scopes, &client.CreatorID, &client.CreatedAt, &client.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan oauth client: %w", err)
+		}
+		client.RedirectURIs = strings.Fields(redirectURIs)
+		client.GrantTypes = strings.Fields(grantTypes)
+		client.Scopes = strings.Fields(scopes)
+		clients = append(clients, &client)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list oauth clients: %w", err)
+	}
+
+	return clients, nil
+}
+
+func (d *Driver) DeleteOAuthClient(ctx context.Context, delete *store.DeleteOAuthClient) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range []string{
+		"DELETE FROM oauth_authorization_codes WHERE client_id = ?",
+		"DELETE FROM oauth_consents WHERE client_id = ?",
+		"DELETE FROM oauth_clients WHERE id = ?",
+	} {
+		if _, err := tx.ExecContext(ctx, stmt, delete.ID); err != nil {
+			return fmt.Errorf("failed to delete oauth client: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
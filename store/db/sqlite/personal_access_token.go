@@ -0,0 +1,114 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/pixb/go-server/store"
+)
+
+func (d *Driver) CreatePersonalAccessToken(ctx context.Context, create *store.CreatePersonalAccessToken) (*store.PersonalAccessToken, error) {
+	now := time.Now()
+	result, err := d.db.ExecContext(ctx,
+		`INSERT INTO personal_access_tokens (user_id, token_hash, description, expires_at, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		create.UserID, create.TokenHash, create.Description, create.ExpiresAt, now, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create personal access token: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	return &store.PersonalAccessToken{
+		ID:          id,
+		UserID:      create.UserID,
+		TokenHash:   create.TokenHash,
+		Description: create.Description,
+		ExpiresAt:   create.ExpiresAt,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+func (d *Driver) UpdatePersonalAccessToken(ctx context.Context, update *store.UpdatePersonalAccessToken) (*store.PersonalAccessToken, error) {
+	query := "UPDATE personal_access_tokens SET updated_at = ?"
+	args := []interface{}{time.Now()}
+
+	if update.LastUsedAt != nil {
+		query += ", last_used_at = ?"
+		args = append(args, *update.LastUsedAt)
+	}
+
+	query += " WHERE id = ? AND deleted_at IS NULL"
+	args = append(args, update.ID)
+
+	_, err := d.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update personal access token: %w", err)
+	}
+
+	// Return updated token by querying
+	return d.getPersonalAccessTokenByID(ctx, update.ID)
+}
+
+func (d *Driver) ListPersonalAccessTokens(ctx context.Context, find *store.FindPersonalAccessToken) ([]*store.PersonalAccessToken, error) {
+	query := "SELECT id, user_id, token_hash, description, expires_at, last_used_at, created_at, updated_at, deleted_at FROM personal_access_tokens WHERE deleted_at IS NULL"
+	args := []interface{}{}
+
+	if find.ID != nil {
+		query += " AND id = ?"
+		args = append(args, *find.ID)
+	}
+	if find.UserID != nil {
+		query += " AND user_id = ?"
+		args = append(args, *find.UserID)
+	}
+	if find.TokenHash != nil {
+		query += " AND token_hash = ?"
+		args = append(args, *find.TokenHash)
+	}
+	query += " ORDER BY created_at DESC"
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list personal access tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []*store.PersonalAccessToken
+	for rows.Next() {
+		var token store.PersonalAccessToken
+		if err := rows.Scan(&token.ID, &token.UserID, &token.TokenHash, &token.Description, &token.ExpiresAt, &token.LastUsedAt, &token.CreatedAt, &token.UpdatedAt, &token.DeletedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan personal access token: %w", err)
+		}
+		tokens = append(tokens, &token)
+	}
+
+	return tokens, nil
+}
+
+func (d *Driver) DeletePersonalAccessToken(ctx context.Context, delete *store.DeletePersonalAccessToken) error {
+	_, err := d.db.ExecContext(ctx, "UPDATE personal_access_tokens SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL", time.Now(), delete.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete personal access token: %w", err)
+	}
+	return nil
+}
+
+func (d *Driver) getPersonalAccessTokenByID(ctx context.Context, id int64) (*store.PersonalAccessToken, error) {
+	var token store.PersonalAccessToken
+	err := d.db.QueryRowContext(ctx,
+		"SELECT id, user_id, token_hash, description, expires_at, last_used_at, created_at, updated_at, deleted_at FROM personal_access_tokens WHERE id = ? AND deleted_at IS NULL",
+		id).Scan(&token.ID, &token.UserID, &token.TokenHash, &token.Description, &token.ExpiresAt, &token.LastUsedAt, &token.CreatedAt, &token.UpdatedAt, &token.DeletedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get personal access token by id: %w", err)
+	}
+	return &token, nil
+}
@@ -0,0 +1,64 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pixb/go-server/store"
+)
+
+func (d *Driver) CreateSecurityEvent(ctx context.Context, create *store.CreateSecurityEvent) (*store.SecurityEvent, error) {
+	now := time.Now()
+	result, err := d.db.ExecContext(ctx,
+		`INSERT INTO security_events (user_id, type, detail, created_at) VALUES (?, ?, ?, ?)`,
+		create.UserID, create.Type, create.Detail, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create security event: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	return &store.SecurityEvent{
+		ID:        id,
+		UserID:    create.UserID,
+		Type:      create.Type,
+		Detail:    create.Detail,
+		CreatedAt: now,
+	}, nil
+}
+
+func (d *Driver) ListSecurityEvents(ctx context.Context, find *store.FindSecurityEvent) ([]*store.SecurityEvent, error) {
+	query := "SELECT id, user_id, type, detail, created_at FROM security_events WHERE 1 = 1"
+	args := []interface{}{}
+
+	if find.UserID != nil {
+		query += " AND user_id = ?"
+		args = append(args, *find.UserID)
+	}
+	if find.Type != nil {
+		query += " AND type = ?"
+		args = append(args, *find.Type)
+	}
+	query += " ORDER BY created_at DESC"
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list security events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*store.SecurityEvent
+	for rows.Next() {
+		var event store.SecurityEvent
+		if err := rows.Scan(&event.ID, &event.UserID, &event.Type, &event.Detail, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan security event: %w", err)
+		}
+		events = append(events, &event)
+	}
+
+	return events, nil
+}
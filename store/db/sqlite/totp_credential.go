@@ -0,0 +1,158 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/pixb/go-server/store"
+)
+
+func (d *Driver) CreateTOTPCredential(ctx context.Context, create *store.CreateTOTPCredential) (*store.TOTPCredential, error) {
+	now := time.Now()
+	result, err := d.db.ExecContext(ctx,
+		`INSERT INTO totp_credentials (user_id, secret, recovery_codes, created_at, updated_at) VALUES (?, ?, ?, ?, ?)`,
+		create.UserID, create.Secret, create.RecoveryCodes, now, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create totp credential: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	return &store.TOTPCredential{
+		ID:            id,
+		UserID:        create.UserID,
+		Secret:        create.Secret,
+		RecoveryCodes: create.RecoveryCodes,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}, nil
+}
+
+func (d *Driver) UpdateTOTPCredential(ctx context.Context, update *store.UpdateTOTPCredential) (*store.TOTPCredential, error) {
+	query := "UPDATE totp_credentials SET updated_at = ?"
+	args := []interface{}{time.Now()}
+
+	if update.Secret != nil {
+		query += ", secret = ?"
+		args = append(args, *update.Secret)
+	}
+	if update.RecoveryCodes != nil {
+		query += ", recovery_codes = ?"
+		args = append(args, *update.RecoveryCodes)
+	}
+	if update.Enabled != nil {
+		query += ", enabled = ?"
+		args = append(args, *update.Enabled)
+	}
+	if update.LastUsedStep != nil {
+		query += ", last_used_step = ?"
+		args = append(args, *update.LastUsedStep)
+	}
+
+	query += " WHERE id = ?"
+	args = append(args, update.ID)
+
+	_, err := d.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update totp credential: %w", err)
+	}
+
+	// Return updated credential by querying
+	return d.getTOTPCredentialByID(ctx, update.ID)
+}
+
+func (d *Driver) ListTOTPCredentials(ctx context.Context, find *store.FindTOTPCredential) ([]*store.TOTPCredential, error) {
+	query := "SELECT id, user_id, secret, recovery_codes, enabled, last_used_step, created_at, updated_at FROM totp_credentials WHERE 1 = 1"
+	args := []interface{}{}
+
+	if find.ID != nil {
+		query += " AND id = ?"
+		args = append(args, *find.ID)
+	}
+	if find.UserID != nil {
+		query += " AND user_id = ?"
+		args = append(args, *find.UserID)
+	}
+	if find.Enabled != nil {
+		query += " AND enabled = ?"
+		args = append(args, *find.Enabled)
+	}
+	query += " ORDER BY id ASC"
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list totp credentials: %w", err)
+	}
+	defer rows.Close()
+
+	var credentials []*store.TOTPCredential
+	for rows.Next() {
+		var credential store.TOTPCredential
+		if err := rows.Scan(&credential.ID, &credential.UserID, &credential.Secret, &credential.RecoveryCodes, &credential.Enabled, &credential.LastUsedStep, &credential.CreatedAt, &credential.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan totp credential: %w", err)
+		}
+		credentials = append(credentials, &credential)
+	}
+
+	return credentials, nil
+}
+
+func (d *Driver) DeleteTOTPCredential(ctx context.Context, delete *store.DeleteTOTPCredential) error {
+	_, err := d.db.ExecContext(ctx, "DELETE FROM totp_credentials WHERE user_id = ?", delete.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to delete totp credential: %w", err)
+	}
+	return nil
+}
+
+// ReencryptTOTPCredentials updates the credentials and upserts the setting in one transaction.
+func (d *Driver) ReencryptTOTPCredentials(ctx context.Context, reencrypt *store.ReencryptTOTPCredentials) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	for _, update := range reencrypt.Credentials {
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE totp_credentials SET secret = COALESCE(?, secret), recovery_codes = COALESCE(?, recovery_codes), updated_at = ? WHERE id = ?`,
+			update.Secret, update.RecoveryCodes, now, update.ID); err != nil {
+			return fmt.Errorf("failed to update totp credential: %w", err)
+		}
+	}
+	setting := reencrypt.Setting
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO system_setting (name, value, description)
+		VALUES (?, ?, ?)
+		ON CONFLICT(name) DO UPDATE
+		SET
+			value = EXCLUDED.value,
+			description = EXCLUDED.description`,
+		setting.Name, setting.Value, setting.Description); err != nil {
+		return fmt.Errorf("failed to upsert instance setting: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (d *Driver) getTOTPCredentialByID(ctx context.Context, id int64) (*store.TOTPCredential, error) {
+	var credential store.TOTPCredential
+	err := d.db.QueryRowContext(ctx,
+		"SELECT id, user_id, secret, recovery_codes, enabled, last_used_step, created_at, updated_at FROM totp_credentials WHERE id = ?",
+		id).Scan(&credential.ID, &credential.UserID, &credential.Secret, &credential.RecoveryCodes, &credential.Enabled, &credential.LastUsedStep, &credential.CreatedAt, &credential.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get totp credential by id: %w", err)
+	}
+	return &credential, nil
+}
@@ -0,0 +1,91 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pixb/go-server/store"
+)
+
+func (d *Driver) CreateUserIdentity(ctx context.Context, create *store.CreateUserIdentity) (*store.UserIdentity, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	userID := create.UserID
+	if user := create.User; user != nil {
+		result, err := tx.ExecContext(ctx,
+			`INSERT INTO users (username, nickname, password, phone, email, role, email_verified, password_expires, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			user.Username, user.Nickname, user.Password, user.Phone, user.Email, user.Role, user.EmailVerified, user.PasswordExpires, now, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create user: %w", err)
+		}
+		if userID, err = result.LastInsertId(); err != nil {
+			return nil, fmt.Errorf("failed to get last insert id: %w", err)
+		}
+	}
+	result, err := tx.ExecContext(ctx,
+		"INSERT INTO user_identities (user_id, provider, subject, created_at) VALUES (?, ?, ?, ?)",
+		userID, create.Provider, create.Subject, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user identity: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &store.UserIdentity{
+		ID:        id,
+		UserID:    userID,
+		Provider:  create.Provider,
+		Subject:   create.Subject,
+		CreatedAt: now,
+	}, nil
+}
+
+func (d *Driver) ListUserIdentities(ctx context.Context, find *store.FindUserIdentity) ([]*store.UserIdentity, error) {
+	query := "SELECT id, user_id, provider, subject, created_at FROM user_identities WHERE 1 = 1"
+	args := []interface{}{}
+
+	if find.UserID != nil {
+		query += " AND user_id = ?"
+		args = append(args, *find.UserID)
+	}
+	if find.Provider != nil {
+		query += " AND provider = ?"
+		args = append(args, *find.Provider)
+	}
+	if find.Subject != nil {
+		query += " AND subject = ?"
+		args = append(args, *find.Subject)
+	}
+	query += " ORDER BY id"
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user identities: %w", err)
+	}
+	defer rows.Close()
+
+	var identities []*store.UserIdentity
+	for rows.Next() {
+		var identity store.UserIdentity
+		if err := rows.Scan(&identity.ID, &identity.UserID, &identity.Provider, &identity.Subject, &identity.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan user identity: %w", err)
+		}
+		identities = append(identities, &identity)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list user identities: %w", err)
+	}
+
+	return identities, nil
+}
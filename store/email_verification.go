@@ -0,0 +1,61 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// EmailVerification is a pending confirmation of an email address. Email is either the
+// current, unverified address of the user or the new address of a pending email change.
+// Only the SHA-256 digest of the token is persisted.
+type EmailVerification struct {
+	ID        int64
+	UserID    int64
+	Email     string
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+type CreateEmailVerification struct {
+	UserID    int64
+	Email     string
+	TokenHash string
+	ExpiresAt time.Time
+}
+
+type FindEmailVerification struct {
+	UserID    *int64
+	Email     *string
+	TokenHash *string
+}
+
+type DeleteEmailVerification struct {
+	UserID int64
+}
+
+func (s *Store) CreateEmailVerification(ctx context.Context, create *CreateEmailVerification) (*EmailVerification, error) {
+	return s.driver.CreateEmailVerification(ctx, create)
+}
+
+// ListEmailVerifications returns the matching verifications, most recent first.
+func (s *Store) ListEmailVerifications(ctx context.Context, find *FindEmailVerification) ([]*EmailVerification, error) {
+	return s.driver.ListEmailVerifications(ctx, find)
+}
+
+// GetEmailVerification returns the most recent verification matching find, or nil if there is none.
+func (s *Store) GetEmailVerification(ctx context.Context, find *FindEmailVerification) (*EmailVerification, error) {
+	list, err := s.ListEmailVerifications(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+// DeleteEmailVerifications removes all pending verifications of a user.
+func (s *Store) DeleteEmailVerifications(ctx context.Context, delete *DeleteEmailVerification) error {
+	return s.driver.DeleteEmailVerifications(ctx, delete)
+}
@@ -0,0 +1,59 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pixb/go-server/store"
+)
+
+func TestEmailVerifications(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+
+	user, err := s.CreateUser(ctx, &store.User{
+		Username:        "testuser",
+		Email:           "test@example.com",
+		Password:        "hash",
+		Nickname:        "Test User",
+		Role:            store.RoleUser,
+		PasswordExpires: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.False(t, user.EmailVerified)
+
+	for _, email := range []string{"test@example.com", "new@example.com"} {
+		_, err := s.CreateEmailVerification(ctx, &store.CreateEmailVerification{
+			UserID:    user.ID,
+			Email:     email,
+			TokenHash: "digest-" + email,
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+	}
+
+	// The most recent verification comes first
+	verification, err := s.GetEmailVerification(ctx, &store.FindEmailVerification{UserID: &user.ID})
+	require.NoError(t, err)
+	require.NotNil(t, verification)
+	assert.Equal(t, "new@example.com", verification.Email)
+	email := "test@example.com"
+	verification, err = s.GetEmailVerification(ctx, &store.FindEmailVerification{Email: &email})
+	require.NoError(t, err)
+	require.NotNil(t, verification)
+	assert.Equal(t, "digest-test@example.com", verification.TokenHash)
+
+	verified := true
+	user, err = s.UpdateUser(ctx, &store.UpdateUser{ID: user.ID, EmailVerified: &verified})
+	require.NoError(t, err)
+	assert.True(t, user.EmailVerified)
+
+	require.NoError(t, s.DeleteEmailVerifications(ctx, &store.DeleteEmailVerification{UserID: user.ID}))
+	verification, err = s.GetEmailVerification(ctx, &store.FindEmailVerification{UserID: &user.ID})
+	require.NoError(t, err)
+	assert.Nil(t, verification)
+}
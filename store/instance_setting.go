@@ -2,9 +2,14 @@ package store
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	storepb "github.com/pixb/go-server/proto/gen/store"
 )
@@ -23,34 +28,104 @@ type DeleteInstanceSetting struct {
 	Name string
 }
 
+// CompareAndSwapInstanceSetting writes New only if the stored value of the setting is still Old.
+type CompareAndSwapInstanceSetting struct {
+	// Old is the value read before, nil if the setting was not stored.
+	Old *string
+	New *InstanceSetting
+}
+
+// maxInstanceSettingUpdateAttempts bounds the retries of UpdateInstanceSetting.
+const maxInstanceSettingUpdateAttempts = 10
+
+// SetupInstance is the first-run setup of an instance.
+type SetupInstance struct {
+	// Setup is the SETUP setting. It is only inserted while there is neither a SETUP
+	// setting nor an admin, nothing else is written otherwise.
+	Setup *InstanceSetting
+	Admin *User
+	// Settings are upserted in the same transaction.
+	Settings []*InstanceSetting
+}
+
 func (s *Store) UpsertInstanceSetting(ctx context.Context, upsert *storepb.InstanceSetting) (*storepb.InstanceSetting, error) {
-	instanceSettingRaw := &InstanceSetting{
-		Name: upsert.Key.String(),
-	}
-	var valueBytes []byte
-	var err error
-	if upsert.Key == storepb.InstanceSettingKey_BASIC {
-		valueBytes, err = protojson.Marshal(upsert.GetBasicSetting())
-	} else {
-		return nil, errors.Errorf("unsupported instance setting key: %v", upsert.Key)
-	}
+	instanceSettingRaw, err := convertInstanceSettingToRaw(upsert)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to marshal instance setting value")
+		return nil, err
 	}
-	valueString := string(valueBytes)
-	instanceSettingRaw.Value = valueString
+	s.settingWatcher.mu.Lock()
 	instanceSettingRaw, err = s.driver.UpsertInstanceSetting(ctx, instanceSettingRaw)
 	if err != nil {
+		s.settingWatcher.mu.Unlock()
 		return nil, errors.Wrap(err, "Failed to upsert instance setting")
 	}
+	s.settingWatcher.record(instanceSettingRaw)
 	instanceSetting, err := convertInstanceSettingFromRaw(instanceSettingRaw)
 	if err != nil {
+		s.instanceSettingCache.Delete(ctx, instanceSettingRaw.Name)
+		s.settingWatcher.mu.Unlock()
 		return nil, errors.Wrap(err, "Failed to convert instance setting")
 	}
 	s.instanceSettingCache.Set(ctx, instanceSetting.Key.String(), instanceSetting)
+	s.settingWatcher.mu.Unlock()
+
+	s.settingWatcher.publish(instanceSetting)
 	return instanceSetting, nil
 }
 
+// UpdateInstanceSetting changes the setting of key with update, which is passed the stored
+// setting, nil if there is none, and returns the new setting or nil to leave it as it is. The
+// new setting is only saved if the stored one has not been changed in the meantime by this or
+// another replica, update is called again with the changed setting otherwise.
+func (s *Store) UpdateInstanceSetting(ctx context.Context, key storepb.InstanceSettingKey, update func(*storepb.InstanceSetting) (*storepb.InstanceSetting, error)) (*storepb.InstanceSetting, error) {
+	for attempt := 0; attempt < maxInstanceSettingUpdateAttempts; attempt++ {
+		list, err := s.driver.ListInstanceSettings(ctx, &FindInstanceSetting{Name: key.String()})
+		if err != nil {
+			return nil, err
+		}
+		var old *string
+		var instanceSetting *storepb.InstanceSetting
+		if len(list) > 0 {
+			old = &list[0].Value
+			if instanceSetting, err = convertInstanceSettingFromRaw(list[0]); err != nil {
+				return nil, errors.Wrap(err, "Failed to convert instance setting")
+			}
+		}
+		updated, err := update(instanceSetting)
+		if err != nil {
+			return nil, err
+		}
+		if updated == nil {
+			return instanceSetting, nil
+		}
+		instanceSettingRaw, err := convertInstanceSettingToRaw(updated)
+		if err != nil {
+			return nil, err
+		}
+		if old != nil && *old == instanceSettingRaw.Value {
+			return updated, nil
+		}
+
+		s.settingWatcher.mu.Lock()
+		swapped, err := s.driver.CompareAndSwapInstanceSetting(ctx, &CompareAndSwapInstanceSetting{Old: old, New: instanceSettingRaw})
+		if err != nil {
+			s.settingWatcher.mu.Unlock()
+			return nil, errors.Wrap(err, "Failed to update instance setting")
+		}
+		if !swapped {
+			s.settingWatcher.mu.Unlock()
+			continue
+		}
+		s.settingWatcher.record(instanceSettingRaw)
+		s.instanceSettingCache.Set(ctx, key.String(), updated)
+		s.settingWatcher.mu.Unlock()
+
+		s.settingWatcher.publish(updated)
+		return updated, nil
+	}
+	return nil, errors.Errorf("instance setting %s kept changing while it was updated", key)
+}
+
 func (s *Store) ListInstanceSettings(ctx context.Context, find *FindInstanceSetting) ([]*storepb.InstanceSetting, error) {
 	list, err := s.driver.ListInstanceSettings(ctx, find)
 	if err != nil {
@@ -112,6 +187,322 @@ func (s *Store) GetInstanceBasicSetting(ctx context.Context) (*storepb.InstanceB
 	return instanceBasicSetting, nil
 }
 
+// RotateInstanceSecretKey replaces the instance secret key with a new random one and returns it.
+// The replaced key is kept as the previous secret key, which still decrypts what servers that
+// have not been restarted encrypt, until the rotation is finished with ReencryptTOTPCredentials.
+// A key is not rotated again before that.
+func (s *Store) RotateInstanceSecretKey(ctx context.Context) (string, error) {
+	secretKey, err := generateSecretKey()
+	if err != nil {
+		return "", err
+	}
+	// Bypass the cache so that a concurrent schema version update is not overwritten.
+	list, err := s.ListInstanceSettings(ctx, &FindInstanceSetting{
+		Name: storepb.InstanceSettingKey_BASIC.String(),
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get instance basic setting")
+	}
+	instanceBasicSetting := &storepb.InstanceBasicSetting{}
+	if len(list) > 0 {
+		instanceBasicSetting = proto.Clone(list[0].GetBasicSetting()).(*storepb.InstanceBasicSetting)
+	}
+	if instanceBasicSetting.PreviousSecretKey != "" {
+		return "", errors.New("the previous secret key is still in use, finish its rotation first")
+	}
+	instanceBasicSetting.PreviousSecretKey = instanceBasicSetting.SecretKey
+	instanceBasicSetting.SecretKey = secretKey
+	if _, err := s.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+		Key:   storepb.InstanceSettingKey_BASIC,
+		Value: &storepb.InstanceSetting_BasicSetting{BasicSetting: instanceBasicSetting},
+	}); err != nil {
+		return "", err
+	}
+	return secretKey, nil
+}
+
+// ReencryptTOTPCredentials updates the encrypted fields of TOTP credentials and the instance
+// basic setting in one transaction, so that a failure leaves both encrypted with the old key.
+func (s *Store) ReencryptTOTPCredentials(ctx context.Context, credentials []*UpdateTOTPCredential, basicSetting *storepb.InstanceBasicSetting) error {
+	instanceSetting := &storepb.InstanceSetting{
+		Key:   storepb.InstanceSettingKey_BASIC,
+		Value: &storepb.InstanceSetting_BasicSetting{BasicSetting: basicSetting},
+	}
+	instanceSettingRaw, err := convertInstanceSettingToRaw(instanceSetting)
+	if err != nil {
+		return err
+	}
+	s.settingWatcher.mu.Lock()
+	if err := s.driver.ReencryptTOTPCredentials(ctx, &ReencryptTOTPCredentials{
+		Credentials: credentials,
+		Setting:     instanceSettingRaw,
+	}); err != nil {
+		s.settingWatcher.mu.Unlock()
+		return errors.Wrap(err, "failed to re-encrypt totp credentials")
+	}
+	s.settingWatcher.record(instanceSettingRaw)
+	s.instanceSettingCache.Set(ctx, instanceSetting.Key.String(), instanceSetting)
+	s.settingWatcher.mu.Unlock()
+
+	s.settingWatcher.publish(instanceSetting)
+	return nil
+}
+
+// SetupInstance creates the first admin together with the given settings and records the
+// setup. It returns nil if the instance has already been set up or has an admin. The
+// unique setting name serializes concurrent setups, only one of them creates its admin.
+func (s *Store) SetupInstance(ctx context.Context, admin *User, settings ...*storepb.InstanceSetting) (*User, error) {
+	setup, err := convertInstanceSettingToRaw(&storepb.InstanceSetting{
+		Key: storepb.InstanceSettingKey_SETUP,
+		Value: &storepb.InstanceSetting_SetupSetting{SetupSetting: &storepb.InstanceSetupSetting{
+			SetupTime: timestamppb.Now(),
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	rawSettings := []*InstanceSetting{}
+	for _, setting := range settings {
+		rawSetting, err := convertInstanceSettingToRaw(setting)
+		if err != nil {
+			return nil, err
+		}
+		rawSettings = append(rawSettings, rawSetting)
+	}
+
+	s.settingWatcher.mu.Lock()
+	user, err := s.driver.SetupInstance(ctx, &SetupInstance{
+		Setup:    setup,
+		Admin:    admin,
+		Settings: rawSettings,
+	})
+	if err != nil {
+		s.settingWatcher.mu.Unlock()
+		return nil, errors.Wrap(err, "failed to set up instance")
+	}
+	if user == nil {
+		s.settingWatcher.mu.Unlock()
+		return nil, nil
+	}
+	changed := []*storepb.InstanceSetting{}
+	for _, rawSetting := range append(rawSettings, setup) {
+		s.settingWatcher.record(rawSetting)
+		if instanceSetting, err := convertInstanceSettingFromRaw(rawSetting); err == nil && instanceSetting != nil {
+			s.instanceSettingCache.Set(ctx, instanceSetting.Key.String(), instanceSetting)
+			changed = append(changed, instanceSetting)
+		}
+	}
+	s.settingWatcher.mu.Unlock()
+
+	s.settingWatcher.publish(changed...)
+	s.userCache.Set(ctx, strconv.FormatInt(user.ID, 10), user)
+	return user, nil
+}
+
+func generateSecretKey() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "failed to generate secret key")
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (s *Store) GetInstanceJWTSigningKeySetting(ctx context.Context) (*storepb.InstanceJWTSigningKeySetting, error) {
+	instanceSetting, err := s.GetInstanceSetting(ctx, &FindInstanceSetting{
+		Name: storepb.InstanceSettingKey_JWT_SIGNING_KEYS.String(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get instance jwt signing key setting")
+	}
+
+	instanceJWTSigningKeySetting := &storepb.InstanceJWTSigningKeySetting{}
+	if instanceSetting != nil {
+		instanceJWTSigningKeySetting = instanceSetting.GetJwtSigningKeySetting()
+	}
+	s.instanceSettingCache.Set(ctx, storepb.InstanceSettingKey_JWT_SIGNING_KEYS.String(), &storepb.InstanceSetting{
+		Key:   storepb.InstanceSettingKey_JWT_SIGNING_KEYS,
+		Value: &storepb.InstanceSetting_JwtSigningKeySetting{JwtSigningKeySetting: instanceJWTSigningKeySetting},
+	})
+	return instanceJWTSigningKeySetting, nil
+}
+
+func (s *Store) GetInstanceSecuritySetting(ctx context.Context) (*storepb.InstanceSecuritySetting, error) {
+	instanceSetting, err := s.GetInstanceSetting(ctx, &FindInstanceSetting{
+		Name: storepb.InstanceSettingKey_SECURITY.String(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get instance security setting")
+	}
+
+	instanceSecuritySetting := &storepb.InstanceSecuritySetting{}
+	if instanceSetting != nil {
+		instanceSecuritySetting = instanceSetting.GetSecuritySetting()
+	}
+	s.instanceSettingCache.Set(ctx, storepb.InstanceSettingKey_SECURITY.String(), &storepb.InstanceSetting{
+		Key:   storepb.InstanceSettingKey_SECURITY,
+		Value: &storepb.InstanceSetting_SecuritySetting{SecuritySetting: instanceSecuritySetting},
+	})
+	return instanceSecuritySetting, nil
+}
+
+func (s *Store) GetInstancePasswordPolicySetting(ctx context.Context) (*storepb.InstancePasswordPolicySetting, error) {
+	instanceSetting, err := s.GetInstanceSetting(ctx, &FindInstanceSetting{
+		Name: storepb.InstanceSettingKey_PASSWORD_POLICY.String(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get instance password policy setting")
+	}
+
+	instancePasswordPolicySetting := &storepb.InstancePasswordPolicySetting{}
+	if instanceSetting != nil {
+		instancePasswordPolicySetting = instanceSetting.GetPasswordPolicySetting()
+	}
+	s.instanceSettingCache.Set(ctx, storepb.InstanceSettingKey_PASSWORD_POLICY.String(), &storepb.InstanceSetting{
+		Key:   storepb.InstanceSettingKey_PASSWORD_POLICY,
+		Value: &storepb.InstanceSetting_PasswordPolicySetting{PasswordPolicySetting: instancePasswordPolicySetting},
+	})
+	return instancePasswordPolicySetting, nil
+}
+
+func (s *Store) GetInstanceIdentityProviderSetting(ctx context.Context) (*storepb.InstanceIdentityProviderSetting, error) {
+	instanceSetting, err := s.GetInstanceSetting(ctx, &FindInstanceSetting{
+		Name: storepb.InstanceSettingKey_IDENTITY_PROVIDERS.String(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get instance identity provider setting")
+	}
+
+	instanceIdentityProviderSetting := &storepb.InstanceIdentityProviderSetting{}
+	if instanceSetting != nil {
+		instanceIdentityProviderSetting = instanceSetting.GetIdentityProviderSetting()
+	}
+	s.instanceSettingCache.Set(ctx, storepb.InstanceSettingKey_IDENTITY_PROVIDERS.String(), &storepb.InstanceSetting{
+		Key:   storepb.InstanceSettingKey_IDENTITY_PROVIDERS,
+		Value: &storepb.InstanceSetting_IdentityProviderSetting{IdentityProviderSetting: instanceIdentityProviderSetting},
+	})
+	return instanceIdentityProviderSetting, nil
+}
+
+// GetIdentityProvider returns the identity provider with the given ID, or nil if there is none.
+func (s *Store) GetIdentityProvider(ctx context.Context, id string) (*storepb.IdentityProvider, error) {
+	setting, err := s.GetInstanceIdentityProviderSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, provider := range setting.Providers {
+		if provider.Id == id {
+			return provider, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *Store) GetInstanceGeneralSetting(ctx context.Context) (*storepb.InstanceGeneralSetting, error) {
+	instanceSetting, err := s.GetInstanceSetting(ctx, &FindInstanceSetting{
+		Name: storepb.InstanceSettingKey_GENERAL.String(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get instance general setting")
+	}
+
+	instanceGeneralSetting := &storepb.InstanceGeneralSetting{}
+	if instanceSetting != nil {
+		instanceGeneralSetting = instanceSetting.GetGeneralSetting()
+	}
+	s.instanceSettingCache.Set(ctx, storepb.InstanceSettingKey_GENERAL.String(), &storepb.InstanceSetting{
+		Key:   storepb.InstanceSettingKey_GENERAL,
+		Value: &storepb.InstanceSetting_GeneralSetting{GeneralSetting: instanceGeneralSetting},
+	})
+	return instanceGeneralSetting, nil
+}
+
+// GetInstanceSetupSetting returns the record of the first-run setup, or nil if the
+// instance has not been set up.
+func (s *Store) GetInstanceSetupSetting(ctx context.Context) (*storepb.InstanceSetupSetting, error) {
+	instanceSetting, err := s.GetInstanceSetting(ctx, &FindInstanceSetting{
+		Name: storepb.InstanceSettingKey_SETUP.String(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get instance setup setting")
+	}
+	if instanceSetting == nil {
+		return nil, nil
+	}
+	return instanceSetting.GetSetupSetting(), nil
+}
+
+func (s *Store) GetInstanceStorageSetting(ctx context.Context) (*storepb.InstanceStorageSetting, error) {
+	instanceSetting, err := s.GetInstanceSetting(ctx, &FindInstanceSetting{
+		Name: storepb.InstanceSettingKey_STORAGE.String(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get instance storage setting")
+	}
+
+	instanceStorageSetting := &storepb.InstanceStorageSetting{}
+	if instanceSetting != nil {
+		instanceStorageSetting = instanceSetting.GetStorageSetting()
+	}
+	s.instanceSettingCache.Set(ctx, storepb.InstanceSettingKey_STORAGE.String(), &storepb.InstanceSetting{
+		Key:   storepb.InstanceSettingKey_STORAGE,
+		Value: &storepb.InstanceSetting_StorageSetting{StorageSetting: instanceStorageSetting},
+	})
+	return instanceStorageSetting, nil
+}
+
+func (s *Store) GetInstanceMaintenanceSetting(ctx context.Context) (*storepb.InstanceMaintenanceSetting, error) {
+	instanceSetting, err := s.GetInstanceSetting(ctx, &FindInstanceSetting{
+		Name: storepb.InstanceSettingKey_MAINTENANCE.String(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get instance maintenance setting")
+	}
+
+	instanceMaintenanceSetting := &storepb.InstanceMaintenanceSetting{}
+	if instanceSetting != nil {
+		instanceMaintenanceSetting = instanceSetting.GetMaintenanceSetting()
+	}
+	s.instanceSettingCache.Set(ctx, storepb.InstanceSettingKey_MAINTENANCE.String(), &storepb.InstanceSetting{
+		Key:   storepb.InstanceSettingKey_MAINTENANCE,
+		Value: &storepb.InstanceSetting_MaintenanceSetting{MaintenanceSetting: instanceMaintenanceSetting},
+	})
+	return instanceMaintenanceSetting, nil
+}
+
+func convertInstanceSettingToRaw(instanceSetting *storepb.InstanceSetting) (*InstanceSetting, error) {
+	var valueBytes []byte
+	var err error
+	switch instanceSetting.Key {
+	case storepb.InstanceSettingKey_BASIC:
+		valueBytes, err = protojson.Marshal(instanceSetting.GetBasicSetting())
+	case storepb.InstanceSettingKey_JWT_SIGNING_KEYS:
+		valueBytes, err = protojson.Marshal(instanceSetting.GetJwtSigningKeySetting())
+	case storepb.InstanceSettingKey_SECURITY:
+		valueBytes, err = protojson.Marshal(instanceSetting.GetSecuritySetting())
+	case storepb.InstanceSettingKey_PASSWORD_POLICY:
+		valueBytes, err = protojson.Marshal(instanceSetting.GetPasswordPolicySetting())
+	case storepb.InstanceSettingKey_IDENTITY_PROVIDERS:
+		valueBytes, err = protojson.Marshal(instanceSetting.GetIdentityProviderSetting())
+	case storepb.InstanceSettingKey_GENERAL:
+		valueBytes, err = protojson.Marshal(instanceSetting.GetGeneralSetting())
+	case storepb.InstanceSettingKey_SETUP:
+		valueBytes, err = protojson.Marshal(instanceSetting.GetSetupSetting())
+	case storepb.InstanceSettingKey_STORAGE:
+		valueBytes, err = protojson.Marshal(instanceSetting.GetStorageSetting())
+	case storepb.InstanceSettingKey_MAINTENANCE:
+		valueBytes, err = protojson.Marshal(instanceSetting.GetMaintenanceSetting())
+	default:
+		return nil, errors.Errorf("unsupported instance setting key: %v", instanceSetting.Key)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal instance setting value")
+	}
+	return &InstanceSetting{
+		Name:  instanceSetting.Key.String(),
+		Value: string(valueBytes),
+	}, nil
+}
+
 func convertInstanceSettingFromRaw(instanceSettingRaw *InstanceSetting) (*storepb.InstanceSetting, error) {
 	instanceSetting := &storepb.InstanceSetting{
 		Key: storepb.InstanceSettingKey(storepb.InstanceSettingKey_value[instanceSettingRaw.Name]),
@@ -123,6 +514,54 @@ func convertInstanceSettingFromRaw(instanceSettingRaw *InstanceSetting) (*storep
 			return nil, err
 		}
 		instanceSetting.Value = &storepb.InstanceSetting_BasicSetting{BasicSetting: basicSetting}
+	case storepb.InstanceSettingKey_JWT_SIGNING_KEYS.String():
+		jwtSigningKeySetting := &storepb.InstanceJWTSigningKeySetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(instanceSettingRaw.Value), jwtSigningKeySetting); err != nil {
+			return nil, err
+		}
+		instanceSetting.Value = &storepb.InstanceSetting_JwtSigningKeySetting{JwtSigningKeySetting: jwtSigningKeySetting}
+	case storepb.InstanceSettingKey_SECURITY.String():
+		securitySetting := &storepb.InstanceSecuritySetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(instanceSettingRaw.Value), securitySetting); err != nil {
+			return nil, err
+		}
+		instanceSetting.Value = &storepb.InstanceSetting_SecuritySetting{SecuritySetting: securitySetting}
+	case storepb.InstanceSettingKey_PASSWORD_POLICY.String():
+		passwordPolicySetting := &storepb.InstancePasswordPolicySetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(instanceSettingRaw.Value), passwordPolicySetting); err != nil {
+			return nil, err
+		}
+		instanceSetting.Value = &storepb.InstanceSetting_PasswordPolicySetting{PasswordPolicySetting: passwordPolicySetting}
+	case storepb.InstanceSettingKey_IDENTITY_PROVIDERS.String():
+		identityProviderSetting := &storepb.InstanceIdentityProviderSetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(instanceSettingRaw.Value), identityProviderSetting); err != nil {
+			return nil, err
+		}
+		instanceSetting.Value = &storepb.InstanceSetting_IdentityProviderSetting{IdentityProviderSetting: identityProviderSetting}
+	case storepb.InstanceSettingKey_GENERAL.String():
+		generalSetting := &storepb.InstanceGeneralSetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(instanceSettingRaw.Value), generalSetting); err != nil {
+			return nil, err
+		}
+		instanceSetting.Value = &storepb.InstanceSetting_GeneralSetting{GeneralSetting: generalSetting}
+	case storepb.InstanceSettingKey_SETUP.String():
+		setupSetting := &storepb.InstanceSetupSetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(instanceSettingRaw.Value), setupSetting); err != nil {
+			return nil, err
+		}
+		instanceSetting.Value = &storepb.InstanceSetting_SetupSetting{SetupSetting: setupSetting}
+	case storepb.InstanceSettingKey_STORAGE.String():
+		storageSetting := &storepb.InstanceStorageSetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(instanceSettingRaw.Value), storageSetting); err != nil {
+			return nil, err
+		}
+		instanceSetting.Value = &storepb.InstanceSetting_StorageSetting{StorageSetting: storageSetting}
+	case storepb.InstanceSettingKey_MAINTENANCE.String():
+		maintenanceSetting := &storepb.InstanceMaintenanceSetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(instanceSettingRaw.Value), maintenanceSetting); err != nil {
+			return nil, err
+		}
+		instanceSetting.Value = &storepb.InstanceSetting_MaintenanceSetting{MaintenanceSetting: maintenanceSetting}
 	default:
 		// Skip unsupported instance setting key.
 		return nil, nil
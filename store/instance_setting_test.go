@@ -0,0 +1,254 @@
+package store_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/pixb/go-server/proto/gen/store"
+	"github.com/pixb/go-server/store"
+)
+
+func TestInstanceSecretKey(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+
+	// The first migration generates a random secret key
+	basicSetting, err := s.GetInstanceBasicSetting(ctx)
+	require.NoError(t, err)
+	secretKey := basicSetting.SecretKey
+	assert.Len(t, secretKey, 64)
+	assert.NotEmpty(t, basicSetting.SchemaVersion)
+
+	// Later migrations keep it
+	require.NoError(t, s.Migrate(ctx))
+	basicSetting, err = s.GetInstanceBasicSetting(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, secretKey, basicSetting.SecretKey)
+
+	// Rotation replaces the secret key and keeps the rest of the setting
+	rotated, err := s.RotateInstanceSecretKey(ctx)
+	require.NoError(t, err)
+	assert.NotEqual(t, secretKey, rotated)
+	rotatedSetting, err := s.GetInstanceBasicSetting(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, rotated, rotatedSetting.SecretKey)
+	assert.Equal(t, secretKey, rotatedSetting.PreviousSecretKey)
+	assert.Equal(t, basicSetting.SchemaVersion, rotatedSetting.SchemaVersion)
+}
+
+func TestSetupInstance(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+
+	setup, err := s.GetInstanceSetupSetting(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, setup)
+
+	// Concurrent setups create exactly one admin
+	const setups = 8
+	admins := make(chan *store.User, setups)
+	var wg sync.WaitGroup
+	for i := range setups {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			admin, err := s.SetupInstance(ctx, &store.User{
+				Username:        fmt.Sprintf("admin%d", i),
+				Email:           fmt.Sprintf("admin%d@example.com", i),
+				Password:        "hash",
+				Role:            store.RoleAdmin,
+				PasswordExpires: time.Now().Add(time.Hour),
+			}, &storepb.InstanceSetting{
+				Key:   storepb.InstanceSettingKey_GENERAL,
+				Value: &storepb.InstanceSetting_GeneralSetting{GeneralSetting: &storepb.InstanceGeneralSetting{Name: fmt.Sprintf("instance%d", i)}},
+			})
+			assert.NoError(t, err)
+			admins <- admin
+		}()
+	}
+	wg.Wait()
+	close(admins)
+	var admin *store.User
+	for a := range admins {
+		if a != nil {
+			require.Nil(t, admin, "more than one setup succeeded")
+			admin = a
+		}
+	}
+	require.NotNil(t, admin)
+
+	adminRole := store.RoleAdmin
+	users, err := s.ListUsers(ctx, &store.FindUser{Role: &adminRole})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, admin.ID, users[0].ID)
+	generalSetting, err := s.GetInstanceGeneralSetting(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "instance"+strings.TrimPrefix(admin.Username, "admin"), generalSetting.Name)
+	setup, err = s.GetInstanceSetupSetting(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, setup)
+	assert.NotNil(t, setup.SetupTime)
+
+	// The setup stays locked once the admin is gone
+	require.NoError(t, s.DeleteUser(ctx, &store.DeleteUser{ID: admin.ID}))
+	again, err := s.SetupInstance(ctx, &store.User{Username: "late", Password: "hash", Role: store.RoleAdmin, PasswordExpires: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	assert.Nil(t, again)
+}
+
+func TestInstanceStorageSetting(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+
+	storageSetting, err := s.GetInstanceStorageSetting(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, storepb.InstanceStorageSetting_STORAGE_TYPE_UNSPECIFIED, storageSetting.StorageType)
+
+	_, err = s.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+		Key: storepb.InstanceSettingKey_STORAGE,
+		Value: &storepb.InstanceSetting_StorageSetting{StorageSetting: &storepb.InstanceStorageSetting{
+			StorageType:       storepb.InstanceStorageSetting_S3,
+			UploadSizeLimitMb: 64,
+			S3Config:          &storepb.StorageS3Config{AccessKeyId: "key", AccessKeySecret: "secret", Bucket: "files"},
+		}},
+	})
+	require.NoError(t, err)
+	storageSetting, err = s.GetInstanceStorageSetting(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, storepb.InstanceStorageSetting_S3, storageSetting.StorageType)
+	assert.Equal(t, int32(64), storageSetting.UploadSizeLimitMb)
+
+	// The setting is read back from the database, not only from the cache
+	list, err := s.ListInstanceSettings(ctx, &store.FindInstanceSetting{Name: storepb.InstanceSettingKey_STORAGE.String()})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "secret", list[0].GetStorageSetting().S3Config.AccessKeySecret)
+}
+
+func TestUpdateInstanceSetting(t *testing.T) {
+	ctx := context.Background()
+	// Two replicas sharing the database.
+	s := newTestStore(t)
+	other := store.New(s.GetDriver(), nil)
+	addProvider := func(setting *storepb.InstanceSetting, id string) *storepb.InstanceSetting {
+		providers := append(setting.GetIdentityProviderSetting().GetProviders(), &storepb.IdentityProvider{Id: id})
+		return &storepb.InstanceSetting{
+			Key:   storepb.InstanceSettingKey_IDENTITY_PROVIDERS,
+			Value: &storepb.InstanceSetting_IdentityProviderSetting{IdentityProviderSetting: &storepb.InstanceIdentityProviderSetting{Providers: providers}},
+		}
+	}
+	providerIDs := func(setting *storepb.InstanceSetting) []string {
+		ids := []string{}
+		for _, provider := range setting.GetIdentityProviderSetting().GetProviders() {
+			ids = append(ids, provider.Id)
+		}
+		return ids
+	}
+
+	// The other replica changes the setting after it was read, the update runs again on top of it
+	calls := 0
+	updated, err := s.UpdateInstanceSetting(ctx, storepb.InstanceSettingKey_IDENTITY_PROVIDERS, func(setting *storepb.InstanceSetting) (*storepb.InstanceSetting, error) {
+		calls++
+		if calls == 1 {
+			assert.Nil(t, setting)
+			_, err := other.UpdateInstanceSetting(ctx, storepb.InstanceSettingKey_IDENTITY_PROVIDERS, func(setting *storepb.InstanceSetting) (*storepb.InstanceSetting, error) {
+				return addProvider(setting, "github"), nil
+			})
+			require.NoError(t, err)
+		}
+		return addProvider(setting, "google"), nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, []string{"github", "google"}, providerIDs(updated))
+	list, err := s.ListInstanceSettings(ctx, &store.FindInstanceSetting{Name: storepb.InstanceSettingKey_IDENTITY_PROVIDERS.String()})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, []string{"github", "google"}, providerIDs(list[0]))
+
+	// Returning nil leaves the setting as it is
+	unchanged, err := s.UpdateInstanceSetting(ctx, storepb.InstanceSettingKey_IDENTITY_PROVIDERS, func(*storepb.InstanceSetting) (*storepb.InstanceSetting, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"github", "google"}, providerIDs(unchanged))
+}
+
+func TestWatchInstanceSettings(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// Two replicas sharing the database.
+	s := newTestStore(t)
+	other := store.New(s.GetDriver(), nil)
+
+	var mu sync.Mutex
+	published := []*storepb.InstanceSecuritySetting{}
+	unsubscribe := s.SubscribeInstanceSettings(func(setting *storepb.InstanceSetting) {
+		if setting.Key == storepb.InstanceSettingKey_SECURITY {
+			mu.Lock()
+			defer mu.Unlock()
+			published = append(published, setting.GetSecuritySetting())
+		}
+	})
+	lastPublished := func() *storepb.InstanceSecuritySetting {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(published) == 0 {
+			return nil
+		}
+		return published[len(published)-1]
+	}
+	upsertSecurity := func(s *store.Store, lifetime int32) {
+		_, err := s.UpsertInstanceSetting(ctx, &storepb.InstanceSetting{
+			Key: storepb.InstanceSettingKey_SECURITY,
+			Value: &storepb.InstanceSetting_SecuritySetting{SecuritySetting: &storepb.InstanceSecuritySetting{
+				AccessTokenLifetimeSeconds: lifetime,
+			}},
+		})
+		require.NoError(t, err)
+	}
+
+	// Changes through the store are published right away
+	upsertSecurity(s, 600)
+	require.NotNil(t, lastPublished())
+	assert.Equal(t, int32(600), lastPublished().AccessTokenLifetimeSeconds)
+
+	// The cached setting is stale until the watcher sees the change of the other replica
+	security, err := s.GetInstanceSecuritySetting(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int32(600), security.AccessTokenLifetimeSeconds)
+	upsertSecurity(other, 900)
+	security, err = s.GetInstanceSecuritySetting(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int32(600), security.AccessTokenLifetimeSeconds)
+
+	go s.WatchInstanceSettings(ctx, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return lastPublished().AccessTokenLifetimeSeconds == 900
+	}, 5*time.Second, 10*time.Millisecond)
+	security, err = s.GetInstanceSecuritySetting(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int32(900), security.AccessTokenLifetimeSeconds)
+
+	// Unchanged settings are not published again
+	mu.Lock()
+	count := len(published)
+	mu.Unlock()
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	assert.Len(t, published, count)
+	mu.Unlock()
+
+	// Unsubscribed functions are not called anymore
+	unsubscribe()
+	upsertSecurity(s, 1200)
+	assert.Equal(t, int32(900), lastPublished().AccessTokenLifetimeSeconds)
+}
@@ -0,0 +1,154 @@
+package store
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	storepb "github.com/pixb/go-server/proto/gen/store"
+)
+
+// InstanceSettingNotifier is implemented by drivers whose database reports changes of instance
+// settings, so that changes made by other replicas are seen before the next poll.
+type InstanceSettingNotifier interface {
+	// ListenInstanceSettings calls changed after any connection changes an instance setting,
+	// until ctx is done.
+	ListenInstanceSettings(ctx context.Context, changed func()) error
+}
+
+// instanceSettingWatcher tracks the stored instance settings to tell subscribers about changes.
+type instanceSettingWatcher struct {
+	// mu serializes writes of settings with reloads, so that a reload does not mistake a value
+	// read before a write for a change.
+	mu sync.Mutex
+	// values are the raw values last seen by setting name.
+	values map[string]string
+
+	subscribersMu sync.Mutex
+	subscribers   map[int]func(*storepb.InstanceSetting)
+	nextID        int
+}
+
+// record remembers the stored value of a setting, mu must be held.
+func (w *instanceSettingWatcher) record(raw *InstanceSetting) {
+	if w.values == nil {
+		w.values = map[string]string{}
+	}
+	w.values[raw.Name] = raw.Value
+}
+
+func (w *instanceSettingWatcher) publish(settings ...*storepb.InstanceSetting) {
+	w.subscribersMu.Lock()
+	subscribers := make([]func(*storepb.InstanceSetting), 0, len(w.subscribers))
+	for _, fn := range w.subscribers {
+		subscribers = append(subscribers, fn)
+	}
+	w.subscribersMu.Unlock()
+
+	for _, setting := range settings {
+		for _, fn := range subscribers {
+			fn(setting)
+		}
+	}
+}
+
+// SubscribeInstanceSettings calls fn with every instance setting that changes, whether through
+// this store or, while WatchInstanceSettings runs, through another replica. A deleted setting is
+// passed without a value. fn is called by the goroutine that saw the change and must not block.
+// The returned function unsubscribes.
+func (s *Store) SubscribeInstanceSettings(fn func(*storepb.InstanceSetting)) func() {
+	w := &s.settingWatcher
+	w.subscribersMu.Lock()
+	defer w.subscribersMu.Unlock()
+	if w.subscribers == nil {
+		w.subscribers = map[int]func(*storepb.InstanceSetting){}
+	}
+	id := w.nextID
+	w.nextID++
+	w.subscribers[id] = fn
+	return func() {
+		w.subscribersMu.Lock()
+		defer w.subscribersMu.Unlock()
+		delete(w.subscribers, id)
+	}
+}
+
+// WatchInstanceSettings reloads the instance settings every interval, and whenever the database
+// reports a change if the driver implements InstanceSettingNotifier, until ctx is done. Changed
+// settings are removed from the instance setting cache and passed to subscribers.
+func (s *Store) WatchInstanceSettings(ctx context.Context, interval time.Duration) {
+	changed := make(chan struct{}, 1)
+	if notifier, ok := s.driver.(InstanceSettingNotifier); ok {
+		go func() {
+			err := notifier.ListenInstanceSettings(ctx, func() {
+				select {
+				case changed <- struct{}{}:
+				default:
+				}
+			})
+			if err != nil {
+				slog.Warn("failed to listen for instance setting changes, polling only", slog.Any("error", err))
+			}
+		}()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := s.reloadInstanceSettings(ctx); err != nil && ctx.Err() == nil {
+			slog.Warn("failed to reload instance settings", slog.Any("error", err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-changed:
+		}
+	}
+}
+
+// reloadInstanceSettings compares the stored settings with the values last seen and publishes
+// the ones that changed.
+func (s *Store) reloadInstanceSettings(ctx context.Context) error {
+	w := &s.settingWatcher
+	w.mu.Lock()
+	list, err := s.driver.ListInstanceSettings(ctx, &FindInstanceSetting{})
+	if err != nil {
+		w.mu.Unlock()
+		return err
+	}
+
+	changed := []*storepb.InstanceSetting{}
+	stored := map[string]bool{}
+	for _, raw := range list {
+		stored[raw.Name] = true
+		if value, ok := w.values[raw.Name]; ok && value == raw.Value {
+			continue
+		}
+		w.record(raw)
+		s.instanceSettingCache.Delete(ctx, raw.Name)
+		instanceSetting, err := convertInstanceSettingFromRaw(raw)
+		if err != nil {
+			slog.Warn("failed to convert instance setting", slog.String("name", raw.Name), slog.Any("error", err))
+			continue
+		}
+		if instanceSetting != nil {
+			changed = append(changed, instanceSetting)
+		}
+	}
+	for name := range w.values {
+		if stored[name] {
+			continue
+		}
+		delete(w.values, name)
+		s.instanceSettingCache.Delete(ctx, name)
+		if key, ok := storepb.InstanceSettingKey_value[name]; ok {
+			changed = append(changed, &storepb.InstanceSetting{Key: storepb.InstanceSettingKey(key)})
+		}
+	}
+	w.mu.Unlock()
+
+	w.publish(changed...)
+	return nil
+}
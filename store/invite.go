@@ -0,0 +1,82 @@
+package store
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// Invite lets people register while the registration policy requires an invite code.
+// Only the SHA-256 digest of the code is persisted.
+type Invite struct {
+	ID       int64
+	CodeHash string
+	// Role is the built-in role of the users who register with the invite.
+	Role      Role
+	MaxUses   int32
+	UseCount  int32
+	ExpiresAt time.Time
+	CreatorID int64
+	CreatedAt time.Time
+}
+
+type CreateInvite struct {
+	CodeHash  string
+	Role      Role
+	MaxUses   int32
+	ExpiresAt time.Time
+	CreatorID int64
+}
+
+type FindInvite struct {
+	ID       *int64
+	CodeHash *string
+}
+
+type DeleteInvite struct {
+	ID int64
+}
+
+// UseInvite counts a registration against an invite and creates its user in the same
+// transaction.
+type UseInvite struct {
+	ID   int64
+	User *User
+}
+
+func (s *Store) CreateInvite(ctx context.Context, create *CreateInvite) (*Invite, error) {
+	return s.driver.CreateInvite(ctx, create)
+}
+
+// ListInvites returns the invites matching find, newest first.
+func (s *Store) ListInvites(ctx context.Context, find *FindInvite) ([]*Invite, error) {
+	return s.driver.ListInvites(ctx, find)
+}
+
+// GetInvite returns the invite matching find, or nil if there is none.
+func (s *Store) GetInvite(ctx context.Context, find *FindInvite) (*Invite, error) {
+	list, err := s.ListInvites(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+// UseInvite creates the user of a registration with an invite. It returns nil when the
+// invite is gone, expired or used up, so that concurrent registrations cannot exceed
+// its uses.
+func (s *Store) UseInvite(ctx context.Context, use *UseInvite) (*User, error) {
+	user, err := s.driver.UseInvite(ctx, use)
+	if err != nil || user == nil {
+		return nil, err
+	}
+	s.userCache.Set(ctx, strconv.FormatInt(user.ID, 10), user)
+	return user, nil
+}
+
+func (s *Store) DeleteInvite(ctx context.Context, delete *DeleteInvite) error {
+	return s.driver.DeleteInvite(ctx, delete)
+}
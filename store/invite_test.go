@@ -0,0 +1,88 @@
+package store_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pixb/go-server/store"
+)
+
+func TestInvites(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+
+	invite, err := s.CreateInvite(ctx, &store.CreateInvite{
+		CodeHash:  "hash",
+		Role:      store.RoleAdmin,
+		MaxUses:   3,
+		ExpiresAt: time.Now().Add(time.Hour),
+		CreatorID: 1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), invite.UseCount)
+	codeHash := "hash"
+	found, err := s.GetInvite(ctx, &store.FindInvite{CodeHash: &codeHash})
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, invite.ID, found.ID)
+	assert.Equal(t, store.RoleAdmin, found.Role)
+
+	// Concurrent registrations cannot use an invite more often than allowed
+	const registrations = 8
+	users := make(chan *store.User, registrations)
+	var wg sync.WaitGroup
+	for i := range registrations {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			user, err := s.UseInvite(ctx, &store.UseInvite{ID: invite.ID, User: &store.User{
+				Username:        fmt.Sprintf("user%d", i),
+				Email:           fmt.Sprintf("user%d@example.com", i),
+				Password:        "hash",
+				Role:            found.Role,
+				PasswordExpires: time.Now().Add(time.Hour),
+			}})
+			assert.NoError(t, err)
+			users <- user
+		}()
+	}
+	wg.Wait()
+	close(users)
+	created := 0
+	for user := range users {
+		if user != nil {
+			assert.Equal(t, store.RoleAdmin, user.Role)
+			created++
+		}
+	}
+	assert.Equal(t, 3, created)
+	found, err = s.GetInvite(ctx, &store.FindInvite{ID: &invite.ID})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), found.UseCount)
+	allUsers, err := s.ListUsers(ctx, &store.FindUser{})
+	require.NoError(t, err)
+	assert.Len(t, allUsers, 3)
+
+	// Expired invites cannot be used
+	expired, err := s.CreateInvite(ctx, &store.CreateInvite{CodeHash: "expired", Role: store.RoleUser, MaxUses: 1, ExpiresAt: time.Now().Add(-time.Minute)})
+	require.NoError(t, err)
+	user, err := s.UseInvite(ctx, &store.UseInvite{ID: expired.ID, User: &store.User{Username: "late", Email: "late@example.com", Password: "hash", Role: store.RoleUser, PasswordExpires: time.Now().Add(time.Hour)}})
+	require.NoError(t, err)
+	assert.Nil(t, user)
+
+	invites, err := s.ListInvites(ctx, &store.FindInvite{})
+	require.NoError(t, err)
+	require.Len(t, invites, 2)
+	assert.Equal(t, expired.ID, invites[0].ID)
+
+	require.NoError(t, s.DeleteInvite(ctx, &store.DeleteInvite{ID: invite.ID}))
+	found, err = s.GetInvite(ctx, &store.FindInvite{ID: &invite.ID})
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
@@ -0,0 +1,83 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// LoginAttemptScope is what failed sign-in attempts are counted for.
+type LoginAttemptScope string
+
+const (
+	// LoginAttemptScopeAccount counts failed attempts by username, whether the user exists or not.
+	LoginAttemptScopeAccount LoginAttemptScope = "ACCOUNT"
+	// LoginAttemptScopeIP counts failed attempts by client IP address.
+	LoginAttemptScopeIP LoginAttemptScope = "IP"
+)
+
+func (s LoginAttemptScope) String() string {
+	return string(s)
+}
+
+// LoginAttempt is the failed sign-in counter of an account or IP address.
+type LoginAttempt struct {
+	ID           int64
+	Scope        LoginAttemptScope
+	Identifier   string
+	FailedCount  int32
+	LastFailedAt time.Time
+	// LockedUntil is set while further attempts are refused.
+	LockedUntil *time.Time
+}
+
+type RecordLoginFailure struct {
+	Scope      LoginAttemptScope
+	Identifier string
+	// ResetBefore restarts the count when the previous failure happened before this time.
+	ResetBefore time.Time
+}
+
+type UpdateLoginAttempt struct {
+	ID          int64
+	LockedUntil *time.Time
+}
+
+type FindLoginAttempt struct {
+	Scope      *LoginAttemptScope
+	Identifier *string
+}
+
+type DeleteLoginAttempt struct {
+	Scope      LoginAttemptScope
+	Identifier string
+}
+
+// RecordLoginFailure atomically increments the failed attempt counter of an account or IP address.
+func (s *Store) RecordLoginFailure(ctx context.Context, record *RecordLoginFailure) (*LoginAttempt, error) {
+	return s.driver.RecordLoginFailure(ctx, record)
+}
+
+func (s *Store) UpdateLoginAttempt(ctx context.Context, update *UpdateLoginAttempt) (*LoginAttempt, error) {
+	return s.driver.UpdateLoginAttempt(ctx, update)
+}
+
+func (s *Store) ListLoginAttempts(ctx context.Context, find *FindLoginAttempt) ([]*LoginAttempt, error) {
+	return s.driver.ListLoginAttempts(ctx, find)
+}
+
+// GetLoginAttempt returns the counter matching find, or nil if there were no failed attempts.
+func (s *Store) GetLoginAttempt(ctx context.Context, find *FindLoginAttempt) (*LoginAttempt, error) {
+	list, err := s.ListLoginAttempts(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+// DeleteLoginAttempts resets the counter of an account or IP address, which also lifts its lock.
+func (s *Store) DeleteLoginAttempts(ctx context.Context, delete *DeleteLoginAttempt) error {
+	return s.driver.DeleteLoginAttempts(ctx, delete)
+}
@@ -0,0 +1,60 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pixb/go-server/store"
+)
+
+func TestLoginAttempts(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+
+	record := &store.RecordLoginFailure{
+		Scope:       store.LoginAttemptScopeAccount,
+		Identifier:  "testuser",
+		ResetBefore: time.Now().Add(-time.Hour),
+	}
+	for i := int32(1); i <= 3; i++ {
+		attempt, err := s.RecordLoginFailure(ctx, record)
+		require.NoError(t, err)
+		assert.Equal(t, i, attempt.FailedCount)
+		assert.Nil(t, attempt.LockedUntil)
+	}
+
+	// Counters are kept apart by scope
+	ipAttempt, err := s.RecordLoginFailure(ctx, &store.RecordLoginFailure{
+		Scope:       store.LoginAttemptScopeIP,
+		Identifier:  "testuser",
+		ResetBefore: record.ResetBefore,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), ipAttempt.FailedCount)
+
+	// Locking sets locked_until
+	scope, identifier := store.LoginAttemptScopeAccount, "testuser"
+	attempt, err := s.GetLoginAttempt(ctx, &store.FindLoginAttempt{Scope: &scope, Identifier: &identifier})
+	require.NoError(t, err)
+	lockedUntil := time.Now().Add(time.Minute)
+	attempt, err = s.UpdateLoginAttempt(ctx, &store.UpdateLoginAttempt{ID: attempt.ID, LockedUntil: &lockedUntil})
+	require.NoError(t, err)
+	require.NotNil(t, attempt.LockedUntil)
+	assert.WithinDuration(t, lockedUntil, *attempt.LockedUntil, time.Second)
+
+	// A failure after the window restarts the count
+	record.ResetBefore = time.Now().Add(time.Second)
+	attempt, err = s.RecordLoginFailure(ctx, record)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), attempt.FailedCount)
+
+	// Deleting the counter unlocks the account
+	require.NoError(t, s.DeleteLoginAttempts(ctx, &store.DeleteLoginAttempt{Scope: scope, Identifier: identifier}))
+	attempt, err = s.GetLoginAttempt(ctx, &store.FindLoginAttempt{Scope: &scope, Identifier: &identifier})
+	require.NoError(t, err)
+	assert.Nil(t, attempt)
+}
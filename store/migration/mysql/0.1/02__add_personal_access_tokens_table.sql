@@ -0,0 +1,17 @@
+-- personal_access_tokens table
+CREATE TABLE personal_access_tokens (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  user_id bigint NOT NULL,
+  token_hash varchar(64) NOT NULL,
+  description text NOT NULL,
+  expires_at DATETIME NULL,
+  last_used_at DATETIME NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  deleted_at DATETIME NULL,
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_personal_access_tokens_token_hash (token_hash),
+  KEY idx_personal_access_tokens_deleted_at (deleted_at),
+  KEY idx_personal_access_tokens_user_id (user_id),
+  CONSTRAINT personal_access_tokens_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id)
+);
@@ -0,0 +1,11 @@
+-- revoked_tokens table
+CREATE TABLE revoked_tokens (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  jti varchar(64) NOT NULL,
+  user_id bigint NOT NULL,
+  expires_at DATETIME NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_revoked_tokens_jti (jti),
+  KEY idx_revoked_tokens_expires_at (expires_at)
+);
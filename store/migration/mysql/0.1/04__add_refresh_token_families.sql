@@ -0,0 +1,18 @@
+-- refresh token families
+ALTER TABLE refresh_tokens ADD COLUMN family_id varchar(64) NOT NULL DEFAULT '';
+
+-- Existing tokens each start their own family
+UPDATE refresh_tokens SET family_id = CAST(id AS CHAR) WHERE family_id = '';
+
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens (family_id);
+
+-- security_events table
+CREATE TABLE security_events (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  user_id bigint NOT NULL,
+  `type` varchar(64) NOT NULL,
+  detail text NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  KEY idx_security_events_user_id (user_id)
+);
@@ -0,0 +1,4 @@
+-- client information of the session a refresh token belongs to
+ALTER TABLE refresh_tokens ADD COLUMN user_agent varchar(512) NOT NULL DEFAULT '';
+
+ALTER TABLE refresh_tokens ADD COLUMN ip_address varchar(64) NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+-- Refresh tokens are now stored as hex encoded SHA-256 digests.
+UPDATE refresh_tokens SET token = SHA2(token, 256);
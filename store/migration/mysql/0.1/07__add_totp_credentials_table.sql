@@ -0,0 +1,14 @@
+-- totp_credentials table
+CREATE TABLE totp_credentials (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  user_id bigint NOT NULL,
+  secret text NOT NULL,
+  recovery_codes text NOT NULL,
+  enabled BOOLEAN NOT NULL DEFAULT FALSE,
+  last_used_step bigint NOT NULL DEFAULT 0,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_totp_credentials_user_id (user_id),
+  CONSTRAINT totp_credentials_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id)
+);
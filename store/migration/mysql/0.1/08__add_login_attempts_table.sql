@@ -0,0 +1,11 @@
+-- login_attempts table
+CREATE TABLE login_attempts (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  scope VARCHAR(16) NOT NULL,
+  identifier VARCHAR(256) NOT NULL,
+  failed_count INT NOT NULL DEFAULT 0,
+  last_failed_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  locked_until DATETIME NULL,
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_login_attempts_scope_identifier (scope, identifier)
+);
@@ -0,0 +1,10 @@
+-- password_histories table
+CREATE TABLE password_histories (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  user_id bigint NOT NULL,
+  password_hash VARCHAR(256) NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  KEY idx_password_histories_user_id (user_id),
+  CONSTRAINT password_histories_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id)
+);
@@ -0,0 +1,13 @@
+-- password_reset_tokens table
+CREATE TABLE password_reset_tokens (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  user_id bigint NOT NULL,
+  token_hash VARCHAR(64) NOT NULL,
+  expires_at DATETIME NOT NULL,
+  used_at DATETIME NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_password_reset_tokens_token_hash (token_hash),
+  KEY idx_password_reset_tokens_user_id (user_id),
+  CONSTRAINT password_reset_tokens_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id)
+);
@@ -0,0 +1,40 @@
+-- roles table
+CREATE TABLE roles (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  name varchar(64) NOT NULL,
+  description text NOT NULL,
+  built_in BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_roles_name (name)
+);
+
+-- role_permissions table
+CREATE TABLE role_permissions (
+  role_id bigint NOT NULL,
+  permission varchar(64) NOT NULL,
+  PRIMARY KEY (role_id, permission),
+  CONSTRAINT role_permissions_role_id_fkey FOREIGN KEY (role_id) REFERENCES roles(id)
+);
+
+-- user_roles table
+CREATE TABLE user_roles (
+  user_id bigint NOT NULL,
+  role_id bigint NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (user_id, role_id),
+  KEY idx_user_roles_role_id (role_id),
+  CONSTRAINT user_roles_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id),
+  CONSTRAINT user_roles_role_id_fkey FOREIGN KEY (role_id) REFERENCES roles(id)
+);
+
+-- built-in roles
+INSERT INTO roles (name, description, built_in) VALUES ('admin', 'Administrators of the instance', TRUE);
+INSERT INTO roles (name, description, built_in) VALUES ('user', 'Regular users', TRUE);
+INSERT INTO role_permissions (role_id, permission) SELECT id, 'users.read' FROM roles WHERE name = 'admin';
+INSERT INTO role_permissions (role_id, permission) SELECT id, 'users.write' FROM roles WHERE name = 'admin';
+INSERT INTO role_permissions (role_id, permission) SELECT id, 'roles.read' FROM roles WHERE name = 'admin';
+INSERT INTO role_permissions (role_id, permission) SELECT id, 'roles.write' FROM roles WHERE name = 'admin';
+INSERT INTO role_permissions (role_id, permission) SELECT id, 'settings.read' FROM roles WHERE name = 'admin';
+INSERT INTO role_permissions (role_id, permission) SELECT id, 'settings.update' FROM roles WHERE name = 'admin';
@@ -0,0 +1,12 @@
+-- user_identities table, links users to the subjects of external identity providers
+CREATE TABLE user_identities (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  user_id bigint NOT NULL,
+  provider varchar(64) NOT NULL,
+  subject varchar(255) NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_user_identities_provider_subject (provider, subject),
+  KEY idx_user_identities_user_id (user_id),
+  CONSTRAINT user_identities_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id)
+);
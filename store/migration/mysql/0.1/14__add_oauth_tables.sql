@@ -0,0 +1,55 @@
+-- oauth_clients table, the applications that use the instance as an authorization server
+CREATE TABLE oauth_clients (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  client_id VARCHAR(64) NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  secret_hash VARCHAR(64) NOT NULL DEFAULT '',
+  redirect_uris TEXT NOT NULL,
+  grant_types TEXT NOT NULL,
+  scopes TEXT NOT NULL,
+  creator_id bigint NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_oauth_clients_client_id (client_id),
+  CONSTRAINT oauth_clients_creator_id_fkey FOREIGN KEY (creator_id) REFERENCES users(id)
+);
+
+-- oauth_authorization_codes table
+CREATE TABLE oauth_authorization_codes (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  code_hash VARCHAR(64) NOT NULL,
+  client_id bigint NOT NULL,
+  user_id bigint NOT NULL,
+  redirect_uri TEXT NOT NULL,
+  scopes TEXT NOT NULL,
+  nonce VARCHAR(255) NOT NULL DEFAULT '',
+  code_challenge VARCHAR(128) NOT NULL,
+  expires_at DATETIME NOT NULL,
+  used_at DATETIME NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_oauth_authorization_codes_code_hash (code_hash),
+  KEY idx_oauth_authorization_codes_client_id (client_id),
+  CONSTRAINT oauth_authorization_codes_client_id_fkey FOREIGN KEY (client_id) REFERENCES oauth_clients(id),
+  CONSTRAINT oauth_authorization_codes_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- oauth_consents table, the scopes a user has granted to a client
+CREATE TABLE oauth_consents (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  user_id bigint NOT NULL,
+  client_id bigint NOT NULL,
+  scopes TEXT NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_oauth_consents_user_id_client_id (user_id, client_id),
+  KEY idx_oauth_consents_client_id (client_id),
+  CONSTRAINT oauth_consents_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id),
+  CONSTRAINT oauth_consents_client_id_fkey FOREIGN KEY (client_id) REFERENCES oauth_clients(id)
+);
+
+-- client registration permissions of the built-in admin role
+INSERT INTO role_permissions (role_id, permission) SELECT id, 'oauth_clients.read' FROM roles WHERE name = 'admin';
+INSERT INTO role_permissions (role_id, permission) SELECT id, 'oauth_clients.write' FROM roles WHERE name = 'admin';
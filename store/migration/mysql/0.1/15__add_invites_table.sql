@@ -0,0 +1,14 @@
+-- invites table, the invite codes people register with
+CREATE TABLE invites (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  code_hash VARCHAR(64) NOT NULL,
+  role VARCHAR(64) NOT NULL DEFAULT 'user',
+  max_uses INT NOT NULL DEFAULT 1,
+  use_count INT NOT NULL DEFAULT 0,
+  expires_at DATETIME NOT NULL,
+  -- 0 when the invite was created by a service principal.
+  creator_id bigint NOT NULL DEFAULT 0,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_invites_code_hash (code_hash)
+);
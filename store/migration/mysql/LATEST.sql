@@ -15,6 +15,7 @@ CREATE TABLE users (
   phone varchar(20) NULL,
   email varchar(100) NULL,
   `role` varchar(20) DEFAULT 'user',
+  email_verified BOOLEAN NOT NULL DEFAULT FALSE,
   password_expires DATETIME NOT NULL,
   created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
   updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
@@ -30,6 +31,9 @@ CREATE TABLE refresh_tokens (
   id BIGINT AUTO_INCREMENT NOT NULL,
   user_id bigint NOT NULL,
   token text NOT NULL,
+  family_id varchar(64) NOT NULL DEFAULT '',
+  user_agent varchar(512) NOT NULL DEFAULT '',
+  ip_address varchar(64) NOT NULL DEFAULT '',
   expires_at DATETIME NOT NULL,
   revoked boolean DEFAULT false,
   created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
@@ -39,5 +43,239 @@ CREATE TABLE refresh_tokens (
   UNIQUE KEY idx_refresh_tokens_token (token(255)),
   KEY idx_refresh_tokens_deleted_at (deleted_at),
   KEY idx_refresh_tokens_user_id (user_id),
+  KEY idx_refresh_tokens_family_id (family_id),
   CONSTRAINT refresh_tokens_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id)
 );
+
+
+-- personal_access_tokens table
+CREATE TABLE personal_access_tokens (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  user_id bigint NOT NULL,
+  token_hash varchar(64) NOT NULL,
+  description text NOT NULL,
+  expires_at DATETIME NULL,
+  last_used_at DATETIME NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  deleted_at DATETIME NULL,
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_personal_access_tokens_token_hash (token_hash),
+  KEY idx_personal_access_tokens_deleted_at (deleted_at),
+  KEY idx_personal_access_tokens_user_id (user_id),
+  CONSTRAINT personal_access_tokens_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- revoked_tokens table
+CREATE TABLE revoked_tokens (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  jti varchar(64) NOT NULL,
+  user_id bigint NOT NULL,
+  expires_at DATETIME NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_revoked_tokens_jti (jti),
+  KEY idx_revoked_tokens_expires_at (expires_at)
+);
+
+-- security_events table
+CREATE TABLE security_events (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  user_id bigint NOT NULL,
+  `type` varchar(64) NOT NULL,
+  detail text NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  KEY idx_security_events_user_id (user_id)
+);
+
+-- totp_credentials table
+CREATE TABLE totp_credentials (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  user_id bigint NOT NULL,
+  secret text NOT NULL,
+  recovery_codes text NOT NULL,
+  enabled BOOLEAN NOT NULL DEFAULT FALSE,
+  last_used_step bigint NOT NULL DEFAULT 0,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_totp_credentials_user_id (user_id),
+  CONSTRAINT totp_credentials_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- login_attempts table
+CREATE TABLE login_attempts (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  scope VARCHAR(16) NOT NULL,
+  identifier VARCHAR(256) NOT NULL,
+  failed_count INT NOT NULL DEFAULT 0,
+  last_failed_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  locked_until DATETIME NULL,
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_login_attempts_scope_identifier (scope, identifier)
+);
+
+-- password_histories table
+CREATE TABLE password_histories (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  user_id bigint NOT NULL,
+  password_hash VARCHAR(256) NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  KEY idx_password_histories_user_id (user_id),
+  CONSTRAINT password_histories_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- password_reset_tokens table
+CREATE TABLE password_reset_tokens (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  user_id bigint NOT NULL,
+  token_hash VARCHAR(64) NOT NULL,
+  expires_at DATETIME NOT NULL,
+  used_at DATETIME NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_password_reset_tokens_token_hash (token_hash),
+  KEY idx_password_reset_tokens_user_id (user_id),
+  CONSTRAINT password_reset_tokens_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- email_verifications table
+CREATE TABLE email_verifications (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  user_id bigint NOT NULL,
+  email varchar(100) NOT NULL,
+  token_hash VARCHAR(64) NOT NULL,
+  expires_at DATETIME NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_email_verifications_token_hash (token_hash),
+  KEY idx_email_verifications_user_id (user_id),
+  CONSTRAINT email_verifications_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- roles table
+CREATE TABLE roles (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  name varchar(64) NOT NULL,
+  description text NOT NULL,
+  built_in BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_roles_name (name)
+);
+
+-- role_permissions table
+CREATE TABLE role_permissions (
+  role_id bigint NOT NULL,
+  permission varchar(64) NOT NULL,
+  PRIMARY KEY (role_id, permission),
+  CONSTRAINT role_permissions_role_id_fkey FOREIGN KEY (role_id) REFERENCES roles(id)
+);
+
+-- user_roles table
+CREATE TABLE user_roles (
+  user_id bigint NOT NULL,
+  role_id bigint NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (user_id, role_id),
+  KEY idx_user_roles_role_id (role_id),
+  CONSTRAINT user_roles_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id),
+  CONSTRAINT user_roles_role_id_fkey FOREIGN KEY (role_id) REFERENCES roles(id)
+);
+
+-- built-in roles
+INSERT INTO roles (name, description, built_in) VALUES ('admin', 'Administrators of the instance', TRUE);
+INSERT INTO roles (name, description, built_in) VALUES ('user', 'Regular users', TRUE);
+INSERT INTO role_permissions (role_id, permission) SELECT id, 'users.read' FROM roles WHERE name = 'admin';
+INSERT INTO role_permissions (role_id, permission) SELECT id, 'users.write' FROM roles WHERE name = 'admin';
+INSERT INTO role_permissions (role_id, permission) SELECT id, 'roles.read' FROM roles WHERE name = 'admin';
+INSERT INTO role_permissions (role_id, permission) SELECT id, 'roles.write' FROM roles WHERE name = 'admin';
+INSERT INTO role_permissions (role_id, permission) SELECT id, 'settings.read' FROM roles WHERE name = 'admin';
+INSERT INTO role_permissions (role_id, permission) SELECT id, 'settings.update' FROM roles WHERE name = 'admin';
+
+-- user_identities table, links users to the subjects of external identity providers
+CREATE TABLE user_identities (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  user_id bigint NOT NULL,
+  provider varchar(64) NOT NULL,
+  subject varchar(255) NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_user_identities_provider_subject (provider, subject),
+  KEY idx_user_identities_user_id (user_id),
+  CONSTRAINT user_identities_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- oauth_clients table, the applications that use the instance as an authorization server
+CREATE TABLE oauth_clients (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  client_id VARCHAR(64) NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  secret_hash VARCHAR(64) NOT NULL DEFAULT '',
+  redirect_uris TEXT NOT NULL,
+  grant_types TEXT NOT NULL,
+  scopes TEXT NOT NULL,
+  creator_id bigint NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_oauth_clients_client_id (client_id),
+  CONSTRAINT oauth_clients_creator_id_fkey FOREIGN KEY (creator_id) REFERENCES users(id)
+);
+
+-- oauth_authorization_codes table
+CREATE TABLE oauth_authorization_codes (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  code_hash VARCHAR(64) NOT NULL,
+  client_id bigint NOT NULL,
+  user_id bigint NOT NULL,
+  redirect_uri TEXT NOT NULL,
+  scopes TEXT NOT NULL,
+  nonce VARCHAR(255) NOT NULL DEFAULT '',
+  code_challenge VARCHAR(128) NOT NULL,
+  expires_at DATETIME NOT NULL,
+  used_at DATETIME NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_oauth_authorization_codes_code_hash (code_hash),
+  KEY idx_oauth_authorization_codes_client_id (client_id),
+  CONSTRAINT oauth_authorization_codes_client_id_fkey FOREIGN KEY (client_id) REFERENCES oauth_clients(id),
+  CONSTRAINT oauth_authorization_codes_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+-- oauth_consents table, the scopes a user has granted to a client
+CREATE TABLE oauth_consents (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  user_id bigint NOT NULL,
+  client_id bigint NOT NULL,
+  scopes TEXT NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_oauth_consents_user_id_client_id (user_id, client_id),
+  KEY idx_oauth_consents_client_id (client_id),
+  CONSTRAINT oauth_consents_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id),
+  CONSTRAINT oauth_consents_client_id_fkey FOREIGN KEY (client_id) REFERENCES oauth_clients(id)
+);
+
+-- client registration permissions of the built-in admin role
+INSERT INTO role_permissions (role_id, permission) SELECT id, 'oauth_clients.read' FROM roles WHERE name = 'admin';
+INSERT INTO role_permissions (role_id, permission) SELECT id, 'oauth_clients.write' FROM roles WHERE name = 'admin';
+
+-- invites table, the invite codes people register with
+CREATE TABLE invites (
+  id BIGINT AUTO_INCREMENT NOT NULL,
+  code_hash VARCHAR(64) NOT NULL,
+  role VARCHAR(64) NOT NULL DEFAULT 'user',
+  max_uses INT NOT NULL DEFAULT 1,
+  use_count INT NOT NULL DEFAULT 0,
+  expires_at DATETIME NOT NULL,
+  -- 0 when the invite was created by a service principal.
+  creator_id bigint NOT NULL DEFAULT 0,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_invites_code_hash (code_hash)
+);
@@ -0,0 +1,19 @@
+-- personal_access_tokens table for PostgreSQL
+
+CREATE TABLE public.personal_access_tokens (
+    id bigserial NOT NULL,
+    user_id bigint NOT NULL,
+    token_hash varchar(64) NOT NULL,
+    description text NOT NULL DEFAULT '',
+    expires_at timestamptz NULL,
+    last_used_at timestamptz NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at timestamptz NULL,
+    CONSTRAINT personal_access_tokens_pkey PRIMARY KEY (id),
+    CONSTRAINT personal_access_tokens_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id)
+);
+
+CREATE UNIQUE INDEX idx_personal_access_tokens_token_hash ON public.personal_access_tokens USING btree (token_hash);
+CREATE INDEX idx_personal_access_tokens_deleted_at ON public.personal_access_tokens USING btree (deleted_at);
+CREATE INDEX idx_personal_access_tokens_user_id ON public.personal_access_tokens USING btree (user_id);
@@ -0,0 +1,13 @@
+-- revoked_tokens table for PostgreSQL
+
+CREATE TABLE public.revoked_tokens (
+    id bigserial NOT NULL,
+    jti varchar(64) NOT NULL,
+    user_id bigint NOT NULL,
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT revoked_tokens_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX idx_revoked_tokens_jti ON public.revoked_tokens USING btree (jti);
+CREATE INDEX idx_revoked_tokens_expires_at ON public.revoked_tokens USING btree (expires_at);
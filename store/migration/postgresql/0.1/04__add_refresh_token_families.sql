@@ -0,0 +1,19 @@
+-- refresh token families and security_events table for PostgreSQL
+
+ALTER TABLE public.refresh_tokens ADD COLUMN family_id varchar(64) NOT NULL DEFAULT '';
+
+-- Existing tokens each start their own family
+UPDATE public.refresh_tokens SET family_id = id::text WHERE family_id = '';
+
+CREATE INDEX idx_refresh_tokens_family_id ON public.refresh_tokens USING btree (family_id);
+
+CREATE TABLE public.security_events (
+    id bigserial NOT NULL,
+    user_id bigint NOT NULL,
+    "type" varchar(64) NOT NULL,
+    detail text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT security_events_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX idx_security_events_user_id ON public.security_events USING btree (user_id);
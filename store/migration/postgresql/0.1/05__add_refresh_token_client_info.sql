@@ -0,0 +1,5 @@
+-- client information of the session a refresh token belongs to
+
+ALTER TABLE public.refresh_tokens ADD COLUMN user_agent text NOT NULL DEFAULT '';
+
+ALTER TABLE public.refresh_tokens ADD COLUMN ip_address varchar(64) NOT NULL DEFAULT '';
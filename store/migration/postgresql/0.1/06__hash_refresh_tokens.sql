@@ -0,0 +1,3 @@
+-- Refresh tokens are now stored as hex encoded SHA-256 digests.
+
+UPDATE public.refresh_tokens SET token = encode(sha256(convert_to(token, 'UTF8')), 'hex');
@@ -0,0 +1,16 @@
+-- totp_credentials table for PostgreSQL
+
+CREATE TABLE public.totp_credentials (
+    id bigserial NOT NULL,
+    user_id bigint NOT NULL,
+    secret text NOT NULL,
+    recovery_codes text NOT NULL DEFAULT '',
+    enabled boolean NOT NULL DEFAULT false,
+    last_used_step bigint NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT totp_credentials_pkey PRIMARY KEY (id),
+    CONSTRAINT totp_credentials_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id)
+);
+
+CREATE UNIQUE INDEX idx_totp_credentials_user_id ON public.totp_credentials USING btree (user_id);
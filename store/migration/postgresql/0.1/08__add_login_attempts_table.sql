@@ -0,0 +1,13 @@
+-- login_attempts table for PostgreSQL
+
+CREATE TABLE public.login_attempts (
+    id bigserial NOT NULL,
+    scope varchar(16) NOT NULL,
+    identifier varchar(256) NOT NULL,
+    failed_count integer NOT NULL DEFAULT 0,
+    last_failed_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until timestamptz NULL,
+    CONSTRAINT login_attempts_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX idx_login_attempts_scope_identifier ON public.login_attempts USING btree (scope, identifier);
@@ -0,0 +1,12 @@
+-- password_histories table for PostgreSQL
+
+CREATE TABLE public.password_histories (
+    id bigserial NOT NULL,
+    user_id bigint NOT NULL,
+    password_hash varchar(256) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT password_histories_pkey PRIMARY KEY (id),
+    CONSTRAINT password_histories_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id)
+);
+
+CREATE INDEX idx_password_histories_user_id ON public.password_histories USING btree (user_id);
@@ -0,0 +1,19 @@
+-- email verification state of users
+
+ALTER TABLE public.users ADD COLUMN email_verified boolean NOT NULL DEFAULT false;
+
+-- email_verifications table for PostgreSQL
+
+CREATE TABLE public.email_verifications (
+    id bigserial NOT NULL,
+    user_id bigint NOT NULL,
+    email varchar(100) NOT NULL,
+    token_hash varchar(64) NOT NULL,
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT email_verifications_pkey PRIMARY KEY (id),
+    CONSTRAINT email_verifications_token_hash_key UNIQUE (token_hash),
+    CONSTRAINT email_verifications_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id)
+);
+
+CREATE INDEX idx_email_verifications_user_id ON public.email_verifications USING btree (user_id);
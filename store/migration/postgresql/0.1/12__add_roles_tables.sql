@@ -0,0 +1,45 @@
+-- roles table for PostgreSQL
+
+CREATE TABLE public.roles (
+    id bigserial NOT NULL,
+    name varchar(64) NOT NULL,
+    description text NOT NULL DEFAULT '',
+    built_in boolean NOT NULL DEFAULT false,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT roles_pkey PRIMARY KEY (id),
+    CONSTRAINT roles_name_key UNIQUE (name)
+);
+
+-- role_permissions table for PostgreSQL
+
+CREATE TABLE public.role_permissions (
+    role_id bigint NOT NULL,
+    permission varchar(64) NOT NULL,
+    CONSTRAINT role_permissions_pkey PRIMARY KEY (role_id, permission),
+    CONSTRAINT role_permissions_role_id_fkey FOREIGN KEY (role_id) REFERENCES public.roles(id)
+);
+
+-- user_roles table for PostgreSQL
+
+CREATE TABLE public.user_roles (
+    user_id bigint NOT NULL,
+    role_id bigint NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT user_roles_pkey PRIMARY KEY (user_id, role_id),
+    CONSTRAINT user_roles_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id),
+    CONSTRAINT user_roles_role_id_fkey FOREIGN KEY (role_id) REFERENCES public.roles(id)
+);
+
+CREATE INDEX idx_user_roles_role_id ON public.user_roles USING btree (role_id);
+
+-- built-in roles
+
+INSERT INTO public.roles (name, description, built_in) VALUES ('admin', 'Administrators of the instance', TRUE);
+INSERT INTO public.roles (name, description, built_in) VALUES ('user', 'Regular users', TRUE);
+INSERT INTO public.role_permissions (role_id, permission) SELECT id, 'users.read' FROM public.roles WHERE name = 'admin';
+INSERT INTO public.role_permissions (role_id, permission) SELECT id, 'users.write' FROM public.roles WHERE name = 'admin';
+INSERT INTO public.role_permissions (role_id, permission) SELECT id, 'roles.read' FROM public.roles WHERE name = 'admin';
+INSERT INTO public.role_permissions (role_id, permission) SELECT id, 'roles.write' FROM public.roles WHERE name = 'admin';
+INSERT INTO public.role_permissions (role_id, permission) SELECT id, 'settings.read' FROM public.roles WHERE name = 'admin';
+INSERT INTO public.role_permissions (role_id, permission) SELECT id, 'settings.update' FROM public.roles WHERE name = 'admin';
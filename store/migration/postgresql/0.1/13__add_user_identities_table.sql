@@ -0,0 +1,14 @@
+-- user_identities table for PostgreSQL, links users to the subjects of external identity providers
+
+CREATE TABLE public.user_identities (
+    id bigserial NOT NULL,
+    user_id bigint NOT NULL,
+    provider varchar(64) NOT NULL,
+    subject varchar(255) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT user_identities_pkey PRIMARY KEY (id),
+    CONSTRAINT user_identities_provider_subject_key UNIQUE (provider, subject),
+    CONSTRAINT user_identities_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id)
+);
+
+CREATE INDEX idx_user_identities_user_id ON public.user_identities USING btree (user_id);
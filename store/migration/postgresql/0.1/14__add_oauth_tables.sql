@@ -0,0 +1,61 @@
+-- oauth_clients table for PostgreSQL, the applications that use the instance as an authorization server
+
+CREATE TABLE public.oauth_clients (
+    id bigserial NOT NULL,
+    client_id varchar(64) NOT NULL,
+    name varchar(255) NOT NULL,
+    secret_hash varchar(64) NOT NULL DEFAULT '',
+    redirect_uris text NOT NULL DEFAULT '',
+    grant_types text NOT NULL DEFAULT '',
+    scopes text NOT NULL DEFAULT '',
+    creator_id bigint NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT oauth_clients_pkey PRIMARY KEY (id),
+    CONSTRAINT oauth_clients_client_id_key UNIQUE (client_id),
+    CONSTRAINT oauth_clients_creator_id_fkey FOREIGN KEY (creator_id) REFERENCES public.users(id)
+);
+
+-- oauth_authorization_codes table for PostgreSQL
+
+CREATE TABLE public.oauth_authorization_codes (
+    id bigserial NOT NULL,
+    code_hash varchar(64) NOT NULL,
+    client_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    redirect_uri text NOT NULL,
+    scopes text NOT NULL DEFAULT '',
+    nonce varchar(255) NOT NULL DEFAULT '',
+    code_challenge varchar(128) NOT NULL,
+    expires_at timestamptz NOT NULL,
+    used_at timestamptz NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT oauth_authorization_codes_pkey PRIMARY KEY (id),
+    CONSTRAINT oauth_authorization_codes_code_hash_key UNIQUE (code_hash),
+    CONSTRAINT oauth_authorization_codes_client_id_fkey FOREIGN KEY (client_id) REFERENCES public.oauth_clients(id),
+    CONSTRAINT oauth_authorization_codes_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id)
+);
+
+CREATE INDEX idx_oauth_authorization_codes_client_id ON public.oauth_authorization_codes USING btree (client_id);
+
+-- oauth_consents table for PostgreSQL, the scopes a user has granted to a client
+
+CREATE TABLE public.oauth_consents (
+    id bigserial NOT NULL,
+    user_id bigint NOT NULL,
+    client_id bigint NOT NULL,
+    scopes text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT oauth_consents_pkey PRIMARY KEY (id),
+    CONSTRAINT oauth_consents_user_id_client_id_key UNIQUE (user_id, client_id),
+    CONSTRAINT oauth_consents_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id),
+    CONSTRAINT oauth_consents_client_id_fkey FOREIGN KEY (client_id) REFERENCES public.oauth_clients(id)
+);
+
+CREATE INDEX idx_oauth_consents_client_id ON public.oauth_consents USING btree (client_id);
+
+-- client registration permissions of the built-in admin role
+
+INSERT INTO public.role_permissions (role_id, permission) SELECT id, 'oauth_clients.read' FROM public.roles WHERE name = 'admin';
+INSERT INTO public.role_permissions (role_id, permission) SELECT id, 'oauth_clients.write' FROM public.roles WHERE name = 'admin';
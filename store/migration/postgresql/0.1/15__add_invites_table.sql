@@ -0,0 +1,15 @@
+-- invites table for PostgreSQL, the invite codes people register with
+
+CREATE TABLE public.invites (
+    id bigserial NOT NULL,
+    code_hash varchar(64) NOT NULL,
+    role varchar(64) NOT NULL DEFAULT 'user',
+    max_uses integer NOT NULL DEFAULT 1,
+    use_count integer NOT NULL DEFAULT 0,
+    expires_at timestamptz NOT NULL,
+    -- 0 when the invite was created by a service principal.
+    creator_id bigint NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT invites_pkey PRIMARY KEY (id),
+    CONSTRAINT invites_code_hash_key UNIQUE (code_hash)
+);
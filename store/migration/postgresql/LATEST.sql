@@ -14,6 +14,7 @@ CREATE TABLE public.users (
 	phone varchar(20) NULL,
 	email varchar(100) NULL,
 	"role" varchar(20) DEFAULT 'user'::character varying NULL,
+	email_verified boolean NOT NULL DEFAULT false,
 	password_expires timestamptz NOT NULL,
 	created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
 	updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
@@ -30,6 +31,9 @@ CREATE TABLE public.refresh_tokens (
     id bigserial NOT NULL,
     user_id bigint NOT NULL,
     token text NOT NULL,
+    family_id varchar(64) NOT NULL DEFAULT '',
+    user_agent text NOT NULL DEFAULT '',
+    ip_address varchar(64) NOT NULL DEFAULT '',
     expires_at timestamptz NOT NULL,
     revoked boolean DEFAULT false,
     created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
@@ -42,3 +46,267 @@ CREATE TABLE public.refresh_tokens (
 CREATE UNIQUE INDEX idx_refresh_tokens_token ON public.refresh_tokens USING btree (token);
 CREATE INDEX idx_refresh_tokens_deleted_at ON public.refresh_tokens USING btree (deleted_at);
 CREATE INDEX idx_refresh_tokens_user_id ON public.refresh_tokens USING btree (user_id);
+CREATE INDEX idx_refresh_tokens_family_id ON public.refresh_tokens USING btree (family_id);
+
+
+-- personal_access_tokens table for PostgreSQL
+
+CREATE TABLE public.personal_access_tokens (
+    id bigserial NOT NULL,
+    user_id bigint NOT NULL,
+    token_hash varchar(64) NOT NULL,
+    description text NOT NULL DEFAULT '',
+    expires_at timestamptz NULL,
+    last_used_at timestamptz NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at timestamptz NULL,
+    CONSTRAINT personal_access_tokens_pkey PRIMARY KEY (id),
+    CONSTRAINT personal_access_tokens_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id)
+);
+
+CREATE UNIQUE INDEX idx_personal_access_tokens_token_hash ON public.personal_access_tokens USING btree (token_hash);
+CREATE INDEX idx_personal_access_tokens_deleted_at ON public.personal_access_tokens USING btree (deleted_at);
+CREATE INDEX idx_personal_access_tokens_user_id ON public.personal_access_tokens USING btree (user_id);
+
+-- revoked_tokens table for PostgreSQL
+
+CREATE TABLE public.revoked_tokens (
+    id bigserial NOT NULL,
+    jti varchar(64) NOT NULL,
+    user_id bigint NOT NULL,
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT revoked_tokens_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX idx_revoked_tokens_jti ON public.revoked_tokens USING btree (jti);
+CREATE INDEX idx_revoked_tokens_expires_at ON public.revoked_tokens USING btree (expires_at);
+
+-- security_events table for PostgreSQL
+
+CREATE TABLE public.security_events (
+    id bigserial NOT NULL,
+    user_id bigint NOT NULL,
+    "type" varchar(64) NOT NULL,
+    detail text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT security_events_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX idx_security_events_user_id ON public.security_events USING btree (user_id);
+
+-- totp_credentials table for PostgreSQL
+
+CREATE TABLE public.totp_credentials (
+    id bigserial NOT NULL,
+    user_id bigint NOT NULL,
+    secret text NOT NULL,
+    recovery_codes text NOT NULL DEFAULT '',
+    enabled boolean NOT NULL DEFAULT false,
+    last_used_step bigint NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT totp_credentials_pkey PRIMARY KEY (id),
+    CONSTRAINT totp_credentials_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id)
+);
+
+CREATE UNIQUE INDEX idx_totp_credentials_user_id ON public.totp_credentials USING btree (user_id);
+
+-- login_attempts table for PostgreSQL
+
+CREATE TABLE public.login_attempts (
+    id bigserial NOT NULL,
+    scope varchar(16) NOT NULL,
+    identifier varchar(256) NOT NULL,
+    failed_count integer NOT NULL DEFAULT 0,
+    last_failed_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until timestamptz NULL,
+    CONSTRAINT login_attempts_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX idx_login_attempts_scope_identifier ON public.login_attempts USING btree (scope, identifier);
+
+-- password_histories table for PostgreSQL
+
+CREATE TABLE public.password_histories (
+    id bigserial NOT NULL,
+    user_id bigint NOT NULL,
+    password_hash varchar(256) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT password_histories_pkey PRIMARY KEY (id),
+    CONSTRAINT password_histories_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id)
+);
+
+CREATE INDEX idx_password_histories_user_id ON public.password_histories USING btree (user_id);
+
+-- password_reset_tokens table for PostgreSQL
+
+CREATE TABLE public.password_reset_tokens (
+    id bigserial NOT NULL,
+    user_id bigint NOT NULL,
+    token_hash varchar(64) NOT NULL,
+    expires_at timestamptz NOT NULL,
+    used_at timestamptz NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT password_reset_tokens_pkey PRIMARY KEY (id),
+    CONSTRAINT password_reset_tokens_token_hash_key UNIQUE (token_hash),
+    CONSTRAINT password_reset_tokens_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id)
+);
+
+CREATE INDEX idx_password_reset_tokens_user_id ON public.password_reset_tokens USING btree (user_id);
+
+-- email_verifications table for PostgreSQL
+
+CREATE TABLE public.email_verifications (
+    id bigserial NOT NULL,
+    user_id bigint NOT NULL,
+    email varchar(100) NOT NULL,
+    token_hash varchar(64) NOT NULL,
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT email_verifications_pkey PRIMARY KEY (id),
+    CONSTRAINT email_verifications_token_hash_key UNIQUE (token_hash),
+    CONSTRAINT email_verifications_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id)
+);
+
+CREATE INDEX idx_email_verifications_user_id ON public.email_verifications USING btree (user_id);
+
+-- roles table for PostgreSQL
+
+CREATE TABLE public.roles (
+    id bigserial NOT NULL,
+    name varchar(64) NOT NULL,
+    description text NOT NULL DEFAULT '',
+    built_in boolean NOT NULL DEFAULT false,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT roles_pkey PRIMARY KEY (id),
+    CONSTRAINT roles_name_key UNIQUE (name)
+);
+
+-- role_permissions table for PostgreSQL
+
+CREATE TABLE public.role_permissions (
+    role_id bigint NOT NULL,
+    permission varchar(64) NOT NULL,
+    CONSTRAINT role_permissions_pkey PRIMARY KEY (role_id, permission),
+    CONSTRAINT role_permissions_role_id_fkey FOREIGN KEY (role_id) REFERENCES public.roles(id)
+);
+
+-- user_roles table for PostgreSQL
+
+CREATE TABLE public.user_roles (
+    user_id bigint NOT NULL,
+    role_id bigint NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT user_roles_pkey PRIMARY KEY (user_id, role_id),
+    CONSTRAINT user_roles_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id),
+    CONSTRAINT user_roles_role_id_fkey FOREIGN KEY (role_id) REFERENCES public.roles(id)
+);
+
+CREATE INDEX idx_user_roles_role_id ON public.user_roles USING btree (role_id);
+
+-- built-in roles
+
+INSERT INTO public.roles (name, description, built_in) VALUES ('admin', 'Administrators of the instance', TRUE);
+INSERT INTO public.roles (name, description, built_in) VALUES ('user', 'Regular users', TRUE);
+INSERT INTO public.role_permissions (role_id, permission) SELECT id, 'users.read' FROM public.roles WHERE name = 'admin';
+INSERT INTO public.role_permissions (role_id, permission) SELECT id, 'users.write' FROM public.roles WHERE name = 'admin';
+INSERT INTO public.role_permissions (role_id, permission) SELECT id, 'roles.read' FROM public.roles WHERE name = 'admin';
+INSERT INTO public.role_permissions (role_id, permission) SELECT id, 'roles.write' FROM public.roles WHERE name = 'admin';
+INSERT INTO public.role_permissions (role_id, permission) SELECT id, 'settings.read' FROM public.roles WHERE name = 'admin';
+INSERT INTO public.role_permissions (role_id, permission) SELECT id, 'settings.update' FROM public.roles WHERE name = 'admin';
+
+-- user_identities table for PostgreSQL, links users to the subjects of external identity providers
+
+CREATE TABLE public.user_identities (
+    id bigserial NOT NULL,
+    user_id bigint NOT NULL,
+    provider varchar(64) NOT NULL,
+    subject varchar(255) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT user_identities_pkey PRIMARY KEY (id),
+    CONSTRAINT user_identities_provider_subject_key UNIQUE (provider, subject),
+    CONSTRAINT user_identities_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id)
+);
+
+CREATE INDEX idx_user_identities_user_id ON public.user_identities USING btree (user_id);
+
+-- oauth_clients table for PostgreSQL, the applications that use the instance as an authorization server
+
+CREATE TABLE public.oauth_clients (
+    id bigserial NOT NULL,
+    client_id varchar(64) NOT NULL,
+    name varchar(255) NOT NULL,
+    secret_hash varchar(64) NOT NULL DEFAULT '',
+    redirect_uris text NOT NULL DEFAULT '',
+    grant_types text NOT NULL DEFAULT '',
+    scopes text NOT NULL DEFAULT '',
+    creator_id bigint NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT oauth_clients_pkey PRIMARY KEY (id),
+    CONSTRAINT oauth_clients_client_id_key UNIQUE (client_id),
+    CONSTRAINT oauth_clients_creator_id_fkey FOREIGN KEY (creator_id) REFERENCES public.users(id)
+);
+
+-- oauth_authorization_codes table for PostgreSQL
+
+CREATE TABLE public.oauth_authorization_codes (
+    id bigserial NOT NULL,
+    code_hash varchar(64) NOT NULL,
+    client_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    redirect_uri text NOT NULL,
+    scopes text NOT NULL DEFAULT '',
+    nonce varchar(255) NOT NULL DEFAULT '',
+    code_challenge varchar(128) NOT NULL,
+    expires_at timestamptz NOT NULL,
+    used_at timestamptz NULL,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT oauth_authorization_codes_pkey PRIMARY KEY (id),
+    CONSTRAINT oauth_authorization_codes_code_hash_key UNIQUE (code_hash),
+    CONSTRAINT oauth_authorization_codes_client_id_fkey FOREIGN KEY (client_id) REFERENCES public.oauth_clients(id),
+    CONSTRAINT oauth_authorization_codes_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id)
+);
+
+CREATE INDEX idx_oauth_authorization_codes_client_id ON public.oauth_authorization_codes USING btree (client_id);
+
+-- oauth_consents table for PostgreSQL, the scopes a user has granted to a client
+
+CREATE TABLE public.oauth_consents (
+    id bigserial NOT NULL,
+    user_id bigint NOT NULL,
+    client_id bigint NOT NULL,
+    scopes text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT oauth_consents_pkey PRIMARY KEY (id),
+    CONSTRAINT oauth_consents_user_id_client_id_key UNIQUE (user_id, client_id),
+    CONSTRAINT oauth_consents_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id),
+    CONSTRAINT oauth_consents_client_id_fkey FOREIGN KEY (client_id) REFERENCES public.oauth_clients(id)
+);
+
+CREATE INDEX idx_oauth_consents_client_id ON public.oauth_consents USING btree (client_id);
+
+-- client registration permissions of the built-in admin role
+
+INSERT INTO public.role_permissions (role_id, permission) SELECT id, 'oauth_clients.read' FROM public.roles WHERE name = 'admin';
+INSERT INTO public.role_permissions (role_id, permission) SELECT id, 'oauth_clients.write' FROM public.roles WHERE name = 'admin';
+
+-- invites table for PostgreSQL, the invite codes people register with
+
+CREATE TABLE public.invites (
+    id bigserial NOT NULL,
+    code_hash varchar(64) NOT NULL,
+    role varchar(64) NOT NULL DEFAULT 'user',
+    max_uses integer NOT NULL DEFAULT 1,
+    use_count integer NOT NULL DEFAULT 0,
+    expires_at timestamptz NOT NULL,
+    -- 0 when the invite was created by a service principal.
+    creator_id bigint NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT invites_pkey PRIMARY KEY (id),
+    CONSTRAINT invites_code_hash_key UNIQUE (code_hash)
+);
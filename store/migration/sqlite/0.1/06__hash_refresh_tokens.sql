@@ -0,0 +1,4 @@
+-- Refresh tokens are now stored as SHA-256 digests. SQLite cannot compute the
+-- digest, so existing plaintext tokens are invalidated and users sign in again.
+
+UPDATE refresh_tokens SET token = 'invalidated-' || id, revoked = TRUE WHERE token NOT LIKE 'invalidated-%';
@@ -0,0 +1,43 @@
+-- roles table for SQLite
+
+CREATE TABLE roles (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    built_in BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- role_permissions table for SQLite
+
+CREATE TABLE role_permissions (
+    role_id INTEGER NOT NULL,
+    permission TEXT NOT NULL,
+    PRIMARY KEY (role_id, permission),
+    FOREIGN KEY (role_id) REFERENCES roles(id)
+);
+
+-- user_roles table for SQLite
+
+CREATE TABLE user_roles (
+    user_id INTEGER NOT NULL,
+    role_id INTEGER NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, role_id),
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (role_id) REFERENCES roles(id)
+);
+
+CREATE INDEX idx_user_roles_role_id ON user_roles(role_id);
+
+-- built-in roles
+
+INSERT INTO roles (name, description, built_in) VALUES ('admin', 'Administrators of the instance', TRUE);
+INSERT INTO roles (name, description, built_in) VALUES ('user', 'Regular users', TRUE);
+INSERT INTO role_permissions (role_id, permission) SELECT id, 'users.read' FROM roles WHERE name = 'admin';
+INSERT INTO role_permissions (role_id, permission) SELECT id, 'users.write' FROM roles WHERE name = 'admin';
+INSERT INTO role_permissions (role_id, permission) SELECT id, 'roles.read' FROM roles WHERE name = 'admin';
+INSERT INTO role_permissions (role_id, permission) SELECT id, 'roles.write' FROM roles WHERE name = 'admin';
+INSERT INTO role_permissions (role_id, permission) SELECT id, 'settings.read' FROM roles WHERE name = 'admin';
+INSERT INTO role_permissions (role_id, permission) SELECT id, 'settings.update' FROM roles WHERE name = 'admin';
@@ -122,6 +122,10 @@ func (s *Store) Migrate(ctx context.Context) error {
 		}
 	}
 
+	if err := s.ensureInstanceSecretKey(ctx); err != nil {
+		return errors.Wrap(err, "failed to ensure instance secret key")
+	}
+
 	if s.profile.Demo {
 		// In demo mode, we should seed the database.
 		if err := s.seed(ctx); err != nil {
@@ -350,6 +354,22 @@ func (*Store) execute(ctx context.Context, tx *sql.Tx, stmt string) error {
 	return nil
 }
 
+// ensureInstanceSecretKey generates the instance secret key if it has not been set yet.
+func (s *Store) ensureInstanceSecretKey(ctx context.Context) error {
+	instanceBasicSetting, err := s.GetInstanceBasicSetting(ctx)
+	if err != nil {
+		return err
+	}
+	if instanceBasicSetting.SecretKey != "" {
+		return nil
+	}
+	if _, err := s.RotateInstanceSecretKey(ctx); err != nil {
+		return err
+	}
+	slog.Info("generated instance secret key")
+	return nil
+}
+
 // updateCurrentSchemaVersion updates the current schema version in the instance basic setting.
 // It retrieves the instance basic setting, updates the schema version, and upserts the setting back to the database.
 func (s *Store) updateCurrentSchemaVersion(ctx context.Context, schemaVersion string) error {
@@ -22,13 +22,15 @@ func (r Role) String() string {
 }
 
 type User struct {
-	ID              int64
-	Username        string
-	Nickname        string
-	Password        string
-	Phone           string
-	Email           string
-	Role            Role
+	ID       int64
+	Username string
+	Nickname string
+	Password string
+	Phone    string
+	Email    string
+	Role     Role
+	// EmailVerified is set once the user has confirmed Email.
+	EmailVerified   bool
 	PasswordExpires time.Time
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
@@ -43,6 +45,7 @@ type UpdateUser struct {
 	Phone           *string
 	Email           *string
 	Role            *Role
+	EmailVerified   *bool
 	PasswordExpires *time.Time
 	UpdatedAt       *time.Time
 }
@@ -62,12 +65,21 @@ type FindUser struct {
 	Username *string
 	Email    *string
 	Role     *Role
+	// ShowDeleted includes soft-deleted users.
+	ShowDeleted bool
+	// Limit returns at most that many users, ordered by ID, when it is positive.
+	Limit  int
+	Offset int
 }
 
 type RefreshToken struct {
-	ID        int64
-	UserID    int64
-	Token     string
+	ID     int64
+	UserID int64
+	Token  string
+	// FamilyID groups all tokens rotated from the same login and doubles as the session ID.
+	FamilyID  string
+	UserAgent string
+	IPAddress string
 	ExpiresAt time.Time
 	Revoked   bool
 	CreatedAt time.Time
@@ -78,6 +90,9 @@ type RefreshToken struct {
 type CreateRefreshToken struct {
 	UserID    int64
 	Token     string
+	FamilyID  string
+	UserAgent string
+	IPAddress string
 	ExpiresAt time.Time
 }
 
@@ -90,10 +105,15 @@ type DeleteUser struct {
 	ID int64
 }
 
+type RestoreUser struct {
+	ID int64
+}
+
 type FindRefreshToken struct {
-	ID     *int64
-	UserID *int64
-	Token  *string
+	ID       *int64
+	UserID   *int64
+	Token    *string
+	FamilyID *string
 }
 
 type DeleteRefreshToken struct {
@@ -0,0 +1,67 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// OAuthAuthorizationCode is issued to a client when a user approves an authorization
+// request and exchanged for tokens once. Only the SHA-256 digest of the code is persisted.
+type OAuthAuthorizationCode struct {
+	ID       int64
+	CodeHash string
+	// ClientID is the ID of the OAuthClient record.
+	ClientID    int64
+	UserID      int64
+	RedirectURI string
+	Scopes      []string
+	Nonce       string
+	// CodeChallenge is the S256 PKCE challenge the code verifier has to match.
+	CodeChallenge string
+	ExpiresAt     time.Time
+	// UsedAt is set once the code has been exchanged.
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+type CreateOAuthAuthorizationCode struct {
+	CodeHash      string
+	ClientID      int64
+	UserID        int64
+	RedirectURI   string
+	Scopes        []string
+	Nonce         string
+	CodeChallenge string
+	ExpiresAt     time.Time
+}
+
+type FindOAuthAuthorizationCode struct {
+	ID       *int64
+	CodeHash *string
+}
+
+func (s *Store) CreateOAuthAuthorizationCode(ctx context.Context, create *CreateOAuthAuthorizationCode) (*OAuthAuthorizationCode, error) {
+	return s.driver.CreateOAuthAuthorizationCode(ctx, create)
+}
+
+func (s *Store) ListOAuthAuthorizationCodes(ctx context.Context, find *FindOAuthAuthorizationCode) ([]*OAuthAuthorizationCode, error) {
+	return s.driver.ListOAuthAuthorizationCodes(ctx, find)
+}
+
+// GetOAuthAuthorizationCode returns the code matching find, or nil if there is none.
+func (s *Store) GetOAuthAuthorizationCode(ctx context.Context, find *FindOAuthAuthorizationCode) (*OAuthAuthorizationCode, error) {
+	list, err := s.ListOAuthAuthorizationCodes(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+// UseOAuthAuthorizationCode marks the code as exchanged. It reports false when the code was
+// already used, so that concurrent requests cannot exchange the same code twice.
+func (s *Store) UseOAuthAuthorizationCode(ctx context.Context, id int64) (bool, error) {
+	return s.driver.UseOAuthAuthorizationCode(ctx, id)
+}
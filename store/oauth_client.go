@@ -0,0 +1,81 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// OAuthClient is an application that signs users in with the instance as its
+// authorization server, or authenticates as itself with the client credentials grant.
+type OAuthClient struct {
+	ID int64
+	// ClientID is the public identifier of the client in OAuth requests.
+	ClientID string
+	Name     string
+	// SecretHash is the SHA-256 digest of the client secret, empty for public clients.
+	SecretHash   string
+	RedirectURIs []string
+	GrantTypes   []string
+	Scopes       []string
+	CreatorID    int64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type CreateOAuthClient struct {
+	ClientID     string
+	Name         string
+	SecretHash   string
+	RedirectURIs []string
+	GrantTypes   []string
+	Scopes       []string
+	CreatorID    int64
+}
+
+// UpdateOAuthClient changes the fields that are set, a nil list leaves the list unchanged.
+type UpdateOAuthClient struct {
+	ID           int64
+	Name         *string
+	SecretHash   *string
+	RedirectURIs []string
+	GrantTypes   []string
+	Scopes       []string
+}
+
+type FindOAuthClient struct {
+	ID       *int64
+	ClientID *string
+}
+
+type DeleteOAuthClient struct {
+	ID int64
+}
+
+func (s *Store) CreateOAuthClient(ctx context.Context, create *CreateOAuthClient) (*OAuthClient, error) {
+	return s.driver.CreateOAuthClient(ctx, create)
+}
+
+func (s *Store) UpdateOAuthClient(ctx context.Context, update *UpdateOAuthClient) (*OAuthClient, error) {
+	return s.driver.UpdateOAuthClient(ctx, update)
+}
+
+func (s *Store) ListOAuthClients(ctx context.Context, find *FindOAuthClient) ([]*OAuthClient, error) {
+	return s.driver.ListOAuthClients(ctx, find)
+}
+
+// GetOAuthClient returns the client matching find, or nil if there is none.
+func (s *Store) GetOAuthClient(ctx context.Context, find *FindOAuthClient) (*OAuthClient, error) {
+	list, err := s.ListOAuthClients(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+// DeleteOAuthClient removes a client together with its authorization codes and consents.
+func (s *Store) DeleteOAuthClient(ctx context.Context, delete *DeleteOAuthClient) error {
+	return s.driver.DeleteOAuthClient(ctx, delete)
+}
@@ -0,0 +1,109 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pixb/go-server/store"
+)
+
+func TestOAuthClients(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+
+	user, err := s.CreateUser(ctx, &store.User{
+		Username:        "alice",
+		Email:           "alice@example.com",
+		Password:        "hash",
+		Nickname:        "alice",
+		Role:            store.RoleAdmin,
+		PasswordExpires: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	client, err := s.CreateOAuthClient(ctx, &store.CreateOAuthClient{
+		ClientID:     "wiki",
+		Name:         "Wiki",
+		SecretHash:   "hash",
+		RedirectURIs: []string{"https://wiki.example.com/callback", "http://127.0.0.1:3000/callback"},
+		GrantTypes:   []string{"authorization_code"},
+		Scopes:       []string{"openid", "profile"},
+		CreatorID:    user.ID,
+	})
+	require.NoError(t, err)
+
+	clientID := "wiki"
+	found, err := s.GetOAuthClient(ctx, &store.FindOAuthClient{ClientID: &clientID})
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, client.ID, found.ID)
+	assert.Equal(t, []string{"https://wiki.example.com/callback", "http://127.0.0.1:3000/callback"}, found.RedirectURIs)
+	assert.Equal(t, []string{"openid", "profile"}, found.Scopes)
+
+	// Client IDs are unique
+	_, err = s.CreateOAuthClient(ctx, &store.CreateOAuthClient{ClientID: "wiki", Name: "Other", CreatorID: user.ID})
+	require.Error(t, err)
+
+	// Only the lists that are set are replaced
+	name := "Team Wiki"
+	updated, err := s.UpdateOAuthClient(ctx, &store.UpdateOAuthClient{
+		ID:     client.ID,
+		Name:   &name,
+		Scopes: []string{"openid", "profile", "email"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Team Wiki", updated.Name)
+	assert.Equal(t, []string{"openid", "profile", "email"}, updated.Scopes)
+	assert.Equal(t, found.RedirectURIs, updated.RedirectURIs)
+
+	// Authorization codes can be used once
+	code, err := s.CreateOAuthAuthorizationCode(ctx, &store.CreateOAuthAuthorizationCode{
+		CodeHash:      "code-hash",
+		ClientID:      client.ID,
+		UserID:        user.ID,
+		RedirectURI:   "https://wiki.example.com/callback",
+		Scopes:        []string{"openid"},
+		Nonce:         "nonce",
+		CodeChallenge: "challenge",
+		ExpiresAt:     time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	codeHash := "code-hash"
+	foundCode, err := s.GetOAuthAuthorizationCode(ctx, &store.FindOAuthAuthorizationCode{CodeHash: &codeHash})
+	require.NoError(t, err)
+	require.NotNil(t, foundCode)
+	assert.Equal(t, []string{"openid"}, foundCode.Scopes)
+	assert.Nil(t, foundCode.UsedAt)
+	used, err := s.UseOAuthAuthorizationCode(ctx, code.ID)
+	require.NoError(t, err)
+	assert.True(t, used)
+	used, err = s.UseOAuthAuthorizationCode(ctx, code.ID)
+	require.NoError(t, err)
+	assert.False(t, used)
+
+	// Consents are replaced per user and client
+	_, err = s.UpsertOAuthConsent(ctx, &store.UpsertOAuthConsent{UserID: user.ID, ClientID: client.ID, Scopes: []string{"openid"}})
+	require.NoError(t, err)
+	consent, err := s.UpsertOAuthConsent(ctx, &store.UpsertOAuthConsent{UserID: user.ID, ClientID: client.ID, Scopes: []string{"openid", "email"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"openid", "email"}, consent.Scopes)
+	consents, err := s.ListOAuthConsents(ctx, &store.FindOAuthConsent{UserID: &user.ID})
+	require.NoError(t, err)
+	assert.Len(t, consents, 1)
+
+	// Deleting a client removes its codes and consents
+	require.NoError(t, s.DeleteOAuthClient(ctx, &store.DeleteOAuthClient{ID: client.ID}))
+	found, err = s.GetOAuthClient(ctx, &store.FindOAuthClient{ClientID: &clientID})
+	require.NoError(t, err)
+	assert.Nil(t, found)
+	foundCode, err = s.GetOAuthAuthorizationCode(ctx, &store.FindOAuthAuthorizationCode{CodeHash: &codeHash})
+	require.NoError(t, err)
+	assert.Nil(t, foundCode)
+	consents, err = s.ListOAuthConsents(ctx, &store.FindOAuthConsent{UserID: &user.ID})
+	require.NoError(t, err)
+	assert.Empty(t, consents)
+}
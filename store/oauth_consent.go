@@ -0,0 +1,59 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// OAuthConsent records the scopes a user has granted to a client, so that the user
+// is not asked again for scopes already granted.
+type OAuthConsent struct {
+	ID     int64
+	UserID int64
+	// ClientID is the ID of the OAuthClient record.
+	ClientID  int64
+	Scopes    []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// UpsertOAuthConsent creates the consent of the user for the client, or replaces its scopes.
+type UpsertOAuthConsent struct {
+	UserID   int64
+	ClientID int64
+	Scopes   []string
+}
+
+type FindOAuthConsent struct {
+	UserID   *int64
+	ClientID *int64
+}
+
+type DeleteOAuthConsent struct {
+	UserID   int64
+	ClientID int64
+}
+
+func (s *Store) UpsertOAuthConsent(ctx context.Context, upsert *UpsertOAuthConsent) (*OAuthConsent, error) {
+	return s.driver.UpsertOAuthConsent(ctx, upsert)
+}
+
+func (s *Store) ListOAuthConsents(ctx context.Context, find *FindOAuthConsent) ([]*OAuthConsent, error) {
+	return s.driver.ListOAuthConsents(ctx, find)
+}
+
+// GetOAuthConsent returns the consent matching find, or nil if there is none.
+func (s *Store) GetOAuthConsent(ctx context.Context, find *FindOAuthConsent) (*OAuthConsent, error) {
+	list, err := s.ListOAuthConsents(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+func (s *Store) DeleteOAuthConsent(ctx context.Context, delete *DeleteOAuthConsent) error {
+	return s.driver.DeleteOAuthConsent(ctx, delete)
+}
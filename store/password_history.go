@@ -0,0 +1,44 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// PasswordHistory is a password hash a user has set, kept to prevent the reuse of recent passwords.
+type PasswordHistory struct {
+	ID           int64
+	UserID       int64
+	PasswordHash string
+	CreatedAt    time.Time
+}
+
+type CreatePasswordHistory struct {
+	UserID       int64
+	PasswordHash string
+}
+
+type FindPasswordHistory struct {
+	UserID int64
+	// Limit returns only the most recent entries when it is positive.
+	Limit int
+}
+
+type DeletePasswordHistory struct {
+	UserID int64
+	// BeforeID removes the entries older than the one with this ID.
+	BeforeID int64
+}
+
+func (s *Store) CreatePasswordHistory(ctx context.Context, create *CreatePasswordHistory) (*PasswordHistory, error) {
+	return s.driver.CreatePasswordHistory(ctx, create)
+}
+
+// ListPasswordHistories returns the password history of a user, most recent first.
+func (s *Store) ListPasswordHistories(ctx context.Context, find *FindPasswordHistory) ([]*PasswordHistory, error) {
+	return s.driver.ListPasswordHistories(ctx, find)
+}
+
+func (s *Store) DeletePasswordHistories(ctx context.Context, delete *DeletePasswordHistory) error {
+	return s.driver.DeletePasswordHistories(ctx, delete)
+}
@@ -0,0 +1,46 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pixb/go-server/store"
+)
+
+func TestPasswordHistories(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+
+	user, err := s.CreateUser(ctx, &store.User{
+		Username:        "testuser",
+		Email:           "test@example.com",
+		Password:        "hash-1",
+		Nickname:        "Test User",
+		Role:            store.RoleUser,
+		PasswordExpires: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	for _, hash := range []string{"hash-1", "hash-2", "hash-3"} {
+		_, err := s.CreatePasswordHistory(ctx, &store.CreatePasswordHistory{UserID: user.ID, PasswordHash: hash})
+		require.NoError(t, err)
+	}
+
+	// Most recent first, limited on request
+	histories, err := s.ListPasswordHistories(ctx, &store.FindPasswordHistory{UserID: user.ID, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, histories, 2)
+	assert.Equal(t, "hash-3", histories[0].PasswordHash)
+	assert.Equal(t, "hash-2", histories[1].PasswordHash)
+
+	// Older entries are pruned
+	require.NoError(t, s.DeletePasswordHistories(ctx, &store.DeletePasswordHistory{UserID: user.ID, BeforeID: histories[1].ID}))
+	histories, err = s.ListPasswordHistories(ctx, &store.FindPasswordHistory{UserID: user.ID})
+	require.NoError(t, err)
+	require.Len(t, histories, 2)
+	assert.Equal(t, "hash-2", histories[1].PasswordHash)
+}
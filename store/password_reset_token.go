@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// PasswordResetToken lets a user set a new password without signing in.
+// Only the SHA-256 digest of the token is persisted, and a token can be used once.
+type PasswordResetToken struct {
+	ID        int64
+	UserID    int64
+	TokenHash string
+	ExpiresAt time.Time
+	// UsedAt is set once the token has been redeemed.
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+type CreatePasswordResetToken struct {
+	UserID    int64
+	TokenHash string
+	ExpiresAt time.Time
+}
+
+type FindPasswordResetToken struct {
+	ID        *int64
+	UserID    *int64
+	TokenHash *string
+}
+
+type DeletePasswordResetToken struct {
+	UserID int64
+}
+
+func (s *Store) CreatePasswordResetToken(ctx context.Context, create *CreatePasswordResetToken) (*PasswordResetToken, error) {
+	return s.driver.CreatePasswordResetToken(ctx, create)
+}
+
+func (s *Store) ListPasswordResetTokens(ctx context.Context, find *FindPasswordResetToken) ([]*PasswordResetToken, error) {
+	return s.driver.ListPasswordResetTokens(ctx, find)
+}
+
+// GetPasswordResetToken returns the first token matching find, or nil if there is none.
+func (s *Store) GetPasswordResetToken(ctx context.Context, find *FindPasswordResetToken) (*PasswordResetToken, error) {
+	list, err := s.ListPasswordResetTokens(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+// UsePasswordResetToken marks the token as used. It reports false when the token was already
+// used, so that concurrent requests cannot redeem the same token twice.
+func (s *Store) UsePasswordResetToken(ctx context.Context, id int64) (bool, error) {
+	return s.driver.UsePasswordResetToken(ctx, id)
+}
+
+// DeletePasswordResetTokens removes all reset tokens of a user.
+func (s *Store) DeletePasswordResetTokens(ctx context.Context, delete *DeletePasswordResetToken) error {
+	return s.driver.DeletePasswordResetTokens(ctx, delete)
+}
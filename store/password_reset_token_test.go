@@ -0,0 +1,57 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pixb/go-server/store"
+)
+
+func TestPasswordResetTokens(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+
+	user, err := s.CreateUser(ctx, &store.User{
+		Username:        "testuser",
+		Email:           "test@example.com",
+		Password:        "hash",
+		Nickname:        "Test User",
+		Role:            store.RoleUser,
+		PasswordExpires: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	created, err := s.CreatePasswordResetToken(ctx, &store.CreatePasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: "digest",
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	tokenHash := "digest"
+	token, err := s.GetPasswordResetToken(ctx, &store.FindPasswordResetToken{TokenHash: &tokenHash})
+	require.NoError(t, err)
+	require.NotNil(t, token)
+	assert.Equal(t, created.ID, token.ID)
+	assert.Nil(t, token.UsedAt)
+
+	// A token can be used only once
+	used, err := s.UsePasswordResetToken(ctx, token.ID)
+	require.NoError(t, err)
+	assert.True(t, used)
+	used, err = s.UsePasswordResetToken(ctx, token.ID)
+	require.NoError(t, err)
+	assert.False(t, used)
+	token, err = s.GetPasswordResetToken(ctx, &store.FindPasswordResetToken{TokenHash: &tokenHash})
+	require.NoError(t, err)
+	assert.NotNil(t, token.UsedAt)
+
+	require.NoError(t, s.DeletePasswordResetTokens(ctx, &store.DeletePasswordResetToken{UserID: user.ID}))
+	token, err = s.GetPasswordResetToken(ctx, &store.FindPasswordResetToken{UserID: &user.ID})
+	require.NoError(t, err)
+	assert.Nil(t, token)
+}
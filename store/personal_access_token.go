@@ -0,0 +1,70 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// PersonalAccessToken is a long-lived API token owned by a user.
+// Only the SHA-256 digest of the token is persisted.
+type PersonalAccessToken struct {
+	ID          int64
+	UserID      int64
+	TokenHash   string
+	Description string
+	ExpiresAt   *time.Time
+	LastUsedAt  *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   *time.Time
+}
+
+type CreatePersonalAccessToken struct {
+	UserID      int64
+	TokenHash   string
+	Description string
+	ExpiresAt   *time.Time
+}
+
+type UpdatePersonalAccessToken struct {
+	ID         int64
+	LastUsedAt *time.Time
+}
+
+type FindPersonalAccessToken struct {
+	ID        *int64
+	UserID    *int64
+	TokenHash *string
+}
+
+type DeletePersonalAccessToken struct {
+	ID int64
+}
+
+func (s *Store) CreatePersonalAccessToken(ctx context.Context, create *CreatePersonalAccessToken) (*PersonalAccessToken, error) {
+	return s.driver.CreatePersonalAccessToken(ctx, create)
+}
+
+func (s *Store) UpdatePersonalAccessToken(ctx context.Context, update *UpdatePersonalAccessToken) (*PersonalAccessToken, error) {
+	return s.driver.UpdatePersonalAccessToken(ctx, update)
+}
+
+func (s *Store) ListPersonalAccessTokens(ctx context.Context, find *FindPersonalAccessToken) ([]*PersonalAccessToken, error) {
+	return s.driver.ListPersonalAccessTokens(ctx, find)
+}
+
+// GetPersonalAccessToken returns the first token matching find, or nil if there is none.
+func (s *Store) GetPersonalAccessToken(ctx context.Context, find *FindPersonalAccessToken) (*PersonalAccessToken, error) {
+	list, err := s.ListPersonalAccessTokens(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+func (s *Store) DeletePersonalAccessToken(ctx context.Context, delete *DeletePersonalAccessToken) error {
+	return s.driver.DeletePersonalAccessToken(ctx, delete)
+}
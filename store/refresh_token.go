@@ -0,0 +1,46 @@
+package store
+
+import (
+	"context"
+
+	"github.com/pixb/go-server/internal/tokenhash"
+)
+
+// Refresh tokens are bearer credentials, so only their SHA-256 digest is persisted.
+// Hashing happens here rather than in the drivers or services: callers keep passing
+// and receiving plaintext tokens, while RefreshToken.Token read back from the store
+// holds the digest.
+
+func (s *Store) CreateRefreshToken(ctx context.Context, create *CreateRefreshToken) (*RefreshToken, error) {
+	hashed := *create
+	hashed.Token = tokenhash.Hash(create.Token)
+	return s.driver.CreateRefreshToken(ctx, &hashed)
+}
+
+func (s *Store) UpdateRefreshToken(ctx context.Context, update *UpdateRefreshToken) (*RefreshToken, error) {
+	return s.driver.UpdateRefreshToken(ctx, update)
+}
+
+// RevokeRefreshToken revokes the token. It reports false when the token was already revoked,
+// so that concurrent requests cannot rotate the same token twice.
+func (s *Store) RevokeRefreshToken(ctx context.Context, id int64) (bool, error) {
+	return s.driver.RevokeRefreshToken(ctx, id)
+}
+
+func (s *Store) ListRefreshTokens(ctx context.Context, find *FindRefreshToken) ([]*RefreshToken, error) {
+	if find.Token != nil {
+		hashed := *find
+		tokenHash := tokenhash.Hash(*find.Token)
+		hashed.Token = &tokenHash
+		find = &hashed
+	}
+	return s.driver.ListRefreshTokens(ctx, find)
+}
+
+func (s *Store) DeleteRefreshToken(ctx context.Context, delete *DeleteRefreshToken) error {
+	return s.driver.DeleteRefreshToken(ctx, delete)
+}
+
+func (s *Store) GetRefreshToken(ctx context.Context, token string) (*RefreshToken, error) {
+	return s.driver.GetRefreshToken(ctx, tokenhash.Hash(token))
+}
@@ -0,0 +1,60 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pixb/go-server/store"
+)
+
+func TestRefreshTokenStoredHashed(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+
+	user, err := s.CreateUser(ctx, &store.User{Username: "testuser", Password: "hashed", Email: "test@example.com", Role: store.RoleUser})
+	require.NoError(t, err)
+	_, err = s.CreateRefreshToken(ctx, &store.CreateRefreshToken{
+		UserID:    user.ID,
+		Token:     "plaintext",
+		FamilyID:  "family-1",
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	// The raw column never contains the plaintext token
+	var stored string
+	require.NoError(t, s.GetDriver().GetDB().QueryRowContext(ctx, "SELECT token FROM refresh_tokens WHERE user_id = ?", user.ID).Scan(&stored))
+	assert.NotEqual(t, "plaintext", stored)
+	assert.Len(t, stored, 64)
+
+	// Lookups by plaintext still work
+	refreshToken, err := s.GetRefreshToken(ctx, "plaintext")
+	require.NoError(t, err)
+	require.NotNil(t, refreshToken)
+	assert.Equal(t, "family-1", refreshToken.FamilyID)
+
+	plaintext := "plaintext"
+	list, err := s.ListRefreshTokens(ctx, &store.FindRefreshToken{Token: &plaintext})
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	// Looking up by the digest does not
+	refreshToken, err = s.GetRefreshToken(ctx, stored)
+	require.NoError(t, err)
+	assert.Nil(t, refreshToken)
+
+	// Tokens are revoked once
+	revoked, err := s.RevokeRefreshToken(ctx, list[0].ID)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+	revoked, err = s.RevokeRefreshToken(ctx, list[0].ID)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+	refreshToken, err = s.GetRefreshToken(ctx, "plaintext")
+	require.NoError(t, err)
+	assert.True(t, refreshToken.Revoked)
+}
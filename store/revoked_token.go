@@ -0,0 +1,74 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// RevokedToken is a denylist entry for an access token that was revoked before it expired.
+// Entries are keyed by the JWT ID (jti), or by the session ID (sid) when every access token
+// of a session is revoked at once, and only need to be kept until ExpiresAt.
+type RevokedToken struct {
+	ID        int64
+	JTI       string
+	UserID    int64
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+type CreateRevokedToken struct {
+	JTI       string
+	UserID    int64
+	ExpiresAt time.Time
+}
+
+type FindRevokedToken struct {
+	JTI    *string
+	UserID *int64
+}
+
+type DeleteRevokedToken struct {
+	// ExpiresBefore removes all entries whose token has already expired at this time.
+	ExpiresBefore time.Time
+}
+
+// CreateRevokedToken adds a token to the denylist. Entries whose token has already
+// expired are pruned at the same time, which keeps the table small.
+func (s *Store) CreateRevokedToken(ctx context.Context, create *CreateRevokedToken) (*RevokedToken, error) {
+	if err := s.driver.DeleteRevokedTokens(ctx, &DeleteRevokedToken{ExpiresBefore: time.Now()}); err != nil {
+		return nil, err
+	}
+	revokedToken, err := s.driver.CreateRevokedToken(ctx, create)
+	if err != nil {
+		return nil, err
+	}
+	s.revokedTokenCache.Set(ctx, create.JTI, true)
+	return revokedToken, nil
+}
+
+func (s *Store) ListRevokedTokens(ctx context.Context, find *FindRevokedToken) ([]*RevokedToken, error) {
+	return s.driver.ListRevokedTokens(ctx, find)
+}
+
+func (s *Store) DeleteRevokedTokens(ctx context.Context, delete *DeleteRevokedToken) error {
+	return s.driver.DeleteRevokedTokens(ctx, delete)
+}
+
+// IsTokenRevoked reports whether the given jti or sid is on the denylist.
+// Both positive and negative lookups are cached briefly so that authenticating a request
+// does not hit the database every time.
+func (s *Store) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	if cached, ok := s.revokedTokenCache.Get(ctx, jti); ok {
+		if revoked, ok := cached.(bool); ok {
+			return revoked, nil
+		}
+	}
+
+	list, err := s.ListRevokedTokens(ctx, &FindRevokedToken{JTI: &jti})
+	if err != nil {
+		return false, err
+	}
+	revoked := len(list) > 0
+	s.revokedTokenCache.Set(ctx, jti, revoked)
+	return revoked, nil
+}
@@ -0,0 +1,200 @@
+package store
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Permission is the name of an action a role grants, such as "users.read".
+type Permission string
+
+// Permission constants
+const (
+	PermissionUsersRead      Permission = "users.read"
+	PermissionUsersWrite     Permission = "users.write"
+	PermissionRolesRead      Permission = "roles.read"
+	PermissionRolesWrite     Permission = "roles.write"
+	PermissionSettingsRead   Permission = "settings.read"
+	PermissionSettingsUpdate Permission = "settings.update"
+	// OAuth clients are the applications that sign users in with the instance.
+	PermissionOAuthClientsRead  Permission = "oauth_clients.read"
+	PermissionOAuthClientsWrite Permission = "oauth_clients.write"
+)
+
+// Permissions lists every permission a role can grant.
+var Permissions = []Permission{
+	PermissionUsersRead,
+	PermissionUsersWrite,
+	PermissionRolesRead,
+	PermissionRolesWrite,
+	PermissionSettingsRead,
+	PermissionSettingsUpdate,
+	PermissionOAuthClientsRead,
+	PermissionOAuthClientsWrite,
+}
+
+// IsValidPermission checks if the permission is known.
+func IsValidPermission(permission Permission) bool {
+	return slices.Contains(Permissions, permission)
+}
+
+// allRoleDefinitionsCacheKey is the roleCache key of the list of all roles.
+const allRoleDefinitionsCacheKey = "*"
+
+// RoleDefinition is a named set of permissions. The built-in roles are the ones User.Role
+// refers to, custom roles are assigned to users on top of it.
+type RoleDefinition struct {
+	ID          int64
+	Name        Role
+	Description string
+	BuiltIn     bool
+	Permissions []Permission
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type CreateRoleDefinition struct {
+	Name        Role
+	Description string
+	Permissions []Permission
+}
+
+type UpdateRoleDefinition struct {
+	ID          int64
+	Description *string
+	// Permissions replaces the permissions of the role when it is not nil.
+	Permissions []Permission
+}
+
+type FindRoleDefinition struct {
+	ID   *int64
+	Name *Role
+	// UserID returns the custom roles assigned to the user.
+	UserID *int64
+}
+
+type DeleteRoleDefinition struct {
+	ID int64
+}
+
+// SetUserRoles replaces the custom roles assigned to a user.
+type SetUserRoles struct {
+	UserID  int64
+	RoleIDs []int64
+}
+
+func (s *Store) CreateRoleDefinition(ctx context.Context, create *CreateRoleDefinition) (*RoleDefinition, error) {
+	role, err := s.driver.CreateRoleDefinition(ctx, create)
+	if err != nil {
+		return nil, err
+	}
+	s.roleCache.Delete(ctx, allRoleDefinitionsCacheKey)
+	return role, nil
+}
+
+func (s *Store) UpdateRoleDefinition(ctx context.Context, update *UpdateRoleDefinition) (*RoleDefinition, error) {
+	role, err := s.driver.UpdateRoleDefinition(ctx, update)
+	if err != nil {
+		return nil, err
+	}
+	s.roleCache.Delete(ctx, allRoleDefinitionsCacheKey)
+	return role, nil
+}
+
+// ListRoleDefinitions returns the roles matching find, ordered by ID. The list of all roles is cached.
+func (s *Store) ListRoleDefinitions(ctx context.Context, find *FindRoleDefinition) ([]*RoleDefinition, error) {
+	listAll := *find == FindRoleDefinition{}
+	if listAll {
+		if cached, ok := s.roleCache.Get(ctx, allRoleDefinitionsCacheKey); ok {
+			if roles, ok := cached.([]*RoleDefinition); ok {
+				return roles, nil
+			}
+		}
+	}
+	roles, err := s.driver.ListRoleDefinitions(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if listAll {
+		s.roleCache.Set(ctx, allRoleDefinitionsCacheKey, roles)
+	}
+	return roles, nil
+}
+
+// GetRoleDefinition returns the first role matching find, or nil if there is none.
+func (s *Store) GetRoleDefinition(ctx context.Context, find *FindRoleDefinition) (*RoleDefinition, error) {
+	list, err := s.ListRoleDefinitions(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+// DeleteRoleDefinition deletes a role and unassigns it from its users.
+func (s *Store) DeleteRoleDefinition(ctx context.Context, delete *DeleteRoleDefinition) error {
+	if err := s.driver.DeleteRoleDefinition(ctx, delete); err != nil {
+		return err
+	}
+	// The assignments cached in userRoleCache expire on their own, roles that
+	// no longer exist are skipped by ListUserRoleDefinitions.
+	s.roleCache.Delete(ctx, allRoleDefinitionsCacheKey)
+	return nil
+}
+
+func (s *Store) SetUserRoles(ctx context.Context, set *SetUserRoles) error {
+	if err := s.driver.SetUserRoles(ctx, set); err != nil {
+		return err
+	}
+	s.userRoleCache.Delete(ctx, strconv.FormatInt(set.UserID, 10))
+	return nil
+}
+
+// ListUserRoleDefinitions returns the roles of a user: the built-in role of the user
+// record first, followed by the custom roles assigned to them.
+func (s *Store) ListUserRoleDefinitions(ctx context.Context, user *User) ([]*RoleDefinition, error) {
+	roles, err := s.ListRoleDefinitions(ctx, &FindRoleDefinition{})
+	if err != nil {
+		return nil, err
+	}
+	roleIDs, err := s.listUserRoleIDs(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	userRoles := []*RoleDefinition{}
+	for _, role := range roles {
+		if role.Name == user.Role {
+			userRoles = append(userRoles, role)
+		}
+	}
+	for _, role := range roles {
+		if role.Name != user.Role && slices.Contains(roleIDs, role.ID) {
+			userRoles = append(userRoles, role)
+		}
+	}
+	return userRoles, nil
+}
+
+func (s *Store) listUserRoleIDs(ctx context.Context, userID int64) ([]int64, error) {
+	key := strconv.FormatInt(userID, 10)
+	if cached, ok := s.userRoleCache.Get(ctx, key); ok {
+		if roleIDs, ok := cached.([]int64); ok {
+			return roleIDs, nil
+		}
+	}
+	roles, err := s.driver.ListRoleDefinitions(ctx, &FindRoleDefinition{UserID: &userID})
+	if err != nil {
+		return nil, err
+	}
+	roleIDs := make([]int64, 0, len(roles))
+	for _, role := range roles {
+		roleIDs = append(roleIDs, role.ID)
+	}
+	s.userRoleCache.Set(ctx, key, roleIDs)
+	return roleIDs, nil
+}
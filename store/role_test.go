@@ -0,0 +1,82 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pixb/go-server/store"
+)
+
+func TestRoleDefinitions(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+
+	// The built-in roles are seeded, admin grants every permission
+	roles, err := s.ListRoleDefinitions(ctx, &store.FindRoleDefinition{})
+	require.NoError(t, err)
+	require.Len(t, roles, 2)
+	assert.Equal(t, store.RoleAdmin, roles[0].Name)
+	assert.True(t, roles[0].BuiltIn)
+	assert.ElementsMatch(t, store.Permissions, roles[0].Permissions)
+	assert.Equal(t, store.RoleUser, roles[1].Name)
+	assert.Empty(t, roles[1].Permissions)
+
+	auditor, err := s.CreateRoleDefinition(ctx, &store.CreateRoleDefinition{
+		Name:        "auditor",
+		Description: "Reads users",
+		Permissions: []store.Permission{store.PermissionUsersRead},
+	})
+	require.NoError(t, err)
+	assert.False(t, auditor.BuiltIn)
+
+	// Creating a role invalidates the cached list
+	roles, err = s.ListRoleDefinitions(ctx, &store.FindRoleDefinition{})
+	require.NoError(t, err)
+	require.Len(t, roles, 3)
+
+	description := "Reads users and roles"
+	auditor, err = s.UpdateRoleDefinition(ctx, &store.UpdateRoleDefinition{
+		ID:          auditor.ID,
+		Description: &description,
+		Permissions: []store.Permission{store.PermissionUsersRead, store.PermissionRolesRead},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, description, auditor.Description)
+	assert.Equal(t, []store.Permission{store.PermissionRolesRead, store.PermissionUsersRead}, auditor.Permissions)
+
+	user, err := s.CreateUser(ctx, &store.User{
+		Username:        "testuser",
+		Email:           "test@example.com",
+		Password:        "hash",
+		Nickname:        "Test User",
+		Role:            store.RoleUser,
+		PasswordExpires: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	// The role of the user record comes first, followed by the assigned roles
+	require.NoError(t, s.SetUserRoles(ctx, &store.SetUserRoles{UserID: user.ID, RoleIDs: []int64{auditor.ID}}))
+	userRoles, err := s.ListUserRoleDefinitions(ctx, user)
+	require.NoError(t, err)
+	require.Len(t, userRoles, 2)
+	assert.Equal(t, store.RoleUser, userRoles[0].Name)
+	assert.Equal(t, auditor.ID, userRoles[1].ID)
+
+	assigned, err := s.ListRoleDefinitions(ctx, &store.FindRoleDefinition{UserID: &user.ID})
+	require.NoError(t, err)
+	require.Len(t, assigned, 1)
+	assert.Equal(t, auditor.Permissions, assigned[0].Permissions)
+
+	// Deleting a role unassigns it
+	require.NoError(t, s.DeleteRoleDefinition(ctx, &store.DeleteRoleDefinition{ID: auditor.ID}))
+	userRoles, err = s.ListUserRoleDefinitions(ctx, user)
+	require.NoError(t, err)
+	require.Len(t, userRoles, 1)
+	assigned, err = s.ListRoleDefinitions(ctx, &store.FindRoleDefinition{UserID: &user.ID})
+	require.NoError(t, err)
+	assert.Empty(t, assigned)
+}
@@ -0,0 +1,50 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// SecurityEventType is the type of a security event.
+type SecurityEventType string
+
+const (
+	// SecurityEventRefreshTokenReuse is recorded when a revoked refresh token is presented again.
+	SecurityEventRefreshTokenReuse SecurityEventType = "REFRESH_TOKEN_REUSE"
+	// SecurityEventAccountLocked is recorded when an account is locked after too many failed logins.
+	SecurityEventAccountLocked SecurityEventType = "ACCOUNT_LOCKED"
+	// SecurityEventPasswordReset is recorded when a password is reset with a reset token.
+	SecurityEventPasswordReset SecurityEventType = "PASSWORD_RESET"
+)
+
+func (t SecurityEventType) String() string {
+	return string(t)
+}
+
+// SecurityEvent is an audit record of a security relevant occurrence for a user.
+type SecurityEvent struct {
+	ID        int64
+	UserID    int64
+	Type      SecurityEventType
+	Detail    string
+	CreatedAt time.Time
+}
+
+type CreateSecurityEvent struct {
+	UserID int64
+	Type   SecurityEventType
+	Detail string
+}
+
+type FindSecurityEvent struct {
+	UserID *int64
+	Type   *SecurityEventType
+}
+
+func (s *Store) CreateSecurityEvent(ctx context.Context, create *CreateSecurityEvent) (*SecurityEvent, error) {
+	return s.driver.CreateSecurityEvent(ctx, create)
+}
+
+func (s *Store) ListSecurityEvents(ctx context.Context, find *FindSecurityEvent) ([]*SecurityEvent, error) {
+	return s.driver.ListSecurityEvents(ctx, find)
+}
@@ -19,15 +19,15 @@ INSERT OR IGNORE INTO users (id, username, nickname, password, phone, email, rol
 (9, 'user8', 'Test User 8', '$2a$10$z6T0xV7yqJ5B7K8uG9x1Ou6Q6Q6Q6Q6Q6Q6Q6Q6Q6Q6Q6Q6Q6Q', '13800138008', 'user8@example.com', 'user', '2027-02-27 00:00:00', '2026-02-27 00:00:00', '2026-02-27 00:00:00'),
 (10, 'user9', 'Test User 9', '$2a$10$z6T0xV7yqJ5B7K8uG9x1Ou6Q6Q6Q6Q6Q6Q6Q6Q6Q6Q6Q6Q6Q6Q', '13800138009', 'user9@example.com', 'user', '2027-02-27 00:00:00', '2026-02-27 00:00:00', '2026-02-27 00:00:00');
 
--- 刷新令牌数据
-INSERT OR IGNORE INTO refresh_tokens (user_id, token, expires_at, revoked, created_at, updated_at) VALUES
-(1, 'admin-refresh-token-1', '2027-02-27 00:00:00', 0, '2026-02-27 00:00:00', '2026-02-27 00:00:00'),
-(2, 'user1-refresh-token-1', '2027-02-27 00:00:00', 0, '2026-02-27 00:00:00', '2026-02-27 00:00:00'),
-(3, 'user2-refresh-token-1', '2027-02-27 00:00:00', 0, '2026-02-27 00:00:00', '2026-02-27 00:00:00'),
-(4, 'user3-refresh-token-1', '2027-02-27 00:00:00', 0, '2026-02-27 00:00:00', '2026-02-27 00:00:00'),
-(5, 'user4-refresh-token-1', '2027-02-27 00:00:00', 0, '2026-02-27 00:00:00', '2026-02-27 00:00:00'),
-(6, 'user5-refresh-token-1', '2027-02-27 00:00:00', 0, '2026-02-27 00:00:00', '2026-02-27 00:00:00'),
-(7, 'user6-refresh-token-1', '2027-02-27 00:00:00', 0, '2026-02-27 00:00:00', '2026-02-27 00:00:00'),
-(8, 'user7-refresh-token-1', '2027-02-27 00:00:00', 0, '2026-02-27 00:00:00', '2026-02-27 00:00:00'),
-(9, 'user8-refresh-token-1', '2027-02-27 00:00:00', 0, '2026-02-27 00:00:00', '2026-02-27 00:00:00'),
-(10, 'user9-refresh-token-1', '2027-02-27 00:00:00', 0, '2026-02-27 00:00:00', '2026-02-27 00:00:00');
+-- 刷新令牌数据（token 列保存的是 SHA-256 摘要，明文为 <username>-refresh-token-1）
+INSERT OR IGNORE INTO refresh_tokens (user_id, token, family_id, expires_at, revoked, created_at, updated_at) VALUES
+(1, 'b37b8090b96167ef2363b1821d12446175cc25a349ac92ba0a1a2cd9dcba0623', 'seed-family-1', '2027-02-27 00:00:00', 0, '2026-02-27 00:00:00', '2026-02-27 00:00:00'),
+(2, 'de3b5ba5c2cb481b3cc7182b9ef94367ac7341a89439af85609b63971fc28d62', 'seed-family-2', '2027-02-27 00:00:00', 0, '2026-02-27 00:00:00', '2026-02-27 00:00:00'),
+(3, '58244811bef13a8ec46fcda141984bf074b7e08b497f7d844a9e1e1a92b5aef0', 'seed-family-3', '2027-02-27 00:00:00', 0, '2026-02-27 00:00:00', '2026-02-27 00:00:00'),
+(4, '728b5f3b97d9dd7db2f7c55c0f8de0b902eebba33d2faed603732613cd12f682', 'seed-family-4', '2027-02-27 00:00:00', 0, '2026-02-27 00:00:00', '2026-02-27 00:00:00'),
+(5, 'a7718dc2266d6f98dba4794b6c0a17eac2ce1828132b13fae6ee763c42ec5d05', 'seed-family-5', '2027-02-27 00:00:00', 0, '2026-02-27 00:00:00', '2026-02-27 00:00:00'),
+(6, 'f0e20efc7945f2aadd213a17b6530eafa27628eb52aa463cf48553e614aca6ab', 'seed-family-6', '2027-02-27 00:00:00', 0, '2026-02-27 00:00:00', '2026-02-27 00:00:00'),
+(7, '9bc6dbcfe9edeb4ebdfe9c21f0cf1383bdd8df1702404aef6a12330a88dca03e', 'seed-family-7', '2027-02-27 00:00:00', 0, '2026-02-27 00:00:00', '2026-02-27 00:00:00'),
+(8, 'fd8171b48543799348a81ad3695c2e9be9b07afe36b8edfa98ea93871165ec9d', 'seed-family-8', '2027-02-27 00:00:00', 0, '2026-02-27 00:00:00', '2026-02-27 00:00:00'),
+(9, 'a8a46e2e5ed5a1eae102cc2787e8d7a42a5b32ab0c52376de0a5304955499079', 'seed-family-9', '2027-02-27 00:00:00', 0, '2026-02-27 00:00:00', '2026-02-27 00:00:00'),
+(10, 'e56d8f62b21c898a47931e92c94a40469292b5471cc9d70629a66ed073b5c7a8', 'seed-family-10', '2027-02-27 00:00:00', 0, '2026-02-27 00:00:00', '2026-02-27 00:00:00');
@@ -19,18 +19,99 @@ type Driver interface {
 	UpdateUser(ctx context.Context, update *UpdateUser) (*User, error)
 	ListUsers(ctx context.Context, find *FindUser) ([]*User, error)
 	DeleteUser(ctx context.Context, delete *DeleteUser) error
+	RestoreUser(ctx context.Context, restore *RestoreUser) error
 	GetUserByUsername(ctx context.Context, username string) (*User, error)
 	GetUserByEmail(ctx context.Context, email string) (*User, error)
 	CreateRefreshToken(ctx context.Context, create *CreateRefreshToken) (*RefreshToken, error)
 	UpdateRefreshToken(ctx context.Context, update *UpdateRefreshToken) (*RefreshToken, error)
+	RevokeRefreshToken(ctx context.Context, id int64) (bool, error)
 	ListRefreshTokens(ctx context.Context, find *FindRefreshToken) ([]*RefreshToken, error)
 	DeleteRefreshToken(ctx context.Context, delete *DeleteRefreshToken) error
 	GetRefreshToken(ctx context.Context, token string) (*RefreshToken, error)
 
+	// PersonalAccessToken model related methods.
+	CreatePersonalAccessToken(ctx context.Context, create *CreatePersonalAccessToken) (*PersonalAccessToken, error)
+	UpdatePersonalAccessToken(ctx context.Context, update *UpdatePersonalAccessToken) (*PersonalAccessToken, error)
+	ListPersonalAccessTokens(ctx context.Context, find *FindPersonalAccessToken) ([]*PersonalAccessToken, error)
+	DeletePersonalAccessToken(ctx context.Context, delete *DeletePersonalAccessToken) error
+
+	// RevokedToken model related methods.
+	CreateRevokedToken(ctx context.Context, create *CreateRevokedToken) (*RevokedToken, error)
+	ListRevokedTokens(ctx context.Context, find *FindRevokedToken) ([]*RevokedToken, error)
+	DeleteRevokedTokens(ctx context.Context, delete *DeleteRevokedToken) error
+
+	// SecurityEvent model related methods.
+	CreateSecurityEvent(ctx context.Context, create *CreateSecurityEvent) (*SecurityEvent, error)
+	ListSecurityEvents(ctx context.Context, find *FindSecurityEvent) ([]*SecurityEvent, error)
+
+	// TOTPCredential model related methods.
+	CreateTOTPCredential(ctx context.Context, create *CreateTOTPCredential) (*TOTPCredential, error)
+	UpdateTOTPCredential(ctx context.Context, update *UpdateTOTPCredential) (*TOTPCredential, error)
+	ListTOTPCredentials(ctx context.Context, find *FindTOTPCredential) ([]*TOTPCredential, error)
+	DeleteTOTPCredential(ctx context.Context, delete *DeleteTOTPCredential) error
+	ReencryptTOTPCredentials(ctx context.Context, reencrypt *ReencryptTOTPCredentials) error
+
+	// LoginAttempt model related methods.
+	RecordLoginFailure(ctx context.Context, record *RecordLoginFailure) (*LoginAttempt, error)
+	UpdateLoginAttempt(ctx context.Context, update *UpdateLoginAttempt) (*LoginAttempt, error)
+	ListLoginAttempts(ctx context.Context, find *FindLoginAttempt) ([]*LoginAttempt, error)
+	DeleteLoginAttempts(ctx context.Context, delete *DeleteLoginAttempt) error
+
+	// PasswordHistory model related methods.
+	CreatePasswordHistory(ctx context.Context, create *CreatePasswordHistory) (*PasswordHistory, error)
+	ListPasswordHistories(ctx context.Context, find *FindPasswordHistory) ([]*PasswordHistory, error)
+	DeletePasswordHistories(ctx context.Context, delete *DeletePasswordHistory) error
+
+	// PasswordResetToken model related methods.
+	CreatePasswordResetToken(ctx context.Context, create *CreatePasswordResetToken) (*PasswordResetToken, error)
+	ListPasswordResetTokens(ctx context.Context, find *FindPasswordResetToken) ([]*PasswordResetToken, error)
+	UsePasswordResetToken(ctx context.Context, id int64) (bool, error)
+	DeletePasswordResetTokens(ctx context.Context, delete *DeletePasswordResetToken) error
+
+	// EmailVerification model related methods.
+	CreateEmailVerification(ctx context.Context, create *CreateEmailVerification) (*EmailVerification, error)
+	ListEmailVerifications(ctx context.Context, find *FindEmailVerification) ([]*EmailVerification, error)
+	DeleteEmailVerifications(ctx context.Context, delete *DeleteEmailVerification) error
+
 	// InstanceSetting model related methods.
 	UpsertInstanceSetting(ctx context.Context, upsert *InstanceSetting) (*InstanceSetting, error)
+	CompareAndSwapInstanceSetting(ctx context.Context, swap *CompareAndSwapInstanceSetting) (bool, error)
 	ListInstanceSettings(ctx context.Context, find *FindInstanceSetting) ([]*InstanceSetting, error)
 	DeleteInstanceSetting(ctx context.Context, delete *DeleteInstanceSetting) error
+	SetupInstance(ctx context.Context, setup *SetupInstance) (*User, error)
+
+	// RoleDefinition model related methods.
+	CreateRoleDefinition(ctx context.Context, create *CreateRoleDefinition) (*RoleDefinition, error)
+	UpdateRoleDefinition(ctx context.Context, update *UpdateRoleDefinition) (*RoleDefinition, error)
+	ListRoleDefinitions(ctx context.Context, find *FindRoleDefinition) ([]*RoleDefinition, error)
+	DeleteRoleDefinition(ctx context.Context, delete *DeleteRoleDefinition) error
+	SetUserRoles(ctx context.Context, set *SetUserRoles) error
+
+	// UserIdentity model related methods.
+	CreateUserIdentity(ctx context.Context, create *CreateUserIdentity) (*UserIdentity, error)
+	ListUserIdentities(ctx context.Context, find *FindUserIdentity) ([]*UserIdentity, error)
+
+	// OAuthClient model related methods.
+	CreateOAuthClient(ctx context.Context, create *CreateOAuthClient) (*OAuthClient, error)
+	UpdateOAuthClient(ctx context.Context, update *UpdateOAuthClient) (*OAuthClient, error)
+	ListOAuthClients(ctx context.Context, find *FindOAuthClient) ([]*OAuthClient, error)
+	DeleteOAuthClient(ctx context.Context, delete *DeleteOAuthClient) error
+
+	// OAuthAuthorizationCode model related methods.
+	CreateOAuthAuthorizationCode(ctx context.Context, create *CreateOAuthAuthorizationCode) (*OAuthAuthorizationCode, error)
+	ListOAuthAuthorizationCodes(ctx context.Context, find *FindOAuthAuthorizationCode) ([]*OAuthAuthorizationCode, error)
+	UseOAuthAuthorizationCode(ctx context.Context, id int64) (bool, error)
+
+	// OAuthConsent model related methods.
+	UpsertOAuthConsent(ctx context.Context, upsert *UpsertOAuthConsent) (*OAuthConsent, error)
+	ListOAuthConsents(ctx context.Context, find *FindOAuthConsent) ([]*OAuthConsent, error)
+	DeleteOAuthConsent(ctx context.Context, delete *DeleteOAuthConsent) error
+
+	// Invite model related methods.
+	CreateInvite(ctx context.Context, create *CreateInvite) (*Invite, error)
+	ListInvites(ctx context.Context, find *FindInvite) ([]*Invite, error)
+	UseInvite(ctx context.Context, use *UseInvite) (*User, error)
+	DeleteInvite(ctx context.Context, delete *DeleteInvite) error
 }
 
 type Store struct {
@@ -40,6 +121,11 @@ type Store struct {
 	cacheConfig          *cache.Config
 	userCache            *cache.Cache
 	instanceSettingCache *cache.Cache
+	revokedTokenCache    *cache.Cache
+	roleCache            *cache.Cache
+	userRoleCache        *cache.Cache
+
+	settingWatcher instanceSettingWatcher
 }
 
 func New(driver Driver, profile *profile.Profile) *Store {
@@ -48,12 +134,20 @@ func New(driver Driver, profile *profile.Profile) *Store {
 		CleanupInterval: 5 * time.Minute,
 		MaxItems:        1000,
 	}
+	// Revocations made by other instances sharing the database become visible once
+	// the cached lookup expires, so keep the TTL short.
+	revokedTokenCacheConfig := *cacheConfig
+	revokedTokenCacheConfig.DefaultTTL = time.Minute
+	revokedTokenCacheConfig.CleanupInterval = time.Minute
 	return &Store{
 		driver:               driver,
 		profile:              profile,
 		cacheConfig:          cacheConfig,
 		userCache:            cache.New(*cacheConfig),
 		instanceSettingCache: cache.New(*cacheConfig),
+		revokedTokenCache:    cache.New(revokedTokenCacheConfig),
+		roleCache:            cache.New(*cacheConfig),
+		userRoleCache:        cache.New(*cacheConfig),
 	}
 }
 
@@ -61,6 +155,10 @@ func (s *Store) GetDriver() Driver { return s.driver }
 
 func (s *Store) Close() error {
 	s.userCache.Close()
+	s.instanceSettingCache.Close()
+	s.revokedTokenCache.Close()
+	s.roleCache.Close()
+	s.userRoleCache.Close()
 	return s.driver.Close()
 }
 
@@ -116,6 +214,14 @@ func (s *Store) DeleteUser(ctx context.Context, delete *DeleteUser) error {
 	return nil
 }
 
+func (s *Store) RestoreUser(ctx context.Context, restore *RestoreUser) error {
+	if err := s.driver.RestoreUser(ctx, restore); err != nil {
+		return err
+	}
+	s.userCache.Delete(ctx, strconv.FormatInt(restore.ID, 10))
+	return nil
+}
+
 func (s *Store) GetUserByUsername(ctx context.Context, username string) (*User, error) {
 	return s.driver.GetUserByUsername(ctx, username)
 }
@@ -123,23 +229,3 @@ func (s *Store) GetUserByUsername(ctx context.Context, username string) (*User,
 func (s *Store) GetUserByEmail(ctx context.Context, email string) (*User, error) {
 	return s.driver.GetUserByEmail(ctx, email)
 }
-
-func (s *Store) CreateRefreshToken(ctx context.Context, create *CreateRefreshToken) (*RefreshToken, error) {
-	return s.driver.CreateRefreshToken(ctx, create)
-}
-
-func (s *Store) UpdateRefreshToken(ctx context.Context, update *UpdateRefreshToken) (*RefreshToken, error) {
-	return s.driver.UpdateRefreshToken(ctx, update)
-}
-
-func (s *Store) ListRefreshTokens(ctx context.Context, find *FindRefreshToken) ([]*RefreshToken, error) {
-	return s.driver.ListRefreshTokens(ctx, find)
-}
-
-func (s *Store) DeleteRefreshToken(ctx context.Context, delete *DeleteRefreshToken) error {
-	return s.driver.DeleteRefreshToken(ctx, delete)
-}
-
-func (s *Store) GetRefreshToken(ctx context.Context, token string) (*RefreshToken, error) {
-	return s.driver.GetRefreshToken(ctx, token)
-}
@@ -0,0 +1,14 @@
+package store_test
+
+import (
+	"testing"
+
+	"github.com/pixb/go-server/store"
+	"github.com/pixb/go-server/store/storetest"
+)
+
+// newTestStore returns a migrated store backed by a temporary SQLite database.
+func newTestStore(t *testing.T) *store.Store {
+	t.Helper()
+	return storetest.NewStore(t)
+}
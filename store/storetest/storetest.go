@@ -0,0 +1,32 @@
+// Package storetest provides stores backed by temporary SQLite databases for tests.
+package storetest
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/pixb/go-server/internal/profile"
+	"github.com/pixb/go-server/store"
+	"github.com/pixb/go-server/store/db/sqlite"
+)
+
+// NewStore returns a migrated store backed by a temporary SQLite database.
+// The store is closed when the test finishes.
+func NewStore(t testing.TB) *store.Store {
+	t.Helper()
+	p := &profile.Profile{
+		Driver: "sqlite",
+		DSN:    filepath.Join(t.TempDir(), "test.db"),
+	}
+	driver, err := sqlite.NewDriver(p)
+	if err != nil {
+		t.Fatalf("failed to open the test database: %v", err)
+	}
+	s := store.New(driver, p)
+	t.Cleanup(func() { s.Close() })
+	if err := s.Migrate(context.Background()); err != nil {
+		t.Fatalf("failed to migrate the test database: %v", err)
+	}
+	return s
+}
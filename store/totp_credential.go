@@ -0,0 +1,82 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// TOTPCredential is the TOTP second factor of a user. The secret and the
+// recovery codes are encrypted before they are persisted.
+type TOTPCredential struct {
+	ID     int64
+	UserID int64
+	Secret string
+	// RecoveryCodes holds the unused recovery codes.
+	RecoveryCodes string
+	// Enabled is set once the user confirmed the enrollment with a valid code.
+	Enabled bool
+	// LastUsedStep is the time step of the last accepted code, codes are accepted only once.
+	LastUsedStep int64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type CreateTOTPCredential struct {
+	UserID        int64
+	Secret        string
+	RecoveryCodes string
+}
+
+type UpdateTOTPCredential struct {
+	ID            int64
+	Secret        *string
+	RecoveryCodes *string
+	Enabled       *bool
+	LastUsedStep  *int64
+}
+
+// ReencryptTOTPCredentials replaces the encrypted fields of TOTP credentials after a
+// rotation of the instance secret.
+type ReencryptTOTPCredentials struct {
+	Credentials []*UpdateTOTPCredential
+	// Setting is upserted in the same transaction.
+	Setting *InstanceSetting
+}
+
+type FindTOTPCredential struct {
+	ID      *int64
+	UserID  *int64
+	Enabled *bool
+}
+
+type DeleteTOTPCredential struct {
+	UserID int64
+}
+
+func (s *Store) CreateTOTPCredential(ctx context.Context, create *CreateTOTPCredential) (*TOTPCredential, error) {
+	return s.driver.CreateTOTPCredential(ctx, create)
+}
+
+func (s *Store) UpdateTOTPCredential(ctx context.Context, update *UpdateTOTPCredential) (*TOTPCredential, error) {
+	return s.driver.UpdateTOTPCredential(ctx, update)
+}
+
+func (s *Store) ListTOTPCredentials(ctx context.Context, find *FindTOTPCredential) ([]*TOTPCredential, error) {
+	return s.driver.ListTOTPCredentials(ctx, find)
+}
+
+// GetTOTPCredential returns the first credential matching find, or nil if there is none.
+func (s *Store) GetTOTPCredential(ctx context.Context, find *FindTOTPCredential) (*TOTPCredential, error) {
+	list, err := s.ListTOTPCredentials(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+func (s *Store) DeleteTOTPCredential(ctx context.Context, delete *DeleteTOTPCredential) error {
+	return s.driver.DeleteTOTPCredential(ctx, delete)
+}
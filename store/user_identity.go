@@ -0,0 +1,52 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// UserIdentity links a user to the subject of an external identity provider.
+type UserIdentity struct {
+	ID     int64
+	UserID int64
+	// Provider is the ID of the identity provider in the instance settings.
+	Provider string
+	// Subject is the sub claim, the stable identifier of the account at the provider.
+	Subject   string
+	CreatedAt time.Time
+}
+
+type CreateUserIdentity struct {
+	UserID int64
+	// User is created in the same transaction when set, UserID is ignored then.
+	User     *User
+	Provider string
+	Subject  string
+}
+
+type FindUserIdentity struct {
+	UserID   *int64
+	Provider *string
+	Subject  *string
+}
+
+// CreateUserIdentity links a user to an external subject. A subject can only be linked once.
+func (s *Store) CreateUserIdentity(ctx context.Context, create *CreateUserIdentity) (*UserIdentity, error) {
+	return s.driver.CreateUserIdentity(ctx, create)
+}
+
+func (s *Store) ListUserIdentities(ctx context.Context, find *FindUserIdentity) ([]*UserIdentity, error) {
+	return s.driver.ListUserIdentities(ctx, find)
+}
+
+// GetUserIdentity returns the identity matching find, or nil if there is none.
+func (s *Store) GetUserIdentity(ctx context.Context, find *FindUserIdentity) (*UserIdentity, error) {
+	list, err := s.ListUserIdentities(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
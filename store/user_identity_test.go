@@ -0,0 +1,74 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pixb/go-server/store"
+)
+
+func TestUserIdentities(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+
+	newUser := func(username, email string) *store.User {
+		return &store.User{
+			Username:        username,
+			Email:           email,
+			Password:        "hash",
+			Nickname:        username,
+			Role:            store.RoleUser,
+			PasswordExpires: time.Now(),
+		}
+	}
+
+	// The user is created along with the identity
+	provider, subject := "example", "subject-1"
+	identity, err := s.CreateUserIdentity(ctx, &store.CreateUserIdentity{
+		User:     newUser("alice", "alice@example.com"),
+		Provider: provider,
+		Subject:  subject,
+	})
+	require.NoError(t, err)
+	user, err := s.GetUser(ctx, &store.FindUser{ID: &identity.UserID})
+	require.NoError(t, err)
+	assert.Equal(t, "alice", user.Username)
+
+	found, err := s.GetUserIdentity(ctx, &store.FindUserIdentity{Provider: &provider, Subject: &subject})
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.Equal(t, identity.ID, found.ID)
+	assert.Equal(t, user.ID, found.UserID)
+
+	// A subject can only be linked once, the new user is not created then
+	_, err = s.CreateUserIdentity(ctx, &store.CreateUserIdentity{
+		User:     newUser("bob", "bob@example.com"),
+		Provider: provider,
+		Subject:  subject,
+	})
+	require.Error(t, err)
+	bob, err := s.GetUserByUsername(ctx, "bob")
+	require.NoError(t, err)
+	assert.Nil(t, bob)
+
+	// Existing users are linked by ID
+	otherSubject := "subject-2"
+	_, err = s.CreateUserIdentity(ctx, &store.CreateUserIdentity{
+		UserID:   user.ID,
+		Provider: "other",
+		Subject:  otherSubject,
+	})
+	require.NoError(t, err)
+	identities, err := s.ListUserIdentities(ctx, &store.FindUserIdentity{UserID: &user.ID})
+	require.NoError(t, err)
+	assert.Len(t, identities, 2)
+
+	// Subjects are scoped to their provider
+	found, err = s.GetUserIdentity(ctx, &store.FindUserIdentity{Provider: &provider, Subject: &otherSubject})
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
@@ -0,0 +1,54 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pixb/go-server/store"
+)
+
+func TestUsers(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore(t)
+
+	var ids []int64
+	for _, username := range []string{"user1", "user2", "user3"} {
+		user, err := s.CreateUser(ctx, &store.User{
+			Username:        username,
+			Email:           username + "@example.com",
+			Password:        "hash",
+			Nickname:        username,
+			Role:            store.RoleUser,
+			PasswordExpires: time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+		ids = append(ids, user.ID)
+	}
+
+	// Pages are ordered by ID
+	users, err := s.ListUsers(ctx, &store.FindUser{Limit: 2, Offset: 1})
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	assert.Equal(t, ids[1], users[0].ID)
+	assert.Equal(t, ids[2], users[1].ID)
+
+	// Deleted users are only listed when asked for
+	require.NoError(t, s.DeleteUser(ctx, &store.DeleteUser{ID: ids[0]}))
+	users, err = s.ListUsers(ctx, &store.FindUser{})
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+	users, err = s.ListUsers(ctx, &store.FindUser{ID: &ids[0], ShowDeleted: true})
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.NotNil(t, users[0].DeletedAt)
+
+	require.NoError(t, s.RestoreUser(ctx, &store.RestoreUser{ID: ids[0]}))
+	user, err := s.GetUser(ctx, &store.FindUser{ID: &ids[0]})
+	require.NoError(t, err)
+	assert.Nil(t, user.DeletedAt)
+	assert.Equal(t, "user1", user.Username)
+}
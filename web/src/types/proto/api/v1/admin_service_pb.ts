@@ -0,0 +1,615 @@
+// @generated by protoc-gen-es v2.11.0 with parameter "target=ts"
+// @generated from file api/v1/admin_service.proto (package goserver.api.v1, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import { file_google_api_annotations } from "../../google/api/annotations_pb";
+import { file_google_api_client } from "../../google/api/client_pb";
+import { file_google_api_field_behavior } from "../../google/api/field_behavior_pb";
+import type { Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Role, User } from "./common_pb";
+import { file_api_v1_common } from "./common_pb";
+import { file_api_v1_options } from "./options_pb";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file api/v1/admin_service.proto.
+ */
+export const file_api_v1_admin_service: GenFile = /*@__PURE__*/
+  fileDesc("ChphcGkvdjEvYWRtaW5fc2VydmljZS5wcm90bxIPZ29zZXJ2ZXIuYXBpLnYxIogBChBMaXN0VXNlcnNSZXF1ZXN0EhYKCXBhZ2Vfc2l6ZRgBIAEoBUID4EEBEhcKCnBhZ2VfdG9rZW4YAiABKAlCA+BBARIoCgRyb2xlGAMgASgOMhUuZ29zZXJ2ZXIuYXBpLnYxLlJvbGVCA+BBARIZCgxzaG93X2RlbGV0ZWQYBCABKAhCA+BBASJcChFMaXN0VXNlcnNSZXNwb25zZRIpCgV1c2VycxgBIAMoCzIVLmdvc2VydmVyLmFwaS52MS5Vc2VyQgPgQQMSHAoPbmV4dF9wYWdlX3Rva2VuGAIgASgJQgPgQQMiIQoOR2V0VXNlclJlcXVlc3QSDwoCaWQYASABKANCA+BBAiI7Cg9HZXRVc2VyUmVzcG9uc2USKAoEdXNlchgBIAEoCzIVLmdvc2VydmVyLmFwaS52MS5Vc2VyQgPgQQMiqgEKEUNyZWF0ZVVzZXJSZXF1ZXN0EhUKCHVzZXJuYW1lGAEgASgJQgPgQQISFQoIbmlja25hbWUYAiABKAlCA+BBAhIVCghwYXNzd29yZBgDIAEoCUID4EECEhIKBWVtYWlsGAQgASgJQgPgQQISEgoFcGhvbmUYBSABKAlCA+BBARIoCgRyb2xlGAYgASgOMhUuZ29zZXJ2ZXIuYXBpLnYxLlJvbGVCA+BBASI+ChJDcmVhdGVVc2VyUmVzcG9uc2USKAoEdXNlchgBIAEoCzIVLmdvc2VydmVyLmFwaS52MS5Vc2VyQgPgQQMitwEKEVVwZGF0ZVVzZXJSZXF1ZXN0Eg8KAmlkGAEgASgDQgPgQQISFQoIbmlja25hbWUYAiABKAlCA+BBARISCgVlbWFpbBgDIAEoCUID4EEBEigKBHJvbGUYBCABKA4yFS5nb3NlcnZlci5hcGkudjEuUm9sZUID4EEBEjwKE3Bhc3N3b3JkX2V4cGlyZXNfYXQYBSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wQgPgQQEiPgoSVXBkYXRlVXNlclJlc3BvbnNlEigKBHVzZXIYASABKAsyFS5nb3NlcnZlci5hcGkudjEuVXNlckID4EEDIiQKEURlbGV0ZVVzZXJSZXF1ZXN0Eg8KAmlkGAEgASgDQgPgQQIiFAoSRGVsZXRlVXNlclJlc3BvbnNlIiUKElJlc3RvcmVVc2VyUmVxdWVzdBIPCgJpZBgBIAEoA0ID4EECIj8KE1Jlc3RvcmVVc2VyUmVzcG9uc2USKAoEdXNlchgBIAEoCzIVLmdvc2VydmVyLmFwaS52MS5Vc2VyQgPgQQMiRgoYUmVzZXRVc2VyUGFzc3dvcmRSZXF1ZXN0Eg8KAmlkGAEgASgDQgPgQQISGQoMbmV3X3Bhc3N3b3JkGAIgASgJQgPgQQIiGwoZUmVzZXRVc2VyUGFzc3dvcmRSZXNwb25zZSLmAQoGSW52aXRlEg8KAmlkGAEgASgDQgPgQQMSIwoEcm9sZRgCIAEoDjIVLmdvc2VydmVyLmFwaS52MS5Sb2xlEhAKCG1heF91c2VzGAMgASgFEhYKCXVzZV9jb3VudBgEIAEoBUID4EEDEi4KCmV4cGlyZXNfYXQYBSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhcKCmNyZWF0b3JfaWQYBiABKANCA+BBAxIzCgpjcmVhdGVkX2F0GAcgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEID4EEDIosBChNDcmVhdGVJbnZpdGVSZXF1ZXN0EigKBHJvbGUYASABKA4yFS5nb3NlcnZlci5hcGkudjEuUm9sZUID4EEBEhUKCG1heF91c2VzGAIgASgFQgPgQQESMwoKZXhwaXJlc19hdBgDIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBCA+BBASJXChRDcmVhdGVJbnZpdGVSZXNwb25zZRIsCgZpbnZpdGUYASABKAsyFy5nb3NlcnZlci5hcGkudjEuSW52aXRlQgPgQQMSEQoEY29kZRgCIAEoCUID4EEDIhQKEkxpc3RJbnZpdGVzUmVxdWVzdCJEChNMaXN0SW52aXRlc1Jlc3BvbnNlEi0KB2ludml0ZXMYASADKAsyFy5nb3NlcnZlci5hcGkudjEuSW52aXRlQgPgQQMiJgoTUmV2b2tlSW52aXRlUmVxdWVzdBIPCgJpZBgBIAEoA0ID4EECIhYKFFJldm9rZUludml0ZVJlc3BvbnNlMpsMCgxBZG1pblNlcnZpY2USggEKCUxpc3RVc2VycxIhLmdvc2VydmVyLmFwaS52MS5MaXN0VXNlcnNSZXF1ZXN0GiIuZ29zZXJ2ZXIuYXBpLnYxLkxpc3RVc2Vyc1Jlc3BvbnNlIi7aQQCKtRgMGgp1c2Vycy5yZWFkgtPkkwIVEhMvYXBpL3YxL2FkbWluL3VzZXJzEoMBCgdHZXRVc2VyEh8uZ29zZXJ2ZXIuYXBpLnYxLkdldFVzZXJSZXF1ZXN0GiAuZ29zZXJ2ZXIuYXBpLnYxLkdldFVzZXJSZXNwb25zZSI12kECaWSKtRgMGgp1c2Vycy5yZWFkgtPkkwIaEhgvYXBpL3YxL2FkbWluL3VzZXJzL3tpZH0SqQEKCkNyZWF0ZVVzZXISIi5nb3NlcnZlci5hcGkudjEuQ3JlYXRlVXNlclJlcXVlc3QaIy5nb3NlcnZlci5hcGkudjEuQ3JlYXRlVXNlclJlc3BvbnNlIlLaQSB1c2VybmFtZSxuaWNrbmFtZSxwYXNzd29yZCxlbWFpbIq1GA0aC3VzZXJzLndyaXRlgtPkkwIYOgEqIhMvYXBpL3YxL2FkbWluL3VzZXJzEpABCgpVcGRhdGVVc2VyEiIuZ29zZXJ2ZXIuYXBpLnYxLlVwZGF0ZVVzZXJSZXF1ZXN0GiMuZ29zZXJ2ZXIuYXBpLnYxLlVwZGF0ZVVzZXJSZXNwb25zZSI52kECaWSKtRgNGgt1c2Vycy53cml0ZYLT5JMCHToBKjIYL2FwaS92MS9hZG1pbi91c2Vycy97aWR9Eo0BCgpEZWxldGVVc2VyEiIuZ29zZXJ2ZXIuYXBpLnYxLkRlbGV0ZVVzZXJSZXF1ZXN0GiMuZ29zZXJ2ZXIuYXBpLnYxLkRlbGV0ZVVzZXJSZXNwb25zZSI22kECaWSKtRgNGgt1c2Vycy53cml0ZYLT5JMCGioYL2FwaS92MS9hZG1pbi91c2Vycy97aWR9EpsBCgtSZXN0b3JlVXNlchIjLmdvc2VydmVyLmFwaS52MS5SZXN0b3JlVXNlclJlcXVlc3QaJC5nb3NlcnZlci5hcGkudjEuUmVzdG9yZVVzZXJSZXNwb25zZSJB2kECaWSKtRgNGgt1c2Vycy53cml0ZYLT5JMCJToBKiIgL2FwaS92MS9hZG1pbi91c2Vycy97aWR9L3Jlc3RvcmUSwQEKEVJlc2V0VXNlclBhc3N3b3JkEikuZ29zZXJ2ZXIuYXBpLnYxLlJlc2V0VXNlclBhc3N3b3JkUmVxdWVzdBoqLmdvc2VydmVyLmFwaS52MS5SZXNldFVzZXJQYXNzd29yZFJlc3BvbnNlIlXaQQ9pZCxuZXdfcGFzc3dvcmSKtRgNGgt1c2Vycy53cml0ZYLT5JMCLDoBKiInL2FwaS92MS9hZG1pbi91c2Vycy97aWR9L3Jlc2V0LXBhc3N3b3JkEqkBCgxDcmVhdGVJbnZpdGUSJC5nb3NlcnZlci5hcGkudjEuQ3JlYXRlSW52aXRlUmVxdWVzdBolLmdvc2VydmVyLmFwaS52MS5DcmVhdGVJbnZpdGVSZXNwb25zZSJM2kEYcm9sZSxtYXhfdXNlcyxleHBpcmVzX2F0irUYDRoLdXNlcnMud3JpdGWC0+STAho6ASoiFS9hcGkvdjEvYWRtaW4vaW52aXRlcxKKAQoLTGlzdEludml0ZXMSIy5nb3NlcnZlci5hcGkudjEuTGlzdEludml0ZXNSZXF1ZXN0GiQuZ29zZXJ2ZXIuYXBpLnYxLkxpc3RJbnZpdGVzUmVzcG9uc2UiMNpBAIq1GAwaCnVzZXJzLnJlYWSC0+STAhcSFS9hcGkvdjEvYWRtaW4vaW52aXRlcxKVAQoMUmV2b2tlSW52aXRlEiQuZ29zZXJ2ZXIuYXBpLnYxLlJldm9rZUludml0ZVJlcXVlc3QaJS5nb3NlcnZlci5hcGkudjEuUmV2b2tlSW52aXRlUmVzcG9uc2UiONpBAmlkirUYDRoLdXNlcnMud3JpdGWC0+STAhwqGi9hcGkvdjEvYWRtaW4vaW52aXRlcy97aWR9QrgBChNjb20uZ29zZXJ2ZXIuYXBpLnYxQhFBZG1pblNlcnZpY2VQcm90b1ABWjBnaXRodWIuY29tL3BpeGIvZ28tc2VydmVyL3Byb3RvL2dlbi9hcGkvdjE7YXBpdjGiAgNHQViqAg9Hb3NlcnZlci5BcGkuVjHKAg9Hb3NlcnZlclxBcGlcVjHiAhtHb3NlcnZlclxBcGlcVjFcR1BCTWV0YWRhdGHqAhFHb3NlcnZlcjo6QXBpOjpWMWIGcHJvdG8z", [file_google_api_annotations, file_google_api_client, file_google_api_field_behavior, file_google_protobuf_timestamp, file_api_v1_common, file_api_v1_options]);
+
+/**
+ * @generated from message goserver.api.v1.ListUsersRequest
+ */
+export type ListUsersRequest = Message<"goserver.api.v1.ListUsersRequest"> & {
+  /**
+   * The maximum number of users to return. Defaults to 50, at most 1000.
+   *
+   * @generated from field: int32 page_size = 1;
+   */
+  pageSize: number;
+
+  /**
+   * The next_page_token of a previous response.
+   *
+   * @generated from field: string page_token = 2;
+   */
+  pageToken: string;
+
+  /**
+   * Only returns users with the role when set.
+   *
+   * @generated from field: goserver.api.v1.Role role = 3;
+   */
+  role: Role;
+
+  /**
+   * Includes soft-deleted users.
+   *
+   * @generated from field: bool show_deleted = 4;
+   */
+  showDeleted: boolean;
+};
+
+/**
+ * Describes the message goserver.api.v1.ListUsersRequest.
+ * Use `create(ListUsersRequestSchema)` to create a new message.
+ */
+export const ListUsersRequestSchema: GenMessage<ListUsersRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_admin_service, 0);
+
+/**
+ * @generated from message goserver.api.v1.ListUsersResponse
+ */
+export type ListUsersResponse = Message<"goserver.api.v1.ListUsersResponse"> & {
+  /**
+   * @generated from field: repeated goserver.api.v1.User users = 1;
+   */
+  users: User[];
+
+  /**
+   * Empty on the last page.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.ListUsersResponse.
+ * Use `create(ListUsersResponseSchema)` to create a new message.
+ */
+export const ListUsersResponseSchema: GenMessage<ListUsersResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_admin_service, 1);
+
+/**
+ * @generated from message goserver.api.v1.GetUserRequest
+ */
+export type GetUserRequest = Message<"goserver.api.v1.GetUserRequest"> & {
+  /**
+   * @generated from field: int64 id = 1;
+   */
+  id: bigint;
+};
+
+/**
+ * Describes the message goserver.api.v1.GetUserRequest.
+ * Use `create(GetUserRequestSchema)` to create a new message.
+ */
+export const GetUserRequestSchema: GenMessage<GetUserRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_admin_service, 2);
+
+/**
+ * @generated from message goserver.api.v1.GetUserResponse
+ */
+export type GetUserResponse = Message<"goserver.api.v1.GetUserResponse"> & {
+  /**
+   * @generated from field: goserver.api.v1.User user = 1;
+   */
+  user?: User;
+};
+
+/**
+ * Describes the message goserver.api.v1.GetUserResponse.
+ * Use `create(GetUserResponseSchema)` to create a new message.
+ */
+export const GetUserResponseSchema: GenMessage<GetUserResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_admin_service, 3);
+
+/**
+ * @generated from message goserver.api.v1.CreateUserRequest
+ */
+export type CreateUserRequest = Message<"goserver.api.v1.CreateUserRequest"> & {
+  /**
+   * @generated from field: string username = 1;
+   */
+  username: string;
+
+  /**
+   * @generated from field: string nickname = 2;
+   */
+  nickname: string;
+
+  /**
+   * @generated from field: string password = 3;
+   */
+  password: string;
+
+  /**
+   * @generated from field: string email = 4;
+   */
+  email: string;
+
+  /**
+   * @generated from field: string phone = 5;
+   */
+  phone: string;
+
+  /**
+   * Defaults to ROLE_USER.
+   *
+   * @generated from field: goserver.api.v1.Role role = 6;
+   */
+  role: Role;
+};
+
+/**
+ * Describes the message goserver.api.v1.CreateUserRequest.
+ * Use `create(CreateUserRequestSchema)` to create a new message.
+ */
+export const CreateUserRequestSchema: GenMessage<CreateUserRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_admin_service, 4);
+
+/**
+ * @generated from message goserver.api.v1.CreateUserResponse
+ */
+export type CreateUserResponse = Message<"goserver.api.v1.CreateUserResponse"> & {
+  /**
+   * @generated from field: goserver.api.v1.User user = 1;
+   */
+  user?: User;
+};
+
+/**
+ * Describes the message goserver.api.v1.CreateUserResponse.
+ * Use `create(CreateUserResponseSchema)` to create a new message.
+ */
+export const CreateUserResponseSchema: GenMessage<CreateUserResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_admin_service, 5);
+
+/**
+ * @generated from message goserver.api.v1.UpdateUserRequest
+ */
+export type UpdateUserRequest = Message<"goserver.api.v1.UpdateUserRequest"> & {
+  /**
+   * @generated from field: int64 id = 1;
+   */
+  id: bigint;
+
+  /**
+   * @generated from field: string nickname = 2;
+   */
+  nickname: string;
+
+  /**
+   * The new address is treated as verified.
+   *
+   * @generated from field: string email = 3;
+   */
+  email: string;
+
+  /**
+   * Admins cannot change their own role.
+   *
+   * @generated from field: goserver.api.v1.Role role = 4;
+   */
+  role: Role;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp password_expires_at = 5;
+   */
+  passwordExpiresAt?: Timestamp;
+};
+
+/**
+ * Describes the message goserver.api.v1.UpdateUserRequest.
+ * Use `create(UpdateUserRequestSchema)` to create a new message.
+ */
+export const UpdateUserRequestSchema: GenMessage<UpdateUserRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_admin_service, 6);
+
+/**
+ * @generated from message goserver.api.v1.UpdateUserResponse
+ */
+export type UpdateUserResponse = Message<"goserver.api.v1.UpdateUserResponse"> & {
+  /**
+   * @generated from field: goserver.api.v1.User user = 1;
+   */
+  user?: User;
+};
+
+/**
+ * Describes the message goserver.api.v1.UpdateUserResponse.
+ * Use `create(UpdateUserResponseSchema)` to create a new message.
+ */
+export const UpdateUserResponseSchema: GenMessage<UpdateUserResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_admin_service, 7);
+
+/**
+ * @generated from message goserver.api.v1.DeleteUserRequest
+ */
+export type DeleteUserRequest = Message<"goserver.api.v1.DeleteUserRequest"> & {
+  /**
+   * @generated from field: int64 id = 1;
+   */
+  id: bigint;
+};
+
+/**
+ * Describes the message goserver.api.v1.DeleteUserRequest.
+ * Use `create(DeleteUserRequestSchema)` to create a new message.
+ */
+export const DeleteUserRequestSchema: GenMessage<DeleteUserRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_admin_service, 8);
+
+/**
+ * @generated from message goserver.api.v1.DeleteUserResponse
+ */
+export type DeleteUserResponse = Message<"goserver.api.v1.DeleteUserResponse"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.DeleteUserResponse.
+ * Use `create(DeleteUserResponseSchema)` to create a new message.
+ */
+export const DeleteUserResponseSchema: GenMessage<DeleteUserResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_admin_service, 9);
+
+/**
+ * @generated from message goserver.api.v1.RestoreUserRequest
+ */
+export type RestoreUserRequest = Message<"goserver.api.v1.RestoreUserRequest"> & {
+  /**
+   * @generated from field: int64 id = 1;
+   */
+  id: bigint;
+};
+
+/**
+ * Describes the message goserver.api.v1.RestoreUserRequest.
+ * Use `create(RestoreUserRequestSchema)` to create a new message.
+ */
+export const RestoreUserRequestSchema: GenMessage<RestoreUserRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_admin_service, 10);
+
+/**
+ * @generated from message goserver.api.v1.RestoreUserResponse
+ */
+export type RestoreUserResponse = Message<"goserver.api.v1.RestoreUserResponse"> & {
+  /**
+   * @generated from field: goserver.api.v1.User user = 1;
+   */
+  user?: User;
+};
+
+/**
+ * Describes the message goserver.api.v1.RestoreUserResponse.
+ * Use `create(RestoreUserResponseSchema)` to create a new message.
+ */
+export const RestoreUserResponseSchema: GenMessage<RestoreUserResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_admin_service, 11);
+
+/**
+ * @generated from message goserver.api.v1.ResetUserPasswordRequest
+ */
+export type ResetUserPasswordRequest = Message<"goserver.api.v1.ResetUserPasswordRequest"> & {
+  /**
+   * @generated from field: int64 id = 1;
+   */
+  id: bigint;
+
+  /**
+   * @generated from field: string new_password = 2;
+   */
+  newPassword: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.ResetUserPasswordRequest.
+ * Use `create(ResetUserPasswordRequestSchema)` to create a new message.
+ */
+export const ResetUserPasswordRequestSchema: GenMessage<ResetUserPasswordRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_admin_service, 12);
+
+/**
+ * @generated from message goserver.api.v1.ResetUserPasswordResponse
+ */
+export type ResetUserPasswordResponse = Message<"goserver.api.v1.ResetUserPasswordResponse"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.ResetUserPasswordResponse.
+ * Use `create(ResetUserPasswordResponseSchema)` to create a new message.
+ */
+export const ResetUserPasswordResponseSchema: GenMessage<ResetUserPasswordResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_admin_service, 13);
+
+/**
+ * Invite lets people register while the registration policy requires an invite code.
+ *
+ * @generated from message goserver.api.v1.Invite
+ */
+export type Invite = Message<"goserver.api.v1.Invite"> & {
+  /**
+   * @generated from field: int64 id = 1;
+   */
+  id: bigint;
+
+  /**
+   * The role of the users who register with the invite.
+   *
+   * @generated from field: goserver.api.v1.Role role = 2;
+   */
+  role: Role;
+
+  /**
+   * The number of registrations the invite admits.
+   *
+   * @generated from field: int32 max_uses = 3;
+   */
+  maxUses: number;
+
+  /**
+   * @generated from field: int32 use_count = 4;
+   */
+  useCount: number;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp expires_at = 5;
+   */
+  expiresAt?: Timestamp;
+
+  /**
+   * The ID of the user who created the invite.
+   *
+   * @generated from field: int64 creator_id = 6;
+   */
+  creatorId: bigint;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 7;
+   */
+  createdAt?: Timestamp;
+};
+
+/**
+ * Describes the message goserver.api.v1.Invite.
+ * Use `create(InviteSchema)` to create a new message.
+ */
+export const InviteSchema: GenMessage<Invite> = /*@__PURE__*/
+  messageDesc(file_api_v1_admin_service, 14);
+
+/**
+ * @generated from message goserver.api.v1.CreateInviteRequest
+ */
+export type CreateInviteRequest = Message<"goserver.api.v1.CreateInviteRequest"> & {
+  /**
+   * Defaults to ROLE_USER.
+   *
+   * @generated from field: goserver.api.v1.Role role = 1;
+   */
+  role: Role;
+
+  /**
+   * Defaults to 1, at most 10000.
+   *
+   * @generated from field: int32 max_uses = 2;
+   */
+  maxUses: number;
+
+  /**
+   * Defaults to 7 days from now.
+   *
+   * @generated from field: google.protobuf.Timestamp expires_at = 3;
+   */
+  expiresAt?: Timestamp;
+};
+
+/**
+ * Describes the message goserver.api.v1.CreateInviteRequest.
+ * Use `create(CreateInviteRequestSchema)` to create a new message.
+ */
+export const CreateInviteRequestSchema: GenMessage<CreateInviteRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_admin_service, 15);
+
+/**
+ * @generated from message goserver.api.v1.CreateInviteResponse
+ */
+export type CreateInviteResponse = Message<"goserver.api.v1.CreateInviteResponse"> & {
+  /**
+   * @generated from field: goserver.api.v1.Invite invite = 1;
+   */
+  invite?: Invite;
+
+  /**
+   * The invite code, only returned here.
+   *
+   * @generated from field: string code = 2;
+   */
+  code: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.CreateInviteResponse.
+ * Use `create(CreateInviteResponseSchema)` to create a new message.
+ */
+export const CreateInviteResponseSchema: GenMessage<CreateInviteResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_admin_service, 16);
+
+/**
+ * @generated from message goserver.api.v1.ListInvitesRequest
+ */
+export type ListInvitesRequest = Message<"goserver.api.v1.ListInvitesRequest"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.ListInvitesRequest.
+ * Use `create(ListInvitesRequestSchema)` to create a new message.
+ */
+export const ListInvitesRequestSchema: GenMessage<ListInvitesRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_admin_service, 17);
+
+/**
+ * @generated from message goserver.api.v1.ListInvitesResponse
+ */
+export type ListInvitesResponse = Message<"goserver.api.v1.ListInvitesResponse"> & {
+  /**
+   * @generated from field: repeated goserver.api.v1.Invite invites = 1;
+   */
+  invites: Invite[];
+};
+
+/**
+ * Describes the message goserver.api.v1.ListInvitesResponse.
+ * Use `create(ListInvitesResponseSchema)` to create a new message.
+ */
+export const ListInvitesResponseSchema: GenMessage<ListInvitesResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_admin_service, 18);
+
+/**
+ * @generated from message goserver.api.v1.RevokeInviteRequest
+ */
+export type RevokeInviteRequest = Message<"goserver.api.v1.RevokeInviteRequest"> & {
+  /**
+   * @generated from field: int64 id = 1;
+   */
+  id: bigint;
+};
+
+/**
+ * Describes the message goserver.api.v1.RevokeInviteRequest.
+ * Use `create(RevokeInviteRequestSchema)` to create a new message.
+ */
+export const RevokeInviteRequestSchema: GenMessage<RevokeInviteRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_admin_service, 19);
+
+/**
+ * @generated from message goserver.api.v1.RevokeInviteResponse
+ */
+export type RevokeInviteResponse = Message<"goserver.api.v1.RevokeInviteResponse"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.RevokeInviteResponse.
+ * Use `create(RevokeInviteResponseSchema)` to create a new message.
+ */
+export const RevokeInviteResponseSchema: GenMessage<RevokeInviteResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_admin_service, 20);
+
+/**
+ * AdminService manages the users of the instance. Reading users requires the
+ * users.read permission, changing them users.write.
+ *
+ * @generated from service goserver.api.v1.AdminService
+ */
+export const AdminService: GenService<{
+  /**
+   * @generated from rpc goserver.api.v1.AdminService.ListUsers
+   */
+  listUsers: {
+    methodKind: "unary";
+    input: typeof ListUsersRequestSchema;
+    output: typeof ListUsersResponseSchema;
+  },
+  /**
+   * @generated from rpc goserver.api.v1.AdminService.GetUser
+   */
+  getUser: {
+    methodKind: "unary";
+    input: typeof GetUserRequestSchema;
+    output: typeof GetUserResponseSchema;
+  },
+  /**
+   * Creates a user with the given role. The email address is treated as verified.
+   *
+   * @generated from rpc goserver.api.v1.AdminService.CreateUser
+   */
+  createUser: {
+    methodKind: "unary";
+    input: typeof CreateUserRequestSchema;
+    output: typeof CreateUserResponseSchema;
+  },
+  /**
+   * Updates the fields that are set. Empty fields are left unchanged.
+   *
+   * @generated from rpc goserver.api.v1.AdminService.UpdateUser
+   */
+  updateUser: {
+    methodKind: "unary";
+    input: typeof UpdateUserRequestSchema;
+    output: typeof UpdateUserResponseSchema;
+  },
+  /**
+   * Soft-deletes a user and signs out all of their sessions.
+   *
+   * @generated from rpc goserver.api.v1.AdminService.DeleteUser
+   */
+  deleteUser: {
+    methodKind: "unary";
+    input: typeof DeleteUserRequestSchema;
+    output: typeof DeleteUserResponseSchema;
+  },
+  /**
+   * Restores a soft-deleted user.
+   *
+   * @generated from rpc goserver.api.v1.AdminService.RestoreUser
+   */
+  restoreUser: {
+    methodKind: "unary";
+    input: typeof RestoreUserRequestSchema;
+    output: typeof RestoreUserResponseSchema;
+  },
+  /**
+   * Sets a new password for a user and signs out all of their sessions.
+   *
+   * @generated from rpc goserver.api.v1.AdminService.ResetUserPassword
+   */
+  resetUserPassword: {
+    methodKind: "unary";
+    input: typeof ResetUserPasswordRequestSchema;
+    output: typeof ResetUserPasswordResponseSchema;
+  },
+  /**
+   * Creates an invite code that lets people register while the registration policy
+   * requires one. Callers need all permissions of the role the invite grants.
+   *
+   * @generated from rpc goserver.api.v1.AdminService.CreateInvite
+   */
+  createInvite: {
+    methodKind: "unary";
+    input: typeof CreateInviteRequestSchema;
+    output: typeof CreateInviteResponseSchema;
+  },
+  /**
+   * @generated from rpc goserver.api.v1.AdminService.ListInvites
+   */
+  listInvites: {
+    methodKind: "unary";
+    input: typeof ListInvitesRequestSchema;
+    output: typeof ListInvitesResponseSchema;
+  },
+  /**
+   * Revokes an invite, its code cannot be used afterwards.
+   *
+   * @generated from rpc goserver.api.v1.AdminService.RevokeInvite
+   */
+  revokeInvite: {
+    methodKind: "unary";
+    input: typeof RevokeInviteRequestSchema;
+    output: typeof RevokeInviteResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_api_v1_admin_service, 0);
+
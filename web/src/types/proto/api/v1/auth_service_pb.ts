@@ -11,13 +11,14 @@ import type { Timestamp } from "@bufbuild/protobuf/wkt";
 import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
 import type { User } from "./common_pb";
 import { file_api_v1_common } from "./common_pb";
+import { file_api_v1_options } from "./options_pb";
 import type { Message } from "@bufbuild/protobuf";
 
 /**
  * Describes the file api/v1/auth_service.proto.
  */
 export const file_api_v1_auth_service: GenFile = /*@__PURE__*/
-  fileDesc("ChlhcGkvdjEvYXV0aF9zZXJ2aWNlLnByb3RvEg9nb3NlcnZlci5hcGkudjEiPAoMTG9naW5SZXF1ZXN0EhUKCHVzZXJuYW1lGAEgASgJQgPgQQISFQoIcGFzc3dvcmQYAiABKAlCA+BBAiKyAQoNTG9naW5SZXNwb25zZRIZCgxhY2Nlc3NfdG9rZW4YASABKAlCA+BBAxIaCg1yZWZyZXNoX3Rva2VuGAIgASgJQgPgQQMSQAoXYWNjZXNzX3Rva2VuX2V4cGlyZXNfYXQYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wQgPgQQMSKAoEdXNlchgEIAEoCzIVLmdvc2VydmVyLmFwaS52MS5Vc2VyQgPgQQMiMQoTUmVmcmVzaFRva2VuUmVxdWVzdBIaCg1yZWZyZXNoX3Rva2VuGAEgASgJQgPgQQIiuQEKFFJlZnJlc2hUb2tlblJlc3BvbnNlEhkKDGFjY2Vzc190b2tlbhgBIAEoCUID4EEDEhoKDXJlZnJlc2hfdG9rZW4YAiABKAlCA+BBAxJAChdhY2Nlc3NfdG9rZW5fZXhwaXJlc19hdBgDIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBCA+BBAxIoCgR1c2VyGAQgASgLMhUuZ29zZXJ2ZXIuYXBpLnYxLlVzZXJCA+BBAyIqChRWYWxpZGF0ZVRva2VuUmVxdWVzdBISCgV0b2tlbhgBIAEoCUID4EECIqABChVWYWxpZGF0ZVRva2VuUmVzcG9uc2USEgoFdmFsaWQYASABKAhCA+BBAxIUCgd1c2VyX2lkGAIgASgDQgPgQQMSFQoIdXNlcm5hbWUYAyABKAlCA+BBAxIRCgRyb2xlGAQgASgJQgPgQQMSMwoKZXhwaXJlc19hdBgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBCA+BBAyIjCg1Mb2dvdXRSZXF1ZXN0EhIKBXRva2VuGAEgASgJQgPgQQIiJgoOTG9nb3V0UmVzcG9uc2USFAoHc3VjY2VzcxgBIAEoCEID4EEDMpUECgtBdXRoU2VydmljZRJ5CgVMb2dpbhIdLmdvc2VydmVyLmFwaS52MS5Mb2dpblJlcXVlc3QaHi5nb3NlcnZlci5hcGkudjEuTG9naW5SZXNwb25zZSIx2kERdXNlcm5hbWUscGFzc3dvcmSC0+STAhc6ASoiEi9hcGkvdjEvYXV0aC9sb2dpbhKMAQoMUmVmcmVzaFRva2VuEiQuZ29zZXJ2ZXIuYXBpLnYxLlJlZnJlc2hUb2tlblJlcXVlc3QaJS5nb3NlcnZlci5hcGkudjEuUmVmcmVzaFRva2VuUmVzcG9uc2UiL9pBDXJlZnJlc2hfdG9rZW6C0+STAhk6ASoiFC9hcGkvdjEvYXV0aC9yZWZyZXNoEogBCg1WYWxpZGF0ZVRva2VuEiUuZ29zZXJ2ZXIuYXBpLnYxLlZhbGlkYXRlVG9rZW5SZXF1ZXN0GiYuZ29zZXJ2ZXIuYXBpLnYxLlZhbGlkYXRlVG9rZW5SZXNwb25zZSIo2kEFdG9rZW6C0+STAho6ASoiFS9hcGkvdjEvYXV0aC92YWxpZGF0ZRJxCgZMb2dvdXQSHi5nb3NlcnZlci5hcGkudjEuTG9nb3V0UmVxdWVzdBofLmdvc2VydmVyLmFwaS52MS5Mb2dvdXRSZXNwb25zZSIm2kEFdG9rZW6C0+STAhg6ASoiEy9hcGkvdjEvYXV0aC9sb2dvdXRCtwEKE2NvbS5nb3NlcnZlci5hcGkudjFCEEF1dGhTZXJ2aWNlUHJvdG9QAVowZ2l0aHViLmNvbS9waXhiL2dvLXNlcnZlci9wcm90by9nZW4vYXBpL3YxO2FwaXYxogIDR0FYqgIPR29zZXJ2ZXIuQXBpLlYxygIPR29zZXJ2ZXJcQXBpXFYx4gIbR29zZXJ2ZXJcQXBpXFYxXEdQQk1ldGFkYXRh6gIRR29zZXJ2ZXI6OkFwaTo6VjFiBnByb3RvMw", [file_google_api_annotations, file_google_api_client, file_google_api_field_behavior, file_google_protobuf_timestamp, file_api_v1_common]);
+  fileDesc("ChlhcGkvdjEvYXV0aF9zZXJ2aWNlLnByb3RvEg9nb3NlcnZlci5hcGkudjEiPAoMTG9naW5SZXF1ZXN0EhUKCHVzZXJuYW1lGAEgASgJQgPgQQISFQoIcGFzc3dvcmQYAiABKAlCA+BBAiKkAgoNTG9naW5SZXNwb25zZRIZCgxhY2Nlc3NfdG9rZW4YASABKAlCA+BBAxIaCg1yZWZyZXNoX3Rva2VuGAIgASgJQgPgQQMSQAoXYWNjZXNzX3Rva2VuX2V4cGlyZXNfYXQYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wQgPgQQMSKAoEdXNlchgEIAEoCzIVLmdvc2VydmVyLmFwaS52MS5Vc2VyQgPgQQMSGQoMbWZhX3JlcXVpcmVkGAUgASgIQgPgQQMSFgoJbWZhX3Rva2VuGAYgASgJQgPgQQMSPQoUbWZhX3Rva2VuX2V4cGlyZXNfYXQYByABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wQgPgQQMiPQoQVmVyaWZ5TUZBUmVxdWVzdBIWCgltZmFfdG9rZW4YASABKAlCA+BBAhIRCgRjb2RlGAIgASgJQgPgQQIitgEKEVZlcmlmeU1GQVJlc3BvbnNlEhkKDGFjY2Vzc190b2tlbhgBIAEoCUID4EEDEhoKDXJlZnJlc2hfdG9rZW4YAiABKAlCA+BBAxJAChdhY2Nlc3NfdG9rZW5fZXhwaXJlc19hdBgDIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBCA+BBAxIoCgR1c2VyGAQgASgLMhUuZ29zZXJ2ZXIuYXBpLnYxLlVzZXJCA+BBAyIxChNSZWZyZXNoVG9rZW5SZXF1ZXN0EhoKDXJlZnJlc2hfdG9rZW4YASABKAlCA+BBAiK5AQoUUmVmcmVzaFRva2VuUmVzcG9uc2USGQoMYWNjZXNzX3Rva2VuGAEgASgJQgPgQQMSGgoNcmVmcmVzaF90b2tlbhgCIAEoCUID4EEDEkAKF2FjY2Vzc190b2tlbl9leHBpcmVzX2F0GAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEID4EEDEigKBHVzZXIYBCABKAsyFS5nb3NlcnZlci5hcGkudjEuVXNlckID4EEDIioKFFZhbGlkYXRlVG9rZW5SZXF1ZXN0EhIKBXRva2VuGAEgASgJQgPgQQIioAEKFVZhbGlkYXRlVG9rZW5SZXNwb25zZRISCgV2YWxpZBgBIAEoCEID4EEDEhQKB3VzZXJfaWQYAiABKANCA+BBAxIVCgh1c2VybmFtZRgDIAEoCUID4EEDEhEKBHJvbGUYBCABKAlCA+BBAxIzCgpleHBpcmVzX2F0GAUgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEID4EEDIj8KDUxvZ291dFJlcXVlc3QSEgoFdG9rZW4YASABKAlCA+BBAhIaCg1yZWZyZXNoX3Rva2VuGAIgASgJQgPgQQEiJgoOTG9nb3V0UmVzcG9uc2USFAoHc3VjY2VzcxgBIAEoCEID4EEDIjEKG1JlcXVlc3RQYXNzd29yZFJlc2V0UmVxdWVzdBISCgVlbWFpbBgBIAEoCUID4EECIh4KHFJlcXVlc3RQYXNzd29yZFJlc2V0UmVzcG9uc2UiTAobQ29uZmlybVBhc3N3b3JkUmVzZXRSZXF1ZXN0EhIKBXRva2VuGAEgASgJQgPgQQISGQoMbmV3X3Bhc3N3b3JkGAIgASgJQgPgQQIiHgocQ29uZmlybVBhc3N3b3JkUmVzZXRSZXNwb25zZSIbChlMaXN0TG9naW5Qcm92aWRlcnNSZXF1ZXN0IlQKGkxpc3RMb2dpblByb3ZpZGVyc1Jlc3BvbnNlEjYKCXByb3ZpZGVycxgBIAMoCzIeLmdvc2VydmVyLmFwaS52MS5Mb2dpblByb3ZpZGVyQgPgQQMiTAoNTG9naW5Qcm92aWRlchIPCgJpZBgBIAEoCUID4EEDEhIKBXRpdGxlGAIgASgJQgPgQQMSFgoJbG9naW5fdXJsGAMgASgJQgPgQQMywwkKC0F1dGhTZXJ2aWNlEn8KBUxvZ2luEh0uZ29zZXJ2ZXIuYXBpLnYxLkxvZ2luUmVxdWVzdBoeLmdvc2VydmVyLmFwaS52MS5Mb2dpblJlc3BvbnNlIjfaQRF1c2VybmFtZSxwYXNzd29yZIq1GAIIAYLT5JMCFzoBKiISL2FwaS92MS9hdXRoL2xvZ2luEo0BCglWZXJpZnlNRkESIS5nb3NlcnZlci5hcGkudjEuVmVyaWZ5TUZBUmVxdWVzdBoiLmdvc2VydmVyLmFwaS52MS5WZXJpZnlNRkFSZXNwb25zZSI52kEObWZhX3Rva2VuLGNvZGWKtRgCCAGC0+STAhw6ASoiFy9hcGkvdjEvYXV0aC9tZmEvdmVyaWZ5EpIBCgxSZWZyZXNoVG9rZW4SJC5nb3NlcnZlci5hcGkudjEuUmVmcmVzaFRva2VuUmVxdWVzdBolLmdvc2VydmVyLmFwaS52MS5SZWZyZXNoVG9rZW5SZXNwb25zZSI12kENcmVmcmVzaF90b2tlboq1GAIIAYLT5JMCGToBKiIUL2FwaS92MS9hdXRoL3JlZnJlc2gSlAEKDVZhbGlkYXRlVG9rZW4SJS5nb3NlcnZlci5hcGkudjEuVmFsaWRhdGVUb2tlblJlcXVlc3QaJi5nb3NlcnZlci5hcGkudjEuVmFsaWRhdGVUb2tlblJlc3BvbnNlIjTaQQV0b2tlboq1GAIIAZK1GAIIAYLT5JMCGjoBKiIVL2FwaS92MS9hdXRoL3ZhbGlkYXRlEnEKBkxvZ291dBIeLmdvc2VydmVyLmFwaS52MS5Mb2dvdXRSZXF1ZXN0Gh8uZ29zZXJ2ZXIuYXBpLnYxLkxvZ291dFJlc3BvbnNlIibaQQV0b2tlboLT5JMCGDoBKiITL2FwaS92MS9hdXRoL2xvZ291dBKpAQoUUmVxdWVzdFBhc3N3b3JkUmVzZXQSLC5nb3NlcnZlci5hcGkudjEuUmVxdWVzdFBhc3N3b3JkUmVzZXRSZXF1ZXN0Gi0uZ29zZXJ2ZXIuYXBpLnYxLlJlcXVlc3RQYXNzd29yZFJlc2V0UmVzcG9uc2UiNNpBBWVtYWlsirUYAggBgtPkkwIgOgEqIhsvYXBpL3YxL2F1dGgvcGFzc3dvcmQtcmVzZXQSvgEKFENvbmZpcm1QYXNzd29yZFJlc2V0EiwuZ29zZXJ2ZXIuYXBpLnYxLkNvbmZpcm1QYXNzd29yZFJlc2V0UmVxdWVzdBotLmdvc2VydmVyLmFwaS52MS5Db25maXJtUGFzc3dvcmRSZXNldFJlc3BvbnNlIknaQRJ0b2tlbixuZXdfcGFzc3dvcmSKtRgCCAGC0+STAig6ASoiIy9hcGkvdjEvYXV0aC9wYXNzd29yZC1yZXNldC9jb25maXJtEpYBChJMaXN0TG9naW5Qcm92aWRlcnMSKi5nb3NlcnZlci5hcGkudjEuTGlzdExvZ2luUHJvdmlkZXJzUmVxdWVzdBorLmdvc2VydmVyLmFwaS52MS5MaXN0TG9naW5Qcm92aWRlcnNSZXNwb25zZSIn2kEAirUYAggBgtPkkwIYEhYvYXBpL3YxL2F1dGgvcHJvdmlkZXJzQrcBChNjb20uZ29zZXJ2ZXIuYXBpLnYxQhBBdXRoU2VydmljZVByb3RvUAFaMGdpdGh1Yi5jb20vcGl4Yi9nby1zZXJ2ZXIvcHJvdG8vZ2VuL2FwaS92MTthcGl2MaICA0dBWKoCD0dvc2VydmVyLkFwaS5WMcoCD0dvc2VydmVyXEFwaVxWMeICG0dvc2VydmVyXEFwaVxWMVxHUEJNZXRhZGF0YeoCEUdvc2VydmVyOjpBcGk6OlYxYgZwcm90bzM", [file_google_api_annotations, file_google_api_client, file_google_api_field_behavior, file_google_protobuf_timestamp, file_api_v1_common, file_api_v1_options]);
 
 /**
  * @generated from message goserver.api.v1.LoginRequest
@@ -64,6 +65,24 @@ export type LoginResponse = Message<"goserver.api.v1.LoginResponse"> & {
    * @generated from field: goserver.api.v1.User user = 4;
    */
   user?: User;
+
+  /**
+   * Set when the user has two-factor authentication enabled. No tokens are issued,
+   * instead the mfa_token is passed to VerifyMFA together with a code.
+   *
+   * @generated from field: bool mfa_required = 5;
+   */
+  mfaRequired: boolean;
+
+  /**
+   * @generated from field: string mfa_token = 6;
+   */
+  mfaToken: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp mfa_token_expires_at = 7;
+   */
+  mfaTokenExpiresAt?: Timestamp;
 };
 
 /**
@@ -73,6 +92,64 @@ export type LoginResponse = Message<"goserver.api.v1.LoginResponse"> & {
 export const LoginResponseSchema: GenMessage<LoginResponse> = /*@__PURE__*/
   messageDesc(file_api_v1_auth_service, 1);
 
+/**
+ * @generated from message goserver.api.v1.VerifyMFARequest
+ */
+export type VerifyMFARequest = Message<"goserver.api.v1.VerifyMFARequest"> & {
+  /**
+   * The challenge token returned by Login. It can be used only once.
+   *
+   * @generated from field: string mfa_token = 1;
+   */
+  mfaToken: string;
+
+  /**
+   * A TOTP code from the authenticator app or an unused recovery code.
+   *
+   * @generated from field: string code = 2;
+   */
+  code: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.VerifyMFARequest.
+ * Use `create(VerifyMFARequestSchema)` to create a new message.
+ */
+export const VerifyMFARequestSchema: GenMessage<VerifyMFARequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_auth_service, 2);
+
+/**
+ * @generated from message goserver.api.v1.VerifyMFAResponse
+ */
+export type VerifyMFAResponse = Message<"goserver.api.v1.VerifyMFAResponse"> & {
+  /**
+   * @generated from field: string access_token = 1;
+   */
+  accessToken: string;
+
+  /**
+   * @generated from field: string refresh_token = 2;
+   */
+  refreshToken: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp access_token_expires_at = 3;
+   */
+  accessTokenExpiresAt?: Timestamp;
+
+  /**
+   * @generated from field: goserver.api.v1.User user = 4;
+   */
+  user?: User;
+};
+
+/**
+ * Describes the message goserver.api.v1.VerifyMFAResponse.
+ * Use `create(VerifyMFAResponseSchema)` to create a new message.
+ */
+export const VerifyMFAResponseSchema: GenMessage<VerifyMFAResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_auth_service, 3);
+
 /**
  * @generated from message goserver.api.v1.RefreshTokenRequest
  */
@@ -88,7 +165,7 @@ export type RefreshTokenRequest = Message<"goserver.api.v1.RefreshTokenRequest">
  * Use `create(RefreshTokenRequestSchema)` to create a new message.
  */
 export const RefreshTokenRequestSchema: GenMessage<RefreshTokenRequest> = /*@__PURE__*/
-  messageDesc(file_api_v1_auth_service, 2);
+  messageDesc(file_api_v1_auth_service, 4);
 
 /**
  * @generated from message goserver.api.v1.RefreshTokenResponse
@@ -120,7 +197,7 @@ export type RefreshTokenResponse = Message<"goserver.api.v1.RefreshTokenResponse
  * Use `create(RefreshTokenResponseSchema)` to create a new message.
  */
 export const RefreshTokenResponseSchema: GenMessage<RefreshTokenResponse> = /*@__PURE__*/
-  messageDesc(file_api_v1_auth_service, 3);
+  messageDesc(file_api_v1_auth_service, 5);
 
 /**
  * @generated from message goserver.api.v1.ValidateTokenRequest
@@ -137,7 +214,7 @@ export type ValidateTokenRequest = Message<"goserver.api.v1.ValidateTokenRequest
  * Use `create(ValidateTokenRequestSchema)` to create a new message.
  */
 export const ValidateTokenRequestSchema: GenMessage<ValidateTokenRequest> = /*@__PURE__*/
-  messageDesc(file_api_v1_auth_service, 4);
+  messageDesc(file_api_v1_auth_service, 6);
 
 /**
  * @generated from message goserver.api.v1.ValidateTokenResponse
@@ -174,7 +251,7 @@ export type ValidateTokenResponse = Message<"goserver.api.v1.ValidateTokenRespon
  * Use `create(ValidateTokenResponseSchema)` to create a new message.
  */
 export const ValidateTokenResponseSchema: GenMessage<ValidateTokenResponse> = /*@__PURE__*/
-  messageDesc(file_api_v1_auth_service, 5);
+  messageDesc(file_api_v1_auth_service, 7);
 
 /**
  * @generated from message goserver.api.v1.LogoutRequest
@@ -184,6 +261,14 @@ export type LogoutRequest = Message<"goserver.api.v1.LogoutRequest"> & {
    * @generated from field: string token = 1;
    */
   token: string;
+
+  /**
+   * The refresh token of the current session. When empty, the refresh tokens of the session the
+   * access token was issued for are revoked, or all of the user's refresh tokens if it has none.
+   *
+   * @generated from field: string refresh_token = 2;
+   */
+  refreshToken: string;
 };
 
 /**
@@ -191,7 +276,7 @@ export type LogoutRequest = Message<"goserver.api.v1.LogoutRequest"> & {
  * Use `create(LogoutRequestSchema)` to create a new message.
  */
 export const LogoutRequestSchema: GenMessage<LogoutRequest> = /*@__PURE__*/
-  messageDesc(file_api_v1_auth_service, 6);
+  messageDesc(file_api_v1_auth_service, 8);
 
 /**
  * @generated from message goserver.api.v1.LogoutResponse
@@ -208,7 +293,135 @@ export type LogoutResponse = Message<"goserver.api.v1.LogoutResponse"> & {
  * Use `create(LogoutResponseSchema)` to create a new message.
  */
 export const LogoutResponseSchema: GenMessage<LogoutResponse> = /*@__PURE__*/
-  messageDesc(file_api_v1_auth_service, 7);
+  messageDesc(file_api_v1_auth_service, 9);
+
+/**
+ * @generated from message goserver.api.v1.RequestPasswordResetRequest
+ */
+export type RequestPasswordResetRequest = Message<"goserver.api.v1.RequestPasswordResetRequest"> & {
+  /**
+   * @generated from field: string email = 1;
+   */
+  email: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.RequestPasswordResetRequest.
+ * Use `create(RequestPasswordResetRequestSchema)` to create a new message.
+ */
+export const RequestPasswordResetRequestSchema: GenMessage<RequestPasswordResetRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_auth_service, 10);
+
+/**
+ * @generated from message goserver.api.v1.RequestPasswordResetResponse
+ */
+export type RequestPasswordResetResponse = Message<"goserver.api.v1.RequestPasswordResetResponse"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.RequestPasswordResetResponse.
+ * Use `create(RequestPasswordResetResponseSchema)` to create a new message.
+ */
+export const RequestPasswordResetResponseSchema: GenMessage<RequestPasswordResetResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_auth_service, 11);
+
+/**
+ * @generated from message goserver.api.v1.ConfirmPasswordResetRequest
+ */
+export type ConfirmPasswordResetRequest = Message<"goserver.api.v1.ConfirmPasswordResetRequest"> & {
+  /**
+   * @generated from field: string token = 1;
+   */
+  token: string;
+
+  /**
+   * @generated from field: string new_password = 2;
+   */
+  newPassword: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.ConfirmPasswordResetRequest.
+ * Use `create(ConfirmPasswordResetRequestSchema)` to create a new message.
+ */
+export const ConfirmPasswordResetRequestSchema: GenMessage<ConfirmPasswordResetRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_auth_service, 12);
+
+/**
+ * @generated from message goserver.api.v1.ConfirmPasswordResetResponse
+ */
+export type ConfirmPasswordResetResponse = Message<"goserver.api.v1.ConfirmPasswordResetResponse"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.ConfirmPasswordResetResponse.
+ * Use `create(ConfirmPasswordResetResponseSchema)` to create a new message.
+ */
+export const ConfirmPasswordResetResponseSchema: GenMessage<ConfirmPasswordResetResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_auth_service, 13);
+
+/**
+ * @generated from message goserver.api.v1.ListLoginProvidersRequest
+ */
+export type ListLoginProvidersRequest = Message<"goserver.api.v1.ListLoginProvidersRequest"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.ListLoginProvidersRequest.
+ * Use `create(ListLoginProvidersRequestSchema)` to create a new message.
+ */
+export const ListLoginProvidersRequestSchema: GenMessage<ListLoginProvidersRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_auth_service, 14);
+
+/**
+ * @generated from message goserver.api.v1.ListLoginProvidersResponse
+ */
+export type ListLoginProvidersResponse = Message<"goserver.api.v1.ListLoginProvidersResponse"> & {
+  /**
+   * @generated from field: repeated goserver.api.v1.LoginProvider providers = 1;
+   */
+  providers: LoginProvider[];
+};
+
+/**
+ * Describes the message goserver.api.v1.ListLoginProvidersResponse.
+ * Use `create(ListLoginProvidersResponseSchema)` to create a new message.
+ */
+export const ListLoginProvidersResponseSchema: GenMessage<ListLoginProvidersResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_auth_service, 15);
+
+/**
+ * @generated from message goserver.api.v1.LoginProvider
+ */
+export type LoginProvider = Message<"goserver.api.v1.LoginProvider"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string title = 2;
+   */
+  title: string;
+
+  /**
+   * The path that starts a sign-in with the provider in the browser. It takes an optional
+   * redirect query parameter, the local path returned to afterwards. The result is passed
+   * in the fragment of that path: access_token, refresh_token and access_token_expires_at,
+   * mfa_token and mfa_token_expires_at when two-factor authentication is required, or
+   * error and error_description.
+   *
+   * @generated from field: string login_url = 3;
+   */
+  loginUrl: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.LoginProvider.
+ * Use `create(LoginProviderSchema)` to create a new message.
+ */
+export const LoginProviderSchema: GenMessage<LoginProvider> = /*@__PURE__*/
+  messageDesc(file_api_v1_auth_service, 16);
 
 /**
  * @generated from service goserver.api.v1.AuthService
@@ -222,6 +435,16 @@ export const AuthService: GenService<{
     input: typeof LoginRequestSchema;
     output: typeof LoginResponseSchema;
   },
+  /**
+   * Completes a login that requires two-factor authentication.
+   *
+   * @generated from rpc goserver.api.v1.AuthService.VerifyMFA
+   */
+  verifyMFA: {
+    methodKind: "unary";
+    input: typeof VerifyMFARequestSchema;
+    output: typeof VerifyMFAResponseSchema;
+  },
   /**
    * @generated from rpc goserver.api.v1.AuthService.RefreshToken
    */
@@ -246,6 +469,38 @@ export const AuthService: GenService<{
     input: typeof LogoutRequestSchema;
     output: typeof LogoutResponseSchema;
   },
+  /**
+   * Sends a single-use password reset token to the email address of the user.
+   * The response is the same whether or not a user has the address.
+   *
+   * @generated from rpc goserver.api.v1.AuthService.RequestPasswordReset
+   */
+  requestPasswordReset: {
+    methodKind: "unary";
+    input: typeof RequestPasswordResetRequestSchema;
+    output: typeof RequestPasswordResetResponseSchema;
+  },
+  /**
+   * Sets a new password with a token sent by RequestPasswordReset.
+   * All sessions of the user are signed out.
+   *
+   * @generated from rpc goserver.api.v1.AuthService.ConfirmPasswordReset
+   */
+  confirmPasswordReset: {
+    methodKind: "unary";
+    input: typeof ConfirmPasswordResetRequestSchema;
+    output: typeof ConfirmPasswordResetResponseSchema;
+  },
+  /**
+   * Lists the external identity providers users can sign in with.
+   *
+   * @generated from rpc goserver.api.v1.AuthService.ListLoginProviders
+   */
+  listLoginProviders: {
+    methodKind: "unary";
+    input: typeof ListLoginProvidersRequestSchema;
+    output: typeof ListLoginProvidersResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_api_v1_auth_service, 0);
 
@@ -13,7 +13,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file api/v1/common.proto.
  */
 export const file_api_v1_common: GenFile = /*@__PURE__*/
-  fileDesc("ChNhcGkvdjEvY29tbW9uLnByb3RvEg9nb3NlcnZlci5hcGkudjEivwIKBFVzZXISDwoCaWQYASABKANCA+BBAxIVCgh1c2VybmFtZRgCIAEoCUID4EECEhIKBWVtYWlsGAMgASgJQgPgQQISFQoIbmlja25hbWUYBCABKAlCA+BBAhISCgVwaG9uZRgFIAEoCUID4EECEigKBHJvbGUYBiABKA4yFS5nb3NlcnZlci5hcGkudjEuUm9sZUID4EEDEjwKE3Bhc3N3b3JkX2V4cGlyZXNfYXQYByABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wQgPgQQMSMwoKY3JlYXRlZF9hdBgIIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBCA+BBAxIzCgp1cGRhdGVkX2F0GAkgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEID4EEDKjsKBFJvbGUSFAoQUk9MRV9VTlNQRUNJRklFRBAAEg4KClJPTEVfQURNSU4QARINCglST0xFX1VTRVIQAkKyAQoTY29tLmdvc2VydmVyLmFwaS52MUILQ29tbW9uUHJvdG9QAVowZ2l0aHViLmNvbS9waXhiL2dvLXNlcnZlci9wcm90by9nZW4vYXBpL3YxO2FwaXYxogIDR0FYqgIPR29zZXJ2ZXIuQXBpLlYxygIPR29zZXJ2ZXJcQXBpXFYx4gIbR29zZXJ2ZXJcQXBpXFYxXEdQQk1ldGFkYXRh6gIRR29zZXJ2ZXI6OkFwaTo6VjFiBnByb3RvMw", [file_google_api_field_behavior, file_google_protobuf_timestamp]);
+  fileDesc("ChNhcGkvdjEvY29tbW9uLnByb3RvEg9nb3NlcnZlci5hcGkudjEikQMKBFVzZXISDwoCaWQYASABKANCA+BBAxIVCgh1c2VybmFtZRgCIAEoCUID4EECEhIKBWVtYWlsGAMgASgJQgPgQQISFQoIbmlja25hbWUYBCABKAlCA+BBAhISCgVwaG9uZRgFIAEoCUID4EECEigKBHJvbGUYBiABKA4yFS5nb3NlcnZlci5hcGkudjEuUm9sZUID4EEDEjwKE3Bhc3N3b3JkX2V4cGlyZXNfYXQYByABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wQgPgQQMSMwoKY3JlYXRlZF9hdBgIIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBCA+BBAxIzCgp1cGRhdGVkX2F0GAkgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEID4EEDEhsKDmVtYWlsX3ZlcmlmaWVkGAogASgIQgPgQQMSMwoKZGVsZXRlZF9hdBgLIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBCA+BBAyo7CgRSb2xlEhQKEFJPTEVfVU5TUEVDSUZJRUQQABIOCgpST0xFX0FETUlOEAESDQoJUk9MRV9VU0VSEAJCsgEKE2NvbS5nb3NlcnZlci5hcGkudjFCC0NvbW1vblByb3RvUAFaMGdpdGh1Yi5jb20vcGl4Yi9nby1zZXJ2ZXIvcHJvdG8vZ2VuL2FwaS92MTthcGl2MaICA0dBWKoCD0dvc2VydmVyLkFwaS5WMcoCD0dvc2VydmVyXEFwaVxWMeICG0dvc2VydmVyXEFwaVxWMVxHUEJNZXRhZGF0YeoCEUdvc2VydmVyOjpBcGk6OlYxYgZwcm90bzM", [file_google_api_field_behavior, file_google_protobuf_timestamp]);
 
 /**
  * @generated from message goserver.api.v1.User
@@ -63,6 +63,18 @@ export type User = Message<"goserver.api.v1.User"> & {
    * @generated from field: google.protobuf.Timestamp updated_at = 9;
    */
   updatedAt?: Timestamp;
+
+  /**
+   * @generated from field: bool email_verified = 10;
+   */
+  emailVerified: boolean;
+
+  /**
+   * Set only on soft-deleted users, which are visible to admins.
+   *
+   * @generated from field: google.protobuf.Timestamp deleted_at = 11;
+   */
+  deletedAt?: Timestamp;
 };
 
 /**
@@ -0,0 +1,345 @@
+// @generated by protoc-gen-es v2.11.0 with parameter "target=ts"
+// @generated from file api/v1/identity_provider_service.proto (package goserver.api.v1, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import { file_google_api_annotations } from "../../google/api/annotations_pb";
+import { file_google_api_client } from "../../google/api/client_pb";
+import { file_google_api_field_behavior } from "../../google/api/field_behavior_pb";
+import type { FieldMask } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_field_mask } from "@bufbuild/protobuf/wkt";
+import { file_api_v1_options } from "./options_pb";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file api/v1/identity_provider_service.proto.
+ */
+export const file_api_v1_identity_provider_service: GenFile = /*@__PURE__*/
+  fileDesc("CiZhcGkvdjEvaWRlbnRpdHlfcHJvdmlkZXJfc2VydmljZS5wcm90bxIPZ29zZXJ2ZXIuYXBpLnYxIsQCChBJZGVudGl0eVByb3ZpZGVyEg8KAmlkGAEgASgJQgPgQQUSEgoFdGl0bGUYAiABKAlCA+BBAhITCgZpc3N1ZXIYAyABKAlCA+BBAhIWCgljbGllbnRfaWQYBCABKAlCA+BBAhIaCg1jbGllbnRfc2VjcmV0GAUgASgJQgPgQQQSEwoGc2NvcGVzGAYgAygJQgPgQQESSgoNY2xhaW1fbWFwcGluZxgHIAEoCzIuLmdvc2VydmVyLmFwaS52MS5JZGVudGl0eVByb3ZpZGVyLkNsYWltTWFwcGluZ0ID4EEBEh4KEWhhc19jbGllbnRfc2VjcmV0GAggASgIQgPgQQMaQQoMQ2xhaW1NYXBwaW5nEhAKCHVzZXJuYW1lGAEgASgJEhAKCG5pY2tuYW1lGAIgASgJEg0KBWVtYWlsGAMgASgJIh4KHExpc3RJZGVudGl0eVByb3ZpZGVyc1JlcXVlc3QiYwodTGlzdElkZW50aXR5UHJvdmlkZXJzUmVzcG9uc2USQgoSaWRlbnRpdHlfcHJvdmlkZXJzGAEgAygLMiEuZ29zZXJ2ZXIuYXBpLnYxLklkZW50aXR5UHJvdmlkZXJCA+BBAyItChpHZXRJZGVudGl0eVByb3ZpZGVyUmVxdWVzdBIPCgJpZBgBIAEoCUID4EECImAKG0dldElkZW50aXR5UHJvdmlkZXJSZXNwb25zZRJBChFpZGVudGl0eV9wcm92aWRlchgBIAEoCzIhLmdvc2VydmVyLmFwaS52MS5JZGVudGl0eVByb3ZpZGVyQgPgQQMiYgodQ3JlYXRlSWRlbnRpdHlQcm92aWRlclJlcXVlc3QSQQoRaWRlbnRpdHlfcHJvdmlkZXIYASABKAsyIS5nb3NlcnZlci5hcGkudjEuSWRlbnRpdHlQcm92aWRlckID4EECImMKHkNyZWF0ZUlkZW50aXR5UHJvdmlkZXJSZXNwb25zZRJBChFpZGVudGl0eV9wcm92aWRlchgBIAEoCzIhLmdvc2VydmVyLmFwaS52MS5JZGVudGl0eVByb3ZpZGVyQgPgQQMimAEKHVVwZGF0ZUlkZW50aXR5UHJvdmlkZXJSZXF1ZXN0EkEKEWlkZW50aXR5X3Byb3ZpZGVyGAEgASgLMiEuZ29zZXJ2ZXIuYXBpLnYxLklkZW50aXR5UHJvdmlkZXJCA+BBAhI0Cgt1cGRhdGVfbWFzaxgCIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5GaWVsZE1hc2tCA+BBAiJjCh5VcGRhdGVJZGVudGl0eVByb3ZpZGVyUmVzcG9uc2USQQoRaWRlbnRpdHlfcHJvdmlkZXIYASABKAsyIS5nb3NlcnZlci5hcGkudjEuSWRlbnRpdHlQcm92aWRlckID4EEDIjAKHURlbGV0ZUlkZW50aXR5UHJvdmlkZXJSZXF1ZXN0Eg8KAmlkGAEgASgJQgPgQQIiIAoeRGVsZXRlSWRlbnRpdHlQcm92aWRlclJlc3BvbnNlMpsIChdJZGVudGl0eVByb3ZpZGVyU2VydmljZRKwAQoVTGlzdElkZW50aXR5UHJvdmlkZXJzEi0uZ29zZXJ2ZXIuYXBpLnYxLkxpc3RJZGVudGl0eVByb3ZpZGVyc1JlcXVlc3QaLi5nb3NlcnZlci5hcGkudjEuTGlzdElkZW50aXR5UHJvdmlkZXJzUmVzcG9uc2UiONpBAIq1GA8aDXNldHRpbmdzLnJlYWSC0+STAhwSGi9hcGkvdjEvaWRlbnRpdHktcHJvdmlkZXJzErEBChNHZXRJZGVudGl0eVByb3ZpZGVyEisuZ29zZXJ2ZXIuYXBpLnYxLkdldElkZW50aXR5UHJvdmlkZXJSZXF1ZXN0GiwuZ29zZXJ2ZXIuYXBpLnYxLkdldElkZW50aXR5UHJvdmlkZXJSZXNwb25zZSI/2kECaWSKtRgPGg1zZXR0aW5ncy5yZWFkgtPkkwIhEh8vYXBpL3YxL2lkZW50aXR5LXByb3ZpZGVycy97aWR9EtkBChZDcmVhdGVJZGVudGl0eVByb3ZpZGVyEi4uZ29zZXJ2ZXIuYXBpLnYxLkNyZWF0ZUlkZW50aXR5UHJvdmlkZXJSZXF1ZXN0Gi8uZ29zZXJ2ZXIuYXBpLnYxLkNyZWF0ZUlkZW50aXR5UHJvdmlkZXJSZXNwb25zZSJe2kERaWRlbnRpdHlfcHJvdmlkZXKKtRgRGg9zZXR0aW5ncy51cGRhdGWC0+STAi86EWlkZW50aXR5X3Byb3ZpZGVyIhovYXBpL3YxL2lkZW50aXR5LXByb3ZpZGVycxL9AQoWVXBkYXRlSWRlbnRpdHlQcm92aWRlchIuLmdvc2VydmVyLmFwaS52MS5VcGRhdGVJZGVudGl0eVByb3ZpZGVyUmVxdWVzdBovLmdvc2VydmVyLmFwaS52MS5VcGRhdGVJZGVudGl0eVByb3ZpZGVyUmVzcG9uc2UigQHaQR1pZGVudGl0eV9wcm92aWRlcix1cGRhdGVfbWFza4q1GBEaD3NldHRpbmdzLnVwZGF0ZYLT5JMCRjoRaWRlbnRpdHlfcHJvdmlkZXIyMS9hcGkvdjEvaWRlbnRpdHktcHJvdmlkZXJzL3tpZGVudGl0eV9wcm92aWRlci5pZH0SvAEKFkRlbGV0ZUlkZW50aXR5UHJvdmlkZXISLi5nb3NlcnZlci5hcGkudjEuRGVsZXRlSWRlbnRpdHlQcm92aWRlclJlcXVlc3QaLy5nb3NlcnZlci5hcGkudjEuRGVsZXRlSWRlbnRpdHlQcm92aWRlclJlc3BvbnNlIkHaQQJpZIq1GBEaD3NldHRpbmdzLnVwZGF0ZYLT5JMCISofL2FwaS92MS9pZGVudGl0eS1wcm92aWRlcnMve2lkfULDAQoTY29tLmdvc2VydmVyLmFwaS52MUIcSWRlbnRpdHlQcm92aWRlclNlcnZpY2VQcm90b1ABWjBnaXRodWIuY29tL3BpeGIvZ28tc2VydmVyL3Byb3RvL2dlbi9hcGkvdjE7YXBpdjGiAgNHQViqAg9Hb3NlcnZlci5BcGkuVjHKAg9Hb3NlcnZlclxBcGlcVjHiAhtHb3NlcnZlclxBcGlcVjFcR1BCTWV0YWRhdGHqAhFHb3NlcnZlcjo6QXBpOjpWMWIGcHJvdG8z", [file_google_api_annotations, file_google_api_client, file_google_api_field_behavior, file_google_protobuf_field_mask, file_api_v1_options]);
+
+/**
+ * @generated from message goserver.api.v1.IdentityProvider
+ */
+export type IdentityProvider = Message<"goserver.api.v1.IdentityProvider"> & {
+  /**
+   * Lowercase letters, digits, "-" and "_", unique across providers.
+   *
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * The name shown on the sign-in page.
+   *
+   * @generated from field: string title = 2;
+   */
+  title: string;
+
+  /**
+   * The issuer URL, the provider configuration is discovered from {issuer}/.well-known/openid-configuration.
+   *
+   * @generated from field: string issuer = 3;
+   */
+  issuer: string;
+
+  /**
+   * @generated from field: string client_id = 4;
+   */
+  clientId: string;
+
+  /**
+   * Empty for public clients. It is never returned.
+   *
+   * @generated from field: string client_secret = 5;
+   */
+  clientSecret: string;
+
+  /**
+   * The scopes requested in addition to openid, "profile" and "email" by default.
+   *
+   * @generated from field: repeated string scopes = 6;
+   */
+  scopes: string[];
+
+  /**
+   * @generated from field: goserver.api.v1.IdentityProvider.ClaimMapping claim_mapping = 7;
+   */
+  claimMapping?: IdentityProvider_ClaimMapping;
+
+  /**
+   * Whether a client secret is set.
+   *
+   * @generated from field: bool has_client_secret = 8;
+   */
+  hasClientSecret: boolean;
+};
+
+/**
+ * Describes the message goserver.api.v1.IdentityProvider.
+ * Use `create(IdentityProviderSchema)` to create a new message.
+ */
+export const IdentityProviderSchema: GenMessage<IdentityProvider> = /*@__PURE__*/
+  messageDesc(file_api_v1_identity_provider_service, 0);
+
+/**
+ * ClaimMapping names the ID token claims the fields of new users are taken from.
+ * Empty values fall back to the standard claims.
+ *
+ * @generated from message goserver.api.v1.IdentityProvider.ClaimMapping
+ */
+export type IdentityProvider_ClaimMapping = Message<"goserver.api.v1.IdentityProvider.ClaimMapping"> & {
+  /**
+   * "preferred_username" by default.
+   *
+   * @generated from field: string username = 1;
+   */
+  username: string;
+
+  /**
+   * "name" by default.
+   *
+   * @generated from field: string nickname = 2;
+   */
+  nickname: string;
+
+  /**
+   * "email" by default.
+   *
+   * @generated from field: string email = 3;
+   */
+  email: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.IdentityProvider.ClaimMapping.
+ * Use `create(IdentityProvider_ClaimMappingSchema)` to create a new message.
+ */
+export const IdentityProvider_ClaimMappingSchema: GenMessage<IdentityProvider_ClaimMapping> = /*@__PURE__*/
+  messageDesc(file_api_v1_identity_provider_service, 0, 0);
+
+/**
+ * @generated from message goserver.api.v1.ListIdentityProvidersRequest
+ */
+export type ListIdentityProvidersRequest = Message<"goserver.api.v1.ListIdentityProvidersRequest"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.ListIdentityProvidersRequest.
+ * Use `create(ListIdentityProvidersRequestSchema)` to create a new message.
+ */
+export const ListIdentityProvidersRequestSchema: GenMessage<ListIdentityProvidersRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_identity_provider_service, 1);
+
+/**
+ * @generated from message goserver.api.v1.ListIdentityProvidersResponse
+ */
+export type ListIdentityProvidersResponse = Message<"goserver.api.v1.ListIdentityProvidersResponse"> & {
+  /**
+   * @generated from field: repeated goserver.api.v1.IdentityProvider identity_providers = 1;
+   */
+  identityProviders: IdentityProvider[];
+};
+
+/**
+ * Describes the message goserver.api.v1.ListIdentityProvidersResponse.
+ * Use `create(ListIdentityProvidersResponseSchema)` to create a new message.
+ */
+export const ListIdentityProvidersResponseSchema: GenMessage<ListIdentityProvidersResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_identity_provider_service, 2);
+
+/**
+ * @generated from message goserver.api.v1.GetIdentityProviderRequest
+ */
+export type GetIdentityProviderRequest = Message<"goserver.api.v1.GetIdentityProviderRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.GetIdentityProviderRequest.
+ * Use `create(GetIdentityProviderRequestSchema)` to create a new message.
+ */
+export const GetIdentityProviderRequestSchema: GenMessage<GetIdentityProviderRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_identity_provider_service, 3);
+
+/**
+ * @generated from message goserver.api.v1.GetIdentityProviderResponse
+ */
+export type GetIdentityProviderResponse = Message<"goserver.api.v1.GetIdentityProviderResponse"> & {
+  /**
+   * @generated from field: goserver.api.v1.IdentityProvider identity_provider = 1;
+   */
+  identityProvider?: IdentityProvider;
+};
+
+/**
+ * Describes the message goserver.api.v1.GetIdentityProviderResponse.
+ * Use `create(GetIdentityProviderResponseSchema)` to create a new message.
+ */
+export const GetIdentityProviderResponseSchema: GenMessage<GetIdentityProviderResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_identity_provider_service, 4);
+
+/**
+ * @generated from message goserver.api.v1.CreateIdentityProviderRequest
+ */
+export type CreateIdentityProviderRequest = Message<"goserver.api.v1.CreateIdentityProviderRequest"> & {
+  /**
+   * @generated from field: goserver.api.v1.IdentityProvider identity_provider = 1;
+   */
+  identityProvider?: IdentityProvider;
+};
+
+/**
+ * Describes the message goserver.api.v1.CreateIdentityProviderRequest.
+ * Use `create(CreateIdentityProviderRequestSchema)` to create a new message.
+ */
+export const CreateIdentityProviderRequestSchema: GenMessage<CreateIdentityProviderRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_identity_provider_service, 5);
+
+/**
+ * @generated from message goserver.api.v1.CreateIdentityProviderResponse
+ */
+export type CreateIdentityProviderResponse = Message<"goserver.api.v1.CreateIdentityProviderResponse"> & {
+  /**
+   * @generated from field: goserver.api.v1.IdentityProvider identity_provider = 1;
+   */
+  identityProvider?: IdentityProvider;
+};
+
+/**
+ * Describes the message goserver.api.v1.CreateIdentityProviderResponse.
+ * Use `create(CreateIdentityProviderResponseSchema)` to create a new message.
+ */
+export const CreateIdentityProviderResponseSchema: GenMessage<CreateIdentityProviderResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_identity_provider_service, 6);
+
+/**
+ * @generated from message goserver.api.v1.UpdateIdentityProviderRequest
+ */
+export type UpdateIdentityProviderRequest = Message<"goserver.api.v1.UpdateIdentityProviderRequest"> & {
+  /**
+   * @generated from field: goserver.api.v1.IdentityProvider identity_provider = 1;
+   */
+  identityProvider?: IdentityProvider;
+
+  /**
+   * The fields to update: "title", "issuer", "client_id", "client_secret", "scopes" and "claim_mapping".
+   *
+   * @generated from field: google.protobuf.FieldMask update_mask = 2;
+   */
+  updateMask?: FieldMask;
+};
+
+/**
+ * Describes the message goserver.api.v1.UpdateIdentityProviderRequest.
+ * Use `create(UpdateIdentityProviderRequestSchema)` to create a new message.
+ */
+export const UpdateIdentityProviderRequestSchema: GenMessage<UpdateIdentityProviderRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_identity_provider_service, 7);
+
+/**
+ * @generated from message goserver.api.v1.UpdateIdentityProviderResponse
+ */
+export type UpdateIdentityProviderResponse = Message<"goserver.api.v1.UpdateIdentityProviderResponse"> & {
+  /**
+   * @generated from field: goserver.api.v1.IdentityProvider identity_provider = 1;
+   */
+  identityProvider?: IdentityProvider;
+};
+
+/**
+ * Describes the message goserver.api.v1.UpdateIdentityProviderResponse.
+ * Use `create(UpdateIdentityProviderResponseSchema)` to create a new message.
+ */
+export const UpdateIdentityProviderResponseSchema: GenMessage<UpdateIdentityProviderResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_identity_provider_service, 8);
+
+/**
+ * @generated from message goserver.api.v1.DeleteIdentityProviderRequest
+ */
+export type DeleteIdentityProviderRequest = Message<"goserver.api.v1.DeleteIdentityProviderRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.DeleteIdentityProviderRequest.
+ * Use `create(DeleteIdentityProviderRequestSchema)` to create a new message.
+ */
+export const DeleteIdentityProviderRequestSchema: GenMessage<DeleteIdentityProviderRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_identity_provider_service, 9);
+
+/**
+ * @generated from message goserver.api.v1.DeleteIdentityProviderResponse
+ */
+export type DeleteIdentityProviderResponse = Message<"goserver.api.v1.DeleteIdentityProviderResponse"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.DeleteIdentityProviderResponse.
+ * Use `create(DeleteIdentityProviderResponseSchema)` to create a new message.
+ */
+export const DeleteIdentityProviderResponseSchema: GenMessage<DeleteIdentityProviderResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_identity_provider_service, 10);
+
+/**
+ * IdentityProviderService manages the external OpenID Connect providers users can sign in with.
+ * A sign-in starts at /auth/oidc/{id}/login, the provider redirects back to /auth/oidc/{id}/callback
+ * which has to be registered as a redirect URI of the client at the provider.
+ *
+ * @generated from service goserver.api.v1.IdentityProviderService
+ */
+export const IdentityProviderService: GenService<{
+  /**
+   * @generated from rpc goserver.api.v1.IdentityProviderService.ListIdentityProviders
+   */
+  listIdentityProviders: {
+    methodKind: "unary";
+    input: typeof ListIdentityProvidersRequestSchema;
+    output: typeof ListIdentityProvidersResponseSchema;
+  },
+  /**
+   * @generated from rpc goserver.api.v1.IdentityProviderService.GetIdentityProvider
+   */
+  getIdentityProvider: {
+    methodKind: "unary";
+    input: typeof GetIdentityProviderRequestSchema;
+    output: typeof GetIdentityProviderResponseSchema;
+  },
+  /**
+   * @generated from rpc goserver.api.v1.IdentityProviderService.CreateIdentityProvider
+   */
+  createIdentityProvider: {
+    methodKind: "unary";
+    input: typeof CreateIdentityProviderRequestSchema;
+    output: typeof CreateIdentityProviderResponseSchema;
+  },
+  /**
+   * Updates the fields of an identity provider listed in update_mask.
+   *
+   * @generated from rpc goserver.api.v1.IdentityProviderService.UpdateIdentityProvider
+   */
+  updateIdentityProvider: {
+    methodKind: "unary";
+    input: typeof UpdateIdentityProviderRequestSchema;
+    output: typeof UpdateIdentityProviderResponseSchema;
+  },
+  /**
+   * Deletes an identity provider. Users who signed in with it keep their accounts,
+   * but cannot sign in with the provider anymore.
+   *
+   * @generated from rpc goserver.api.v1.IdentityProviderService.DeleteIdentityProvider
+   */
+  deleteIdentityProvider: {
+    methodKind: "unary";
+    input: typeof DeleteIdentityProviderRequestSchema;
+    output: typeof DeleteIdentityProviderResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_api_v1_identity_provider_service, 0);
+
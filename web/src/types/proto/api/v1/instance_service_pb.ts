@@ -2,18 +2,23 @@
 // @generated from file api/v1/instance_service.proto (package goserver.api.v1, syntax proto3)
 /* eslint-disable */
 
-import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
-import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { GenEnum, GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { enumDesc, fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
 import type { User } from "./common_pb";
 import { file_api_v1_common } from "./common_pb";
+import { file_api_v1_options } from "./options_pb";
 import { file_google_api_annotations } from "../../google/api/annotations_pb";
+import { file_google_api_client } from "../../google/api/client_pb";
+import { file_google_api_field_behavior } from "../../google/api/field_behavior_pb";
+import type { FieldMask } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_field_mask } from "@bufbuild/protobuf/wkt";
 import type { Message } from "@bufbuild/protobuf";
 
 /**
  * Describes the file api/v1/instance_service.proto.
  */
 export const file_api_v1_instance_service: GenFile = /*@__PURE__*/
-  fileDesc("Ch1hcGkvdjEvaW5zdGFuY2Vfc2VydmljZS5wcm90bxIPZ29zZXJ2ZXIuYXBpLnYxIlYKD0luc3RhbmNlUHJvZmlsZRIPCgd2ZXJzaW9uGAEgASgJEgwKBGRlbW8YAiABKAgSJAoFYWRtaW4YAyABKAsyFS5nb3NlcnZlci5hcGkudjEuVXNlciIbChlHZXRJbnN0YW5jZVByb2ZpbGVSZXF1ZXN0MpgBCg9JbnN0YW5jZVNlcnZpY2UShAEKEkdldEluc3RhbmNlUHJvZmlsZRIqLmdvc2VydmVyLmFwaS52MS5HZXRJbnN0YW5jZVByb2ZpbGVSZXF1ZXN0GiAuZ29zZXJ2ZXIuYXBpLnYxLkluc3RhbmNlUHJvZmlsZSIggtPkkwIaEhgvYXBpL3YxL2luc3RhbmNlL3Byb2ZpbGVCuwEKE2NvbS5nb3NlcnZlci5hcGkudjFCFEluc3RhbmNlU2VydmljZVByb3RvUAFaMGdpdGh1Yi5jb20vcGl4Yi9nby1zZXJ2ZXIvcHJvdG8vZ2VuL2FwaS92MTthcGl2MaICA0dBWKoCD0dvc2VydmVyLkFwaS5WMcoCD0dvc2VydmVyXEFwaVxWMeICG0dvc2VydmVyXEFwaVxWMVxHUEJNZXRhZGF0YeoCEUdvc2VydmVyOjpBcGk6OlYxYgZwcm90bzM", [file_api_v1_common, file_google_api_annotations]);
+  fileDesc("Ch1hcGkvdjEvaW5zdGFuY2Vfc2VydmljZS5wcm90bxIPZ29zZXJ2ZXIuYXBpLnYxIs8CCg9JbnN0YW5jZVByb2ZpbGUSDwoHdmVyc2lvbhgBIAEoCRIMCgRkZW1vGAIgASgIEiQKBWFkbWluGAMgASgLMhUuZ29zZXJ2ZXIuYXBpLnYxLlVzZXISQQoLbWFpbnRlbmFuY2UYBCABKAsyLC5nb3NlcnZlci5hcGkudjEuSW5zdGFuY2VQcm9maWxlLk1haW50ZW5hbmNlGrMBCgtNYWludGVuYW5jZRI/CgRtb2RlGAEgASgOMjEuZ29zZXJ2ZXIuYXBpLnYxLkluc3RhbmNlUHJvZmlsZS5NYWludGVuYW5jZS5Nb2RlEg8KB21lc3NhZ2UYAiABKAkSGwoTcmV0cnlfYWZ0ZXJfc2Vjb25kcxgDIAEoBSI1CgRNb2RlEhQKEE1PREVfVU5TUEVDSUZJRUQQABINCglSRUFEX09OTFkQARIICgRGVUxMEAIiGwoZR2V0SW5zdGFuY2VQcm9maWxlUmVxdWVzdCKfAQoUU2V0dXBJbnN0YW5jZVJlcXVlc3QSFQoIdXNlcm5hbWUYASABKAlCA+BBAhIVCghuaWNrbmFtZRgCIAEoCUID4EECEhUKCHBhc3N3b3JkGAMgASgJQgPgQQISEgoFcGhvbmUYBCABKAlCA+BBAhISCgVlbWFpbBgFIAEoCUID4EECEhoKDWluc3RhbmNlX25hbWUYBiABKAlCA+BBAiJCChVTZXR1cEluc3RhbmNlUmVzcG9uc2USKQoFYWRtaW4YASABKAsyFS5nb3NlcnZlci5hcGkudjEuVXNlckID4EEDIp8MCg9JbnN0YW5jZVNldHRpbmcSNgoDa2V5GAEgASgOMiQuZ29zZXJ2ZXIuYXBpLnYxLkluc3RhbmNlU2V0dGluZy5LZXlCA+BBAhJKCg9nZW5lcmFsX3NldHRpbmcYAiABKAsyLy5nb3NlcnZlci5hcGkudjEuSW5zdGFuY2VTZXR0aW5nLkdlbmVyYWxTZXR0aW5nSAASTAoQc2VjdXJpdHlfc2V0dGluZxgDIAEoCzIwLmdvc2VydmVyLmFwaS52MS5JbnN0YW5jZVNldHRpbmcuU2VjdXJpdHlTZXR0aW5nSAASSgoPc3RvcmFnZV9zZXR0aW5nGAQgASgLMi8uZ29zZXJ2ZXIuYXBpLnYxLkluc3RhbmNlU2V0dGluZy5TdG9yYWdlU2V0dGluZ0gAGlIKDkdlbmVyYWxTZXR0aW5nEhEKBG5hbWUYASABKAlCA+BBAhIYCgtkZXNjcmlwdGlvbhgCIAEoCUID4EEBEhMKBmxvY2FsZRgDIAEoCUID4EEBGqgCCg9TZWN1cml0eVNldHRpbmcSKgodYWNjZXNzX3Rva2VuX2xpZmV0aW1lX3NlY29uZHMYASABKAVCA+BBARIrCh5yZWZyZXNoX3Rva2VuX2xpZmV0aW1lX3NlY29uZHMYAiABKAVCA+BBARJOCgxyZWdpc3RyYXRpb24YAyABKAsyMy5nb3NlcnZlci5hcGkudjEuSW5zdGFuY2VTZXR0aW5nLlJlZ2lzdHJhdGlvblBvbGljeUID4EEBEkkKCnJhdGVfbGltaXQYBCABKAsyMC5nb3NlcnZlci5hcGkudjEuSW5zdGFuY2VTZXR0aW5nLlJhdGVMaW1pdFBvbGljeUID4EEBEiEKFGNvcnNfYWxsb3dlZF9vcmlnaW5zGAUgAygJQgPgQQEaPQoPUmF0ZUxpbWl0UG9saWN5EhsKE3JlcXVlc3RzX3Blcl9zZWNvbmQYASABKAESDQoFYnVyc3QYAiABKAUa3QEKElJlZ2lzdHJhdGlvblBvbGljeRJGCgRtb2RlGAEgASgOMjguZ29zZXJ2ZXIuYXBpLnYxLkluc3RhbmNlU2V0dGluZy5SZWdpc3RyYXRpb25Qb2xpY3kuTW9kZRIdChVhbGxvd2VkX2VtYWlsX2RvbWFpbnMYAiADKAkiYAoETW9kZRIUChBNT0RFX1VOU1BFQ0lGSUVEEAASCAoET1BFThABEgwKCERJU0FCTEVEEAISDwoLSU5WSVRFX09OTFkQAxIZChVBTExPV0VEX0VNQUlMX0RPTUFJTlMQBBq3AgoOU3RvcmFnZVNldHRpbmcSUQoMc3RvcmFnZV90eXBlGAEgASgOMjsuZ29zZXJ2ZXIuYXBpLnYxLkluc3RhbmNlU2V0dGluZy5TdG9yYWdlU2V0dGluZy5TdG9yYWdlVHlwZRIeChFmaWxlcGF0aF90ZW1wbGF0ZRgCIAEoCUID4EEBEiEKFHVwbG9hZF9zaXplX2xpbWl0X21iGAMgASgFQgPgQQESQQoJczNfY29uZmlnGAQgASgLMikuZ29zZXJ2ZXIuYXBpLnYxLkluc3RhbmNlU2V0dGluZy5TM0NvbmZpZ0ID4EEBIkwKC1N0b3JhZ2VUeXBlEhwKGFNUT1JBR0VfVFlQRV9VTlNQRUNJRklFRBAAEgwKCERBVEFCQVNFEAESCQoFTE9DQUwQAhIGCgJTMxADGsgBCghTM0NvbmZpZxIaCg1hY2Nlc3Nfa2V5X2lkGAEgASgJQgPgQQISHgoRYWNjZXNzX2tleV9zZWNyZXQYAiABKAlCA+BBBBIVCghlbmRwb2ludBgDIAEoCUID4EECEhMKBnJlZ2lvbhgEIAEoCUID4EECEhMKBmJ1Y2tldBgFIAEoCUID4EECEhsKDnVzZV9wYXRoX3N0eWxlGAYgASgIQgPgQQESIgoVaGFzX2FjY2Vzc19rZXlfc2VjcmV0GAcgASgIQgPgQQMiQgoDS2V5EhMKD0tFWV9VTlNQRUNJRklFRBAAEgsKB0dFTkVSQUwQARIMCghTRUNVUklUWRACEgsKB1NUT1JBR0UQA0IHCgV2YWx1ZSJTChlHZXRJbnN0YW5jZVNldHRpbmdSZXF1ZXN0EjYKA2tleRgBIAEoDjIkLmdvc2VydmVyLmFwaS52MS5JbnN0YW5jZVNldHRpbmcuS2V5QgPgQQIiVAoaR2V0SW5zdGFuY2VTZXR0aW5nUmVzcG9uc2USNgoHc2V0dGluZxgBIAEoCzIgLmdvc2VydmVyLmFwaS52MS5JbnN0YW5jZVNldHRpbmdCA+BBAyKMAQocVXBkYXRlSW5zdGFuY2VTZXR0aW5nUmVxdWVzdBI2CgdzZXR0aW5nGAEgASgLMiAuZ29zZXJ2ZXIuYXBpLnYxLkluc3RhbmNlU2V0dGluZ0ID4EECEjQKC3VwZGF0ZV9tYXNrGAIgASgLMhouZ29vZ2xlLnByb3RvYnVmLkZpZWxkTWFza0ID4EECIlcKHVVwZGF0ZUluc3RhbmNlU2V0dGluZ1Jlc3BvbnNlEjYKB3NldHRpbmcYASABKAsyIC5nb3NlcnZlci5hcGkudjEuSW5zdGFuY2VTZXR0aW5nQgPgQQMyvgUKD0luc3RhbmNlU2VydmljZRKQAQoSR2V0SW5zdGFuY2VQcm9maWxlEiouZ29zZXJ2ZXIuYXBpLnYxLkdldEluc3RhbmNlUHJvZmlsZVJlcXVlc3QaIC5nb3NlcnZlci5hcGkudjEuSW5zdGFuY2VQcm9maWxlIiyKtRgCCAGStRgCEAGC0+STAhoSGC9hcGkvdjEvaW5zdGFuY2UvcHJvZmlsZRKHAQoNU2V0dXBJbnN0YW5jZRIlLmdvc2VydmVyLmFwaS52MS5TZXR1cEluc3RhbmNlUmVxdWVzdBomLmdvc2VydmVyLmFwaS52MS5TZXR1cEluc3RhbmNlUmVzcG9uc2UiJ4q1GAIIAYLT5JMCGzoBKiIWL2FwaS92MS9pbnN0YW5jZS9zZXR1cBKvAQoSR2V0SW5zdGFuY2VTZXR0aW5nEiouZ29zZXJ2ZXIuYXBpLnYxLkdldEluc3RhbmNlU2V0dGluZ1JlcXVlc3QaKy5nb3NlcnZlci5hcGkudjEuR2V0SW5zdGFuY2VTZXR0aW5nUmVzcG9uc2UiQNpBA2tleYq1GA8aDXNldHRpbmdzLnJlYWSC0+STAiESHy9hcGkvdjEvaW5zdGFuY2Uvc2V0dGluZ3Mve2tleX0S2wEKFVVwZGF0ZUluc3RhbmNlU2V0dGluZxItLmdvc2VydmVyLmFwaS52MS5VcGRhdGVJbnN0YW5jZVNldHRpbmdSZXF1ZXN0Gi4uZ29zZXJ2ZXIuYXBpLnYxLlVwZGF0ZUluc3RhbmNlU2V0dGluZ1Jlc3BvbnNlImPaQRNzZXR0aW5nLHVwZGF0ZV9tYXNrirUYERoPc2V0dGluZ3MudXBkYXRlgtPkkwIyOgdzZXR0aW5nMicvYXBpL3YxL2luc3RhbmNlL3NldHRpbmdzL3tzZXR0aW5nLmtleX1CuwEKE2NvbS5nb3NlcnZlci5hcGkudjFCFEluc3RhbmNlU2VydmljZVByb3RvUAFaMGdpdGh1Yi5jb20vcGl4Yi9nby1zZXJ2ZXIvcHJvdG8vZ2VuL2FwaS92MTthcGl2MaICA0dBWKoCD0dvc2VydmVyLkFwaS5WMcoCD0dvc2VydmVyXEFwaVxWMeICG0dvc2VydmVyXEFwaVxWMVxHUEJNZXRhZGF0YeoCEUdvc2VydmVyOjpBcGk6OlYxYgZwcm90bzM", [file_api_v1_common, file_api_v1_options, file_google_api_annotations, file_google_api_client, file_google_api_field_behavior, file_google_protobuf_field_mask]);
 
 /**
  * Instance profile message containing basic instance information.
@@ -38,10 +43,18 @@ export type InstanceProfile = Message<"goserver.api.v1.InstanceProfile"> & {
   /**
    * The first administrator who set up this instance.
    * When null, instance requires initial setup (creating the first admin account).
+   * It is not looked up in full maintenance, check maintenance first.
    *
    * @generated from field: goserver.api.v1.User admin = 3;
    */
   admin?: User;
+
+  /**
+   * The maintenance mode, unset while the instance is in service.
+   *
+   * @generated from field: goserver.api.v1.InstanceProfile.Maintenance maintenance = 4;
+   */
+  maintenance?: InstanceProfile_Maintenance;
 };
 
 /**
@@ -51,6 +64,67 @@ export type InstanceProfile = Message<"goserver.api.v1.InstanceProfile"> & {
 export const InstanceProfileSchema: GenMessage<InstanceProfile> = /*@__PURE__*/
   messageDesc(file_api_v1_instance_service, 0);
 
+/**
+ * @generated from message goserver.api.v1.InstanceProfile.Maintenance
+ */
+export type InstanceProfile_Maintenance = Message<"goserver.api.v1.InstanceProfile.Maintenance"> & {
+  /**
+   * @generated from field: goserver.api.v1.InstanceProfile.Maintenance.Mode mode = 1;
+   */
+  mode: InstanceProfile_Maintenance_Mode;
+
+  /**
+   * Why the instance is in maintenance, if the administrator said so.
+   *
+   * @generated from field: string message = 2;
+   */
+  message: string;
+
+  /**
+   * How long callers should wait before retrying rejected requests.
+   *
+   * @generated from field: int32 retry_after_seconds = 3;
+   */
+  retryAfterSeconds: number;
+};
+
+/**
+ * Describes the message goserver.api.v1.InstanceProfile.Maintenance.
+ * Use `create(InstanceProfile_MaintenanceSchema)` to create a new message.
+ */
+export const InstanceProfile_MaintenanceSchema: GenMessage<InstanceProfile_Maintenance> = /*@__PURE__*/
+  messageDesc(file_api_v1_instance_service, 0, 0);
+
+/**
+ * @generated from enum goserver.api.v1.InstanceProfile.Maintenance.Mode
+ */
+export enum InstanceProfile_Maintenance_Mode {
+  /**
+   * @generated from enum value: MODE_UNSPECIFIED = 0;
+   */
+  MODE_UNSPECIFIED = 0,
+
+  /**
+   * Methods that change data are rejected with UNAVAILABLE.
+   *
+   * @generated from enum value: READ_ONLY = 1;
+   */
+  READ_ONLY = 1,
+
+  /**
+   * Only health checks and the instance profile are served.
+   *
+   * @generated from enum value: FULL = 2;
+   */
+  FULL = 2,
+}
+
+/**
+ * Describes the enum goserver.api.v1.InstanceProfile.Maintenance.Mode.
+ */
+export const InstanceProfile_Maintenance_ModeSchema: GenEnum<InstanceProfile_Maintenance_Mode> = /*@__PURE__*/
+  enumDesc(file_api_v1_instance_service, 0, 0, 0);
+
 /**
  * Request for instance profile.
  *
@@ -66,6 +140,541 @@ export type GetInstanceProfileRequest = Message<"goserver.api.v1.GetInstanceProf
 export const GetInstanceProfileRequestSchema: GenMessage<GetInstanceProfileRequest> = /*@__PURE__*/
   messageDesc(file_api_v1_instance_service, 1);
 
+/**
+ * @generated from message goserver.api.v1.SetupInstanceRequest
+ */
+export type SetupInstanceRequest = Message<"goserver.api.v1.SetupInstanceRequest"> & {
+  /**
+   * The account of the first administrator, validated like a registration.
+   *
+   * @generated from field: string username = 1;
+   */
+  username: string;
+
+  /**
+   * @generated from field: string nickname = 2;
+   */
+  nickname: string;
+
+  /**
+   * @generated from field: string password = 3;
+   */
+  password: string;
+
+  /**
+   * @generated from field: string phone = 4;
+   */
+  phone: string;
+
+  /**
+   * @generated from field: string email = 5;
+   */
+  email: string;
+
+  /**
+   * The name of the instance shown to users.
+   *
+   * @generated from field: string instance_name = 6;
+   */
+  instanceName: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.SetupInstanceRequest.
+ * Use `create(SetupInstanceRequestSchema)` to create a new message.
+ */
+export const SetupInstanceRequestSchema: GenMessage<SetupInstanceRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_instance_service, 2);
+
+/**
+ * @generated from message goserver.api.v1.SetupInstanceResponse
+ */
+export type SetupInstanceResponse = Message<"goserver.api.v1.SetupInstanceResponse"> & {
+  /**
+   * @generated from field: goserver.api.v1.User admin = 1;
+   */
+  admin?: User;
+};
+
+/**
+ * Describes the message goserver.api.v1.SetupInstanceResponse.
+ * Use `create(SetupInstanceResponseSchema)` to create a new message.
+ */
+export const SetupInstanceResponseSchema: GenMessage<SetupInstanceResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_instance_service, 3);
+
+/**
+ * InstanceSetting is a setting of the instance that admins can change at runtime.
+ * Internal settings such as the secret key are not available through the API.
+ *
+ * @generated from message goserver.api.v1.InstanceSetting
+ */
+export type InstanceSetting = Message<"goserver.api.v1.InstanceSetting"> & {
+  /**
+   * @generated from field: goserver.api.v1.InstanceSetting.Key key = 1;
+   */
+  key: InstanceSetting_Key;
+
+  /**
+   * @generated from oneof goserver.api.v1.InstanceSetting.value
+   */
+  value: {
+    /**
+     * @generated from field: goserver.api.v1.InstanceSetting.GeneralSetting general_setting = 2;
+     */
+    value: InstanceSetting_GeneralSetting;
+    case: "generalSetting";
+  } | {
+    /**
+     * @generated from field: goserver.api.v1.InstanceSetting.SecuritySetting security_setting = 3;
+     */
+    value: InstanceSetting_SecuritySetting;
+    case: "securitySetting";
+  } | {
+    /**
+     * @generated from field: goserver.api.v1.InstanceSetting.StorageSetting storage_setting = 4;
+     */
+    value: InstanceSetting_StorageSetting;
+    case: "storageSetting";
+  } | { case: undefined; value?: undefined };
+};
+
+/**
+ * Describes the message goserver.api.v1.InstanceSetting.
+ * Use `create(InstanceSettingSchema)` to create a new message.
+ */
+export const InstanceSettingSchema: GenMessage<InstanceSetting> = /*@__PURE__*/
+  messageDesc(file_api_v1_instance_service, 4);
+
+/**
+ * @generated from message goserver.api.v1.InstanceSetting.GeneralSetting
+ */
+export type InstanceSetting_GeneralSetting = Message<"goserver.api.v1.InstanceSetting.GeneralSetting"> & {
+  /**
+   * The name of the instance shown to users, at most 100 characters.
+   *
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * At most 1000 characters.
+   *
+   * @generated from field: string description = 2;
+   */
+  description: string;
+
+  /**
+   * The default locale of the web app as a BCP 47 language tag, such as "en" or "zh-Hans".
+   *
+   * @generated from field: string locale = 3;
+   */
+  locale: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.InstanceSetting.GeneralSetting.
+ * Use `create(InstanceSetting_GeneralSettingSchema)` to create a new message.
+ */
+export const InstanceSetting_GeneralSettingSchema: GenMessage<InstanceSetting_GeneralSetting> = /*@__PURE__*/
+  messageDesc(file_api_v1_instance_service, 4, 0);
+
+/**
+ * @generated from message goserver.api.v1.InstanceSetting.SecuritySetting
+ */
+export type InstanceSetting_SecuritySetting = Message<"goserver.api.v1.InstanceSetting.SecuritySetting"> & {
+  /**
+   * The lifetime of access tokens issued at sign-in, between 5 minutes and 24 hours.
+   * 0 means the default of 24 hours.
+   *
+   * @generated from field: int32 access_token_lifetime_seconds = 1;
+   */
+  accessTokenLifetimeSeconds: number;
+
+  /**
+   * The lifetime of refresh tokens, between 1 hour and 90 days and at least the access
+   * token lifetime. 0 means the default of 7 days.
+   *
+   * @generated from field: int32 refresh_token_lifetime_seconds = 2;
+   */
+  refreshTokenLifetimeSeconds: number;
+
+  /**
+   * @generated from field: goserver.api.v1.InstanceSetting.RegistrationPolicy registration = 3;
+   */
+  registration?: InstanceSetting_RegistrationPolicy;
+
+  /**
+   * Limits HTTP requests per client address. Requests are not limited when unset.
+   *
+   * @generated from field: goserver.api.v1.InstanceSetting.RateLimitPolicy rate_limit = 4;
+   */
+  rateLimit?: InstanceSetting_RateLimitPolicy;
+
+  /**
+   * Origins allowed to make cross-origin requests, such as "https://app.example.com".
+   * Any origin is allowed when empty.
+   *
+   * @generated from field: repeated string cors_allowed_origins = 5;
+   */
+  corsAllowedOrigins: string[];
+};
+
+/**
+ * Describes the message goserver.api.v1.InstanceSetting.SecuritySetting.
+ * Use `create(InstanceSetting_SecuritySettingSchema)` to create a new message.
+ */
+export const InstanceSetting_SecuritySettingSchema: GenMessage<InstanceSetting_SecuritySetting> = /*@__PURE__*/
+  messageDesc(file_api_v1_instance_service, 4, 1);
+
+/**
+ * RateLimitPolicy is a token bucket per client address.
+ *
+ * @generated from message goserver.api.v1.InstanceSetting.RateLimitPolicy
+ */
+export type InstanceSetting_RateLimitPolicy = Message<"goserver.api.v1.InstanceSetting.RateLimitPolicy"> & {
+  /**
+   * Between 0 and 10000, 0 disables the limit.
+   *
+   * @generated from field: double requests_per_second = 1;
+   */
+  requestsPerSecond: number;
+
+  /**
+   * The number of requests allowed at once, requests_per_second rounded up when 0.
+   *
+   * @generated from field: int32 burst = 2;
+   */
+  burst: number;
+};
+
+/**
+ * Describes the message goserver.api.v1.InstanceSetting.RateLimitPolicy.
+ * Use `create(InstanceSetting_RateLimitPolicySchema)` to create a new message.
+ */
+export const InstanceSetting_RateLimitPolicySchema: GenMessage<InstanceSetting_RateLimitPolicy> = /*@__PURE__*/
+  messageDesc(file_api_v1_instance_service, 4, 2);
+
+/**
+ * RegistrationPolicy decides who can sign up with UserService.RegisterUser.
+ *
+ * @generated from message goserver.api.v1.InstanceSetting.RegistrationPolicy
+ */
+export type InstanceSetting_RegistrationPolicy = Message<"goserver.api.v1.InstanceSetting.RegistrationPolicy"> & {
+  /**
+   * @generated from field: goserver.api.v1.InstanceSetting.RegistrationPolicy.Mode mode = 1;
+   */
+  mode: InstanceSetting_RegistrationPolicy_Mode;
+
+  /**
+   * The domains accepted in ALLOWED_EMAIL_DOMAINS mode, such as "example.com".
+   * Subdomains are not included.
+   *
+   * @generated from field: repeated string allowed_email_domains = 2;
+   */
+  allowedEmailDomains: string[];
+};
+
+/**
+ * Describes the message goserver.api.v1.InstanceSetting.RegistrationPolicy.
+ * Use `create(InstanceSetting_RegistrationPolicySchema)` to create a new message.
+ */
+export const InstanceSetting_RegistrationPolicySchema: GenMessage<InstanceSetting_RegistrationPolicy> = /*@__PURE__*/
+  messageDesc(file_api_v1_instance_service, 4, 3);
+
+/**
+ * @generated from enum goserver.api.v1.InstanceSetting.RegistrationPolicy.Mode
+ */
+export enum InstanceSetting_RegistrationPolicy_Mode {
+  /**
+   * Anyone can register, the default.
+   *
+   * @generated from enum value: MODE_UNSPECIFIED = 0;
+   */
+  MODE_UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: OPEN = 1;
+   */
+  OPEN = 1,
+
+  /**
+   * Nobody can register, not even with an invite.
+   *
+   * @generated from enum value: DISABLED = 2;
+   */
+  DISABLED = 2,
+
+  /**
+   * Only holders of an invite code can register.
+   *
+   * @generated from enum value: INVITE_ONLY = 3;
+   */
+  INVITE_ONLY = 3,
+
+  /**
+   * Only addresses of allowed_email_domains or holders of an invite code can register.
+   * Users then have to verify their email address before signing in, and identity
+   * providers have to vouch for the addresses of the users they sign in.
+   *
+   * @generated from enum value: ALLOWED_EMAIL_DOMAINS = 4;
+   */
+  ALLOWED_EMAIL_DOMAINS = 4,
+}
+
+/**
+ * Describes the enum goserver.api.v1.InstanceSetting.RegistrationPolicy.Mode.
+ */
+export const InstanceSetting_RegistrationPolicy_ModeSchema: GenEnum<InstanceSetting_RegistrationPolicy_Mode> = /*@__PURE__*/
+  enumDesc(file_api_v1_instance_service, 4, 3, 0);
+
+/**
+ * @generated from message goserver.api.v1.InstanceSetting.StorageSetting
+ */
+export type InstanceSetting_StorageSetting = Message<"goserver.api.v1.InstanceSetting.StorageSetting"> & {
+  /**
+   * @generated from field: goserver.api.v1.InstanceSetting.StorageSetting.StorageType storage_type = 1;
+   */
+  storageType: InstanceSetting_StorageSetting_StorageType;
+
+  /**
+   * The path of files kept in LOCAL storage, relative to the data directory.
+   * It may contain {filename}, {timestamp} and {uuid}, "assets/{timestamp}_{filename}" by default.
+   *
+   * @generated from field: string filepath_template = 2;
+   */
+  filepathTemplate: string;
+
+  /**
+   * The maximum size of an uploaded file in MiB, between 1 and 1024. 0 means the default of 32.
+   *
+   * @generated from field: int32 upload_size_limit_mb = 3;
+   */
+  uploadSizeLimitMb: number;
+
+  /**
+   * Required for S3 storage.
+   *
+   * @generated from field: goserver.api.v1.InstanceSetting.S3Config s3_config = 4;
+   */
+  s3Config?: InstanceSetting_S3Config;
+};
+
+/**
+ * Describes the message goserver.api.v1.InstanceSetting.StorageSetting.
+ * Use `create(InstanceSetting_StorageSettingSchema)` to create a new message.
+ */
+export const InstanceSetting_StorageSettingSchema: GenMessage<InstanceSetting_StorageSetting> = /*@__PURE__*/
+  messageDesc(file_api_v1_instance_service, 4, 4);
+
+/**
+ * @generated from enum goserver.api.v1.InstanceSetting.StorageSetting.StorageType
+ */
+export enum InstanceSetting_StorageSetting_StorageType {
+  /**
+   * Files are kept in the database, the default.
+   *
+   * @generated from enum value: STORAGE_TYPE_UNSPECIFIED = 0;
+   */
+  STORAGE_TYPE_UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: DATABASE = 1;
+   */
+  DATABASE = 1,
+
+  /**
+   * Files are kept in a directory of the server.
+   *
+   * @generated from enum value: LOCAL = 2;
+   */
+  LOCAL = 2,
+
+  /**
+   * Files are kept in an S3 compatible bucket.
+   *
+   * @generated from enum value: S3 = 3;
+   */
+  S3 = 3,
+}
+
+/**
+ * Describes the enum goserver.api.v1.InstanceSetting.StorageSetting.StorageType.
+ */
+export const InstanceSetting_StorageSetting_StorageTypeSchema: GenEnum<InstanceSetting_StorageSetting_StorageType> = /*@__PURE__*/
+  enumDesc(file_api_v1_instance_service, 4, 4, 0);
+
+/**
+ * @generated from message goserver.api.v1.InstanceSetting.S3Config
+ */
+export type InstanceSetting_S3Config = Message<"goserver.api.v1.InstanceSetting.S3Config"> & {
+  /**
+   * @generated from field: string access_key_id = 1;
+   */
+  accessKeyId: string;
+
+  /**
+   * It is never returned.
+   *
+   * @generated from field: string access_key_secret = 2;
+   */
+  accessKeySecret: string;
+
+  /**
+   * The URL of the S3 endpoint, such as "https://s3.us-east-1.amazonaws.com".
+   *
+   * @generated from field: string endpoint = 3;
+   */
+  endpoint: string;
+
+  /**
+   * @generated from field: string region = 4;
+   */
+  region: string;
+
+  /**
+   * @generated from field: string bucket = 5;
+   */
+  bucket: string;
+
+  /**
+   * Addresses the bucket in the path instead of the host name.
+   *
+   * @generated from field: bool use_path_style = 6;
+   */
+  usePathStyle: boolean;
+
+  /**
+   * Whether an access key secret is set.
+   *
+   * @generated from field: bool has_access_key_secret = 7;
+   */
+  hasAccessKeySecret: boolean;
+};
+
+/**
+ * Describes the message goserver.api.v1.InstanceSetting.S3Config.
+ * Use `create(InstanceSetting_S3ConfigSchema)` to create a new message.
+ */
+export const InstanceSetting_S3ConfigSchema: GenMessage<InstanceSetting_S3Config> = /*@__PURE__*/
+  messageDesc(file_api_v1_instance_service, 4, 5);
+
+/**
+ * @generated from enum goserver.api.v1.InstanceSetting.Key
+ */
+export enum InstanceSetting_Key {
+  /**
+   * @generated from enum value: KEY_UNSPECIFIED = 0;
+   */
+  KEY_UNSPECIFIED = 0,
+
+  /**
+   * The name and presentation of the instance.
+   *
+   * @generated from enum value: GENERAL = 1;
+   */
+  GENERAL = 1,
+
+  /**
+   * Token lifetimes and who can register.
+   *
+   * @generated from enum value: SECURITY = 2;
+   */
+  SECURITY = 2,
+
+  /**
+   * Where uploaded files are kept.
+   *
+   * @generated from enum value: STORAGE = 3;
+   */
+  STORAGE = 3,
+}
+
+/**
+ * Describes the enum goserver.api.v1.InstanceSetting.Key.
+ */
+export const InstanceSetting_KeySchema: GenEnum<InstanceSetting_Key> = /*@__PURE__*/
+  enumDesc(file_api_v1_instance_service, 4, 0);
+
+/**
+ * @generated from message goserver.api.v1.GetInstanceSettingRequest
+ */
+export type GetInstanceSettingRequest = Message<"goserver.api.v1.GetInstanceSettingRequest"> & {
+  /**
+   * @generated from field: goserver.api.v1.InstanceSetting.Key key = 1;
+   */
+  key: InstanceSetting_Key;
+};
+
+/**
+ * Describes the message goserver.api.v1.GetInstanceSettingRequest.
+ * Use `create(GetInstanceSettingRequestSchema)` to create a new message.
+ */
+export const GetInstanceSettingRequestSchema: GenMessage<GetInstanceSettingRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_instance_service, 5);
+
+/**
+ * @generated from message goserver.api.v1.GetInstanceSettingResponse
+ */
+export type GetInstanceSettingResponse = Message<"goserver.api.v1.GetInstanceSettingResponse"> & {
+  /**
+   * @generated from field: goserver.api.v1.InstanceSetting setting = 1;
+   */
+  setting?: InstanceSetting;
+};
+
+/**
+ * Describes the message goserver.api.v1.GetInstanceSettingResponse.
+ * Use `create(GetInstanceSettingResponseSchema)` to create a new message.
+ */
+export const GetInstanceSettingResponseSchema: GenMessage<GetInstanceSettingResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_instance_service, 6);
+
+/**
+ * @generated from message goserver.api.v1.UpdateInstanceSettingRequest
+ */
+export type UpdateInstanceSettingRequest = Message<"goserver.api.v1.UpdateInstanceSettingRequest"> & {
+  /**
+   * @generated from field: goserver.api.v1.InstanceSetting setting = 1;
+   */
+  setting?: InstanceSetting;
+
+  /**
+   * The fields of the typed setting to update:
+   * GENERAL: "name", "description" and "locale".
+   * SECURITY: "access_token_lifetime_seconds", "refresh_token_lifetime_seconds" and "registration".
+   * STORAGE: "storage_type", "filepath_template", "upload_size_limit_mb", "s3_config" and
+   * "s3_config.access_key_secret". "s3_config" keeps the stored access key secret.
+   *
+   * @generated from field: google.protobuf.FieldMask update_mask = 2;
+   */
+  updateMask?: FieldMask;
+};
+
+/**
+ * Describes the message goserver.api.v1.UpdateInstanceSettingRequest.
+ * Use `create(UpdateInstanceSettingRequestSchema)` to create a new message.
+ */
+export const UpdateInstanceSettingRequestSchema: GenMessage<UpdateInstanceSettingRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_instance_service, 7);
+
+/**
+ * @generated from message goserver.api.v1.UpdateInstanceSettingResponse
+ */
+export type UpdateInstanceSettingResponse = Message<"goserver.api.v1.UpdateInstanceSettingResponse"> & {
+  /**
+   * @generated from field: goserver.api.v1.InstanceSetting setting = 1;
+   */
+  setting?: InstanceSetting;
+};
+
+/**
+ * Describes the message goserver.api.v1.UpdateInstanceSettingResponse.
+ * Use `create(UpdateInstanceSettingResponseSchema)` to create a new message.
+ */
+export const UpdateInstanceSettingResponseSchema: GenMessage<UpdateInstanceSettingResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_instance_service, 8);
+
 /**
  * @generated from service goserver.api.v1.InstanceService
  */
@@ -80,6 +689,37 @@ export const InstanceService: GenService<{
     input: typeof GetInstanceProfileRequestSchema;
     output: typeof InstanceProfileSchema;
   },
+  /**
+   * Creates the first administrator and names a new instance. It is only allowed while
+   * the instance has no administrator and succeeds at most once.
+   *
+   * @generated from rpc goserver.api.v1.InstanceService.SetupInstance
+   */
+  setupInstance: {
+    methodKind: "unary";
+    input: typeof SetupInstanceRequestSchema;
+    output: typeof SetupInstanceResponseSchema;
+  },
+  /**
+   * Gets an instance setting. Settings that were never changed are returned with their defaults.
+   *
+   * @generated from rpc goserver.api.v1.InstanceService.GetInstanceSetting
+   */
+  getInstanceSetting: {
+    methodKind: "unary";
+    input: typeof GetInstanceSettingRequestSchema;
+    output: typeof GetInstanceSettingResponseSchema;
+  },
+  /**
+   * Updates the fields of an instance setting listed in update_mask.
+   *
+   * @generated from rpc goserver.api.v1.InstanceService.UpdateInstanceSetting
+   */
+  updateInstanceSetting: {
+    methodKind: "unary";
+    input: typeof UpdateInstanceSettingRequestSchema;
+    output: typeof UpdateInstanceSettingResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_api_v1_instance_service, 0);
 
@@ -0,0 +1,658 @@
+// @generated by protoc-gen-es v2.11.0 with parameter "target=ts"
+// @generated from file api/v1/oauth_service.proto (package goserver.api.v1, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import { file_google_api_annotations } from "../../google/api/annotations_pb";
+import { file_google_api_client } from "../../google/api/client_pb";
+import { file_google_api_field_behavior } from "../../google/api/field_behavior_pb";
+import type { FieldMask, Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_field_mask, file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import { file_api_v1_options } from "./options_pb";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file api/v1/oauth_service.proto.
+ */
+export const file_api_v1_oauth_service: GenFile = /*@__PURE__*/
+  fileDesc("ChphcGkvdjEvb2F1dGhfc2VydmljZS5wcm90bxIPZ29zZXJ2ZXIuYXBpLnYxIogCCgtPQXV0aENsaWVudBIWCgljbGllbnRfaWQYASABKAlCA+BBAxIRCgRuYW1lGAIgASgJQgPgQQISGgoNcmVkaXJlY3RfdXJpcxgDIAMoCUID4EEBEhgKC2dyYW50X3R5cGVzGAQgAygJQgPgQQISEwoGc2NvcGVzGAUgAygJQgPgQQESGQoMY29uZmlkZW50aWFsGAYgASgIQgPgQQUSMwoKY3JlYXRlZF9hdBgHIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBCA+BBAxIzCgp1cGRhdGVkX2F0GAggASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEID4EEDIuABChJPQXV0aEF1dGhvcml6YXRpb24SFgoJY2xpZW50X2lkGAEgASgJQgPgQQISGQoMcmVkaXJlY3RfdXJpGAIgASgJQgPgQQISGgoNcmVzcG9uc2VfdHlwZRgDIAEoCUID4EECEhIKBXNjb3BlGAQgASgJQgPgQQESEgoFc3RhdGUYBSABKAlCA+BBARISCgVub25jZRgGIAEoCUID4EEBEhsKDmNvZGVfY2hhbGxlbmdlGAcgASgJQgPgQQISIgoVY29kZV9jaGFsbGVuZ2VfbWV0aG9kGAggASgJQgPgQQIivwEKDE9BdXRoQ29uc2VudBIWCgljbGllbnRfaWQYASABKAlCA+BBAxIYCgtjbGllbnRfbmFtZRgCIAEoCUID4EEDEhMKBnNjb3BlcxgDIAMoCUID4EEDEjMKCmNyZWF0ZWRfYXQYBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wQgPgQQMSMwoKdXBkYXRlZF9hdBgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBCA+BBAyIZChdMaXN0T0F1dGhDbGllbnRzUmVxdWVzdCJUChhMaXN0T0F1dGhDbGllbnRzUmVzcG9uc2USOAoNb2F1dGhfY2xpZW50cxgBIAMoCzIcLmdvc2VydmVyLmFwaS52MS5PQXV0aENsaWVudEID4EEDIi8KFUdldE9BdXRoQ2xpZW50UmVxdWVzdBIWCgljbGllbnRfaWQYASABKAlCA+BBAiJRChZHZXRPQXV0aENsaWVudFJlc3BvbnNlEjcKDG9hdXRoX2NsaWVudBgBIAEoCzIcLmdvc2VydmVyLmFwaS52MS5PQXV0aENsaWVudEID4EEDIlMKGENyZWF0ZU9BdXRoQ2xpZW50UmVxdWVzdBI3CgxvYXV0aF9jbGllbnQYASABKAsyHC5nb3NlcnZlci5hcGkudjEuT0F1dGhDbGllbnRCA+BBAiJwChlDcmVhdGVPQXV0aENsaWVudFJlc3BvbnNlEjcKDG9hdXRoX2NsaWVudBgBIAEoCzIcLmdvc2VydmVyLmFwaS52MS5PQXV0aENsaWVudEID4EEDEhoKDWNsaWVudF9zZWNyZXQYAiABKAlCA+BBAyKJAQoYVXBkYXRlT0F1dGhDbGllbnRSZXF1ZXN0EjcKDG9hdXRoX2NsaWVudBgBIAEoCzIcLmdvc2VydmVyLmFwaS52MS5PQXV0aENsaWVudEID4EECEjQKC3VwZGF0ZV9tYXNrGAIgASgLMhouZ29vZ2xlLnByb3RvYnVmLkZpZWxkTWFza0ID4EECIlQKGVVwZGF0ZU9BdXRoQ2xpZW50UmVzcG9uc2USNwoMb2F1dGhfY2xpZW50GAEgASgLMhwuZ29zZXJ2ZXIuYXBpLnYxLk9BdXRoQ2xpZW50QgPgQQMiOAoeUm90YXRlT0F1dGhDbGllbnRTZWNyZXRSZXF1ZXN0EhYKCWNsaWVudF9pZBgBIAEoCUID4EECIj0KH1JvdGF0ZU9BdXRoQ2xpZW50U2VjcmV0UmVzcG9uc2USGgoNY2xpZW50X3NlY3JldBgBIAEoCUID4EEDIjIKGERlbGV0ZU9BdXRoQ2xpZW50UmVxdWVzdBIWCgljbGllbnRfaWQYASABKAlCA+BBAiIbChlEZWxldGVPQXV0aENsaWVudFJlc3BvbnNlIl8KHEdldE9BdXRoQXV0aG9yaXphdGlvblJlcXVlc3QSPwoNYXV0aG9yaXphdGlvbhgBIAEoCzIjLmdvc2VydmVyLmFwaS52MS5PQXV0aEF1dGhvcml6YXRpb25CA+BBAiJtCh1HZXRPQXV0aEF1dGhvcml6YXRpb25SZXNwb25zZRIYCgtjbGllbnRfbmFtZRgBIAEoCUID4EEDEhMKBnNjb3BlcxgCIAMoCUID4EEDEh0KEGNvbnNlbnRfcmVxdWlyZWQYAyABKAhCA+BBAyJ6CiBBcHByb3ZlT0F1dGhBdXRob3JpemF0aW9uUmVxdWVzdBI/Cg1hdXRob3JpemF0aW9uGAEgASgLMiMuZ29zZXJ2ZXIuYXBpLnYxLk9BdXRoQXV0aG9yaXphdGlvbkID4EECEhUKCGFwcHJvdmVkGAIgASgIQgPgQQIiPgohQXBwcm92ZU9BdXRoQXV0aG9yaXphdGlvblJlc3BvbnNlEhkKDHJlZGlyZWN0X3VybBgBIAEoCUID4EEDIhoKGExpc3RPQXV0aENvbnNlbnRzUmVxdWVzdCJRChlMaXN0T0F1dGhDb25zZW50c1Jlc3BvbnNlEjQKCGNvbnNlbnRzGAEgAygLMh0uZ29zZXJ2ZXIuYXBpLnYxLk9BdXRoQ29uc2VudEID4EEDIjMKGVJldm9rZU9BdXRoQ29uc2VudFJlcXVlc3QSFgoJY2xpZW50X2lkGAEgASgJQgPgQQIiHAoaUmV2b2tlT0F1dGhDb25zZW50UmVzcG9uc2Uy8Q4KDE9BdXRoU2VydmljZRKhAQoQTGlzdE9BdXRoQ2xpZW50cxIoLmdvc2VydmVyLmFwaS52MS5MaXN0T0F1dGhDbGllbnRzUmVxdWVzdBopLmdvc2VydmVyLmFwaS52MS5MaXN0T0F1dGhDbGllbnRzUmVzcG9uc2UiONpBAIq1GBQaEm9hdXRoX2NsaWVudHMucmVhZILT5JMCFxIVL2FwaS92MS9vYXV0aC1jbGllbnRzErABCg5HZXRPQXV0aENsaWVudBImLmdvc2VydmVyLmFwaS52MS5HZXRPQXV0aENsaWVudFJlcXVlc3QaJy5nb3NlcnZlci5hcGkudjEuR2V0T0F1dGhDbGllbnRSZXNwb25zZSJN2kEJY2xpZW50X2lkirUYFBoSb2F1dGhfY2xpZW50cy5yZWFkgtPkkwIjEiEvYXBpL3YxL29hdXRoLWNsaWVudHMve2NsaWVudF9pZH0SvwEKEUNyZWF0ZU9BdXRoQ2xpZW50EikuZ29zZXJ2ZXIuYXBpLnYxLkNyZWF0ZU9BdXRoQ2xpZW50UmVxdWVzdBoqLmdvc2VydmVyLmFwaS52MS5DcmVhdGVPQXV0aENsaWVudFJlc3BvbnNlIlPaQQxvYXV0aF9jbGllbnSKtRgVGhNvYXV0aF9jbGllbnRzLndyaXRlgtPkkwIlOgxvYXV0aF9jbGllbnQiFS9hcGkvdjEvb2F1dGgtY2xpZW50cxLkAQoRVXBkYXRlT0F1dGhDbGllbnQSKS5nb3NlcnZlci5hcGkudjEuVXBkYXRlT0F1dGhDbGllbnRSZXF1ZXN0GiouZ29zZXJ2ZXIuYXBpLnYxLlVwZGF0ZU9BdXRoQ2xpZW50UmVzcG9uc2UieNpBGG9hdXRoX2NsaWVudCx1cGRhdGVfbWFza4q1GBUaE29hdXRoX2NsaWVudHMud3JpdGWC0+STAj46DG9hdXRoX2NsaWVudDIuL2FwaS92MS9vYXV0aC1jbGllbnRzL3tvYXV0aF9jbGllbnQuY2xpZW50X2lkfRLdAQoXUm90YXRlT0F1dGhDbGllbnRTZWNyZXQSLy5nb3NlcnZlci5hcGkudjEuUm90YXRlT0F1dGhDbGllbnRTZWNyZXRSZXF1ZXN0GjAuZ29zZXJ2ZXIuYXBpLnYxLlJvdGF0ZU9BdXRoQ2xpZW50U2VjcmV0UmVzcG9uc2UiX9pBCWNsaWVudF9pZIq1GBUaE29hdXRoX2NsaWVudHMud3JpdGWC0+STAjQ6ASoiLy9hcGkvdjEvb2F1dGgtY2xpZW50cy97Y2xpZW50X2lkfS9yb3RhdGUtc2VjcmV0EroBChFEZWxldGVPQXV0aENsaWVudBIpLmdvc2VydmVyLmFwaS52MS5EZWxldGVPQXV0aENsaWVudFJlcXVlc3QaKi5nb3NlcnZlci5hcGkudjEuRGVsZXRlT0F1dGhDbGllbnRSZXNwb25zZSJO2kEJY2xpZW50X2lkirUYFRoTb2F1dGhfY2xpZW50cy53cml0ZYLT5JMCIyohL2FwaS92MS9vYXV0aC1jbGllbnRzL3tjbGllbnRfaWR9EqsBChVHZXRPQXV0aEF1dGhvcml6YXRpb24SLS5nb3NlcnZlci5hcGkudjEuR2V0T0F1dGhBdXRob3JpemF0aW9uUmVxdWVzdBouLmdvc2VydmVyLmFwaS52MS5HZXRPQXV0aEF1dGhvcml6YXRpb25SZXNwb25zZSIz2kENYXV0aG9yaXphdGlvboLT5JMCHRIbL2FwaS92MS9vYXV0aC1hdXRob3JpemF0aW9uEssBChlBcHByb3ZlT0F1dGhBdXRob3JpemF0aW9uEjEuZ29zZXJ2ZXIuYXBpLnYxLkFwcHJvdmVPQXV0aEF1dGhvcml6YXRpb25SZXF1ZXN0GjIuZ29zZXJ2ZXIuYXBpLnYxLkFwcHJvdmVPQXV0aEF1dGhvcml6YXRpb25SZXNwb25zZSJH2kEWYXV0aG9yaXphdGlvbixhcHByb3ZlZILT5JMCKDoBKiIjL2FwaS92MS9vYXV0aC1hdXRob3JpemF0aW9uL2FwcHJvdmUSlgEKEUxpc3RPQXV0aENvbnNlbnRzEikuZ29zZXJ2ZXIuYXBpLnYxLkxpc3RPQXV0aENvbnNlbnRzUmVxdWVzdBoqLmdvc2VydmVyLmFwaS52MS5MaXN0T0F1dGhDb25zZW50c1Jlc3BvbnNlIiraQQCC0+STAiESHy9hcGkvdjEvdXNlcnMvbWUvb2F1dGgtY29uc2VudHMSrgEKElJldm9rZU9BdXRoQ29uc2VudBIqLmdvc2VydmVyLmFwaS52MS5SZXZva2VPQXV0aENvbnNlbnRSZXF1ZXN0GisuZ29zZXJ2ZXIuYXBpLnYxLlJldm9rZU9BdXRoQ29uc2VudFJlc3BvbnNlIj/aQQljbGllbnRfaWSC0+STAi0qKy9hcGkvdjEvdXNlcnMvbWUvb2F1dGgtY29uc2VudHMve2NsaWVudF9pZH1CuAEKE2NvbS5nb3NlcnZlci5hcGkudjFCEU9hdXRoU2VydmljZVByb3RvUAFaMGdpdGh1Yi5jb20vcGl4Yi9nby1zZXJ2ZXIvcHJvdG8vZ2VuL2FwaS92MTthcGl2MaICA0dBWKoCD0dvc2VydmVyLkFwaS5WMcoCD0dvc2VydmVyXEFwaVxWMeICG0dvc2VydmVyXEFwaVxWMVxHUEJNZXRhZGF0YeoCEUdvc2VydmVyOjpBcGk6OlYxYgZwcm90bzM", [file_google_api_annotations, file_google_api_client, file_google_api_field_behavior, file_google_protobuf_field_mask, file_google_protobuf_timestamp, file_api_v1_options]);
+
+/**
+ * @generated from message goserver.api.v1.OAuthClient
+ */
+export type OAuthClient = Message<"goserver.api.v1.OAuthClient"> & {
+  /**
+   * The identifier of the client in OAuth requests, generated on creation.
+   *
+   * @generated from field: string client_id = 1;
+   */
+  clientId: string;
+
+  /**
+   * The name shown to users when they are asked for consent.
+   *
+   * @generated from field: string name = 2;
+   */
+  name: string;
+
+  /**
+   * The exact redirect URIs of the client, https or http on a loopback host.
+   *
+   * @generated from field: repeated string redirect_uris = 3;
+   */
+  redirectUris: string[];
+
+  /**
+   * "authorization_code" and "client_credentials", the latter requires a confidential client.
+   *
+   * @generated from field: repeated string grant_types = 4;
+   */
+  grantTypes: string[];
+
+  /**
+   * The scopes the client may request, "openid", "profile" and "email" by default.
+   *
+   * @generated from field: repeated string scopes = 5;
+   */
+  scopes: string[];
+
+  /**
+   * Confidential clients authenticate with their secret at the token endpoint.
+   * Public clients, such as single-page and native apps, only use PKCE.
+   *
+   * @generated from field: bool confidential = 6;
+   */
+  confidential: boolean;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 7;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp updated_at = 8;
+   */
+  updatedAt?: Timestamp;
+};
+
+/**
+ * Describes the message goserver.api.v1.OAuthClient.
+ * Use `create(OAuthClientSchema)` to create a new message.
+ */
+export const OAuthClientSchema: GenMessage<OAuthClient> = /*@__PURE__*/
+  messageDesc(file_api_v1_oauth_service, 0);
+
+/**
+ * OAuthAuthorization is an authorization request as it was sent to /oauth/authorize.
+ *
+ * @generated from message goserver.api.v1.OAuthAuthorization
+ */
+export type OAuthAuthorization = Message<"goserver.api.v1.OAuthAuthorization"> & {
+  /**
+   * @generated from field: string client_id = 1;
+   */
+  clientId: string;
+
+  /**
+   * @generated from field: string redirect_uri = 2;
+   */
+  redirectUri: string;
+
+  /**
+   * Only "code" is supported.
+   *
+   * @generated from field: string response_type = 3;
+   */
+  responseType: string;
+
+  /**
+   * Space-separated scopes, all scopes of the client when empty.
+   *
+   * @generated from field: string scope = 4;
+   */
+  scope: string;
+
+  /**
+   * @generated from field: string state = 5;
+   */
+  state: string;
+
+  /**
+   * @generated from field: string nonce = 6;
+   */
+  nonce: string;
+
+  /**
+   * @generated from field: string code_challenge = 7;
+   */
+  codeChallenge: string;
+
+  /**
+   * Only "S256" is supported.
+   *
+   * @generated from field: string code_challenge_method = 8;
+   */
+  codeChallengeMethod: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.OAuthAuthorization.
+ * Use `create(OAuthAuthorizationSchema)` to create a new message.
+ */
+export const OAuthAuthorizationSchema: GenMessage<OAuthAuthorization> = /*@__PURE__*/
+  messageDesc(file_api_v1_oauth_service, 1);
+
+/**
+ * @generated from message goserver.api.v1.OAuthConsent
+ */
+export type OAuthConsent = Message<"goserver.api.v1.OAuthConsent"> & {
+  /**
+   * @generated from field: string client_id = 1;
+   */
+  clientId: string;
+
+  /**
+   * @generated from field: string client_name = 2;
+   */
+  clientName: string;
+
+  /**
+   * @generated from field: repeated string scopes = 3;
+   */
+  scopes: string[];
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 4;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp updated_at = 5;
+   */
+  updatedAt?: Timestamp;
+};
+
+/**
+ * Describes the message goserver.api.v1.OAuthConsent.
+ * Use `create(OAuthConsentSchema)` to create a new message.
+ */
+export const OAuthConsentSchema: GenMessage<OAuthConsent> = /*@__PURE__*/
+  messageDesc(file_api_v1_oauth_service, 2);
+
+/**
+ * @generated from message goserver.api.v1.ListOAuthClientsRequest
+ */
+export type ListOAuthClientsRequest = Message<"goserver.api.v1.ListOAuthClientsRequest"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.ListOAuthClientsRequest.
+ * Use `create(ListOAuthClientsRequestSchema)` to create a new message.
+ */
+export const ListOAuthClientsRequestSchema: GenMessage<ListOAuthClientsRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_oauth_service, 3);
+
+/**
+ * @generated from message goserver.api.v1.ListOAuthClientsResponse
+ */
+export type ListOAuthClientsResponse = Message<"goserver.api.v1.ListOAuthClientsResponse"> & {
+  /**
+   * @generated from field: repeated goserver.api.v1.OAuthClient oauth_clients = 1;
+   */
+  oauthClients: OAuthClient[];
+};
+
+/**
+ * Describes the message goserver.api.v1.ListOAuthClientsResponse.
+ * Use `create(ListOAuthClientsResponseSchema)` to create a new message.
+ */
+export const ListOAuthClientsResponseSchema: GenMessage<ListOAuthClientsResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_oauth_service, 4);
+
+/**
+ * @generated from message goserver.api.v1.GetOAuthClientRequest
+ */
+export type GetOAuthClientRequest = Message<"goserver.api.v1.GetOAuthClientRequest"> & {
+  /**
+   * @generated from field: string client_id = 1;
+   */
+  clientId: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.GetOAuthClientRequest.
+ * Use `create(GetOAuthClientRequestSchema)` to create a new message.
+ */
+export const GetOAuthClientRequestSchema: GenMessage<GetOAuthClientRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_oauth_service, 5);
+
+/**
+ * @generated from message goserver.api.v1.GetOAuthClientResponse
+ */
+export type GetOAuthClientResponse = Message<"goserver.api.v1.GetOAuthClientResponse"> & {
+  /**
+   * @generated from field: goserver.api.v1.OAuthClient oauth_client = 1;
+   */
+  oauthClient?: OAuthClient;
+};
+
+/**
+ * Describes the message goserver.api.v1.GetOAuthClientResponse.
+ * Use `create(GetOAuthClientResponseSchema)` to create a new message.
+ */
+export const GetOAuthClientResponseSchema: GenMessage<GetOAuthClientResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_oauth_service, 6);
+
+/**
+ * @generated from message goserver.api.v1.CreateOAuthClientRequest
+ */
+export type CreateOAuthClientRequest = Message<"goserver.api.v1.CreateOAuthClientRequest"> & {
+  /**
+   * @generated from field: goserver.api.v1.OAuthClient oauth_client = 1;
+   */
+  oauthClient?: OAuthClient;
+};
+
+/**
+ * Describes the message goserver.api.v1.CreateOAuthClientRequest.
+ * Use `create(CreateOAuthClientRequestSchema)` to create a new message.
+ */
+export const CreateOAuthClientRequestSchema: GenMessage<CreateOAuthClientRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_oauth_service, 7);
+
+/**
+ * @generated from message goserver.api.v1.CreateOAuthClientResponse
+ */
+export type CreateOAuthClientResponse = Message<"goserver.api.v1.CreateOAuthClientResponse"> & {
+  /**
+   * @generated from field: goserver.api.v1.OAuthClient oauth_client = 1;
+   */
+  oauthClient?: OAuthClient;
+
+  /**
+   * Empty for public clients. It cannot be retrieved later.
+   *
+   * @generated from field: string client_secret = 2;
+   */
+  clientSecret: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.CreateOAuthClientResponse.
+ * Use `create(CreateOAuthClientResponseSchema)` to create a new message.
+ */
+export const CreateOAuthClientResponseSchema: GenMessage<CreateOAuthClientResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_oauth_service, 8);
+
+/**
+ * @generated from message goserver.api.v1.UpdateOAuthClientRequest
+ */
+export type UpdateOAuthClientRequest = Message<"goserver.api.v1.UpdateOAuthClientRequest"> & {
+  /**
+   * @generated from field: goserver.api.v1.OAuthClient oauth_client = 1;
+   */
+  oauthClient?: OAuthClient;
+
+  /**
+   * The fields to update: "name", "redirect_uris", "grant_types" and "scopes".
+   *
+   * @generated from field: google.protobuf.FieldMask update_mask = 2;
+   */
+  updateMask?: FieldMask;
+};
+
+/**
+ * Describes the message goserver.api.v1.UpdateOAuthClientRequest.
+ * Use `create(UpdateOAuthClientRequestSchema)` to create a new message.
+ */
+export const UpdateOAuthClientRequestSchema: GenMessage<UpdateOAuthClientRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_oauth_service, 9);
+
+/**
+ * @generated from message goserver.api.v1.UpdateOAuthClientResponse
+ */
+export type UpdateOAuthClientResponse = Message<"goserver.api.v1.UpdateOAuthClientResponse"> & {
+  /**
+   * @generated from field: goserver.api.v1.OAuthClient oauth_client = 1;
+   */
+  oauthClient?: OAuthClient;
+};
+
+/**
+ * Describes the message goserver.api.v1.UpdateOAuthClientResponse.
+ * Use `create(UpdateOAuthClientResponseSchema)` to create a new message.
+ */
+export const UpdateOAuthClientResponseSchema: GenMessage<UpdateOAuthClientResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_oauth_service, 10);
+
+/**
+ * @generated from message goserver.api.v1.RotateOAuthClientSecretRequest
+ */
+export type RotateOAuthClientSecretRequest = Message<"goserver.api.v1.RotateOAuthClientSecretRequest"> & {
+  /**
+   * @generated from field: string client_id = 1;
+   */
+  clientId: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.RotateOAuthClientSecretRequest.
+ * Use `create(RotateOAuthClientSecretRequestSchema)` to create a new message.
+ */
+export const RotateOAuthClientSecretRequestSchema: GenMessage<RotateOAuthClientSecretRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_oauth_service, 11);
+
+/**
+ * @generated from message goserver.api.v1.RotateOAuthClientSecretResponse
+ */
+export type RotateOAuthClientSecretResponse = Message<"goserver.api.v1.RotateOAuthClientSecretResponse"> & {
+  /**
+   * @generated from field: string client_secret = 1;
+   */
+  clientSecret: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.RotateOAuthClientSecretResponse.
+ * Use `create(RotateOAuthClientSecretResponseSchema)` to create a new message.
+ */
+export const RotateOAuthClientSecretResponseSchema: GenMessage<RotateOAuthClientSecretResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_oauth_service, 12);
+
+/**
+ * @generated from message goserver.api.v1.DeleteOAuthClientRequest
+ */
+export type DeleteOAuthClientRequest = Message<"goserver.api.v1.DeleteOAuthClientRequest"> & {
+  /**
+   * @generated from field: string client_id = 1;
+   */
+  clientId: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.DeleteOAuthClientRequest.
+ * Use `create(DeleteOAuthClientRequestSchema)` to create a new message.
+ */
+export const DeleteOAuthClientRequestSchema: GenMessage<DeleteOAuthClientRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_oauth_service, 13);
+
+/**
+ * @generated from message goserver.api.v1.DeleteOAuthClientResponse
+ */
+export type DeleteOAuthClientResponse = Message<"goserver.api.v1.DeleteOAuthClientResponse"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.DeleteOAuthClientResponse.
+ * Use `create(DeleteOAuthClientResponseSchema)` to create a new message.
+ */
+export const DeleteOAuthClientResponseSchema: GenMessage<DeleteOAuthClientResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_oauth_service, 14);
+
+/**
+ * @generated from message goserver.api.v1.GetOAuthAuthorizationRequest
+ */
+export type GetOAuthAuthorizationRequest = Message<"goserver.api.v1.GetOAuthAuthorizationRequest"> & {
+  /**
+   * @generated from field: goserver.api.v1.OAuthAuthorization authorization = 1;
+   */
+  authorization?: OAuthAuthorization;
+};
+
+/**
+ * Describes the message goserver.api.v1.GetOAuthAuthorizationRequest.
+ * Use `create(GetOAuthAuthorizationRequestSchema)` to create a new message.
+ */
+export const GetOAuthAuthorizationRequestSchema: GenMessage<GetOAuthAuthorizationRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_oauth_service, 15);
+
+/**
+ * @generated from message goserver.api.v1.GetOAuthAuthorizationResponse
+ */
+export type GetOAuthAuthorizationResponse = Message<"goserver.api.v1.GetOAuthAuthorizationResponse"> & {
+  /**
+   * @generated from field: string client_name = 1;
+   */
+  clientName: string;
+
+  /**
+   * The scopes that will be granted.
+   *
+   * @generated from field: repeated string scopes = 2;
+   */
+  scopes: string[];
+
+  /**
+   * False when the user has already granted all of the scopes to the client,
+   * the request can then be approved without asking the user.
+   *
+   * @generated from field: bool consent_required = 3;
+   */
+  consentRequired: boolean;
+};
+
+/**
+ * Describes the message goserver.api.v1.GetOAuthAuthorizationResponse.
+ * Use `create(GetOAuthAuthorizationResponseSchema)` to create a new message.
+ */
+export const GetOAuthAuthorizationResponseSchema: GenMessage<GetOAuthAuthorizationResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_oauth_service, 16);
+
+/**
+ * @generated from message goserver.api.v1.ApproveOAuthAuthorizationRequest
+ */
+export type ApproveOAuthAuthorizationRequest = Message<"goserver.api.v1.ApproveOAuthAuthorizationRequest"> & {
+  /**
+   * @generated from field: goserver.api.v1.OAuthAuthorization authorization = 1;
+   */
+  authorization?: OAuthAuthorization;
+
+  /**
+   * False denies the request, the client receives an access_denied error.
+   *
+   * @generated from field: bool approved = 2;
+   */
+  approved: boolean;
+};
+
+/**
+ * Describes the message goserver.api.v1.ApproveOAuthAuthorizationRequest.
+ * Use `create(ApproveOAuthAuthorizationRequestSchema)` to create a new message.
+ */
+export const ApproveOAuthAuthorizationRequestSchema: GenMessage<ApproveOAuthAuthorizationRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_oauth_service, 17);
+
+/**
+ * @generated from message goserver.api.v1.ApproveOAuthAuthorizationResponse
+ */
+export type ApproveOAuthAuthorizationResponse = Message<"goserver.api.v1.ApproveOAuthAuthorizationResponse"> & {
+  /**
+   * The redirect URI of the client with the authorization code or the error.
+   *
+   * @generated from field: string redirect_url = 1;
+   */
+  redirectUrl: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.ApproveOAuthAuthorizationResponse.
+ * Use `create(ApproveOAuthAuthorizationResponseSchema)` to create a new message.
+ */
+export const ApproveOAuthAuthorizationResponseSchema: GenMessage<ApproveOAuthAuthorizationResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_oauth_service, 18);
+
+/**
+ * @generated from message goserver.api.v1.ListOAuthConsentsRequest
+ */
+export type ListOAuthConsentsRequest = Message<"goserver.api.v1.ListOAuthConsentsRequest"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.ListOAuthConsentsRequest.
+ * Use `create(ListOAuthConsentsRequestSchema)` to create a new message.
+ */
+export const ListOAuthConsentsRequestSchema: GenMessage<ListOAuthConsentsRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_oauth_service, 19);
+
+/**
+ * @generated from message goserver.api.v1.ListOAuthConsentsResponse
+ */
+export type ListOAuthConsentsResponse = Message<"goserver.api.v1.ListOAuthConsentsResponse"> & {
+  /**
+   * @generated from field: repeated goserver.api.v1.OAuthConsent consents = 1;
+   */
+  consents: OAuthConsent[];
+};
+
+/**
+ * Describes the message goserver.api.v1.ListOAuthConsentsResponse.
+ * Use `create(ListOAuthConsentsResponseSchema)` to create a new message.
+ */
+export const ListOAuthConsentsResponseSchema: GenMessage<ListOAuthConsentsResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_oauth_service, 20);
+
+/**
+ * @generated from message goserver.api.v1.RevokeOAuthConsentRequest
+ */
+export type RevokeOAuthConsentRequest = Message<"goserver.api.v1.RevokeOAuthConsentRequest"> & {
+  /**
+   * @generated from field: string client_id = 1;
+   */
+  clientId: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.RevokeOAuthConsentRequest.
+ * Use `create(RevokeOAuthConsentRequestSchema)` to create a new message.
+ */
+export const RevokeOAuthConsentRequestSchema: GenMessage<RevokeOAuthConsentRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_oauth_service, 21);
+
+/**
+ * @generated from message goserver.api.v1.RevokeOAuthConsentResponse
+ */
+export type RevokeOAuthConsentResponse = Message<"goserver.api.v1.RevokeOAuthConsentResponse"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.RevokeOAuthConsentResponse.
+ * Use `create(RevokeOAuthConsentResponseSchema)` to create a new message.
+ */
+export const RevokeOAuthConsentResponseSchema: GenMessage<RevokeOAuthConsentResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_oauth_service, 22);
+
+/**
+ * OAuthService registers the applications that use the instance as their OAuth 2.0 and
+ * OpenID Connect authorization server, and records the consent of users.
+ *
+ * Clients discover the endpoints at /.well-known/openid-configuration. An authorization
+ * request to /oauth/authorize is validated and redirected to /oauth/consent with the same
+ * query, where the web app signs the user in, shows GetOAuthAuthorization and redirects to
+ * the redirect_url returned by ApproveOAuthAuthorization. Codes are exchanged at /oauth/token,
+ * which also serves the client credentials grant, and /userinfo returns the claims of a user.
+ *
+ * @generated from service goserver.api.v1.OAuthService
+ */
+export const OAuthService: GenService<{
+  /**
+   * @generated from rpc goserver.api.v1.OAuthService.ListOAuthClients
+   */
+  listOAuthClients: {
+    methodKind: "unary";
+    input: typeof ListOAuthClientsRequestSchema;
+    output: typeof ListOAuthClientsResponseSchema;
+  },
+  /**
+   * @generated from rpc goserver.api.v1.OAuthService.GetOAuthClient
+   */
+  getOAuthClient: {
+    methodKind: "unary";
+    input: typeof GetOAuthClientRequestSchema;
+    output: typeof GetOAuthClientResponseSchema;
+  },
+  /**
+   * Registers a client. The secret of a confidential client is only returned here.
+   *
+   * @generated from rpc goserver.api.v1.OAuthService.CreateOAuthClient
+   */
+  createOAuthClient: {
+    methodKind: "unary";
+    input: typeof CreateOAuthClientRequestSchema;
+    output: typeof CreateOAuthClientResponseSchema;
+  },
+  /**
+   * Updates the fields of a client listed in update_mask.
+   *
+   * @generated from rpc goserver.api.v1.OAuthService.UpdateOAuthClient
+   */
+  updateOAuthClient: {
+    methodKind: "unary";
+    input: typeof UpdateOAuthClientRequestSchema;
+    output: typeof UpdateOAuthClientResponseSchema;
+  },
+  /**
+   * Replaces the secret of a confidential client, the previous secret stops working at once.
+   *
+   * @generated from rpc goserver.api.v1.OAuthService.RotateOAuthClientSecret
+   */
+  rotateOAuthClientSecret: {
+    methodKind: "unary";
+    input: typeof RotateOAuthClientSecretRequestSchema;
+    output: typeof RotateOAuthClientSecretResponseSchema;
+  },
+  /**
+   * Deletes a client with its consents. The tokens it holds are no longer accepted by /userinfo.
+   *
+   * @generated from rpc goserver.api.v1.OAuthService.DeleteOAuthClient
+   */
+  deleteOAuthClient: {
+    methodKind: "unary";
+    input: typeof DeleteOAuthClientRequestSchema;
+    output: typeof DeleteOAuthClientResponseSchema;
+  },
+  /**
+   * Validates an authorization request on behalf of the current user and tells whether
+   * the user has to be asked for consent.
+   *
+   * @generated from rpc goserver.api.v1.OAuthService.GetOAuthAuthorization
+   */
+  getOAuthAuthorization: {
+    methodKind: "unary";
+    input: typeof GetOAuthAuthorizationRequestSchema;
+    output: typeof GetOAuthAuthorizationResponseSchema;
+  },
+  /**
+   * Approves or denies an authorization request of the current user. An approval records
+   * the consent of the user and issues an authorization code.
+   *
+   * @generated from rpc goserver.api.v1.OAuthService.ApproveOAuthAuthorization
+   */
+  approveOAuthAuthorization: {
+    methodKind: "unary";
+    input: typeof ApproveOAuthAuthorizationRequestSchema;
+    output: typeof ApproveOAuthAuthorizationResponseSchema;
+  },
+  /**
+   * Lists the clients the current user has granted access to.
+   *
+   * @generated from rpc goserver.api.v1.OAuthService.ListOAuthConsents
+   */
+  listOAuthConsents: {
+    methodKind: "unary";
+    input: typeof ListOAuthConsentsRequestSchema;
+    output: typeof ListOAuthConsentsResponseSchema;
+  },
+  /**
+   * Revokes the consent of the current user, the client has to ask for it again.
+   *
+   * @generated from rpc goserver.api.v1.OAuthService.RevokeOAuthConsent
+   */
+  revokeOAuthConsent: {
+    methodKind: "unary";
+    input: typeof RevokeOAuthConsentRequestSchema;
+    output: typeof RevokeOAuthConsentResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_api_v1_oauth_service, 0);
+
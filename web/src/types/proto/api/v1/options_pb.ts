@@ -0,0 +1,100 @@
+// @generated by protoc-gen-es v2.11.0 with parameter "target=ts"
+// @generated from file api/v1/options.proto (package goserver.api.v1, syntax proto3)
+/* eslint-disable */
+
+import type { GenExtension, GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
+import { extDesc, fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
+import type { Role } from "./common_pb";
+import { file_api_v1_common } from "./common_pb";
+import type { MethodOptions } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_descriptor } from "@bufbuild/protobuf/wkt";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file api/v1/options.proto.
+ */
+export const file_api_v1_options: GenFile = /*@__PURE__*/
+  fileDesc("ChRhcGkvdjEvb3B0aW9ucy5wcm90bxIPZ29zZXJ2ZXIuYXBpLnYxIlUKCEF1dGhSdWxlEg4KBnB1YmxpYxgBIAEoCBIkCgVyb2xlcxgCIAMoDjIVLmdvc2VydmVyLmFwaS52MS5Sb2xlEhMKC3Blcm1pc3Npb25zGAMgAygJIjQKD01haW50ZW5hbmNlUnVsZRIRCglyZWFkX29ubHkYASABKAgSDgoGYWx3YXlzGAIgASgIOk8KBGF1dGgSHi5nb29nbGUucHJvdG9idWYuTWV0aG9kT3B0aW9ucxjRhgMgASgLMhkuZ29zZXJ2ZXIuYXBpLnYxLkF1dGhSdWxlUgRhdXRoOmQKC21haW50ZW5hbmNlEh4uZ29vZ2xlLnByb3RvYnVmLk1ldGhvZE9wdGlvbnMY0oYDIAEoCzIgLmdvc2VydmVyLmFwaS52MS5NYWludGVuYW5jZVJ1bGVSC21haW50ZW5hbmNlQrMBChNjb20uZ29zZXJ2ZXIuYXBpLnYxQgxPcHRpb25zUHJvdG9QAVowZ2l0aHViLmNvbS9waXhiL2dvLXNlcnZlci9wcm90by9nZW4vYXBpL3YxO2FwaXYxogIDR0FYqgIPR29zZXJ2ZXIuQXBpLlYxygIPR29zZXJ2ZXJcQXBpXFYx4gIbR29zZXJ2ZXJcQXBpXFYxXEdQQk1ldGFkYXRh6gIRR29zZXJ2ZXI6OkFwaTo6VjFiBnByb3RvMw", [file_api_v1_common, file_google_protobuf_descriptor]);
+
+/**
+ * AuthRule declares who may call an RPC method. Methods without a rule can be
+ * called by any authenticated user.
+ *
+ * @generated from message goserver.api.v1.AuthRule
+ */
+export type AuthRule = Message<"goserver.api.v1.AuthRule"> & {
+  /**
+   * The method can be called without authentication.
+   *
+   * @generated from field: bool public = 1;
+   */
+  public: boolean;
+
+  /**
+   * The caller must have one of the roles. Any authenticated caller is allowed when empty.
+   *
+   * @generated from field: repeated goserver.api.v1.Role roles = 2;
+   */
+  roles: Role[];
+
+  /**
+   * The caller must have all of the permissions, such as "users.read".
+   *
+   * @generated from field: repeated string permissions = 3;
+   */
+  permissions: string[];
+};
+
+/**
+ * Describes the message goserver.api.v1.AuthRule.
+ * Use `create(AuthRuleSchema)` to create a new message.
+ */
+export const AuthRuleSchema: GenMessage<AuthRule> = /*@__PURE__*/
+  messageDesc(file_api_v1_options, 0);
+
+/**
+ * MaintenanceRule declares the maintenance modes an RPC method is served in. Methods
+ * are served in read-only mode if their HTTP rule is a GET, and only while the instance
+ * is in service otherwise.
+ *
+ * @generated from message goserver.api.v1.MaintenanceRule
+ */
+export type MaintenanceRule = Message<"goserver.api.v1.MaintenanceRule"> & {
+  /**
+   * The method does not change data and is served in read-only mode.
+   *
+   * @generated from field: bool read_only = 1;
+   */
+  readOnly: boolean;
+
+  /**
+   * The method is served in all modes, including full maintenance.
+   *
+   * @generated from field: bool always = 2;
+   */
+  always: boolean;
+};
+
+/**
+ * Describes the message goserver.api.v1.MaintenanceRule.
+ * Use `create(MaintenanceRuleSchema)` to create a new message.
+ */
+export const MaintenanceRuleSchema: GenMessage<MaintenanceRule> = /*@__PURE__*/
+  messageDesc(file_api_v1_options, 1);
+
+/**
+ * The authorization rule enforced on all protocols before the method is called.
+ *
+ * @generated from extension: goserver.api.v1.AuthRule auth = 50001;
+ */
+export const auth: GenExtension<MethodOptions, AuthRule> = /*@__PURE__*/
+  extDesc(file_api_v1_options, 0);
+
+/**
+ * The maintenance rule enforced on all protocols before authentication.
+ *
+ * @generated from extension: goserver.api.v1.MaintenanceRule maintenance = 50002;
+ */
+export const maintenance: GenExtension<MethodOptions, MaintenanceRule> = /*@__PURE__*/
+  extDesc(file_api_v1_options, 1);
+
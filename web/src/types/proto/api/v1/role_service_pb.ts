@@ -0,0 +1,461 @@
+// @generated by protoc-gen-es v2.11.0 with parameter "target=ts"
+// @generated from file api/v1/role_service.proto (package goserver.api.v1, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import { file_google_api_annotations } from "../../google/api/annotations_pb";
+import { file_google_api_client } from "../../google/api/client_pb";
+import { file_google_api_field_behavior } from "../../google/api/field_behavior_pb";
+import type { FieldMask, Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_field_mask, file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import { file_api_v1_options } from "./options_pb";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file api/v1/role_service.proto.
+ */
+export const file_api_v1_role_service: GenFile = /*@__PURE__*/
+  fileDesc("ChlhcGkvdjEvcm9sZV9zZXJ2aWNlLnByb3RvEg9nb3NlcnZlci5hcGkudjEi3wEKDlJvbGVEZWZpbml0aW9uEg8KAmlkGAEgASgDQgPgQQMSEQoEbmFtZRgCIAEoCUID4EEFEhMKC2Rlc2NyaXB0aW9uGAMgASgJEhUKCGJ1aWx0X2luGAQgASgIQgPgQQMSEwoLcGVybWlzc2lvbnMYBSADKAkSMwoKY3JlYXRlZF9hdBgGIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBCA+BBAxIzCgp1cGRhdGVkX2F0GAcgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEID4EEDIhgKFkxpc3RQZXJtaXNzaW9uc1JlcXVlc3QiMwoXTGlzdFBlcm1pc3Npb25zUmVzcG9uc2USGAoLcGVybWlzc2lvbnMYASADKAlCA+BBAyISChBMaXN0Um9sZXNSZXF1ZXN0IkgKEUxpc3RSb2xlc1Jlc3BvbnNlEjMKBXJvbGVzGAEgAygLMh8uZ29zZXJ2ZXIuYXBpLnYxLlJvbGVEZWZpbml0aW9uQgPgQQMiIQoOR2V0Um9sZVJlcXVlc3QSDwoCaWQYASABKANCA+BBAiJFCg9HZXRSb2xlUmVzcG9uc2USMgoEcm9sZRgBIAEoCzIfLmdvc2VydmVyLmFwaS52MS5Sb2xlRGVmaW5pdGlvbkID4EEDIloKEUNyZWF0ZVJvbGVSZXF1ZXN0EhEKBG5hbWUYASABKAlCA+BBAhIYCgtkZXNjcmlwdGlvbhgCIAEoCUID4EEBEhgKC3Blcm1pc3Npb25zGAMgAygJQgPgQQIiSAoSQ3JlYXRlUm9sZVJlc3BvbnNlEjIKBHJvbGUYASABKAsyHy5nb3NlcnZlci5hcGkudjEuUm9sZURlZmluaXRpb25CA+BBAyKOAQoRVXBkYXRlUm9sZVJlcXVlc3QSDwoCaWQYASABKANCA+BBAhIYCgtkZXNjcmlwdGlvbhgCIAEoCUID4EEBEhgKC3Blcm1pc3Npb25zGAMgAygJQgPgQQESNAoLdXBkYXRlX21hc2sYBCABKAsyGi5nb29nbGUucHJvdG9idWYuRmllbGRNYXNrQgPgQQIiSAoSVXBkYXRlUm9sZVJlc3BvbnNlEjIKBHJvbGUYASABKAsyHy5nb3NlcnZlci5hcGkudjEuUm9sZURlZmluaXRpb25CA+BBAyIkChFEZWxldGVSb2xlUmVxdWVzdBIPCgJpZBgBIAEoA0ID4EECIhQKEkRlbGV0ZVJvbGVSZXNwb25zZSIsChRMaXN0VXNlclJvbGVzUmVxdWVzdBIUCgd1c2VyX2lkGAEgASgDQgPgQQIiZgoVTGlzdFVzZXJSb2xlc1Jlc3BvbnNlEjMKBXJvbGVzGAEgAygLMh8uZ29zZXJ2ZXIuYXBpLnYxLlJvbGVEZWZpbml0aW9uQgPgQQMSGAoLcGVybWlzc2lvbnMYAiADKAlCA+BBAyI/ChNTZXRVc2VyUm9sZXNSZXF1ZXN0EhQKB3VzZXJfaWQYASABKANCA+BBAhISCgVyb2xlcxgCIAMoCUID4EEBIksKFFNldFVzZXJSb2xlc1Jlc3BvbnNlEjMKBXJvbGVzGAEgAygLMh8uZ29zZXJ2ZXIuYXBpLnYxLlJvbGVEZWZpbml0aW9uQgPgQQMysQkKC1JvbGVTZXJ2aWNlEpQBCg9MaXN0UGVybWlzc2lvbnMSJy5nb3NlcnZlci5hcGkudjEuTGlzdFBlcm1pc3Npb25zUmVxdWVzdBooLmdvc2VydmVyLmFwaS52MS5MaXN0UGVybWlzc2lvbnNSZXNwb25zZSIu2kEAirUYDBoKcm9sZXMucmVhZILT5JMCFRITL2FwaS92MS9wZXJtaXNzaW9ucxJ8CglMaXN0Um9sZXMSIS5nb3NlcnZlci5hcGkudjEuTGlzdFJvbGVzUmVxdWVzdBoiLmdvc2VydmVyLmFwaS52MS5MaXN0Um9sZXNSZXNwb25zZSIo2kEAirUYDBoKcm9sZXMucmVhZILT5JMCDxINL2FwaS92MS9yb2xlcxJ9CgdHZXRSb2xlEh8uZ29zZXJ2ZXIuYXBpLnYxLkdldFJvbGVSZXF1ZXN0GiAuZ29zZXJ2ZXIuYXBpLnYxLkdldFJvbGVSZXNwb25zZSIv2kECaWSKtRgMGgpyb2xlcy5yZWFkgtPkkwIUEhIvYXBpL3YxL3JvbGVzL3tpZH0SkwEKCkNyZWF0ZVJvbGUSIi5nb3NlcnZlci5hcGkudjEuQ3JlYXRlUm9sZVJlcXVlc3QaIy5nb3NlcnZlci5hcGkudjEuQ3JlYXRlUm9sZVJlc3BvbnNlIjzaQRBuYW1lLHBlcm1pc3Npb25zirUYDRoLcm9sZXMud3JpdGWC0+STAhI6ASoiDS9hcGkvdjEvcm9sZXMSlgEKClVwZGF0ZVJvbGUSIi5nb3NlcnZlci5hcGkudjEuVXBkYXRlUm9sZVJlcXVlc3QaIy5nb3NlcnZlci5hcGkudjEuVXBkYXRlUm9sZVJlc3BvbnNlIj/aQQ5pZCx1cGRhdGVfbWFza4q1GA0aC3JvbGVzLndyaXRlgtPkkwIXOgEqMhIvYXBpL3YxL3JvbGVzL3tpZH0ShwEKCkRlbGV0ZVJvbGUSIi5nb3NlcnZlci5hcGkudjEuRGVsZXRlUm9sZVJlcXVlc3QaIy5nb3NlcnZlci5hcGkudjEuRGVsZXRlUm9sZVJlc3BvbnNlIjDaQQJpZIq1GA0aC3JvbGVzLndyaXRlgtPkkwIUKhIvYXBpL3YxL3JvbGVzL3tpZH0SpQEKDUxpc3RVc2VyUm9sZXMSJS5nb3NlcnZlci5hcGkudjEuTGlzdFVzZXJSb2xlc1JlcXVlc3QaJi5nb3NlcnZlci5hcGkudjEuTGlzdFVzZXJSb2xlc1Jlc3BvbnNlIkXaQQd1c2VyX2lkirUYDBoKcm9sZXMucmVhZILT5JMCJRIjL2FwaS92MS9hZG1pbi91c2Vycy97dXNlcl9pZH0vcm9sZXMSrAEKDFNldFVzZXJSb2xlcxIkLmdvc2VydmVyLmFwaS52MS5TZXRVc2VyUm9sZXNSZXF1ZXN0GiUuZ29zZXJ2ZXIuYXBpLnYxLlNldFVzZXJSb2xlc1Jlc3BvbnNlIk/aQQ11c2VyX2lkLHJvbGVzirUYDRoLcm9sZXMud3JpdGWC0+STAig6ASoaIy9hcGkvdjEvYWRtaW4vdXNlcnMve3VzZXJfaWR9L3JvbGVzQrcBChNjb20uZ29zZXJ2ZXIuYXBpLnYxQhBSb2xlU2VydmljZVByb3RvUAFaMGdpdGh1Yi5jb20vcGl4Yi9nby1zZXJ2ZXIvcHJvdG8vZ2VuL2FwaS92MTthcGl2MaICA0dBWKoCD0dvc2VydmVyLkFwaS5WMcoCD0dvc2VydmVyXEFwaVxWMeICG0dvc2VydmVyXEFwaVxWMVxHUEJNZXRhZGF0YeoCEUdvc2VydmVyOjpBcGk6OlYxYgZwcm90bzM", [file_google_api_annotations, file_google_api_client, file_google_api_field_behavior, file_google_protobuf_field_mask, file_google_protobuf_timestamp, file_api_v1_options]);
+
+/**
+ * @generated from message goserver.api.v1.RoleDefinition
+ */
+export type RoleDefinition = Message<"goserver.api.v1.RoleDefinition"> & {
+  /**
+   * @generated from field: int64 id = 1;
+   */
+  id: bigint;
+
+  /**
+   * Lowercase letters, digits, "-" and "_", unique across roles.
+   *
+   * @generated from field: string name = 2;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string description = 3;
+   */
+  description: string;
+
+  /**
+   * Built-in roles cannot be changed, deleted or assigned as custom roles.
+   *
+   * @generated from field: bool built_in = 4;
+   */
+  builtIn: boolean;
+
+  /**
+   * @generated from field: repeated string permissions = 5;
+   */
+  permissions: string[];
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 6;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp updated_at = 7;
+   */
+  updatedAt?: Timestamp;
+};
+
+/**
+ * Describes the message goserver.api.v1.RoleDefinition.
+ * Use `create(RoleDefinitionSchema)` to create a new message.
+ */
+export const RoleDefinitionSchema: GenMessage<RoleDefinition> = /*@__PURE__*/
+  messageDesc(file_api_v1_role_service, 0);
+
+/**
+ * @generated from message goserver.api.v1.ListPermissionsRequest
+ */
+export type ListPermissionsRequest = Message<"goserver.api.v1.ListPermissionsRequest"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.ListPermissionsRequest.
+ * Use `create(ListPermissionsRequestSchema)` to create a new message.
+ */
+export const ListPermissionsRequestSchema: GenMessage<ListPermissionsRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_role_service, 1);
+
+/**
+ * @generated from message goserver.api.v1.ListPermissionsResponse
+ */
+export type ListPermissionsResponse = Message<"goserver.api.v1.ListPermissionsResponse"> & {
+  /**
+   * @generated from field: repeated string permissions = 1;
+   */
+  permissions: string[];
+};
+
+/**
+ * Describes the message goserver.api.v1.ListPermissionsResponse.
+ * Use `create(ListPermissionsResponseSchema)` to create a new message.
+ */
+export const ListPermissionsResponseSchema: GenMessage<ListPermissionsResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_role_service, 2);
+
+/**
+ * @generated from message goserver.api.v1.ListRolesRequest
+ */
+export type ListRolesRequest = Message<"goserver.api.v1.ListRolesRequest"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.ListRolesRequest.
+ * Use `create(ListRolesRequestSchema)` to create a new message.
+ */
+export const ListRolesRequestSchema: GenMessage<ListRolesRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_role_service, 3);
+
+/**
+ * @generated from message goserver.api.v1.ListRolesResponse
+ */
+export type ListRolesResponse = Message<"goserver.api.v1.ListRolesResponse"> & {
+  /**
+   * @generated from field: repeated goserver.api.v1.RoleDefinition roles = 1;
+   */
+  roles: RoleDefinition[];
+};
+
+/**
+ * Describes the message goserver.api.v1.ListRolesResponse.
+ * Use `create(ListRolesResponseSchema)` to create a new message.
+ */
+export const ListRolesResponseSchema: GenMessage<ListRolesResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_role_service, 4);
+
+/**
+ * @generated from message goserver.api.v1.GetRoleRequest
+ */
+export type GetRoleRequest = Message<"goserver.api.v1.GetRoleRequest"> & {
+  /**
+   * @generated from field: int64 id = 1;
+   */
+  id: bigint;
+};
+
+/**
+ * Describes the message goserver.api.v1.GetRoleRequest.
+ * Use `create(GetRoleRequestSchema)` to create a new message.
+ */
+export const GetRoleRequestSchema: GenMessage<GetRoleRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_role_service, 5);
+
+/**
+ * @generated from message goserver.api.v1.GetRoleResponse
+ */
+export type GetRoleResponse = Message<"goserver.api.v1.GetRoleResponse"> & {
+  /**
+   * @generated from field: goserver.api.v1.RoleDefinition role = 1;
+   */
+  role?: RoleDefinition;
+};
+
+/**
+ * Describes the message goserver.api.v1.GetRoleResponse.
+ * Use `create(GetRoleResponseSchema)` to create a new message.
+ */
+export const GetRoleResponseSchema: GenMessage<GetRoleResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_role_service, 6);
+
+/**
+ * @generated from message goserver.api.v1.CreateRoleRequest
+ */
+export type CreateRoleRequest = Message<"goserver.api.v1.CreateRoleRequest"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string description = 2;
+   */
+  description: string;
+
+  /**
+   * @generated from field: repeated string permissions = 3;
+   */
+  permissions: string[];
+};
+
+/**
+ * Describes the message goserver.api.v1.CreateRoleRequest.
+ * Use `create(CreateRoleRequestSchema)` to create a new message.
+ */
+export const CreateRoleRequestSchema: GenMessage<CreateRoleRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_role_service, 7);
+
+/**
+ * @generated from message goserver.api.v1.CreateRoleResponse
+ */
+export type CreateRoleResponse = Message<"goserver.api.v1.CreateRoleResponse"> & {
+  /**
+   * @generated from field: goserver.api.v1.RoleDefinition role = 1;
+   */
+  role?: RoleDefinition;
+};
+
+/**
+ * Describes the message goserver.api.v1.CreateRoleResponse.
+ * Use `create(CreateRoleResponseSchema)` to create a new message.
+ */
+export const CreateRoleResponseSchema: GenMessage<CreateRoleResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_role_service, 8);
+
+/**
+ * @generated from message goserver.api.v1.UpdateRoleRequest
+ */
+export type UpdateRoleRequest = Message<"goserver.api.v1.UpdateRoleRequest"> & {
+  /**
+   * @generated from field: int64 id = 1;
+   */
+  id: bigint;
+
+  /**
+   * @generated from field: string description = 2;
+   */
+  description: string;
+
+  /**
+   * @generated from field: repeated string permissions = 3;
+   */
+  permissions: string[];
+
+  /**
+   * The fields to update: "description" and "permissions".
+   *
+   * @generated from field: google.protobuf.FieldMask update_mask = 4;
+   */
+  updateMask?: FieldMask;
+};
+
+/**
+ * Describes the message goserver.api.v1.UpdateRoleRequest.
+ * Use `create(UpdateRoleRequestSchema)` to create a new message.
+ */
+export const UpdateRoleRequestSchema: GenMessage<UpdateRoleRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_role_service, 9);
+
+/**
+ * @generated from message goserver.api.v1.UpdateRoleResponse
+ */
+export type UpdateRoleResponse = Message<"goserver.api.v1.UpdateRoleResponse"> & {
+  /**
+   * @generated from field: goserver.api.v1.RoleDefinition role = 1;
+   */
+  role?: RoleDefinition;
+};
+
+/**
+ * Describes the message goserver.api.v1.UpdateRoleResponse.
+ * Use `create(UpdateRoleResponseSchema)` to create a new message.
+ */
+export const UpdateRoleResponseSchema: GenMessage<UpdateRoleResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_role_service, 10);
+
+/**
+ * @generated from message goserver.api.v1.DeleteRoleRequest
+ */
+export type DeleteRoleRequest = Message<"goserver.api.v1.DeleteRoleRequest"> & {
+  /**
+   * @generated from field: int64 id = 1;
+   */
+  id: bigint;
+};
+
+/**
+ * Describes the message goserver.api.v1.DeleteRoleRequest.
+ * Use `create(DeleteRoleRequestSchema)` to create a new message.
+ */
+export const DeleteRoleRequestSchema: GenMessage<DeleteRoleRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_role_service, 11);
+
+/**
+ * @generated from message goserver.api.v1.DeleteRoleResponse
+ */
+export type DeleteRoleResponse = Message<"goserver.api.v1.DeleteRoleResponse"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.DeleteRoleResponse.
+ * Use `create(DeleteRoleResponseSchema)` to create a new message.
+ */
+export const DeleteRoleResponseSchema: GenMessage<DeleteRoleResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_role_service, 12);
+
+/**
+ * @generated from message goserver.api.v1.ListUserRolesRequest
+ */
+export type ListUserRolesRequest = Message<"goserver.api.v1.ListUserRolesRequest"> & {
+  /**
+   * @generated from field: int64 user_id = 1;
+   */
+  userId: bigint;
+};
+
+/**
+ * Describes the message goserver.api.v1.ListUserRolesRequest.
+ * Use `create(ListUserRolesRequestSchema)` to create a new message.
+ */
+export const ListUserRolesRequestSchema: GenMessage<ListUserRolesRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_role_service, 13);
+
+/**
+ * @generated from message goserver.api.v1.ListUserRolesResponse
+ */
+export type ListUserRolesResponse = Message<"goserver.api.v1.ListUserRolesResponse"> & {
+  /**
+   * The custom roles assigned to the user, not including the built-in role of the user.
+   *
+   * @generated from field: repeated goserver.api.v1.RoleDefinition roles = 1;
+   */
+  roles: RoleDefinition[];
+
+  /**
+   * The permissions granted by the built-in role and the custom roles of the user.
+   *
+   * @generated from field: repeated string permissions = 2;
+   */
+  permissions: string[];
+};
+
+/**
+ * Describes the message goserver.api.v1.ListUserRolesResponse.
+ * Use `create(ListUserRolesResponseSchema)` to create a new message.
+ */
+export const ListUserRolesResponseSchema: GenMessage<ListUserRolesResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_role_service, 14);
+
+/**
+ * @generated from message goserver.api.v1.SetUserRolesRequest
+ */
+export type SetUserRolesRequest = Message<"goserver.api.v1.SetUserRolesRequest"> & {
+  /**
+   * @generated from field: int64 user_id = 1;
+   */
+  userId: bigint;
+
+  /**
+   * The names of the custom roles, an empty list removes all of them.
+   *
+   * @generated from field: repeated string roles = 2;
+   */
+  roles: string[];
+};
+
+/**
+ * Describes the message goserver.api.v1.SetUserRolesRequest.
+ * Use `create(SetUserRolesRequestSchema)` to create a new message.
+ */
+export const SetUserRolesRequestSchema: GenMessage<SetUserRolesRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_role_service, 15);
+
+/**
+ * @generated from message goserver.api.v1.SetUserRolesResponse
+ */
+export type SetUserRolesResponse = Message<"goserver.api.v1.SetUserRolesResponse"> & {
+  /**
+   * @generated from field: repeated goserver.api.v1.RoleDefinition roles = 1;
+   */
+  roles: RoleDefinition[];
+};
+
+/**
+ * Describes the message goserver.api.v1.SetUserRolesResponse.
+ * Use `create(SetUserRolesResponseSchema)` to create a new message.
+ */
+export const SetUserRolesResponseSchema: GenMessage<SetUserRolesResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_role_service, 16);
+
+/**
+ * RoleService manages the roles of the instance and the roles assigned to users.
+ * A role is a named set of permissions. The built-in admin and user roles are the
+ * role of a user record, custom roles are assigned to users on top of it.
+ *
+ * @generated from service goserver.api.v1.RoleService
+ */
+export const RoleService: GenService<{
+  /**
+   * Lists every permission a role can grant.
+   *
+   * @generated from rpc goserver.api.v1.RoleService.ListPermissions
+   */
+  listPermissions: {
+    methodKind: "unary";
+    input: typeof ListPermissionsRequestSchema;
+    output: typeof ListPermissionsResponseSchema;
+  },
+  /**
+   * @generated from rpc goserver.api.v1.RoleService.ListRoles
+   */
+  listRoles: {
+    methodKind: "unary";
+    input: typeof ListRolesRequestSchema;
+    output: typeof ListRolesResponseSchema;
+  },
+  /**
+   * @generated from rpc goserver.api.v1.RoleService.GetRole
+   */
+  getRole: {
+    methodKind: "unary";
+    input: typeof GetRoleRequestSchema;
+    output: typeof GetRoleResponseSchema;
+  },
+  /**
+   * Creates a custom role.
+   *
+   * @generated from rpc goserver.api.v1.RoleService.CreateRole
+   */
+  createRole: {
+    methodKind: "unary";
+    input: typeof CreateRoleRequestSchema;
+    output: typeof CreateRoleResponseSchema;
+  },
+  /**
+   * Updates the fields of a custom role listed in update_mask. Built-in roles cannot be changed.
+   *
+   * @generated from rpc goserver.api.v1.RoleService.UpdateRole
+   */
+  updateRole: {
+    methodKind: "unary";
+    input: typeof UpdateRoleRequestSchema;
+    output: typeof UpdateRoleResponseSchema;
+  },
+  /**
+   * Deletes a custom role and unassigns it from its users. Built-in roles cannot be deleted.
+   *
+   * @generated from rpc goserver.api.v1.RoleService.DeleteRole
+   */
+  deleteRole: {
+    methodKind: "unary";
+    input: typeof DeleteRoleRequestSchema;
+    output: typeof DeleteRoleResponseSchema;
+  },
+  /**
+   * Lists the custom roles assigned to a user and the permissions they end up with.
+   *
+   * @generated from rpc goserver.api.v1.RoleService.ListUserRoles
+   */
+  listUserRoles: {
+    methodKind: "unary";
+    input: typeof ListUserRolesRequestSchema;
+    output: typeof ListUserRolesResponseSchema;
+  },
+  /**
+   * Replaces the custom roles assigned to a user.
+   *
+   * @generated from rpc goserver.api.v1.RoleService.SetUserRoles
+   */
+  setUserRoles: {
+    methodKind: "unary";
+    input: typeof SetUserRolesRequestSchema;
+    output: typeof SetUserRolesResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_api_v1_role_service, 0);
+
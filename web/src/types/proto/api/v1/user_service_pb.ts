@@ -11,13 +11,14 @@ import type { Timestamp } from "@bufbuild/protobuf/wkt";
 import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
 import type { User } from "./common_pb";
 import { file_api_v1_common } from "./common_pb";
+import { file_api_v1_options } from "./options_pb";
 import type { Message } from "@bufbuild/protobuf";
 
 /**
  * Describes the file api/v1/user_service.proto.
  */
 export const file_api_v1_user_service: GenFile = /*@__PURE__*/
-  fileDesc("ChlhcGkvdjEvdXNlcl9zZXJ2aWNlLnByb3RvEg9nb3NlcnZlci5hcGkudjEiggEKE1JlZ2lzdGVyVXNlclJlcXVlc3QSFQoIdXNlcm5hbWUYASABKAlCA+BBAhIVCghuaWNrbmFtZRgCIAEoCUID4EECEhUKCHBhc3N3b3JkGAMgASgJQgPgQQISEgoFcGhvbmUYBCABKAlCA+BBAhISCgVlbWFpbBgFIAEoCUID4EECIrkBChRSZWdpc3RlclVzZXJSZXNwb25zZRIZCgxhY2Nlc3NfdG9rZW4YASABKAlCA+BBAxIaCg1yZWZyZXNoX3Rva2VuGAIgASgJQgPgQQMSQAoXYWNjZXNzX3Rva2VuX2V4cGlyZXNfYXQYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wQgPgQQMSKAoEdXNlchgEIAEoCzIVLmdvc2VydmVyLmFwaS52MS5Vc2VyQgPgQQMiFwoVR2V0VXNlclByb2ZpbGVSZXF1ZXN0IkIKFkdldFVzZXJQcm9maWxlUmVzcG9uc2USKAoEdXNlchgBIAEoCzIVLmdvc2VydmVyLmFwaS52MS5Vc2VyQgPgQQMiWQoYVXBkYXRlVXNlclByb2ZpbGVSZXF1ZXN0EhUKCG5pY2tuYW1lGAEgASgJQgPgQQESEgoFcGhvbmUYAiABKAlCA+BBARISCgVlbWFpbBgDIAEoCUID4EEBIkUKGVVwZGF0ZVVzZXJQcm9maWxlUmVzcG9uc2USKAoEdXNlchgBIAEoCzIVLmdvc2VydmVyLmFwaS52MS5Vc2VyQgPgQQMiTQoVQ2hhbmdlUGFzc3dvcmRSZXF1ZXN0EhkKDG9sZF9wYXNzd29yZBgBIAEoCUID4EECEhkKDG5ld19wYXNzd29yZBgCIAEoCUID4EECIkIKFkNoYW5nZVBhc3N3b3JkUmVzcG9uc2USKAoEdXNlchgBIAEoCzIVLmdvc2VydmVyLmFwaS52MS5Vc2VyQgPgQQMy9QQKC1VzZXJTZXJ2aWNlEp4BCgxSZWdpc3RlclVzZXISJC5nb3NlcnZlci5hcGkudjEuUmVnaXN0ZXJVc2VyUmVxdWVzdBolLmdvc2VydmVyLmFwaS52MS5SZWdpc3RlclVzZXJSZXNwb25zZSJB2kEmdXNlcm5hbWUsbmlja25hbWUscGFzc3dvcmQscGhvbmUsZW1haWyC0+STAhI6ASoiDS9hcGkvdjEvdXNlcnMSfgoOR2V0VXNlclByb2ZpbGUSJi5nb3NlcnZlci5hcGkudjEuR2V0VXNlclByb2ZpbGVSZXF1ZXN0GicuZ29zZXJ2ZXIuYXBpLnYxLkdldFVzZXJQcm9maWxlUmVzcG9uc2UiG9pBAILT5JMCEhIQL2FwaS92MS91c2Vycy9tZRKeAQoRVXBkYXRlVXNlclByb2ZpbGUSKS5nb3NlcnZlci5hcGkudjEuVXBkYXRlVXNlclByb2ZpbGVSZXF1ZXN0GiouZ29zZXJ2ZXIuYXBpLnYxLlVwZGF0ZVVzZXJQcm9maWxlUmVzcG9uc2UiMtpBFG5pY2tuYW1lLHBob25lLGVtYWlsgtPkkwIVOgEqMhAvYXBpL3YxL3VzZXJzL21lEqMBCg5DaGFuZ2VQYXNzd29yZBImLmdvc2VydmVyLmFwaS52MS5DaGFuZ2VQYXNzd29yZFJlcXVlc3QaJy5nb3NlcnZlci5hcGkudjEuQ2hhbmdlUGFzc3dvcmRSZXNwb25zZSJA2kEZb2xkX3Bhc3N3b3JkLG5ld19wYXNzd29yZILT5JMCHjoBKiIZL2FwaS92MS91c2Vycy9tZS9wYXNzd29yZEK3AQoTY29tLmdvc2VydmVyLmFwaS52MUIQVXNlclNlcnZpY2VQcm90b1ABWjBnaXRodWIuY29tL3BpeGIvZ28tc2VydmVyL3Byb3RvL2dlbi9hcGkvdjE7YXBpdjGiAgNHQViqAg9Hb3NlcnZlci5BcGkuVjHKAg9Hb3NlcnZlclxBcGlcVjHiAhtHb3NlcnZlclxBcGlcVjFcR1BCTWV0YWRhdGHqAhFHb3NlcnZlcjo6QXBpOjpWMWIGcHJvdG8z", [file_google_api_annotations, file_google_api_client, file_google_api_field_behavior, file_google_protobuf_timestamp, file_api_v1_common]);
+  fileDesc("ChlhcGkvdjEvdXNlcl9zZXJ2aWNlLnByb3RvEg9nb3NlcnZlci5hcGkudjEinAEKE1JlZ2lzdGVyVXNlclJlcXVlc3QSFQoIdXNlcm5hbWUYASABKAlCA+BBAhIVCghuaWNrbmFtZRgCIAEoCUID4EECEhUKCHBhc3N3b3JkGAMgASgJQgPgQQISEgoFcGhvbmUYBCABKAlCA+BBAhISCgVlbWFpbBgFIAEoCUID4EECEhgKC2ludml0ZV9jb2RlGAYgASgJQgPgQQEiuQEKFFJlZ2lzdGVyVXNlclJlc3BvbnNlEhkKDGFjY2Vzc190b2tlbhgBIAEoCUID4EEDEhoKDXJlZnJlc2hfdG9rZW4YAiABKAlCA+BBAxJAChdhY2Nlc3NfdG9rZW5fZXhwaXJlc19hdBgDIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBCA+BBAxIoCgR1c2VyGAQgASgLMhUuZ29zZXJ2ZXIuYXBpLnYxLlVzZXJCA+BBAyIXChVHZXRVc2VyUHJvZmlsZVJlcXVlc3QiQgoWR2V0VXNlclByb2ZpbGVSZXNwb25zZRIoCgR1c2VyGAEgASgLMhUuZ29zZXJ2ZXIuYXBpLnYxLlVzZXJCA+BBAyJZChhVcGRhdGVVc2VyUHJvZmlsZVJlcXVlc3QSFQoIbmlja25hbWUYASABKAlCA+BBARISCgVwaG9uZRgCIAEoCUID4EEBEhIKBWVtYWlsGAMgASgJQgPgQQEiYQoZVXBkYXRlVXNlclByb2ZpbGVSZXNwb25zZRIoCgR1c2VyGAEgASgLMhUuZ29zZXJ2ZXIuYXBpLnYxLlVzZXJCA+BBAxIaCg1wZW5kaW5nX2VtYWlsGAIgASgJQgPgQQMiTQoVQ2hhbmdlUGFzc3dvcmRSZXF1ZXN0EhkKDG9sZF9wYXNzd29yZBgBIAEoCUID4EECEhkKDG5ld19wYXNzd29yZBgCIAEoCUID4EECIkIKFkNoYW5nZVBhc3N3b3JkUmVzcG9uc2USKAoEdXNlchgBIAEoCzIVLmdvc2VydmVyLmFwaS52MS5Vc2VyQgPgQQMi1wEKE1BlcnNvbmFsQWNjZXNzVG9rZW4SDwoCaWQYASABKANCA+BBAxITCgtkZXNjcmlwdGlvbhgCIAEoCRIuCgpleHBpcmVzX2F0GAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBI1CgxsYXN0X3VzZWRfYXQYBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wQgPgQQMSMwoKY3JlYXRlZF9hdBgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBCA+BBAyJxCiBDcmVhdGVQZXJzb25hbEFjY2Vzc1Rva2VuUmVxdWVzdBIYCgtkZXNjcmlwdGlvbhgBIAEoCUID4EECEjMKCmV4cGlyZXNfYXQYAiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wQgPgQQEigQEKIUNyZWF0ZVBlcnNvbmFsQWNjZXNzVG9rZW5SZXNwb25zZRJIChVwZXJzb25hbF9hY2Nlc3NfdG9rZW4YASABKAsyJC5nb3NlcnZlci5hcGkudjEuUGVyc29uYWxBY2Nlc3NUb2tlbkID4EEDEhIKBXRva2VuGAIgASgJQgPgQQMiIQofTGlzdFBlcnNvbmFsQWNjZXNzVG9rZW5zUmVxdWVzdCJtCiBMaXN0UGVyc29uYWxBY2Nlc3NUb2tlbnNSZXNwb25zZRJJChZwZXJzb25hbF9hY2Nlc3NfdG9rZW5zGAEgAygLMiQuZ29zZXJ2ZXIuYXBpLnYxLlBlcnNvbmFsQWNjZXNzVG9rZW5CA+BBAyIzCiBEZWxldGVQZXJzb25hbEFjY2Vzc1Rva2VuUmVxdWVzdBIPCgJpZBgBIAEoA0ID4EECIiMKIURlbGV0ZVBlcnNvbmFsQWNjZXNzVG9rZW5SZXNwb25zZSKDAgoHU2Vzc2lvbhIPCgJpZBgBIAEoCUID4EEDEhcKCnVzZXJfYWdlbnQYAiABKAlCA+BBAxIXCgppcF9hZGRyZXNzGAMgASgJQgPgQQMSMwoKY3JlYXRlZF9hdBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBCA+BBAxI1CgxsYXN0X3VzZWRfYXQYBSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wQgPgQQMSMwoKZXhwaXJlc19hdBgGIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBCA+BBAxIUCgdjdXJyZW50GAcgASgIQgPgQQMiFQoTTGlzdFNlc3Npb25zUmVxdWVzdCJHChRMaXN0U2Vzc2lvbnNSZXNwb25zZRIvCghzZXNzaW9ucxgBIAMoCzIYLmdvc2VydmVyLmFwaS52MS5TZXNzaW9uQgPgQQMiJwoUUmV2b2tlU2Vzc2lvblJlcXVlc3QSDwoCaWQYASABKAlCA+BBAiIXChVSZXZva2VTZXNzaW9uUmVzcG9uc2UiHwodUmV2b2tlQWxsT3RoZXJTZXNzaW9uc1JlcXVlc3QiPAoeUmV2b2tlQWxsT3RoZXJTZXNzaW9uc1Jlc3BvbnNlEhoKDXJldm9rZWRfY291bnQYASABKAVCA+BBAyITChFFbnJvbGxUT1RQUmVxdWVzdCJgChJFbnJvbGxUT1RQUmVzcG9uc2USEwoGc2VjcmV0GAEgASgJQgPgQQMSGAoLb3RwYXV0aF91cmkYAiABKAlCA+BBAxIbCg5yZWNvdmVyeV9jb2RlcxgDIAMoCUID4EEDIicKEkNvbmZpcm1UT1RQUmVxdWVzdBIRCgRjb2RlGAEgASgJQgPgQQIiFQoTQ29uZmlybVRPVFBSZXNwb25zZSIrChJEaXNhYmxlVE9UUFJlcXVlc3QSFQoIcGFzc3dvcmQYASABKAlCA+BBAiIVChNEaXNhYmxlVE9UUFJlc3BvbnNlIiQKEVVubG9ja1VzZXJSZXF1ZXN0Eg8KAmlkGAEgASgDQgPgQQIiFAoSVW5sb2NrVXNlclJlc3BvbnNlIigKElZlcmlmeUVtYWlsUmVxdWVzdBISCgV0b2tlbhgBIAEoCUID4EECIj8KE1ZlcmlmeUVtYWlsUmVzcG9uc2USKAoEdXNlchgBIAEoCzIVLmdvc2VydmVyLmFwaS52MS5Vc2VyQgPgQQMiLwoZUmVzZW5kVmVyaWZpY2F0aW9uUmVxdWVzdBISCgVlbWFpbBgBIAEoCUID4EECIhwKGlJlc2VuZFZlcmlmaWNhdGlvblJlc3BvbnNlMvYTCgtVc2VyU2VydmljZRKkAQoMUmVnaXN0ZXJVc2VyEiQuZ29zZXJ2ZXIuYXBpLnYxLlJlZ2lzdGVyVXNlclJlcXVlc3QaJS5nb3NlcnZlci5hcGkudjEuUmVnaXN0ZXJVc2VyUmVzcG9uc2UiR9pBJnVzZXJuYW1lLG5pY2tuYW1lLHBhc3N3b3JkLHBob25lLGVtYWlsirUYAggBgtPkkwISOgEqIg0vYXBpL3YxL3VzZXJzEn4KDkdldFVzZXJQcm9maWxlEiYuZ29zZXJ2ZXIuYXBpLnYxLkdldFVzZXJQcm9maWxlUmVxdWVzdBonLmdvc2VydmVyLmFwaS52MS5HZXRVc2VyUHJvZmlsZVJlc3BvbnNlIhvaQQCC0+STAhISEC9hcGkvdjEvdXNlcnMvbWUSngEKEVVwZGF0ZVVzZXJQcm9maWxlEikuZ29zZXJ2ZXIuYXBpLnYxLlVwZGF0ZVVzZXJQcm9maWxlUmVxdWVzdBoqLmdvc2VydmVyLmFwaS52MS5VcGRhdGVVc2VyUHJvZmlsZVJlc3BvbnNlIjLaQRRuaWNrbmFtZSxwaG9uZSxlbWFpbILT5JMCFToBKjIQL2FwaS92MS91c2Vycy9tZRKjAQoOQ2hhbmdlUGFzc3dvcmQSJi5nb3NlcnZlci5hcGkudjEuQ2hhbmdlUGFzc3dvcmRSZXF1ZXN0GicuZ29zZXJ2ZXIuYXBpLnYxLkNoYW5nZVBhc3N3b3JkUmVzcG9uc2UiQNpBGW9sZF9wYXNzd29yZCxuZXdfcGFzc3dvcmSC0+STAh46ASoiGS9hcGkvdjEvdXNlcnMvbWUvcGFzc3dvcmQSzwEKGUNyZWF0ZVBlcnNvbmFsQWNjZXNzVG9rZW4SMS5nb3NlcnZlci5hcGkudjEuQ3JlYXRlUGVyc29uYWxBY2Nlc3NUb2tlblJlcXVlc3QaMi5nb3NlcnZlci5hcGkudjEuQ3JlYXRlUGVyc29uYWxBY2Nlc3NUb2tlblJlc3BvbnNlIkvaQRZkZXNjcmlwdGlvbixleHBpcmVzX2F0gtPkkwIsOgEqIicvYXBpL3YxL3VzZXJzL21lL3BlcnNvbmFsLWFjY2Vzcy10b2tlbnMSswEKGExpc3RQZXJzb25hbEFjY2Vzc1Rva2VucxIwLmdvc2VydmVyLmFwaS52MS5MaXN0UGVyc29uYWxBY2Nlc3NUb2tlbnNSZXF1ZXN0GjEuZ29zZXJ2ZXIuYXBpLnYxLkxpc3RQZXJzb25hbEFjY2Vzc1Rva2Vuc1Jlc3BvbnNlIjLaQQCC0+STAikSJy9hcGkvdjEvdXNlcnMvbWUvcGVyc29uYWwtYWNjZXNzLXRva2VucxK9AQoZRGVsZXRlUGVyc29uYWxBY2Nlc3NUb2tlbhIxLmdvc2VydmVyLmFwaS52MS5EZWxldGVQZXJzb25hbEFjY2Vzc1Rva2VuUmVxdWVzdBoyLmdvc2VydmVyLmFwaS52MS5EZWxldGVQZXJzb25hbEFjY2Vzc1Rva2VuUmVzcG9uc2UiOdpBAmlkgtPkkwIuKiwvYXBpL3YxL3VzZXJzL21lL3BlcnNvbmFsLWFjY2Vzcy10b2tlbnMve2lkfRKBAQoMTGlzdFNlc3Npb25zEiQuZ29zZXJ2ZXIuYXBpLnYxLkxpc3RTZXNzaW9uc1JlcXVlc3QaJS5nb3NlcnZlci5hcGkudjEuTGlzdFNlc3Npb25zUmVzcG9uc2UiJNpBAILT5JMCGxIZL2FwaS92MS91c2Vycy9tZS9zZXNzaW9ucxKLAQoNUmV2b2tlU2Vzc2lvbhIlLmdvc2VydmVyLmFwaS52MS5SZXZva2VTZXNzaW9uUmVxdWVzdBomLmdvc2VydmVyLmFwaS52MS5SZXZva2VTZXNzaW9uUmVzcG9uc2UiK9pBAmlkgtPkkwIgKh4vYXBpL3YxL3VzZXJzL21lL3Nlc3Npb25zL3tpZH0SsAEKFlJldm9rZUFsbE90aGVyU2Vzc2lvbnMSLi5nb3NlcnZlci5hcGkudjEuUmV2b2tlQWxsT3RoZXJTZXNzaW9uc1JlcXVlc3QaLy5nb3NlcnZlci5hcGkudjEuUmV2b2tlQWxsT3RoZXJTZXNzaW9uc1Jlc3BvbnNlIjXaQQCC0+STAiw6ASoiJy9hcGkvdjEvdXNlcnMvbWUvc2Vzc2lvbnMvcmV2b2tlLW90aGVycxJ6CgpFbnJvbGxUT1RQEiIuZ29zZXJ2ZXIuYXBpLnYxLkVucm9sbFRPVFBSZXF1ZXN0GiMuZ29zZXJ2ZXIuYXBpLnYxLkVucm9sbFRPVFBSZXNwb25zZSIj2kEAgtPkkwIaOgEqIhUvYXBpL3YxL3VzZXJzL21lL3RvdHASiQEKC0NvbmZpcm1UT1RQEiMuZ29zZXJ2ZXIuYXBpLnYxLkNvbmZpcm1UT1RQUmVxdWVzdBokLmdvc2VydmVyLmFwaS52MS5Db25maXJtVE9UUFJlc3BvbnNlIi/aQQRjb2RlgtPkkwIiOgEqIh0vYXBpL3YxL3VzZXJzL21lL3RvdHAvY29uZmlybRKNAQoLRGlzYWJsZVRPVFASIy5nb3NlcnZlci5hcGkudjEuRGlzYWJsZVRPVFBSZXF1ZXN0GiQuZ29zZXJ2ZXIuYXBpLnYxLkRpc2FibGVUT1RQUmVzcG9uc2UiM9pBCHBhc3N3b3JkgtPkkwIiOgEqIh0vYXBpL3YxL3VzZXJzL21lL3RvdHAvZGlzYWJsZRKRAQoKVW5sb2NrVXNlchIiLmdvc2VydmVyLmFwaS52MS5VbmxvY2tVc2VyUmVxdWVzdBojLmdvc2VydmVyLmFwaS52MS5VbmxvY2tVc2VyUmVzcG9uc2UiOtpBAmlkirUYDRoLdXNlcnMud3JpdGWC0+STAh46ASoiGS9hcGkvdjEvdXNlcnMve2lkfS91bmxvY2sSjQEKC1ZlcmlmeUVtYWlsEiMuZ29zZXJ2ZXIuYXBpLnYxLlZlcmlmeUVtYWlsUmVxdWVzdBokLmdvc2VydmVyLmFwaS52MS5WZXJpZnlFbWFpbFJlc3BvbnNlIjPaQQV0b2tlboq1GAIIAYLT5JMCHzoBKiIaL2FwaS92MS91c2Vycy9lbWFpbC92ZXJpZnkSrwEKElJlc2VuZFZlcmlmaWNhdGlvbhIqLmdvc2VydmVyLmFwaS52MS5SZXNlbmRWZXJpZmljYXRpb25SZXF1ZXN0GisuZ29zZXJ2ZXIuYXBpLnYxLlJlc2VuZFZlcmlmaWNhdGlvblJlc3BvbnNlIkDaQQVlbWFpbIq1GAIIAYLT5JMCLDoBKiInL2FwaS92MS91c2Vycy9lbWFpbC9yZXNlbmQtdmVyaWZpY2F0aW9uQrcBChNjb20uZ29zZXJ2ZXIuYXBpLnYxQhBVc2VyU2VydmljZVByb3RvUAFaMGdpdGh1Yi5jb20vcGl4Yi9nby1zZXJ2ZXIvcHJvdG8vZ2VuL2FwaS92MTthcGl2MaICA0dBWKoCD0dvc2VydmVyLkFwaS5WMcoCD0dvc2VydmVyXEFwaVxWMeICG0dvc2VydmVyXEFwaVxWMVxHUEJNZXRhZGF0YeoCEUdvc2VydmVyOjpBcGk6OlYxYgZwcm90bzM", [file_google_api_annotations, file_google_api_client, file_google_api_field_behavior, file_google_protobuf_timestamp, file_api_v1_common, file_api_v1_options]);
 
 /**
  * @generated from message goserver.api.v1.RegisterUserRequest
@@ -47,6 +48,14 @@ export type RegisterUserRequest = Message<"goserver.api.v1.RegisterUserRequest">
    * @generated from field: string email = 5;
    */
   email: string;
+
+  /**
+   * An invite code from AdminService.CreateInvite. It is required when the registration
+   * policy is invite-only and grants the role of the invite.
+   *
+   * @generated from field: string invite_code = 6;
+   */
+  inviteCode: string;
 };
 
 /**
@@ -153,6 +162,13 @@ export type UpdateUserProfileResponse = Message<"goserver.api.v1.UpdateUserProfi
    * @generated from field: goserver.api.v1.User user = 1;
    */
   user?: User;
+
+  /**
+   * 待验证的新邮箱，验证前 user.email 仍为原邮箱
+   *
+   * @generated from field: string pending_email = 2;
+   */
+  pendingEmail: string;
 };
 
 /**
@@ -201,6 +217,498 @@ export type ChangePasswordResponse = Message<"goserver.api.v1.ChangePasswordResp
 export const ChangePasswordResponseSchema: GenMessage<ChangePasswordResponse> = /*@__PURE__*/
   messageDesc(file_api_v1_user_service, 7);
 
+/**
+ * 个人访问令牌，令牌明文只在创建时返回一次
+ *
+ * @generated from message goserver.api.v1.PersonalAccessToken
+ */
+export type PersonalAccessToken = Message<"goserver.api.v1.PersonalAccessToken"> & {
+  /**
+   * @generated from field: int64 id = 1;
+   */
+  id: bigint;
+
+  /**
+   * @generated from field: string description = 2;
+   */
+  description: string;
+
+  /**
+   * 为空表示永不过期
+   *
+   * @generated from field: google.protobuf.Timestamp expires_at = 3;
+   */
+  expiresAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp last_used_at = 4;
+   */
+  lastUsedAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 5;
+   */
+  createdAt?: Timestamp;
+};
+
+/**
+ * Describes the message goserver.api.v1.PersonalAccessToken.
+ * Use `create(PersonalAccessTokenSchema)` to create a new message.
+ */
+export const PersonalAccessTokenSchema: GenMessage<PersonalAccessToken> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 8);
+
+/**
+ * @generated from message goserver.api.v1.CreatePersonalAccessTokenRequest
+ */
+export type CreatePersonalAccessTokenRequest = Message<"goserver.api.v1.CreatePersonalAccessTokenRequest"> & {
+  /**
+   * @generated from field: string description = 1;
+   */
+  description: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp expires_at = 2;
+   */
+  expiresAt?: Timestamp;
+};
+
+/**
+ * Describes the message goserver.api.v1.CreatePersonalAccessTokenRequest.
+ * Use `create(CreatePersonalAccessTokenRequestSchema)` to create a new message.
+ */
+export const CreatePersonalAccessTokenRequestSchema: GenMessage<CreatePersonalAccessTokenRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 9);
+
+/**
+ * @generated from message goserver.api.v1.CreatePersonalAccessTokenResponse
+ */
+export type CreatePersonalAccessTokenResponse = Message<"goserver.api.v1.CreatePersonalAccessTokenResponse"> & {
+  /**
+   * @generated from field: goserver.api.v1.PersonalAccessToken personal_access_token = 1;
+   */
+  personalAccessToken?: PersonalAccessToken;
+
+  /**
+   * 令牌明文，以 pat_ 开头
+   *
+   * @generated from field: string token = 2;
+   */
+  token: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.CreatePersonalAccessTokenResponse.
+ * Use `create(CreatePersonalAccessTokenResponseSchema)` to create a new message.
+ */
+export const CreatePersonalAccessTokenResponseSchema: GenMessage<CreatePersonalAccessTokenResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 10);
+
+/**
+ * @generated from message goserver.api.v1.ListPersonalAccessTokensRequest
+ */
+export type ListPersonalAccessTokensRequest = Message<"goserver.api.v1.ListPersonalAccessTokensRequest"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.ListPersonalAccessTokensRequest.
+ * Use `create(ListPersonalAccessTokensRequestSchema)` to create a new message.
+ */
+export const ListPersonalAccessTokensRequestSchema: GenMessage<ListPersonalAccessTokensRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 11);
+
+/**
+ * @generated from message goserver.api.v1.ListPersonalAccessTokensResponse
+ */
+export type ListPersonalAccessTokensResponse = Message<"goserver.api.v1.ListPersonalAccessTokensResponse"> & {
+  /**
+   * @generated from field: repeated goserver.api.v1.PersonalAccessToken personal_access_tokens = 1;
+   */
+  personalAccessTokens: PersonalAccessToken[];
+};
+
+/**
+ * Describes the message goserver.api.v1.ListPersonalAccessTokensResponse.
+ * Use `create(ListPersonalAccessTokensResponseSchema)` to create a new message.
+ */
+export const ListPersonalAccessTokensResponseSchema: GenMessage<ListPersonalAccessTokensResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 12);
+
+/**
+ * @generated from message goserver.api.v1.DeletePersonalAccessTokenRequest
+ */
+export type DeletePersonalAccessTokenRequest = Message<"goserver.api.v1.DeletePersonalAccessTokenRequest"> & {
+  /**
+   * @generated from field: int64 id = 1;
+   */
+  id: bigint;
+};
+
+/**
+ * Describes the message goserver.api.v1.DeletePersonalAccessTokenRequest.
+ * Use `create(DeletePersonalAccessTokenRequestSchema)` to create a new message.
+ */
+export const DeletePersonalAccessTokenRequestSchema: GenMessage<DeletePersonalAccessTokenRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 13);
+
+/**
+ * @generated from message goserver.api.v1.DeletePersonalAccessTokenResponse
+ */
+export type DeletePersonalAccessTokenResponse = Message<"goserver.api.v1.DeletePersonalAccessTokenResponse"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.DeletePersonalAccessTokenResponse.
+ * Use `create(DeletePersonalAccessTokenResponseSchema)` to create a new message.
+ */
+export const DeletePersonalAccessTokenResponseSchema: GenMessage<DeletePersonalAccessTokenResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 14);
+
+/**
+ * 登录会话，每次登录产生一个会话，刷新令牌时会话保持不变
+ *
+ * @generated from message goserver.api.v1.Session
+ */
+export type Session = Message<"goserver.api.v1.Session"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string user_agent = 2;
+   */
+  userAgent: string;
+
+  /**
+   * @generated from field: string ip_address = 3;
+   */
+  ipAddress: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 4;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * 最近一次登录或刷新令牌的时间
+   *
+   * @generated from field: google.protobuf.Timestamp last_used_at = 5;
+   */
+  lastUsedAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp expires_at = 6;
+   */
+  expiresAt?: Timestamp;
+
+  /**
+   * 是否为发起本次请求的会话
+   *
+   * @generated from field: bool current = 7;
+   */
+  current: boolean;
+};
+
+/**
+ * Describes the message goserver.api.v1.Session.
+ * Use `create(SessionSchema)` to create a new message.
+ */
+export const SessionSchema: GenMessage<Session> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 15);
+
+/**
+ * @generated from message goserver.api.v1.ListSessionsRequest
+ */
+export type ListSessionsRequest = Message<"goserver.api.v1.ListSessionsRequest"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.ListSessionsRequest.
+ * Use `create(ListSessionsRequestSchema)` to create a new message.
+ */
+export const ListSessionsRequestSchema: GenMessage<ListSessionsRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 16);
+
+/**
+ * @generated from message goserver.api.v1.ListSessionsResponse
+ */
+export type ListSessionsResponse = Message<"goserver.api.v1.ListSessionsResponse"> & {
+  /**
+   * @generated from field: repeated goserver.api.v1.Session sessions = 1;
+   */
+  sessions: Session[];
+};
+
+/**
+ * Describes the message goserver.api.v1.ListSessionsResponse.
+ * Use `create(ListSessionsResponseSchema)` to create a new message.
+ */
+export const ListSessionsResponseSchema: GenMessage<ListSessionsResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 17);
+
+/**
+ * @generated from message goserver.api.v1.RevokeSessionRequest
+ */
+export type RevokeSessionRequest = Message<"goserver.api.v1.RevokeSessionRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.RevokeSessionRequest.
+ * Use `create(RevokeSessionRequestSchema)` to create a new message.
+ */
+export const RevokeSessionRequestSchema: GenMessage<RevokeSessionRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 18);
+
+/**
+ * @generated from message goserver.api.v1.RevokeSessionResponse
+ */
+export type RevokeSessionResponse = Message<"goserver.api.v1.RevokeSessionResponse"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.RevokeSessionResponse.
+ * Use `create(RevokeSessionResponseSchema)` to create a new message.
+ */
+export const RevokeSessionResponseSchema: GenMessage<RevokeSessionResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 19);
+
+/**
+ * @generated from message goserver.api.v1.RevokeAllOtherSessionsRequest
+ */
+export type RevokeAllOtherSessionsRequest = Message<"goserver.api.v1.RevokeAllOtherSessionsRequest"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.RevokeAllOtherSessionsRequest.
+ * Use `create(RevokeAllOtherSessionsRequestSchema)` to create a new message.
+ */
+export const RevokeAllOtherSessionsRequestSchema: GenMessage<RevokeAllOtherSessionsRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 20);
+
+/**
+ * @generated from message goserver.api.v1.RevokeAllOtherSessionsResponse
+ */
+export type RevokeAllOtherSessionsResponse = Message<"goserver.api.v1.RevokeAllOtherSessionsResponse"> & {
+  /**
+   * @generated from field: int32 revoked_count = 1;
+   */
+  revokedCount: number;
+};
+
+/**
+ * Describes the message goserver.api.v1.RevokeAllOtherSessionsResponse.
+ * Use `create(RevokeAllOtherSessionsResponseSchema)` to create a new message.
+ */
+export const RevokeAllOtherSessionsResponseSchema: GenMessage<RevokeAllOtherSessionsResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 21);
+
+/**
+ * @generated from message goserver.api.v1.EnrollTOTPRequest
+ */
+export type EnrollTOTPRequest = Message<"goserver.api.v1.EnrollTOTPRequest"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.EnrollTOTPRequest.
+ * Use `create(EnrollTOTPRequestSchema)` to create a new message.
+ */
+export const EnrollTOTPRequestSchema: GenMessage<EnrollTOTPRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 22);
+
+/**
+ * @generated from message goserver.api.v1.EnrollTOTPResponse
+ */
+export type EnrollTOTPResponse = Message<"goserver.api.v1.EnrollTOTPResponse"> & {
+  /**
+   * Base32 编码的 TOTP 密钥，用于手动输入验证器
+   *
+   * @generated from field: string secret = 1;
+   */
+  secret: string;
+
+  /**
+   * otpauth:// 格式的 URI，用于生成二维码
+   *
+   * @generated from field: string otpauth_uri = 2;
+   */
+  otpauthUri: string;
+
+  /**
+   * 一次性恢复码，只在绑定时返回一次
+   *
+   * @generated from field: repeated string recovery_codes = 3;
+   */
+  recoveryCodes: string[];
+};
+
+/**
+ * Describes the message goserver.api.v1.EnrollTOTPResponse.
+ * Use `create(EnrollTOTPResponseSchema)` to create a new message.
+ */
+export const EnrollTOTPResponseSchema: GenMessage<EnrollTOTPResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 23);
+
+/**
+ * @generated from message goserver.api.v1.ConfirmTOTPRequest
+ */
+export type ConfirmTOTPRequest = Message<"goserver.api.v1.ConfirmTOTPRequest"> & {
+  /**
+   * @generated from field: string code = 1;
+   */
+  code: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.ConfirmTOTPRequest.
+ * Use `create(ConfirmTOTPRequestSchema)` to create a new message.
+ */
+export const ConfirmTOTPRequestSchema: GenMessage<ConfirmTOTPRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 24);
+
+/**
+ * @generated from message goserver.api.v1.ConfirmTOTPResponse
+ */
+export type ConfirmTOTPResponse = Message<"goserver.api.v1.ConfirmTOTPResponse"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.ConfirmTOTPResponse.
+ * Use `create(ConfirmTOTPResponseSchema)` to create a new message.
+ */
+export const ConfirmTOTPResponseSchema: GenMessage<ConfirmTOTPResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 25);
+
+/**
+ * @generated from message goserver.api.v1.DisableTOTPRequest
+ */
+export type DisableTOTPRequest = Message<"goserver.api.v1.DisableTOTPRequest"> & {
+  /**
+   * @generated from field: string password = 1;
+   */
+  password: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.DisableTOTPRequest.
+ * Use `create(DisableTOTPRequestSchema)` to create a new message.
+ */
+export const DisableTOTPRequestSchema: GenMessage<DisableTOTPRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 26);
+
+/**
+ * @generated from message goserver.api.v1.DisableTOTPResponse
+ */
+export type DisableTOTPResponse = Message<"goserver.api.v1.DisableTOTPResponse"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.DisableTOTPResponse.
+ * Use `create(DisableTOTPResponseSchema)` to create a new message.
+ */
+export const DisableTOTPResponseSchema: GenMessage<DisableTOTPResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 27);
+
+/**
+ * @generated from message goserver.api.v1.UnlockUserRequest
+ */
+export type UnlockUserRequest = Message<"goserver.api.v1.UnlockUserRequest"> & {
+  /**
+   * @generated from field: int64 id = 1;
+   */
+  id: bigint;
+};
+
+/**
+ * Describes the message goserver.api.v1.UnlockUserRequest.
+ * Use `create(UnlockUserRequestSchema)` to create a new message.
+ */
+export const UnlockUserRequestSchema: GenMessage<UnlockUserRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 28);
+
+/**
+ * @generated from message goserver.api.v1.UnlockUserResponse
+ */
+export type UnlockUserResponse = Message<"goserver.api.v1.UnlockUserResponse"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.UnlockUserResponse.
+ * Use `create(UnlockUserResponseSchema)` to create a new message.
+ */
+export const UnlockUserResponseSchema: GenMessage<UnlockUserResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 29);
+
+/**
+ * @generated from message goserver.api.v1.VerifyEmailRequest
+ */
+export type VerifyEmailRequest = Message<"goserver.api.v1.VerifyEmailRequest"> & {
+  /**
+   * @generated from field: string token = 1;
+   */
+  token: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.VerifyEmailRequest.
+ * Use `create(VerifyEmailRequestSchema)` to create a new message.
+ */
+export const VerifyEmailRequestSchema: GenMessage<VerifyEmailRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 30);
+
+/**
+ * @generated from message goserver.api.v1.VerifyEmailResponse
+ */
+export type VerifyEmailResponse = Message<"goserver.api.v1.VerifyEmailResponse"> & {
+  /**
+   * @generated from field: goserver.api.v1.User user = 1;
+   */
+  user?: User;
+};
+
+/**
+ * Describes the message goserver.api.v1.VerifyEmailResponse.
+ * Use `create(VerifyEmailResponseSchema)` to create a new message.
+ */
+export const VerifyEmailResponseSchema: GenMessage<VerifyEmailResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 31);
+
+/**
+ * @generated from message goserver.api.v1.ResendVerificationRequest
+ */
+export type ResendVerificationRequest = Message<"goserver.api.v1.ResendVerificationRequest"> & {
+  /**
+   * 未验证的邮箱，或待验证的新邮箱
+   *
+   * @generated from field: string email = 1;
+   */
+  email: string;
+};
+
+/**
+ * Describes the message goserver.api.v1.ResendVerificationRequest.
+ * Use `create(ResendVerificationRequestSchema)` to create a new message.
+ */
+export const ResendVerificationRequestSchema: GenMessage<ResendVerificationRequest> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 32);
+
+/**
+ * @generated from message goserver.api.v1.ResendVerificationResponse
+ */
+export type ResendVerificationResponse = Message<"goserver.api.v1.ResendVerificationResponse"> & {
+};
+
+/**
+ * Describes the message goserver.api.v1.ResendVerificationResponse.
+ * Use `create(ResendVerificationResponseSchema)` to create a new message.
+ */
+export const ResendVerificationResponseSchema: GenMessage<ResendVerificationResponse> = /*@__PURE__*/
+  messageDesc(file_api_v1_user_service, 33);
+
 /**
  * @generated from service goserver.api.v1.UserService
  */
@@ -245,6 +753,126 @@ export const UserService: GenService<{
     input: typeof ChangePasswordRequestSchema;
     output: typeof ChangePasswordResponseSchema;
   },
+  /**
+   * 创建个人访问令牌
+   *
+   * @generated from rpc goserver.api.v1.UserService.CreatePersonalAccessToken
+   */
+  createPersonalAccessToken: {
+    methodKind: "unary";
+    input: typeof CreatePersonalAccessTokenRequestSchema;
+    output: typeof CreatePersonalAccessTokenResponseSchema;
+  },
+  /**
+   * 获取个人访问令牌列表
+   *
+   * @generated from rpc goserver.api.v1.UserService.ListPersonalAccessTokens
+   */
+  listPersonalAccessTokens: {
+    methodKind: "unary";
+    input: typeof ListPersonalAccessTokensRequestSchema;
+    output: typeof ListPersonalAccessTokensResponseSchema;
+  },
+  /**
+   * 删除个人访问令牌
+   *
+   * @generated from rpc goserver.api.v1.UserService.DeletePersonalAccessToken
+   */
+  deletePersonalAccessToken: {
+    methodKind: "unary";
+    input: typeof DeletePersonalAccessTokenRequestSchema;
+    output: typeof DeletePersonalAccessTokenResponseSchema;
+  },
+  /**
+   * 获取当前用户的登录会话列表
+   *
+   * @generated from rpc goserver.api.v1.UserService.ListSessions
+   */
+  listSessions: {
+    methodKind: "unary";
+    input: typeof ListSessionsRequestSchema;
+    output: typeof ListSessionsResponseSchema;
+  },
+  /**
+   * 注销指定会话
+   *
+   * @generated from rpc goserver.api.v1.UserService.RevokeSession
+   */
+  revokeSession: {
+    methodKind: "unary";
+    input: typeof RevokeSessionRequestSchema;
+    output: typeof RevokeSessionResponseSchema;
+  },
+  /**
+   * 注销除当前会话外的所有会话
+   *
+   * @generated from rpc goserver.api.v1.UserService.RevokeAllOtherSessions
+   */
+  revokeAllOtherSessions: {
+    methodKind: "unary";
+    input: typeof RevokeAllOtherSessionsRequestSchema;
+    output: typeof RevokeAllOtherSessionsResponseSchema;
+  },
+  /**
+   * 开始绑定 TOTP 两步验证，返回密钥和恢复码，需调用 ConfirmTOTP 确认后生效
+   *
+   * @generated from rpc goserver.api.v1.UserService.EnrollTOTP
+   */
+  enrollTOTP: {
+    methodKind: "unary";
+    input: typeof EnrollTOTPRequestSchema;
+    output: typeof EnrollTOTPResponseSchema;
+  },
+  /**
+   * 使用验证器生成的验证码确认绑定 TOTP 两步验证
+   *
+   * @generated from rpc goserver.api.v1.UserService.ConfirmTOTP
+   */
+  confirmTOTP: {
+    methodKind: "unary";
+    input: typeof ConfirmTOTPRequestSchema;
+    output: typeof ConfirmTOTPResponseSchema;
+  },
+  /**
+   * 关闭 TOTP 两步验证
+   *
+   * @generated from rpc goserver.api.v1.UserService.DisableTOTP
+   */
+  disableTOTP: {
+    methodKind: "unary";
+    input: typeof DisableTOTPRequestSchema;
+    output: typeof DisableTOTPResponseSchema;
+  },
+  /**
+   * 解除因多次登录失败而被锁定的账户，需要 users.write 权限
+   *
+   * @generated from rpc goserver.api.v1.UserService.UnlockUser
+   */
+  unlockUser: {
+    methodKind: "unary";
+    input: typeof UnlockUserRequestSchema;
+    output: typeof UnlockUserResponseSchema;
+  },
+  /**
+   * 使用邮件中的令牌验证邮箱，邮箱变更在验证后才生效
+   *
+   * @generated from rpc goserver.api.v1.UserService.VerifyEmail
+   */
+  verifyEmail: {
+    methodKind: "unary";
+    input: typeof VerifyEmailRequestSchema;
+    output: typeof VerifyEmailResponseSchema;
+  },
+  /**
+   * 重新发送验证邮件，无论邮箱是否存在都返回相同结果
+   *
+   * @generated from rpc goserver.api.v1.UserService.ResendVerification
+   */
+  resendVerification: {
+    methodKind: "unary";
+    input: typeof ResendVerificationRequestSchema;
+    output: typeof ResendVerificationResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_api_v1_user_service, 0);
 
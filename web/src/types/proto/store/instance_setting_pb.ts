@@ -4,13 +4,15 @@
 
 import type { GenEnum, GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
 import { enumDesc, fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
+import type { Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
 import type { Message } from "@bufbuild/protobuf";
 
 /**
  * Describes the file store/instance_setting.proto.
  */
 export const file_store_instance_setting: GenFile = /*@__PURE__*/
-  fileDesc("ChxzdG9yZS9pbnN0YW5jZV9zZXR0aW5nLnByb3RvEg5nb3NlcnZlci5zdG9yZSKKAQoPSW5zdGFuY2VTZXR0aW5nEi8KA2tleRgBIAEoDjIiLmdvc2VydmVyLnN0b3JlLkluc3RhbmNlU2V0dGluZ0tleRI9Cg1iYXNpY19zZXR0aW5nGAIgASgLMiQuZ29zZXJ2ZXIuc3RvcmUuSW5zdGFuY2VCYXNpY1NldHRpbmdIAEIHCgV2YWx1ZSJCChRJbnN0YW5jZUJhc2ljU2V0dGluZxISCgpzZWNyZXRfa2V5GAEgASgJEhYKDnNjaGVtYV92ZXJzaW9uGAIgASgJKkUKEkluc3RhbmNlU2V0dGluZ0tleRIkCiBJTlNUQU5DRV9TRVRUSU5HX0tFWV9VTlNQRUNJRklFRBAAEgkKBUJBU0lDEAFCrgEKEmNvbS5nb3NlcnZlci5zdG9yZUIUSW5zdGFuY2VTZXR0aW5nUHJvdG9QAVopZ2l0aHViLmNvbS9waXhiL2dvLXNlcnZlci9wcm90by9nZW4vc3RvcmWiAgNHU1iqAg5Hb3NlcnZlci5TdG9yZcoCDkdvc2VydmVyXFN0b3Jl4gIaR29zZXJ2ZXJcU3RvcmVcR1BCTWV0YWRhdGHqAg9Hb3NlcnZlcjo6U3RvcmViBnByb3RvMw");
+  fileDesc("ChxzdG9yZS9pbnN0YW5jZV9zZXR0aW5nLnByb3RvEg5nb3NlcnZlci5zdG9yZSLYBQoPSW5zdGFuY2VTZXR0aW5nEi8KA2tleRgBIAEoDjIiLmdvc2VydmVyLnN0b3JlLkluc3RhbmNlU2V0dGluZ0tleRI9Cg1iYXNpY19zZXR0aW5nGAIgASgLMiQuZ29zZXJ2ZXIuc3RvcmUuSW5zdGFuY2VCYXNpY1NldHRpbmdIABJPChdqd3Rfc2lnbmluZ19rZXlfc2V0dGluZxgDIAEoCzIsLmdvc2VydmVyLnN0b3JlLkluc3RhbmNlSldUU2lnbmluZ0tleVNldHRpbmdIABJDChBzZWN1cml0eV9zZXR0aW5nGAQgASgLMicuZ29zZXJ2ZXIuc3RvcmUuSW5zdGFuY2VTZWN1cml0eVNldHRpbmdIABJQChdwYXNzd29yZF9wb2xpY3lfc2V0dGluZxgFIAEoCzItLmdvc2VydmVyLnN0b3JlLkluc3RhbmNlUGFzc3dvcmRQb2xpY3lTZXR0aW5nSAASVAoZaWRlbnRpdHlfcHJvdmlkZXJfc2V0dGluZxgGIAEoCzIvLmdvc2VydmVyLnN0b3JlLkluc3RhbmNlSWRlbnRpdHlQcm92aWRlclNldHRpbmdIABJBCg9nZW5lcmFsX3NldHRpbmcYByABKAsyJi5nb3NlcnZlci5zdG9yZS5JbnN0YW5jZUdlbmVyYWxTZXR0aW5nSAASPQoNc2V0dXBfc2V0dGluZxgIIAEoCzIkLmdvc2VydmVyLnN0b3JlLkluc3RhbmNlU2V0dXBTZXR0aW5nSAASQQoPc3RvcmFnZV9zZXR0aW5nGAkgASgLMiYuZ29zZXJ2ZXIuc3RvcmUuSW5zdGFuY2VTdG9yYWdlU2V0dGluZ0gAEkkKE21haW50ZW5hbmNlX3NldHRpbmcYCiABKAsyKi5nb3NlcnZlci5zdG9yZS5JbnN0YW5jZU1haW50ZW5hbmNlU2V0dGluZ0gAQgcKBXZhbHVlIl8KFEluc3RhbmNlQmFzaWNTZXR0aW5nEhIKCnNlY3JldF9rZXkYASABKAkSFgoOc2NoZW1hX3ZlcnNpb24YAiABKAkSGwoTcHJldmlvdXNfc2VjcmV0X2tleRgDIAEoCSKJAQocSW5zdGFuY2VKV1RTaWduaW5nS2V5U2V0dGluZxIrCgRrZXlzGAEgAygLMh0uZ29zZXJ2ZXIuc3RvcmUuSldUU2lnbmluZ0tleRI8ChhsZWdhY3lfc2VjcmV0X2V4cGlyZXNfYXQYAiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIsMBCg1KV1RTaWduaW5nS2V5EgsKA2tpZBgBIAEoCRIRCglhbGdvcml0aG0YAiABKAkSEwoLcHJpdmF0ZV9rZXkYAyABKAkSLgoKY3JlYXRlZF9hdBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLgoKZXhwaXJlc19hdBgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASHQoVZW5jcnlwdGVkX3ByaXZhdGVfa2V5GAYgASgJItgCChdJbnN0YW5jZVNlY3VyaXR5U2V0dGluZxI9Cg9hY2NvdW50X2xvY2tvdXQYASABKAsyJC5nb3NlcnZlci5zdG9yZS5BY2NvdW50TG9ja291dFBvbGljeRIiChpyZXF1aXJlX2VtYWlsX3ZlcmlmaWNhdGlvbhgCIAEoCBI4CgxyZWdpc3RyYXRpb24YAyABKAsyIi5nb3NlcnZlci5zdG9yZS5SZWdpc3RyYXRpb25Qb2xpY3kSJQodYWNjZXNzX3Rva2VuX2xpZmV0aW1lX3NlY29uZHMYBCABKAUSJgoecmVmcmVzaF90b2tlbl9saWZldGltZV9zZWNvbmRzGAUgASgFEjMKCnJhdGVfbGltaXQYBiABKAsyHy5nb3NlcnZlci5zdG9yZS5SYXRlTGltaXRQb2xpY3kSHAoUY29yc19hbGxvd2VkX29yaWdpbnMYByADKAkiPQoPUmF0ZUxpbWl0UG9saWN5EhsKE3JlcXVlc3RzX3Blcl9zZWNvbmQYASABKAESDQoFYnVyc3QYAiABKAUizAEKElJlZ2lzdHJhdGlvblBvbGljeRI1CgRtb2RlGAEgASgOMicuZ29zZXJ2ZXIuc3RvcmUuUmVnaXN0cmF0aW9uUG9saWN5Lk1vZGUSHQoVYWxsb3dlZF9lbWFpbF9kb21haW5zGAIgAygJImAKBE1vZGUSFAoQTU9ERV9VTlNQRUNJRklFRBAAEggKBE9QRU4QARIMCghESVNBQkxFRBACEg8KC0lOVklURV9PTkxZEAMSGQoVQUxMT1dFRF9FTUFJTF9ET01BSU5TEAQixgEKFEFjY291bnRMb2Nrb3V0UG9saWN5EhwKFG1heF9hY2NvdW50X2ZhaWx1cmVzGAEgASgFEhcKD21heF9pcF9mYWlsdXJlcxgCIAEoBRIgChhsb2Nrb3V0X2R1cmF0aW9uX3NlY29uZHMYAyABKAUSHgoWZmFpbHVyZV93aW5kb3dfc2Vjb25kcxgEIAEoBRIaChJiYXNlX2RlbGF5X3NlY29uZHMYBSABKAUSGQoRbWF4X2RlbGF5X3NlY29uZHMYBiABKAUi5AEKHUluc3RhbmNlUGFzc3dvcmRQb2xpY3lTZXR0aW5nEhIKCm1pbl9sZW5ndGgYASABKAUSGQoRcmVxdWlyZV91cHBlcmNhc2UYAiABKAgSGQoRcmVxdWlyZV9sb3dlcmNhc2UYAyABKAgSFQoNcmVxdWlyZV9kaWdpdBgEIAEoCBIWCg5yZXF1aXJlX3N5bWJvbBgFIAEoCBIeChZhbGxvd19jb21tb25fcGFzc3dvcmRzGAYgASgIEhUKDWhpc3RvcnlfY291bnQYByABKAUSEwoLZXhwaXJ5X2RheXMYCCABKAUiVgofSW5zdGFuY2VJZGVudGl0eVByb3ZpZGVyU2V0dGluZxIzCglwcm92aWRlcnMYASADKAsyIC5nb3NlcnZlci5zdG9yZS5JZGVudGl0eVByb3ZpZGVyIrwBChBJZGVudGl0eVByb3ZpZGVyEgoKAmlkGAEgASgJEg0KBXRpdGxlGAIgASgJEg4KBmlzc3VlchgDIAEoCRIRCgljbGllbnRfaWQYBCABKAkSFQoNY2xpZW50X3NlY3JldBgFIAEoCRIOCgZzY29wZXMYBiADKAkSQwoNY2xhaW1fbWFwcGluZxgHIAEoCzIsLmdvc2VydmVyLnN0b3JlLklkZW50aXR5UHJvdmlkZXJDbGFpbU1hcHBpbmciUQocSWRlbnRpdHlQcm92aWRlckNsYWltTWFwcGluZxIQCgh1c2VybmFtZRgBIAEoCRIQCghuaWNrbmFtZRgCIAEoCRINCgVlbWFpbBgDIAEoCSJLChZJbnN0YW5jZUdlbmVyYWxTZXR0aW5nEgwKBG5hbWUYASABKAkSEwoLZGVzY3JpcHRpb24YAiABKAkSDgoGbG9jYWxlGAMgASgJIkYKFEluc3RhbmNlU2V0dXBTZXR0aW5nEi4KCnNldHVwX3RpbWUYASABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIp0CChZJbnN0YW5jZVN0b3JhZ2VTZXR0aW5nEkgKDHN0b3JhZ2VfdHlwZRgBIAEoDjIyLmdvc2VydmVyLnN0b3JlLkluc3RhbmNlU3RvcmFnZVNldHRpbmcuU3RvcmFnZVR5cGUSGQoRZmlsZXBhdGhfdGVtcGxhdGUYAiABKAkSHAoUdXBsb2FkX3NpemVfbGltaXRfbWIYAyABKAUSMgoJczNfY29uZmlnGAQgASgLMh8uZ29zZXJ2ZXIuc3RvcmUuU3RvcmFnZVMzQ29uZmlnIkwKC1N0b3JhZ2VUeXBlEhwKGFNUT1JBR0VfVFlQRV9VTlNQRUNJRklFRBAAEgwKCERBVEFCQVNFEAESCQoFTE9DQUwQAhIGCgJTMxADIo0BCg9TdG9yYWdlUzNDb25maWcSFQoNYWNjZXNzX2tleV9pZBgBIAEoCRIZChFhY2Nlc3Nfa2V5X3NlY3JldBgCIAEoCRIQCghlbmRwb2ludBgDIAEoCRIOCgZyZWdpb24YBCABKAkSDgoGYnVja2V0GAUgASgJEhYKDnVzZV9wYXRoX3N0eWxlGAYgASgIIsABChpJbnN0YW5jZU1haW50ZW5hbmNlU2V0dGluZxI9CgRtb2RlGAEgASgOMi8uZ29zZXJ2ZXIuc3RvcmUuSW5zdGFuY2VNYWludGVuYW5jZVNldHRpbmcuTW9kZRIPCgdtZXNzYWdlGAIgASgJEhsKE3JldHJ5X2FmdGVyX3NlY29uZHMYAyABKAUiNQoETW9kZRIUChBNT0RFX1VOU1BFQ0lGSUVEEAASDQoJUkVBRF9PTkxZEAESCAoERlVMTBACKswBChJJbnN0YW5jZVNldHRpbmdLZXkSJAogSU5TVEFOQ0VfU0VUVElOR19LRVlfVU5TUEVDSUZJRUQQABIJCgVCQVNJQxABEhQKEEpXVF9TSUdOSU5HX0tFWVMQAhIMCghTRUNVUklUWRADEhMKD1BBU1NXT1JEX1BPTElDWRAEEhYKEklERU5USVRZX1BST1ZJREVSUxAFEgsKB0dFTkVSQUwQBhIJCgVTRVRVUBAHEgsKB1NUT1JBR0UQCBIPCgtNQUlOVEVOQU5DRRAJQq4BChJjb20uZ29zZXJ2ZXIuc3RvcmVCFEluc3RhbmNlU2V0dGluZ1Byb3RvUAFaKWdpdGh1Yi5jb20vcGl4Yi9nby1zZXJ2ZXIvcHJvdG8vZ2VuL3N0b3JlogIDR1NYqgIOR29zZXJ2ZXIuU3RvcmXKAg5Hb3NlcnZlclxTdG9yZeICGkdvc2VydmVyXFN0b3JlXEdQQk1ldGFkYXRh6gIPR29zZXJ2ZXI6OlN0b3JlYgZwcm90bzM", [file_google_protobuf_timestamp]);
 
 /**
  * @generated from message goserver.store.InstanceSetting
@@ -30,6 +32,54 @@ export type InstanceSetting = Message<"goserver.store.InstanceSetting"> & {
      */
     value: InstanceBasicSetting;
     case: "basicSetting";
+  } | {
+    /**
+     * @generated from field: goserver.store.InstanceJWTSigningKeySetting jwt_signing_key_setting = 3;
+     */
+    value: InstanceJWTSigningKeySetting;
+    case: "jwtSigningKeySetting";
+  } | {
+    /**
+     * @generated from field: goserver.store.InstanceSecuritySetting security_setting = 4;
+     */
+    value: InstanceSecuritySetting;
+    case: "securitySetting";
+  } | {
+    /**
+     * @generated from field: goserver.store.InstancePasswordPolicySetting password_policy_setting = 5;
+     */
+    value: InstancePasswordPolicySetting;
+    case: "passwordPolicySetting";
+  } | {
+    /**
+     * @generated from field: goserver.store.InstanceIdentityProviderSetting identity_provider_setting = 6;
+     */
+    value: InstanceIdentityProviderSetting;
+    case: "identityProviderSetting";
+  } | {
+    /**
+     * @generated from field: goserver.store.InstanceGeneralSetting general_setting = 7;
+     */
+    value: InstanceGeneralSetting;
+    case: "generalSetting";
+  } | {
+    /**
+     * @generated from field: goserver.store.InstanceSetupSetting setup_setting = 8;
+     */
+    value: InstanceSetupSetting;
+    case: "setupSetting";
+  } | {
+    /**
+     * @generated from field: goserver.store.InstanceStorageSetting storage_setting = 9;
+     */
+    value: InstanceStorageSetting;
+    case: "storageSetting";
+  } | {
+    /**
+     * @generated from field: goserver.store.InstanceMaintenanceSetting maintenance_setting = 10;
+     */
+    value: InstanceMaintenanceSetting;
+    case: "maintenanceSetting";
   } | { case: undefined; value?: undefined };
 };
 
@@ -57,6 +107,15 @@ export type InstanceBasicSetting = Message<"goserver.store.InstanceBasicSetting"
    * @generated from field: string schema_version = 2;
    */
   schemaVersion: string;
+
+  /**
+   * The secret key replaced by the last rotation. Servers decrypt with it as well, until the
+   * rotation is finished by re-encrypting the two-factor secrets and signing keys with the
+   * current key.
+   *
+   * @generated from field: string previous_secret_key = 3;
+   */
+  previousSecretKey: string;
 };
 
 /**
@@ -66,6 +125,711 @@ export type InstanceBasicSetting = Message<"goserver.store.InstanceBasicSetting"
 export const InstanceBasicSettingSchema: GenMessage<InstanceBasicSetting> = /*@__PURE__*/
   messageDesc(file_store_instance_setting, 1);
 
+/**
+ * @generated from message goserver.store.InstanceJWTSigningKeySetting
+ */
+export type InstanceJWTSigningKeySetting = Message<"goserver.store.InstanceJWTSigningKeySetting"> & {
+  /**
+   * The signing keys, the active key is the one without expires_at.
+   *
+   * @generated from field: repeated goserver.store.JWTSigningKey keys = 1;
+   */
+  keys: JWTSigningKey[];
+
+  /**
+   * HS256 tokens signed with the instance secret are accepted until this time.
+   * It is set when the first signing key is generated.
+   *
+   * @generated from field: google.protobuf.Timestamp legacy_secret_expires_at = 2;
+   */
+  legacySecretExpiresAt?: Timestamp;
+};
+
+/**
+ * Describes the message goserver.store.InstanceJWTSigningKeySetting.
+ * Use `create(InstanceJWTSigningKeySettingSchema)` to create a new message.
+ */
+export const InstanceJWTSigningKeySettingSchema: GenMessage<InstanceJWTSigningKeySetting> = /*@__PURE__*/
+  messageDesc(file_store_instance_setting, 2);
+
+/**
+ * @generated from message goserver.store.JWTSigningKey
+ */
+export type JWTSigningKey = Message<"goserver.store.JWTSigningKey"> & {
+  /**
+   * The key id, sent as the kid header of tokens signed with this key.
+   *
+   * @generated from field: string kid = 1;
+   */
+  kid: string;
+
+  /**
+   * The JWS algorithm of the key, either RS256 or EdDSA.
+   *
+   * @generated from field: string algorithm = 2;
+   */
+  algorithm: string;
+
+  /**
+   * The PEM encoded PKCS #8 private key of keys stored before private keys were encrypted.
+   * It is moved to encrypted_private_key the next time the keys are saved.
+   *
+   * @generated from field: string private_key = 3;
+   */
+  privateKey: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 4;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * Set when the key is rotated out. Tokens signed with it are accepted until then.
+   *
+   * @generated from field: google.protobuf.Timestamp expires_at = 5;
+   */
+  expiresAt?: Timestamp;
+
+  /**
+   * The PEM encoded PKCS #8 private key, encrypted with AES-GCM using a key derived from
+   * the instance secret.
+   *
+   * @generated from field: string encrypted_private_key = 6;
+   */
+  encryptedPrivateKey: string;
+};
+
+/**
+ * Describes the message goserver.store.JWTSigningKey.
+ * Use `create(JWTSigningKeySchema)` to create a new message.
+ */
+export const JWTSigningKeySchema: GenMessage<JWTSigningKey> = /*@__PURE__*/
+  messageDesc(file_store_instance_setting, 3);
+
+/**
+ * @generated from message goserver.store.InstanceSecuritySetting
+ */
+export type InstanceSecuritySetting = Message<"goserver.store.InstanceSecuritySetting"> & {
+  /**
+   * @generated from field: goserver.store.AccountLockoutPolicy account_lockout = 1;
+   */
+  accountLockout?: AccountLockoutPolicy;
+
+  /**
+   * Refuses sign-in until the user has verified their email address.
+   *
+   * @generated from field: bool require_email_verification = 2;
+   */
+  requireEmailVerification: boolean;
+
+  /**
+   * @generated from field: goserver.store.RegistrationPolicy registration = 3;
+   */
+  registration?: RegistrationPolicy;
+
+  /**
+   * The lifetime of access tokens issued at sign-in, 24 hours by default and at most.
+   *
+   * @generated from field: int32 access_token_lifetime_seconds = 4;
+   */
+  accessTokenLifetimeSeconds: number;
+
+  /**
+   * The lifetime of refresh tokens, 7 days by default.
+   *
+   * @generated from field: int32 refresh_token_lifetime_seconds = 5;
+   */
+  refreshTokenLifetimeSeconds: number;
+
+  /**
+   * Limits HTTP requests per client address. Requests are not limited when unset.
+   *
+   * @generated from field: goserver.store.RateLimitPolicy rate_limit = 6;
+   */
+  rateLimit?: RateLimitPolicy;
+
+  /**
+   * Origins allowed to make cross-origin requests, such as "https://app.example.com".
+   * Any origin is allowed when empty.
+   *
+   * @generated from field: repeated string cors_allowed_origins = 7;
+   */
+  corsAllowedOrigins: string[];
+};
+
+/**
+ * Describes the message goserver.store.InstanceSecuritySetting.
+ * Use `create(InstanceSecuritySettingSchema)` to create a new message.
+ */
+export const InstanceSecuritySettingSchema: GenMessage<InstanceSecuritySetting> = /*@__PURE__*/
+  messageDesc(file_store_instance_setting, 4);
+
+/**
+ * RateLimitPolicy is a token bucket per client address.
+ *
+ * @generated from message goserver.store.RateLimitPolicy
+ */
+export type RateLimitPolicy = Message<"goserver.store.RateLimitPolicy"> & {
+  /**
+   * @generated from field: double requests_per_second = 1;
+   */
+  requestsPerSecond: number;
+
+  /**
+   * The number of requests allowed at once, requests_per_second rounded up when 0.
+   *
+   * @generated from field: int32 burst = 2;
+   */
+  burst: number;
+};
+
+/**
+ * Describes the message goserver.store.RateLimitPolicy.
+ * Use `create(RateLimitPolicySchema)` to create a new message.
+ */
+export const RateLimitPolicySchema: GenMessage<RateLimitPolicy> = /*@__PURE__*/
+  messageDesc(file_store_instance_setting, 5);
+
+/**
+ * RegistrationPolicy decides who can sign up with UserService.RegisterUser.
+ *
+ * @generated from message goserver.store.RegistrationPolicy
+ */
+export type RegistrationPolicy = Message<"goserver.store.RegistrationPolicy"> & {
+  /**
+   * @generated from field: goserver.store.RegistrationPolicy.Mode mode = 1;
+   */
+  mode: RegistrationPolicy_Mode;
+
+  /**
+   * The domains accepted in ALLOWED_EMAIL_DOMAINS mode, such as "example.com".
+   * Subdomains are not included.
+   *
+   * @generated from field: repeated string allowed_email_domains = 2;
+   */
+  allowedEmailDomains: string[];
+};
+
+/**
+ * Describes the message goserver.store.RegistrationPolicy.
+ * Use `create(RegistrationPolicySchema)` to create a new message.
+ */
+export const RegistrationPolicySchema: GenMessage<RegistrationPolicy> = /*@__PURE__*/
+  messageDesc(file_store_instance_setting, 6);
+
+/**
+ * @generated from enum goserver.store.RegistrationPolicy.Mode
+ */
+export enum RegistrationPolicy_Mode {
+  /**
+   * Anyone can register, the default.
+   *
+   * @generated from enum value: MODE_UNSPECIFIED = 0;
+   */
+  MODE_UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: OPEN = 1;
+   */
+  OPEN = 1,
+
+  /**
+   * Nobody can register, not even with an invite.
+   *
+   * @generated from enum value: DISABLED = 2;
+   */
+  DISABLED = 2,
+
+  /**
+   * Only holders of an invite code can register.
+   *
+   * @generated from enum value: INVITE_ONLY = 3;
+   */
+  INVITE_ONLY = 3,
+
+  /**
+   * Only addresses of allowed_email_domains or holders of an invite code can register.
+   * Users then have to verify their email address before signing in, and identity
+   * providers have to vouch for the addresses of the users they sign in.
+   *
+   * @generated from enum value: ALLOWED_EMAIL_DOMAINS = 4;
+   */
+  ALLOWED_EMAIL_DOMAINS = 4,
+}
+
+/**
+ * Describes the enum goserver.store.RegistrationPolicy.Mode.
+ */
+export const RegistrationPolicy_ModeSchema: GenEnum<RegistrationPolicy_Mode> = /*@__PURE__*/
+  enumDesc(file_store_instance_setting, 6, 0);
+
+/**
+ * AccountLockoutPolicy limits failed sign-in attempts. Zero values fall back to the defaults.
+ *
+ * @generated from message goserver.store.AccountLockoutPolicy
+ */
+export type AccountLockoutPolicy = Message<"goserver.store.AccountLockoutPolicy"> & {
+  /**
+   * The failed attempts for one account after which it is locked.
+   *
+   * @generated from field: int32 max_account_failures = 1;
+   */
+  maxAccountFailures: number;
+
+  /**
+   * The failed attempts from one IP address after which it is locked.
+   *
+   * @generated from field: int32 max_ip_failures = 2;
+   */
+  maxIpFailures: number;
+
+  /**
+   * How long an account or IP address stays locked.
+   *
+   * @generated from field: int32 lockout_duration_seconds = 3;
+   */
+  lockoutDurationSeconds: number;
+
+  /**
+   * Failed attempts older than this are forgotten.
+   *
+   * @generated from field: int32 failure_window_seconds = 4;
+   */
+  failureWindowSeconds: number;
+
+  /**
+   * The delay after the first failed attempt for an account, doubled with every further one.
+   *
+   * @generated from field: int32 base_delay_seconds = 5;
+   */
+  baseDelaySeconds: number;
+
+  /**
+   * The upper bound of the delay between attempts.
+   *
+   * @generated from field: int32 max_delay_seconds = 6;
+   */
+  maxDelaySeconds: number;
+};
+
+/**
+ * Describes the message goserver.store.AccountLockoutPolicy.
+ * Use `create(AccountLockoutPolicySchema)` to create a new message.
+ */
+export const AccountLockoutPolicySchema: GenMessage<AccountLockoutPolicy> = /*@__PURE__*/
+  messageDesc(file_store_instance_setting, 7);
+
+/**
+ * InstancePasswordPolicySetting are the rules new passwords must satisfy.
+ * Zero values fall back to the defaults.
+ *
+ * @generated from message goserver.store.InstancePasswordPolicySetting
+ */
+export type InstancePasswordPolicySetting = Message<"goserver.store.InstancePasswordPolicySetting"> & {
+  /**
+   * The minimum length in characters, 8 by default.
+   *
+   * @generated from field: int32 min_length = 1;
+   */
+  minLength: number;
+
+  /**
+   * @generated from field: bool require_uppercase = 2;
+   */
+  requireUppercase: boolean;
+
+  /**
+   * @generated from field: bool require_lowercase = 3;
+   */
+  requireLowercase: boolean;
+
+  /**
+   * @generated from field: bool require_digit = 4;
+   */
+  requireDigit: boolean;
+
+  /**
+   * @generated from field: bool require_symbol = 5;
+   */
+  requireSymbol: boolean;
+
+  /**
+   * Allows passwords from the built-in list of common and breached passwords.
+   *
+   * @generated from field: bool allow_common_passwords = 6;
+   */
+  allowCommonPasswords: boolean;
+
+  /**
+   * The number of most recent passwords, including the current one, that cannot be reused.
+   *
+   * @generated from field: int32 history_count = 7;
+   */
+  historyCount: number;
+
+  /**
+   * The number of days after which a password expires, 90 by default.
+   * A negative value disables expiry.
+   *
+   * @generated from field: int32 expiry_days = 8;
+   */
+  expiryDays: number;
+};
+
+/**
+ * Describes the message goserver.store.InstancePasswordPolicySetting.
+ * Use `create(InstancePasswordPolicySettingSchema)` to create a new message.
+ */
+export const InstancePasswordPolicySettingSchema: GenMessage<InstancePasswordPolicySetting> = /*@__PURE__*/
+  messageDesc(file_store_instance_setting, 8);
+
+/**
+ * @generated from message goserver.store.InstanceIdentityProviderSetting
+ */
+export type InstanceIdentityProviderSetting = Message<"goserver.store.InstanceIdentityProviderSetting"> & {
+  /**
+   * @generated from field: repeated goserver.store.IdentityProvider providers = 1;
+   */
+  providers: IdentityProvider[];
+};
+
+/**
+ * Describes the message goserver.store.InstanceIdentityProviderSetting.
+ * Use `create(InstanceIdentityProviderSettingSchema)` to create a new message.
+ */
+export const InstanceIdentityProviderSettingSchema: GenMessage<InstanceIdentityProviderSetting> = /*@__PURE__*/
+  messageDesc(file_store_instance_setting, 9);
+
+/**
+ * IdentityProvider is an OpenID Connect provider users sign in with through the
+ * authorization code flow with PKCE.
+ *
+ * @generated from message goserver.store.IdentityProvider
+ */
+export type IdentityProvider = Message<"goserver.store.IdentityProvider"> & {
+  /**
+   * The identifier used in the sign-in URLs and stored with the linked identities.
+   *
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * The name shown on the sign-in page.
+   *
+   * @generated from field: string title = 2;
+   */
+  title: string;
+
+  /**
+   * The issuer URL, the provider configuration is discovered from
+   * {issuer}/.well-known/openid-configuration.
+   *
+   * @generated from field: string issuer = 3;
+   */
+  issuer: string;
+
+  /**
+   * @generated from field: string client_id = 4;
+   */
+  clientId: string;
+
+  /**
+   * Empty for public clients.
+   *
+   * @generated from field: string client_secret = 5;
+   */
+  clientSecret: string;
+
+  /**
+   * The scopes requested in addition to openid, "profile" and "email" by default.
+   *
+   * @generated from field: repeated string scopes = 6;
+   */
+  scopes: string[];
+
+  /**
+   * @generated from field: goserver.store.IdentityProviderClaimMapping claim_mapping = 7;
+   */
+  claimMapping?: IdentityProviderClaimMapping;
+};
+
+/**
+ * Describes the message goserver.store.IdentityProvider.
+ * Use `create(IdentityProviderSchema)` to create a new message.
+ */
+export const IdentityProviderSchema: GenMessage<IdentityProvider> = /*@__PURE__*/
+  messageDesc(file_store_instance_setting, 10);
+
+/**
+ * IdentityProviderClaimMapping names the ID token claims the fields of new users are taken from.
+ * Empty values fall back to the standard claims.
+ *
+ * @generated from message goserver.store.IdentityProviderClaimMapping
+ */
+export type IdentityProviderClaimMapping = Message<"goserver.store.IdentityProviderClaimMapping"> & {
+  /**
+   * The claim holding the username, "preferred_username" by default.
+   *
+   * @generated from field: string username = 1;
+   */
+  username: string;
+
+  /**
+   * The claim holding the nickname, "name" by default.
+   *
+   * @generated from field: string nickname = 2;
+   */
+  nickname: string;
+
+  /**
+   * The claim holding the email address, "email" by default.
+   *
+   * @generated from field: string email = 3;
+   */
+  email: string;
+};
+
+/**
+ * Describes the message goserver.store.IdentityProviderClaimMapping.
+ * Use `create(IdentityProviderClaimMappingSchema)` to create a new message.
+ */
+export const IdentityProviderClaimMappingSchema: GenMessage<IdentityProviderClaimMapping> = /*@__PURE__*/
+  messageDesc(file_store_instance_setting, 11);
+
+/**
+ * @generated from message goserver.store.InstanceGeneralSetting
+ */
+export type InstanceGeneralSetting = Message<"goserver.store.InstanceGeneralSetting"> & {
+  /**
+   * The name of the instance shown to users.
+   *
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string description = 2;
+   */
+  description: string;
+
+  /**
+   * The default locale of the web app as a BCP 47 language tag, such as "en" or "zh-Hans".
+   *
+   * @generated from field: string locale = 3;
+   */
+  locale: string;
+};
+
+/**
+ * Describes the message goserver.store.InstanceGeneralSetting.
+ * Use `create(InstanceGeneralSettingSchema)` to create a new message.
+ */
+export const InstanceGeneralSettingSchema: GenMessage<InstanceGeneralSetting> = /*@__PURE__*/
+  messageDesc(file_store_instance_setting, 12);
+
+/**
+ * @generated from message goserver.store.InstanceSetupSetting
+ */
+export type InstanceSetupSetting = Message<"goserver.store.InstanceSetupSetting"> & {
+  /**
+   * The time the first administrator was created.
+   *
+   * @generated from field: google.protobuf.Timestamp setup_time = 1;
+   */
+  setupTime?: Timestamp;
+};
+
+/**
+ * Describes the message goserver.store.InstanceSetupSetting.
+ * Use `create(InstanceSetupSettingSchema)` to create a new message.
+ */
+export const InstanceSetupSettingSchema: GenMessage<InstanceSetupSetting> = /*@__PURE__*/
+  messageDesc(file_store_instance_setting, 13);
+
+/**
+ * @generated from message goserver.store.InstanceStorageSetting
+ */
+export type InstanceStorageSetting = Message<"goserver.store.InstanceStorageSetting"> & {
+  /**
+   * @generated from field: goserver.store.InstanceStorageSetting.StorageType storage_type = 1;
+   */
+  storageType: InstanceStorageSetting_StorageType;
+
+  /**
+   * The path of files kept in LOCAL storage, relative to the data directory.
+   * It may contain {filename}, {timestamp} and {uuid}, "assets/{timestamp}_{filename}" by default.
+   *
+   * @generated from field: string filepath_template = 2;
+   */
+  filepathTemplate: string;
+
+  /**
+   * The maximum size of an uploaded file in MiB, between 1 and 1024. 0 means the default of 32.
+   *
+   * @generated from field: int32 upload_size_limit_mb = 3;
+   */
+  uploadSizeLimitMb: number;
+
+  /**
+   * @generated from field: goserver.store.StorageS3Config s3_config = 4;
+   */
+  s3Config?: StorageS3Config;
+};
+
+/**
+ * Describes the message goserver.store.InstanceStorageSetting.
+ * Use `create(InstanceStorageSettingSchema)` to create a new message.
+ */
+export const InstanceStorageSettingSchema: GenMessage<InstanceStorageSetting> = /*@__PURE__*/
+  messageDesc(file_store_instance_setting, 14);
+
+/**
+ * @generated from enum goserver.store.InstanceStorageSetting.StorageType
+ */
+export enum InstanceStorageSetting_StorageType {
+  /**
+   * Files are kept in the database, the default.
+   *
+   * @generated from enum value: STORAGE_TYPE_UNSPECIFIED = 0;
+   */
+  STORAGE_TYPE_UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: DATABASE = 1;
+   */
+  DATABASE = 1,
+
+  /**
+   * Files are kept in a directory of the server.
+   *
+   * @generated from enum value: LOCAL = 2;
+   */
+  LOCAL = 2,
+
+  /**
+   * Files are kept in an S3 compatible bucket.
+   *
+   * @generated from enum value: S3 = 3;
+   */
+  S3 = 3,
+}
+
+/**
+ * Describes the enum goserver.store.InstanceStorageSetting.StorageType.
+ */
+export const InstanceStorageSetting_StorageTypeSchema: GenEnum<InstanceStorageSetting_StorageType> = /*@__PURE__*/
+  enumDesc(file_store_instance_setting, 14, 0);
+
+/**
+ * @generated from message goserver.store.StorageS3Config
+ */
+export type StorageS3Config = Message<"goserver.store.StorageS3Config"> & {
+  /**
+   * @generated from field: string access_key_id = 1;
+   */
+  accessKeyId: string;
+
+  /**
+   * @generated from field: string access_key_secret = 2;
+   */
+  accessKeySecret: string;
+
+  /**
+   * @generated from field: string endpoint = 3;
+   */
+  endpoint: string;
+
+  /**
+   * @generated from field: string region = 4;
+   */
+  region: string;
+
+  /**
+   * @generated from field: string bucket = 5;
+   */
+  bucket: string;
+
+  /**
+   * Addresses the bucket in the path instead of the host name.
+   *
+   * @generated from field: bool use_path_style = 6;
+   */
+  usePathStyle: boolean;
+};
+
+/**
+ * Describes the message goserver.store.StorageS3Config.
+ * Use `create(StorageS3ConfigSchema)` to create a new message.
+ */
+export const StorageS3ConfigSchema: GenMessage<StorageS3Config> = /*@__PURE__*/
+  messageDesc(file_store_instance_setting, 15);
+
+/**
+ * InstanceMaintenanceSetting takes the instance out of service, for writes or fully.
+ *
+ * @generated from message goserver.store.InstanceMaintenanceSetting
+ */
+export type InstanceMaintenanceSetting = Message<"goserver.store.InstanceMaintenanceSetting"> & {
+  /**
+   * @generated from field: goserver.store.InstanceMaintenanceSetting.Mode mode = 1;
+   */
+  mode: InstanceMaintenanceSetting_Mode;
+
+  /**
+   * Told to rejected callers instead of the default message.
+   *
+   * @generated from field: string message = 2;
+   */
+  message: string;
+
+  /**
+   * How long rejected callers are told to wait before retrying, 5 minutes when 0.
+   *
+   * @generated from field: int32 retry_after_seconds = 3;
+   */
+  retryAfterSeconds: number;
+};
+
+/**
+ * Describes the message goserver.store.InstanceMaintenanceSetting.
+ * Use `create(InstanceMaintenanceSettingSchema)` to create a new message.
+ */
+export const InstanceMaintenanceSettingSchema: GenMessage<InstanceMaintenanceSetting> = /*@__PURE__*/
+  messageDesc(file_store_instance_setting, 16);
+
+/**
+ * @generated from enum goserver.store.InstanceMaintenanceSetting.Mode
+ */
+export enum InstanceMaintenanceSetting_Mode {
+  /**
+   * The instance is in service.
+   *
+   * @generated from enum value: MODE_UNSPECIFIED = 0;
+   */
+  MODE_UNSPECIFIED = 0,
+
+  /**
+   * Methods that change data are rejected.
+   *
+   * @generated from enum value: READ_ONLY = 1;
+   */
+  READ_ONLY = 1,
+
+  /**
+   * Only health checks and the instance profile are served.
+   *
+   * @generated from enum value: FULL = 2;
+   */
+  FULL = 2,
+}
+
+/**
+ * Describes the enum goserver.store.InstanceMaintenanceSetting.Mode.
+ */
+export const InstanceMaintenanceSetting_ModeSchema: GenEnum<InstanceMaintenanceSetting_Mode> = /*@__PURE__*/
+  enumDesc(file_store_instance_setting, 16, 0);
+
 /**
  * @generated from enum goserver.store.InstanceSettingKey
  */
@@ -81,6 +845,64 @@ export enum InstanceSettingKey {
    * @generated from enum value: BASIC = 1;
    */
   BASIC = 1,
+
+  /**
+   * JWT_SIGNING_KEYS is the key for the access token signing keys.
+   *
+   * @generated from enum value: JWT_SIGNING_KEYS = 2;
+   */
+  JWT_SIGNING_KEYS = 2,
+
+  /**
+   * SECURITY is the key for security policies.
+   *
+   * @generated from enum value: SECURITY = 3;
+   */
+  SECURITY = 3,
+
+  /**
+   * PASSWORD_POLICY is the key for the rules passwords must satisfy.
+   *
+   * @generated from enum value: PASSWORD_POLICY = 4;
+   */
+  PASSWORD_POLICY = 4,
+
+  /**
+   * IDENTITY_PROVIDERS is the key for the external OpenID Connect providers users can sign in with.
+   *
+   * @generated from enum value: IDENTITY_PROVIDERS = 5;
+   */
+  IDENTITY_PROVIDERS = 5,
+
+  /**
+   * GENERAL is the key for the name and presentation of the instance.
+   *
+   * @generated from enum value: GENERAL = 6;
+   */
+  GENERAL = 6,
+
+  /**
+   * SETUP is the key for the record of the first-run setup. It is written once by the
+   * setup and never changed, its presence locks the setup.
+   *
+   * @generated from enum value: SETUP = 7;
+   */
+  SETUP = 7,
+
+  /**
+   * STORAGE is the key for where uploaded files are kept.
+   *
+   * @generated from enum value: STORAGE = 8;
+   */
+  STORAGE = 8,
+
+  /**
+   * MAINTENANCE is the key for taking the instance out of service, set with the
+   * maintenance command.
+   *
+   * @generated from enum value: MAINTENANCE = 9;
+   */
+  MAINTENANCE = 9,
 }
 
 /**